@@ -14,8 +14,8 @@
 # limitations under the License.
 
 #
-# Build script that will get the module dependencies and build Linux and
-# Windows binaries. The google_cloud_workload_agent binary will be built into
+# Build script that will get the module dependencies and build Linux (amd64 and
+# arm64) and Windows binaries. The google_cloud_workload_agent binary will be built into
 # the buildoutput/ dir.
 #
 
@@ -121,6 +121,9 @@ pushd cmd
 echo "**************  Building Linux binary"
 env GOOS=linux GOARCH=amd64 go build -mod=vendor -v -o ../buildoutput/google_cloud_workload_agent
 
+echo "**************  Building Linux arm64 binary"
+env GOOS=linux GOARCH=arm64 go build -mod=vendor -v -o ../buildoutput/google_cloud_workload_agent_arm64
+
 echo "**************  Building Windows binary"
 env GOOS=windows GOARCH=amd64 go build -mod=vendor -v -o ../buildoutput/google_cloud_workload_agent.exe
 popd
@@ -21,6 +21,7 @@ $SVC_NAME = 'google-cloud-workload-agent'
 $BIN_NAME_EXE = 'google-cloud-workload-agent.exe'
 $MONITOR_TASK = 'google-cloud-workload-agent-monitor'
 $MIGRATION_TASK = 'google-cloud-workload-agent-migration'
+$EVENT_SOURCE = 'google-cloud-workload-agent'
 $LOGS_DIR = "$DATA_DIR\logs"
 $CONF_DIR = "$INSTALL_DIR\conf"
 $LOG_FILE ="$LOGS_DIR\google-cloud-workload-agent-install.log"
@@ -92,6 +93,18 @@ function ConfigureAgentWindows-Service {
   Start-Service $SVC_NAME
 }
 
+function Register-EventSource {
+  #.DESCRIPTION
+  #  Registers the event source the agent writes its Windows Event Log events with.
+  #  EventCreate.exe holds the messages of the event IDs written by the agent.
+  if ([System.Diagnostics.EventLog]::SourceExists($EVENT_SOURCE)) {
+    Log-Write "Event source exists: $EVENT_SOURCE"
+    return
+  }
+  New-EventLog -LogName Application -Source $EVENT_SOURCE -MessageResourceFile "$env:SystemRoot\System32\EventCreate.exe"
+  Log-Write "Registered event source: $EVENT_SOURCE"
+}
+
 function AddMonitor-Task {
   if ($(Get-ScheduledTask $MONITOR_TASK -ErrorAction Ignore).TaskName) {
      Log-Write "Scheduled task exists: $MONITOR_TASK"
@@ -169,6 +182,10 @@ try {
   MoveFiles-IntoPlace
   Log-Write 'File moves complete'
 
+  Log-Write 'Registering event source...'
+  Register-EventSource
+  Log-Write 'Event source registered'
+
   Log-Write 'Configuring Windows service...'
   ConfigureAgentWindows-Service
   Log-Write 'Windows service configured'
@@ -16,6 +16,7 @@ $BIN_NAME_EXE = 'google-cloud-workload-agent.exe'
 $SVC_NAME = 'google-cloud-workload-agent'
 $MONITOR_TASK = 'google-cloud-workload-agent-monitor'
 $MIGRATION_TASK = 'google-cloud-workload-agent-migration'
+$EVENT_SOURCE = 'google-cloud-workload-agent'
 
 function Log-Uninstall {
   #.DESCRIPTION
@@ -43,6 +44,11 @@ try {
     & sc.exe delete $SVC_NAME
   }
 
+  # remove the event source
+  if ([System.Diagnostics.EventLog]::SourceExists($EVENT_SOURCE)) {
+    Remove-EventLog -Source $EVENT_SOURCE
+  }
+
   # remove the agent directory
   if (Test-Path $INSTALL_DIR) {
     Remove-Item -Recurse -Force $INSTALL_DIR
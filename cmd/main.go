@@ -20,20 +20,25 @@ package main
 import (
 	"context"
 	"os"
-	"runtime"
 
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"go.uber.org/zap/zapcore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/logusage"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/migrate"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/status"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/importconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/supportbundle"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/version"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/outbound"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 
@@ -43,11 +48,21 @@ import (
 func main() {
 	ctx := context.Background()
 	lp := log.Parameters{
-		OSType:     runtime.GOOS,
+		OSType:     capabilities.Host().OS,
 		Level:      zapcore.InfoLevel,
 		LogToCloud: true,
 	}
 
+	// The network settings must be applied before the first outbound connection. The daemon
+	// applies them again from its fully merged configuration.
+	outbound.Install()
+	config, err := configuration.ConfigFromFile(configuration.ConfigPath(), os.ReadFile)
+	if err == nil {
+		if err := outbound.Apply(config.GetNetwork(), os.Setenv, os.ReadFile, outbound.WriteFile); err != nil {
+			log.Logger.Warnw("Unable to apply the network configuration, outbound connections use the system settings.", "error", err)
+		}
+	}
+
 	cloudProps := &cpb.CloudProperties{}
 	if cp := metadataserver.FetchCloudProperties(); cp != nil {
 		cloudProps = &cpb.CloudProperties{
@@ -63,18 +78,20 @@ func main() {
 			ServiceAccountEmail: cp.ServiceAccountEmail,
 		}
 	}
-	lp.CloudLoggingClient = log.CloudLoggingClient(ctx, cloudProps.GetProjectId())
+	lp.CloudLoggingClient = endpoints.CloudLoggingClient(ctx, cloudProps.GetProjectId(), config.GetEndpoints())
 
 	rootCmd := &cobra.Command{
 		Use:   "google_cloud_workload_agent",
 		Short: "Google Cloud Agent for Compute Workloads",
 		Long:  "Google Cloud Agent for Compute Workloads",
 	}
-	rootCmd.AddCommand(version.NewCommand())
+	rootCmd.AddCommand(version.NewCommand(cloudProps))
 	rootCmd.AddCommand(logusage.NewCommand(lp, cloudProps))
 	rootCmd.AddCommand(migrate.NewCommand())
 	rootCmd.AddCommand(configure.NewCommand(lp))
 	rootCmd.AddCommand(status.NewCommand(cloudProps))
+	rootCmd.AddCommand(supportbundle.NewCommand(lp, cloudProps))
+	rootCmd.AddCommand(importconfig.NewCommand(lp))
 	d := daemon.NewDaemon(lp, cloudProps)
 	daemonCmd := daemon.NewDaemonSubCommand(d)
 
@@ -98,6 +115,7 @@ func main() {
 	rc := 0
 	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		log.Logger.Error(err)
+		onetime.PrintError(os.Stdout, err)
 		rc = 1
 	}
 
@@ -38,16 +38,26 @@ require (
   go.uber.org/zap v1.27.0
   golang.org/x/crypto v0.36.0
   golang.org/x/exp v0.0.0-20230321023759-10a507213a29
+  golang.org/x/oauth2 v0.27.0
+  golang.org/x/sys v0.31.0
   google.golang.org/api v0.220.0
   google.golang.org/genproto v0.0.0-20250204164813-702378808489
   google.golang.org/genproto/googleapis/api v0.0.0-20250204164813-702378808489
   google.golang.org/protobuf v1.36.5
+  gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
   cloud.google.com/go/artifactregistry v1.16.1
+  cloud.google.com/go/logging v1.13.0
+  cloud.google.com/go/secretmanager v1.14.4
   github.com/GoogleCloudPlatform/agentcommunication_client v0.0.0-20250227185639-b70667e4a927
+  github.com/golang/protobuf v1.5.4
   github.com/googleapis/gax-go v1.0.3
+  github.com/googleapis/gax-go/v2 v2.14.1
+  golang.org/x/net v0.38.0
+  google.golang.org/grpc v1.70.0
+  k8s.io/api v0.34.1
   k8s.io/apimachinery v0.34.1
   k8s.io/client-go v0.34.1
 )
@@ -58,9 +68,7 @@ require (
   cloud.google.com/go/auth/oauth2adapt v0.2.7 // indirect
   cloud.google.com/go/compute/metadata v0.6.0 // indirect
   cloud.google.com/go/iam v1.3.1 // indirect
-  cloud.google.com/go/logging v1.13.0 // indirect
   cloud.google.com/go/longrunning v0.6.4 // indirect
-  cloud.google.com/go/secretmanager v1.14.4 // indirect
   filippo.io/edwards25519 v1.1.0 // indirect
   github.com/BurntSushi/toml v0.3.1 // indirect
   github.com/bmizerany/assert v0.0.0-20160611221934-b7ed37b82869 // indirect
@@ -81,13 +89,11 @@ require (
   github.com/gogo/protobuf v1.3.2 // indirect
   github.com/golang-sql/civil v0.0.0-20220223132316-b832511892a9 // indirect
   github.com/golang-sql/sqlexp v0.1.0 // indirect
-  github.com/golang/protobuf v1.5.4 // indirect
   github.com/golang/snappy v0.0.4 // indirect
   github.com/google/gnostic-models v0.7.0 // indirect
   github.com/google/s2a-go v0.1.9 // indirect
   github.com/google/uuid v1.6.0 // indirect
   github.com/googleapis/enterprise-certificate-proxy v0.3.4 // indirect
-  github.com/googleapis/gax-go/v2 v2.14.1 // indirect
   github.com/inconshreveable/mousetrap v1.1.0 // indirect
   github.com/josharian/intern v1.0.0 // indirect
   github.com/json-iterator/go v1.1.12 // indirect
@@ -122,21 +128,15 @@ require (
   go.yaml.in/yaml/v3 v3.0.4 // indirect
   golang.org/x/lint v0.0.0-20181026193005-c67002cb31c3 // indirect
   golang.org/x/mod v0.21.0 // indirect
-  golang.org/x/net v0.38.0 // indirect
-  golang.org/x/oauth2 v0.27.0 // indirect
   golang.org/x/sync v0.12.0 // indirect
-  golang.org/x/sys v0.31.0 // indirect
   golang.org/x/term v0.30.0 // indirect
   golang.org/x/text v0.23.0 // indirect
   golang.org/x/time v0.9.0 // indirect
   golang.org/x/tools v0.26.0 // indirect
   google.golang.org/genproto/googleapis/rpc v0.0.0-20250127172529-29210b9bc287 // indirect
-  google.golang.org/grpc v1.70.0 // indirect
   gopkg.in/evanphx/json-patch.v4 v4.12.0 // indirect
   gopkg.in/inf.v0 v0.9.1 // indirect
-  gopkg.in/yaml.v3 v3.0.1 // indirect
   honnef.co/go/tools v0.0.0-20190102054323-c2f93a96b099 // indirect
-  k8s.io/api v0.34.1 // indirect
   k8s.io/klog/v2 v2.130.1 // indirect
   k8s.io/kube-openapi v0.0.0-20250710124328-f3f2b991d03b // indirect
   k8s.io/utils v0.0.0-20250604170112-4c0f3b243397 // indirect
@@ -0,0 +1,122 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package agenterrors classifies agent failures by cause so they can be reported with distinct usage metrics.
+package agenterrors
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
+)
+
+// Kind is the cause of a failure.
+type Kind string
+
+const (
+	// Unknown means the failure could not be classified.
+	Unknown Kind = "UNKNOWN"
+	// Authentication means credentials were missing or rejected.
+	Authentication Kind = "AUTHENTICATION"
+	// Connection means the database or API could not be reached.
+	Connection Kind = "CONNECTION"
+	// Query means a query or command against the database failed.
+	Query Kind = "QUERY"
+	// Quota means an API rejected the request because a quota was exceeded.
+	Quota Kind = "QUOTA"
+	// Parse means a value returned by the database or host could not be parsed.
+	Parse Kind = "PARSE"
+)
+
+// usageCodes maps each kind to the usage metrics error reported for it.
+var usageCodes = map[Kind]int{
+	Authentication: usagemetrics.AuthenticationFailure,
+	Connection:     usagemetrics.DatabaseConnectionFailure,
+	Query:          usagemetrics.QueryFailure,
+	Quota:          usagemetrics.APIQuotaExceeded,
+	Parse:          usagemetrics.ParseFailure,
+}
+
+// Error is an error classified by its cause.
+// Its message is the message of the wrapped error.
+type Error struct {
+	Kind Kind
+	Err  error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// New returns err classified as kind, or nil if err is nil.
+func New(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// KindOf returns the cause of err.
+// Errors not classified with New are classified from well known gRPC, HTTP and network errors.
+func KindOf(err error) Kind {
+	if err == nil {
+		return Unknown
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.ResourceExhausted:
+			return Quota
+		case codes.Unauthenticated, codes.PermissionDenied:
+			return Authentication
+		case codes.Unavailable:
+			return Connection
+		}
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case http.StatusTooManyRequests:
+			return Quota
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return Authentication
+		}
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, syscall.ECONNREFUSED) {
+		return Connection
+	}
+	return Unknown
+}
+
+// UsageCode returns the usage metrics error for the cause of err.
+// The fallback is returned for errors which could not be classified.
+func UsageCode(err error, fallback int) int {
+	if code, ok := usageCodes[KindOf(err)]; ok {
+		return code
+	}
+	return fallback
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agenterrors
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{
+			name: "Nil",
+			want: Unknown,
+		},
+		{
+			name: "Unclassified",
+			err:  errors.New("something went wrong"),
+			want: Unknown,
+		},
+		{
+			name: "Classified",
+			err:  New(Parse, errors.New("invalid syntax")),
+			want: Parse,
+		},
+		{
+			name: "WrappedClassified",
+			err:  fmt.Errorf("getting work_mem: %w", New(Query, errors.New("relation does not exist"))),
+			want: Query,
+		},
+		{
+			name: "GRPCResourceExhausted",
+			err:  status.Error(codes.ResourceExhausted, "quota exceeded"),
+			want: Quota,
+		},
+		{
+			name: "GRPCPermissionDenied",
+			err:  status.Error(codes.PermissionDenied, "permission denied"),
+			want: Authentication,
+		},
+		{
+			name: "GRPCUnavailable",
+			err:  status.Error(codes.Unavailable, "unavailable"),
+			want: Connection,
+		},
+		{
+			name: "HTTPTooManyRequests",
+			err:  fmt.Errorf("writing insight: %w", &googleapi.Error{Code: http.StatusTooManyRequests}),
+			want: Quota,
+		},
+		{
+			name: "HTTPUnauthorized",
+			err:  &googleapi.Error{Code: http.StatusUnauthorized},
+			want: Authentication,
+		},
+		{
+			name: "HTTPInternalServerError",
+			err:  &googleapi.Error{Code: http.StatusInternalServerError},
+			want: Unknown,
+		},
+		{
+			name: "DNSError",
+			err:  fmt.Errorf("dial: %w", &net.DNSError{Err: "no such host", Name: "db.internal"}),
+			want: Connection,
+		},
+		{
+			name: "ConnectionRefused",
+			err:  fmt.Errorf("dial tcp: %w", syscall.ECONNREFUSED),
+			want: Connection,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := KindOf(tc.err); got != tc.want {
+				t.Errorf("KindOf(%v) = %q, want %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	if err := New(Query, nil); err != nil {
+		t.Errorf("New(Query, nil) = %v, want nil", err)
+	}
+
+	inner := errors.New("access denied")
+	err := New(Authentication, inner)
+	if err.Error() != inner.Error() {
+		t.Errorf("New(Authentication, %v).Error() = %q, want %q", inner, err.Error(), inner.Error())
+	}
+	if !errors.Is(err, inner) {
+		t.Errorf("errors.Is(New(Authentication, %v), %v) = false, want true", inner, inner)
+	}
+}
+
+func TestUsageCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "Authentication",
+			err:  New(Authentication, errors.New("access denied")),
+			want: usagemetrics.AuthenticationFailure,
+		},
+		{
+			name: "Connection",
+			err:  New(Connection, errors.New("connection refused")),
+			want: usagemetrics.DatabaseConnectionFailure,
+		},
+		{
+			name: "Query",
+			err:  New(Query, errors.New("syntax error")),
+			want: usagemetrics.QueryFailure,
+		},
+		{
+			name: "Quota",
+			err:  status.Error(codes.ResourceExhausted, "quota exceeded"),
+			want: usagemetrics.APIQuotaExceeded,
+		},
+		{
+			name: "Parse",
+			err:  New(Parse, errors.New("invalid syntax")),
+			want: usagemetrics.ParseFailure,
+		},
+		{
+			name: "UnknownUsesFallback",
+			err:  errors.New("something went wrong"),
+			want: usagemetrics.MySQLMetricCollectionFailure,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := UsageCode(tc.err, usagemetrics.MySQLMetricCollectionFailure); got != tc.want {
+				t.Errorf("UsageCode(%v) = %d, want %d", tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the outbound API calls of the agent to a local audit log.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// LinuxLogPath is the default audit log path on Linux.
+const LinuxLogPath = "/var/log/google-cloud-workload-agent-audit.log"
+
+// StatusOK is the status recorded for a successful call.
+const StatusOK = "OK"
+
+// Record describes a single outbound API call.
+type Record struct {
+	Time         time.Time `json:"time"`
+	API          string    `json:"api"`
+	Method       string    `json:"method"`
+	Endpoint     string    `json:"endpoint"`
+	PayloadBytes int       `json:"payloadBytes"`
+	Status       string    `json:"status"`
+	LatencyMs    int64     `json:"latencyMs"`
+}
+
+// Log appends records to the audit log file as JSON lines.
+// A nil Log records nothing, so callers do not need to check whether auditing is enabled.
+type Log struct {
+	path string
+	mu   sync.Mutex
+}
+
+var (
+	logsMu sync.Mutex
+	// logs holds a single Log per path so that concurrent writers share its lock.
+	logs = make(map[string]*Log)
+)
+
+// FromConfig returns the audit log of the configuration, or nil if auditing is disabled.
+func FromConfig(config *cpb.Configuration) *Log {
+	if !config.GetAuditLog().GetEnabled() {
+		return nil
+	}
+	path := config.GetAuditLog().GetFilePath()
+	if path == "" {
+		path = defaultPath()
+	}
+	logsMu.Lock()
+	defer logsMu.Unlock()
+	if l, ok := logs[path]; ok {
+		return l
+	}
+	l := &Log{path: path}
+	logs[path] = l
+	return l
+}
+
+func defaultPath() string {
+	if capabilities.Host().IsWindows() {
+		return fmt.Sprintf(`%s\Google\google-cloud-workload-agent\logs\google-cloud-workload-agent-audit.log`, log.CreateWindowsLogBasePath())
+	}
+	return LinuxLogPath
+}
+
+// Path returns the path of the audit log file, or an empty string for a nil Log.
+func (l *Log) Path() string {
+	if l == nil {
+		return ""
+	}
+	return l.path
+}
+
+// Record appends r to the audit log.
+// Failures are logged and do not affect the audited call.
+func (l *Log) Record(ctx context.Context, r Record) {
+	if l == nil {
+		return
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Could not marshal audit record", "error", err)
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Could not open audit log", "path", l.path, "error", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		log.CtxLogger(ctx).Warnw("Could not write audit log", "path", l.path, "error", err)
+	}
+}
+
+// RecordCall appends a record for a call which started at start and returned err.
+func (l *Log) RecordCall(ctx context.Context, api, method, endpoint string, payloadBytes int, start time.Time, err error) {
+	if l == nil {
+		return
+	}
+	l.Record(ctx, Record{
+		Time:         start,
+		API:          api,
+		Method:       method,
+		Endpoint:     endpoint,
+		PayloadBytes: payloadBytes,
+		Status:       Status(err),
+		LatencyMs:    time.Since(start).Milliseconds(),
+	})
+}
+
+// Status returns the status recorded for a call which returned err.
+// gRPC errors are recorded with their status code.
+func Status(err error) string {
+	if err == nil {
+		return StatusOK
+	}
+	if s, ok := status.FromError(err); ok {
+		return s.Code().String()
+	}
+	return "ERROR"
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	if got := FromConfig(&cpb.Configuration{}); got != nil {
+		t.Errorf("FromConfig() with auditing disabled = %v, want nil", got)
+	}
+	config := &cpb.Configuration{AuditLog: &cpb.AuditLog{Enabled: proto.Bool(true), FilePath: path}}
+	first := FromConfig(config)
+	if first == nil || first.path != path {
+		t.Fatalf("FromConfig() = %v, want a log writing to %s", first, path)
+	}
+	if second := FromConfig(config); second != first {
+		t.Errorf("FromConfig() returned a different log for the same path")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	l := &Log{path: path}
+	start := time.Now()
+	l.RecordCall(context.Background(), "monitoring", "CreateTimeSeries", "monitoring.googleapis.com:443", 42, start, nil)
+	l.RecordCall(context.Background(), "monitoring", "CreateTimeSeries", "monitoring.googleapis.com:443", 7, start, status.Error(codes.PermissionDenied, "denied"))
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+	}
+	var got []Record
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		var r Record
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("json.Unmarshal(%q) returned unexpected error: %v", line, err)
+		}
+		got = append(got, r)
+	}
+	want := []Record{
+		{API: "monitoring", Method: "CreateTimeSeries", Endpoint: "monitoring.googleapis.com:443", PayloadBytes: 42, Status: StatusOK},
+		{API: "monitoring", Method: "CreateTimeSeries", Endpoint: "monitoring.googleapis.com:443", PayloadBytes: 7, Status: "PermissionDenied"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(Record{}, "Time", "LatencyMs")); diff != "" {
+		t.Errorf("audit log contains unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func TestRecordNilLog(t *testing.T) {
+	var l *Log
+	// A nil log must not panic.
+	l.RecordCall(context.Background(), "api", "method", "endpoint", 1, time.Now(), nil)
+	if got := l.Path(); got != "" {
+		t.Errorf("Path() of a nil log = %q, want empty", got)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "NoError", want: StatusOK},
+		{name: "GRPCError", err: status.Error(codes.Unavailable, "unavailable"), want: "Unavailable"},
+		{name: "OtherError", err: errors.New("failed"), want: "ERROR"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Status(tc.err); got != tc.want {
+				t.Errorf("Status(%v) = %q, want %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/googleapis/gax-go/v2"
+	"google.golang.org/protobuf/proto"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+)
+
+// defaultMonitoringEndpoint is recorded in the audit log when no endpoint is configured.
+const defaultMonitoringEndpoint = "monitoring.googleapis.com:443"
+
+// TimeSeriesCreator records every CreateTimeSeries call in the audit log.
+type TimeSeriesCreator struct {
+	cloudmonitoring.TimeSeriesCreator
+	log      *Log
+	endpoint string
+}
+
+// NewTimeSeriesCreator returns a TimeSeriesCreator which records the calls of creator in l.
+func NewTimeSeriesCreator(creator cloudmonitoring.TimeSeriesCreator, l *Log, e *cpb.Endpoints) *TimeSeriesCreator {
+	endpoint := e.GetCloudMonitoring()
+	if endpoint == "" {
+		endpoint = defaultMonitoringEndpoint
+	}
+	return &TimeSeriesCreator{TimeSeriesCreator: creator, log: l, endpoint: endpoint}
+}
+
+// CreateTimeSeries creates the time series and records the call in the audit log.
+func (a *TimeSeriesCreator) CreateTimeSeries(ctx context.Context, req *mrpb.CreateTimeSeriesRequest, opts ...gax.CallOption) error {
+	start := time.Now()
+	err := a.TimeSeriesCreator.CreateTimeSeries(ctx, req, opts...)
+	a.log.RecordCall(ctx, "monitoring", "CreateTimeSeries", a.endpoint, proto.Size(req), start, err)
+	return err
+}
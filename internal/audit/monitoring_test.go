@@ -0,0 +1,82 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	cmfake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring/fake"
+)
+
+func TestTimeSeriesCreator(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints *cpb.Endpoints
+		err       error
+		want      Record
+	}{
+		{
+			name: "DefaultEndpoint",
+			want: Record{API: "monitoring", Method: "CreateTimeSeries", Endpoint: "monitoring.googleapis.com:443", Status: StatusOK},
+		},
+		{
+			name:      "ConfiguredEndpoint",
+			endpoints: &cpb.Endpoints{CloudMonitoring: "monitoring-psc.p.googleapis.com:443"},
+			err:       status.Error(codes.PermissionDenied, "denied"),
+			want:      Record{API: "monitoring", Method: "CreateTimeSeries", Endpoint: "monitoring-psc.p.googleapis.com:443", Status: "PermissionDenied"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.log")
+			fake := &cmfake.TimeSeriesCreator{Err: tc.err}
+			creator := NewTimeSeriesCreator(fake, &Log{path: path}, tc.endpoints)
+
+			err := creator.CreateTimeSeries(context.Background(), &mrpb.CreateTimeSeriesRequest{Name: "projects/test-project"})
+			if !cmp.Equal(err, tc.err, cmpopts.EquateErrors()) {
+				t.Errorf("CreateTimeSeries() returned error %v, want %v", err, tc.err)
+			}
+			if len(fake.Calls) != 1 {
+				t.Errorf("CreateTimeSeries() made %d calls to the wrapped creator, want 1", len(fake.Calls))
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+			}
+			var got Record
+			if err := json.Unmarshal([]byte(strings.TrimSpace(string(content))), &got); err != nil {
+				t.Fatalf("json.Unmarshal(%q) returned unexpected error: %v", content, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Record{}, "Time", "LatencyMs", "PayloadBytes")); diff != "" {
+				t.Errorf("audit log contains unexpected record (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
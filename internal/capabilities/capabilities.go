@@ -0,0 +1,145 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package capabilities detects the operating system, architecture and system tools of the host.
+// Collectors consult the capabilities to choose between their implementations instead of checking
+// the operating system themselves.
+package capabilities
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// Tool is a facility of the host which some collectors depend on.
+type Tool string
+
+const (
+	// Procfs is the /proc filesystem, which holds the settings of the kernel and of processes.
+	Procfs Tool = "procfs"
+	// Systemd is the service manager of the host, controlled with systemctl.
+	Systemd Tool = "systemd"
+	// PowerShell runs the scripts of the Windows collectors.
+	PowerShell Tool = "powershell"
+	// WMIC is the WMI command line client. It is deprecated and missing from recent Windows Server releases.
+	WMIC Tool = "wmic"
+)
+
+// Operating systems and architectures.
+const (
+	Linux   = "linux"
+	Windows = "windows"
+	AMD64   = "amd64"
+	ARM64   = "arm64"
+)
+
+// SupportedArchs are the architectures the agent is built and supported on.
+var SupportedArchs = []string{AMD64, ARM64}
+
+// LookPath returns the path of an executable, see exec.LookPath.
+type LookPath func(file string) (string, error)
+
+// Stat returns the file info of a path, see os.Stat.
+type Stat func(name string) (os.FileInfo, error)
+
+// Capabilities of a host.
+type Capabilities struct {
+	OS    string
+	Arch  string
+	tools map[Tool]bool
+}
+
+var (
+	host     *Capabilities
+	hostOnce sync.Once
+)
+
+// Probe returns the capabilities of a host running goos on goarch, whose tools are found with
+// lookPath and stat.
+func Probe(goos, goarch string, lookPath LookPath, stat Stat) *Capabilities {
+	c := &Capabilities{OS: goos, Arch: goarch, tools: make(map[Tool]bool)}
+	exists := func(path string) bool {
+		_, err := stat(path)
+		return err == nil
+	}
+	found := func(file string) bool {
+		_, err := lookPath(file)
+		return err == nil
+	}
+	switch goos {
+	case Linux:
+		c.tools[Procfs] = exists("/proc/self")
+		// The directory only exists if systemd is the init system, see sd_booted(3).
+		c.tools[Systemd] = exists("/run/systemd/system") && found("systemctl")
+	case Windows:
+		c.tools[PowerShell] = found("powershell.exe")
+		c.tools[WMIC] = found("wmic.exe")
+	}
+	return c
+}
+
+// Host returns the capabilities of the host the agent runs on, which are probed on first use.
+func Host() *Capabilities {
+	hostOnce.Do(func() {
+		host = Probe(runtime.GOOS, runtime.GOARCH, exec.LookPath, os.Stat)
+	})
+	return host
+}
+
+// IsLinux returns true if the host runs Linux.
+func (c *Capabilities) IsLinux() bool {
+	return c.OS == Linux
+}
+
+// IsWindows returns true if the host runs Windows.
+func (c *Capabilities) IsWindows() bool {
+	return c.OS == Windows
+}
+
+// Has returns true if the tool is available on the host.
+func (c *Capabilities) Has(t Tool) bool {
+	return c.tools[t]
+}
+
+// Tools returns the names of the tools available on the host, sorted.
+func (c *Capabilities) Tools() []string {
+	var tools []string
+	for t, ok := range c.tools {
+		if ok {
+			tools = append(tools, string(t))
+		}
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+// SupportedArch returns true if the agent is supported on the architecture of the host.
+func (c *Capabilities) SupportedArch() bool {
+	return slices.Contains(SupportedArchs, c.Arch)
+}
+
+// ByOS returns windows on a Windows host and linux on any other host.
+// It selects the OS specific default of a setting such as a file path.
+func ByOS[T any](linux, windows T) T {
+	if Host().IsWindows() {
+		return windows
+	}
+	return linux
+}
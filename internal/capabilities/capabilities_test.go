@@ -0,0 +1,131 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package capabilities
+
+import (
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		name          string
+		goos          string
+		goarch        string
+		executables   []string
+		paths         []string
+		wantTools     []string
+		wantLinux     bool
+		wantWindows   bool
+		wantSupported bool
+	}{
+		{
+			name:          "LinuxWithSystemd",
+			goos:          Linux,
+			goarch:        AMD64,
+			executables:   []string{"systemctl"},
+			paths:         []string{"/proc/self", "/run/systemd/system"},
+			wantTools:     []string{"procfs", "systemd"},
+			wantLinux:     true,
+			wantSupported: true,
+		},
+		{
+			name:          "LinuxARM64WithoutSystemd",
+			goos:          Linux,
+			goarch:        ARM64,
+			executables:   []string{"systemctl"},
+			paths:         []string{"/proc/self"},
+			wantTools:     []string{"procfs"},
+			wantLinux:     true,
+			wantSupported: true,
+		},
+		{
+			name:          "WindowsWithoutWMIC",
+			goos:          Windows,
+			goarch:        AMD64,
+			executables:   []string{"powershell.exe"},
+			wantTools:     []string{"powershell"},
+			wantWindows:   true,
+			wantSupported: true,
+		},
+		{
+			name:      "UnsupportedArch",
+			goos:      Linux,
+			goarch:    "386",
+			wantLinux: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lookPath := func(file string) (string, error) {
+				for _, e := range tc.executables {
+					if e == file {
+						return "/usr/bin/" + file, nil
+					}
+				}
+				return "", errors.New("executable file not found")
+			}
+			stat := func(name string) (os.FileInfo, error) {
+				for _, p := range tc.paths {
+					if p == name {
+						return nil, nil
+					}
+				}
+				return nil, os.ErrNotExist
+			}
+
+			c := Probe(tc.goos, tc.goarch, lookPath, stat)
+			if diff := cmp.Diff(tc.wantTools, c.Tools()); diff != "" {
+				t.Errorf("Probe(%q, %q).Tools() returned unexpected diff (-want +got):\n%s", tc.goos, tc.goarch, diff)
+			}
+			if got := c.IsLinux(); got != tc.wantLinux {
+				t.Errorf("Probe(%q, %q).IsLinux() = %v, want %v", tc.goos, tc.goarch, got, tc.wantLinux)
+			}
+			if got := c.IsWindows(); got != tc.wantWindows {
+				t.Errorf("Probe(%q, %q).IsWindows() = %v, want %v", tc.goos, tc.goarch, got, tc.wantWindows)
+			}
+			if got := c.SupportedArch(); got != tc.wantSupported {
+				t.Errorf("Probe(%q, %q).SupportedArch() = %v, want %v", tc.goos, tc.goarch, got, tc.wantSupported)
+			}
+		})
+	}
+}
+
+func TestHost(t *testing.T) {
+	c := Host()
+	if c.OS != runtime.GOOS || c.Arch != runtime.GOARCH {
+		t.Errorf("Host() = %s/%s, want %s/%s", c.OS, c.Arch, runtime.GOOS, runtime.GOARCH)
+	}
+	if Host() != c {
+		t.Error("Host() returned a different Capabilities on the second call")
+	}
+}
+
+func TestByOS(t *testing.T) {
+	want := "linux"
+	if runtime.GOOS == Windows {
+		want = "windows"
+	}
+	if got := ByOS("linux", "windows"); got != want {
+		t.Errorf("ByOS() = %q, want %q", got, want)
+	}
+}
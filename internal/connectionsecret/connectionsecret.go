@@ -0,0 +1,180 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package connectionsecret reads database connection parameters from secrets with JSON
+// payloads, stored in Secret Manager or HashiCorp Vault, so that credentials and endpoints
+// can be rotated together. It also reads passwords from the local credential store.
+package connectionsecret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentialstore"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// SecretGetter reads secrets from Secret Manager.
+type SecretGetter interface {
+	GetSecret(ctx context.Context, projectID, secretName string) (string, error)
+}
+
+// Provider reads a connection secret from a secret store.
+type Provider interface {
+	// Read returns the JSON object stored in the secret.
+	Read(ctx context.Context) ([]byte, error)
+	// String describes the secret in error messages.
+	String() string
+}
+
+// secretManagerProvider reads a JSON connection secret from Secret Manager.
+type secretManagerProvider struct {
+	secrets SecretGetter
+	ref     *configpb.SecretRef
+}
+
+func (s secretManagerProvider) Read(ctx context.Context) ([]byte, error) {
+	content, err := s.secrets.GetSecret(ctx, s.ref.GetProjectId(), s.ref.GetSecretName())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret: %w", err)
+	}
+	return []byte(content), nil
+}
+
+func (s secretManagerProvider) String() string {
+	return fmt.Sprintf("secret %s in project %s", s.ref.GetSecretName(), s.ref.GetProjectId())
+}
+
+// storedCredentialProvider reads a password from the local credential store.
+type storedCredentialProvider struct {
+	ref *configpb.StoredCredentialRef
+}
+
+func (s storedCredentialProvider) Read(ctx context.Context) ([]byte, error) {
+	password, err := credentialstore.New(s.ref.GetStoreFile(), s.ref.GetKeyFile()).Get(s.ref.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(payload{Password: password.SecretValue()})
+}
+
+func (s storedCredentialProvider) String() string {
+	return fmt.Sprintf("stored credential %s", s.ref.GetName())
+}
+
+// port accepts the port as a JSON number or string.
+type port int32
+
+func (p *port) UnmarshalJSON(b []byte) error {
+	s := string(bytes.Trim(b, `"`))
+	if s == "" {
+		return nil
+	}
+	v, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid port %s: %w", b, err)
+	}
+	*p = port(v)
+	return nil
+}
+
+// payload is the content of a JSON connection secret.
+type payload struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Host     string `json:"host"`
+	Port     port   `json:"port"`
+}
+
+// Resolve returns the connection parameters to connect to the database with.
+// If cp references a Vault secret, a JSON Secret Manager secret or a stored credential, the returned copy of cp holds
+// the username, password, host and port found in the secret instead of the secret reference;
+// fields missing from the secret keep their configured values. Otherwise cp is returned unchanged.
+func Resolve(ctx context.Context, cp *configpb.ConnectionParameters, secrets SecretGetter) (*configpb.ConnectionParameters, error) {
+	provider, err := providerFor(cp, secrets)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return cp, nil
+	}
+	content, err := provider.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var p payload
+	if err := json.Unmarshal(content, &p); err != nil {
+		// The error may quote the payload, which contains the password.
+		return nil, fmt.Errorf("%s is not a valid JSON connection secret", provider)
+	}
+
+	resolved := proto.Clone(cp).(*configpb.ConnectionParameters)
+	resolved.Secret = nil
+	resolved.VaultSecret = nil
+	resolved.StoredCredential = nil
+	resolved.Password = p.Password
+	if p.Username != "" {
+		resolved.Username = p.Username
+	}
+	if p.Host != "" {
+		resolved.Host = p.Host
+	}
+	if p.Port != 0 {
+		resolved.Port = int32(p.Port)
+	}
+	return resolved, nil
+}
+
+// Password returns the password to connect with the resolved connection parameters cp:
+// the configured password if set, otherwise the content of the Secret Manager secret cp references.
+// The password is empty if neither is set.
+func Password(ctx context.Context, cp *configpb.ConnectionParameters, secrets SecretGetter) (secret.String, error) {
+	if cp.GetPassword() != "" {
+		return secret.String(cp.GetPassword()), nil
+	}
+	ref := cp.GetSecret()
+	if ref.GetProjectId() == "" || ref.GetSecretName() == "" {
+		return secret.String(""), nil
+	}
+	pw, err := secrets.GetSecret(ctx, ref.GetProjectId(), ref.GetSecretName())
+	if err != nil {
+		return secret.String(""), fmt.Errorf("failed to get secret: %w", err)
+	}
+	return secret.String(pw), nil
+}
+
+// providerFor returns the provider of the connection secret referenced by cp,
+// or nil if cp does not reference one. A Vault secret takes precedence over a stored credential,
+// which takes precedence over a Secret Manager secret.
+func providerFor(cp *configpb.ConnectionParameters, secrets SecretGetter) (Provider, error) {
+	if vault := cp.GetVaultSecret(); vault != nil {
+		return NewVaultProvider(vault)
+	}
+	if stored := cp.GetStoredCredential(); stored.GetName() != "" {
+		return storedCredentialProvider{ref: stored}, nil
+	}
+	ref := cp.GetSecret()
+	if ref.GetFormat() != configpb.SecretRef_JSON || ref.GetProjectId() == "" || ref.GetSecretName() == "" {
+		return nil, nil
+	}
+	return secretManagerProvider{secrets: secrets, ref: ref}, nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectionsecret
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentialstore"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+type fakeSecrets struct {
+	content string
+	err     error
+}
+
+func (f fakeSecrets) GetSecret(ctx context.Context, projectID, secretName string) (string, error) {
+	return f.content, f.err
+}
+
+func TestResolve(t *testing.T) {
+	jsonSecret := &configpb.SecretRef{ProjectId: "test-project", SecretName: "db-connection", Format: configpb.SecretRef_JSON}
+	tests := []struct {
+		name    string
+		cp      *configpb.ConnectionParameters
+		secrets fakeSecrets
+		want    *configpb.ConnectionParameters
+		wantErr bool
+	}{
+		{
+			name: "PasswordSecretIsUnchanged",
+			cp: &configpb.ConnectionParameters{
+				Username: "agent",
+				Secret:   &configpb.SecretRef{ProjectId: "test-project", SecretName: "db-password"},
+			},
+			want: &configpb.ConnectionParameters{
+				Username: "agent",
+				Secret:   &configpb.SecretRef{ProjectId: "test-project", SecretName: "db-password"},
+			},
+		},
+		{
+			name: "AllFields",
+			cp: &configpb.ConnectionParameters{
+				Username: "configured",
+				Host:     "localhost",
+				Port:     3306,
+				Secret:   jsonSecret,
+			},
+			secrets: fakeSecrets{content: `{"username": "agent", "password": "pw", "host": "10.0.0.5", "port": 3307}`},
+			want: &configpb.ConnectionParameters{
+				Username: "agent",
+				Password: "pw",
+				Host:     "10.0.0.5",
+				Port:     3307,
+			},
+		},
+		{
+			name: "MissingFieldsKeepConfiguredValues",
+			cp: &configpb.ConnectionParameters{
+				Username: "configured",
+				Host:     "localhost",
+				Port:     3306,
+				Secret:   jsonSecret,
+			},
+			secrets: fakeSecrets{content: `{"password": "pw"}`},
+			want: &configpb.ConnectionParameters{
+				Username: "configured",
+				Password: "pw",
+				Host:     "localhost",
+				Port:     3306,
+			},
+		},
+		{
+			name:    "PortAsString",
+			cp:      &configpb.ConnectionParameters{Secret: jsonSecret},
+			secrets: fakeSecrets{content: `{"password": "pw", "port": "5433"}`},
+			want:    &configpb.ConnectionParameters{Password: "pw", Port: 5433},
+		},
+		{
+			name:    "InvalidPort",
+			cp:      &configpb.ConnectionParameters{Secret: jsonSecret},
+			secrets: fakeSecrets{content: `{"password": "pw", "port": "postgres"}`},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidJSON",
+			cp:      &configpb.ConnectionParameters{Secret: jsonSecret},
+			secrets: fakeSecrets{content: "pw"},
+			wantErr: true,
+		},
+		{
+			name:    "SecretError",
+			cp:      &configpb.ConnectionParameters{Secret: jsonSecret},
+			secrets: fakeSecrets{err: errors.New("permission denied")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Resolve(context.Background(), tc.cp, tc.secrets)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Resolve() returned error %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Resolve() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveErrorDoesNotLeakPayload(t *testing.T) {
+	cp := &configpb.ConnectionParameters{Secret: &configpb.SecretRef{ProjectId: "test-project", SecretName: "db-connection", Format: configpb.SecretRef_JSON}}
+	_, err := Resolve(context.Background(), cp, fakeSecrets{content: `{"password": "s3cr3t",`})
+	if err == nil {
+		t.Fatal("Resolve() returned nil error for a truncated secret, want error")
+	}
+	if strings.Contains(err.Error(), "s3cr3t") {
+		t.Errorf("Resolve() returned error %q, want it to not contain the secret payload", err)
+	}
+}
+
+func TestResolveStoredCredential(t *testing.T) {
+	dir := t.TempDir()
+	ref := &configpb.StoredCredentialRef{
+		Name:      "mysql",
+		StoreFile: filepath.Join(dir, "credentialstore.json"),
+		KeyFile:   filepath.Join(dir, "credentialstore.key"),
+	}
+	if err := credentialstore.New(ref.GetStoreFile(), ref.GetKeyFile()).Set("mysql", secret.String("stored-password")); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	cp := &configpb.ConnectionParameters{Username: "agent", Password: "plaintext", StoredCredential: ref}
+
+	got, err := Resolve(context.Background(), cp, fakeSecrets{})
+	if err != nil {
+		t.Fatalf("Resolve() returned unexpected error: %v", err)
+	}
+	want := &configpb.ConnectionParameters{Username: "agent", Password: "stored-password"}
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("Resolve() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		cp      *configpb.ConnectionParameters
+		secrets fakeSecrets
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "ConfiguredPassword",
+			cp:      &configpb.ConnectionParameters{Password: "pw", Secret: &configpb.SecretRef{ProjectId: "test-project", SecretName: "db-password"}},
+			secrets: fakeSecrets{content: "secret-pw"},
+			want:    "pw",
+		},
+		{
+			name:    "SecretPassword",
+			cp:      &configpb.ConnectionParameters{Secret: &configpb.SecretRef{ProjectId: "test-project", SecretName: "db-password"}},
+			secrets: fakeSecrets{content: "secret-pw"},
+			want:    "secret-pw",
+		},
+		{
+			name: "NoPassword",
+			cp:   &configpb.ConnectionParameters{Username: "agent"},
+			want: "",
+		},
+		{
+			name:    "SecretError",
+			cp:      &configpb.ConnectionParameters{Secret: &configpb.SecretRef{ProjectId: "test-project", SecretName: "db-password"}},
+			secrets: fakeSecrets{err: errors.New("permission denied")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Password(context.Background(), tc.cp, tc.secrets)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Password() returned error %v, wantErr %t", err, tc.wantErr)
+			}
+			if got.SecretValue() != tc.want {
+				t.Errorf("Password() = %q, want %q", got.SecretValue(), tc.want)
+			}
+		})
+	}
+}
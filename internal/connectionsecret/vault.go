@@ -0,0 +1,184 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectionsecret
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/tlsconfig"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	defaultVaultMount        = "secret"
+	defaultVaultAppRoleMount = "approle"
+	vaultRequestTimeout      = 30 * time.Second
+)
+
+// VaultProvider reads connection secrets from the KV version 2 secrets engine of HashiCorp Vault.
+// It authenticates with a token read from a file or by logging in with AppRole for every read.
+type VaultProvider struct {
+	ref    *configpb.VaultSecretRef
+	client *http.Client
+}
+
+// NewVaultProvider returns a VaultProvider reading the secret referenced by ref.
+func NewVaultProvider(ref *configpb.VaultSecretRef) (*VaultProvider, error) {
+	address, err := url.Parse(ref.GetAddress())
+	if err != nil || address.Host == "" {
+		return nil, fmt.Errorf("invalid Vault address %q", ref.GetAddress())
+	}
+	tlsConfig, err := tlsconfig.New(ref.GetTls(), address.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("configuring TLS for Vault: %w", err)
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &VaultProvider{ref: ref, client: &http.Client{Transport: transport, Timeout: vaultRequestTimeout}}, nil
+}
+
+// String describes the secret in error messages.
+func (v *VaultProvider) String() string {
+	return fmt.Sprintf("Vault secret %s/%s", v.mount(), strings.Trim(v.ref.GetPath(), "/"))
+}
+
+// Read returns the data of the latest version of the secret.
+func (v *VaultProvider) Read(ctx context.Context) ([]byte, error) {
+	token, err := v.token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("authenticating with Vault: %w", err)
+	}
+	var res struct {
+		Data struct {
+			Data json.RawMessage `json:"data"`
+		} `json:"data"`
+	}
+	endpoint := fmt.Sprintf("/v1/%s/data/%s", v.mount(), strings.Trim(v.ref.GetPath(), "/"))
+	if err := v.do(ctx, http.MethodGet, endpoint, token, nil, &res); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", v, err)
+	}
+	if len(res.Data.Data) == 0 {
+		return nil, fmt.Errorf("%s has no data", v)
+	}
+	return res.Data.Data, nil
+}
+
+func (v *VaultProvider) mount() string {
+	if mount := strings.Trim(v.ref.GetMount(), "/"); mount != "" {
+		return mount
+	}
+	return defaultVaultMount
+}
+
+// token returns the Vault token to read the secret with.
+func (v *VaultProvider) token(ctx context.Context) (string, error) {
+	switch auth := v.ref.GetAuth().(type) {
+	case *configpb.VaultSecretRef_TokenFile:
+		return readFile(auth.TokenFile)
+	case *configpb.VaultSecretRef_AppRole:
+		return v.appRoleLogin(ctx, auth.AppRole)
+	default:
+		return "", fmt.Errorf("one of token_file and app_role is required")
+	}
+}
+
+// appRoleLogin logs in with the AppRole auth method and returns the client token.
+func (v *VaultProvider) appRoleLogin(ctx context.Context, appRole *configpb.VaultAppRole) (string, error) {
+	secretID, err := readFile(appRole.GetSecretIdFile())
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(map[string]string{"role_id": appRole.GetRoleId(), "secret_id": secretID})
+	if err != nil {
+		return "", fmt.Errorf("marshalling AppRole login: %w", err)
+	}
+	mount := strings.Trim(appRole.GetMount(), "/")
+	if mount == "" {
+		mount = defaultVaultAppRoleMount
+	}
+	var res struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := v.do(ctx, http.MethodPost, fmt.Sprintf("/v1/auth/%s/login", mount), "", body, &res); err != nil {
+		return "", fmt.Errorf("logging in with AppRole: %w", err)
+	}
+	if res.Auth.ClientToken == "" {
+		return "", fmt.Errorf("AppRole login returned no client token")
+	}
+	return res.Auth.ClientToken, nil
+}
+
+// do sends a request to the Vault API and decodes the response into res.
+func (v *VaultProvider) do(ctx context.Context, method, endpoint, token string, body []byte, res any) error {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(v.ref.GetAddress(), "/")+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if namespace := v.ref.GetNamespace(); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Vault error responses list the errors, they do not contain secret data.
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		json.Unmarshal(content, &vaultErr)
+		return fmt.Errorf("Vault returned status %s: %s", resp.Status, strings.Join(vaultErr.Errors, "; "))
+	}
+	if err := json.Unmarshal(content, res); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// readFile returns the trimmed content of a token or secret ID file.
+func readFile(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("file path is empty")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectionsecret
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// fakeVault serves the AppRole login and KV version 2 read endpoints of Vault.
+func fakeVault(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/auth/approle/login", func(w http.ResponseWriter, r *http.Request) {
+		var login map[string]string
+		json.NewDecoder(r.Body).Decode(&login)
+		if login["role_id"] != "test-role" || login["secret_id"] != "test-secret-id" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"errors": ["invalid role or secret ID"]}`))
+			return
+		}
+		w.Write([]byte(`{"auth": {"client_token": "approle-token"}}`))
+	})
+	mux.HandleFunc("GET /v1/kv/data/databases/mysql", func(w http.ResponseWriter, r *http.Request) {
+		if token := r.Header.Get("X-Vault-Token"); token != "file-token" && token != "approle-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+		if r.Header.Get("X-Vault-Namespace") != "team-a" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": []}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"data": {"username": "agent", "password": "pw", "port": "3307"}, "metadata": {"version": 3}}}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+	return path
+}
+
+func TestResolveVault(t *testing.T) {
+	server := fakeVault(t)
+	tokenFile := writeFile(t, "file-token\n")
+	wrongTokenFile := writeFile(t, "wrong-token")
+	secretIDFile := writeFile(t, "test-secret-id")
+	vaultRef := func(auth func(*configpb.VaultSecretRef)) *configpb.VaultSecretRef {
+		ref := &configpb.VaultSecretRef{Address: server.URL, Mount: "kv", Path: "/databases/mysql", Namespace: "team-a"}
+		auth(ref)
+		return ref
+	}
+	tests := []struct {
+		name    string
+		ref     *configpb.VaultSecretRef
+		want    *configpb.ConnectionParameters
+		wantErr bool
+	}{
+		{
+			name: "TokenFile",
+			ref:  vaultRef(func(r *configpb.VaultSecretRef) { r.Auth = &configpb.VaultSecretRef_TokenFile{TokenFile: tokenFile} }),
+			want: &configpb.ConnectionParameters{Username: "agent", Password: "pw", Host: "localhost", Port: 3307},
+		},
+		{
+			name: "AppRole",
+			ref: vaultRef(func(r *configpb.VaultSecretRef) {
+				r.Auth = &configpb.VaultSecretRef_AppRole{AppRole: &configpb.VaultAppRole{RoleId: "test-role", SecretIdFile: secretIDFile}}
+			}),
+			want: &configpb.ConnectionParameters{Username: "agent", Password: "pw", Host: "localhost", Port: 3307},
+		},
+		{
+			name: "AppRoleLoginFails",
+			ref: vaultRef(func(r *configpb.VaultSecretRef) {
+				r.Auth = &configpb.VaultSecretRef_AppRole{AppRole: &configpb.VaultAppRole{RoleId: "other-role", SecretIdFile: secretIDFile}}
+			}),
+			wantErr: true,
+		},
+		{
+			name: "PermissionDenied",
+			ref: vaultRef(func(r *configpb.VaultSecretRef) {
+				r.Auth = &configpb.VaultSecretRef_TokenFile{TokenFile: wrongTokenFile}
+			}),
+			wantErr: true,
+		},
+		{
+			name:    "MissingAuth",
+			ref:     vaultRef(func(r *configpb.VaultSecretRef) {}),
+			wantErr: true,
+		},
+		{
+			name:    "InvalidAddress",
+			ref:     &configpb.VaultSecretRef{Address: "vault", Path: "databases/mysql", Auth: &configpb.VaultSecretRef_TokenFile{TokenFile: tokenFile}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cp := &configpb.ConnectionParameters{Username: "configured", Host: "localhost", VaultSecret: tc.ref}
+			got, err := Resolve(context.Background(), cp, fakeSecrets{})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Resolve() returned error %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Resolve() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials builds the Google API client options for the credentials set in the agent configuration.
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	// cloudPlatformScope is the scope requested for impersonated credentials.
+	cloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+	// stsTokenURL is the Security Token Service endpoint used by workload identity federation.
+	stsTokenURL = "https://sts.googleapis.com/v1/token"
+	// jwtTokenType is the type of the OIDC token exchanged by workload identity federation.
+	jwtTokenType = "urn:ietf:params:oauth:token-type:jwt"
+	// adcEnvVar is the environment variable read by application default credentials.
+	adcEnvVar = "GOOGLE_APPLICATION_CREDENTIALS"
+)
+
+// impersonatedTokenSource returns a token source authenticating as target using the credentials in opts.
+// It is a variable so tests can avoid calling the IAM Credentials API.
+var impersonatedTokenSource = func(ctx context.Context, target string, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+	return impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: target,
+		Scopes:          []string{cloudPlatformScope},
+	}, opts...)
+}
+
+var (
+	defaultMu   sync.Mutex
+	defaultOpts []option.ClientOption
+)
+
+// externalAccount is the credential configuration file format for workload identity federation.
+type externalAccount struct {
+	Type             string           `json:"type"`
+	Audience         string           `json:"audience"`
+	SubjectTokenType string           `json:"subject_token_type"`
+	TokenURL         string           `json:"token_url"`
+	CredentialSource credentialSource `json:"credential_source"`
+}
+
+type credentialSource struct {
+	File string `json:"file"`
+}
+
+// ClientOptions returns the client options authenticating with creds.
+// No options are returned when creds is empty so the client uses the application default credentials.
+func ClientOptions(ctx context.Context, creds *cpb.Credentials) ([]option.ClientOption, error) {
+	ts, err := tokenSource(ctx, creds)
+	if err != nil || ts == nil {
+		return nil, err
+	}
+	return []option.ClientOption{option.WithTokenSource(ts)}, nil
+}
+
+// tokenSource returns the token source of creds, the credentials file or workload identity federation
+// impersonating the configured service account if any.
+// It returns nil when creds is empty so the clients use the application default credentials.
+func tokenSource(ctx context.Context, creds *cpb.Credentials) (oauth2.TokenSource, error) {
+	var content []byte
+	switch {
+	case creds.GetCredentialsFile() != "":
+		var err error
+		if content, err = os.ReadFile(creds.GetCredentialsFile()); err != nil {
+			return nil, fmt.Errorf("reading credentials file: %w", err)
+		}
+	case creds.GetWorkloadIdentityFederation() != nil:
+		wif := creds.GetWorkloadIdentityFederation()
+		var err error
+		content, err = json.Marshal(externalAccount{
+			Type:             "external_account",
+			Audience:         wif.GetAudience(),
+			SubjectTokenType: jwtTokenType,
+			TokenURL:         stsTokenURL,
+			CredentialSource: credentialSource{File: wif.GetSubjectTokenFile()},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("marshalling workload identity federation configuration: %w", err)
+		}
+	}
+
+	var base oauth2.TokenSource
+	if content != nil {
+		c, err := google.CredentialsFromJSON(ctx, content, cloudPlatformScope)
+		if err != nil {
+			return nil, fmt.Errorf("parsing credentials: %w", err)
+		}
+		base = c.TokenSource
+	}
+	target := creds.GetImpersonateServiceAccount()
+	if target == "" {
+		return base, nil
+	}
+	var opts []option.ClientOption
+	if base != nil {
+		opts = append(opts, option.WithTokenSource(base))
+	}
+	ts, err := impersonatedTokenSource(ctx, target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("impersonating service account %s: %w", target, err)
+	}
+	return ts, nil
+}
+
+// SetDefault builds the token source of the configured credentials and makes it the default of
+// the clients created with DefaultOptions, the Compute Engine, Secret Manager and Cloud Storage
+// clients. A credentials file is also made the application default credentials of the process for
+// the clients of the shared libraries.
+func SetDefault(ctx context.Context, creds *cpb.Credentials) error {
+	opts, err := ClientOptions(ctx, creds)
+	if err != nil {
+		return err
+	}
+	if path := creds.GetCredentialsFile(); path != "" {
+		if err := os.Setenv(adcEnvVar, path); err != nil {
+			return fmt.Errorf("setting %s: %w", adcEnvVar, err)
+		}
+		log.CtxLogger(ctx).Infow("Using the configured credentials file as application default credentials", "path", path)
+	}
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultOpts = opts
+	return nil
+}
+
+// DefaultOptions returns the client options of the credentials set by SetDefault.
+// No options are returned if no credentials are configured, the clients then use the application
+// default credentials.
+func DefaultOptions() []option.ClientOption {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	return slices.Clone(defaultOpts)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// writeCredentialsFile writes an authorized user credentials file and returns its path.
+func writeCredentialsFile(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "creds.json")
+	content := `{"type":"authorized_user","client_id":"id","client_secret":"secret","refresh_token":"token"}`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("os.WriteFile(%q) returned unexpected error: %v", path, err)
+	}
+	return path
+}
+
+func TestClientOptions(t *testing.T) {
+	credsFile := writeCredentialsFile(t)
+	tests := []struct {
+		name            string
+		creds           *cpb.Credentials
+		impersonateErr  error
+		wantOpts        int
+		wantImpersonate bool
+		wantBase        bool
+		wantErr         bool
+	}{
+		{
+			name:     "Default",
+			wantOpts: 0,
+		},
+		{
+			name:     "CredentialsFile",
+			creds:    &cpb.Credentials{CredentialsFile: credsFile},
+			wantOpts: 1,
+		},
+		{
+			name:    "MissingCredentialsFile",
+			creds:   &cpb.Credentials{CredentialsFile: filepath.Join(t.TempDir(), "missing.json")},
+			wantErr: true,
+		},
+		{
+			name: "WorkloadIdentityFederation",
+			creds: &cpb.Credentials{
+				WorkloadIdentityFederation: &cpb.WorkloadIdentityFederation{
+					Audience:         "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider",
+					SubjectTokenFile: "/var/run/token",
+				},
+			},
+			wantOpts: 1,
+		},
+		{
+			name:            "Impersonation",
+			creds:           &cpb.Credentials{ImpersonateServiceAccount: "agent@project.iam.gserviceaccount.com"},
+			wantOpts:        1,
+			wantImpersonate: true,
+		},
+		{
+			name: "ImpersonationWithWorkloadIdentityFederation",
+			creds: &cpb.Credentials{
+				WorkloadIdentityFederation: &cpb.WorkloadIdentityFederation{
+					Audience:         "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider",
+					SubjectTokenFile: "/var/run/token",
+				},
+				ImpersonateServiceAccount: "agent@project.iam.gserviceaccount.com",
+			},
+			wantOpts:        1,
+			wantImpersonate: true,
+			wantBase:        true,
+		},
+		{
+			name: "ImpersonationError",
+			creds: &cpb.Credentials{
+				CredentialsFile:           credsFile,
+				ImpersonateServiceAccount: "agent@project.iam.gserviceaccount.com",
+			},
+			impersonateErr:  errors.New("impersonation failed"),
+			wantImpersonate: true,
+			wantBase:        true,
+			wantErr:         true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			impersonated := false
+			old := impersonatedTokenSource
+			defer func() { impersonatedTokenSource = old }()
+			impersonatedTokenSource = func(ctx context.Context, target string, opts ...option.ClientOption) (oauth2.TokenSource, error) {
+				impersonated = true
+				if target != tc.creds.GetImpersonateServiceAccount() {
+					t.Errorf("impersonatedTokenSource() called with target %q, want %q", target, tc.creds.GetImpersonateServiceAccount())
+				}
+				if gotBase := len(opts) > 0; gotBase != tc.wantBase {
+					t.Errorf("impersonatedTokenSource() called with base credentials: %v, want: %v", gotBase, tc.wantBase)
+				}
+				if tc.impersonateErr != nil {
+					return nil, tc.impersonateErr
+				}
+				return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}), nil
+			}
+
+			got, err := ClientOptions(context.Background(), tc.creds)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ClientOptions() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if len(got) != tc.wantOpts {
+				t.Errorf("ClientOptions() returned %d options, want %d", len(got), tc.wantOpts)
+			}
+			if impersonated != tc.wantImpersonate {
+				t.Errorf("ClientOptions() impersonated: %v, want: %v", impersonated, tc.wantImpersonate)
+			}
+		})
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	t.Setenv(adcEnvVar, "")
+	defer func() { defaultOpts = nil }()
+	if err := SetDefault(context.Background(), nil); err != nil {
+		t.Fatalf("SetDefault(nil) returned unexpected error: %v", err)
+	}
+	if got := os.Getenv(adcEnvVar); got != "" {
+		t.Errorf("SetDefault(nil) set %s to %q, want unset", adcEnvVar, got)
+	}
+	if got := DefaultOptions(); len(got) != 0 {
+		t.Errorf("DefaultOptions() after SetDefault(nil) returned %d options, want 0", len(got))
+	}
+
+	credsFile := writeCredentialsFile(t)
+	if err := SetDefault(context.Background(), &cpb.Credentials{CredentialsFile: credsFile}); err != nil {
+		t.Fatalf("SetDefault() returned unexpected error: %v", err)
+	}
+	if got := os.Getenv(adcEnvVar); got != credsFile {
+		t.Errorf("SetDefault() set %s to %q, want %q", adcEnvVar, got, credsFile)
+	}
+	if got := DefaultOptions(); len(got) != 1 {
+		t.Errorf("DefaultOptions() returned %d options, want 1", len(got))
+	}
+
+	wif := &cpb.Credentials{
+		WorkloadIdentityFederation: &cpb.WorkloadIdentityFederation{
+			Audience:         "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider",
+			SubjectTokenFile: "/var/run/token",
+		},
+	}
+	if err := SetDefault(context.Background(), wif); err != nil {
+		t.Fatalf("SetDefault() returned unexpected error: %v", err)
+	}
+	if got := DefaultOptions(); len(got) != 1 {
+		t.Errorf("DefaultOptions() with workload identity federation returned %d options, want 1", len(got))
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentialstore encrypts database passwords at rest in a local file, for environments
+// where Secret Manager is not reachable.
+//
+// Passwords are encrypted with AES-256-GCM. The key is read from a local key file or, by default,
+// derived from the project and instance IDs of the Compute Engine instance and the salt stored in
+// the file. These IDs are not secret, they appear in insights, logs and support bundles, so the
+// default key only obfuscates the passwords: anyone holding a copy of the store file and the IDs can
+// decrypt it. A key file kept apart from the store, readable only by the agent, protects the
+// passwords; a warning is logged whenever the default key is used.
+package credentialstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/metadataserver"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+)
+
+const (
+	// LinuxPath is the default path of the credential store on linux.
+	LinuxPath = `/etc/google-cloud-workload-agent/credentialstore.json`
+	// WindowsPath is the default path of the credential store on windows.
+	WindowsPath = `C:\Program Files\Google\google-cloud-workload-agent\conf\credentialstore.json`
+
+	keySize  = 32
+	saltSize = 16
+	// hkdfInfo separates the keys derived for the credential store from other uses of the identity.
+	hkdfInfo = "google-cloud-workload-agent credential store"
+
+	keySourceInstance = "instance"
+	keySourceKeyFile  = "key_file"
+)
+
+// ErrNotFound is returned for credentials which are not in the store.
+var ErrNotFound = errors.New("credential not found")
+
+// instanceKeyWarning logs once per process that credentials are only obfuscated.
+var instanceKeyWarning sync.Once
+
+// entry is an encrypted credential.
+type entry struct {
+	KeySource  string `json:"key_source"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// storeFile is the content of the credential store.
+type storeFile struct {
+	// Salt is used to derive the key from the instance identity.
+	Salt        []byte           `json:"salt"`
+	Credentials map[string]entry `json:"credentials"`
+}
+
+// Store reads and writes the credentials in a credential store file.
+type Store struct {
+	path    string
+	keyFile string
+	// instanceIdentity returns the identity of the instance the default key is derived from.
+	instanceIdentity func() (string, error)
+}
+
+// DefaultPath returns the default path of the credential store based on the operating system.
+func DefaultPath() string {
+	return capabilities.ByOS(LinuxPath, WindowsPath)
+}
+
+// New returns a Store for the credential store at path, or at DefaultPath if path is empty.
+// Credentials are encrypted with the key in keyFile, or with the instance key if keyFile is empty.
+func New(path, keyFile string) *Store {
+	if path == "" {
+		path = DefaultPath()
+	}
+	return &Store{path: path, keyFile: keyFile, instanceIdentity: instanceIdentity}
+}
+
+// Set encrypts password and stores it as the credential name, replacing any previous value.
+// A missing store file or key file is created.
+func (s *Store) Set(name string, password secret.String) error {
+	f, err := s.read()
+	if errors.Is(err, os.ErrNotExist) {
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("generating salt: %w", err)
+		}
+		f = &storeFile{Salt: salt, Credentials: make(map[string]entry)}
+	} else if err != nil {
+		return err
+	}
+
+	key, source, err := s.key(f.Salt, true)
+	if err != nil {
+		return err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generating nonce: %w", err)
+	}
+	// The name is authenticated so that a credential can't be swapped for another one.
+	f.Credentials[name] = entry{
+		KeySource:  source,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, []byte(password.SecretValue()), []byte(name)),
+	}
+	return s.write(f)
+}
+
+// Get returns the decrypted credential name.
+func (s *Store) Get(name string) (secret.String, error) {
+	f, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	e, ok := f.Credentials[name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s in %s", ErrNotFound, name, s.path)
+	}
+	key, source, err := s.key(f.Salt, false)
+	if err != nil {
+		return "", err
+	}
+	if source != e.KeySource {
+		return "", fmt.Errorf("credential %s in %s is encrypted with the %s key, not the %s key", name, s.path, e.KeySource, source)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+	password, err := aead.Open(nil, e.Nonce, e.Ciphertext, []byte(name))
+	if err != nil {
+		return "", fmt.Errorf("decrypting credential %s in %s, it was encrypted with another key: %w", name, s.path, err)
+	}
+	return secret.String(password), nil
+}
+
+// key returns the encryption key and its source. With create, a missing key file is generated.
+func (s *Store) key(salt []byte, create bool) ([]byte, string, error) {
+	if s.keyFile == "" {
+		instanceKeyWarning.Do(func() {
+			log.Logger.Warnw("The credential store uses the default key derived from the non-secret instance identity, its passwords are only obfuscated. Use a key file to encrypt them.", "path", s.path)
+		})
+		identity, err := s.instanceIdentity()
+		if err != nil {
+			return nil, "", err
+		}
+		key, err := hkdf.Key(sha256.New, []byte(identity), salt, hkdfInfo, keySize)
+		if err != nil {
+			return nil, "", fmt.Errorf("deriving key: %w", err)
+		}
+		return key, keySourceInstance, nil
+	}
+
+	key, err := os.ReadFile(s.keyFile)
+	if errors.Is(err, os.ErrNotExist) && create {
+		key = make([]byte, keySize)
+		if _, err := rand.Read(key); err != nil {
+			return nil, "", fmt.Errorf("generating key: %w", err)
+		}
+		if err := os.WriteFile(s.keyFile, key, 0600); err != nil {
+			return nil, "", fmt.Errorf("writing key file %s: %w", s.keyFile, err)
+		}
+		return key, keySourceKeyFile, nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("reading key file %s: %w", s.keyFile, err)
+	}
+	if len(key) != keySize {
+		return nil, "", fmt.Errorf("key file %s contains %d bytes, want %d", s.keyFile, len(key), keySize)
+	}
+	return key, keySourceKeyFile, nil
+}
+
+func (s *Store) read() (*storeFile, error) {
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading credential store: %w", err)
+	}
+	f := &storeFile{}
+	if err := json.Unmarshal(content, f); err != nil {
+		return nil, fmt.Errorf("parsing credential store %s: %w", s.path, err)
+	}
+	if f.Credentials == nil {
+		f.Credentials = make(map[string]entry)
+	}
+	return f, nil
+}
+
+// write replaces the store file, which is only readable by the user running the agent.
+func (s *Store) write(f *storeFile) error {
+	content, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling credential store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary credential store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temporary credential store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary credential store: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return fmt.Errorf("setting permissions of temporary credential store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replacing credential store %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// instanceIdentity returns the project and instance IDs of the Compute Engine instance.
+func instanceIdentity() (string, error) {
+	cp := metadataserver.ReadCloudPropertiesWithRetry(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), 2))
+	if cp == nil || cp.InstanceID == "" {
+		return "", errors.New("reading the instance identity from the metadata server failed, use a key file instead")
+	}
+	return cp.ProjectID + "/" + cp.InstanceID, nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentialstore
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+)
+
+func newTestStore(t *testing.T, path, keyFile, identity string) *Store {
+	t.Helper()
+	s := New(path, keyFile)
+	s.instanceIdentity = func() (string, error) { return identity, nil }
+	return s
+}
+
+func TestSetGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyFile bool
+	}{
+		{name: "InstanceKey"},
+		{name: "KeyFile", keyFile: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "credentialstore.json")
+			keyFile := ""
+			if tc.keyFile {
+				keyFile = filepath.Join(dir, "credentialstore.key")
+			}
+			s := newTestStore(t, path, keyFile, "test-project/123")
+			if err := s.Set("mysql", secret.String("mysql-password")); err != nil {
+				t.Fatalf("Set(mysql) returned unexpected error: %v", err)
+			}
+			if err := s.Set("redis", secret.String("redis-password")); err != nil {
+				t.Fatalf("Set(redis) returned unexpected error: %v", err)
+			}
+
+			for name, want := range map[string]string{"mysql": "mysql-password", "redis": "redis-password"} {
+				got, err := s.Get(name)
+				if err != nil {
+					t.Fatalf("Get(%s) returned unexpected error: %v", name, err)
+				}
+				if got.SecretValue() != want {
+					t.Errorf("Get(%s) = %q, want %q", name, got.SecretValue(), want)
+				}
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("os.Stat(%s) returned unexpected error: %v", path, err)
+			}
+			if info.Mode().Perm() != 0600 {
+				t.Errorf("credential store has mode %v, want 0600", info.Mode().Perm())
+			}
+		})
+	}
+}
+
+func TestGetErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentialstore.json")
+	keyFile := filepath.Join(dir, "credentialstore.key")
+	if err := newTestStore(t, path, "", "test-project/123").Set("mysql", secret.String("password")); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		store        *Store
+		key          string
+		wantNotFound bool
+	}{
+		{
+			name:  "OtherInstance",
+			store: newTestStore(t, path, "", "test-project/456"),
+			key:   "mysql",
+		},
+		{
+			name:  "KeyFileForInstanceKey",
+			store: newTestStore(t, path, keyFile, "test-project/123"),
+			key:   "mysql",
+		},
+		{
+			name:         "NotFound",
+			store:        newTestStore(t, path, "", "test-project/123"),
+			key:          "postgres",
+			wantNotFound: true,
+		},
+		{
+			name:  "MissingStore",
+			store: newTestStore(t, filepath.Join(dir, "missing.json"), "", "test-project/123"),
+			key:   "mysql",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.store.Get(tc.key)
+			if err == nil {
+				t.Fatalf("Get(%s) returned nil error, want error", tc.key)
+			}
+			if got := errors.Is(err, ErrNotFound); got != tc.wantNotFound {
+				t.Errorf("Get(%s) returned error %v, errors.Is(ErrNotFound) = %v, want %v", tc.key, err, got, tc.wantNotFound)
+			}
+		})
+	}
+}
+
+func TestSetKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "credentialstore.key")
+	s := newTestStore(t, filepath.Join(dir, "credentialstore.json"), keyFile, "")
+	if err := s.Set("mysql", secret.String("password")); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	key, err := os.ReadFile(keyFile)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", keyFile, err)
+	}
+	if len(key) != keySize {
+		t.Errorf("generated key has %d bytes, want %d", len(key), keySize)
+	}
+
+	if err := os.WriteFile(keyFile, []byte("short"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Get("mysql"); err == nil {
+		t.Error("Get() with a truncated key file returned nil error, want error")
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const (
+	backupInfix      = ".bak."
+	backupTimeFormat = "20060102-150405.000000"
+)
+
+// BackupPath returns the path of a backup of the configuration file at path taken at t.
+func BackupPath(path string, t time.Time) string {
+	return path + backupInfix + t.UTC().Format(backupTimeFormat)
+}
+
+// Backups returns the backups of the configuration file at path, oldest first.
+func Backups(path string) ([]string, error) {
+	backups, err := filepath.Glob(path + backupInfix + "*")
+	if err != nil {
+		return nil, fmt.Errorf("listing configuration backups: %w", err)
+	}
+	// The timestamp format sorts lexically in chronological order.
+	sort.Strings(backups)
+	return backups, nil
+}
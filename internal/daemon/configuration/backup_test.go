@@ -0,0 +1,45 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "configuration.json")
+	newer := BackupPath(path, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	older := BackupPath(path, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, p := range []string{newer, older, path} {
+		if err := os.WriteFile(p, []byte("{}"), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", p, err)
+		}
+	}
+
+	got, err := Backups(path)
+	if err != nil {
+		t.Fatalf("Backups(%s) returned unexpected error: %v", path, err)
+	}
+	if diff := cmp.Diff([]string{older, newer}, got); diff != "" {
+		t.Errorf("Backups(%s) returned unexpected diff (-want +got):\n%s", path, diff)
+	}
+}
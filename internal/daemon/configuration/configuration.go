@@ -22,13 +22,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
 	"sort"
 	"time"
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"go.uber.org/zap/zapcore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlguard"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 
@@ -67,6 +69,20 @@ var (
 	errMissingServiceName          = errors.New("service_name is required")
 	errMissingProjectID            = errors.New("project_id is required")
 	errMissingSecretName           = errors.New("secret_name is required")
+	errQueryNotReadOnly            = errors.New("queries must be read-only")
+	errMissingExportFilePath       = errors.New("file_path is required")
+	errMultipleRemoteSources       = errors.New("only one of gcs_uri and secret can be set")
+	errMultipleCredentialSources   = errors.New("only one of credentials_file and workload_identity_federation can be set")
+	errMissingAudience             = errors.New("audience is required")
+	errMissingSubjectTokenFile     = errors.New("subject_token_file is required")
+	errMissingVaultAddress         = errors.New("vault_secret address is required")
+	errMissingVaultPath            = errors.New("vault_secret path is required")
+	errMissingVaultAuth            = errors.New("one of vault_secret token_file and app_role is required")
+	errMissingVaultRoleID          = errors.New("app_role role_id is required")
+	errMissingVaultSecretIDFile    = errors.New("app_role secret_id_file is required")
+	errDiagnosticsPackNotLicensed  = errors.New("activity_sampling from ACTIVE_SESSION_HISTORY requires diagnostics_pack_licensed")
+	errMissingBaselineWorkloadType = errors.New("baseline workload_type is required")
+	errMissingBaselinePath         = errors.New("baseline path is required")
 
 	sqlServerConfigurationErrors = map[string]error{
 		"errMissingCollectionConfiguration":  errors.New("collection_configuration is required"),
@@ -82,11 +98,8 @@ const (
 	// AgentName is a short-hand name of the agent.
 	AgentName = `workloadagent`
 
-	
-
 	// AgentVersion is the version of the agent.
 	AgentVersion = `1.2`
-	
 
 	// LinuxConfigPath is the default path to agent configuration file on linux.
 	LinuxConfigPath = `/etc/google-cloud-workload-agent/configuration.json`
@@ -123,6 +136,11 @@ func ConfigFromFile(path string, read ReadConfigFile) (*cpb.Configuration, error
 	if len(content) == 0 {
 		return nil, fmt.Errorf("configuration file is empty")
 	}
+	// Older layouts are upgraded in memory; the schema version is only recorded by MigrateFile.
+	content, _, err = migrate(content, migrations, false)
+	if err != nil {
+		return nil, err
+	}
 	cfgFromFile := &cpb.Configuration{}
 	err = protojson.Unmarshal(content, cfgFromFile)
 	if err != nil {
@@ -133,10 +151,7 @@ func ConfigFromFile(path string, read ReadConfigFile) (*cpb.Configuration, error
 
 // ConfigPath returns the default configuration file path based on the operating system.
 func ConfigPath() string {
-	if runtime.GOOS == "windows" {
-		return WindowsConfigPath
-	}
-	return LinuxConfigPath
+	return capabilities.ByOS(LinuxConfigPath, WindowsConfigPath)
 }
 
 // Load loads the configuration from a JSON file and applies defaults for missing fields.
@@ -155,12 +170,8 @@ func Load(path string, read ReadConfigFile, cloudProps *cpb.CloudProperties) (*c
 		return nil, fmt.Errorf("gathering configuration from file: %w", err)
 	}
 
-	if err := validateOracleConfiguration(userCfg); err != nil {
-		return nil, fmt.Errorf("validating Oracle configuration: %w", err)
-	}
-
-	if err := validateSQLServerConfiguration(userCfg); err != nil {
-		return nil, fmt.Errorf("validating SQL Server configuration: %w", err)
+	if err := validate(userCfg); err != nil {
+		return nil, err
 	}
 
 	defaultOracleQueries := defaultCfg.GetOracleConfiguration().GetOracleMetrics().GetQueries()
@@ -173,6 +184,42 @@ func Load(path string, read ReadConfigFile, cloudProps *cpb.CloudProperties) (*c
 	return defaultCfg, nil
 }
 
+// validate checks the workload configurations which cannot be expressed in the proto definition.
+func validate(config *cpb.Configuration) error {
+	if err := validateOracleConfiguration(config); err != nil {
+		return fmt.Errorf("validating Oracle configuration: %w", err)
+	}
+
+	if err := validateSQLServerConfiguration(config); err != nil {
+		return fmt.Errorf("validating SQL Server configuration: %w", err)
+	}
+
+	if err := validateDataWarehouseExport(config); err != nil {
+		return fmt.Errorf("validating Data Warehouse export configuration: %w", err)
+	}
+
+	if err := validateConfigDrift(config); err != nil {
+		return fmt.Errorf("validating configuration drift: %w", err)
+	}
+
+	if err := validateRemoteConfiguration(config); err != nil {
+		return fmt.Errorf("validating remote configuration: %w", err)
+	}
+
+	if err := validateCredentials(config); err != nil {
+		return fmt.Errorf("validating credentials: %w", err)
+	}
+
+	if err := validateVaultSecrets(config); err != nil {
+		return fmt.Errorf("validating Vault secrets: %w", err)
+	}
+
+	if _, err := servicecommunication.NewRegistry(config.GetCommonDiscovery().GetWorkloadSignatures()); err != nil {
+		return fmt.Errorf("validating common discovery configuration: %w", err)
+	}
+	return nil
+}
+
 func validateOracleConfiguration(config *cpb.Configuration) error {
 	if !config.GetOracleConfiguration().GetEnabled() {
 		return nil
@@ -198,6 +245,20 @@ func validateOracleConfiguration(config *cpb.Configuration) error {
 				return errMissingSecretName
 			}
 		}
+		for _, q := range config.GetOracleConfiguration().GetOracleMetrics().GetQueries() {
+			// Disabled queries are never run.
+			if q.GetDisabled() {
+				continue
+			}
+			if err := sqlguard.ValidateQuery(q.GetSql()); err != nil {
+				return fmt.Errorf("query %q: %w: %v", q.GetName(), errQueryNotReadOnly, err)
+			}
+		}
+		// Active Session History is part of the Oracle Diagnostics Pack, which must be licensed separately.
+		sampling := config.GetOracleConfiguration().GetOracleMetrics().GetActivitySampling()
+		if sampling.GetEnabled() && sampling.GetSource() == cpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY && !sampling.GetDiagnosticsPackLicensed() {
+			return errDiagnosticsPackNotLicensed
+		}
 	}
 	return nil
 }
@@ -230,6 +291,112 @@ func validateSQLServerConfiguration(config *cpb.Configuration) error {
 	return nil
 }
 
+func validateDataWarehouseExport(config *cpb.Configuration) error {
+	if !config.GetDataWarehouseExport().GetEnabled() {
+		return nil
+	}
+	if config.GetDataWarehouseExport().GetFilePath() == "" {
+		return errMissingExportFilePath
+	}
+	return nil
+}
+
+func validateConfigDrift(config *cpb.Configuration) error {
+	if !config.GetConfigDrift().GetEnabled() {
+		return nil
+	}
+	for _, b := range config.GetConfigDrift().GetBaselines() {
+		if b.GetWorkloadType() == "" {
+			return errMissingBaselineWorkloadType
+		}
+		if b.GetPath() == "" {
+			return errMissingBaselinePath
+		}
+	}
+	return nil
+}
+
+func validateCredentials(config *cpb.Configuration) error {
+	creds := config.GetCredentials()
+	wif := creds.GetWorkloadIdentityFederation()
+	if wif == nil {
+		return nil
+	}
+	if creds.GetCredentialsFile() != "" {
+		return errMultipleCredentialSources
+	}
+	if wif.GetAudience() == "" {
+		return errMissingAudience
+	}
+	if wif.GetSubjectTokenFile() == "" {
+		return errMissingSubjectTokenFile
+	}
+	return nil
+}
+
+// validateVaultSecrets validates the Vault secrets of the connection parameters read through
+// the connectionsecret package.
+func validateVaultSecrets(config *cpb.Configuration) error {
+	params := []*cpb.ConnectionParameters{
+		config.GetMysqlConfiguration().GetConnectionParameters(),
+		config.GetPostgresConfiguration().GetConnectionParameters(),
+		config.GetRedisConfiguration().GetConnectionParameters(),
+		config.GetMongoDbConfiguration().GetConnectionParameters(),
+		config.GetMysqlConfiguration().GetProxysql(),
+		config.GetPostgresConfiguration().GetPgbouncer(),
+	}
+	params = append(params, config.GetMysqlConfiguration().GetInstances()...)
+	for _, cp := range params {
+		vault := cp.GetVaultSecret()
+		if vault == nil {
+			continue
+		}
+		if vault.GetAddress() == "" {
+			return errMissingVaultAddress
+		}
+		if vault.GetPath() == "" {
+			return errMissingVaultPath
+		}
+		switch {
+		case vault.GetTokenFile() != "":
+		case vault.GetAppRole() != nil:
+			if vault.GetAppRole().GetRoleId() == "" {
+				return errMissingVaultRoleID
+			}
+			if vault.GetAppRole().GetSecretIdFile() == "" {
+				return errMissingVaultSecretIDFile
+			}
+		default:
+			return errMissingVaultAuth
+		}
+	}
+	return nil
+}
+
+func validateRemoteConfiguration(config *cpb.Configuration) error {
+	rc := config.GetRemoteConfiguration()
+	if rc == nil {
+		return nil
+	}
+	if rc.GetGcsUri() != "" && rc.GetSecret() != nil {
+		return errMultipleRemoteSources
+	}
+	if rc.GetGcsUri() != "" {
+		if _, _, err := parseGCSURI(rc.GetGcsUri()); err != nil {
+			return err
+		}
+	}
+	if rc.GetSecret() != nil {
+		if rc.GetSecret().GetProjectId() == "" {
+			return errMissingProjectID
+		}
+		if rc.GetSecret().GetSecretName() == "" {
+			return errMissingSecretName
+		}
+	}
+	return nil
+}
+
 // defaultConfig returns the default configuration.
 func defaultConfig(cloudProps *cpb.CloudProperties) (*cpb.Configuration, error) {
 	oracleQueries, err := defaultOracleQueries()
@@ -373,7 +540,7 @@ func ensureConfigExists(stat StatFile, mkdirall MkdirAll, write WriteConfigFile)
 // WriteConfigToFile writes the contents of a configuration struct to a file at the given path.
 func WriteConfigToFile(config *cpb.Configuration, path string, write WriteConfigFile) error {
 	content, err := protojson.MarshalOptions{
-		Multiline: true,
+		Multiline:     true,
 		UseProtoNames: true,
 	}.Marshal(config)
 	if err != nil {
@@ -382,3 +549,38 @@ func WriteConfigToFile(config *cpb.Configuration, path string, write WriteConfig
 	}
 	return write(path, content, 0644)
 }
+
+// WriteFileAtomic writes configuration content to path without ever leaving a partial or invalid file behind.
+// The content is written to a temporary file in the same directory, validated with Load and then
+// renamed over path. If any step fails, path is left untouched.
+func WriteFileAtomic(path string, content []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary configuration file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temporary configuration file %s: %w", tmpPath, err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("syncing temporary configuration file %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary configuration file %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, perm); err != nil {
+		return fmt.Errorf("setting permissions of temporary configuration file %s: %w", tmpPath, err)
+	}
+
+	if _, err := Load(tmpPath, os.ReadFile, nil); err != nil {
+		return fmt.Errorf("validating configuration before writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("replacing configuration file %s: %w", path, err)
+	}
+	return nil
+}
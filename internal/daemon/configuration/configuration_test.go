@@ -19,6 +19,7 @@ package configuration
 import (
 	"errors"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -784,6 +785,98 @@ func TestValidateOracleConfiguration(t *testing.T) {
 			},
 			want: errMissingSecretName,
 		},
+		{
+			name: "Oracle Metrics enabled with a read-only custom query",
+			config: &cpb.Configuration{
+				OracleConfiguration: &cpb.OracleConfiguration{
+					Enabled: proto.Bool(true),
+					OracleMetrics: &cpb.OracleMetrics{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: []*cpb.ConnectionParameters{
+							{
+								Username:    "testuser",
+								ServiceName: "orcl",
+								Secret:      &cpb.SecretRef{ProjectId: "testproject", SecretName: "testsecret"},
+							},
+						},
+						Queries: []*cpb.Query{
+							{Name: "custom_query", Sql: "SELECT value FROM v$sysstat WHERE name = 'user commits'"},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Oracle Metrics enabled with a custom query that modifies data",
+			config: &cpb.Configuration{
+				OracleConfiguration: &cpb.OracleConfiguration{
+					Enabled: proto.Bool(true),
+					OracleMetrics: &cpb.OracleMetrics{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: []*cpb.ConnectionParameters{
+							{
+								Username:    "testuser",
+								ServiceName: "orcl",
+								Secret:      &cpb.SecretRef{ProjectId: "testproject", SecretName: "testsecret"},
+							},
+						},
+						Queries: []*cpb.Query{
+							{Name: "custom_query", Sql: "DELETE FROM audit_log"},
+						},
+					},
+				},
+			},
+			want: errQueryNotReadOnly,
+		},
+		{
+			name: "Oracle activity sampling from Active Session History with the Diagnostics Pack licensed",
+			config: &cpb.Configuration{
+				OracleConfiguration: &cpb.OracleConfiguration{
+					Enabled: proto.Bool(true),
+					OracleMetrics: &cpb.OracleMetrics{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: []*cpb.ConnectionParameters{
+							{
+								Username:    "testuser",
+								ServiceName: "orcl",
+								Secret:      &cpb.SecretRef{ProjectId: "testproject", SecretName: "testsecret"},
+							},
+						},
+						ActivitySampling: &cpb.OracleActivitySampling{
+							Enabled:                 proto.Bool(true),
+							DiagnosticsPackLicensed: true,
+							Source:                  cpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY,
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Oracle activity sampling from Active Session History without the Diagnostics Pack licensed",
+			config: &cpb.Configuration{
+				OracleConfiguration: &cpb.OracleConfiguration{
+					Enabled: proto.Bool(true),
+					OracleMetrics: &cpb.OracleMetrics{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: []*cpb.ConnectionParameters{
+							{
+								Username:    "testuser",
+								ServiceName: "orcl",
+								Secret:      &cpb.SecretRef{ProjectId: "testproject", SecretName: "testsecret"},
+							},
+						},
+						ActivitySampling: &cpb.OracleActivitySampling{
+							Enabled:                 proto.Bool(true),
+							DiagnosticsPackLicensed: false,
+							Source:                  cpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY,
+						},
+					},
+				},
+			},
+			want: errDiagnosticsPackNotLicensed,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			err := validateOracleConfiguration(tc.config)
@@ -927,6 +1020,291 @@ func TestValidateSQLServerConfiguration(t *testing.T) {
 	}
 }
 
+func TestValidateDataWarehouseExport(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config *cpb.Configuration
+		want   error
+	}{
+		{
+			name:   "Export not configured",
+			config: &cpb.Configuration{},
+			want:   nil,
+		},
+		{
+			name: "Export disabled without file path",
+			config: &cpb.Configuration{
+				DataWarehouseExport: &cpb.DataWarehouseExport{Enabled: proto.Bool(false)},
+			},
+			want: nil,
+		},
+		{
+			name: "Valid configuration",
+			config: &cpb.Configuration{
+				DataWarehouseExport: &cpb.DataWarehouseExport{
+					Enabled:  proto.Bool(true),
+					FilePath: "/var/log/insights.jsonl",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "File path not provided",
+			config: &cpb.Configuration{
+				DataWarehouseExport: &cpb.DataWarehouseExport{Enabled: proto.Bool(true)},
+			},
+			want: errMissingExportFilePath,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateDataWarehouseExport(tc.config)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("validateDataWarehouseExport() got %v, want: %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfigDrift(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config *cpb.Configuration
+		want   error
+	}{
+		{
+			name:   "Drift not configured",
+			config: &cpb.Configuration{},
+			want:   nil,
+		},
+		{
+			name: "Valid configuration",
+			config: &cpb.Configuration{
+				ConfigDrift: &cpb.ConfigDrift{
+					Enabled:   proto.Bool(true),
+					Baselines: []*cpb.ConfigBaseline{{WorkloadType: "MYSQL", Path: "/etc/baselines/mysql.yaml"}},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Workload type not provided",
+			config: &cpb.Configuration{
+				ConfigDrift: &cpb.ConfigDrift{
+					Enabled:   proto.Bool(true),
+					Baselines: []*cpb.ConfigBaseline{{Path: "/etc/baselines/mysql.yaml"}},
+				},
+			},
+			want: errMissingBaselineWorkloadType,
+		},
+		{
+			name: "Path not provided",
+			config: &cpb.Configuration{
+				ConfigDrift: &cpb.ConfigDrift{
+					Enabled:   proto.Bool(true),
+					Baselines: []*cpb.ConfigBaseline{{WorkloadType: "MYSQL"}},
+				},
+			},
+			want: errMissingBaselinePath,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateConfigDrift(tc.config)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("validateConfigDrift() got %v, want: %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateWorkloadSignatures(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		signatures []*cpb.WorkloadSignature
+		wantErr    bool
+	}{
+		{
+			name: "No signatures",
+		},
+		{
+			name:       "Valid signature",
+			signatures: []*cpb.WorkloadSignature{{Workload: "mysql", ProcessNamePatterns: []string{`^mysqld_custom$`}}},
+		},
+		{
+			name:       "Invalid pattern",
+			signatures: []*cpb.WorkloadSignature{{Workload: "mysql", CmdlinePatterns: []string{`(`}}},
+			wantErr:    true,
+		},
+		{
+			name:       "Unknown workload",
+			signatures: []*cpb.WorkloadSignature{{Workload: "db2", ProcessNamePatterns: []string{`^db2sysc$`}}},
+			wantErr:    true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &cpb.Configuration{CommonDiscovery: &cpb.CommonDiscovery{WorkloadSignatures: tc.signatures}}
+			err := validate(config)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("validate() got %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	wif := &cpb.WorkloadIdentityFederation{
+		Audience:         "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider",
+		SubjectTokenFile: "/var/run/token",
+	}
+	for _, tc := range []struct {
+		name   string
+		config *cpb.Configuration
+		want   error
+	}{
+		{
+			name:   "Credentials not configured",
+			config: &cpb.Configuration{},
+			want:   nil,
+		},
+		{
+			name: "Credentials file with impersonation",
+			config: &cpb.Configuration{
+				Credentials: &cpb.Credentials{
+					CredentialsFile:           "/etc/creds.json",
+					ImpersonateServiceAccount: "agent@project.iam.gserviceaccount.com",
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "Valid workload identity federation",
+			config: &cpb.Configuration{
+				Credentials: &cpb.Credentials{WorkloadIdentityFederation: wif},
+			},
+			want: nil,
+		},
+		{
+			name: "Credentials file and workload identity federation",
+			config: &cpb.Configuration{
+				Credentials: &cpb.Credentials{CredentialsFile: "/etc/creds.json", WorkloadIdentityFederation: wif},
+			},
+			want: errMultipleCredentialSources,
+		},
+		{
+			name: "Missing audience",
+			config: &cpb.Configuration{
+				Credentials: &cpb.Credentials{
+					WorkloadIdentityFederation: &cpb.WorkloadIdentityFederation{SubjectTokenFile: "/var/run/token"},
+				},
+			},
+			want: errMissingAudience,
+		},
+		{
+			name: "Missing subject token file",
+			config: &cpb.Configuration{
+				Credentials: &cpb.Credentials{
+					WorkloadIdentityFederation: &cpb.WorkloadIdentityFederation{Audience: "audience"},
+				},
+			},
+			want: errMissingSubjectTokenFile,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCredentials(tc.config)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("validateCredentials() got %v, want: %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateVaultSecrets(t *testing.T) {
+	mysql := func(vault *cpb.VaultSecretRef) *cpb.Configuration {
+		return &cpb.Configuration{
+			MysqlConfiguration: &cpb.MySQLConfiguration{
+				ConnectionParameters: &cpb.ConnectionParameters{Username: "agent", VaultSecret: vault},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name   string
+		config *cpb.Configuration
+		want   error
+	}{
+		{
+			name:   "Vault not configured",
+			config: &cpb.Configuration{},
+			want:   nil,
+		},
+		{
+			name: "Token file",
+			config: mysql(&cpb.VaultSecretRef{
+				Address: "https://vault:8200",
+				Path:    "databases/mysql",
+				Auth:    &cpb.VaultSecretRef_TokenFile{TokenFile: "/etc/vault/token"},
+			}),
+			want: nil,
+		},
+		{
+			name: "AppRole",
+			config: mysql(&cpb.VaultSecretRef{
+				Address: "https://vault:8200",
+				Path:    "databases/mysql",
+				Auth:    &cpb.VaultSecretRef_AppRole{AppRole: &cpb.VaultAppRole{RoleId: "role", SecretIdFile: "/etc/vault/secret-id"}},
+			}),
+			want: nil,
+		},
+		{
+			name:   "Missing address",
+			config: mysql(&cpb.VaultSecretRef{Path: "databases/mysql", Auth: &cpb.VaultSecretRef_TokenFile{TokenFile: "/etc/vault/token"}}),
+			want:   errMissingVaultAddress,
+		},
+		{
+			name:   "Missing path",
+			config: mysql(&cpb.VaultSecretRef{Address: "https://vault:8200", Auth: &cpb.VaultSecretRef_TokenFile{TokenFile: "/etc/vault/token"}}),
+			want:   errMissingVaultPath,
+		},
+		{
+			name:   "Missing auth",
+			config: mysql(&cpb.VaultSecretRef{Address: "https://vault:8200", Path: "databases/mysql"}),
+			want:   errMissingVaultAuth,
+		},
+		{
+			name: "Missing role ID",
+			config: mysql(&cpb.VaultSecretRef{
+				Address: "https://vault:8200",
+				Path:    "databases/mysql",
+				Auth:    &cpb.VaultSecretRef_AppRole{AppRole: &cpb.VaultAppRole{SecretIdFile: "/etc/vault/secret-id"}},
+			}),
+			want: errMissingVaultRoleID,
+		},
+		{
+			name: "Missing secret ID file",
+			config: mysql(&cpb.VaultSecretRef{
+				Address: "https://vault:8200",
+				Path:    "databases/mysql",
+				Auth:    &cpb.VaultSecretRef_AppRole{AppRole: &cpb.VaultAppRole{RoleId: "role"}},
+			}),
+			want: errMissingVaultSecretIDFile,
+		},
+		{
+			name: "Invalid MySQL instance",
+			config: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					Instances: []*cpb.ConnectionParameters{{VaultSecret: &cpb.VaultSecretRef{Path: "databases/mysql"}}},
+				},
+			},
+			want: errMissingVaultAddress,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateVaultSecrets(tc.config)
+			if !errors.Is(err, tc.want) {
+				t.Errorf("validateVaultSecrets() got %v, want: %v", err, tc.want)
+			}
+		})
+	}
+}
+
 func TestMergeQueries(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1088,3 +1466,65 @@ func TestWriteConfigToFile(t *testing.T) {
 		t.Errorf("WriteConfigToFile() returned unexpected diff (-want +got):\n%s", diff)
 	}
 }
+
+func TestWriteFileAtomic(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantErr     bool
+		wantContent string
+	}{
+		{
+			name:        "ValidConfiguration",
+			content:     `{"log_level":"DEBUG"}`,
+			wantContent: `{"log_level":"DEBUG"}`,
+		},
+		{
+			name:        "TruncatedConfiguration",
+			content:     `{"log_level":`,
+			wantErr:     true,
+			wantContent: `{"log_level":"INFO"}`,
+		},
+		{
+			name:        "InvalidConfiguration",
+			content:     `{"oracle_configuration":{"enabled":true,"oracle_metrics":{"enabled":true}}}`,
+			wantErr:     true,
+			wantContent: `{"log_level":"INFO"}`,
+		},
+		{
+			name:        "EmptyConfiguration",
+			content:     ``,
+			wantErr:     true,
+			wantContent: `{"log_level":"INFO"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "configuration.json")
+			if err := os.WriteFile(path, []byte(`{"log_level":"INFO"}`), 0644); err != nil {
+				t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+			}
+
+			err := WriteFileAtomic(path, []byte(tc.content), 0644)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("WriteFileAtomic(%q) returned error %v, want error: %v", tc.content, err, tc.wantErr)
+			}
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+			}
+			if string(got) != tc.wantContent {
+				t.Errorf("WriteFileAtomic(%q) left content %q, want %q", tc.content, got, tc.wantContent)
+			}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatalf("os.ReadDir(%s) returned unexpected error: %v", dir, err)
+			}
+			if len(entries) != 1 {
+				t.Errorf("WriteFileAtomic(%q) left %d files in the directory, want only the configuration file", tc.content, len(entries))
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// CurrentSchemaVersion is the configuration file layout understood by this agent.
+const CurrentSchemaVersion = 1
+
+const schemaVersionKey = "schema_version"
+
+// migration upgrades a raw configuration file to version from the previous version.
+type migration struct {
+	version     int
+	description string
+	apply       func(config map[string]any) error
+}
+
+// migrations upgrade older configuration layouts, sorted by version.
+// A migration must be added here whenever a configuration field is renamed or moved,
+// since protojson rejects the fields it does not know. The schema version is only recorded in a
+// file which a migration changes.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "record the schema version",
+		apply:       func(map[string]any) error { return nil },
+	},
+}
+
+// MigrateFile upgrades the configuration file at path to CurrentSchemaVersion in place.
+// The file is left untouched unless a migration changes its content. Otherwise the previous file
+// is saved as a backup readable only by its owner, since it may hold passwords, and the migrated
+// file is validated before it is written.
+// Returns true if the file was migrated.
+func MigrateFile(path string) (bool, error) {
+	return migrateFile(path, migrations)
+}
+
+func migrateFile(path string, ms []migration) (bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("reading configuration file %s: %w", path, err)
+	}
+	migrated, ok, err := migrate(content, ms, true)
+	if err != nil || !ok {
+		return false, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("reading configuration file %s: %w", path, err)
+	}
+
+	backupPath := BackupPath(path, time.Now())
+	if err := os.WriteFile(backupPath, content, 0600); err != nil {
+		return false, fmt.Errorf("writing configuration backup %s: %w", backupPath, err)
+	}
+	if err := WriteFileAtomic(path, migrated, info.Mode().Perm()); err != nil {
+		return false, err
+	}
+	log.Logger.Infow("Migrated configuration file", "path", path, "backup", backupPath, "schemaVersion", CurrentSchemaVersion)
+	return true, nil
+}
+
+// migrate applies the migrations newer than the schema version of content and, if recordVersion
+// is set, updates the schema version. Returns the migrated content and true if any migration
+// changed the configuration, or content unchanged otherwise. Content which is not a JSON object is returned unchanged
+// so that parsing reports the error.
+func migrate(content []byte, ms []migration, recordVersion bool) ([]byte, bool, error) {
+	if len(ms) == 0 {
+		return content, false, nil
+	}
+	raw := make(map[string]any)
+	d := json.NewDecoder(bytes.NewReader(content))
+	d.UseNumber()
+	if err := d.Decode(&raw); err != nil {
+		return content, false, nil
+	}
+
+	version, err := schemaVersion(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	latest := ms[len(ms)-1].version
+	if version > latest {
+		log.Logger.Warnw("Configuration schema version is newer than supported by this agent", "schemaVersion", version, "supportedVersion", latest)
+	}
+	if version >= latest {
+		return content, false, nil
+	}
+
+	before, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshalling configuration: %w", err)
+	}
+	for _, m := range ms {
+		if m.version <= version {
+			continue
+		}
+		log.Logger.Infow("Migrating configuration", "schemaVersion", m.version, "description", m.description)
+		if err := m.apply(raw); err != nil {
+			return nil, false, fmt.Errorf("migrating configuration to schema version %d: %w", m.version, err)
+		}
+	}
+	// Maps are marshalled with sorted keys, so the configuration is unchanged if its encoding is.
+	after, err := json.Marshal(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshalling migrated configuration: %w", err)
+	}
+	if bytes.Equal(before, after) {
+		return content, false, nil
+	}
+	if recordVersion {
+		raw[schemaVersionKey] = latest
+	}
+
+	migrated, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return nil, false, fmt.Errorf("marshalling migrated configuration: %w", err)
+	}
+	return migrated, true, nil
+}
+
+// schemaVersion returns the schema version recorded in the raw configuration, 0 if there is none.
+func schemaVersion(raw map[string]any) (int, error) {
+	v, ok := raw[schemaVersionKey]
+	if !ok {
+		return 0, nil
+	}
+	var s string
+	switch v := v.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		// protojson accepts integers as strings.
+		s = v
+	default:
+		return 0, fmt.Errorf("invalid %s %v", schemaVersionKey, v)
+	}
+	version, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", schemaVersionKey, s, err)
+	}
+	return version, nil
+}
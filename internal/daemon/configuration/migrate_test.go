@@ -0,0 +1,251 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// renameField renames the field from to to in obj if it is set.
+func renameField(obj map[string]any, from, to string) {
+	if v, ok := obj[from]; ok {
+		delete(obj, from)
+		obj[to] = v
+	}
+}
+
+var testMigrations = []migration{
+	{
+		version:     1,
+		description: "rename collection_interval",
+		apply: func(config map[string]any) error {
+			if discovery, ok := config["common_discovery"].(map[string]any); ok {
+				renameField(discovery, "collection_interval", "collection_frequency")
+			}
+			return nil
+		},
+	},
+	{
+		version:     2,
+		description: "rename logging_level",
+		apply: func(config map[string]any) error {
+			renameField(config, "logging_level", "log_level")
+			return nil
+		},
+	},
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		migrations    []migration
+		recordVersion bool
+		want          map[string]any
+		wantMigrated  bool
+		wantErr       bool
+	}{
+		{
+			name:          "AllMigrations",
+			content:       `{"logging_level": "DEBUG", "common_discovery": {"collection_interval": "60s"}}`,
+			migrations:    testMigrations,
+			recordVersion: true,
+			want: map[string]any{
+				"log_level":        "DEBUG",
+				"common_discovery": map[string]any{"collection_frequency": "60s"},
+				"schema_version":   float64(2),
+			},
+			wantMigrated: true,
+		},
+		{
+			name:          "OnlyNewerMigrations",
+			content:       `{"schema_version": 1, "logging_level": "DEBUG", "common_discovery": {"collection_interval": "60s"}}`,
+			migrations:    testMigrations,
+			recordVersion: true,
+			want: map[string]any{
+				"log_level":        "DEBUG",
+				"common_discovery": map[string]any{"collection_interval": "60s"},
+				"schema_version":   float64(2),
+			},
+			wantMigrated: true,
+		},
+		{
+			name:       "VersionNotRecorded",
+			content:    `{"logging_level": "DEBUG"}`,
+			migrations: testMigrations,
+			want: map[string]any{
+				"log_level": "DEBUG",
+			},
+			wantMigrated: true,
+		},
+		{
+			name:          "UnchangedByMigrations",
+			content:       `{"log_level": "DEBUG", "common_discovery": {"collection_frequency": "60s"}}`,
+			migrations:    testMigrations,
+			recordVersion: true,
+			want: map[string]any{
+				"log_level":        "DEBUG",
+				"common_discovery": map[string]any{"collection_frequency": "60s"},
+			},
+		},
+		{
+			name:       "VersionAsString",
+			content:    `{"schema_version": "2", "logging_level": "DEBUG"}`,
+			migrations: testMigrations,
+			want: map[string]any{
+				"schema_version": "2",
+				"logging_level":  "DEBUG",
+			},
+		},
+		{
+			name:       "CurrentVersion",
+			content:    `{"schema_version": 2, "log_level": "DEBUG"}`,
+			migrations: testMigrations,
+			want: map[string]any{
+				"schema_version": float64(2),
+				"log_level":      "DEBUG",
+			},
+		},
+		{
+			name:       "NewerVersion",
+			content:    `{"schema_version": 3, "log_level": "DEBUG"}`,
+			migrations: testMigrations,
+			want: map[string]any{
+				"schema_version": float64(3),
+				"log_level":      "DEBUG",
+			},
+		},
+		{
+			name:       "InvalidVersion",
+			content:    `{"schema_version": "one"}`,
+			migrations: testMigrations,
+			wantErr:    true,
+		},
+		{
+			name:       "FailedMigration",
+			content:    `{}`,
+			migrations: []migration{{version: 1, apply: func(map[string]any) error { return errors.New("migration failed") }}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotMigrated, err := migrate([]byte(tc.content), tc.migrations, tc.recordVersion)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("migrate(%q) returned error %v, want error: %v", tc.content, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if gotMigrated != tc.wantMigrated {
+				t.Errorf("migrate(%q) returned migrated %v, want %v", tc.content, gotMigrated, tc.wantMigrated)
+			}
+			gotRaw := make(map[string]any)
+			if err := json.Unmarshal(got, &gotRaw); err != nil {
+				t.Fatalf("json.Unmarshal(%s) returned unexpected error: %v", got, err)
+			}
+			if diff := cmp.Diff(tc.want, gotRaw); diff != "" {
+				t.Errorf("migrate(%q) returned unexpected diff (-want +got):\n%s", tc.content, diff)
+			}
+		})
+	}
+}
+
+func TestMigrateNotJSONObject(t *testing.T) {
+	content := []byte(`{"log_level": `)
+	got, migrated, err := migrate(content, testMigrations, true)
+	if err != nil || migrated || string(got) != string(content) {
+		t.Errorf("migrate(%q) = (%q, %v, %v), want the content unchanged", content, got, migrated, err)
+	}
+}
+
+func TestMigrateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "configuration.json")
+	original := `{"logging_level": "DEBUG"}`
+	if err := os.WriteFile(path, []byte(original), 0640); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+
+	migrated, err := migrateFile(path, testMigrations)
+	if err != nil || !migrated {
+		t.Fatalf("migrateFile(%s) = (%v, %v), want (true, nil)", path, migrated, err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+	}
+	got := make(map[string]any)
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) returned unexpected error: %v", content, err)
+	}
+	want := map[string]any{"log_level": "DEBUG", "schema_version": float64(2)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("migrateFile(%s) wrote unexpected diff (-want +got):\n%s", path, diff)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0640 {
+		t.Errorf("os.Stat(%s) = (%v, %v), want the permissions of the original file", path, info, err)
+	}
+	backups, err := Backups(path)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("Backups(%s) = (%v, %v), want a single backup", path, backups, err)
+	}
+	backup, err := os.ReadFile(backups[0])
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", backups[0], err)
+	}
+	if string(backup) != original {
+		t.Errorf("migrateFile(%s) saved backup %q, want %q", path, backup, original)
+	}
+	if info, err := os.Stat(backups[0]); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("os.Stat(%s) = (%v, %v), want a backup only readable by its owner", backups[0], info, err)
+	}
+
+	migrated, err = migrateFile(path, testMigrations)
+	if err != nil || migrated {
+		t.Errorf("migrateFile(%s) on a migrated file = (%v, %v), want (false, nil)", path, migrated, err)
+	}
+}
+
+func TestMigrateFileUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "configuration.json")
+	original := "{\n    \"log_level\": \"DEBUG\",\n    \"log_to_cloud\": false\n}\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+
+	migrated, err := MigrateFile(path)
+	if err != nil || migrated {
+		t.Fatalf("MigrateFile(%s) = (%v, %v), want (false, nil)", path, migrated, err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+	}
+	if string(got) != original {
+		t.Errorf("MigrateFile(%s) rewrote the file as %q, want it untouched", path, got)
+	}
+	if backups, err := Backups(path); err != nil || len(backups) != 0 {
+		t.Errorf("Backups(%s) = (%v, %v), want no backup", path, backups, err)
+	}
+}
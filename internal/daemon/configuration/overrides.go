@@ -0,0 +1,185 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	// EnvOverridePrefix prefixes the environment variables which override configuration fields.
+	EnvOverridePrefix = "GOOGLE_CLOUD_WORKLOAD_AGENT_"
+	// MetadataOverridePrefix prefixes the GCE instance metadata attributes which override configuration fields.
+	MetadataOverridePrefix = "google-cloud-workload-agent-"
+
+	// overrideFieldSeparator separates nested field names in an override key.
+	overrideFieldSeparator = "__"
+)
+
+// Override sources, in increasing order of precedence.
+const (
+	OverrideSourceMetadata    = "metadata"
+	OverrideSourceEnvironment = "environment"
+)
+
+// Override is a configuration field set from outside of the configuration file.
+type Override struct {
+	Source string
+	Key    string
+	Field  string
+}
+
+// ApplyOverrides sets the configuration fields overridden by GCE instance metadata attributes and
+// environment variables. environ is in the form returned by os.Environ.
+//
+// The precedence order, from lowest to highest, is:
+//  1. agent defaults
+//  2. the configuration file
+//  3. the remote configuration, see MergeRemote
+//  4. instance metadata attributes named google-cloud-workload-agent-<field>
+//  5. environment variables named GOOGLE_CLOUD_WORKLOAD_AGENT_<FIELD>
+//  6. configuration fragments delivered by OS policies, see MergePolicyFragments
+//
+// <field> is the path of the field in configuration.json with nested field names separated by "__",
+// for example GOOGLE_CLOUD_WORKLOAD_AGENT_MYSQL_CONFIGURATION__ENABLED=true. Values use the JSON
+// syntax of the configuration file; strings, enums and durations may be given without quotes.
+// An override which does not name a configuration field, has an invalid value or makes the
+// configuration invalid is logged and skipped, so that a typo in project wide metadata does not stop
+// the agents. Returns the overrides which were applied.
+func ApplyOverrides(config *cpb.Configuration, environ []string, attributes map[string]string) []Override {
+	type pending struct {
+		override Override
+		path     []string
+		value    string
+	}
+	var overrides []pending
+
+	for _, key := range sortedKeys(attributes) {
+		if !strings.HasPrefix(key, MetadataOverridePrefix) {
+			continue
+		}
+		path := strings.Split(strings.TrimPrefix(key, MetadataOverridePrefix), overrideFieldSeparator)
+		overrides = append(overrides, pending{
+			override: Override{Source: OverrideSourceMetadata, Key: key, Field: strings.Join(path, ".")},
+			path:     path,
+			value:    attributes[key],
+		})
+	}
+
+	env := make(map[string]string)
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok && strings.HasPrefix(k, EnvOverridePrefix) {
+			env[k] = v
+		}
+	}
+	for _, key := range sortedKeys(env) {
+		path := strings.Split(strings.ToLower(strings.TrimPrefix(key, EnvOverridePrefix)), overrideFieldSeparator)
+		overrides = append(overrides, pending{
+			override: Override{Source: OverrideSourceEnvironment, Key: key, Field: strings.Join(path, ".")},
+			path:     path,
+			value:    env[key],
+		})
+	}
+
+	applied := make([]Override, 0, len(overrides))
+	for _, o := range overrides {
+		overridden := proto.Clone(config).(*cpb.Configuration)
+		err := setField(overridden.ProtoReflect(), o.path, o.value)
+		if err == nil {
+			err = validate(overridden)
+		}
+		if err != nil {
+			log.Logger.Warnw("Ignoring invalid configuration override, please fix the environment variable or instance metadata attribute", "source", o.override.Source, "key", o.override.Key, "error", err)
+			continue
+		}
+		proto.Reset(config)
+		proto.Merge(config, overridden)
+		applied = append(applied, o.override)
+	}
+	return applied
+}
+
+// setField replaces the field at path in m with value.
+func setField(m protoreflect.Message, path []string, value string) error {
+	fields := make([]protoreflect.FieldDescriptor, len(path))
+	md := m.Descriptor()
+	for i, name := range path {
+		if md == nil {
+			return fmt.Errorf("field %s does not contain other fields", strings.Join(path[:i], "."))
+		}
+		fd := md.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			return fmt.Errorf("unknown field %s", strings.Join(path[:i+1], "."))
+		}
+		fields[i] = fd
+		md = nil
+		if fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+			md = fd.Message()
+		}
+	}
+
+	// The value is parsed by protojson, which knows the JSON encoding of every field type.
+	parsed := m.New()
+	if err := protojson.Unmarshal([]byte(nestedJSON(path, value)), parsed.Interface()); err != nil {
+		if qErr := protojson.Unmarshal([]byte(nestedJSON(path, strconv.Quote(value))), parsed.Interface()); qErr != nil {
+			return fmt.Errorf("invalid value %q for field %s: %w", value, strings.Join(path, "."), err)
+		}
+	}
+
+	dst, src := m, parsed
+	for _, fd := range fields[:len(fields)-1] {
+		dst, src = dst.Mutable(fd).Message(), src.Get(fd).Message()
+	}
+	leaf := fields[len(fields)-1]
+	if !src.Has(leaf) {
+		// A null value clears the field.
+		dst.Clear(leaf)
+		return nil
+	}
+	dst.Set(leaf, src.Get(leaf))
+	return nil
+}
+
+// nestedJSON returns a JSON object which sets the field at path to the JSON encoded value.
+func nestedJSON(path []string, value string) string {
+	var b strings.Builder
+	for _, name := range path {
+		b.WriteString(`{` + strconv.Quote(name) + `:`)
+	}
+	b.WriteString(value)
+	b.WriteString(strings.Repeat("}", len(path)))
+	return b.String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
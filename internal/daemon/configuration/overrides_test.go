@@ -0,0 +1,174 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	dpb "google.golang.org/protobuf/types/known/durationpb"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *cpb.Configuration
+		environ       []string
+		attributes    map[string]string
+		want          *cpb.Configuration
+		wantOverrides []Override
+	}{
+		{
+			name:   "NoOverrides",
+			config: &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+			environ: []string{
+				"PATH=/usr/bin",
+			},
+			attributes: map[string]string{"ssh-keys": "key"},
+			want:       &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+		},
+		{
+			name: "FieldTypes",
+			config: &cpb.Configuration{
+				LogLevel: cpb.Configuration_INFO,
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{Username: "root"},
+				},
+			},
+			environ: []string{
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_LEVEL=DEBUG",
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_TO_CLOUD=false",
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_MYSQL_CONFIGURATION__ENABLED=true",
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_MYSQL_CONFIGURATION__CONNECTION_PARAMETERS__PORT=3307",
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_MYSQL_CONFIGURATION__DBCENTER_COLLECTION_FREQUENCY=600s",
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_DATA_WAREHOUSE_ENDPOINT=https://example.com/",
+			},
+			want: &cpb.Configuration{
+				LogLevel:              cpb.Configuration_DEBUG,
+				LogToCloud:            proto.Bool(false),
+				DataWarehouseEndpoint: "https://example.com/",
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					Enabled:                     proto.Bool(true),
+					DbcenterCollectionFrequency: &dpb.Duration{Seconds: 600},
+					ConnectionParameters:        &cpb.ConnectionParameters{Username: "root", Port: 3307},
+				},
+			},
+			wantOverrides: []Override{
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_DATA_WAREHOUSE_ENDPOINT", Field: "data_warehouse_endpoint"},
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_LEVEL", Field: "log_level"},
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_TO_CLOUD", Field: "log_to_cloud"},
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_MYSQL_CONFIGURATION__CONNECTION_PARAMETERS__PORT", Field: "mysql_configuration.connection_parameters.port"},
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_MYSQL_CONFIGURATION__DBCENTER_COLLECTION_FREQUENCY", Field: "mysql_configuration.dbcenter_collection_frequency"},
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_MYSQL_CONFIGURATION__ENABLED", Field: "mysql_configuration.enabled"},
+			},
+		},
+		{
+			name:   "EnvironmentTakesPrecedenceOverMetadata",
+			config: &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+			environ: []string{
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_LEVEL=ERROR",
+			},
+			attributes: map[string]string{
+				"google-cloud-workload-agent-log_level":    "DEBUG",
+				"google-cloud-workload-agent-log_to_cloud": "false",
+			},
+			want: &cpb.Configuration{
+				LogLevel:   cpb.Configuration_ERROR,
+				LogToCloud: proto.Bool(false),
+			},
+			wantOverrides: []Override{
+				{Source: OverrideSourceMetadata, Key: "google-cloud-workload-agent-log_level", Field: "log_level"},
+				{Source: OverrideSourceMetadata, Key: "google-cloud-workload-agent-log_to_cloud", Field: "log_to_cloud"},
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_LEVEL", Field: "log_level"},
+			},
+		},
+		{
+			name:   "MessageValueReplacesField",
+			config: &cpb.Configuration{CommonDiscovery: &cpb.CommonDiscovery{Enabled: proto.Bool(true)}},
+			attributes: map[string]string{
+				"google-cloud-workload-agent-common_discovery": `{"collection_frequency": "60s"}`,
+			},
+			want: &cpb.Configuration{CommonDiscovery: &cpb.CommonDiscovery{CollectionFrequency: &dpb.Duration{Seconds: 60}}},
+			wantOverrides: []Override{
+				{Source: OverrideSourceMetadata, Key: "google-cloud-workload-agent-common_discovery", Field: "common_discovery"},
+			},
+		},
+		{
+			name:    "UnknownFieldIsSkipped",
+			config:  &cpb.Configuration{},
+			environ: []string{"GOOGLE_CLOUD_WORKLOAD_AGENT_NO_SUCH_FIELD=1"},
+			want:    &cpb.Configuration{},
+		},
+		{
+			name:    "NotAMessageIsSkipped",
+			config:  &cpb.Configuration{},
+			environ: []string{"GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_LEVEL__ENABLED=true"},
+			want:    &cpb.Configuration{},
+		},
+		{
+			name:    "InvalidValueIsSkipped",
+			config:  &cpb.Configuration{},
+			environ: []string{"GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_TO_CLOUD=maybe"},
+			want:    &cpb.Configuration{},
+		},
+		{
+			name:   "InvalidConfigurationIsSkipped",
+			config: &cpb.Configuration{},
+			environ: []string{
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_DATA_WAREHOUSE_EXPORT__ENABLED=true",
+			},
+			want: &cpb.Configuration{},
+		},
+		{
+			name:   "ValidOverridesAreAppliedDespiteInvalidOnes",
+			config: &cpb.Configuration{LogLevel: cpb.Configuration_INFO},
+			environ: []string{
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_LEVEL=DEBUG",
+				"GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_TO_CLOUD=maybe",
+			},
+			attributes: map[string]string{
+				"google-cloud-workload-agent-log_levle":    "ERROR",
+				"google-cloud-workload-agent-log_to_cloud": "false",
+			},
+			want: &cpb.Configuration{
+				LogLevel:   cpb.Configuration_DEBUG,
+				LogToCloud: proto.Bool(false),
+			},
+			wantOverrides: []Override{
+				{Source: OverrideSourceMetadata, Key: "google-cloud-workload-agent-log_to_cloud", Field: "log_to_cloud"},
+				{Source: OverrideSourceEnvironment, Key: "GOOGLE_CLOUD_WORKLOAD_AGENT_LOG_LEVEL", Field: "log_level"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotOverrides := ApplyOverrides(tc.config, tc.environ, tc.attributes)
+			if diff := cmp.Diff(tc.want, tc.config, protocmp.Transform()); diff != "" {
+				t.Errorf("ApplyOverrides() returned unexpected configuration diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantOverrides, gotOverrides, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("ApplyOverrides() returned unexpected overrides diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
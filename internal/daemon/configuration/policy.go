@@ -0,0 +1,110 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	// LinuxPolicyDir is the directory of configuration fragments delivered by OS policies on linux.
+	LinuxPolicyDir = `/etc/google-cloud-workload-agent/policy.d`
+	// WindowsPolicyDir is the directory of configuration fragments delivered by OS policies on windows.
+	WindowsPolicyDir = `C:\Program Files\Google\google-cloud-workload-agent\conf\policy.d`
+)
+
+// PolicyDir returns the directory of configuration fragments delivered by OS policies based on the operating system.
+func PolicyDir() string {
+	return capabilities.ByOS(LinuxPolicyDir, WindowsPolicyDir)
+}
+
+// PolicyFragments returns the configuration fragments in dir in the order they are merged,
+// which is the lexical order of their file names. A missing directory has no fragments.
+func PolicyFragments(dir string) ([]string, error) {
+	fragments, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("listing policy configuration fragments: %w", err)
+	}
+	sort.Strings(fragments)
+	return fragments, nil
+}
+
+// MergePolicyFragments returns a copy of config with the configuration fragments in dir merged over it.
+//
+// Fragments are partial configuration.json files written by OS Config OS policies, which lets
+// administrators enforce settings centrally, e.g. a policy file resource writing
+// {"oracle_configuration": {"oracle_metrics": {"enabled": true}}} to policy.d/oracle.json.
+// Fragments take precedence over all other configuration sources, see ApplyOverrides.
+// Returns the merged fragments.
+func MergePolicyFragments(config *cpb.Configuration, dir string, read ReadConfigFile) (*cpb.Configuration, []string, error) {
+	fragments, err := PolicyFragments(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	merged := proto.Clone(config).(*cpb.Configuration)
+	for _, f := range fragments {
+		content, err := read(f)
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading policy configuration fragment %s: %w", f, err)
+		}
+		if err := mergeFragment(merged, content); err != nil {
+			return nil, nil, fmt.Errorf("merging policy configuration fragment %s: %w", f, err)
+		}
+	}
+	if err := validate(merged); err != nil {
+		return nil, nil, fmt.Errorf("validating configuration with policy fragments: %w", err)
+	}
+	return merged, fragments, nil
+}
+
+// PolicyModTime returns the most recent modification time of the policy directory and its fragments,
+// or the zero time if there are none. Removing a fragment updates the modification time of the directory.
+func PolicyModTime(dir string) (time.Time, error) {
+	var latest time.Time
+	info, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return latest, nil
+	}
+	if err != nil {
+		return latest, err
+	}
+	latest = info.ModTime()
+	fragments, err := PolicyFragments(dir)
+	if err != nil {
+		return latest, err
+	}
+	for _, f := range fragments {
+		info, err := os.Stat(f)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
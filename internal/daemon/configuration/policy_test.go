@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func writeFragments(t *testing.T, dir string, fragments map[string]string) {
+	t.Helper()
+	for name, content := range fragments {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", p, err)
+		}
+	}
+}
+
+func TestMergePolicyFragments(t *testing.T) {
+	config := &cpb.Configuration{
+		LogLevel: cpb.Configuration_INFO,
+		MysqlConfiguration: &cpb.MySQLConfiguration{
+			Enabled: proto.Bool(false),
+		},
+	}
+	tests := []struct {
+		name          string
+		fragments     map[string]string
+		want          *cpb.Configuration
+		wantFragments []string
+		wantErr       bool
+	}{
+		{
+			name: "NoFragments",
+			want: config,
+		},
+		{
+			name: "FragmentsInLexicalOrder",
+			fragments: map[string]string{
+				"10-mysql.json":   `{"mysql_configuration": {"enabled": true}}`,
+				"20-logging.json": `{"log_level": "DEBUG"}`,
+				"30-logging.json": `{"log_level": "ERROR"}`,
+				"README.txt":      `not a fragment`,
+			},
+			want: &cpb.Configuration{
+				LogLevel: cpb.Configuration_ERROR,
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					Enabled: proto.Bool(true),
+				},
+			},
+			wantFragments: []string{"10-mysql.json", "20-logging.json", "30-logging.json"},
+		},
+		{
+			name:      "MalformedFragment",
+			fragments: map[string]string{"bad.json": `{"log_level": `},
+			wantErr:   true,
+		},
+		{
+			name:      "InvalidConfiguration",
+			fragments: map[string]string{"export.json": `{"data_warehouse_export": {"enabled": true}}`},
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFragments(t, dir, tc.fragments)
+
+			got, gotFragments, err := MergePolicyFragments(config, dir, os.ReadFile)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("MergePolicyFragments() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("MergePolicyFragments() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			var wantFragments []string
+			for _, f := range tc.wantFragments {
+				wantFragments = append(wantFragments, filepath.Join(dir, f))
+			}
+			if diff := cmp.Diff(wantFragments, gotFragments); diff != "" {
+				t.Errorf("MergePolicyFragments() returned unexpected fragments (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergePolicyFragmentsMissingDir(t *testing.T) {
+	config := &cpb.Configuration{LogLevel: cpb.Configuration_INFO}
+	got, fragments, err := MergePolicyFragments(config, filepath.Join(t.TempDir(), "missing"), os.ReadFile)
+	if err != nil {
+		t.Fatalf("MergePolicyFragments() returned unexpected error: %v", err)
+	}
+	if len(fragments) != 0 {
+		t.Errorf("MergePolicyFragments() returned fragments %v, want none", fragments)
+	}
+	if diff := cmp.Diff(config, got, protocmp.Transform()); diff != "" {
+		t.Errorf("MergePolicyFragments() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPolicyModTime(t *testing.T) {
+	dir := t.TempDir()
+	if got, err := PolicyModTime(filepath.Join(dir, "missing")); err != nil || !got.IsZero() {
+		t.Errorf("PolicyModTime(missing) = (%v, %v), want the zero time", got, err)
+	}
+
+	writeFragments(t, dir, map[string]string{"oracle.json": "{}"})
+	want := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(filepath.Join(dir, "oracle.json"), want, want); err != nil {
+		t.Fatalf("os.Chtimes() returned unexpected error: %v", err)
+	}
+	got, err := PolicyModTime(dir)
+	if err != nil {
+		t.Fatalf("PolicyModTime(%s) returned unexpected error: %v", dir, err)
+	}
+	if !got.Equal(want) {
+		t.Errorf("PolicyModTime(%s) = %v, want %v", dir, got, want)
+	}
+}
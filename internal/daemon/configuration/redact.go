@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// RedactedValue replaces secrets in a redacted configuration.
+const RedactedValue = "<redacted>"
+
+// Redact returns a copy of the configuration with all connection passwords replaced by RedactedValue.
+// Secret Manager references are kept since they do not contain the secret itself.
+func Redact(config *cpb.Configuration) *cpb.Configuration {
+	if config == nil {
+		return nil
+	}
+	redacted := proto.Clone(config).(*cpb.Configuration)
+	redactMessage(redacted.ProtoReflect())
+	return redacted
+}
+
+// redactMessage walks m and redacts the password of every ConnectionParameters message found.
+func redactMessage(m protoreflect.Message) {
+	if cp, ok := m.Interface().(*cpb.ConnectionParameters); ok {
+		if cp.GetPassword() != "" {
+			cp.Password = RedactedValue
+		}
+		return
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				redactMessage(list.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				redactMessage(mv.Message())
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			redactMessage(v.Message())
+		}
+		return true
+	})
+}
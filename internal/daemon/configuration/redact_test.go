@@ -0,0 +1,110 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *cpb.Configuration
+		want   *cpb.Configuration
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name: "PasswordsAreRedacted",
+			config: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{Username: "root", Password: "mysql-pass"},
+				},
+				OracleConfiguration: &cpb.OracleConfiguration{
+					OracleMetrics: &cpb.OracleMetrics{
+						ConnectionParameters: []*cpb.ConnectionParameters{
+							{Username: "system", Password: "oracle-pass"},
+							{Username: "other", Secret: &cpb.SecretRef{ProjectId: "p", SecretName: "s"}},
+						},
+					},
+				},
+				SqlserverConfiguration: &cpb.SQLServerConfiguration{
+					CredentialConfigurations: []*cpb.SQLServerConfiguration_CredentialConfiguration{
+						{
+							GuestConfigurations: &cpb.SQLServerConfiguration_CredentialConfiguration_RemoteWin{
+								RemoteWin: &cpb.SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin{
+									ConnectionParameters: &cpb.ConnectionParameters{Username: "admin", Password: "win-pass"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{Username: "root", Password: RedactedValue},
+				},
+				OracleConfiguration: &cpb.OracleConfiguration{
+					OracleMetrics: &cpb.OracleMetrics{
+						ConnectionParameters: []*cpb.ConnectionParameters{
+							{Username: "system", Password: RedactedValue},
+							{Username: "other", Secret: &cpb.SecretRef{ProjectId: "p", SecretName: "s"}},
+						},
+					},
+				},
+				SqlserverConfiguration: &cpb.SQLServerConfiguration{
+					CredentialConfigurations: []*cpb.SQLServerConfiguration_CredentialConfiguration{
+						{
+							GuestConfigurations: &cpb.SQLServerConfiguration_CredentialConfiguration_RemoteWin{
+								RemoteWin: &cpb.SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin{
+									ConnectionParameters: &cpb.ConnectionParameters{Username: "admin", Password: RedactedValue},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Redact(tc.config)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Redact() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRedactDoesNotModifyInput(t *testing.T) {
+	config := &cpb.Configuration{
+		RedisConfiguration: &cpb.RedisConfiguration{
+			ConnectionParameters: &cpb.ConnectionParameters{Password: "redis-pass"},
+		},
+	}
+	Redact(config)
+	if got := config.GetRedisConfiguration().GetConnectionParameters().GetPassword(); got != "redis-pass" {
+		t.Errorf("Redact() modified the input password to %q, want %q", got, "redis-pass")
+	}
+}
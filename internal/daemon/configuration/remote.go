@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/storage/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// DefaultRemoteRefreshInterval is how often the remote configuration is checked for changes
+// when no refresh interval is configured.
+const DefaultRemoteRefreshInterval = 10 * time.Minute
+
+// RemoteReaders read the sources a remote configuration can be fetched from.
+type RemoteReaders struct {
+	ReadGCSObject func(ctx context.Context, bucket, object string) ([]byte, error)
+	GetSecret     func(ctx context.Context, projectID, secretName string) (string, error)
+}
+
+// RemoteRefreshInterval returns how often the remote configuration should be checked for changes.
+func RemoteRefreshInterval(rc *cpb.RemoteConfiguration) time.Duration {
+	if d := rc.GetRefreshInterval().AsDuration(); d > 0 {
+		return d
+	}
+	return DefaultRemoteRefreshInterval
+}
+
+// FetchRemote returns the content of the remote configuration, or nil if no remote source is configured.
+func FetchRemote(ctx context.Context, rc *cpb.RemoteConfiguration, r RemoteReaders) ([]byte, error) {
+	switch {
+	case rc.GetGcsUri() != "":
+		bucket, object, err := parseGCSURI(rc.GetGcsUri())
+		if err != nil {
+			return nil, err
+		}
+		content, err := r.ReadGCSObject(ctx, bucket, object)
+		if err != nil {
+			return nil, fmt.Errorf("reading remote configuration from %s: %w", rc.GetGcsUri(), err)
+		}
+		return content, nil
+	case rc.GetSecret() != nil:
+		content, err := r.GetSecret(ctx, rc.GetSecret().GetProjectId(), rc.GetSecret().GetSecretName())
+		if err != nil {
+			return nil, fmt.Errorf("reading remote configuration from secret %s in project %s: %w", rc.GetSecret().GetSecretName(), rc.GetSecret().GetProjectId(), err)
+		}
+		return []byte(content), nil
+	default:
+		return nil, nil
+	}
+}
+
+// MergeRemote returns a copy of config with the remote configuration content merged over it.
+// Oracle queries are merged by name as in Load. The remote configuration cannot point to another
+// remote configuration, so its remote_configuration field is ignored.
+func MergeRemote(config *cpb.Configuration, content []byte) (*cpb.Configuration, error) {
+	merged := proto.Clone(config).(*cpb.Configuration)
+	if err := mergeFragment(merged, content); err != nil {
+		return nil, fmt.Errorf("merging remote configuration: %w", err)
+	}
+	if err := validate(merged); err != nil {
+		return nil, fmt.Errorf("validating remote configuration: %w", err)
+	}
+	return merged, nil
+}
+
+// mergeFragment merges a partial configuration.json over dst.
+// Oracle queries are merged by name as in Load and remote_configuration is ignored.
+// Other lists set in the fragment replace those of dst, so merging a fragment again doesn't duplicate them.
+func mergeFragment(dst *cpb.Configuration, content []byte) error {
+	if len(content) == 0 {
+		return fmt.Errorf("configuration is empty")
+	}
+	content, _, err := migrate(content, migrations, false)
+	if err != nil {
+		return err
+	}
+	fragment := &cpb.Configuration{}
+	if err := protojson.Unmarshal(content, fragment); err != nil {
+		return fmt.Errorf("parsing configuration: %w", err)
+	}
+	fragment.RemoteConfiguration = nil
+
+	fragmentQueries := fragment.GetOracleConfiguration().GetOracleMetrics().GetQueries()
+	queries := mergeQueries(dst.GetOracleConfiguration().GetOracleMetrics().GetQueries(), fragmentQueries)
+	clearRepeated(dst.ProtoReflect(), fragment.ProtoReflect())
+	proto.Merge(dst, fragment)
+	if len(fragmentQueries) > 0 {
+		dst.GetOracleConfiguration().GetOracleMetrics().Queries = queries
+	}
+	return nil
+}
+
+// clearRepeated clears the list fields of dst which are set in src, including those of nested messages.
+func clearRepeated(dst, src protoreflect.Message) {
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList():
+			dst.Clear(fd)
+		case fd.Message() != nil && !fd.IsMap() && dst.Has(fd):
+			clearRepeated(dst.Mutable(fd).Message(), v.Message())
+		}
+		return true
+	})
+}
+
+// ReadGCSObject reads a Cloud Storage object with a client created with opts.
+func ReadGCSObject(ctx context.Context, bucket, object string, opts ...option.ClientOption) ([]byte, error) {
+	s, err := storage.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating Cloud Storage client: %w", err)
+	}
+	res, err := s.Objects.Get(bucket, object).Context(ctx).Download()
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	return io.ReadAll(res.Body)
+}
+
+// parseGCSURI splits a gs://bucket/object URI.
+func parseGCSURI(uri string) (bucket, object string, err error) {
+	path, ok := strings.CutPrefix(uri, "gs://")
+	if ok {
+		bucket, object, ok = strings.Cut(path, "/")
+	}
+	if !ok || bucket == "" || object == "" {
+		return "", "", fmt.Errorf("invalid gcs_uri %q, must be gs://bucket/object", uri)
+	}
+	return bucket, object, nil
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	dpb "google.golang.org/protobuf/types/known/durationpb"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+type fakeRemote struct {
+	bucket, object      string
+	projectID, secretID string
+	content             string
+	err                 error
+}
+
+func (f *fakeRemote) readers() RemoteReaders {
+	return RemoteReaders{
+		ReadGCSObject: func(_ context.Context, bucket, object string) ([]byte, error) {
+			f.bucket, f.object = bucket, object
+			return []byte(f.content), f.err
+		},
+		GetSecret: func(_ context.Context, projectID, secretName string) (string, error) {
+			f.projectID, f.secretID = projectID, secretName
+			return f.content, f.err
+		},
+	}
+}
+
+func TestFetchRemote(t *testing.T) {
+	tests := []struct {
+		name        string
+		rc          *cpb.RemoteConfiguration
+		remote      *fakeRemote
+		want        string
+		wantRead    *fakeRemote
+		wantErr     bool
+		wantNothing bool
+	}{
+		{
+			name:        "NotConfigured",
+			remote:      &fakeRemote{content: "{}"},
+			wantRead:    &fakeRemote{content: "{}"},
+			wantNothing: true,
+		},
+		{
+			name:     "GCS",
+			rc:       &cpb.RemoteConfiguration{GcsUri: "gs://bucket/path/to/configuration.json"},
+			remote:   &fakeRemote{content: `{"log_level":"DEBUG"}`},
+			want:     `{"log_level":"DEBUG"}`,
+			wantRead: &fakeRemote{bucket: "bucket", object: "path/to/configuration.json", content: `{"log_level":"DEBUG"}`},
+		},
+		{
+			name:     "Secret",
+			rc:       &cpb.RemoteConfiguration{Secret: &cpb.SecretRef{ProjectId: "project", SecretName: "config"}},
+			remote:   &fakeRemote{content: `{"log_level":"DEBUG"}`},
+			want:     `{"log_level":"DEBUG"}`,
+			wantRead: &fakeRemote{projectID: "project", secretID: "config", content: `{"log_level":"DEBUG"}`},
+		},
+		{
+			name:    "InvalidGCSURI",
+			rc:      &cpb.RemoteConfiguration{GcsUri: "https://bucket/object"},
+			remote:  &fakeRemote{},
+			wantErr: true,
+		},
+		{
+			name:    "ReadError",
+			rc:      &cpb.RemoteConfiguration{GcsUri: "gs://bucket/object"},
+			remote:  &fakeRemote{err: errors.New("permission denied")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := FetchRemote(context.Background(), tc.rc, tc.remote.readers())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("FetchRemote(%v) returned error %v, want error: %v", tc.rc, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if tc.wantNothing {
+				if got != nil {
+					t.Errorf("FetchRemote(%v) = %q, want nil", tc.rc, got)
+				}
+				return
+			}
+			if string(got) != tc.want {
+				t.Errorf("FetchRemote(%v) = %q, want %q", tc.rc, got, tc.want)
+			}
+			if diff := cmp.Diff(tc.wantRead, tc.remote, cmp.AllowUnexported(fakeRemote{})); diff != "" {
+				t.Errorf("FetchRemote(%v) read unexpected source (-want +got):\n%s", tc.rc, diff)
+			}
+		})
+	}
+}
+
+func TestMergeRemote(t *testing.T) {
+	config := &cpb.Configuration{
+		LogLevel:   cpb.Configuration_INFO,
+		LogToCloud: proto.Bool(true),
+		RemoteConfiguration: &cpb.RemoteConfiguration{
+			GcsUri: "gs://bucket/object",
+		},
+	}
+	tests := []struct {
+		name    string
+		content string
+		want    *cpb.Configuration
+		wantErr bool
+	}{
+		{
+			name:    "RemoteOverridesFile",
+			content: `{"log_level": "DEBUG", "remote_configuration": {"gcs_uri": "gs://other/object"}}`,
+			want: &cpb.Configuration{
+				LogLevel:   cpb.Configuration_DEBUG,
+				LogToCloud: proto.Bool(true),
+				RemoteConfiguration: &cpb.RemoteConfiguration{
+					GcsUri: "gs://bucket/object",
+				},
+			},
+		},
+		{
+			name:    "Empty",
+			wantErr: true,
+		},
+		{
+			name:    "Malformed",
+			content: `{"log_level": `,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid",
+			content: `{"data_warehouse_export": {"enabled": true}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			original := proto.Clone(config)
+			got, err := MergeRemote(config, []byte(tc.content))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("MergeRemote(%q) returned error %v, want error: %v", tc.content, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("MergeRemote(%q) returned unexpected diff (-want +got):\n%s", tc.content, diff)
+			}
+			if diff := cmp.Diff(original, config, protocmp.Transform()); diff != "" {
+				t.Errorf("MergeRemote(%q) modified its input (-want +got):\n%s", tc.content, diff)
+			}
+		})
+	}
+}
+
+func TestMergeRemoteTwice(t *testing.T) {
+	config := &cpb.Configuration{
+		MysqlConfiguration: &cpb.MySQLConfiguration{
+			BackupDirectories: []string{"/local"},
+		},
+	}
+	content := []byte(`{
+		"data_warehouse_additional_destinations": [{"project_id": "central"}],
+		"mysql_configuration": {
+			"instances": [{"host": "db1"}, {"host": "db2"}],
+			"backup_directories": ["/backups"]
+		}
+	}`)
+	want := &cpb.Configuration{
+		DataWarehouseAdditionalDestinations: []*cpb.DataWarehouseDestination{{ProjectId: "central"}},
+		MysqlConfiguration: &cpb.MySQLConfiguration{
+			Instances:         []*cpb.ConnectionParameters{{Host: "db1"}, {Host: "db2"}},
+			BackupDirectories: []string{"/backups"},
+		},
+	}
+
+	once, err := MergeRemote(config, content)
+	if err != nil {
+		t.Fatalf("MergeRemote() returned unexpected error: %v", err)
+	}
+	twice, err := MergeRemote(once, content)
+	if err != nil {
+		t.Fatalf("MergeRemote() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, twice, protocmp.Transform()); diff != "" {
+		t.Errorf("MergeRemote() merged twice returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemoteRefreshInterval(t *testing.T) {
+	if got := RemoteRefreshInterval(&cpb.RemoteConfiguration{}); got != DefaultRemoteRefreshInterval {
+		t.Errorf("RemoteRefreshInterval() = %v, want %v", got, DefaultRemoteRefreshInterval)
+	}
+	rc := &cpb.RemoteConfiguration{RefreshInterval: dpb.New(time.Minute)}
+	if got := RemoteRefreshInterval(rc); got != time.Minute {
+		t.Errorf("RemoteRefreshInterval(%v) = %v, want %v", rc, got, time.Minute)
+	}
+}
+
+func TestValidateRemoteConfiguration(t *testing.T) {
+	tests := []struct {
+		name    string
+		rc      *cpb.RemoteConfiguration
+		wantErr bool
+	}{
+		{name: "NotConfigured"},
+		{name: "GCS", rc: &cpb.RemoteConfiguration{GcsUri: "gs://bucket/object"}},
+		{name: "Secret", rc: &cpb.RemoteConfiguration{Secret: &cpb.SecretRef{ProjectId: "p", SecretName: "s"}}},
+		{name: "MissingObject", rc: &cpb.RemoteConfiguration{GcsUri: "gs://bucket"}, wantErr: true},
+		{name: "MissingSecretName", rc: &cpb.RemoteConfiguration{Secret: &cpb.SecretRef{ProjectId: "p"}}, wantErr: true},
+		{
+			name:    "BothSources",
+			rc:      &cpb.RemoteConfiguration{GcsUri: "gs://bucket/object", Secret: &cpb.SecretRef{ProjectId: "p", SecretName: "s"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateRemoteConfiguration(&cpb.Configuration{RemoteConfiguration: tc.rc})
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("validateRemoteConfiguration(%v) returned error %v, want error: %v", tc.rc, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"fmt"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// Workloads are the names of the workloads the agent can monitor, in the order their services are started.
+var Workloads = []string{"oracle", "mysql", "redis", "sqlserver", "postgres", "openshift", "mongodb"}
+
+// SetWorkloadEnabled sets the enabled field of the named workload's configuration.
+func SetWorkloadEnabled(config *cpb.Configuration, workload string, enabled bool) error {
+	switch workload {
+	case "oracle":
+		if config.OracleConfiguration == nil {
+			config.OracleConfiguration = &cpb.OracleConfiguration{}
+		}
+		config.OracleConfiguration.Enabled = &enabled
+	case "mysql":
+		if config.MysqlConfiguration == nil {
+			config.MysqlConfiguration = &cpb.MySQLConfiguration{}
+		}
+		config.MysqlConfiguration.Enabled = &enabled
+	case "redis":
+		if config.RedisConfiguration == nil {
+			config.RedisConfiguration = &cpb.RedisConfiguration{}
+		}
+		config.RedisConfiguration.Enabled = &enabled
+	case "sqlserver":
+		if config.SqlserverConfiguration == nil {
+			config.SqlserverConfiguration = &cpb.SQLServerConfiguration{}
+		}
+		config.SqlserverConfiguration.Enabled = &enabled
+	case "postgres":
+		if config.PostgresConfiguration == nil {
+			config.PostgresConfiguration = &cpb.PostgresConfiguration{}
+		}
+		config.PostgresConfiguration.Enabled = &enabled
+	case "openshift":
+		if config.OpenshiftConfiguration == nil {
+			config.OpenshiftConfiguration = &cpb.OpenShiftConfiguration{}
+		}
+		config.OpenshiftConfiguration.Enabled = &enabled
+	case "mongodb":
+		if config.MongoDbConfiguration == nil {
+			config.MongoDbConfiguration = &cpb.MongoDBConfiguration{}
+		}
+		config.MongoDbConfiguration.Enabled = &enabled
+	default:
+		return fmt.Errorf("unknown workload %q", workload)
+	}
+	return nil
+}
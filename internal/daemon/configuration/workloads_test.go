@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configuration
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestSetWorkloadEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *cpb.Configuration
+		workload string
+		enabled  bool
+		want     *cpb.Configuration
+		wantErr  bool
+	}{
+		{
+			name:     "EnableMissingConfiguration",
+			config:   &cpb.Configuration{},
+			workload: "mysql",
+			enabled:  true,
+			want:     &cpb.Configuration{MysqlConfiguration: &cpb.MySQLConfiguration{Enabled: proto.Bool(true)}},
+		},
+		{
+			name: "DisableKeepsOtherFields",
+			config: &cpb.Configuration{RedisConfiguration: &cpb.RedisConfiguration{
+				Enabled:              proto.Bool(true),
+				ConnectionParameters: &cpb.ConnectionParameters{Username: "user"},
+			}},
+			workload: "redis",
+			enabled:  false,
+			want: &cpb.Configuration{RedisConfiguration: &cpb.RedisConfiguration{
+				Enabled:              proto.Bool(false),
+				ConnectionParameters: &cpb.ConnectionParameters{Username: "user"},
+			}},
+		},
+		{
+			name:     "UnknownWorkload",
+			config:   &cpb.Configuration{},
+			workload: "db2",
+			want:     &cpb.Configuration{},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := SetWorkloadEnabled(tc.config, tc.workload, tc.enabled)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("SetWorkloadEnabled(%q, %v) returned error: %v, want error: %v", tc.workload, tc.enabled, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, tc.config, protocmp.Transform()); diff != "" {
+				t.Errorf("SetWorkloadEnabled(%q, %v) returned unexpected diff (-want +got):\n%s", tc.workload, tc.enabled, diff)
+			}
+		})
+	}
+
+	for _, w := range Workloads {
+		if err := SetWorkloadEnabled(&cpb.Configuration{}, w, true); err != nil {
+			t.Errorf("SetWorkloadEnabled(%q) returned unexpected error: %v", w, err)
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package control implements the control socket of the running agent.
+// One time commands use it to apply configuration changes without restarting the agent.
+package control
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/servicemanager"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	// LinuxSocketPath is the path to the control socket on linux.
+	LinuxSocketPath = `/var/run/google-cloud-workload-agent/control.sock`
+	// WindowsSocketPath is the path to the control socket on windows.
+	WindowsSocketPath = `C:\Program Files\Google\google-cloud-workload-agent\control.sock`
+
+	setWorkloadEnabledPath = "/v1/setWorkloadEnabled"
+	servicesPath           = "/v1/services"
+	queryStatsPath         = "/v1/queryStats"
+	// metricsPath serves the query stats in the Prometheus text format.
+	metricsPath    = "/metrics"
+	requestTimeout = 30 * time.Second
+)
+
+// Handler applies the requests received on the control socket.
+type Handler interface {
+	SetWorkloadEnabled(ctx context.Context, workload string, enabled bool) error
+	ServiceStatuses() []servicemanager.Status
+}
+
+// setWorkloadEnabledRequest is the body of a SetWorkloadEnabled request.
+type setWorkloadEnabledRequest struct {
+	Workload string `json:"workload"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// response is the body of every control socket response.
+type response struct {
+	Error      string                  `json:"error,omitempty"`
+	Services   []servicemanager.Status `json:"services,omitempty"`
+	QueryStats []querystats.Stat       `json:"query_stats,omitempty"`
+}
+
+// SocketPath returns the control socket path based on the operating system.
+func SocketPath() string {
+	return capabilities.ByOS(LinuxSocketPath, WindowsSocketPath)
+}
+
+// Server serves the control socket.
+type Server struct {
+	path    string
+	handler Handler
+}
+
+// NewServer returns a Server which listens on path and passes requests to handler.
+func NewServer(path string, handler Handler) *Server {
+	return &Server{path: path, handler: handler}
+}
+
+// Serve listens on the control socket until ctx is cancelled.
+// The socket is only accessible to the user running the agent, and on Windows to Administrators.
+func (s *Server) Serve(ctx context.Context) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("creating control socket directory: %w", err)
+	}
+	// A socket left behind by a previous run prevents listening on the same path.
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing stale control socket %s: %w", s.path, err)
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return fmt.Errorf("listening on control socket %s: %w", s.path, err)
+	}
+	if err := restrictAccess(s.path); err != nil {
+		listener.Close()
+		return fmt.Errorf("restricting control socket permissions: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+setWorkloadEnabledPath, s.setWorkloadEnabled)
+	mux.HandleFunc("GET "+servicesPath, s.services)
+	mux.HandleFunc("GET "+queryStatsPath, s.queryStats)
+	mux.HandleFunc("GET "+metricsPath, s.metrics)
+	srv := &http.Server{
+		Handler:     mux,
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	log.Logger.Infow("Serving the control socket", "path", s.path)
+	if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving control socket: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) setWorkloadEnabled(w http.ResponseWriter, r *http.Request) {
+	var req setWorkloadEnabledRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeResponse(w, http.StatusBadRequest, fmt.Errorf("decoding request: %w", err))
+		return
+	}
+	log.Logger.Infow("Received SetWorkloadEnabled request", "workload", req.Workload, "enabled", req.Enabled)
+	if err := s.handler.SetWorkloadEnabled(r.Context(), req.Workload, req.Enabled); err != nil {
+		writeResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeResponse(w, http.StatusOK, nil)
+}
+
+func (s *Server) services(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, response{Services: s.handler.ServiceStatuses()})
+}
+
+func (s *Server) queryStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, response{QueryStats: querystats.Snapshot()})
+}
+
+func (s *Server) metrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	if err := querystats.WritePrometheus(w); err != nil {
+		log.Logger.Debugw("Failed to write metrics", "error", err)
+	}
+}
+
+func writeResponse(w http.ResponseWriter, status int, err error) {
+	var res response
+	if err != nil {
+		res.Error = err.Error()
+	}
+	writeJSON(w, status, res)
+}
+
+func writeJSON(w http.ResponseWriter, status int, res response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res)
+}
+
+// SetWorkloadEnabled asks the agent listening on the control socket at path to enable or disable a workload.
+// The running agent starts or stops the workload's service without a restart.
+func SetWorkloadEnabled(ctx context.Context, path, workload string, enabled bool) error {
+	body, err := json.Marshal(setWorkloadEnabledRequest{Workload: workload, Enabled: enabled})
+	if err != nil {
+		return fmt.Errorf("marshalling request: %w", err)
+	}
+	_, err = call(ctx, path, http.MethodPost, setWorkloadEnabledPath, body)
+	return err
+}
+
+// ServiceStatuses returns the states of the services of the agent listening on the control socket at path.
+func ServiceStatuses(ctx context.Context, path string) ([]servicemanager.Status, error) {
+	res, err := call(ctx, path, http.MethodGet, servicesPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.Services, nil
+}
+
+// QueryStats returns the latency and errors of the queries run by the agent listening on the control socket at path.
+func QueryStats(ctx context.Context, path string) ([]querystats.Stat, error) {
+	res, err := call(ctx, path, http.MethodGet, queryStatsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return res.QueryStats, nil
+}
+
+// call sends a request to the control socket at path and returns the response of the agent,
+// or the error it reported.
+func call(ctx context.Context, path, method, endpoint string, body []byte) (response, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+	}
+	// The host is ignored, the connection is always made to the control socket.
+	req, err := http.NewRequestWithContext(ctx, method, "http://agent"+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return response{}, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return response{}, fmt.Errorf("connecting to the agent control socket %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	var res response
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return response{}, fmt.Errorf("decoding response with status %s: %w", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return response{}, fmt.Errorf("agent returned status %s: %s", resp.Status, res.Error)
+	}
+	return res, nil
+}
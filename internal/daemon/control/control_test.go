@@ -0,0 +1,190 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/servicemanager"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+)
+
+type fakeHandler struct {
+	workload string
+	enabled  bool
+	err      error
+	statuses []servicemanager.Status
+}
+
+func (h *fakeHandler) SetWorkloadEnabled(_ context.Context, workload string, enabled bool) error {
+	h.workload = workload
+	h.enabled = enabled
+	return h.err
+}
+
+func (h *fakeHandler) ServiceStatuses() []servicemanager.Status {
+	return h.statuses
+}
+
+// startServer serves the control socket in a temporary directory until the test ends.
+func startServer(t *testing.T, h Handler) string {
+	t.Helper()
+	// Unix socket paths are limited in length, t.TempDir may be too long.
+	dir, err := os.MkdirTemp("", "control")
+	if err != nil {
+		t.Fatalf("os.MkdirTemp() returned unexpected error: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, "control.sock")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewServer(path, h).Serve(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve() returned unexpected error: %v", err)
+		}
+	})
+
+	// Wait for the socket to be created.
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("control socket %s was not created", path)
+	return ""
+}
+
+func TestSetWorkloadEnabled(t *testing.T) {
+	tests := []struct {
+		name       string
+		handlerErr error
+		workload   string
+		enabled    bool
+		wantErr    bool
+	}{
+		{
+			name:     "Enable",
+			workload: "mysql",
+			enabled:  true,
+		},
+		{
+			name:     "Disable",
+			workload: "redis",
+			enabled:  false,
+		},
+		{
+			name:       "HandlerError",
+			handlerErr: errors.New("unknown workload"),
+			workload:   "db2",
+			enabled:    true,
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &fakeHandler{err: tc.handlerErr}
+			path := startServer(t, h)
+
+			err := SetWorkloadEnabled(context.Background(), path, tc.workload, tc.enabled)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("SetWorkloadEnabled(%q, %v) returned error: %v, want error: %v", tc.workload, tc.enabled, err, tc.wantErr)
+			}
+			if h.workload != tc.workload || h.enabled != tc.enabled {
+				t.Errorf("SetWorkloadEnabled(%q, %v) passed (%q, %v) to the handler", tc.workload, tc.enabled, h.workload, h.enabled)
+			}
+		})
+	}
+}
+
+func TestSetWorkloadEnabledNoAgent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.sock")
+	if err := SetWorkloadEnabled(context.Background(), path, "mysql", true); err == nil {
+		t.Errorf("SetWorkloadEnabled(%s) returned nil error without a running agent, want error", path)
+	}
+}
+
+func TestServiceStatuses(t *testing.T) {
+	want := []servicemanager.Status{
+		{Name: "discovery", State: servicemanager.StateRunning},
+		{Name: "mysql", State: servicemanager.StateFailed, DependsOn: []string{"discovery", "wlmclient"}, Error: `dependency "wlmclient" failed`},
+		{Name: "wlmclient", State: servicemanager.StateFailed, Error: "no credentials"},
+		{Name: "MySQLDiscovery", State: servicemanager.StateQuarantined, Panics: 6},
+	}
+	path := startServer(t, &fakeHandler{statuses: want})
+
+	got, err := ServiceStatuses(context.Background(), path)
+	if err != nil {
+		t.Fatalf("ServiceStatuses() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ServiceStatuses() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestQueryStats(t *testing.T) {
+	querystats.Observe("mysql", "SELECT 1", 3*time.Millisecond, errors.New("query failed"))
+	path := startServer(t, &fakeHandler{})
+
+	got, err := QueryStats(context.Background(), path)
+	if err != nil {
+		t.Fatalf("QueryStats() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(querystats.Snapshot(), got); diff != "" {
+		t.Errorf("QueryStats() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestServeReplacesStaleSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "control")
+	if err != nil {
+		t.Fatalf("os.MkdirTemp() returned unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "control.sock")
+	if err := os.WriteFile(path, nil, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- NewServer(path, &fakeHandler{}).Serve(ctx) }()
+	var callErr error
+	for i := 0; i < 100; i++ {
+		if callErr = SetWorkloadEnabled(context.Background(), path, "mysql", true); callErr == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Serve() returned unexpected error: %v", err)
+	}
+	if callErr != nil {
+		t.Errorf("SetWorkloadEnabled() returned unexpected error after replacing a stale socket: %v", callErr)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows"
+)
+
+// socketSDDL grants full access to LocalSystem, which runs the agent service, and to the
+// Administrators running the one time commands. Permissions inherited from the installation
+// directory are not applied, and file modes are ignored on Windows.
+const socketSDDL = "D:P(A;;GA;;;SY)(A;;GA;;;BA)"
+
+// restrictAccess makes the control socket at path only accessible to LocalSystem and Administrators.
+func restrictAccess(path string) error {
+	sd, err := windows.SecurityDescriptorFromString(socketSDDL)
+	if err != nil {
+		return fmt.Errorf("parsing security descriptor: %w", err)
+	}
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("reading access control list: %w", err)
+	}
+	return windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION, nil, nil, dacl, nil)
+}
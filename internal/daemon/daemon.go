@@ -18,30 +18,48 @@ limitations under the License.
 package daemon
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentials"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/control"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/mongodb"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/mysql"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/openshift"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/oracle"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/postgres"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/preflight"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/redis"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/servicemanager"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/sqlserver"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/eventlog"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/metricexport"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/outbound"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication/datawarehouseactivation"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication/discovery"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/osinfo"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
@@ -57,7 +75,21 @@ type Daemon struct {
 	config         *cpb.Configuration
 	cloudProps     *cpb.CloudProperties
 	osData         osinfo.Data
-	services       []Service
+	// inFlight tracks the metric collections and Data Warehouse writes which shutdown waits for.
+	inFlight workloadmanager.InFlight
+
+	// mu guards the fields below, which allow a single workload service to be restarted
+	// through the control socket.
+	mu             sync.Mutex
+	services       map[string]Service
+	serviceCtx     context.Context
+	newService     map[string]func(*cpb.Configuration) Service
+	serviceCancels map[string]context.CancelFunc
+	configModTime  time.Time
+	// closeBatches writes the pending Data Warehouse batches and stops batching, if batching is enabled.
+	closeBatches func()
+	// manager starts the agent components and reports their states on the control socket.
+	manager *servicemanager.Manager
 }
 
 type (
@@ -71,6 +103,28 @@ type (
 	}
 )
 
+// Components started by the service manager in addition to the workload services.
+const (
+	wlmClientComponent    = "wlmclient"
+	exporterComponent     = "exporter"
+	discoveryComponent    = "discovery"
+	dwActivationComponent = "datawarehouseactivation"
+)
+
+// workloadDependencies are the components each workload service needs before it is started.
+var workloadDependencies = map[string][]string{
+	"oracle":    {discoveryComponent, wlmClientComponent},
+	"mysql":     {discoveryComponent, wlmClientComponent, exporterComponent},
+	"redis":     {discoveryComponent, wlmClientComponent, exporterComponent},
+	"sqlserver": {discoveryComponent, wlmClientComponent},
+	"postgres":  {discoveryComponent, wlmClientComponent, exporterComponent},
+	"openshift": {discoveryComponent, wlmClientComponent},
+	"mongodb":   {discoveryComponent, wlmClientComponent, exporterComponent},
+}
+
+// shutdownTimeout bounds how long shutdown waits for in-flight work to finish.
+const shutdownTimeout = 10 * time.Second
+
 var (
 	configFileReader = func(path string) (io.ReadCloser, error) {
 		file, err := os.Open(path)
@@ -114,6 +168,10 @@ func (d *Daemon) Execute(ctx context.Context) error {
 		os.Chmod(logDir, 0777)
 	}
 	log.SetupLogging(d.lp)
+	if err := eventlog.Open(); err != nil {
+		log.Logger.Warnw("Unable to open the Windows Event Log, agent events are only written to the agent log.", "error", err)
+	}
+	defer eventlog.Close()
 
 	osData, err := osinfo.ReadData(ctx, osinfo.FileReadCloser(configFileReader), osinfo.OSName, osinfo.OSReleaseFilePath)
 	if err != nil {
@@ -121,15 +179,34 @@ func (d *Daemon) Execute(ctx context.Context) error {
 	}
 	d.osData = osData
 
+	// Upgrade older configuration layouts before the poller starts watching the file.
+	if _, err := configuration.MigrateFile(d.configFilePath); err != nil {
+		log.Logger.Warnw("Unable to migrate the configuration file, older fields may not be recognized.", "error", err, "configFile", d.configFilePath)
+	}
+
+	// The control socket outlives daemon restarts, so it is not tied to the handler context.
+	go func() {
+		if err := control.NewServer(control.SocketPath(), d).Serve(ctx); err != nil {
+			log.Logger.Warnw("Unable to serve the control socket, configuration changes require a restart.", "error", err)
+		}
+	}()
+
 	// Run the config poller and daemon handler that will start any services.
 	ctx, d.cancel = context.WithCancel(ctx)
 	d.startConfigPollerRoutine(ctx)
-	return d.startdaemonHandler(ctx, false)
+	if err := d.startdaemonHandler(ctx, false); err != nil {
+		eventlog.Error(fmt.Sprintf("The agent failed to start: %v", err))
+		return err
+	}
+	return nil
 }
 
 func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error {
 	// Cloud properties are exclusively set from the metadata server.
-	configureUsageMetricsForDaemon(d.cloudProps)
+	// The usage metrics opt-out of the configuration file applies before the configuration is
+	// loaded, so it is honored when loading fails. Usage metrics are reconfigured once the
+	// remote configuration and policy fragments have been merged.
+	configureUsageMetricsForDaemon(d.cloudProps, d.fileAgentProperties())
 
 	// Load the agent configuration from the config file.
 	var err error
@@ -139,21 +216,49 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error
 		usagemetrics.Misconfigured()
 		return err
 	}
+	// The remote configuration is fetched with the network settings and credentials of the configuration file.
+	applyNetwork(d.config)
+	if err := credentials.SetDefault(ctx, d.config.GetCredentials()); err != nil {
+		log.Logger.Errorw("Could not apply the configured credentials", "error", err)
+		usagemetrics.Error(usagemetrics.StartDaemonFailure)
+		return err
+	}
+	remoteContent := d.mergeRemoteConfiguration(ctx)
+	overrides := configuration.ApplyOverrides(d.config, os.Environ(), metadataAttributes())
+	policyConfig, fragments, err := configuration.MergePolicyFragments(d.config, configuration.PolicyDir(), os.ReadFile)
+	if err != nil {
+		log.Logger.Errorw("Invalid OS policy configuration fragment, please fix the OS policy and restart the service.", "error", err, "policyDir", configuration.PolicyDir())
+		usagemetrics.Misconfigured()
+		return err
+	}
+	d.config = policyConfig
+	applyNetwork(d.config)
+	configureUsageMetricsForDaemon(d.cloudProps, d.config.GetAgentProperties())
 	usagemetrics.Configured()
 
 	// Setup logging based on the agent configuration.
 	d.lp.LogToCloud = d.config.GetLogToCloud()
 	d.lp.Level = configuration.LogLevelToZapcore(d.config.GetLogLevel())
 	if d.config.GetCloudProperties().GetProjectId() != "" {
-		d.lp.CloudLoggingClient = log.CloudLoggingClient(ctx, d.config.GetCloudProperties().GetProjectId())
+		d.lp.CloudLoggingClient = endpoints.CloudLoggingClient(ctx, d.config.GetCloudProperties().GetProjectId(), d.config.GetEndpoints())
 	}
 	if d.lp.CloudLoggingClient != nil {
 		defer log.FlushCloudLog()
 	}
 	log.SetupLogging(d.lp)
 
+	// Replica zones are resolved with a process wide timeout shared by all workload collectors.
+	ipinfo.SetDNSTimeout(d.config.GetNetwork().GetDnsLookupTimeout().AsDuration())
+
+	// The GCE, Secret Manager and Cloud Storage clients use the credentials of the merged configuration.
+	if err := credentials.SetDefault(ctx, d.config.GetCredentials()); err != nil {
+		log.Logger.Errorw("Could not apply the configured credentials", "error", err)
+		usagemetrics.Error(usagemetrics.StartDaemonFailure)
+		return err
+	}
+
 	// Get vCPU count and memory size from GCE and add to cloudProps.
-	gceClient, err := gce.NewGCEClient(ctx)
+	gceClient, err := endpoints.NewGCEClient(ctx, d.config.GetEndpoints())
 	if err != nil {
 		log.Logger.Errorw("creating GCE client", "error", err)
 		usagemetrics.Error(usagemetrics.StartDaemonFailure)
@@ -169,6 +274,15 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error
 	d.cloudProps.MemorySizeMb = memorySize
 
 	log.Logger.Infow("Starting daemon mode", "agent_name", configuration.AgentName, "agent_version", configuration.AgentVersion)
+	for _, o := range overrides {
+		log.Logger.Infow("Configuration field overridden", "field", o.Field, "source", o.Source, "key", o.Key)
+	}
+	for _, f := range fragments {
+		log.Logger.Infow("Merged OS policy configuration fragment", "path", f)
+	}
+	if rc := d.config.GetRemoteConfiguration(); rc != nil {
+		d.startRemoteConfigPollerRoutine(ctx, rc, remoteContent)
+	}
 	log.Logger.Infow("Cloud Properties",
 		"projectid", d.cloudProps.GetProjectId(),
 		"projectnumber", d.cloudProps.GetNumericProjectId(),
@@ -189,19 +303,34 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error
 		"vendor", d.osData.OSVendor,
 		"version", d.osData.OSVersion,
 	)
+	caps := capabilities.Host()
+	log.Logger.Infow("Host capabilities", "os", caps.OS, "arch", caps.Arch, "tools", caps.Tools())
+	if !caps.SupportedArch() {
+		log.Logger.Warnw("The agent is not supported on this architecture", "arch", caps.Arch, "supported", capabilities.SupportedArchs)
+	}
 
-	wlmClient, err := workloadmanager.Client(ctx, d.config)
-	if err != nil {
-		log.Logger.Errorw("Error creating WLM Client", "error", err)
-		usagemetrics.Error(usagemetrics.WorkloadManagerConnectionError)
-		return err
+	// Preflight failures are reported but do not prevent the services from starting.
+	checks := preflight.Run(ctx, preflight.Params{
+		Config:            d.config,
+		CloudProps:        d.cloudProps,
+		Secrets:           gceClient,
+		LogPaths:          []string{d.lp.LogFileName},
+		MetadataReachable: metadataReachable,
+	})
+	if !preflight.Report(ctx, checks) {
+		usagemetrics.Error(usagemetrics.PreflightCheckFailure)
+		eventlog.Warning("Agent preflight checks failed, see the agent log for details.")
 	}
 
 	// Check if the metric override file exists. If it does, operate in override mode.
 	// Override mode will collect metrics from the override file and send them to Data Warehouse.
 	// Override mode will not start any other services.
-	if fileInfo, err := os.ReadFile(workloadmanager.MetricOverridePath); fileInfo != nil && err == nil {
+	if fileInfo, err := os.ReadFile(workloadmanager.MetricOverridePath(d.config)); fileInfo != nil && err == nil {
 		log.Logger.Info("Metric override file found. Operating in override mode.")
+		wlmClient, err := d.newWLMClient(ctx, gceClient)
+		if err != nil {
+			return err
+		}
 		metricCollectionService := workloadmanager.Service{Config: d.config, Client: wlmClient}
 		metricCollectionCtx := log.SetCtx(ctx, "context", "WorkloadManagerMetrics")
 		recoverableStart := &recovery.RecoverableRoutine{
@@ -210,10 +339,12 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error
 			ErrorCode:           0,
 			ExpectedMinDuration: 20 * time.Second,
 			UsageLogger:         *usagemetrics.UsageLogger,
+			Backoff:             restartbudget.New("WorkloadManagerMetrics", d.config.GetServiceRecovery()),
 		}
 		recoverableStart.StartRoutine(metricCollectionCtx)
 
 		log.Logger.Info("Daemon override mode startup complete")
+		eventlog.Info(fmt.Sprintf("%s %s started in metric override mode.", configuration.AgentName, configuration.AgentVersion))
 		if !restarting {
 			usagemetrics.Started()
 			go usagemetrics.LogRunningDaily()
@@ -222,7 +353,6 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error
 		return nil
 	}
 
-	log.Logger.Info("Starting common discovery")
 	oracleCh := make(chan *servicecommunication.Message, 3)
 	mySQLCh := make(chan *servicecommunication.Message, 3)
 	redisCh := make(chan *servicecommunication.Message, 3)
@@ -230,65 +360,147 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error
 	postgresCh := make(chan *servicecommunication.Message, 3)
 	openshiftCh := make(chan *servicecommunication.Message, 3)
 	mongoCh := make(chan *servicecommunication.Message, 3)
+	containerContextCh := make(chan *servicecommunication.Message, 3)
+	hostSettingsCh := make(chan *servicecommunication.Message, 3)
+	legacyAgentsCh := make(chan *servicecommunication.Message, 3)
 	scChs := map[string]chan<- *servicecommunication.Message{
-		"mysql":     mySQLCh,
-		"oracle":    oracleCh,
-		"redis":     redisCh,
-		"sqlserver": sqlserverCh,
-		"postgres":  postgresCh,
-		"openshift": openshiftCh,
-		"mongodb":   mongoCh,
-	}
-	commondiscovery := discovery.Service{
-		ProcessLister: discovery.DefaultProcessLister{},
-		ReadFile:      os.ReadFile,
-		Hostname:      os.Hostname,
-		Config:        d.config,
-	}
-	recoverableStart := &recovery.RecoverableRoutine{
-		Routine:             commondiscovery.CommonDiscovery,
-		RoutineArg:          scChs,
-		ErrorCode:           commondiscovery.ErrorCode(),
-		ExpectedMinDuration: commondiscovery.ExpectedMinDuration(),
-		UsageLogger:         *usagemetrics.UsageLogger,
+		"mysql":            mySQLCh,
+		"oracle":           oracleCh,
+		"redis":            redisCh,
+		"sqlserver":        sqlserverCh,
+		"postgres":         postgresCh,
+		"openshift":        openshiftCh,
+		"mongodb":          mongoCh,
+		"containercontext": containerContextCh,
+		"hostsettings":     hostSettingsCh,
+		"legacyagents":     legacyAgentsCh,
 	}
-	recoverableStart.StartRoutine(ctx)
-
-	dwActivation := datawarehouseactivation.Service{Config: d.config, Client: wlmClient}
-	recoverableStart = &recovery.RecoverableRoutine{
-		Routine:             dwActivation.DataWarehouseActivationCheck,
-		RoutineArg:          scChs,
-		ErrorCode:           dwActivation.ErrorCode(),
-		ExpectedMinDuration: dwActivation.ExpectedMinDuration(),
-		UsageLogger:         *usagemetrics.UsageLogger,
-	}
-	recoverableStart.StartRoutine(ctx)
-
-	// Create a new databasecenter client.
-	dbcenterClient := databasecenter.NewClient(d.config, nil)
-
-	// Add any additional services here.
-	d.services = []Service{
-		&oracle.Service{Config: d.config, CloudProps: d.cloudProps, CommonCh: oracleCh},
-		&mysql.Service{Config: d.config, CloudProps: d.cloudProps, CommonCh: mySQLCh, WLMClient: wlmClient, DBcenterClient: dbcenterClient},
-		&redis.Service{Config: d.config, CloudProps: d.cloudProps, CommonCh: redisCh, WLMClient: wlmClient, OSData: d.osData},
-		&sqlserver.Service{Config: d.config, CloudProps: d.cloudProps, CommonCh: sqlserverCh, DBcenterClient: dbcenterClient},
-		&postgres.Service{Config: d.config, CloudProps: d.cloudProps, CommonCh: postgresCh, WLMClient: wlmClient, DBcenterClient: dbcenterClient},
-		&openshift.Service{Config: d.config, CloudProps: d.cloudProps, CommonCh: openshiftCh, WLMClient: wlmClient},
-		&mongodb.Service{Config: d.config, CloudProps: d.cloudProps, CommonCh: mongoCh, WLMClient: wlmClient},
-	}
-	for _, service := range d.services {
-		log.Logger.Infof("Starting %s", service.String())
+	// Insights of workloads also collected by a legacy agent running on the host are not sent twice.
+	legacyAgents := workloadmanager.NewLegacyAgents(servicecommunication.RegistryFromConfig(ctx, d.config), d.config.GetLegacyAgents())
+	go legacyAgents.Listen(ctx, legacyAgentsCh)
+
+	// The components below are started concurrently once the components they depend on are running.
+	// The variables are set by the components and only read by the components depending on them.
+	manager := servicemanager.New()
+	var (
+		wlmClient    workloadmanager.WLMWriter
+		hostSettings *workloadmanager.HostSettingsContext
+		exporter     *metricexport.Exporter
+	)
+	manager.Add(wlmClientComponent, func(ctx context.Context) error {
+		client, err := d.newWLMClient(ctx, gceClient)
+		if err != nil {
+			return err
+		}
+		// Insights of workloads running in containers are attributed to their cgroup and pod.
+		containerContext := workloadmanager.NewContainerContextWriter(client, servicecommunication.RegistryFromConfig(ctx, d.config))
+		go containerContext.Listen(ctx, containerContextCh)
+		wlmClient = legacyAgents.Writer(containerContext)
+		// Insights of database workloads include the OS settings of the host and the limits of the database process.
+		hostSettings = workloadmanager.NewHostSettingsContext(servicecommunication.RegistryFromConfig(ctx, d.config))
+		go hostSettings.Listen(ctx, hostSettingsCh)
+		return nil
+	})
+	manager.Add(exporterComponent, func(ctx context.Context) error {
+		// Workload metrics are exported to Cloud Monitoring in addition to Data Warehouse when enabled.
+		var err error
+		if exporter, err = metricexport.New(ctx, d.config); err != nil {
+			log.Logger.Errorw("Could not create the Cloud Monitoring exporter, workload metrics are not exported", "error", err)
+		}
+		return nil
+	})
+	manager.Add(discoveryComponent, func(ctx context.Context) error {
+		log.Logger.Info("Starting common discovery")
+		commondiscovery := discovery.Service{
+			ProcessLister: discovery.DefaultProcessLister{},
+			ReadFile:      os.ReadFile,
+			Hostname:      os.Hostname,
+			Config:        d.config,
+		}
 		recoverableStart := &recovery.RecoverableRoutine{
-			Routine:             service.Start,
-			ErrorCode:           service.ErrorCode(),
-			ExpectedMinDuration: service.ExpectedMinDuration(),
+			Routine:             commondiscovery.CommonDiscovery,
+			RoutineArg:          scChs,
+			ErrorCode:           commondiscovery.ErrorCode(),
+			ExpectedMinDuration: commondiscovery.ExpectedMinDuration(),
 			UsageLogger:         *usagemetrics.UsageLogger,
+			Backoff:             restartbudget.New("common discovery", d.config.GetServiceRecovery()),
 		}
 		recoverableStart.StartRoutine(ctx)
+		return nil
+	})
+	manager.Add(dwActivationComponent, func(ctx context.Context) error {
+		// The activation check bypasses the deduplication and the context added to the insights of the workloads.
+		activationClient, err := workloadmanager.ActivationClient(ctx, d.config)
+		if err != nil {
+			log.Logger.Errorw("Error creating the Data Warehouse activation client", "error", err)
+			usagemetrics.Error(usagemetrics.WorkloadManagerConnectionError)
+			return err
+		}
+		dwActivation := datawarehouseactivation.Service{Config: d.config, Client: activationClient}
+		recoverableStart := &recovery.RecoverableRoutine{
+			Routine:             dwActivation.DataWarehouseActivationCheck,
+			RoutineArg:          scChs,
+			ErrorCode:           dwActivation.ErrorCode(),
+			ExpectedMinDuration: dwActivation.ExpectedMinDuration(),
+			UsageLogger:         *usagemetrics.UsageLogger,
+			Backoff:             restartbudget.New("Data Warehouse activation check", d.config.GetServiceRecovery()),
+		}
+		recoverableStart.StartRoutine(ctx)
+		return nil
+	})
+
+	// Create a new databasecenter client.
+	dbcenterClient := databasecenter.NewClient(d.config, nil)
+
+	// Add any additional services here, keyed by their name in configuration.Workloads.
+	d.mu.Lock()
+	d.serviceCtx = ctx
+	d.manager = manager
+	d.newService = map[string]func(*cpb.Configuration) Service{
+		"oracle": func(c *cpb.Configuration) Service {
+			return &oracle.Service{Config: c, CloudProps: d.cloudProps, CommonCh: oracleCh, WLMClient: hostSettings.Writer(wlmClient, servicecommunication.Oracle)}
+		},
+		"mysql": func(c *cpb.Configuration) Service {
+			return &mysql.Service{Config: c, CloudProps: d.cloudProps, CommonCh: mySQLCh, WLMClient: hostSettings.Writer(wlmClient, servicecommunication.MySQL), DBcenterClient: dbcenterClient, Exporter: exporter, InFlight: &d.inFlight}
+		},
+		"redis": func(c *cpb.Configuration) Service {
+			return &redis.Service{Config: c, CloudProps: d.cloudProps, CommonCh: redisCh, WLMClient: wlmClient, OSData: d.osData, Exporter: exporter, InFlight: &d.inFlight}
+		},
+		"sqlserver": func(c *cpb.Configuration) Service {
+			return &sqlserver.Service{Config: c, CloudProps: d.cloudProps, CommonCh: sqlserverCh, DBcenterClient: dbcenterClient, InFlight: &d.inFlight, WLMClient: wlmClient, LegacyAgents: legacyAgents}
+		},
+		"postgres": func(c *cpb.Configuration) Service {
+			return &postgres.Service{Config: c, CloudProps: d.cloudProps, CommonCh: postgresCh, WLMClient: hostSettings.Writer(wlmClient, servicecommunication.Postgres), DBcenterClient: dbcenterClient, Exporter: exporter, InFlight: &d.inFlight}
+		},
+		"openshift": func(c *cpb.Configuration) Service {
+			return &openshift.Service{Config: c, CloudProps: d.cloudProps, CommonCh: openshiftCh, WLMClient: wlmClient, InFlight: &d.inFlight}
+		},
+		"mongodb": func(c *cpb.Configuration) Service {
+			return &mongodb.Service{Config: c, CloudProps: d.cloudProps, CommonCh: mongoCh, WLMClient: wlmClient, Exporter: exporter, InFlight: &d.inFlight}
+		},
+	}
+	d.services = make(map[string]Service)
+	d.serviceCancels = make(map[string]context.CancelFunc)
+	d.mu.Unlock()
+	for _, workload := range configuration.Workloads {
+		manager.Add(workload, func(context.Context) error {
+			d.mu.Lock()
+			defer d.mu.Unlock()
+			d.startService(workload, d.config)
+			return nil
+		}, workloadDependencies[workload]...)
+	}
+
+	if err := manager.Start(ctx); err != nil {
+		return err
+	}
+	if err := manager.Wait(ctx); err != nil {
+		log.Logger.Errorw("Agent components failed to start", "error", err)
+		return err
 	}
 
 	log.Logger.Info("Daemon mode startup complete")
+	eventlog.Info(fmt.Sprintf("%s %s started.", configuration.AgentName, configuration.AgentVersion))
 	if !restarting {
 		usagemetrics.Started()
 		go usagemetrics.LogRunningDaily()
@@ -297,13 +509,133 @@ func (d *Daemon) startdaemonHandler(ctx context.Context, restarting bool) error
 	return nil
 }
 
+// newWLMClient creates the Data Warehouse client shared by the workload services.
+func (d *Daemon) newWLMClient(ctx context.Context, gceClient *endpoints.GCE) (workloadmanager.WLMWriter, error) {
+	wlmClient, err := workloadmanager.Client(ctx, d.config)
+	if err != nil {
+		log.Logger.Errorw("Error creating WLM Client", "error", err)
+		usagemetrics.Error(usagemetrics.WorkloadManagerConnectionError)
+		return nil, err
+	}
+	// Pending batches are written on shutdown instead of after their flush interval.
+	var closeBatches func()
+	if batchWriter, ok := wlmClient.(*workloadmanager.BatchWriter); ok {
+		closeBatches = batchWriter.Close
+	}
+	d.mu.Lock()
+	d.closeBatches = closeBatches
+	d.mu.Unlock()
+	// Settings are compared with the baselines declared for change management audits.
+	if d.config.GetConfigDrift().GetEnabled() {
+		wlmClient = workloadmanager.NewConfigDriftWriter(ctx, wlmClient, d.config.GetConfigDrift())
+	}
+	// Data Warehouse rules compare workload settings to the capacity of the host.
+	hostContext := workloadmanager.CollectHostContext(ctx, gceClient, d.cloudProps)
+	wlmClient = workloadmanager.NewHostContextWriter(wlmClient, hostContext)
+	// The data directories reported by the workloads are mapped to their filesystems and disks.
+	wlmClient = workloadmanager.NewDiskLayoutWriter(wlmClient, hostContext)
+	// Their sizes are tracked to report how fast they grow and when their filesystems fill up.
+	wlmClient = workloadmanager.NewDataGrowthWriter(wlmClient, statestore.Default())
+	return workloadmanager.NewInFlightWriter(wlmClient, &d.inFlight), nil
+}
+
+// startService starts the named workload service with config. d.mu must be held.
+func (d *Daemon) startService(workload string, config *cpb.Configuration) {
+	service := d.newService[workload](config)
+	log.Logger.Infof("Starting %s", service.String())
+	ctx, cancel := context.WithCancel(d.serviceCtx)
+	d.serviceCancels[workload] = cancel
+	d.services[workload] = service
+	recoverableStart := &recovery.RecoverableRoutine{
+		Routine:             service.Start,
+		ErrorCode:           service.ErrorCode(),
+		ExpectedMinDuration: service.ExpectedMinDuration(),
+		UsageLogger:         *usagemetrics.UsageLogger,
+		// Services which keep panicking are quarantined instead of being restarted forever.
+		Backoff: restartbudget.New(service.String(), config.GetServiceRecovery()),
+	}
+	recoverableStart.StartRoutine(ctx)
+}
+
+// SetWorkloadEnabled enables or disables a workload in the running agent.
+// Only the workload's service is restarted, the other services keep running.
+// It implements control.Handler.
+func (d *Daemon) SetWorkloadEnabled(ctx context.Context, workload string, enabled bool) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.newService == nil {
+		return fmt.Errorf("the agent is not running workload services")
+	}
+	if _, ok := d.newService[workload]; !ok {
+		return fmt.Errorf("unknown workload %q", workload)
+	}
+	if _, ok := d.services[workload]; !ok {
+		return fmt.Errorf("the %s service has not been started yet", workload)
+	}
+	config := proto.Clone(d.config).(*cpb.Configuration)
+	if err := configuration.SetWorkloadEnabled(config, workload, enabled); err != nil {
+		return err
+	}
+
+	log.CtxLogger(ctx).Infow("Restarting workload service", "workload", workload, "enabled", enabled)
+	d.serviceCancels[workload]()
+	d.config = config
+	d.startService(workload, config)
+
+	// The configure command persists the same change to the config file,
+	// which must not restart all of the services again.
+	if modTime, err := d.lastModifiedTime(); err == nil {
+		d.configModTime = modTime
+	}
+	return nil
+}
+
+// ServiceStatuses returns the states of the agent components and workload services,
+// followed by the routines with a restart budget and how often they panicked.
+// It implements control.Handler.
+func (d *Daemon) ServiceStatuses() []servicemanager.Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.manager == nil {
+		return nil
+	}
+	statuses := d.manager.Statuses()
+	for _, b := range restartbudget.Statuses() {
+		s := servicemanager.Status{Name: b.Service, State: servicemanager.StateRunning, Panics: b.Panics}
+		if b.Quarantined {
+			s.State = servicemanager.StateQuarantined
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// fileAgentProperties returns the agent properties of the configuration file without validating
+// the rest of the configuration. The agent properties loaded previously are returned if the file
+// can't be read or parsed.
+func (d *Daemon) fileAgentProperties() *cpb.AgentProperties {
+	path := d.configFilePath
+	if path == "" {
+		path = configuration.ConfigPath()
+	}
+	config, err := configuration.ConfigFromFile(path, os.ReadFile)
+	if err != nil {
+		return d.config.GetAgentProperties()
+	}
+	return config.GetAgentProperties()
+}
+
 // configureUsageMetricsForDaemon sets up UsageMetrics for Daemon.
-func configureUsageMetricsForDaemon(cp *cpb.CloudProperties) {
+// Usage metrics are logged unless disabled by the configured agent properties.
+func configureUsageMetricsForDaemon(cp *cpb.CloudProperties, ap *cpb.AgentProperties) {
 	usagemetrics.SetAgentProperties(&cpb.AgentProperties{
 		Name:            configuration.AgentName,
 		Version:         configuration.AgentVersion,
-		LogUsageMetrics: true,
+		LogUsageMetrics: proto.Bool(usagemetrics.Enabled(ap)),
 	})
+	if ap.GetAnonymizeUsageMetrics() {
+		cp = usagemetrics.AnonymizeCloudProperties(cp)
+	}
 	usagemetrics.SetCloudProperties(cp)
 }
 
@@ -321,11 +653,36 @@ func (d *Daemon) waitForShutdown(ctx context.Context, restarting bool) {
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	// Wait for the shutdown signal.
 	<-shutdown
+	start := time.Now()
 	log.Logger.Info("Shutdown signal observed, the agent will begin shutting down")
+	eventlog.Info(fmt.Sprintf("%s is shutting down.", configuration.AgentName))
+	// Cancelling the services stops the collection schedules, the collections in progress keep
+	// their own context and are drained.
 	d.cancel()
-	usagemetrics.Stopped()
-	time.Sleep(3 * time.Second)
-	log.Logger.Info("Shutting down...")
+	d.drain()
+	duration := time.Since(start)
+	usagemetrics.StoppedAfter(duration)
+	log.Logger.Infow("Shutting down...", "duration", duration)
+}
+
+// drain waits, for at most shutdownTimeout, for the in-flight metric collections and
+// Data Warehouse writes to finish, and cancels them afterwards.
+// Batching stops first: pending batches are written without waiting for their flush interval and
+// the insights of the collections in progress are written as soon as they are collected.
+func (d *Daemon) drain() {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	d.mu.Lock()
+	closeBatches := d.closeBatches
+	d.mu.Unlock()
+	if closeBatches != nil {
+		go closeBatches()
+	}
+	if err := d.inFlight.Wait(ctx); err != nil {
+		log.Logger.Warnw("In-flight work did not finish before shutdown", "inFlight", d.inFlight.Count(), "timeout", shutdownTimeout)
+		usagemetrics.Error(usagemetrics.ShutdownTimeout)
+		d.inFlight.Abort()
+	}
 }
 
 // restart cancels the current context and invokes the startdaemonHandler once more.
@@ -336,7 +693,11 @@ func (d *Daemon) restart() {
 	time.Sleep(5 * time.Second)
 	var ctx context.Context
 	ctx, d.cancel = context.WithCancel(context.Background())
-	go d.startdaemonHandler(ctx, true)
+	go func() {
+		if err := d.startdaemonHandler(ctx, true); err != nil {
+			eventlog.Error(fmt.Sprintf("The agent failed to restart: %v", err))
+		}
+	}()
 }
 
 func (d *Daemon) startConfigPollerRoutine(ctx context.Context) {
@@ -360,6 +721,9 @@ func (d *Daemon) pollConfigFile() {
 		log.Logger.Errorw("Failed to get last modified time for config file", "error", err)
 		return
 	}
+	d.mu.Lock()
+	d.configModTime = prev
+	d.mu.Unlock()
 	shutdownch := make(chan os.Signal, 1)
 	signal.Notify(shutdownch, syscall.SIGINT, syscall.SIGTERM, os.Interrupt)
 	for {
@@ -374,8 +738,13 @@ func (d *Daemon) pollConfigFile() {
 				log.Logger.Errorw("Failed to get last modified time for config file", "error", err)
 				continue
 			}
-			if res.After(prev) {
-				prev = res
+			d.mu.Lock()
+			changed := res.After(d.configModTime)
+			if changed {
+				d.configModTime = res
+			}
+			d.mu.Unlock()
+			if changed {
 				log.Logger.Infow("Detected config file change", "configFile", d.configFilePath)
 				d.restart()
 			}
@@ -383,6 +752,107 @@ func (d *Daemon) pollConfigFile() {
 	}
 }
 
+// remoteReaders returns the readers of the remote configuration sources.
+// Cloud Storage is read with the credentials set by credentials.SetDefault and the configured endpoint.
+func remoteReaders(config *cpb.Configuration) configuration.RemoteReaders {
+	return configuration.RemoteReaders{
+		ReadGCSObject: func(ctx context.Context, bucket, object string) ([]byte, error) {
+			opts := append(credentials.DefaultOptions(), endpoints.StorageOptions(config.GetEndpoints())...)
+			return configuration.ReadGCSObject(ctx, bucket, object, opts...)
+		},
+		GetSecret: func(ctx context.Context, projectID, secretName string) (string, error) {
+			gceClient, err := endpoints.NewGCEClient(ctx, config.GetEndpoints())
+			if err != nil {
+				return "", err
+			}
+			return gceClient.GetSecret(ctx, projectID, secretName)
+		},
+	}
+}
+
+// applyNetwork applies the proxy and certificate authority settings of config to outbound connections.
+func applyNetwork(config *cpb.Configuration) {
+	if err := outbound.Apply(config.GetNetwork(), os.Setenv, os.ReadFile, outbound.WriteFile); err != nil {
+		log.Logger.Warnw("Unable to apply the network configuration, outbound connections keep the previous settings.", "error", err)
+	}
+}
+
+// mergeRemoteConfiguration merges the remote configuration, if any, over the configuration file.
+// If the remote configuration cannot be fetched or is invalid, the configuration file is used as is.
+// Returns the fetched remote configuration content.
+func (d *Daemon) mergeRemoteConfiguration(ctx context.Context) []byte {
+	rc := d.config.GetRemoteConfiguration()
+	content, err := configuration.FetchRemote(ctx, rc, remoteReaders(d.config))
+	if err != nil {
+		log.Logger.Errorw("Unable to fetch the remote configuration, using the configuration file only.", "error", err)
+		return nil
+	}
+	if content == nil {
+		return nil
+	}
+	merged, err := configuration.MergeRemote(d.config, content)
+	if err != nil {
+		log.Logger.Errorw("Invalid remote configuration, using the configuration file only.", "error", err)
+		return content
+	}
+	log.Logger.Infow("Merged the remote configuration", "gcsURI", rc.GetGcsUri(), "secret", rc.GetSecret().GetSecretName())
+	d.config = merged
+	return content
+}
+
+func (d *Daemon) startRemoteConfigPollerRoutine(ctx context.Context, rc *cpb.RemoteConfiguration, content []byte) {
+	readers := remoteReaders(d.config)
+	pollRemoteConfigRoutine := &recovery.RecoverableRoutine{
+		Routine: func(ctx context.Context, _ any) {
+			d.pollRemoteConfig(ctx, rc, readers, content)
+		},
+		UsageLogger:         *usagemetrics.UsageLogger,
+		ExpectedMinDuration: configuration.RemoteRefreshInterval(rc),
+	}
+	pollRemoteConfigRoutine.StartRoutine(ctx)
+}
+
+// pollRemoteConfig periodically fetches the remote configuration.
+// If its content has changed, the daemon is restarted to pick up the new config.
+func (d *Daemon) pollRemoteConfig(ctx context.Context, rc *cpb.RemoteConfiguration, readers configuration.RemoteReaders, prev []byte) {
+	ticker := time.NewTicker(configuration.RemoteRefreshInterval(rc))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Logger.Info("Context cancelled, exiting the remote config poller")
+			return
+		case <-ticker.C:
+			log.Logger.Debug("Polling remote config")
+			content, err := configuration.FetchRemote(ctx, rc, readers)
+			if err != nil {
+				log.Logger.Warnw("Failed to fetch the remote configuration", "error", err)
+				continue
+			}
+			if !bytes.Equal(content, prev) {
+				log.Logger.Infow("Detected remote config change", "gcsURI", rc.GetGcsUri(), "secret", rc.GetSecret().GetSecretName())
+				d.restart()
+				return
+			}
+		}
+	}
+}
+
+// metadataAttributes returns the instance metadata attributes, which may override configuration fields.
+func metadataAttributes() map[string]string {
+	attributes := make(map[string]string)
+	// An empty key returns all attributes as a JSON object.
+	content := metadataserver.InstanceAttributeWithRetry(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), 2), "")
+	if content == "" {
+		return attributes
+	}
+	if err := json.Unmarshal([]byte(content), &attributes); err != nil {
+		log.Logger.Warnw("Unable to parse instance metadata attributes, configuration overrides from metadata are ignored", "error", err)
+	}
+	return attributes
+}
+
+// lastModifiedTime returns the most recent modification time of the config file and the OS policy configuration fragments.
 func (d *Daemon) lastModifiedTime() (time.Time, error) {
 	path := d.configFilePath
 	if len(path) == 0 {
@@ -392,9 +862,24 @@ func (d *Daemon) lastModifiedTime() (time.Time, error) {
 	if err != nil {
 		return time.Time{}, err
 	}
+	policyModTime, err := configuration.PolicyModTime(configuration.PolicyDir())
+	if err != nil {
+		return time.Time{}, err
+	}
+	if policyModTime.After(res.ModTime()) {
+		return policyModTime, nil
+	}
 	return res.ModTime(), nil
 }
 
+// metadataReachable returns an error if the cloud properties can't be read from the metadata server.
+func metadataReachable(ctx context.Context) error {
+	if metadataserver.ReadCloudPropertiesWithRetry(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Second), 2)) == nil {
+		return errors.New("could not read the cloud properties from the metadata server")
+	}
+	return nil
+}
+
 func logDefaultCredentials(ctx context.Context, cloudProps *cpb.CloudProperties) {
 	credsPath := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
 	if credsPath != "" {
@@ -19,17 +19,21 @@ package mongodb
 
 import (
 	"context"
-	"strings"
+	"errors"
 	"time"
 
 	"go.uber.org/zap/zapcore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/mongodbdiscovery"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/metricexport"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/mongodbmetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/schedule"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
 
@@ -50,9 +54,12 @@ type Service struct {
 	CommonCh         <-chan *servicecommunication.Message
 	processes        servicecommunication.DiscoveryResult
 	mongodbProcesses []servicecommunication.ProcessWrapper
+	presence         servicecommunication.Presence
 	dwActivated      bool
 	WLMClient        workloadmanager.WLMWriter
 	DBcenterClient   databasecenter.Client
+	Exporter         *metricexport.Exporter
+	InFlight         *workloadmanager.InFlight
 }
 
 type runDiscoveryArgs struct {
@@ -105,6 +112,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.MongoDBDiscoveryFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("MongoDBDiscovery", s.Config.GetServiceRecovery()),
 	}
 	discoveryRoutine.StartRoutine(dCtx)
 
@@ -116,6 +124,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.MongoDBMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("MongoDBMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	metricCollectionRoutine.StartRoutine(mcCtx)
 	select {
@@ -178,7 +187,7 @@ func runMetricCollection(ctx context.Context, a any) {
 	metricCollectionFrequency := metricCollectionFrequency(args)
 	ticker := time.NewTicker(metricCollectionFrequency)
 	defer ticker.Stop()
-	gceService, err := gce.NewGCEClient(ctx)
+	gceService, err := endpoints.NewGCEClient(ctx, args.s.Config.GetEndpoints())
 	if err != nil {
 		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
 		log.CtxLogger(ctx).Errorf("Error while initializing GCE services: %w", err)
@@ -189,18 +198,38 @@ func runMetricCollection(ctx context.Context, a any) {
 	err = m.InitDB(ctx, gceService, 30*time.Second)
 	if err != nil {
 		log.CtxLogger(ctx).Errorf("Failed to initialize MongoDB DB: %w", err)
+		usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.DatabaseConnectionFailure))
 		return
 	}
-	for {
-		_, err := m.CollectMetricsOnce(ctx, args.s.dwActivated)
-		if err != nil {
+	if !schedule.WaitStart(ctx, ticker, args.s.Config.GetCollectionSchedule(), metricCollectionFrequency) {
+		return
+	}
+	collect := func() {
+		// The collection finishes with its own context when the agent shuts down.
+		ctx, done := args.s.InFlight.StartWork(ctx)
+		defer done()
+		metrics, err := m.CollectMetricsOnce(ctx, args.s.dwActivated)
+		if errors.Is(err, workloadmanager.ErrCircuitOpen) {
+			// The rejection was already reported when Data Warehouse writes were paused.
+			log.CtxLogger(ctx).Debugw("MongoDB metrics were not sent to Data Warehouse", "error", err)
+		} else if err != nil {
 			log.CtxLogger(ctx).Debugf("failed to collect MongoDB metrics: %v", err)
+			usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.MongoDBMetricCollectionFailure))
+		} else if metrics.Failed() {
+			log.CtxLogger(ctx).Debugw("Collected MongoDB metrics with failures", "errors", metrics.Errors)
+		}
+		if err := args.s.Exporter.Export(ctx, metrics, mongodbmetrics.MetricSchema()); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to export MongoDB metrics to Cloud Monitoring", "error", err)
 		}
+	}
+	for {
+		collect()
 		select {
 		case <-ctx.Done():
 			log.CtxLogger(ctx).Info("MongoDB metric collection cancellation requested")
 			return
 		case <-ticker.C:
+			schedule.Jitter(ticker, args.s.Config.GetCollectionSchedule(), metricCollectionFrequency)
 			continue
 		}
 	}
@@ -220,8 +249,12 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 		log.CtxLogger(ctx).Debugw("MongoDB workload agent service received a message on the common channel", "message", msg)
 		switch msg.Origin {
 		case servicecommunication.Discovery:
-			s.processes = msg.DiscoveryResult
-			s.identifyMongoDBProcesses(ctx)
+			// Heartbeats carry no processes, the processes of the last full result still apply.
+			if !msg.DiscoveryResult.Unchanged {
+				s.processes = msg.DiscoveryResult
+				s.identifyMongoDBProcesses(ctx)
+			}
+			s.presence.Observe(ctx, "MongoDB", len(s.mongodbProcesses) > 0)
 		case servicecommunication.DWActivation:
 			s.dwActivated = msg.DWActivationResult.Activated
 		default:
@@ -231,18 +264,20 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 }
 
 func (s *Service) identifyMongoDBProcesses(ctx context.Context) {
+	signatures := servicecommunication.RegistryFromConfig(ctx, s.Config)
 	s.mongodbProcesses = []servicecommunication.ProcessWrapper{}
 	for _, process := range s.processes.Processes {
-		name, err := process.Name()
-		if err == nil && strings.Contains(name, "mongod") {
+		if signatures.Matches(servicecommunication.MongoDB, process) {
 			s.mongodbProcesses = append(s.mongodbProcesses, process)
 		}
 	}
 	s.logMongoDBProcesses(ctx, zapcore.DebugLevel)
 }
 
+// isWorkloadPresent returns whether MongoDB processes were discovered, or were discovered
+// recently enough for their absence to be a restart rather than the workload being gone.
 func (s *Service) isWorkloadPresent() bool {
-	return len(s.mongodbProcesses) > 0
+	return len(s.mongodbProcesses) > 0 || s.presence.Present()
 }
 
 func (s *Service) logMongoDBProcesses(ctx context.Context, loglevel zapcore.Level) {
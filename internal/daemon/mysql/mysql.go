@@ -19,16 +19,23 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/healthprobe"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/mysqldiscovery"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/metricexport"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/mysqlmetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/schedule"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
 
@@ -44,7 +51,7 @@ const (
 )
 
 var (
-	newGCEClient    = gce.NewGCEClient
+	newGCEClient    = endpoints.NewGCEClient
 	newMySQLMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mysqlmetrics.New(ctx, config, wlmClient, dbcenterClient)
 	}
@@ -55,7 +62,9 @@ var (
 type MetricsInterface interface {
 	InitDB(ctx context.Context, gceService mysqlmetrics.GceInterface) error
 	CollectWlmMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error)
+	CollectConfigFileMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error)
 	CollectDBCenterMetricsOnce(ctx context.Context) error
+	VerifyPrivileges(ctx context.Context) error
 }
 
 // Service implements the interfaces for MySQL workload agent service.
@@ -65,9 +74,12 @@ type Service struct {
 	CommonCh       <-chan *servicecommunication.Message
 	processes      servicecommunication.DiscoveryResult
 	mySQLProcesses []servicecommunication.ProcessWrapper
+	presence       servicecommunication.Presence
 	dwActivated    bool
 	WLMClient      workloadmanager.WLMWriter
 	DBcenterClient databasecenter.Client
+	Exporter       *metricexport.Exporter
+	InFlight       *workloadmanager.InFlight
 }
 
 type runDiscoveryArgs struct {
@@ -82,6 +94,15 @@ type runDBCenterMetricCollectionArgs struct {
 	s *Service
 }
 
+type runHealthProbesArgs struct {
+	s *Service
+}
+
+// healthProbeTarget is implemented by the instances which can be checked by the health probes.
+type healthProbeTarget interface {
+	HealthProbeTarget() healthprobe.Target
+}
+
 // Start initiates the MySQL workload agent service
 func (s *Service) Start(ctx context.Context, a any) {
 	if s.Config.GetMysqlConfiguration() != nil && !s.Config.GetMysqlConfiguration().GetEnabled() {
@@ -121,6 +142,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.MySQLDiscoveryFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("MySQLDiscovery", s.Config.GetServiceRecovery()),
 	}
 	discoveryRoutine.StartRoutine(dCtx)
 
@@ -132,6 +154,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.MySQLMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("MySQLWlmMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	wlmMetricCollectionRoutine.StartRoutine(mcCtx)
 
@@ -143,8 +166,22 @@ EnableCheck:
 		ErrorCode:           usagemetrics.MySQLMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("MySQLDBCenterMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	dbcenterMetricCollectionRoutine.StartRoutine(dbcenterMCCtx)
+
+	if s.Config.GetHealthProbes().GetEnabled() {
+		healthProbeCtx := log.SetCtx(ctx, "context", "MySQLHealthProbes")
+		healthProbeRoutine := &recovery.RecoverableRoutine{
+			Routine:             runHealthProbes,
+			RoutineArg:          runHealthProbesArgs{s},
+			ErrorCode:           usagemetrics.MySQLMetricCollectionFailure,
+			UsageLogger:         *usagemetrics.UsageLogger,
+			ExpectedMinDuration: 20 * time.Second,
+			Backoff:             restartbudget.New("MySQLHealthProbes", s.Config.GetServiceRecovery()),
+		}
+		healthProbeRoutine.StartRoutine(healthProbeCtx)
+	}
 	select {
 	case <-ctx.Done():
 		log.CtxLogger(ctx).Info("MySQL workload agent service cancellation requested")
@@ -174,6 +211,45 @@ func runDiscovery(ctx context.Context, a any) {
 	}
 }
 
+// instanceConfigs returns a configuration for each MySQL instance metrics are collected from.
+// Without configured instances, metrics are collected from the instance in connection_parameters.
+func instanceConfigs(config *configpb.Configuration) []*configpb.Configuration {
+	instances := config.GetMysqlConfiguration().GetInstances()
+	if len(instances) == 0 {
+		return []*configpb.Configuration{config}
+	}
+	configs := make([]*configpb.Configuration, 0, len(instances))
+	for _, cp := range instances {
+		c := proto.Clone(config).(*configpb.Configuration)
+		c.MysqlConfiguration.ConnectionParameters = cp
+		configs = append(configs, c)
+	}
+	return configs
+}
+
+// initInstances connects to each MySQL instance metrics are collected from.
+// Instances which can't be connected to are returned as disconnected, missing privileges are reported up front.
+func initInstances(ctx context.Context, s *Service, gceService mysqlmetrics.GceInterface) (instances, disconnected []MetricsInterface) {
+	for _, config := range instanceConfigs(s.Config) {
+		m := newMySQLMetrics(ctx, config, s.WLMClient, s.DBcenterClient)
+		if err := m.InitDB(ctx, gceService); err != nil {
+			cp := config.GetMysqlConfiguration().GetConnectionParameters()
+			log.CtxLogger(ctx).Errorw("failed to initialize MySQL DB", "host", cp.GetHost(), "port", cp.GetPort(), "error", err)
+			usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.DatabaseConnectionFailure))
+			disconnected = append(disconnected, m)
+			continue
+		}
+		// Missing privileges only disable some of the metrics, so the instance is kept.
+		if err := m.VerifyPrivileges(ctx); err != nil {
+			cp := config.GetMysqlConfiguration().GetConnectionParameters()
+			log.CtxLogger(ctx).Errorw("MySQL user is missing privileges, some metrics will not be collected", "host", cp.GetHost(), "port", cp.GetPort(), "error", err)
+			usagemetrics.Error(usagemetrics.MissingPrivileges)
+		}
+		instances = append(instances, m)
+	}
+	return instances, disconnected
+}
+
 func getDbCenterMetricCollectionFrequency(args runDBCenterMetricCollectionArgs) time.Duration {
 	metricCollectionFrequencyDefault := dbCenterMetricCollectionFrequencyDefault
 	if args.s == nil || args.s.Config == nil {
@@ -204,22 +280,23 @@ func runDBCenterMetricCollection(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Debugw("MySQL dbcenter metric collection args", "args", args)
 	ticker := newTicker(getDbCenterMetricCollectionFrequency(args))
 	defer ticker.Stop()
-	gceService, err := newGCEClient(ctx)
+	gceService, err := newGCEClient(ctx, args.s.Config.GetEndpoints())
 	if err != nil {
 		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
 		log.CtxLogger(ctx).Errorf("initializing GCE services: %w", err)
 		return
 	}
-	m := newMySQLMetrics(ctx, args.s.Config, args.s.WLMClient, args.s.DBcenterClient)
-	err = m.InitDB(ctx, gceService)
-	if err != nil {
-		log.CtxLogger(ctx).Errorf("failed to initialize MySQL DB: %v", err)
+	instances, _ := initInstances(ctx, args.s, gceService)
+	if len(instances) == 0 {
+		log.CtxLogger(ctx).Error("failed to initialize MySQL DB")
 		return
 	}
 	for {
-		err := m.CollectDBCenterMetricsOnce(ctx)
-		if err != nil {
-			log.CtxLogger(ctx).Debugf("failed to collect MySQL metrics: %v", err)
+		for _, m := range instances {
+			err := m.CollectDBCenterMetricsOnce(ctx)
+			if err != nil {
+				log.CtxLogger(ctx).Debugf("failed to collect MySQL metrics: %v", err)
+			}
 		}
 		select {
 		case <-ctx.Done():
@@ -231,6 +308,39 @@ func runDBCenterMetricCollection(ctx context.Context, a any) {
 	}
 }
 
+// runHealthProbes checks the availability of every instance metrics are collected from,
+// including the instances the agent could not connect to when it started.
+func runHealthProbes(ctx context.Context, a any) {
+	log.CtxLogger(ctx).Info("Starting MySQL health probes")
+	var args runHealthProbesArgs
+	var ok bool
+	if args, ok = a.(runHealthProbesArgs); !ok {
+		log.CtxLogger(ctx).Errorw("failed to parse health probe args", "args", a)
+		return
+	}
+	gceService, err := newGCEClient(ctx, args.s.Config.GetEndpoints())
+	if err != nil {
+		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
+		log.CtxLogger(ctx).Errorf("initializing GCE services: %w", err)
+		return
+	}
+	instances, disconnected := initInstances(ctx, args.s, gceService)
+	var targets []healthprobe.Target
+	for _, m := range append(instances, disconnected...) {
+		if t, ok := m.(healthProbeTarget); ok {
+			targets = append(targets, t.HealthProbeTarget())
+		}
+	}
+	if len(targets) == 0 {
+		log.CtxLogger(ctx).Error("no MySQL instances to probe")
+		return
+	}
+	if args.s.Exporter == nil {
+		log.CtxLogger(ctx).Warn("Cloud Monitoring export is disabled, MySQL health probe results are only logged")
+	}
+	healthprobe.Run(ctx, args.s.Config.GetHealthProbes(), targets, args.s.Exporter)
+}
+
 func runWlmMetricCollection(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Info("Starting MySQL Metric Collection")
 	var args runWlmMetricCollectionArgs
@@ -243,28 +353,52 @@ func runWlmMetricCollection(ctx context.Context, a any) {
 
 	ticker := newTicker(wlmMetricCollectionFrequencyDefault)
 	defer ticker.Stop()
-	gceService, err := newGCEClient(ctx)
+	gceService, err := newGCEClient(ctx, args.s.Config.GetEndpoints())
 	if err != nil {
 		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
 		log.CtxLogger(ctx).Errorf("initializing GCE services: %w", err)
 		return
 	}
-	m := newMySQLMetrics(ctx, args.s.Config, args.s.WLMClient, args.s.DBcenterClient)
-	err = m.InitDB(ctx, gceService)
-	if err != nil {
-		log.CtxLogger(ctx).Errorf("failed to initialize MySQL DB: %v", err)
+	instances, disconnected := initInstances(ctx, args.s, gceService)
+	if len(instances) == 0 && len(disconnected) == 0 {
+		log.CtxLogger(ctx).Error("failed to initialize MySQL DB")
 		return
 	}
-	for {
-		_, err := m.CollectWlmMetricsOnce(ctx, args.s.dwActivated)
-		if err != nil {
+	if !schedule.WaitStart(ctx, ticker, args.s.Config.GetCollectionSchedule(), wlmMetricCollectionFrequencyDefault) {
+		return
+	}
+	collect := func(collectOnce func(context.Context, bool) (*workloadmanager.WorkloadMetrics, error)) {
+		// The collection finishes with its own context when the agent shuts down.
+		ctx, done := args.s.InFlight.StartWork(ctx)
+		defer done()
+		metrics, err := collectOnce(ctx, args.s.dwActivated)
+		if errors.Is(err, workloadmanager.ErrCircuitOpen) {
+			// The rejection was already reported when Data Warehouse writes were paused.
+			log.CtxLogger(ctx).Debugw("MySQL metrics were not sent to Data Warehouse", "error", err)
+		} else if err != nil {
 			log.CtxLogger(ctx).Debugf("failed to collect MySQL metrics: %v", err)
+			usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.MySQLMetricCollectionFailure))
+		} else if metrics.Failed() {
+			log.CtxLogger(ctx).Debugw("Collected MySQL metrics with failures", "errors", metrics.Errors)
+		}
+		if err := args.s.Exporter.Export(ctx, metrics, mysqlmetrics.MetricSchema()); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to export MySQL metrics to Cloud Monitoring", "error", err)
+		}
+	}
+	for {
+		for _, m := range instances {
+			collect(m.CollectWlmMetricsOnce)
+		}
+		// The settings of instances the agent could not connect to are read from their configuration files.
+		for _, m := range disconnected {
+			collect(m.CollectConfigFileMetricsOnce)
 		}
 		select {
 		case <-ctx.Done():
 			log.CtxLogger(ctx).Info("MySQL metric collection cancellation requested")
 			return
 		case <-ticker.C:
+			schedule.Jitter(ticker, args.s.Config.GetCollectionSchedule(), wlmMetricCollectionFrequencyDefault)
 			continue
 		}
 	}
@@ -284,8 +418,12 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 		log.CtxLogger(ctx).Debugw("MySQL workload agent service received a message on the common channel", "message", msg)
 		switch msg.Origin {
 		case servicecommunication.Discovery:
-			s.processes = msg.DiscoveryResult
-			s.identifyMySQLProcesses(ctx)
+			// Heartbeats carry no processes, the processes of the last full result still apply.
+			if !msg.DiscoveryResult.Unchanged {
+				s.processes = msg.DiscoveryResult
+				s.identifyMySQLProcesses(ctx)
+			}
+			s.presence.Observe(ctx, "MySQL", len(s.mySQLProcesses) > 0)
 		case servicecommunication.DWActivation:
 			s.dwActivated = msg.DWActivationResult.Activated
 		default:
@@ -295,18 +433,23 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 }
 
 func (s *Service) identifyMySQLProcesses(ctx context.Context) {
+	signatures := servicecommunication.RegistryFromConfig(ctx, s.Config)
 	s.mySQLProcesses = []servicecommunication.ProcessWrapper{}
 	for _, process := range s.processes.Processes {
-		name, err := process.Name()
-		if err == nil && name == "mysqld" {
+		if signatures.Matches(servicecommunication.MySQL, process) {
 			s.mySQLProcesses = append(s.mySQLProcesses, process)
 		}
 	}
 	s.logMySQLProcesses(ctx, zapcore.DebugLevel)
+	if s.Config.GetMysqlConfiguration().GetProxysql() == nil && len(signatures.Filter(servicecommunication.ProxySQL, s.processes.Processes)) > 0 {
+		log.CtxLogger(ctx).Debugw("ProxySQL is running on this host, configure mysql_configuration.proxysql to report its topology with the MySQL insight")
+	}
 }
 
+// isWorkloadPresent returns whether MySQL processes were discovered, or were discovered
+// recently enough for their absence to be a restart rather than the workload being gone.
 func (s *Service) isWorkloadPresent() bool {
-	return len(s.mySQLProcesses) > 0
+	return len(s.mySQLProcesses) > 0 || s.presence.Present()
 }
 
 func (s *Service) logMySQLProcesses(ctx context.Context, loglevel zapcore.Level) {
@@ -24,14 +24,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jonboulle/clockwork"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/mysqlmetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	pb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
@@ -53,15 +56,20 @@ type fakeMetrics struct {
 	CollectWlmCalled chan bool
 	CollectWlmErr    error
 
+	CollectConfigFileCalled chan bool
+
 	CollectDBCenterCalled chan bool
 	CollectDBCenterErr    error
+
+	VerifyPrivilegesErr error
 }
 
 func newFakeMetrics() *fakeMetrics {
 	return &fakeMetrics{
-		InitDBCalled:          make(chan bool, 1),
-		CollectWlmCalled:      make(chan bool, 5),
-		CollectDBCenterCalled: make(chan bool, 5),
+		InitDBCalled:            make(chan bool, 1),
+		CollectWlmCalled:        make(chan bool, 5),
+		CollectConfigFileCalled: make(chan bool, 5),
+		CollectDBCenterCalled:   make(chan bool, 5),
 	}
 }
 
@@ -81,6 +89,14 @@ func (f *fakeMetrics) CollectWlmMetricsOnce(ctx context.Context, dwActivated boo
 	return nil, f.CollectWlmErr
 }
 
+func (f *fakeMetrics) CollectConfigFileMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
+	select {
+	case f.CollectConfigFileCalled <- true:
+	default:
+	}
+	return nil, nil
+}
+
 func (f *fakeMetrics) CollectDBCenterMetricsOnce(ctx context.Context) error {
 	select {
 	case f.CollectDBCenterCalled <- true:
@@ -89,6 +105,10 @@ func (f *fakeMetrics) CollectDBCenterMetricsOnce(ctx context.Context) error {
 	return f.CollectDBCenterErr
 }
 
+func (f *fakeMetrics) VerifyPrivileges(ctx context.Context) error {
+	return f.VerifyPrivilegesErr
+}
+
 // Username returns the username of the process.
 func (p processStub) Username() (string, error) {
 	return p.username, nil
@@ -208,6 +228,26 @@ func TestIdentifyMySQLProcesses(t *testing.T) {
 					}}},
 			want: 0,
 		},
+		{
+			name: "CustomNamedProcess",
+			s: &Service{
+				Config: &pb.Configuration{
+					CommonDiscovery: &pb.CommonDiscovery{
+						WorkloadSignatures: []*pb.WorkloadSignature{
+							{Workload: servicecommunication.MySQL, ProcessNamePatterns: []string{`^mysqld_custom$`}},
+						},
+					},
+				},
+				processes: servicecommunication.DiscoveryResult{
+					Processes: []servicecommunication.ProcessWrapper{
+						processStub{
+							username: "mysql_user",
+							pid:      1234,
+							name:     "mysqld_custom",
+						},
+					}}},
+			want: 1,
+		},
 		{
 			name: "ZeroProcesses",
 			s:    &Service{processes: servicecommunication.DiscoveryResult{Processes: []servicecommunication.ProcessWrapper{}}},
@@ -296,6 +336,50 @@ func TestCheckServiceCommunication(t *testing.T) {
 	}
 }
 
+func TestCheckServiceCommunicationHeartbeat(t *testing.T) {
+	ch := make(chan *servicecommunication.Message, 1)
+	s := &Service{CommonCh: ch}
+	ch <- &servicecommunication.Message{
+		Origin: servicecommunication.Discovery,
+		DiscoveryResult: servicecommunication.DiscoveryResult{
+			Processes: []servicecommunication.ProcessWrapper{processStub{username: "mysql_user", pid: 1234, name: "mysqld"}},
+		},
+	}
+	s.checkServiceCommunication(context.Background())
+	ch <- &servicecommunication.Message{
+		Origin:          servicecommunication.Discovery,
+		DiscoveryResult: servicecommunication.DiscoveryResult{Unchanged: true},
+	}
+	s.checkServiceCommunication(context.Background())
+	if got := len(s.mySQLProcesses); got != 1 {
+		t.Errorf("checkServiceCommunication() after a heartbeat found %d MySQL processes, want 1", got)
+	}
+}
+
+func TestCheckServiceCommunicationRestart(t *testing.T) {
+	ch := make(chan *servicecommunication.Message, 1)
+	s := &Service{CommonCh: ch}
+	discover := func(processes ...servicecommunication.ProcessWrapper) {
+		ch <- &servicecommunication.Message{
+			Origin:          servicecommunication.Discovery,
+			DiscoveryResult: servicecommunication.DiscoveryResult{Processes: processes},
+		}
+		s.checkServiceCommunication(context.Background())
+	}
+
+	discover(processStub{username: "mysql_user", pid: 1234, name: "mysqld"})
+	discover()
+	if !s.isWorkloadPresent() {
+		t.Errorf("isWorkloadPresent() = false after a single cycle without MySQL processes, want true")
+	}
+	for i := 1; i < servicecommunication.DefaultAbsentCycles; i++ {
+		discover()
+	}
+	if s.isWorkloadPresent() {
+		t.Errorf("isWorkloadPresent() = true after %d cycles without MySQL processes, want false", servicecommunication.DefaultAbsentCycles)
+	}
+}
+
 func TestCheckServiceCommunicationDWActivation(t *testing.T) {
 	cancelledCtx, cancel := context.WithCancel(context.Background())
 	cancel()
@@ -555,7 +639,7 @@ func TestRunDBCenterMetricCollection_Success(t *testing.T) {
 	newMySQLMetrics = func(ctx context.Context, config *pb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mockMetrics
 	}
-	newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
+	newGCEClient = func(ctx context.Context, e *pb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -587,53 +671,53 @@ func TestRunDBCenterMetricCollection_Success(t *testing.T) {
 }
 
 func TestRunDBCenterMetricCollection_InitDBError(t *testing.T) {
-    // Save original functions
-    origNewTicker := newTicker
-    origNewMySQLMetrics := newMySQLMetrics
-    origNewGCEClient := newGCEClient
-
-    // Defer restoration
-    defer func() {
-        newTicker = origNewTicker
-        newMySQLMetrics = origNewMySQLMetrics
-        newGCEClient = origNewGCEClient
-    }()
-    fakeClock := clockwork.NewFakeClock()
-    // Stub functions
-    newTicker = func(d time.Duration) *time.Ticker {
-        return &time.Ticker{C: fakeClock.NewTicker(d).Chan()}
-    }
-
-    mockMetrics := newFakeMetrics()
-    mockMetrics.InitDBErr = errors.New("InitDB error")
-    newMySQLMetrics = func(ctx context.Context, config *pb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
-        return mockMetrics
-    }
-    newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
-
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-    defer cancel()
-
-    done := make(chan struct{})
-    go func() {
-        runDBCenterMetricCollection(ctx, runDBCenterMetricCollectionArgs{s: &Service{Config: &pb.Configuration{}}})
-        close(done)
-    }()
-
-    // Wait for InitDB to be called
-    select {
-    case <-mockMetrics.InitDBCalled:
-    case <-ctx.Done():
-        t.Fatalf("runDBCenterMetricCollection: InitDB not called within timeout: %v", ctx.Err())
-    }
-    // Advance the clock to make sure that CollectDBCenterMetricsOnce is never called.
-    fakeClock.Advance(dbCenterMetricCollectionFrequencyDefault + 1*time.Second)
-    select {
-    case <-mockMetrics.CollectDBCenterCalled:
-        t.Fatalf("runDBCenterMetricCollection: CollectDBCenterMetricsOnce should not be called if InitDB fails")
-    case <-time.After(100 * time.Millisecond):
-        // This is the expected case, CollectDBCenterMetricsOnce should not be called.
-    }
+	// Save original functions
+	origNewTicker := newTicker
+	origNewMySQLMetrics := newMySQLMetrics
+	origNewGCEClient := newGCEClient
+
+	// Defer restoration
+	defer func() {
+		newTicker = origNewTicker
+		newMySQLMetrics = origNewMySQLMetrics
+		newGCEClient = origNewGCEClient
+	}()
+	fakeClock := clockwork.NewFakeClock()
+	// Stub functions
+	newTicker = func(d time.Duration) *time.Ticker {
+		return &time.Ticker{C: fakeClock.NewTicker(d).Chan()}
+	}
+
+	mockMetrics := newFakeMetrics()
+	mockMetrics.InitDBErr = errors.New("InitDB error")
+	newMySQLMetrics = func(ctx context.Context, config *pb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
+		return mockMetrics
+	}
+	newGCEClient = func(ctx context.Context, e *pb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		runDBCenterMetricCollection(ctx, runDBCenterMetricCollectionArgs{s: &Service{Config: &pb.Configuration{}}})
+		close(done)
+	}()
+
+	// Wait for InitDB to be called
+	select {
+	case <-mockMetrics.InitDBCalled:
+	case <-ctx.Done():
+		t.Fatalf("runDBCenterMetricCollection: InitDB not called within timeout: %v", ctx.Err())
+	}
+	// Advance the clock to make sure that CollectDBCenterMetricsOnce is never called.
+	fakeClock.Advance(dbCenterMetricCollectionFrequencyDefault + 1*time.Second)
+	select {
+	case <-mockMetrics.CollectDBCenterCalled:
+		t.Fatalf("runDBCenterMetricCollection: CollectDBCenterMetricsOnce should not be called if InitDB fails")
+	case <-time.After(100 * time.Millisecond):
+		// This is the expected case, CollectDBCenterMetricsOnce should not be called.
+	}
 }
 
 // TestRunWlmMetricCollection_Success tests the happy path where metrics are collected periodically.
@@ -663,12 +747,14 @@ func TestRunWlmMetricCollection_Success(t *testing.T) {
 	newMySQLMetrics = func(ctx context.Context, config *pb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mockMetrics
 	}
-	newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
+	newGCEClient = func(ctx context.Context, e *pb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	go runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: &pb.Configuration{}}})
+	// The first collection is not delayed by a random offset.
+	config := &pb.Configuration{CollectionSchedule: &pb.CollectionSchedule{RandomizeStart: proto.Bool(false)}}
+	go runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: config}})
 
 	// Wait for InitDB to be called
 	select {
@@ -718,14 +804,15 @@ func TestRunWlmMetricCollection_InitDBError(t *testing.T) {
 	newMySQLMetrics = func(ctx context.Context, config *pb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mockMetrics
 	}
-	newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
+	newGCEClient = func(ctx context.Context, e *pb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	done := make(chan struct{})
+	config := &pb.Configuration{CollectionSchedule: &pb.CollectionSchedule{RandomizeStart: proto.Bool(false)}}
 	go func() {
-		runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: &pb.Configuration{}}})
+		runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: config}})
 		close(done)
 	}()
 
@@ -735,6 +822,12 @@ func TestRunWlmMetricCollection_InitDBError(t *testing.T) {
 	case <-ctx.Done():
 		t.Fatalf("runWlmMetricCollection: InitDB not called within timeout: %v", ctx.Err())
 	}
+	// The metrics of an instance which can't be connected to are read from its configuration files.
+	select {
+	case <-mockMetrics.CollectConfigFileCalled:
+	case <-ctx.Done():
+		t.Fatalf("runWlmMetricCollection: CollectConfigFileMetricsOnce not called within timeout: %v", ctx.Err())
+	}
 	// Advance the clock to make sure that CollectWlmMetricsOnce is never called.
 	fakeClock.Advance(wlmMetricCollectionFrequencyDefault + 1*time.Second)
 	select {
@@ -743,4 +836,103 @@ func TestRunWlmMetricCollection_InitDBError(t *testing.T) {
 	case <-time.After(100 * time.Millisecond):
 		// This is the expected case, CollectWlmMetricsOnce should not be called.
 	}
+	cancel()
+	<-done
+}
+
+func TestInitInstancesMissingPrivileges(t *testing.T) {
+	origNew := newMySQLMetrics
+	defer func() { newMySQLMetrics = origNew }()
+	mockMetrics := newFakeMetrics()
+	mockMetrics.VerifyPrivilegesErr = errors.New("missing privileges")
+	newMySQLMetrics = func(ctx context.Context, config *pb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
+		return mockMetrics
+	}
+
+	got, disconnected := initInstances(context.Background(), &Service{Config: &pb.Configuration{}}, nil)
+	if len(got) != 1 {
+		t.Errorf("initInstances() returned %d instances, want 1 as missing privileges do not skip the instance", len(got))
+	}
+	if len(disconnected) != 0 {
+		t.Errorf("initInstances() returned %d disconnected instances, want 0", len(disconnected))
+	}
+}
+
+func TestInitInstancesDisconnected(t *testing.T) {
+	origNew := newMySQLMetrics
+	defer func() { newMySQLMetrics = origNew }()
+	mockMetrics := newFakeMetrics()
+	mockMetrics.InitDBErr = errors.New("connection refused")
+	newMySQLMetrics = func(ctx context.Context, config *pb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
+		return mockMetrics
+	}
+
+	got, disconnected := initInstances(context.Background(), &Service{Config: &pb.Configuration{}}, nil)
+	if len(got) != 0 || len(disconnected) != 1 {
+		t.Errorf("initInstances() returned %d instances and %d disconnected instances, want 0 and 1", len(got), len(disconnected))
+	}
+}
+
+func TestInstanceConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *pb.Configuration
+		want   []*pb.Configuration
+	}{
+		{
+			name: "NoInstances",
+			config: &pb.Configuration{
+				MysqlConfiguration: &pb.MySQLConfiguration{
+					ConnectionParameters: &pb.ConnectionParameters{Username: "user"},
+				},
+			},
+			want: []*pb.Configuration{
+				{
+					MysqlConfiguration: &pb.MySQLConfiguration{
+						ConnectionParameters: &pb.ConnectionParameters{Username: "user"},
+					},
+				},
+			},
+		},
+		{
+			name: "MultipleInstances",
+			config: &pb.Configuration{
+				MysqlConfiguration: &pb.MySQLConfiguration{
+					ConnectionParameters: &pb.ConnectionParameters{Username: "user"},
+					Instances: []*pb.ConnectionParameters{
+						{Username: "user1", Port: 1},
+						{Username: "user2", Host: "10.0.0.2", Port: 2},
+					},
+				},
+			},
+			want: []*pb.Configuration{
+				{
+					MysqlConfiguration: &pb.MySQLConfiguration{
+						ConnectionParameters: &pb.ConnectionParameters{Username: "user1", Port: 1},
+						Instances: []*pb.ConnectionParameters{
+							{Username: "user1", Port: 1},
+							{Username: "user2", Host: "10.0.0.2", Port: 2},
+						},
+					},
+				},
+				{
+					MysqlConfiguration: &pb.MySQLConfiguration{
+						ConnectionParameters: &pb.ConnectionParameters{Username: "user2", Host: "10.0.0.2", Port: 2},
+						Instances: []*pb.ConnectionParameters{
+							{Username: "user1", Port: 1},
+							{Username: "user2", Host: "10.0.0.2", Port: 2},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := instanceConfigs(tc.config)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("instanceConfigs() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
 }
@@ -23,6 +23,8 @@ import (
 
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/openshiftmetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/schedule"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
@@ -43,6 +45,9 @@ type Service struct {
 	CloudProps *configpb.CloudProperties
 	CommonCh   <-chan *servicecommunication.Message
 	WLMClient  workloadmanager.WLMWriter
+	InFlight   *workloadmanager.InFlight
+	// dwActivated is updated by the Data Warehouse activation check, metrics are only sent while activated.
+	dwActivated bool
 }
 
 type runMetricCollectionArgs struct {
@@ -58,6 +63,12 @@ func (s *Service) Start(ctx context.Context, a any) {
 	}
 	log.CtxLogger(ctx).Debug("Starting OpenShift workload agent service")
 
+	go (func() {
+		for ctx.Err() == nil {
+			s.checkServiceCommunication(ctx)
+		}
+	})()
+
 	// Start Openshift Metric Collection
 	mcCtx := log.SetCtx(ctx, "context", "OpenShiftMetricCollection")
 	metricCollectionRoutine := &recovery.RecoverableRoutine{
@@ -66,6 +77,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 		ErrorCode:           usagemetrics.OpenShiftMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: wlmCollectionFrequency,
+		Backoff:             restartbudget.New("OpenShiftMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	metricCollectionRoutine.StartRoutine(mcCtx)
 	select {
@@ -86,13 +98,31 @@ func runMetricCollection(ctx context.Context, a any) {
 	ticker := time.NewTicker(wlmCollectionFrequency)
 	defer ticker.Stop()
 
-	for {
+	wasActivated := true
+	if !schedule.WaitStart(ctx, ticker, args.s.Config.GetCollectionSchedule(), wlmCollectionFrequency) {
+		return
+	}
+	collect := func() {
+		// The collection finishes with its own context when the agent shuts down.
+		ctx, done := args.s.InFlight.StartWork(ctx)
+		defer done()
 		collectMetrics(ctx, args)
+	}
+	for {
+		// Skipped collections are only logged when the activation changes, not on every tick.
+		if activated := args.s.dwActivated; activated != wasActivated {
+			log.CtxLogger(ctx).Infow("OpenShift metrics are only sent while Data Warehouse is activated", "activated", activated)
+			wasActivated = activated
+		}
+		if args.s.dwActivated {
+			collect()
+		}
 		select {
 		case <-ctx.Done():
 			log.CtxLogger(ctx).Debug("OpenShift metric collection cancellation requested")
 			return
 		case <-ticker.C:
+			schedule.Jitter(ticker, args.s.Config.GetCollectionSchedule(), wlmCollectionFrequency)
 			continue
 		}
 	}
@@ -128,6 +158,23 @@ func collectMetrics(ctx context.Context, args runMetricCollectionArgs) {
 	log.CtxLogger(ctx).Debug("Metrics successfully sent to WLM")
 }
 
+// checkServiceCommunication listens to the common channel for messages and processes them.
+func (s *Service) checkServiceCommunication(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case msg := <-s.CommonCh:
+		switch msg.Origin {
+		case servicecommunication.DWActivation:
+			s.dwActivated = msg.DWActivationResult.Activated
+		case servicecommunication.Discovery:
+			// OpenShift metrics are collected from the cluster API, discovery results are not used.
+		default:
+			log.CtxLogger(ctx).Debugw("OpenShift workload agent service received a message with an unexpected origin", "origin", msg.Origin)
+		}
+	}
+}
+
 // String returns the name of the OpenShift service.
 func (s *Service) String() string {
 	return "OpenShift Service"
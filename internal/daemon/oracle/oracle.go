@@ -19,18 +19,22 @@ package oracle
 
 import (
 	"context"
-	"runtime"
 	"sync"
 	"time"
 
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/oraclediscovery"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/oraclemetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/schedule"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
 
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
 )
 
 // Service implements the interfaces for Oracle workload agent service.
@@ -41,7 +45,9 @@ type Service struct {
 	discoveryRoutine        *recovery.RecoverableRoutine
 	currentSIDs             []string
 	CommonCh                <-chan *servicecommunication.Message
-	isProcessPresent        bool
+	WLMClient               workloadmanager.WLMWriter
+	dwActivated             bool
+	presence                servicecommunication.Presence
 	processes               []servicecommunication.ProcessWrapper
 	processesMutex          sync.Mutex
 }
@@ -54,8 +60,6 @@ type runMetricCollectionArgs struct {
 	s *Service
 }
 
-var oraProcessPrefixes = []string{"ora_pmon_", "db_pmon_"}
-
 // Start initiates the Oracle workload agent service
 func (s *Service) Start(ctx context.Context, a any) {
 	go (func() {
@@ -67,7 +71,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 	if s.Config.GetOracleConfiguration().Enabled == nil {
 		log.CtxLogger(ctx).Info("Oracle service enabled field is not set, will check for workload presence to determine if service should be enabled.")
 		// If the workload is present, proceed with starting the service even if it is not enabled.
-		for !s.isProcessPresent {
+		for !s.presence.Present() {
 			time.Sleep(5 * time.Second)
 		}
 		log.CtxLogger(ctx).Info("Oracle workload is present. Starting service.")
@@ -76,7 +80,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 		return
 	}
 
-	if runtime.GOOS != "linux" {
+	if !capabilities.Host().IsLinux() {
 		log.CtxLogger(ctx).Error("Oracle service is only supported on Linux")
 		return
 	}
@@ -89,6 +93,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 			ErrorCode:           usagemetrics.OracleDiscoverDatabaseFailure,
 			UsageLogger:         *usagemetrics.UsageLogger,
 			ExpectedMinDuration: 20 * time.Second,
+			Backoff:             restartbudget.New("OracleDiscovery", s.Config.GetServiceRecovery()),
 		}
 		s.discoveryRoutine.StartRoutine(dCtx)
 	}
@@ -101,6 +106,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 			ErrorCode:           usagemetrics.OracleMetricCollectionFailure,
 			UsageLogger:         *usagemetrics.UsageLogger,
 			ExpectedMinDuration: 20 * time.Second,
+			Backoff:             restartbudget.New("OracleMetricCollection", s.Config.GetServiceRecovery()),
 		}
 		s.metricCollectionRoutine.StartRoutine(mcCtx)
 	}
@@ -121,13 +127,16 @@ func runDiscovery(ctx context.Context, a any) {
 	}
 	s := args.s
 
-	ticker := time.NewTicker(args.s.Config.GetOracleConfiguration().GetOracleDiscovery().GetUpdateFrequency().AsDuration())
+	frequency := args.s.Config.GetOracleConfiguration().GetOracleDiscovery().GetUpdateFrequency().AsDuration()
+	ticker := time.NewTicker(frequency)
 	defer ticker.Stop()
 
-	ds := oraclediscovery.New()
+	ds := oraclediscovery.New(oraclediscovery.WithSignatures(servicecommunication.RegistryFromConfig(ctx, s.Config)))
 
+	if !schedule.WaitStart(ctx, ticker, args.s.Config.GetCollectionSchedule(), frequency) {
+		return
+	}
 	for {
-		// Discovery data is not used yet.
 		s.processesMutex.Lock()
 		processes := s.processes
 		s.processesMutex.Unlock()
@@ -146,22 +155,59 @@ func runDiscovery(ctx context.Context, a any) {
 				continue
 			}
 		}
-		_, err := ds.Discover(ctx, s.CloudProps, processes)
+		discovery, err := ds.Discover(ctx, s.CloudProps, processes)
 		if err != nil {
 			log.CtxLogger(ctx).Errorw("Failed to discover databases", "error", err)
 			return
 		}
+		s.sendDiscoveryInsight(ctx, discovery)
 
 		select {
 		case <-ctx.Done():
 			log.CtxLogger(ctx).Info("Oracle Discovery cancellation requested")
 			return
 		case <-ticker.C:
+			schedule.Jitter(ticker, args.s.Config.GetCollectionSchedule(), frequency)
 			continue
 		}
 	}
 }
 
+// sendDiscoveryInsight sends the listener endpoints, service registrations, TLS configuration,
+// patch levels and init parameters found by the discovery to Data Warehouse.
+func (s *Service) sendDiscoveryInsight(ctx context.Context, discovery *odpb.Discovery) {
+	if discovery == nil {
+		return
+	}
+	if !s.dwActivated || s.WLMClient == nil {
+		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending Oracle discovery details to Data Warehouse")
+		return
+	}
+	details := oraclediscovery.ListenerDetails(discovery)
+	for _, d := range []map[string]string{oraclediscovery.PatchDetails(discovery), oraclediscovery.InitParameterDetails(discovery), oraclediscovery.DataDirectoryDetails(discovery)} {
+		for k, v := range d {
+			details[k] = v
+		}
+	}
+	res, err := workloadmanager.SendDataInsight(ctx, workloadmanager.SendDataInsightParams{
+		WLMetrics: workloadmanager.WorkloadMetrics{
+			WorkloadType: workloadmanager.ORACLE,
+			Metrics:      details,
+		},
+		CloudProps:          s.CloudProps,
+		WLMService:          s.WLMClient,
+		StrictWorkloadTypes: s.Config.GetDataWarehouseStrictWorkloadTypes(),
+	})
+	if err != nil {
+		return
+	}
+	if res == nil {
+		log.CtxLogger(ctx).Warn("SendDataInsight did not return an error but the WriteInsight response is nil")
+		return
+	}
+	log.CtxLogger(ctx).Debugw("WriteInsight response", "StatusCode", res.HTTPStatusCode)
+}
+
 func runMetricCollection(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Info("Running Oracle metric collection")
 	var args runMetricCollectionArgs
@@ -188,6 +234,7 @@ func runMetricCollection(ctx context.Context, a any) {
 		case <-ticker.C:
 			metricCollector.SendHealthMetricsToCloudMonitoring(ctx)
 			metricCollector.SendDefaultMetricsToCloudMonitoring(ctx)
+			metricCollector.SendActivityMetricsToCloudMonitoring(ctx)
 		}
 	}
 }
@@ -207,18 +254,25 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 		switch msg.Origin {
 		case servicecommunication.Discovery:
 			log.CtxLogger(ctx).Debugw("Oracle workload agent service received a discovery message")
+			// Heartbeats carry no processes, the processes of the last full result still apply.
 			s.processesMutex.Lock()
-			s.processes = msg.DiscoveryResult.Processes
+			if !msg.DiscoveryResult.Unchanged {
+				s.processes = msg.DiscoveryResult.Processes
+			}
+			processes := s.processes
 			s.processesMutex.Unlock()
-			for _, p := range msg.DiscoveryResult.Processes {
-				name, err := p.Name()
-				if err == nil && servicecommunication.HasAnyPrefix(name, oraProcessPrefixes) {
-					s.isProcessPresent = true
+			signatures := servicecommunication.RegistryFromConfig(ctx, s.Config)
+			found := false
+			for _, p := range processes {
+				if signatures.Matches(servicecommunication.Oracle, p) {
+					found = true
 					break
 				}
 			}
+			s.presence.Observe(ctx, "Oracle", found)
 		case servicecommunication.DWActivation:
 			log.CtxLogger(ctx).Debugw("Oracle workload agent service received a DW activation message")
+			s.dwActivated = msg.DWActivationResult.Activated
 		default:
 			log.CtxLogger(ctx).Debugw("Oracle workload agent service received a message with an unexpected origin", "origin", msg.Origin)
 		}
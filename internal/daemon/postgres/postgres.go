@@ -19,17 +19,23 @@ package postgres
 
 import (
 	"context"
-	"strings"
+	"errors"
 	"time"
 
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/healthprobe"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/postgresdiscovery"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/metricexport"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/postgresmetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/schedule"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
 
@@ -45,7 +51,7 @@ const (
 )
 
 var (
-	newGCEClient       = gce.NewGCEClient
+	newGCEClient       = endpoints.NewGCEClient
 	newPostgresMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return postgresmetrics.New(ctx, config, wlmClient, dbcenterClient)
 	}
@@ -56,7 +62,9 @@ var (
 type MetricsInterface interface {
 	InitDB(ctx context.Context, gceService postgresmetrics.GceInterface) error
 	CollectWlmMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error)
+	CollectConfigFileMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error)
 	CollectDBCenterMetricsOnce(ctx context.Context) error
+	VerifyPrivileges(ctx context.Context) error
 }
 
 // Service implements the interfaces for Postgres workload agent service.
@@ -66,9 +74,12 @@ type Service struct {
 	CommonCh          <-chan *servicecommunication.Message
 	processes         servicecommunication.DiscoveryResult
 	postgresProcesses []servicecommunication.ProcessWrapper
+	presence          servicecommunication.Presence
 	dwActivated       bool
 	WLMClient         workloadmanager.WLMWriter
 	DBcenterClient    databasecenter.Client
+	Exporter          *metricexport.Exporter
+	InFlight          *workloadmanager.InFlight
 }
 
 type runDiscoveryArgs struct {
@@ -83,6 +94,15 @@ type runDBCenterMetricCollectionArgs struct {
 	s *Service
 }
 
+type runHealthProbesArgs struct {
+	s *Service
+}
+
+// healthProbeTarget is implemented by the instances which can be checked by the health probes.
+type healthProbeTarget interface {
+	HealthProbeTarget() healthprobe.Target
+}
+
 // Start initiates the Postgres workload agent service
 func (s *Service) Start(ctx context.Context, a any) {
 	if s.Config.GetPostgresConfiguration() != nil && !s.Config.GetPostgresConfiguration().GetEnabled() {
@@ -125,6 +145,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.PostgresDiscoveryFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("PostgresDiscovery", s.Config.GetServiceRecovery()),
 	}
 	discoveryRoutine.StartRoutine(dCtx)
 
@@ -136,6 +157,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.PostgresMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("PostgreSQLWlmMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	wlmMetricCollectionRoutine.StartRoutine(wlmMCCtx)
 
@@ -147,8 +169,22 @@ EnableCheck:
 		ErrorCode:           usagemetrics.PostgresMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("PostgreSQLDBCenterMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	dbcenterMetricCollectionRoutine.StartRoutine(dbcenterMCCtx)
+
+	if s.Config.GetHealthProbes().GetEnabled() {
+		healthProbeCtx := log.SetCtx(ctx, "context", "PostgresHealthProbes")
+		healthProbeRoutine := &recovery.RecoverableRoutine{
+			Routine:             runHealthProbes,
+			RoutineArg:          runHealthProbesArgs{s},
+			ErrorCode:           usagemetrics.PostgresMetricCollectionFailure,
+			UsageLogger:         *usagemetrics.UsageLogger,
+			ExpectedMinDuration: 20 * time.Second,
+			Backoff:             restartbudget.New("PostgresHealthProbes", s.Config.GetServiceRecovery()),
+		}
+		healthProbeRoutine.StartRoutine(healthProbeCtx)
+	}
 	select {
 	case <-ctx.Done():
 		log.CtxLogger(ctx).Info("Postgres workload agent service cancellation requested")
@@ -178,6 +214,39 @@ func runDiscovery(ctx context.Context, a any) {
 	}
 }
 
+// runHealthProbes checks the availability of every instance metrics are collected from,
+// including the instances the agent could not connect to when it started.
+func runHealthProbes(ctx context.Context, a any) {
+	log.CtxLogger(ctx).Info("Starting Postgres health probes")
+	var args runHealthProbesArgs
+	var ok bool
+	if args, ok = a.(runHealthProbesArgs); !ok {
+		log.CtxLogger(ctx).Errorw("failed to parse health probe args", "args", a)
+		return
+	}
+	gceService, err := newGCEClient(ctx, args.s.Config.GetEndpoints())
+	if err != nil {
+		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
+		log.CtxLogger(ctx).Errorf("initializing GCE services: %w", err)
+		return
+	}
+	instances, disconnected := initInstances(ctx, args.s, gceService)
+	var targets []healthprobe.Target
+	for _, m := range append(instances, disconnected...) {
+		if t, ok := m.(healthProbeTarget); ok {
+			targets = append(targets, t.HealthProbeTarget())
+		}
+	}
+	if len(targets) == 0 {
+		log.CtxLogger(ctx).Error("no Postgres instances to probe")
+		return
+	}
+	if args.s.Exporter == nil {
+		log.CtxLogger(ctx).Warn("Cloud Monitoring export is disabled, Postgres health probe results are only logged")
+	}
+	healthprobe.Run(ctx, args.s.Config.GetHealthProbes(), targets, args.s.Exporter)
+}
+
 func runWlmMetricCollection(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Info("Starting Postgres WLM Metric Collection")
 	var args runWlmMetricCollectionArgs
@@ -190,31 +259,94 @@ func runWlmMetricCollection(ctx context.Context, a any) {
 
 	ticker := newTicker(wlmMetricCollectionFrequencyDefault)
 	defer ticker.Stop()
-	gceService, err := newGCEClient(ctx)
+	gceService, err := newGCEClient(ctx, args.s.Config.GetEndpoints())
 	if err != nil {
 		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
 		log.CtxLogger(ctx).Errorf("Error while initializing GCE services: %v", err)
 		return
 	}
-	p := newPostgresMetrics(ctx, args.s.Config, args.s.WLMClient, args.s.DBcenterClient)
-	err = p.InitDB(ctx, gceService)
-	if err != nil {
-		log.CtxLogger(ctx).Errorf("Failed to initialize Postgres DB for WLM metrics: %v", err)
+	instances, disconnected := initInstances(ctx, args.s, gceService)
+	if len(instances) == 0 && len(disconnected) == 0 {
+		log.CtxLogger(ctx).Error("Failed to initialize Postgres DB for WLM metrics")
 		return
 	}
-	for {
-		_, err := p.CollectWlmMetricsOnce(ctx, args.s.dwActivated)
-		if err != nil {
+	if !schedule.WaitStart(ctx, ticker, args.s.Config.GetCollectionSchedule(), wlmMetricCollectionFrequencyDefault) {
+		return
+	}
+	collect := func(collectOnce func(context.Context, bool) (*workloadmanager.WorkloadMetrics, error)) {
+		// The collection finishes with its own context when the agent shuts down.
+		ctx, done := args.s.InFlight.StartWork(ctx)
+		defer done()
+		metrics, err := collectOnce(ctx, args.s.dwActivated)
+		if errors.Is(err, workloadmanager.ErrCircuitOpen) {
+			// The rejection was already reported when Data Warehouse writes were paused.
+			log.CtxLogger(ctx).Debugw("Postgres metrics were not sent to Data Warehouse", "error", err)
+		} else if err != nil {
 			log.CtxLogger(ctx).Debugf("Failed to collect Postgres WLM metrics: %v", err)
+			usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.PostgresMetricCollectionFailure))
+		} else if metrics.Failed() {
+			log.CtxLogger(ctx).Debugw("Collected Postgres metrics with failures", "errors", metrics.Errors)
+		}
+		if err := args.s.Exporter.Export(ctx, metrics, postgresmetrics.MetricSchema()); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to export Postgres metrics to Cloud Monitoring", "error", err)
+		}
+	}
+	for {
+		for _, p := range instances {
+			collect(p.CollectWlmMetricsOnce)
+		}
+		// The settings of instances the agent could not connect to are read from their configuration files.
+		for _, p := range disconnected {
+			collect(p.CollectConfigFileMetricsOnce)
 		}
 		select {
 		case <-ctx.Done():
 			log.CtxLogger(ctx).Info("Postgres WLM metric collection cancellation requested")
 			return
 		case <-ticker.C:
+			schedule.Jitter(ticker, args.s.Config.GetCollectionSchedule(), wlmMetricCollectionFrequencyDefault)
+			continue
+		}
+	}
+}
+
+// instanceConfigs returns a configuration for each Postgres instance metrics are collected from.
+// Without configured instances, metrics are collected from the instance in connection_parameters.
+func instanceConfigs(config *configpb.Configuration) []*configpb.Configuration {
+	instances := config.GetPostgresConfiguration().GetInstances()
+	if len(instances) == 0 {
+		return []*configpb.Configuration{config}
+	}
+	configs := make([]*configpb.Configuration, 0, len(instances))
+	for _, cp := range instances {
+		c := proto.Clone(config).(*configpb.Configuration)
+		c.PostgresConfiguration.ConnectionParameters = cp
+		configs = append(configs, c)
+	}
+	return configs
+}
+
+// initInstances connects to each Postgres instance metrics are collected from.
+// Instances which can't be connected to are returned as disconnected, missing privileges are reported up front.
+func initInstances(ctx context.Context, s *Service, gceService postgresmetrics.GceInterface) (instances, disconnected []MetricsInterface) {
+	for _, config := range instanceConfigs(s.Config) {
+		p := newPostgresMetrics(ctx, config, s.WLMClient, s.DBcenterClient)
+		if err := p.InitDB(ctx, gceService); err != nil {
+			cp := config.GetPostgresConfiguration().GetConnectionParameters()
+			log.CtxLogger(ctx).Errorw("Failed to initialize Postgres DB", "host", cp.GetHost(), "port", cp.GetPort(), "error", err)
+			usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.DatabaseConnectionFailure))
+			disconnected = append(disconnected, p)
 			continue
 		}
+		// Missing privileges only disable some of the metrics, so the instance is kept.
+		if err := p.VerifyPrivileges(ctx); err != nil {
+			cp := config.GetPostgresConfiguration().GetConnectionParameters()
+			log.CtxLogger(ctx).Errorw("Postgres user is missing privileges, some metrics will not be collected", "host", cp.GetHost(), "port", cp.GetPort(), "error", err)
+			usagemetrics.Error(usagemetrics.MissingPrivileges)
+		}
+		instances = append(instances, p)
 	}
+	return instances, disconnected
 }
 
 func getDbCenterMetricCollectionFrequency(args runDBCenterMetricCollectionArgs) time.Duration {
@@ -247,22 +379,23 @@ func runDBCenterMetricCollection(ctx context.Context, a any) {
 	metricCollectionFrequency := getDbCenterMetricCollectionFrequency(args)
 	ticker := newTicker(metricCollectionFrequency)
 	defer ticker.Stop()
-	gceService, err := newGCEClient(ctx)
+	gceService, err := newGCEClient(ctx, args.s.Config.GetEndpoints())
 	if err != nil {
 		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
 		log.CtxLogger(ctx).Errorf("Error while initializing GCE services: %v", err)
 		return
 	}
-	p := newPostgresMetrics(ctx, args.s.Config, args.s.WLMClient, args.s.DBcenterClient)
-	err = p.InitDB(ctx, gceService)
-	if err != nil {
-		log.CtxLogger(ctx).Errorf("Failed to initialize Postgres DB for DB Center metrics: %v", err)
+	instances, _ := initInstances(ctx, args.s, gceService)
+	if len(instances) == 0 {
+		log.CtxLogger(ctx).Error("Failed to initialize Postgres DB for DB Center metrics")
 		return
 	}
 	for {
-		err := p.CollectDBCenterMetricsOnce(ctx)
-		if err != nil {
-			log.CtxLogger(ctx).Debugf("Failed to collect Postgres DB Center metrics: %v", err)
+		for _, p := range instances {
+			err := p.CollectDBCenterMetricsOnce(ctx)
+			if err != nil {
+				log.CtxLogger(ctx).Debugf("Failed to collect Postgres DB Center metrics: %v", err)
+			}
 		}
 		select {
 		case <-ctx.Done():
@@ -288,8 +421,12 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 		log.CtxLogger(ctx).Debugw("Postgres workload agent service received a message on the common channel", "message", msg)
 		switch msg.Origin {
 		case servicecommunication.Discovery:
-			s.processes = msg.DiscoveryResult
-			s.identifyPostgresProcesses(ctx)
+			// Heartbeats carry no processes, the processes of the last full result still apply.
+			if !msg.DiscoveryResult.Unchanged {
+				s.processes = msg.DiscoveryResult
+				s.identifyPostgresProcesses(ctx)
+			}
+			s.presence.Observe(ctx, "Postgres", len(s.postgresProcesses) > 0)
 		case servicecommunication.DWActivation:
 			s.dwActivated = msg.DWActivationResult.Activated
 		default:
@@ -299,18 +436,23 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 }
 
 func (s *Service) identifyPostgresProcesses(ctx context.Context) {
+	signatures := servicecommunication.RegistryFromConfig(ctx, s.Config)
 	s.postgresProcesses = []servicecommunication.ProcessWrapper{}
 	for _, process := range s.processes.Processes {
-		name, err := process.Name()
-		if err == nil && strings.Contains(name, "postgres") {
+		if signatures.Matches(servicecommunication.Postgres, process) {
 			s.postgresProcesses = append(s.postgresProcesses, process)
 		}
 	}
 	s.logPostgresProcesses(ctx, zapcore.DebugLevel)
+	if s.Config.GetPostgresConfiguration().GetPgbouncer() == nil && len(signatures.Filter(servicecommunication.PgBouncer, s.processes.Processes)) > 0 {
+		log.CtxLogger(ctx).Debugw("PgBouncer is running on this host, configure postgres_configuration.pgbouncer to report its topology with the Postgres insight")
+	}
 }
 
+// isWorkloadPresent returns whether Postgres processes were discovered, or were discovered
+// recently enough for their absence to be a restart rather than the workload being gone.
 func (s *Service) isWorkloadPresent() bool {
-	return len(s.postgresProcesses) > 0
+	return len(s.postgresProcesses) > 0 || s.presence.Present()
 }
 
 func (s *Service) logPostgresProcesses(ctx context.Context, loglevel zapcore.Level) {
@@ -23,15 +23,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jonboulle/clockwork"
 	"google.golang.org/protobuf/proto"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/postgresmetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 
+	"google.golang.org/protobuf/testing/protocmp"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
@@ -44,15 +46,20 @@ type fakeMetrics struct {
 	CollectWlmCalled chan bool
 	CollectWlmErr    error
 
+	CollectConfigFileCalled chan bool
+
 	CollectDBCenterCalled chan bool
 	CollectDBCenterErr    error
+
+	VerifyPrivilegesErr error
 }
 
 func newFakeMetrics() *fakeMetrics {
 	return &fakeMetrics{
-		InitDBCalled:          make(chan bool, 1),
-		CollectWlmCalled:      make(chan bool, 5),
-		CollectDBCenterCalled: make(chan bool, 5),
+		InitDBCalled:            make(chan bool, 1),
+		CollectWlmCalled:        make(chan bool, 5),
+		CollectConfigFileCalled: make(chan bool, 5),
+		CollectDBCenterCalled:   make(chan bool, 5),
 	}
 }
 
@@ -72,6 +79,14 @@ func (f *fakeMetrics) CollectWlmMetricsOnce(ctx context.Context, dwActivated boo
 	return nil, f.CollectWlmErr
 }
 
+func (f *fakeMetrics) CollectConfigFileMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
+	select {
+	case f.CollectConfigFileCalled <- true:
+	default:
+	}
+	return nil, nil
+}
+
 func (f *fakeMetrics) CollectDBCenterMetricsOnce(ctx context.Context) error {
 	select {
 	case f.CollectDBCenterCalled <- true:
@@ -80,6 +95,10 @@ func (f *fakeMetrics) CollectDBCenterMetricsOnce(ctx context.Context) error {
 	return f.CollectDBCenterErr
 }
 
+func (f *fakeMetrics) VerifyPrivileges(ctx context.Context) error {
+	return f.VerifyPrivilegesErr
+}
+
 // Stub is a no-op test double for psutil.Process.
 type processStub struct {
 	username string
@@ -590,7 +609,7 @@ func TestRunDBCenterMetricCollection_Success(t *testing.T) {
 	newPostgresMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mockMetrics
 	}
-	newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
+	newGCEClient = func(ctx context.Context, e *configpb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -644,7 +663,7 @@ func TestRunDBCenterMetricCollection_InitDBError(t *testing.T) {
 	newPostgresMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mockMetrics
 	}
-	newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
+	newGCEClient = func(ctx context.Context, e *configpb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -694,12 +713,14 @@ func TestRunWlmMetricCollection_Success(t *testing.T) {
 	newPostgresMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mockMetrics
 	}
-	newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
+	newGCEClient = func(ctx context.Context, e *configpb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	go runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: &configpb.Configuration{}}})
+	// The first collection is not delayed by a random offset.
+	config := &configpb.Configuration{CollectionSchedule: &configpb.CollectionSchedule{RandomizeStart: proto.Bool(false)}}
+	go runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: config}})
 
 	// Wait for InitDB to be called
 	select {
@@ -748,14 +769,15 @@ func TestRunWlmMetricCollection_InitDBError(t *testing.T) {
 	newPostgresMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
 		return mockMetrics
 	}
-	newGCEClient = func(ctx context.Context) (*gce.GCE, error) { return nil, nil }
+	newGCEClient = func(ctx context.Context, e *configpb.Endpoints) (*endpoints.GCE, error) { return nil, nil }
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	done := make(chan struct{})
+	config := &configpb.Configuration{CollectionSchedule: &configpb.CollectionSchedule{RandomizeStart: proto.Bool(false)}}
 	go func() {
-		runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: &configpb.Configuration{}}})
+		runWlmMetricCollection(ctx, runWlmMetricCollectionArgs{s: &Service{Config: config}})
 		close(done)
 	}()
 
@@ -765,6 +787,12 @@ func TestRunWlmMetricCollection_InitDBError(t *testing.T) {
 	case <-ctx.Done():
 		t.Fatalf("runWlmMetricCollection: InitDB not called within timeout: %v", ctx.Err())
 	}
+	// The metrics of an instance which can't be connected to are read from its configuration files.
+	select {
+	case <-mockMetrics.CollectConfigFileCalled:
+	case <-ctx.Done():
+		t.Fatalf("runWlmMetricCollection: CollectConfigFileMetricsOnce not called within timeout: %v", ctx.Err())
+	}
 	// Advance the clock to make sure that CollectWlmMetricsOnce is never called.
 	fakeClock.Advance(wlmMetricCollectionFrequencyDefault + 1*time.Second)
 	select {
@@ -773,4 +801,103 @@ func TestRunWlmMetricCollection_InitDBError(t *testing.T) {
 	case <-time.After(100 * time.Millisecond):
 		// This is the expected case, CollectWlmMetricsOnce should not be called.
 	}
+	cancel()
+	<-done
+}
+
+func TestInitInstancesMissingPrivileges(t *testing.T) {
+	origNew := newPostgresMetrics
+	defer func() { newPostgresMetrics = origNew }()
+	mockMetrics := newFakeMetrics()
+	mockMetrics.VerifyPrivilegesErr = errors.New("missing privileges")
+	newPostgresMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
+		return mockMetrics
+	}
+
+	got, disconnected := initInstances(context.Background(), &Service{Config: &configpb.Configuration{}}, nil)
+	if len(got) != 1 {
+		t.Errorf("initInstances() returned %d instances, want 1 as missing privileges do not skip the instance", len(got))
+	}
+	if len(disconnected) != 0 {
+		t.Errorf("initInstances() returned %d disconnected instances, want 0", len(disconnected))
+	}
+}
+
+func TestInitInstancesDisconnected(t *testing.T) {
+	origNew := newPostgresMetrics
+	defer func() { newPostgresMetrics = origNew }()
+	mockMetrics := newFakeMetrics()
+	mockMetrics.InitDBErr = errors.New("connection refused")
+	newPostgresMetrics = func(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) MetricsInterface {
+		return mockMetrics
+	}
+
+	got, disconnected := initInstances(context.Background(), &Service{Config: &configpb.Configuration{}}, nil)
+	if len(got) != 0 || len(disconnected) != 1 {
+		t.Errorf("initInstances() returned %d instances and %d disconnected instances, want 0 and 1", len(got), len(disconnected))
+	}
+}
+
+func TestInstanceConfigs(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *configpb.Configuration
+		want   []*configpb.Configuration
+	}{
+		{
+			name: "NoInstances",
+			config: &configpb.Configuration{
+				PostgresConfiguration: &configpb.PostgresConfiguration{
+					ConnectionParameters: &configpb.ConnectionParameters{Username: "user"},
+				},
+			},
+			want: []*configpb.Configuration{
+				{
+					PostgresConfiguration: &configpb.PostgresConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{Username: "user"},
+					},
+				},
+			},
+		},
+		{
+			name: "MultipleInstances",
+			config: &configpb.Configuration{
+				PostgresConfiguration: &configpb.PostgresConfiguration{
+					ConnectionParameters: &configpb.ConnectionParameters{Username: "user"},
+					Instances: []*configpb.ConnectionParameters{
+						{Username: "user1", Port: 1},
+						{Username: "user2", Host: "10.0.0.2", Port: 2},
+					},
+				},
+			},
+			want: []*configpb.Configuration{
+				{
+					PostgresConfiguration: &configpb.PostgresConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{Username: "user1", Port: 1},
+						Instances: []*configpb.ConnectionParameters{
+							{Username: "user1", Port: 1},
+							{Username: "user2", Host: "10.0.0.2", Port: 2},
+						},
+					},
+				},
+				{
+					PostgresConfiguration: &configpb.PostgresConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{Username: "user2", Host: "10.0.0.2", Port: 2},
+						Instances: []*configpb.ConnectionParameters{
+							{Username: "user1", Port: 1},
+							{Username: "user2", Host: "10.0.0.2", Port: 2},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := instanceConfigs(tc.config)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("instanceConfigs() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
 }
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package preflight checks the prerequisites of the agent before the daemon starts its services.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// RequiredScope is the access scope the agent needs to call Google Cloud APIs.
+const RequiredScope = "https://www.googleapis.com/auth/cloud-platform"
+
+// Check is the outcome of a single preflight check.
+type Check struct {
+	Name string
+	Err  error
+}
+
+// SecretGetter reads secrets from Secret Manager.
+type SecretGetter interface {
+	GetSecret(ctx context.Context, projectID, secretName string) (string, error)
+}
+
+// Params holds the dependencies of the preflight checks.
+type Params struct {
+	Config     *cpb.Configuration
+	CloudProps *cpb.CloudProperties
+	Secrets    SecretGetter
+	// LogPaths are the files the agent writes its logs to.
+	LogPaths []string
+	// MetadataReachable returns an error if the metadata server can't be reached.
+	MetadataReachable func(ctx context.Context) error
+}
+
+// Run runs all preflight checks and returns their outcomes in a stable order.
+func Run(ctx context.Context, p Params) []Check {
+	checks := []Check{
+		{Name: "metadata_server", Err: p.MetadataReachable(ctx)},
+		{Name: "api_scopes", Err: checkScopes(p.Config.GetCredentials(), p.CloudProps)},
+	}
+	for _, ref := range secretRefs(p.Config) {
+		checks = append(checks, Check{
+			Name: fmt.Sprintf("secret:%s/%s", ref.GetProjectId(), ref.GetSecretName()),
+			Err:  checkSecret(ctx, p.Secrets, ref),
+		})
+	}
+	for _, path := range p.LogPaths {
+		if path == "" {
+			continue
+		}
+		checks = append(checks, Check{Name: "log_path:" + path, Err: checkWritable(path)})
+	}
+	return checks
+}
+
+// Report logs a consolidated PASS/FAIL report of the checks and returns true if all of them passed.
+func Report(ctx context.Context, checks []Check) bool {
+	passed := true
+	for _, c := range checks {
+		if c.Err != nil {
+			passed = false
+			log.CtxLogger(ctx).Warnw("Preflight check", "check", c.Name, "result", "FAIL", "error", c.Err)
+			continue
+		}
+		log.CtxLogger(ctx).Infow("Preflight check", "check", c.Name, "result", "PASS")
+	}
+	result := "PASS"
+	if !passed {
+		result = "FAIL"
+	}
+	log.CtxLogger(ctx).Infow("Preflight checks complete", "result", result, "checks", len(checks))
+	return passed
+}
+
+// checkScopes checks the access scopes of the instance. They only limit the credentials of the
+// instance service account, so they are not checked if a credentials file or workload identity
+// federation is configured.
+func checkScopes(creds *cpb.Credentials, cp *cpb.CloudProperties) error {
+	if creds.GetCredentialsFile() != "" || creds.GetWorkloadIdentityFederation() != nil {
+		return nil
+	}
+	if !slices.Contains(cp.GetScopes(), RequiredScope) {
+		return fmt.Errorf("the instance is missing the %s access scope", RequiredScope)
+	}
+	return nil
+}
+
+func checkSecret(ctx context.Context, s SecretGetter, ref *cpb.SecretRef) error {
+	if s == nil {
+		return fmt.Errorf("no Secret Manager client is available")
+	}
+	if _, err := s.GetSecret(ctx, ref.GetProjectId(), ref.GetSecretName()); err != nil {
+		return fmt.Errorf("reading secret %s in project %s: %w", ref.GetSecretName(), ref.GetProjectId(), err)
+	}
+	return nil
+}
+
+// checkWritable opens path for appending without truncating existing content.
+func checkWritable(path string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// secretRefs returns the distinct Secret Manager references in the configuration, sorted by project and name.
+func secretRefs(config *cpb.Configuration) []*cpb.SecretRef {
+	if config == nil {
+		return nil
+	}
+	var refs []*cpb.SecretRef
+	seen := make(map[string]bool)
+	collectSecretRefs(config.ProtoReflect(), func(ref *cpb.SecretRef) {
+		if ref.GetProjectId() == "" || ref.GetSecretName() == "" {
+			return
+		}
+		key := ref.GetProjectId() + "/" + ref.GetSecretName()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		refs = append(refs, ref)
+	})
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].GetProjectId() != refs[j].GetProjectId() {
+			return refs[i].GetProjectId() < refs[j].GetProjectId()
+		}
+		return refs[i].GetSecretName() < refs[j].GetSecretName()
+	})
+	return refs
+}
+
+// collectSecretRefs walks m and calls add for every SecretRef message found.
+func collectSecretRefs(m protoreflect.Message, add func(*cpb.SecretRef)) {
+	if ref, ok := m.Interface().(*cpb.SecretRef); ok {
+		add(ref)
+		return
+	}
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				collectSecretRefs(list.Get(i).Message(), add)
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				collectSecretRefs(mv.Message(), add)
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			collectSecretRefs(v.Message(), add)
+		}
+		return true
+	})
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package preflight
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+type fakeSecrets struct {
+	missing map[string]bool
+}
+
+func (f *fakeSecrets) GetSecret(ctx context.Context, projectID, secretName string) (string, error) {
+	if f.missing[projectID+"/"+secretName] {
+		return "", errors.New("permission denied")
+	}
+	return "secret", nil
+}
+
+func secretConfig() *cpb.Configuration {
+	return &cpb.Configuration{
+		MysqlConfiguration: &cpb.MySQLConfiguration{
+			ConnectionParameters: &cpb.ConnectionParameters{
+				Secret: &cpb.SecretRef{ProjectId: "p1", SecretName: "mysql"},
+			},
+		},
+		PostgresConfiguration: &cpb.PostgresConfiguration{
+			ConnectionParameters: &cpb.ConnectionParameters{
+				Secret: &cpb.SecretRef{ProjectId: "p1", SecretName: "mysql"},
+			},
+		},
+		RedisConfiguration: &cpb.RedisConfiguration{
+			ConnectionParameters: &cpb.ConnectionParameters{
+				Secret: &cpb.SecretRef{ProjectId: "p1", SecretName: "redis"},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	reachable := func(context.Context) error { return nil }
+	tests := []struct {
+		name   string
+		params Params
+		want   []Check
+	}{
+		{
+			name: "AllPass",
+			params: Params{
+				Config:            secretConfig(),
+				CloudProps:        &cpb.CloudProperties{Scopes: []string{RequiredScope}},
+				Secrets:           &fakeSecrets{},
+				LogPaths:          []string{filepath.Join(dir, "agent.log")},
+				MetadataReachable: reachable,
+			},
+			want: []Check{
+				{Name: "metadata_server"},
+				{Name: "api_scopes"},
+				{Name: "secret:p1/mysql"},
+				{Name: "secret:p1/redis"},
+				{Name: "log_path:" + filepath.Join(dir, "agent.log")},
+			},
+		},
+		{
+			name: "AllFail",
+			params: Params{
+				Config:            secretConfig(),
+				CloudProps:        &cpb.CloudProperties{Scopes: []string{"https://www.googleapis.com/auth/compute.readonly"}},
+				Secrets:           &fakeSecrets{missing: map[string]bool{"p1/redis": true}},
+				LogPaths:          []string{filepath.Join(dir, "missing", "agent.log")},
+				MetadataReachable: func(context.Context) error { return errors.New("unreachable") },
+			},
+			want: []Check{
+				{Name: "metadata_server", Err: cmpopts.AnyError},
+				{Name: "api_scopes", Err: cmpopts.AnyError},
+				{Name: "secret:p1/mysql"},
+				{Name: "secret:p1/redis", Err: cmpopts.AnyError},
+				{Name: "log_path:" + filepath.Join(dir, "missing", "agent.log"), Err: cmpopts.AnyError},
+			},
+		},
+		{
+			name: "NoSecretClient",
+			params: Params{
+				Config:            secretConfig(),
+				CloudProps:        &cpb.CloudProperties{Scopes: []string{RequiredScope}},
+				MetadataReachable: reachable,
+			},
+			want: []Check{
+				{Name: "metadata_server"},
+				{Name: "api_scopes"},
+				{Name: "secret:p1/mysql", Err: cmpopts.AnyError},
+				{Name: "secret:p1/redis", Err: cmpopts.AnyError},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Run(context.Background(), tc.params)
+			if diff := cmp.Diff(tc.want, got, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Run() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckScopes(t *testing.T) {
+	missingScope := &cpb.CloudProperties{Scopes: []string{"https://www.googleapis.com/auth/compute.readonly"}}
+	tests := []struct {
+		name       string
+		creds      *cpb.Credentials
+		cloudProps *cpb.CloudProperties
+		wantErr    bool
+	}{
+		{
+			name:       "RequiredScope",
+			cloudProps: &cpb.CloudProperties{Scopes: []string{RequiredScope}},
+		},
+		{
+			name:       "MissingScope",
+			cloudProps: missingScope,
+			wantErr:    true,
+		},
+		{
+			name:       "ImpersonationUsesInstanceCredentials",
+			creds:      &cpb.Credentials{ImpersonateServiceAccount: "agent@project.iam.gserviceaccount.com"},
+			cloudProps: missingScope,
+			wantErr:    true,
+		},
+		{
+			name:       "CredentialsFile",
+			creds:      &cpb.Credentials{CredentialsFile: "/etc/creds.json"},
+			cloudProps: missingScope,
+		},
+		{
+			name:       "WorkloadIdentityFederation",
+			creds:      &cpb.Credentials{WorkloadIdentityFederation: &cpb.WorkloadIdentityFederation{Audience: "audience"}},
+			cloudProps: missingScope,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkScopes(tc.creds, tc.cloudProps)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("checkScopes() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []Check
+		want   bool
+	}{
+		{
+			name:   "NoChecks",
+			checks: nil,
+			want:   true,
+		},
+		{
+			name:   "Passed",
+			checks: []Check{{Name: "metadata_server"}, {Name: "api_scopes"}},
+			want:   true,
+		},
+		{
+			name:   "Failed",
+			checks: []Check{{Name: "metadata_server"}, {Name: "api_scopes", Err: errors.New("missing scope")}},
+			want:   false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Report(context.Background(), tc.checks); got != tc.want {
+				t.Errorf("Report() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSecretRefsSkipsIncompleteReferences(t *testing.T) {
+	config := &cpb.Configuration{
+		MysqlConfiguration: &cpb.MySQLConfiguration{
+			ConnectionParameters: &cpb.ConnectionParameters{
+				Secret: &cpb.SecretRef{SecretName: "mysql"},
+			},
+		},
+	}
+	if got := secretRefs(config); len(got) != 0 {
+		t.Errorf("secretRefs() = %v, want no references", got)
+	}
+}
@@ -19,15 +19,20 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"go.uber.org/zap/zapcore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/redisdiscovery"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/metricexport"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/redismetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/schedule"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/osinfo"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
@@ -47,9 +52,12 @@ type Service struct {
 	CommonCh       <-chan *servicecommunication.Message
 	processes      servicecommunication.DiscoveryResult
 	redisProcesses []servicecommunication.ProcessWrapper
+	presence       servicecommunication.Presence
 	dwActivated    bool
 	WLMClient      workloadmanager.WLMWriter
 	OSData         osinfo.Data
+	Exporter       *metricexport.Exporter
+	InFlight       *workloadmanager.InFlight
 }
 
 type runDiscoveryArgs struct {
@@ -99,6 +107,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.RedisDiscoveryFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("RedisDiscovery", s.Config.GetServiceRecovery()),
 	}
 	discoveryRoutine.StartRoutine(dCtx)
 
@@ -110,6 +119,7 @@ EnableCheck:
 		ErrorCode:           usagemetrics.RedisMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("RedisMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	metricCollectionRoutine.StartRoutine(mcCtx)
 	select {
@@ -150,7 +160,7 @@ func runMetricCollection(ctx context.Context, a any) {
 		return
 	}
 	log.CtxLogger(ctx).Debugw("Redis metric collection args", "args", args)
-	gceService, err := gce.NewGCEClient(ctx)
+	gceService, err := endpoints.NewGCEClient(ctx, args.s.Config.GetEndpoints())
 	if err != nil {
 		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
 		log.CtxLogger(ctx).Errorf("initializing GCE services: %w", err)
@@ -160,20 +170,40 @@ func runMetricCollection(ctx context.Context, a any) {
 	err = r.InitDB(ctx, gceService)
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("failed to initialize Redis DB client", "error", err)
+		usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.DatabaseConnectionFailure))
 		return
 	}
 	ticker := time.NewTicker(wlmCollectionFrequency)
 	defer ticker.Stop()
-	for {
-		_, err := r.CollectMetricsOnce(ctx, args.s.dwActivated)
-		if err != nil {
+	if !schedule.WaitStart(ctx, ticker, args.s.Config.GetCollectionSchedule(), wlmCollectionFrequency) {
+		return
+	}
+	collect := func() {
+		// The collection finishes with its own context when the agent shuts down.
+		ctx, done := args.s.InFlight.StartWork(ctx)
+		defer done()
+		metrics, err := r.CollectMetricsOnce(ctx, args.s.dwActivated)
+		if errors.Is(err, workloadmanager.ErrCircuitOpen) {
+			// The rejection was already reported when Data Warehouse writes were paused.
+			log.CtxLogger(ctx).Debugw("Redis metrics were not sent to Data Warehouse", "error", err)
+		} else if err != nil {
 			log.CtxLogger(ctx).Debugf("failed to collect Redis metrics: %v", err)
+			usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.RedisMetricCollectionFailure))
+		} else if metrics.Failed() {
+			log.CtxLogger(ctx).Debugw("Collected Redis metrics with failures", "errors", metrics.Errors)
+		}
+		if err := args.s.Exporter.Export(ctx, metrics, redismetrics.MetricSchema()); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to export Redis metrics to Cloud Monitoring", "error", err)
 		}
+	}
+	for {
+		collect()
 		select {
 		case <-ctx.Done():
 			log.CtxLogger(ctx).Info("Redis metric collection cancellation requested")
 			return
 		case <-ticker.C:
+			schedule.Jitter(ticker, args.s.Config.GetCollectionSchedule(), wlmCollectionFrequency)
 			continue
 		}
 	}
@@ -193,8 +223,12 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 		log.CtxLogger(ctx).Debugw("Redis workload agent service received a message on the common channel", "message", msg)
 		switch msg.Origin {
 		case servicecommunication.Discovery:
-			s.processes = msg.DiscoveryResult
-			s.identifyRedisProcesses(ctx)
+			// Heartbeats carry no processes, the processes of the last full result still apply.
+			if !msg.DiscoveryResult.Unchanged {
+				s.processes = msg.DiscoveryResult
+				s.identifyRedisProcesses(ctx)
+			}
+			s.presence.Observe(ctx, "Redis", len(s.redisProcesses) > 0)
 		case servicecommunication.DWActivation:
 			s.dwActivated = msg.DWActivationResult.Activated
 		default:
@@ -204,17 +238,20 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 }
 
 func (s *Service) identifyRedisProcesses(ctx context.Context) {
+	signatures := servicecommunication.RegistryFromConfig(ctx, s.Config)
+	s.redisProcesses = []servicecommunication.ProcessWrapper{}
 	for _, process := range s.processes.Processes {
-		name, err := process.Name()
-		if err == nil && name == "redis-server" {
+		if signatures.Matches(servicecommunication.Redis, process) {
 			s.redisProcesses = append(s.redisProcesses, process)
 		}
 	}
 	s.logRedisProcesses(ctx, zapcore.DebugLevel)
 }
 
+// isWorkloadPresent returns whether Redis processes were discovered, or were discovered
+// recently enough for their absence to be a restart rather than the workload being gone.
 func (s *Service) isWorkloadPresent() bool {
-	return len(s.redisProcesses) > 0
+	return len(s.redisProcesses) > 0 || s.presence.Present()
 }
 
 func (s *Service) logRedisProcesses(ctx context.Context, loglevel zapcore.Level) {
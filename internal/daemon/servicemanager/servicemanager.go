@@ -0,0 +1,243 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package servicemanager starts the components of the agent concurrently, each once the
+// components it depends on are running, and tracks their states.
+package servicemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// State is the state of a managed component.
+type State string
+
+const (
+	// StatePending means the component waits for its dependencies.
+	StatePending State = "PENDING"
+	// StateStarting means the component is being started.
+	StateStarting State = "STARTING"
+	// StateRunning means the component started successfully.
+	StateRunning State = "RUNNING"
+	// StateFailed means the component or one of its dependencies failed to start.
+	StateFailed State = "FAILED"
+	// StateQuarantined means the routine panicked too often and is not restarted.
+	StateQuarantined State = "QUARANTINED"
+)
+
+// Status is the state of a managed component as reported on the control socket.
+type Status struct {
+	Name      string   `json:"name"`
+	State     State    `json:"state"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	// Panics is the number of times a routine with a restart budget panicked.
+	Panics int `json:"panics,omitempty"`
+}
+
+// component is a single managed component.
+type component struct {
+	name      string
+	dependsOn []string
+	start     func(context.Context) error
+	// done is closed once the component is running or failed.
+	done  chan struct{}
+	state State
+	err   error
+}
+
+// Manager starts components in dependency order.
+type Manager struct {
+	mu         sync.Mutex
+	components map[string]*component
+	started    bool
+}
+
+// New returns an empty Manager.
+func New() *Manager {
+	return &Manager{components: make(map[string]*component)}
+}
+
+// Add registers a component which is started by start once the components in dependsOn are running.
+// start must not block for the lifetime of the component, long running work belongs in a goroutine.
+func (m *Manager) Add(name string, start func(context.Context) error, dependsOn ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started {
+		return fmt.Errorf("cannot add component %q after the manager started", name)
+	}
+	if _, ok := m.components[name]; ok {
+		return fmt.Errorf("component %q is already registered", name)
+	}
+	m.components[name] = &component{
+		name:      name,
+		dependsOn: dependsOn,
+		start:     start,
+		done:      make(chan struct{}),
+		state:     StatePending,
+	}
+	return nil
+}
+
+// Start validates the dependencies and starts all components concurrently.
+// It returns without waiting for the components, use Wait for that.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started {
+		return errors.New("the manager already started")
+	}
+	if err := m.validate(); err != nil {
+		return err
+	}
+	m.started = true
+	for _, c := range m.components {
+		go m.run(ctx, c)
+	}
+	return nil
+}
+
+// Wait blocks until every component is running or failed, or ctx is done.
+// It returns the errors of the failed components.
+func (m *Manager) Wait(ctx context.Context) error {
+	m.mu.Lock()
+	components := make([]*component, 0, len(m.components))
+	for _, name := range m.names() {
+		components = append(components, m.components[name])
+	}
+	m.mu.Unlock()
+
+	var errs []error
+	for _, c := range components {
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		m.mu.Lock()
+		if c.state == StateFailed {
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, c.err))
+		}
+		m.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// Statuses returns the state of every component, sorted by name.
+func (m *Manager) Statuses() []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	statuses := make([]Status, 0, len(m.components))
+	for _, name := range m.names() {
+		c := m.components[name]
+		s := Status{Name: c.name, State: c.state, DependsOn: c.dependsOn}
+		if c.err != nil {
+			s.Error = c.err.Error()
+		}
+		statuses = append(statuses, s)
+	}
+	return statuses
+}
+
+// run starts c once its dependencies are running. A component is failed without being
+// started if one of its dependencies failed.
+func (m *Manager) run(ctx context.Context, c *component) {
+	defer close(c.done)
+	for _, name := range c.dependsOn {
+		dep := m.components[name]
+		select {
+		case <-dep.done:
+		case <-ctx.Done():
+			m.setState(c, StateFailed, ctx.Err())
+			return
+		}
+		m.mu.Lock()
+		failed := dep.state == StateFailed
+		m.mu.Unlock()
+		if failed {
+			log.CtxLogger(ctx).Warnw("Not starting component because a dependency failed", "component", c.name, "dependency", name)
+			m.setState(c, StateFailed, fmt.Errorf("dependency %q failed", name))
+			return
+		}
+	}
+
+	m.setState(c, StateStarting, nil)
+	log.CtxLogger(ctx).Debugw("Starting component", "component", c.name)
+	if err := c.start(ctx); err != nil {
+		log.CtxLogger(ctx).Errorw("Component failed to start", "component", c.name, "error", err)
+		m.setState(c, StateFailed, err)
+		return
+	}
+	m.setState(c, StateRunning, nil)
+}
+
+func (m *Manager) setState(c *component, state State, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c.state = state
+	c.err = err
+}
+
+// validate returns an error for dependencies which are not registered or cyclic. m.mu must be held.
+func (m *Manager) validate() error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	marks := make(map[string]int)
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle: %v", append(path, name))
+		case visited:
+			return nil
+		}
+		marks[name] = visiting
+		for _, dep := range m.components[name].dependsOn {
+			if _, ok := m.components[dep]; !ok {
+				return fmt.Errorf("component %q depends on unknown component %q", name, dep)
+			}
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		marks[name] = visited
+		return nil
+	}
+	for _, name := range m.names() {
+		if err := visit(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// names returns the sorted component names. m.mu must be held.
+func (m *Manager) names() []string {
+	names := make([]string, 0, len(m.components))
+	for name := range m.components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicemanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type registration struct {
+	name      string
+	err       error
+	dependsOn []string
+}
+
+func TestManager(t *testing.T) {
+	tests := []struct {
+		name          string
+		registrations []registration
+		// wantBefore lists dependencies which must have started before a component.
+		wantBefore   map[string][]string
+		wantStatuses []Status
+		wantErr      bool
+	}{
+		{
+			name: "DependenciesStartFirst",
+			registrations: []registration{
+				{name: "mysql", dependsOn: []string{"discovery", "wlmclient"}},
+				{name: "discovery"},
+				{name: "wlmclient"},
+				{name: "activation", dependsOn: []string{"wlmclient"}},
+			},
+			wantBefore: map[string][]string{
+				"mysql":      {"discovery", "wlmclient"},
+				"activation": {"wlmclient"},
+			},
+			wantStatuses: []Status{
+				{Name: "activation", State: StateRunning, DependsOn: []string{"wlmclient"}},
+				{Name: "discovery", State: StateRunning},
+				{Name: "mysql", State: StateRunning, DependsOn: []string{"discovery", "wlmclient"}},
+				{Name: "wlmclient", State: StateRunning},
+			},
+		},
+		{
+			name: "FailedDependency",
+			registrations: []registration{
+				{name: "discovery"},
+				{name: "wlmclient", err: errors.New("no credentials")},
+				{name: "mysql", dependsOn: []string{"discovery", "wlmclient"}},
+				{name: "sqlserver", dependsOn: []string{"discovery"}},
+			},
+			wantStatuses: []Status{
+				{Name: "discovery", State: StateRunning},
+				{Name: "mysql", State: StateFailed, DependsOn: []string{"discovery", "wlmclient"}, Error: `dependency "wlmclient" failed`},
+				{Name: "sqlserver", State: StateRunning, DependsOn: []string{"discovery"}},
+				{Name: "wlmclient", State: StateFailed, Error: "no credentials"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := New()
+			var mu sync.Mutex
+			started := make(map[string]bool)
+			for _, r := range tc.registrations {
+				start := func(context.Context) error {
+					mu.Lock()
+					defer mu.Unlock()
+					for _, dep := range tc.wantBefore[r.name] {
+						if !started[dep] {
+							t.Errorf("component %q started before its dependency %q", r.name, dep)
+						}
+					}
+					started[r.name] = true
+					return r.err
+				}
+				if err := m.Add(r.name, start, r.dependsOn...); err != nil {
+					t.Fatalf("Add(%q) returned unexpected error: %v", r.name, err)
+				}
+			}
+
+			if err := m.Start(context.Background()); err != nil {
+				t.Fatalf("Start() returned unexpected error: %v", err)
+			}
+			err := m.Wait(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Wait() returned error: %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantStatuses, m.Statuses()); diff != "" {
+				t.Errorf("Statuses() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManagerStartErrors(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	tests := []struct {
+		name          string
+		registrations []registration
+	}{
+		{
+			name:          "UnknownDependency",
+			registrations: []registration{{name: "mysql", dependsOn: []string{"discovery"}}},
+		},
+		{
+			name: "Cycle",
+			registrations: []registration{
+				{name: "a", dependsOn: []string{"b"}},
+				{name: "b", dependsOn: []string{"c"}},
+				{name: "c", dependsOn: []string{"a"}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := New()
+			for _, r := range tc.registrations {
+				m.Add(r.name, noop, r.dependsOn...)
+			}
+			if err := m.Start(context.Background()); err == nil {
+				t.Error("Start() returned nil error, want error")
+			}
+		})
+	}
+}
+
+func TestManagerAddErrors(t *testing.T) {
+	noop := func(context.Context) error { return nil }
+	m := New()
+	if err := m.Add("discovery", noop); err != nil {
+		t.Fatalf("Add(discovery) returned unexpected error: %v", err)
+	}
+	if err := m.Add("discovery", noop); err == nil {
+		t.Error("Add() of a duplicate component returned nil error, want error")
+	}
+	if err := m.Start(context.Background()); err != nil {
+		t.Fatalf("Start() returned unexpected error: %v", err)
+	}
+	if err := m.Add("mysql", noop); err == nil {
+		t.Error("Add() after Start() returned nil error, want error")
+	}
+	if err := m.Start(context.Background()); err == nil {
+		t.Error("second Start() returned nil error, want error")
+	}
+}
+
+func TestManagerCancelled(t *testing.T) {
+	m := New()
+	block := make(chan struct{})
+	defer close(block)
+	m.Add("wlmclient", func(context.Context) error {
+		<-block
+		return nil
+	})
+	m.Add("mysql", func(context.Context) error { return nil }, "wlmclient")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start() returned unexpected error: %v", err)
+	}
+	cancel()
+	if err := m.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait() returned error %v, want %v", err, context.Canceled)
+	}
+}
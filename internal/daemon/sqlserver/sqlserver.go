@@ -23,9 +23,12 @@ import (
 	"time"
 
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/restartbudget"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/schedule"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
 
@@ -40,12 +43,18 @@ const (
 
 // Service implements the interfaces for SQL Server workload agent service.
 type Service struct {
-	Config           *configpb.Configuration
-	CloudProps       *configpb.CloudProperties
-	CommonCh         <-chan *servicecommunication.Message
-	isProcessPresent bool
-	DBcenterClient   databasecenter.Client
-	dwActivated      bool
+	Config     *configpb.Configuration
+	CloudProps *configpb.CloudProperties
+	CommonCh   <-chan *servicecommunication.Message
+	// processFound is set when the last full discovery result contained a SQL Server process.
+	processFound   bool
+	presence       servicecommunication.Presence
+	DBcenterClient databasecenter.Client
+	dwActivated    bool
+	InFlight       *workloadmanager.InFlight
+	WLMClient      workloadmanager.WLMWriter
+	// LegacyAgents suppresses the collection while the Agent for SQL Server collects the workload.
+	LegacyAgents *workloadmanager.LegacyAgents
 }
 
 type runMetricCollectionArgs struct {
@@ -69,7 +78,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 			}
 		})()
 		// If the workload is present, proceed with starting the service even if it is not enabled.
-		for !s.isProcessPresent {
+		for !s.presence.Present() {
 			time.Sleep(5 * time.Second)
 		}
 		log.CtxLogger(ctx).Info("SQL Server workload is present. Starting service.")
@@ -87,6 +96,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 		ErrorCode:           usagemetrics.SQLServerMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("SQLServerMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	metricCollectionRoutine.StartRoutine(mcCtx)
 
@@ -98,6 +108,7 @@ func (s *Service) Start(ctx context.Context, a any) {
 		ErrorCode:           usagemetrics.SQLServerMetricCollectionFailure,
 		UsageLogger:         *usagemetrics.UsageLogger,
 		ExpectedMinDuration: 20 * time.Second,
+		Backoff:             restartbudget.New("SQLServerDBCenterMetricCollection", s.Config.GetServiceRecovery()),
 	}
 	dbcenterMetricCollectionRoutine.StartRoutine(dbcenterMCCtx)
 
@@ -138,17 +149,32 @@ func runMetricCollection(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Debugw("SqlServer metric collection args", "args", args)
 	r := &sqlservermetrics.SQLServerMetrics{
 		Config:         args.s.Config.GetSqlserverConfiguration(),
+		Endpoints:      args.s.Config.GetEndpoints(),
+		Filter:         workloadmanager.NewFilter(args.s.Config.GetDataWarehouseFilter()),
 		DBcenterClient: args.s.DBcenterClient,
+		WLMClient:      args.s.WLMClient,
+		LegacyAgents:   args.s.LegacyAgents,
 	}
-	ticker := time.NewTicker(args.s.Config.GetSqlserverConfiguration().GetCollectionConfiguration().GetCollectionFrequency().AsDuration())
+	frequency := args.s.Config.GetSqlserverConfiguration().GetCollectionConfiguration().GetCollectionFrequency().AsDuration()
+	ticker := time.NewTicker(frequency)
 	defer ticker.Stop()
-	for {
+	if !schedule.WaitStart(ctx, ticker, args.s.Config.GetCollectionSchedule(), frequency) {
+		return
+	}
+	collect := func() {
+		// The collection finishes with its own context when the agent shuts down.
+		ctx, done := args.s.InFlight.StartWork(ctx)
+		defer done()
 		r.CollectMetricsOnce(ctx, args.s.dwActivated)
+	}
+	for {
+		collect()
 		select {
 		case <-ctx.Done():
 			log.CtxLogger(ctx).Info("SQL Server metric collection cancellation requested")
 			return
 		case <-ticker.C:
+			schedule.Jitter(ticker, args.s.Config.GetCollectionSchedule(), frequency)
 			continue
 		}
 	}
@@ -165,6 +191,8 @@ func runDBCenterMetricCollection(ctx context.Context, a any) {
 	log.CtxLogger(ctx).Debugw("SqlServer dbcenter metric collection args", "args", args)
 	r := &sqlservermetrics.SQLServerMetrics{
 		Config:         args.s.Config.GetSqlserverConfiguration(),
+		Endpoints:      args.s.Config.GetEndpoints(),
+		Filter:         workloadmanager.NewFilter(args.s.Config.GetDataWarehouseFilter()),
 		DBcenterClient: args.s.DBcenterClient,
 	}
 	ticker := time.NewTicker(dbcenterMetricCollectionFrequency(args))
@@ -210,13 +238,18 @@ func (s *Service) checkServiceCommunication(ctx context.Context) {
 		switch msg.Origin {
 		case servicecommunication.Discovery:
 			log.CtxLogger(ctx).Debug("SQL Server workload agent service received a discovery message")
-			for _, p := range msg.DiscoveryResult.Processes {
-				name, err := p.Name()
-				if err == nil && strings.Contains(name, sqlserverProcessSubstring) {
-					s.isProcessPresent = true
-					break
+			// Heartbeats carry no processes, the processes of the last full result still apply.
+			if !msg.DiscoveryResult.Unchanged {
+				s.processFound = false
+				for _, p := range msg.DiscoveryResult.Processes {
+					name, err := p.Name()
+					if err == nil && strings.Contains(name, sqlserverProcessSubstring) {
+						s.processFound = true
+						break
+					}
 				}
 			}
+			s.presence.Observe(ctx, "SQL Server", s.processFound)
 		case servicecommunication.DWActivation:
 			log.CtxLogger(ctx).Debug("SQL Server workload agent service received a DW activation message")
 			s.dwActivated = msg.DWActivationResult.Activated
@@ -21,11 +21,15 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"strings"
 	"time"
 
+	"google.golang.org/protobuf/proto"
 	anypb "google.golang.org/protobuf/types/known/anypb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	"github.com/GoogleCloudPlatform/agentcommunication_client"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/audit"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/communication"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -67,7 +71,10 @@ const (
 // DBCenterMetrics is a struct for database center metrics.
 type DBCenterMetrics struct {
 	EngineType EngineType
-	Metrics    map[string]string
+	// Instance identifies the database instance on the host, such as its address, SID or data
+	// directory. Several instances of an engine may run on one host.
+	Instance string
+	Metrics  map[string]string
 }
 
 // CommunicationClient is an interface for communication client.
@@ -93,6 +100,9 @@ func NewClient(config *configpb.Configuration, commClient CommunicationClient) C
 	if commClient == nil {
 		commClient = &realCommunicationClient{}
 	}
+	if l := audit.FromConfig(config); l != nil {
+		commClient = &auditCommunicationClient{CommunicationClient: commClient, log: l}
+	}
 	return &realClient{
 		Config:     config,
 		CommClient: commClient,
@@ -113,6 +123,19 @@ func (r *realCommunicationClient) SendAgentMessage(ctx context.Context, agentTyp
 	return communication.SendAgentMessage(ctx, agentType, messageType, msg, conn)
 }
 
+// auditCommunicationClient records every message sent to database center in the audit log.
+type auditCommunicationClient struct {
+	CommunicationClient
+	log *audit.Log
+}
+
+func (a *auditCommunicationClient) SendAgentMessage(ctx context.Context, agentType string, messageType string, msg *anypb.Any, conn *client.Connection) error {
+	start := time.Now()
+	err := a.CommunicationClient.SendAgentMessage(ctx, agentType, messageType, msg, conn)
+	a.log.RecordCall(ctx, "databasecenter", messageType, channel, proto.Size(msg), start, err)
+	return err
+}
+
 func (c *realClient) getEngineType(metrics DBCenterMetrics) dcpb.Engine {
 	switch metrics.EngineType {
 	case MYSQL:
@@ -126,6 +149,20 @@ func (c *realClient) getEngineType(metrics DBCenterMetrics) dcpb.Engine {
 	}
 }
 
+// resourceID returns the ID of the database instance of metrics. The ID of the VM alone is not
+// unique, databases of several engines and several instances of one engine may run on it.
+func (c *realClient) resourceID(metrics DBCenterMetrics) *dcpb.DatabaseResourceId {
+	uniqueID := c.Config.GetCloudProperties().GetInstanceId() + "/" + strings.ToLower(string(metrics.EngineType))
+	if metrics.Instance != "" {
+		uniqueID += "/" + metrics.Instance
+	}
+	return &dcpb.DatabaseResourceId{
+		Provider:     dcpb.DatabaseResourceId_GCP,
+		UniqueId:     uniqueID,
+		ResourceType: "compute.googleapis.com/Instance",
+	}
+}
+
 // buildDatabaseResourceMetadataMessage builds the snapshot message.
 func (c *realClient) buildDatabaseResourceMetadataMessage(ctx context.Context, metrics DBCenterMetrics) (*anypb.Any, error) {
 	cloudProps := c.Config.GetCloudProperties()
@@ -136,11 +173,7 @@ func (c *realClient) buildDatabaseResourceMetadataMessage(ctx context.Context, m
 		FeedType:      dcpb.DatabaseResourceFeed_RESOURCE_METADATA,
 		Content: &dcpb.DatabaseResourceFeed_ResourceMetadata{
 			ResourceMetadata: &dcpb.DatabaseResourceMetadata{
-				Id: &dcpb.DatabaseResourceId{
-					Provider:     dcpb.DatabaseResourceId_GCP,
-					UniqueId:     cloudProps.GetInstanceId(),
-					ResourceType: "compute.googleapis.com/Instance",
-				},
+				Id:                c.resourceID(metrics),
 				ResourceName:      "//compute.googleapis.com/projects/" + cloudProps.GetProjectId() + "/zones/" + cloudProps.GetZone() + "/instances/" + cloudProps.GetInstanceName(),
 				ResourceContainer: "projects/" + cloudProps.GetNumericProjectId(),
 				Location:          cloudProps.GetRegion(),
@@ -157,7 +190,7 @@ func (c *realClient) buildDatabaseResourceMetadataMessage(ctx context.Context, m
 				},
 				MachineConfiguration: &dcpb.MachineConfiguration{
 					VcpuCount:         float64(cloudProps.GetVcpuCount()),
-					MemorySizeInBytes: cloudProps.GetMemorySizeMb() * 1024 * 1024, // convert memory size to bytes
+					MemorySizeInBytes: cloudProps.GetMemorySizeMb() * units.Megabyte, // convert memory size to bytes
 				},
 			},
 		},
@@ -192,7 +225,7 @@ func (c *realClient) getSignalValue(value string) bool {
 }
 
 // buildConfigBasedSignalMessage builds the config based signal message.
-func (c *realClient) buildConfigBasedSignalMessage(ctx context.Context, key string, value string) (*anypb.Any, error) {
+func (c *realClient) buildConfigBasedSignalMessage(ctx context.Context, metrics DBCenterMetrics, key string, value string) (*anypb.Any, error) {
 	cloudProps := c.Config.GetCloudProperties()
 	feedTime := timestamppb.New(time.Now())
 	// construct an object of DatabaseResourceFeed proto.
@@ -201,11 +234,7 @@ func (c *realClient) buildConfigBasedSignalMessage(ctx context.Context, key stri
 		FeedType:      dcpb.DatabaseResourceFeed_CONFIG_BASED_SIGNAL_DATA,
 		Content: &dcpb.DatabaseResourceFeed_ConfigBasedSignalData{
 			ConfigBasedSignalData: &dcpb.ConfigBasedSignalData{
-				ResourceId: &dcpb.DatabaseResourceId{
-					Provider:     dcpb.DatabaseResourceId_GCP,
-					UniqueId:     cloudProps.GetInstanceId(),
-					ResourceType: "compute.googleapis.com/Instance",
-				},
+				ResourceId:       c.resourceID(metrics),
 				FullResourceName: "//compute.googleapis.com/projects/" + cloudProps.GetProjectId() + "/zones/" + cloudProps.GetZone() + "/instances/" + cloudProps.GetInstanceName(),
 				LastRefreshTime:  feedTime,
 				SignalType:       c.getSignalType(key),
@@ -250,7 +279,7 @@ func (c *realClient) SendMetadataToDatabaseCenter(ctx context.Context, metrics D
 		if key == MajorVersionKey || key == MinorVersionKey {
 			continue
 		}
-		msg, err := c.buildConfigBasedSignalMessage(ctx, key, value)
+		msg, err := c.buildConfigBasedSignalMessage(ctx, metrics, key, value)
 		if err != nil {
 			return fmt.Errorf("failed to build config based signal message: %v", err)
 		}
@@ -46,7 +46,7 @@ var (
 	defaultResourceName = "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/test-instance-name"
 	defaultResourceID   = &dcpb.DatabaseResourceId{
 		Provider:     dcpb.DatabaseResourceId_GCP,
-		UniqueId:     "test-instance-id",
+		UniqueId:     "test-instance-id/mysql/localhost:3306",
 		ResourceType: "compute.googleapis.com/Instance",
 	}
 )
@@ -186,7 +186,7 @@ func TestBuildConfigBasedSignalMessage(t *testing.T) {
 					ConfigBasedSignalData: &dcpb.ConfigBasedSignalData{
 						ResourceId: &dcpb.DatabaseResourceId{
 							Provider:     dcpb.DatabaseResourceId_GCP,
-							UniqueId:     "",
+							UniqueId:     "/mysql/localhost:3306",
 							ResourceType: "compute.googleapis.com/Instance",
 						},
 						FullResourceName: "//compute.googleapis.com/projects//zones//instances/",
@@ -220,7 +220,7 @@ func TestBuildConfigBasedSignalMessage(t *testing.T) {
 			ctx := context.Background()
 			client := &realClient{Config: tc.config}
 
-			gotAny, err := client.buildConfigBasedSignalMessage(ctx, tc.key, tc.value)
+			gotAny, err := client.buildConfigBasedSignalMessage(ctx, DBCenterMetrics{EngineType: MYSQL, Instance: "localhost:3306"}, tc.key, tc.value)
 			if (err != nil) != tc.wantErr {
 				t.Fatalf("buildConfigBasedSignalMessage(%q, %q) error = %v, wantErr %v", tc.key, tc.value, err, tc.wantErr)
 			}
@@ -269,6 +269,7 @@ func TestBuildDatabaseResourceMetadataMessage(t *testing.T) {
 			},
 			metrics: DBCenterMetrics{
 				EngineType: MYSQL,
+				Instance:   "localhost:3306",
 				Metrics: map[string]string{
 					"major_version": "8.0",
 					"minor_version": "8.0.26",
@@ -281,7 +282,7 @@ func TestBuildDatabaseResourceMetadataMessage(t *testing.T) {
 					ResourceMetadata: &dcpb.DatabaseResourceMetadata{
 						Id: &dcpb.DatabaseResourceId{
 							Provider:     dcpb.DatabaseResourceId_GCP,
-							UniqueId:     "test-instance",
+							UniqueId:     "test-instance/mysql/localhost:3306",
 							ResourceType: "compute.googleapis.com/Instance",
 						},
 						ResourceName:      "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/test-instance-name",
@@ -335,7 +336,7 @@ func TestBuildDatabaseResourceMetadataMessage(t *testing.T) {
 					ResourceMetadata: &dcpb.DatabaseResourceMetadata{
 						Id: &dcpb.DatabaseResourceId{
 							Provider:     dcpb.DatabaseResourceId_GCP,
-							UniqueId:     "test-instance",
+							UniqueId:     "test-instance/postgres",
 							ResourceType: "compute.googleapis.com/Instance",
 						},
 						ResourceName:      "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/test-instance-name",
@@ -389,7 +390,7 @@ func TestBuildDatabaseResourceMetadataMessage(t *testing.T) {
 					ResourceMetadata: &dcpb.DatabaseResourceMetadata{
 						Id: &dcpb.DatabaseResourceId{
 							Provider:     dcpb.DatabaseResourceId_GCP,
-							UniqueId:     "test-instance",
+							UniqueId:     "test-instance/sqlserver",
 							ResourceType: "compute.googleapis.com/Instance",
 						},
 						ResourceName:      "//compute.googleapis.com/projects/test-project/zones/us-central1-a/instances/test-instance-name",
@@ -433,6 +434,7 @@ func TestBuildDatabaseResourceMetadataMessage(t *testing.T) {
 					ResourceMetadata: &dcpb.DatabaseResourceMetadata{
 						Id: &dcpb.DatabaseResourceId{
 							Provider:     dcpb.DatabaseResourceId_GCP,
+							UniqueId:     "/postgres",
 							ResourceType: "compute.googleapis.com/Instance",
 						},
 						ResourceName:      "//compute.googleapis.com/projects//zones//instances/",
@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package endpoints creates Google API clients which use the endpoints set in the agent configuration.
+package endpoints
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/logging"
+	"cloud.google.com/go/secretmanager/apiv1"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/option"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentials"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	smpb "google.golang.org/genproto/googleapis/cloud/secretmanager/v1"
+)
+
+// GCE is a Compute Engine and Secret Manager client using the configured endpoints and the
+// credentials set by credentials.SetDefault.
+type GCE struct {
+	compute *compute.Service
+	secret  *secretmanager.Client
+}
+
+// NewGCEClient creates a GCE client using the configured Compute Engine and Secret Manager endpoints.
+func NewGCEClient(ctx context.Context, e *cpb.Endpoints) (*GCE, error) {
+	opts := credentials.DefaultOptions()
+	computeService, err := compute.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating compute client: %w", err)
+	}
+	if e.GetCompute() != "" {
+		computeService.BasePath = e.GetCompute()
+	}
+	secret, err := secretmanager.NewClient(ctx, append(opts, endpointOptions(e.GetSecretManager())...)...)
+	if err != nil {
+		return nil, fmt.Errorf("creating secret manager client: %w", err)
+	}
+	return &GCE{compute: computeService, secret: secret}, nil
+}
+
+// GetInstance returns the Compute Engine instance.
+func (g *GCE) GetInstance(project, zone, instance string) (*compute.Instance, error) {
+	return g.compute.Instances.Get(project, zone, instance).Do()
+}
+
+// GetDisk returns the persistent disk.
+func (g *GCE) GetDisk(project, zone, disk string) (*compute.Disk, error) {
+	return g.compute.Disks.Get(project, zone, disk).Do()
+}
+
+// GetInstanceCPUAndMemorySize returns the vCPU count and the memory size in MB of the machine type of the instance.
+func (g *GCE) GetInstanceCPUAndMemorySize(ctx context.Context, project, zone, instanceName string) (int64, int64, error) {
+	instance, err := g.compute.Instances.Get(project, zone, instanceName).Context(ctx).Do()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get instance %s in project %s, zone %s: %w", instanceName, project, zone, err)
+	}
+	if instance.MachineType == "" {
+		return 0, 0, fmt.Errorf("instance.MachineType field is empty")
+	}
+	// The machine type is a URL ending with its name.
+	machineTypeName := instance.MachineType[strings.LastIndex(instance.MachineType, "/")+1:]
+	machineType, err := g.compute.MachineTypes.Get(project, zone, machineTypeName).Context(ctx).Do()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get machine type %s: %w", machineTypeName, err)
+	}
+	return machineType.GuestCpus, machineType.MemoryMb, nil
+}
+
+// GetSecret returns the latest version of the secret.
+func (g *GCE) GetSecret(ctx context.Context, projectID, secretName string) (string, error) {
+	name := fmt.Sprintf("projects/%s/secrets/%s/versions/latest", projectID, secretName)
+	result, err := g.secret.AccessSecretVersion(ctx, &smpb.AccessSecretVersionRequest{Name: name})
+	if err != nil {
+		return "", err
+	}
+	return string(result.GetPayload().GetData()), nil
+}
+
+// MonitoringOptions returns the client options for the configured Cloud Monitoring endpoint.
+func MonitoringOptions(e *cpb.Endpoints) []option.ClientOption {
+	return endpointOptions(e.GetCloudMonitoring())
+}
+
+// PubSubOptions returns the client options for the configured Pub/Sub endpoint.
+func PubSubOptions(e *cpb.Endpoints) []option.ClientOption {
+	return endpointOptions(e.GetPubsub())
+}
+
+// BigQueryOptions returns the client options for the configured BigQuery endpoint.
+func BigQueryOptions(e *cpb.Endpoints) []option.ClientOption {
+	return endpointOptions(e.GetBigquery())
+}
+
+// StorageOptions returns the client options for the configured Cloud Storage endpoint.
+func StorageOptions(e *cpb.Endpoints) []option.ClientOption {
+	return endpointOptions(e.GetCloudStorage())
+}
+
+// CloudLoggingClient creates a Cloud Logging client using the configured endpoint.
+// It returns nil if the client cannot be created or Cloud Logging cannot be reached.
+func CloudLoggingClient(ctx context.Context, projectID string, e *cpb.Endpoints) *logging.Client {
+	endpoint := e.GetCloudLogging()
+	if endpoint == "" {
+		return log.CloudLoggingClient(ctx, projectID)
+	}
+	client, err := logging.NewClient(ctx, projectID, endpointOptions(endpoint)...)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Could not create Cloud Logging client", "endpoint", endpoint, "error", err)
+		return nil
+	}
+	if err := client.Ping(ctx); err != nil {
+		log.CtxLogger(ctx).Warnw("Could not reach Cloud Logging", "endpoint", endpoint, "error", err)
+		client.Close()
+		return nil
+	}
+	return client
+}
+
+func endpointOptions(endpoint string) []option.ClientOption {
+	if endpoint == "" {
+		return nil
+	}
+	return []option.ClientOption{option.WithEndpoint(endpoint)}
+}
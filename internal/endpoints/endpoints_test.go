@@ -0,0 +1,84 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package endpoints
+
+import (
+	"testing"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestMonitoringOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints *cpb.Endpoints
+		want      int
+	}{
+		{
+			name: "NotConfigured",
+			want: 0,
+		},
+		{
+			name:      "OtherEndpointsOnly",
+			endpoints: &cpb.Endpoints{SecretManager: "secretmanager-psc.p.googleapis.com:443"},
+			want:      0,
+		},
+		{
+			name:      "Configured",
+			endpoints: &cpb.Endpoints{CloudMonitoring: "monitoring-psc.p.googleapis.com:443"},
+			want:      1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MonitoringOptions(tc.endpoints); len(got) != tc.want {
+				t.Errorf("MonitoringOptions(%v) returned %d options, want %d", tc.endpoints, len(got), tc.want)
+			}
+		})
+	}
+}
+
+func TestPubSubOptions(t *testing.T) {
+	if got := PubSubOptions(nil); len(got) != 0 {
+		t.Errorf("PubSubOptions(nil) returned %d options, want 0", len(got))
+	}
+	e := &cpb.Endpoints{Pubsub: "pubsub-psc.p.googleapis.com:443"}
+	if got := PubSubOptions(e); len(got) != 1 {
+		t.Errorf("PubSubOptions(%v) returned %d options, want 1", e, len(got))
+	}
+}
+
+func TestBigQueryOptions(t *testing.T) {
+	if got := BigQueryOptions(nil); len(got) != 0 {
+		t.Errorf("BigQueryOptions(nil) returned %d options, want 0", len(got))
+	}
+	e := &cpb.Endpoints{Bigquery: "https://bigquery-psc.p.googleapis.com/bigquery/v2/"}
+	if got := BigQueryOptions(e); len(got) != 1 {
+		t.Errorf("BigQueryOptions(%v) returned %d options, want 1", e, len(got))
+	}
+}
+
+func TestStorageOptions(t *testing.T) {
+	if got := StorageOptions(nil); len(got) != 0 {
+		t.Errorf("StorageOptions(nil) returned %d options, want 0", len(got))
+	}
+	e := &cpb.Endpoints{CloudStorage: "https://storage-psc.p.googleapis.com/storage/v1/"}
+	if got := StorageOptions(e); len(got) != 1 {
+		t.Errorf("StorageOptions(%v) returned %d options, want 1", e, len(got))
+	}
+}
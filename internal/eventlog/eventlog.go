@@ -0,0 +1,30 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package eventlog writes significant agent lifecycle and error events to the Windows Event Log,
+// in addition to the file and cloud loggers, so they are visible to standard Windows tooling.
+// On other platforms the events are only written by the regular loggers.
+package eventlog
+
+// Source is the event source of the agent, which matches the name of the Windows service.
+const Source = "google-cloud-workload-agent"
+
+// Event IDs written with each event severity.
+const (
+	InfoEventID    uint32 = 1
+	WarningEventID uint32 = 2
+	ErrorEventID   uint32 = 3
+)
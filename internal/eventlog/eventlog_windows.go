@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventlog
+
+import (
+	"sync"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+var (
+	mu     sync.Mutex
+	logger *eventlog.Log
+)
+
+// Open opens the Windows Event Log under Source, which is registered by the installer.
+// Events are dropped until Open succeeds.
+func Open() error {
+	mu.Lock()
+	defer mu.Unlock()
+	if logger != nil {
+		return nil
+	}
+	l, err := eventlog.Open(Source)
+	if err != nil {
+		return err
+	}
+	logger = l
+	return nil
+}
+
+// Close closes the Windows Event Log.
+func Close() {
+	mu.Lock()
+	defer mu.Unlock()
+	if logger != nil {
+		logger.Close()
+		logger = nil
+	}
+}
+
+// Info writes an informational event.
+func Info(msg string) {
+	write(func(l *eventlog.Log) error { return l.Info(InfoEventID, msg) })
+}
+
+// Warning writes a warning event.
+func Warning(msg string) {
+	write(func(l *eventlog.Log) error { return l.Warning(WarningEventID, msg) })
+}
+
+// Error writes an error event.
+func Error(msg string) {
+	write(func(l *eventlog.Log) error { return l.Error(ErrorEventID, msg) })
+}
+
+// write writes an event if the event log is open.
+// Failures are ignored since the event is also written by the regular loggers.
+func write(f func(*eventlog.Log) error) {
+	mu.Lock()
+	defer mu.Unlock()
+	if logger != nil {
+		f(logger)
+	}
+}
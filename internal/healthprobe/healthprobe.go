@@ -0,0 +1,186 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package healthprobe runs synthetic availability checks against the databases on the host.
+//
+// Each database is checked with a TCP connect, a new authenticated connection and a trivial
+// query. The availability and latency of each check are reported as workload metrics.
+package healthprobe
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	// DefaultInterval is how often the databases are checked if no interval is configured.
+	DefaultInterval = time.Minute
+	// DefaultTimeout is the timeout of each check if no timeout is configured.
+	DefaultTimeout = 5 * time.Second
+	minInterval    = 10 * time.Second
+)
+
+// Metric keys of the probe results.
+const (
+	UpKey               = "probe_up"
+	TCPUpKey            = "probe_tcp_up"
+	TCPLatencyKey       = "probe_tcp_latency_ms"
+	HandshakeUpKey      = "probe_handshake_up"
+	HandshakeLatencyKey = "probe_handshake_latency_ms"
+	QueryUpKey          = "probe_query_up"
+	QueryLatencyKey     = "probe_query_latency_ms"
+)
+
+// Target is a database checked by the probes.
+type Target struct {
+	WorkloadType workloadmanager.WorkloadType
+	// Address is the host and port the database listens on.
+	Address string
+	// Handshake opens, authenticates and closes a new connection to the database.
+	Handshake func(ctx context.Context) error
+	// Query runs a trivial query on the connection used by the collectors.
+	Query func(ctx context.Context) error
+}
+
+// metricSchema defines the types of the probe results.
+var metricSchema = workloadmanager.NewSchema(
+	workloadmanager.MetricDefinition{Name: UpKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: TCPUpKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: TCPLatencyKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitMilliseconds},
+	workloadmanager.MetricDefinition{Name: HandshakeUpKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: HandshakeLatencyKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitMilliseconds},
+	workloadmanager.MetricDefinition{Name: QueryUpKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: QueryLatencyKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitMilliseconds},
+)
+
+// Exporter writes the probe results, it is implemented by *metricexport.Exporter.
+type Exporter interface {
+	Export(ctx context.Context, wm *workloadmanager.WorkloadMetrics, schema *workloadmanager.Schema) error
+}
+
+// Prober checks the availability of databases.
+type Prober struct {
+	timeout time.Duration
+	dial    func(ctx context.Context, network, address string) (net.Conn, error)
+	now     func() time.Time
+}
+
+// New returns a Prober for the configuration.
+func New(config *configpb.HealthProbes) *Prober {
+	return &Prober{
+		timeout: Timeout(config),
+		dial:    (&net.Dialer{}).DialContext,
+		now:     time.Now,
+	}
+}
+
+// Interval returns how often the databases are checked.
+func Interval(config *configpb.HealthProbes) time.Duration {
+	if config.GetInterval() == nil {
+		return DefaultInterval
+	}
+	return max(config.GetInterval().AsDuration(), minInterval)
+}
+
+// Timeout returns the timeout of each check.
+func Timeout(config *configpb.HealthProbes) time.Duration {
+	if t := config.GetTimeout().AsDuration(); t > 0 {
+		return t
+	}
+	return DefaultTimeout
+}
+
+// Probe checks the target and returns the results as workload metrics.
+// The handshake and the query are not run if the database can't be reached,
+// and they are reported as unavailable.
+func (p *Prober) Probe(ctx context.Context, t Target) *workloadmanager.WorkloadMetrics {
+	metrics := map[string]string{workloadmanager.DatabaseInstanceKey: t.Address}
+	up := p.check(ctx, metrics, TCPUpKey, TCPLatencyKey, func(ctx context.Context) error {
+		conn, err := p.dial(ctx, "tcp", t.Address)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	})
+	if !up {
+		metrics[HandshakeUpKey] = "false"
+		metrics[QueryUpKey] = "false"
+		metrics[UpKey] = "false"
+		return &workloadmanager.WorkloadMetrics{WorkloadType: t.WorkloadType, Metrics: metrics}
+	}
+	if t.Handshake != nil {
+		up = p.check(ctx, metrics, HandshakeUpKey, HandshakeLatencyKey, t.Handshake) && up
+	}
+	if t.Query != nil {
+		up = p.check(ctx, metrics, QueryUpKey, QueryLatencyKey, t.Query) && up
+	}
+	metrics[UpKey] = strconv.FormatBool(up)
+	return &workloadmanager.WorkloadMetrics{WorkloadType: t.WorkloadType, Metrics: metrics}
+}
+
+// check runs fn with the probe timeout and records whether it succeeded and how long it took.
+// The latency is only recorded for successful checks.
+func (p *Prober) check(ctx context.Context, metrics map[string]string, upKey, latencyKey string, fn func(context.Context) error) bool {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+	start := p.now()
+	if err := fn(ctx); err != nil {
+		log.CtxLogger(ctx).Debugw("Health probe failed", "check", upKey, "error", err)
+		metrics[upKey] = "false"
+		return false
+	}
+	metrics[upKey] = "true"
+	metrics[latencyKey] = strconv.FormatInt(p.now().Sub(start).Milliseconds(), 10)
+	return true
+}
+
+// Run checks the targets every interval and exports the results until ctx is done.
+// Databases becoming unavailable or available again are logged.
+func Run(ctx context.Context, config *configpb.HealthProbes, targets []Target, exporter Exporter) {
+	p := New(config)
+	ticker := time.NewTicker(Interval(config))
+	defer ticker.Stop()
+	available := make(map[string]bool)
+	for {
+		for _, t := range targets {
+			wm := p.Probe(ctx, t)
+			up := wm.Metrics[UpKey] == "true"
+			if was, ok := available[t.Address]; ok && was != up {
+				if up {
+					log.CtxLogger(ctx).Infow("Database is available again", "workload", t.WorkloadType, "address", t.Address)
+				} else {
+					log.CtxLogger(ctx).Warnw("Database is unavailable", "workload", t.WorkloadType, "address", t.Address, "results", wm.Metrics)
+				}
+			}
+			available[t.Address] = up
+			log.CtxLogger(ctx).Debugw("Health probe results", "workload", t.WorkloadType, "results", wm.Metrics)
+			if err := exporter.Export(ctx, wm, metricSchema); err != nil {
+				log.CtxLogger(ctx).Warnw("Failed to export health probe results to Cloud Monitoring", "workload", t.WorkloadType, "error", err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
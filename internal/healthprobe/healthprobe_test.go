@@ -0,0 +1,176 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package healthprobe
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// fakeClock advances by step every time it is read.
+func fakeClock(step time.Duration) func() time.Time {
+	now := time.Unix(0, 0)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func fakeDial(err error) func(context.Context, string, string) (net.Conn, error) {
+	return func(context.Context, string, string) (net.Conn, error) {
+		if err != nil {
+			return nil, err
+		}
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	}
+}
+
+func check(err error) func(context.Context) error {
+	return func(context.Context) error { return err }
+}
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialErr error
+		target  Target
+		want    map[string]string
+	}{
+		{
+			name:   "Available",
+			target: Target{WorkloadType: workloadmanager.MYSQL, Address: "localhost:3306", Handshake: check(nil), Query: check(nil)},
+			want: map[string]string{
+				workloadmanager.DatabaseInstanceKey: "localhost:3306",
+				UpKey:                               "true",
+				TCPUpKey:                            "true",
+				TCPLatencyKey:                       "10",
+				HandshakeUpKey:                      "true",
+				HandshakeLatencyKey:                 "10",
+				QueryUpKey:                          "true",
+				QueryLatencyKey:                     "10",
+			},
+		},
+		{
+			name:    "Unreachable",
+			dialErr: errors.New("connection refused"),
+			target:  Target{WorkloadType: workloadmanager.MYSQL, Address: "localhost:3306", Handshake: check(nil), Query: check(nil)},
+			want: map[string]string{
+				workloadmanager.DatabaseInstanceKey: "localhost:3306",
+				UpKey:                               "false",
+				TCPUpKey:                            "false",
+				HandshakeUpKey:                      "false",
+				QueryUpKey:                          "false",
+			},
+		},
+		{
+			name:   "AuthenticationFailure",
+			target: Target{WorkloadType: workloadmanager.POSTGRES, Address: "localhost:5432", Handshake: check(errors.New("password authentication failed")), Query: check(nil)},
+			want: map[string]string{
+				workloadmanager.DatabaseInstanceKey: "localhost:5432",
+				UpKey:                               "false",
+				TCPUpKey:                            "true",
+				TCPLatencyKey:                       "10",
+				HandshakeUpKey:                      "false",
+				QueryUpKey:                          "true",
+				QueryLatencyKey:                     "10",
+			},
+		},
+		{
+			name:   "NoHandshakeOrQuery",
+			target: Target{WorkloadType: workloadmanager.POSTGRES, Address: "localhost:5432"},
+			want: map[string]string{
+				workloadmanager.DatabaseInstanceKey: "localhost:5432",
+				UpKey:                               "true",
+				TCPUpKey:                            "true",
+				TCPLatencyKey:                       "10",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Prober{timeout: time.Second, dial: fakeDial(tc.dialErr), now: fakeClock(10 * time.Millisecond)}
+			got := p.Probe(context.Background(), tc.target)
+			if got.WorkloadType != tc.target.WorkloadType {
+				t.Errorf("Probe() returned workload type %s, want %s", got.WorkloadType, tc.target.WorkloadType)
+			}
+			if diff := cmp.Diff(tc.want, got.Metrics); diff != "" {
+				t.Errorf("Probe() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProbeTimeout(t *testing.T) {
+	p := &Prober{timeout: time.Millisecond, dial: fakeDial(nil), now: time.Now}
+	target := Target{
+		Address: "localhost:3306",
+		Query: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	if got := p.Probe(context.Background(), target).Metrics[QueryUpKey]; got != "false" {
+		t.Errorf("Probe() returned %s=%s, want false", QueryUpKey, got)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *configpb.HealthProbes
+		want   time.Duration
+	}{
+		{name: "Default", want: DefaultInterval},
+		{name: "Configured", config: &configpb.HealthProbes{Interval: durationpb.New(30 * time.Second)}, want: 30 * time.Second},
+		{name: "TooShort", config: &configpb.HealthProbes{Interval: durationpb.New(time.Second)}, want: minInterval},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Interval(tc.config); got != tc.want {
+				t.Errorf("Interval(%v) = %v, want %v", tc.config, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *configpb.HealthProbes
+		want   time.Duration
+	}{
+		{name: "Default", want: DefaultTimeout},
+		{name: "Configured", config: &configpb.HealthProbes{Timeout: durationpb.New(time.Second)}, want: time.Second},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Timeout(tc.config); got != tc.want {
+				t.Errorf("Timeout(%v) = %v, want %v", tc.config, got, tc.want)
+			}
+		})
+	}
+}
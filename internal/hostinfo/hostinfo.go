@@ -0,0 +1,72 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hostinfo reads the resources of the host without shelling out to system tools.
+package hostinfo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
+)
+
+// TotalMemoryBytes returns the physical memory of the host in bytes.
+// It reads /proc/meminfo on Linux and queries Win32_ComputerSystem through WMI on Windows.
+func TotalMemoryBytes(ctx context.Context) (int64, error) {
+	return totalMemoryBytes(ctx)
+}
+
+// MeminfoValue returns the value of a /proc/meminfo field without its unit, e.g. "2048" for
+// "Hugepagesize: 2048 kB". An empty string is returned if the field is not present.
+func MeminfoValue(meminfo []byte, field string) string {
+	scanner := bufio.NewScanner(strings.NewReader(string(meminfo)))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || name != field {
+			continue
+		}
+		if fields := strings.Fields(value); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// MeminfoBytes returns the value of a /proc/meminfo field in bytes.
+// Fields are reported in kB by the kernel, fields without a unit such as HugePages_Total are counts
+// and cannot be converted.
+func MeminfoBytes(meminfo []byte, field string) (int64, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(meminfo)))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || name != field {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) != 2 || !strings.EqualFold(fields[1], "kB") {
+			return 0, fmt.Errorf("unexpected format of meminfo field %s: %q", field, strings.TrimSpace(value))
+		}
+		bytes, err := units.ParseMemory(value, units.Byte)
+		if err != nil {
+			return 0, fmt.Errorf("parsing meminfo field %s: %w", field, err)
+		}
+		return bytes, nil
+	}
+	return 0, fmt.Errorf("meminfo field %s not found", field)
+}
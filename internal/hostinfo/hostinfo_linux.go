@@ -0,0 +1,33 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostinfo
+
+import (
+	"context"
+	"os"
+)
+
+// readFile is replaced in tests.
+var readFile = os.ReadFile
+
+func totalMemoryBytes(ctx context.Context) (int64, error) {
+	meminfo, err := readFile("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	return MeminfoBytes(meminfo, "MemTotal")
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostinfo
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTotalMemoryBytes(t *testing.T) {
+	tests := []struct {
+		name    string
+		meminfo string
+		readErr error
+		want    int64
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			meminfo: testMeminfo,
+			want:    4025040 * 1024,
+		},
+		{
+			name:    "MissingField",
+			meminfo: "MemFree:          152412 kB\n",
+			wantErr: true,
+		},
+		{
+			name:    "ReadError",
+			readErr: errors.New("read failed"),
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(f func(string) ([]byte, error)) { readFile = f }(readFile)
+			readFile = func(string) ([]byte, error) { return []byte(tc.meminfo), tc.readErr }
+
+			got, err := TotalMemoryBytes(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Errorf("TotalMemoryBytes() returned error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("TotalMemoryBytes() = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostinfo
+
+import (
+	"testing"
+)
+
+const testMeminfo = `MemTotal:        4025040 kB
+MemFree:          152412 kB
+HugePages_Total:       0
+Hugepagesize:       2048 kB
+Broken:         12a4 kB
+`
+
+func TestMeminfoValue(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{field: "MemTotal", want: "4025040"},
+		{field: "HugePages_Total", want: "0"},
+		{field: "Hugepagesize", want: "2048"},
+		{field: "SwapTotal", want: ""},
+		{field: "Mem", want: ""},
+	}
+	for _, tc := range tests {
+		if got := MeminfoValue([]byte(testMeminfo), tc.field); got != tc.want {
+			t.Errorf("MeminfoValue(%q) = %q, want %q", tc.field, got, tc.want)
+		}
+	}
+}
+
+func TestMeminfoBytes(t *testing.T) {
+	tests := []struct {
+		field   string
+		want    int64
+		wantErr bool
+	}{
+		{field: "MemTotal", want: 4025040 * 1024},
+		{field: "Hugepagesize", want: 2048 * 1024},
+		{field: "HugePages_Total", wantErr: true},
+		{field: "Broken", wantErr: true},
+		{field: "SwapTotal", wantErr: true},
+	}
+	for _, tc := range tests {
+		got, err := MeminfoBytes([]byte(testMeminfo), tc.field)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("MeminfoBytes(%q) returned error: %v, wantErr: %v", tc.field, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("MeminfoBytes(%q) = %d, want %d", tc.field, got, tc.want)
+		}
+	}
+}
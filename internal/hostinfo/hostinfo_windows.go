@@ -0,0 +1,40 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hostinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/StackExchange/wmi"
+)
+
+type win32ComputerSystem struct {
+	TotalPhysicalMemory uint64
+}
+
+func totalMemoryBytes(ctx context.Context) (int64, error) {
+	var result []win32ComputerSystem
+	if err := wmi.Query("SELECT TotalPhysicalMemory FROM Win32_ComputerSystem", &result); err != nil {
+		return 0, fmt.Errorf("querying Win32_ComputerSystem: %w", err)
+	}
+	if len(result) == 0 {
+		return 0, errors.New("no Win32_ComputerSystem instance found")
+	}
+	return int64(result[0].TotalPhysicalMemory), nil
+}
@@ -19,11 +19,52 @@ package ipinfo
 
 import (
 	"context"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 )
 
+// Resolver looks up the addresses and names of hosts.
+type Resolver interface {
+	LookupHost(host string) ([]string, error)
+	ParseIP(ip string) net.IP
+	LookupAddr(ip string) ([]string, error)
+}
+
+// NetResolver is a Resolver backed by the net package.
+type NetResolver struct {
+	// Timeout bounds each lookup, lookups are not bounded if it is not positive.
+	Timeout time.Duration
+}
+
+func (r NetResolver) context() (context.Context, context.CancelFunc) {
+	if r.Timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), r.Timeout)
+}
+
+// LookupHost looks up the addresses of host.
+func (r NetResolver) LookupHost(host string) ([]string, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+	return net.DefaultResolver.LookupHost(ctx, host)
+}
+
+// ParseIP parses ip as an IPv4 or IPv6 address.
+func (NetResolver) ParseIP(ip string) net.IP {
+	return net.ParseIP(ip)
+}
+
+// LookupAddr looks up the names of ip.
+func (r NetResolver) LookupAddr(ip string) ([]string, error) {
+	ctx, cancel := r.context()
+	defer cancel()
+	return net.DefaultResolver.LookupAddr(ctx, ip)
+}
+
 // ZoneFromHost returns the zone for the given host.
 func ZoneFromHost(ctx context.Context, host string) string {
 	// host is something like "name.us-central1-a.c.gce-performance-manual.internal."
@@ -78,3 +119,25 @@ func ZonesFromIPs(ctx context.Context, ips []string, netLookupAddr func(ip strin
 	}
 	return zones
 }
+
+// ZoneFromHostOrIP returns the zone for a host given by its IP, fully qualified or short name.
+// Short names, e.g. Windows computer names, carry no zone and are resolved to their IPs instead.
+func ZoneFromHostOrIP(ctx context.Context, host string, resolver Resolver) string {
+	if resolver.ParseIP(host) != nil {
+		return ZoneFromIP(ctx, host, resolver.LookupAddr)
+	}
+	addrs, err := resolver.LookupHost(host)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to look up host", "host", host, "err", err)
+		return ""
+	}
+	if zone := ZoneFromHost(ctx, host); zone != "" {
+		return zone
+	}
+	for _, addr := range addrs {
+		if zone := ZoneFromIP(ctx, addr, resolver.LookupAddr); zone != "" {
+			return zone
+		}
+	}
+	return ""
+}
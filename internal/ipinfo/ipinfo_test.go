@@ -18,6 +18,7 @@ package ipinfo
 
 import (
 	"errors"
+	"net"
 	"testing"
 
 	"context"
@@ -246,3 +247,76 @@ func TestZonesFromIPs(t *testing.T) {
 		})
 	}
 }
+
+type fakeResolver struct {
+	hosts map[string][]string
+	addrs map[string][]string
+}
+
+func (f fakeResolver) LookupHost(host string) ([]string, error) {
+	addrs, ok := f.hosts[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func (f fakeResolver) ParseIP(ip string) net.IP {
+	return net.ParseIP(ip)
+}
+
+func (f fakeResolver) LookupAddr(ip string) ([]string, error) {
+	return f.addrs[ip], nil
+}
+
+func TestZoneFromHostOrIP(t *testing.T) {
+	resolver := fakeResolver{
+		hosts: map[string][]string{
+			"sql-1.us-central1-a.c.fake-project.internal": {"10.0.0.1"},
+			"sql-2": {"10.0.0.2"},
+			"sql-3": {"10.0.0.3"},
+		},
+		addrs: map[string][]string{
+			"10.0.0.1": {"sql-1.us-central1-a.c.fake-project.internal."},
+			"10.0.0.2": {"sql-2.us-central1-b.c.fake-project.internal."},
+		},
+	}
+	tests := []struct {
+		name string
+		host string
+		want string
+	}{
+		{
+			name: "IP",
+			host: "10.0.0.1",
+			want: "us-central1-a",
+		},
+		{
+			name: "FullyQualifiedName",
+			host: "sql-1.us-central1-a.c.fake-project.internal",
+			want: "us-central1-a",
+		},
+		{
+			name: "ShortName",
+			host: "sql-2",
+			want: "us-central1-b",
+		},
+		{
+			name: "ShortNameWithoutReverseLookup",
+			host: "sql-3",
+			want: "",
+		},
+		{
+			name: "UnknownHost",
+			host: "sql-4",
+			want: "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ZoneFromHostOrIP(context.Background(), tc.host, resolver); got != tc.want {
+				t.Errorf("ZoneFromHostOrIP(%q) = %q, want %q", tc.host, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipinfo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	// DefaultDNSTimeout bounds the DNS lookups of the default ZoneResolver unless configured otherwise.
+	DefaultDNSTimeout = 5 * time.Second
+	// ZoneCacheTTL is how long a ZoneResolver caches the zone of a host.
+	ZoneCacheTTL = time.Hour
+	// UnknownZoneCacheTTL is how long a ZoneResolver caches that the zone of a host is unknown.
+	// It spans several collection cycles so that broken reverse DNS is not queried on every cycle.
+	UnknownZoneCacheTTL = 15 * time.Minute
+)
+
+// defaultZoneResolver is shared by the collectors of all workloads.
+var defaultZoneResolver = NewZoneResolver(NetResolver{Timeout: DefaultDNSTimeout})
+
+// DefaultZoneResolver returns the ZoneResolver shared by the collectors of all workloads.
+func DefaultZoneResolver() *ZoneResolver {
+	return defaultZoneResolver
+}
+
+// SetDNSTimeout sets the timeout of the DNS lookups made by the default ZoneResolver.
+// A timeout which is not positive restores DefaultDNSTimeout.
+func SetDNSTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = DefaultDNSTimeout
+	}
+	defaultZoneResolver.setResolver(NetResolver{Timeout: timeout})
+}
+
+type cachedZone struct {
+	zone    string
+	expires time.Time
+}
+
+// ZoneResolver resolves replica hosts to the GCE zones they run in.
+// Resolved zones are cached so that replicas are not looked up on every collection.
+// Hosts without a zone are cached for the shorter UnknownZoneCacheTTL.
+type ZoneResolver struct {
+	now func() time.Time
+
+	mu       sync.Mutex
+	resolver Resolver
+	cache    map[string]cachedZone
+}
+
+// NewZoneResolver returns a ZoneResolver which looks up hosts with resolver.
+func NewZoneResolver(resolver Resolver) *ZoneResolver {
+	return &ZoneResolver{
+		now:      time.Now,
+		resolver: resolver,
+		cache:    make(map[string]cachedZone),
+	}
+}
+
+func (z *ZoneResolver) setResolver(resolver Resolver) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.resolver = resolver
+	// Lookups which timed out with the previous resolver may succeed with this one.
+	z.cache = make(map[string]cachedZone)
+}
+
+// Zone returns the zone of a host given by its IP, fully qualified or short name, or "" if it is unknown.
+func (z *ZoneResolver) Zone(ctx context.Context, host string) string {
+	z.mu.Lock()
+	cached, ok := z.cache[host]
+	resolver := z.resolver
+	z.mu.Unlock()
+	if ok && z.now().Before(cached.expires) {
+		return cached.zone
+	}
+	if ctx.Err() != nil {
+		log.CtxLogger(ctx).Debugw("Skipping zone lookup of cancelled collection", "host", host, "err", ctx.Err())
+		return ""
+	}
+
+	zone := ZoneFromHostOrIP(ctx, host, resolver)
+	ttl := ZoneCacheTTL
+	if zone == "" {
+		ttl = UnknownZoneCacheTTL
+	}
+	z.mu.Lock()
+	z.cache[host] = cachedZone{zone: zone, expires: z.now().Add(ttl)}
+	z.mu.Unlock()
+	return zone
+}
+
+// Zones returns the zones of hosts, skipping the hosts whose zone is unknown.
+func (z *ZoneResolver) Zones(ctx context.Context, hosts []string) []string {
+	var zones []string
+	for _, host := range hosts {
+		if zone := z.Zone(ctx, host); zone != "" {
+			zones = append(zones, zone)
+		}
+	}
+	return zones
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// countingResolver counts the reverse lookups made through a fakeResolver.
+type countingResolver struct {
+	fakeResolver
+	lookups int
+}
+
+func (c *countingResolver) LookupAddr(ip string) ([]string, error) {
+	c.lookups++
+	return c.fakeResolver.LookupAddr(ip)
+}
+
+func TestZoneResolverCache(t *testing.T) {
+	resolver := &countingResolver{fakeResolver: fakeResolver{addrs: map[string][]string{
+		"10.0.0.1": {"replica-1.us-central1-a.c.fake-project.internal."},
+	}}}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	z := NewZoneResolver(resolver)
+	z.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		host        string
+		advance     time.Duration
+		want        string
+		wantLookups int
+	}{
+		{name: "FirstLookup", host: "10.0.0.1", want: "us-central1-a", wantLookups: 1},
+		{name: "Cached", host: "10.0.0.1", advance: ZoneCacheTTL - time.Second, want: "us-central1-a", wantLookups: 1},
+		{name: "Expired", host: "10.0.0.1", advance: time.Second, want: "us-central1-a", wantLookups: 2},
+		{name: "UnknownZone", host: "10.0.0.2", want: "", wantLookups: 3},
+		{name: "UnknownZoneCached", host: "10.0.0.2", advance: UnknownZoneCacheTTL - time.Second, want: "", wantLookups: 3},
+		{name: "UnknownZoneExpired", host: "10.0.0.2", advance: time.Second, want: "", wantLookups: 4},
+	}
+	// The cases share the resolver and run in order.
+	for _, tc := range tests {
+		now = now.Add(tc.advance)
+		if got := z.Zone(ctx, tc.host); got != tc.want {
+			t.Errorf("%s: Zone(%q) = %q, want %q", tc.name, tc.host, got, tc.want)
+		}
+		if resolver.lookups != tc.wantLookups {
+			t.Errorf("%s: Zone(%q) made %d lookups in total, want %d", tc.name, tc.host, resolver.lookups, tc.wantLookups)
+		}
+	}
+}
+
+func TestZoneResolverCancelled(t *testing.T) {
+	resolver := &countingResolver{fakeResolver: fakeResolver{addrs: map[string][]string{
+		"10.0.0.1": {"replica-1.us-central1-a.c.fake-project.internal."},
+	}}}
+	z := NewZoneResolver(resolver)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if got := z.Zone(ctx, "10.0.0.1"); got != "" {
+		t.Errorf("Zone() with a cancelled context = %q, want \"\"", got)
+	}
+	if resolver.lookups != 0 {
+		t.Errorf("Zone() with a cancelled context made %d lookups, want 0", resolver.lookups)
+	}
+	if got := z.Zone(context.Background(), "10.0.0.1"); got != "us-central1-a" {
+		t.Errorf("Zone() after a cancelled lookup = %q, want us-central1-a", got)
+	}
+}
+
+func TestZoneResolverZones(t *testing.T) {
+	z := NewZoneResolver(fakeResolver{addrs: map[string][]string{
+		"10.0.0.1": {"replica-1.us-central1-a.c.fake-project.internal."},
+		"10.0.0.2": {"replica-2.us-central1-b.c.fake-project.internal."},
+	}})
+	got := z.Zones(context.Background(), []string{"10.0.0.1", "10.0.0.3", "10.0.0.2"})
+	want := []string{"us-central1-a", "us-central1-b"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Zones() returned diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetDNSTimeout(t *testing.T) {
+	defer SetDNSTimeout(DefaultDNSTimeout)
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{name: "Configured", timeout: 2 * time.Second, want: 2 * time.Second},
+		{name: "Unset", timeout: 0, want: DefaultDNSTimeout},
+		{name: "Negative", timeout: -time.Second, want: DefaultDNSTimeout},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDNSTimeout(tc.timeout)
+			got := DefaultZoneResolver().resolver.(NetResolver).Timeout
+			if got != tc.want {
+				t.Errorf("SetDNSTimeout(%v) set timeout %v, want %v", tc.timeout, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lifecycle emits structured events when the agent observes a change in the state of a
+// workload, such as the workload starting on the host or a database changing its replication role,
+// so operators can correlate topology changes with the state observed by the agent.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/eventlog"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// EventType is the kind of change a lifecycle event reports.
+type EventType string
+
+const (
+	// WorkloadDetected is emitted when processes of a workload are discovered on the host.
+	WorkloadDetected EventType = "WORKLOAD_DETECTED"
+	// WorkloadDisappeared is emitted when a workload is no longer running on the host.
+	WorkloadDisappeared EventType = "WORKLOAD_DISAPPEARED"
+	// VersionChanged is emitted when a database instance reports a different version.
+	VersionChanged EventType = "VERSION_CHANGED"
+	// RoleChanged is emitted when a database instance reports a different replication role,
+	// e.g. when a replica is promoted to source.
+	RoleChanged EventType = "ROLE_CHANGED"
+)
+
+// Event is a change in the state of a workload.
+type Event struct {
+	Type     EventType
+	Workload string
+	// Instance identifies the database instance when the event concerns one of several instances
+	// of the workload on the host.
+	Instance string
+	Previous string
+	Current  string
+}
+
+// String returns a human readable description of the event.
+func (e Event) String() string {
+	subject := e.Workload
+	if e.Instance != "" {
+		subject = fmt.Sprintf("%s instance %s", e.Workload, e.Instance)
+	}
+	switch e.Type {
+	case WorkloadDetected:
+		return fmt.Sprintf("%s workload detected on the host.", subject)
+	case WorkloadDisappeared:
+		return fmt.Sprintf("%s workload is no longer running on the host.", subject)
+	case VersionChanged:
+		return fmt.Sprintf("%s version changed from %s to %s.", subject, e.Previous, e.Current)
+	case RoleChanged:
+		return fmt.Sprintf("%s role changed from %s to %s.", subject, e.Previous, e.Current)
+	}
+	return fmt.Sprintf("%s %s.", subject, e.Type)
+}
+
+// Emit writes the event as a structured log entry, which is also sent to Cloud Logging when it is
+// enabled, and to the Windows Event Log.
+func Emit(ctx context.Context, e Event) {
+	log.CtxLogger(ctx).Infow("Workload lifecycle event", "lifecycle_event", string(e.Type), "workload", e.Workload, "instance", e.Instance, "previous", e.Previous, "current", e.Current, "description", e.String())
+	eventlog.Info(e.String())
+}
+
+type trackerKey struct {
+	eventType EventType
+	workload  string
+	instance  string
+}
+
+// Tracker emits an event when an observed attribute of a workload instance changes.
+// The zero value is ready to use.
+type Tracker struct {
+	mu   sync.Mutex
+	last map[trackerKey]string
+}
+
+// Observe records the current value of the attribute reported by eventType and emits an event if
+// it differs from the previously observed value. Empty values, e.g. from a failed collection, are
+// ignored. It returns the emitted event, if any.
+func (t *Tracker) Observe(ctx context.Context, eventType EventType, workload, instance, value string) (Event, bool) {
+	if value == "" {
+		return Event{}, false
+	}
+	key := trackerKey{eventType: eventType, workload: workload, instance: instance}
+	t.mu.Lock()
+	if t.last == nil {
+		t.last = make(map[trackerKey]string)
+	}
+	previous, seen := t.last[key]
+	t.last[key] = value
+	t.mu.Unlock()
+	if !seen || previous == value {
+		return Event{}, false
+	}
+	e := Event{Type: eventType, Workload: workload, Instance: instance, Previous: previous, Current: value}
+	Emit(ctx, e)
+	return e, true
+}
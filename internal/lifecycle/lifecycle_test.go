@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTrackerObserve(t *testing.T) {
+	type observation struct {
+		eventType EventType
+		instance  string
+		value     string
+	}
+	tests := []struct {
+		name         string
+		observations []observation
+		want         []Event
+	}{
+		{
+			name: "FirstObservationIsNotAChange",
+			observations: []observation{
+				{RoleChanged, "", "source"},
+			},
+		},
+		{
+			name: "UnchangedValue",
+			observations: []observation{
+				{RoleChanged, "", "source"},
+				{RoleChanged, "", "source"},
+			},
+		},
+		{
+			name: "RoleChanged",
+			observations: []observation{
+				{RoleChanged, "", "replica"},
+				{RoleChanged, "", "source"},
+			},
+			want: []Event{
+				{Type: RoleChanged, Workload: "MYSQL", Previous: "replica", Current: "source"},
+			},
+		},
+		{
+			name: "EmptyValueIsIgnored",
+			observations: []observation{
+				{VersionChanged, "", "7.0.1"},
+				{VersionChanged, "", ""},
+				{VersionChanged, "", "7.0.1"},
+			},
+		},
+		{
+			name: "InstancesAreTrackedSeparately",
+			observations: []observation{
+				{VersionChanged, "localhost:3306", "8.0.36"},
+				{VersionChanged, "localhost:3307", "8.4.0"},
+				{VersionChanged, "localhost:3306", "8.4.0"},
+			},
+			want: []Event{
+				{Type: VersionChanged, Workload: "MYSQL", Instance: "localhost:3306", Previous: "8.0.36", Current: "8.4.0"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := &Tracker{}
+			var got []Event
+			for _, o := range tc.observations {
+				if e, ok := tracker.Observe(context.Background(), o.eventType, "MYSQL", o.instance, o.value); ok {
+					got = append(got, e)
+				}
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Observe() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEventString(t *testing.T) {
+	tests := []struct {
+		event Event
+		want  string
+	}{
+		{
+			event: Event{Type: WorkloadDetected, Workload: "MySQL"},
+			want:  "MySQL workload detected on the host.",
+		},
+		{
+			event: Event{Type: WorkloadDisappeared, Workload: "Redis"},
+			want:  "Redis workload is no longer running on the host.",
+		},
+		{
+			event: Event{Type: RoleChanged, Workload: "MYSQL", Instance: "localhost:3306", Previous: "replica", Current: "source"},
+			want:  "MYSQL instance localhost:3306 role changed from replica to source.",
+		},
+		{
+			event: Event{Type: VersionChanged, Workload: "MONGODB", Previous: "6.0.4", Current: "7.0.1"},
+			want:  "MONGODB version changed from 6.0.4 to 7.0.1.",
+		},
+	}
+	for _, tc := range tests {
+		if got := tc.event.String(); got != tc.want {
+			t.Errorf("%#v.String() = %q, want %q", tc.event, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metricexport writes the metrics of the workload collectors to Cloud Monitoring.
+package metricexport
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/monitoring/apiv3"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/audit"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/metadataserver"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/timeseries"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const metricURL = "workload.googleapis.com"
+
+// invalidMetricChars matches the characters of a metric key which are not allowed in a metric type.
+var invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Exporter writes workload metrics to Cloud Monitoring as gauges.
+// A nil Exporter exports nothing, so collectors do not need to check whether the export is enabled.
+type Exporter struct {
+	creator    cloudmonitoring.TimeSeriesCreator
+	backOffs   *cloudmonitoring.BackOffIntervals
+	cloudProps *configpb.CloudProperties
+}
+
+// New returns an Exporter for the configuration, or nil if the Cloud Monitoring export is disabled.
+func New(ctx context.Context, config *configpb.Configuration) (*Exporter, error) {
+	if !config.GetCloudMonitoringExport().GetEnabled() {
+		return nil, nil
+	}
+	metricClient, err := monitoring.NewMetricClient(ctx, endpoints.MonitoringOptions(config.GetEndpoints())...)
+	if err != nil {
+		usagemetrics.Error(usagemetrics.MetricClientCreationFailure)
+		return nil, fmt.Errorf("creating metric service client: %w", err)
+	}
+	var creator cloudmonitoring.TimeSeriesCreator = metricClient
+	if l := audit.FromConfig(config); l != nil {
+		creator = audit.NewTimeSeriesCreator(creator, l, config.GetEndpoints())
+	}
+	return &Exporter{
+		creator:    creator,
+		backOffs:   cloudmonitoring.NewDefaultBackOffIntervals(),
+		cloudProps: config.GetCloudProperties(),
+	}, nil
+}
+
+// Export writes the integer, float and boolean metrics of wm to Cloud Monitoring, their value types
+// are taken from the schema of the workload. Metrics of any other type, such as versions or
+// settings, metrics which are not defined in the schema and unknown values are not exported.
+func (e *Exporter) Export(ctx context.Context, wm *workloadmanager.WorkloadMetrics, schema *workloadmanager.Schema) error {
+	if e == nil || wm == nil || schema == nil {
+		return nil
+	}
+	ts := e.timeSeries(wm, schema, tspb.Now())
+	if len(ts) == 0 {
+		return nil
+	}
+	sent, batchCount, err := cloudmonitoring.SendTimeSeries(ctx, ts, e.creator, e.backOffs, e.cloudProps.GetProjectId())
+	if err != nil {
+		return fmt.Errorf("sending %s metrics to Cloud Monitoring: %w", wm.WorkloadType, err)
+	}
+	log.CtxLogger(ctx).Debugw("Sent workload metrics to Cloud Monitoring", "workload", wm.WorkloadType, "sent", sent, "batches", batchCount)
+	return nil
+}
+
+// timeSeries converts the metrics of wm to gauges of the types defined in schema at timestamp.
+// The time series of a database instance are labelled with it.
+func (e *Exporter) timeSeries(wm *workloadmanager.WorkloadMetrics, schema *workloadmanager.Schema, timestamp *tspb.Timestamp) []*mrpb.TimeSeries {
+	prefix := metricURL + "/" + strings.ToLower(string(wm.WorkloadType)) + "/"
+	var labels map[string]string
+	if instance := wm.Metrics[workloadmanager.DatabaseInstanceKey]; instance != "" {
+		labels = map[string]string{workloadmanager.DatabaseInstanceKey: instance}
+	}
+
+	keys := make([]string, 0, len(wm.Metrics))
+	for k := range wm.Metrics {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ts []*mrpb.TimeSeries
+	for _, k := range keys {
+		d, ok := schema.Definition(k)
+		if !ok {
+			continue
+		}
+		p := timeseries.Params{
+			CloudProp:    convertCloudProperties(e.cloudProps),
+			MetricType:   prefix + invalidMetricChars.ReplaceAllString(k, "_"),
+			MetricLabels: labels,
+			Timestamp:    timestamp,
+		}
+		v := wm.Metrics[k]
+		switch d.Type {
+		case workloadmanager.IntMetric:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				p.Int64Value = i
+				ts = append(ts, timeseries.BuildInt(p))
+			}
+		case workloadmanager.FloatMetric:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				p.Float64Value = f
+				ts = append(ts, timeseries.BuildFloat64(p))
+			}
+		case workloadmanager.BoolMetric:
+			if b, err := strconv.ParseBool(v); err == nil {
+				p.BoolValue = b
+				ts = append(ts, timeseries.BuildBool(p))
+			}
+		}
+	}
+	return ts
+}
+
+// convertCloudProperties converts Cloud Properties proto to CloudProperties struct.
+func convertCloudProperties(cp *configpb.CloudProperties) *metadataserver.CloudProperties {
+	return &metadataserver.CloudProperties{
+		ProjectID:        cp.GetProjectId(),
+		InstanceID:       cp.GetInstanceId(),
+		Zone:             cp.GetZone(),
+		InstanceName:     cp.GetInstanceName(),
+		Image:            cp.GetImage(),
+		NumericProjectID: cp.GetNumericProjectId(),
+		Region:           cp.GetRegion(),
+	}
+}
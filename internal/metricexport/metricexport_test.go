@@ -0,0 +1,175 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricexport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	cmfake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring/fake"
+)
+
+// point summarizes an exported time series.
+type point struct {
+	MetricType string
+	Labels     map[string]string
+	Value      any
+}
+
+// testSchema defines the metrics of the tests.
+var testSchema = workloadmanager.NewSchema(
+	workloadmanager.MetricDefinition{Name: "buffer_pool_size", Type: workloadmanager.IntMetric},
+	workloadmanager.MetricDefinition{Name: "buffer_pool_hit_ratio", Type: workloadmanager.FloatMetric, Unit: workloadmanager.UnitRatio},
+	workloadmanager.MetricDefinition{Name: "innodb", Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: "version", Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: "replication-lag", Type: workloadmanager.IntMetric},
+	workloadmanager.MetricDefinition{Name: "maxmemory", Type: workloadmanager.IntMetric},
+	workloadmanager.MetricDefinition{Name: "work_mem", Type: workloadmanager.IntMetric},
+)
+
+func points(calls []*mrpb.CreateTimeSeriesRequest) []point {
+	var got []point
+	for _, req := range calls {
+		for _, ts := range req.GetTimeSeries() {
+			var value any
+			switch v := ts.GetPoints()[0].GetValue().GetValue().(type) {
+			case *mrpb.TypedValue_Int64Value:
+				value = v.Int64Value
+			case *mrpb.TypedValue_DoubleValue:
+				value = v.DoubleValue
+			case *mrpb.TypedValue_BoolValue:
+				value = v.BoolValue
+			}
+			got = append(got, point{MetricType: ts.GetMetric().GetType(), Labels: ts.GetMetric().GetLabels(), Value: value})
+		}
+	}
+	return got
+}
+
+func TestExport(t *testing.T) {
+	tests := []struct {
+		name       string
+		metrics    *workloadmanager.WorkloadMetrics
+		creatorErr error
+		want       []point
+		wantErr    bool
+	}{
+		{
+			name: "NumericAndBooleanMetrics",
+			metrics: &workloadmanager.WorkloadMetrics{
+				WorkloadType: workloadmanager.MYSQL,
+				Metrics: map[string]string{
+					"buffer_pool_size":                  "134217728",
+					"buffer_pool_hit_ratio":             "0.95",
+					"innodb":                            "true",
+					"version":                           "8.0.32",
+					"replication-lag":                   "3",
+					workloadmanager.DatabaseInstanceKey: "10.0.0.1:3306",
+				},
+			},
+			want: []point{
+				{MetricType: "workload.googleapis.com/mysql/buffer_pool_hit_ratio", Labels: map[string]string{"database_instance": "10.0.0.1:3306"}, Value: 0.95},
+				{MetricType: "workload.googleapis.com/mysql/buffer_pool_size", Labels: map[string]string{"database_instance": "10.0.0.1:3306"}, Value: int64(134217728)},
+				{MetricType: "workload.googleapis.com/mysql/innodb", Labels: map[string]string{"database_instance": "10.0.0.1:3306"}, Value: true},
+				{MetricType: "workload.googleapis.com/mysql/replication_lag", Labels: map[string]string{"database_instance": "10.0.0.1:3306"}, Value: int64(3)},
+			},
+		},
+		{
+			name: "ValueTypesFromSchema",
+			metrics: &workloadmanager.WorkloadMetrics{
+				WorkloadType: workloadmanager.MYSQL,
+				Metrics: map[string]string{
+					"buffer_pool_hit_ratio": "0",
+					"buffer_pool_size":      "",
+					"innodb":                "false",
+					"undefined":             "1",
+				},
+			},
+			want: []point{
+				{MetricType: "workload.googleapis.com/mysql/buffer_pool_hit_ratio", Value: float64(0)},
+				{MetricType: "workload.googleapis.com/mysql/innodb", Value: false},
+			},
+		},
+		{
+			name: "NoDatabaseInstance",
+			metrics: &workloadmanager.WorkloadMetrics{
+				WorkloadType: workloadmanager.REDIS,
+				Metrics:      map[string]string{"maxmemory": "1024"},
+			},
+			want: []point{
+				{MetricType: "workload.googleapis.com/redis/maxmemory", Value: int64(1024)},
+			},
+		},
+		{
+			name: "NoNumericMetrics",
+			metrics: &workloadmanager.WorkloadMetrics{
+				WorkloadType: workloadmanager.MONGODB,
+				Metrics:      map[string]string{"version": "7.0.2"},
+			},
+		},
+		{
+			name: "CreateTimeSeriesError",
+			metrics: &workloadmanager.WorkloadMetrics{
+				WorkloadType: workloadmanager.POSTGRES,
+				Metrics:      map[string]string{"work_mem": "4096"},
+			},
+			creatorErr: errors.New("permission denied"),
+			want: []point{
+				{MetricType: "workload.googleapis.com/postgres/work_mem", Value: int64(4096)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			creator := &cmfake.TimeSeriesCreator{Err: tc.creatorErr}
+			e := &Exporter{
+				creator:    creator,
+				backOffs:   cloudmonitoring.NoBackOff(),
+				cloudProps: &configpb.CloudProperties{ProjectId: "test-project", InstanceId: "123", Zone: "us-central1-a"},
+			}
+			err := e.Export(context.Background(), tc.metrics, testSchema)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Export() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, points(creator.Calls)); diff != "" {
+				t.Errorf("Export() exported unexpected time series (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExportNil(t *testing.T) {
+	var e *Exporter
+	if err := e.Export(context.Background(), &workloadmanager.WorkloadMetrics{Metrics: map[string]string{"a": "1"}}, testSchema); err != nil {
+		t.Errorf("Export() on a nil Exporter returned error %v, want nil", err)
+	}
+}
+
+func TestNewDisabled(t *testing.T) {
+	e, err := New(context.Background(), &configpb.Configuration{})
+	if e != nil || err != nil {
+		t.Errorf("New() with the export disabled = (%v, %v), want (nil, nil)", e, err)
+	}
+}
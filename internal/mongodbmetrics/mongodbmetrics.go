@@ -19,13 +19,25 @@ package mongodbmetrics
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/connectionsecret"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/tlsconfig"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
@@ -34,9 +46,18 @@ import (
 )
 
 const (
-	versionKey = "version"
+	versionKey  = "version"
+	defaultHost = "localhost"
+	defaultPort = 27017
 )
 
+// authMechanisms maps the configured authentication mechanisms to their connection string names.
+var authMechanisms = map[configpb.MongoDBConfiguration_AuthMechanism]string{
+	configpb.MongoDBConfiguration_SCRAM_SHA_256: "SCRAM-SHA-256",
+	configpb.MongoDBConfiguration_SCRAM_SHA_1:   "SCRAM-SHA-1",
+	configpb.MongoDBConfiguration_MONGODB_X509:  "MONGODB-X509",
+}
+
 type gceInterface interface {
 	GetSecret(ctx context.Context, projectID, secretName string) (string, error)
 }
@@ -51,6 +72,23 @@ type MongoDBMetrics struct {
 	RunCommand     func(ctx context.Context, client *mongo.Client, dbName string, cmd bson.D, resultStruct any) (any, error)
 }
 
+// resolveConnectionSecret replaces the connection parameters with the ones in their secret
+// if it is a JSON connection secret. The configuration is copied, not modified.
+func (m *MongoDBMetrics) resolveConnectionSecret(ctx context.Context, gceService gceInterface) error {
+	cp := m.Config.GetMongoDbConfiguration().GetConnectionParameters()
+	resolved, err := connectionsecret.Resolve(ctx, cp, gceService)
+	if err != nil {
+		return fmt.Errorf("resolving connection secret: %w", err)
+	}
+	if resolved == cp {
+		return nil
+	}
+	config := proto.Clone(m.Config).(*configpb.Configuration)
+	config.MongoDbConfiguration.ConnectionParameters = resolved
+	m.Config = config
+	return nil
+}
+
 // password gets the password for the MongoDB database.
 // If the password is set in the configuration, it is used directly (not recommended).
 // Otherwise, if the secret configuration is set, the secret is fetched from GCE.
@@ -85,42 +123,105 @@ func New(ctx context.Context, config *configpb.Configuration, wlmClient workload
 func pingDB(ctx context.Context, client *mongo.Client) error {
 	err := client.Ping(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to ping MongoDB during db connection initialization: %w", err)
+		return agenterrors.New(agenterrors.Connection, fmt.Errorf("failed to ping MongoDB during db connection initialization: %w", err))
 	}
 	log.CtxLogger(ctx).Info("Successfully pinged MongoDB database.")
 	return nil
 }
 
+// host returns the configured MongoDB host.
+func (m *MongoDBMetrics) host() string {
+	if host := m.Config.GetMongoDbConfiguration().GetConnectionParameters().GetHost(); host != "" {
+		return host
+	}
+	return defaultHost
+}
+
+// address returns the host:port of the configured MongoDB database.
+func (m *MongoDBMetrics) address() string {
+	port := m.Config.GetMongoDbConfiguration().GetConnectionParameters().GetPort()
+	if port == 0 {
+		port = defaultPort
+	}
+	return net.JoinHostPort(m.host(), strconv.Itoa(int(port)))
+}
+
+// uri returns the connection string for the configured authentication mechanism.
+// x.509 authentication takes the user from the client certificate, so no password is sent.
+func (m *MongoDBMetrics) uri(pw secret.String) string {
+	cfg := m.Config.GetMongoDbConfiguration()
+	user := cfg.GetConnectionParameters().GetUsername()
+	u := &url.URL{Scheme: "mongodb", Host: m.address()}
+	if cfg.GetAuthMechanism() == configpb.MongoDBConfiguration_MONGODB_X509 {
+		if user != "" {
+			u.User = url.User(user)
+		}
+	} else {
+		u.User = url.UserPassword(user, pw.SecretValue())
+	}
+	if mechanism, ok := authMechanisms[cfg.GetAuthMechanism()]; ok {
+		u.Path = "/"
+		u.RawQuery = url.Values{"authMechanism": {mechanism}}.Encode()
+	}
+	return u.String()
+}
+
+// clientOptions returns the options to connect to MongoDB with the given URI.
+func clientOptions(uri string, tlsConfig *tls.Config, serverSelectionTimeout time.Duration) *options.ClientOptions {
+	clientOptions := options.Client().ApplyURI(uri)
+	clientOptions.SetServerSelectionTimeout(serverSelectionTimeout)
+	if tlsConfig != nil {
+		clientOptions.SetTLSConfig(tlsConfig)
+	}
+	return clientOptions
+}
+
 // InitDB initializes the MongoDB database connection.
 func (m *MongoDBMetrics) InitDB(ctx context.Context, gceService gceInterface, serverSelectionTimeout time.Duration) error {
-	var err error
-	// Set client options
-	clientOptions := options.Client().ApplyURI("mongodb://localhost:27017")
-	clientOptions.SetServerSelectionTimeout(serverSelectionTimeout)
-	m.mongoClient, err = mongo.Connect(clientOptions)
+	if err := m.resolveConnectionSecret(ctx, gceService); err != nil {
+		return agenterrors.New(agenterrors.Authentication, err)
+	}
+	cfg := m.Config.GetMongoDbConfiguration()
+	hasClientCert := cfg.GetTls().GetCertFile() != "" || cfg.GetTls().GetCertSecret() != nil
+	if cfg.GetAuthMechanism() == configpb.MongoDBConfiguration_MONGODB_X509 && (!cfg.GetTls().GetEnabled() || !hasClientCert) {
+		return errors.New("x.509 authentication requires TLS with a client certificate")
+	}
+	// The TLS configuration reloads a rotated client certificate for new connections.
+	tlsConfig, err := tlsconfig.NewWithSecrets(ctx, cfg.GetTls(), m.host(), gceService)
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
+	}
+	m.mongoClient, err = mongo.Connect(clientOptions((&url.URL{Scheme: "mongodb", Host: m.address()}).String(), tlsConfig, serverSelectionTimeout))
 	if err == nil && pingDB(ctx, m.mongoClient) == nil {
 		log.CtxLogger(ctx).Debug("Was able to connect to MongoDB without credentials.")
 	}
 	// if authorization is enabled then we need to provide the username and password.
-	user := m.Config.GetMongoDbConfiguration().GetConnectionParameters().GetUsername()
-	pw, err := m.password(ctx, gceService)
-	if err != nil {
-		return fmt.Errorf("getting password from configuration or secret manager failed: %w", err)
+	var pw secret.String
+	if cfg.GetAuthMechanism() != configpb.MongoDBConfiguration_MONGODB_X509 {
+		if pw, err = m.password(ctx, gceService); err != nil {
+			return agenterrors.New(agenterrors.Authentication, fmt.Errorf("getting password from configuration or secret manager failed: %w", err))
+		}
 	}
-	clientOptions = options.Client().ApplyURI(fmt.Sprintf("mongodb://%s:%s@localhost:27017", user, pw.SecretValue()))
-	clientOptions.SetServerSelectionTimeout(serverSelectionTimeout)
-	m.mongoClient, err = mongo.Connect(clientOptions)
+	uri := m.uri(pw)
+	m.mongoClient, err = mongo.Connect(clientOptions(uri, tlsConfig, serverSelectionTimeout))
 	if err != nil {
-		return fmt.Errorf("failed to connect to MongoDB: %w", err)
+		return agenterrors.New(agenterrors.Connection, fmt.Errorf("failed to connect to MongoDB: %w", secretredact.Error(err, uri, pw.SecretValue())))
 	}
-	return pingDB(ctx, m.mongoClient)
+	return secretredact.Error(pingDB(ctx, m.mongoClient), uri, pw.SecretValue())
 }
 
 // DefaultRunCommand is the default implementation of the RunCommand function.
 // It uses the Decode method of the SingleResult to decode the result into the receiver.
 func DefaultRunCommand(ctx context.Context, client *mongo.Client, dbName string, cmd bson.D, receiver any) (any, error) {
 	db := client.Database(dbName)
+	start := time.Now()
 	err := db.RunCommand(ctx, cmd).Decode(&receiver)
+	// Commands are identified by their name, the first element of the command document.
+	var name string
+	if len(cmd) > 0 {
+		name = cmd[0].Key
+	}
+	querystats.Observe("mongodb", name, time.Since(start), err)
 	return receiver, err
 }
 
@@ -129,7 +230,7 @@ func (m *MongoDBMetrics) version(ctx context.Context) (string, error) {
 	res, err := m.RunCommand(ctx, m.mongoClient, "admin", bson.D{bson.E{Key: "buildInfo", Value: 1}}, result)
 	if err != nil {
 		log.CtxLogger(ctx).Warnf("Failed to get db version: %w", err)
-		return "", err
+		return "", agenterrors.New(agenterrors.Query, err)
 	}
 	var version string
 	for _, element := range res.(bson.D) {
@@ -146,7 +247,11 @@ func (m *MongoDBMetrics) version(ctx context.Context) (string, error) {
 }
 
 // CollectMetricsOnce collects metrics for MongoDB databases running on the host.
+// Failures of single metrics or collection steps are recorded in the Errors of the returned metrics
+// and the metrics which were collected are still sent. An error is returned if the metrics could not
+// be collected at all or could not be sent, in the latter case together with the collected metrics.
 func (m *MongoDBMetrics) CollectMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
+	start := time.Now()
 	version, err := m.version(ctx)
 	if err != nil {
 		log.CtxLogger(ctx).Warnf("Failed to get work mem: %w", err)
@@ -158,18 +263,27 @@ func (m *MongoDBMetrics) CollectMetricsOnce(ctx context.Context, dwActivated boo
 		Metrics: map[string]string{
 			versionKey: version,
 		},
+		CollectionTime: start,
+	}
+	replicationMetrics, err := m.replicationMetrics(ctx, ipinfo.DefaultZoneResolver())
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get replication zones", "error", err)
 	}
+	metrics.AddMetrics("replication", replicationMetrics, err)
+	metrics.CollectionDuration = time.Since(start)
 	if !dwActivated {
 		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
 		return &metrics, nil
 	}
 	res, err := workloadmanager.SendDataInsight(ctx, workloadmanager.SendDataInsightParams{
-		WLMetrics:  metrics,
-		CloudProps: m.Config.GetCloudProperties(),
-		WLMService: m.WLMClient,
+		WLMetrics:           metrics,
+		CloudProps:          m.Config.GetCloudProperties(),
+		WLMService:          m.WLMClient,
+		StrictWorkloadTypes: m.Config.GetDataWarehouseStrictWorkloadTypes(),
+		Schema:              metricSchema,
 	})
 	if err != nil {
-		return nil, err
+		return &metrics, err
 	}
 	if res == nil {
 		log.CtxLogger(ctx).Warn("SendDataInsight did not return an error but the WriteInsight response is nil")
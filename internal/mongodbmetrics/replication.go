@@ -0,0 +1,89 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+)
+
+const replicationZonesKey = "replication_zones"
+
+// replicaSetMembers returns the hosts of the other members of the replica set.
+func (m *MongoDBMetrics) replicaSetMembers(ctx context.Context) ([]string, error) {
+	var result any
+	res, err := m.RunCommand(ctx, m.mongoClient, "admin", bson.D{bson.E{Key: "replSetGetStatus", Value: 1}}, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replica set status: %w", err)
+	}
+	status, ok := res.(bson.D)
+	if !ok {
+		return nil, fmt.Errorf("unexpected replica set status type %T", res)
+	}
+	var members bson.A
+	for _, element := range status {
+		if element.Key == "members" {
+			members, _ = element.Value.(bson.A)
+			break
+		}
+	}
+	if len(members) == 0 {
+		return nil, errors.New("replica set status contains no members")
+	}
+
+	var hosts []string
+	for _, member := range members {
+		doc, ok := member.(bson.D)
+		if !ok {
+			continue
+		}
+		var name string
+		var self bool
+		for _, element := range doc {
+			switch element.Key {
+			case "name":
+				name, _ = element.Value.(string)
+			case "self":
+				self, _ = element.Value.(bool)
+			}
+		}
+		if self || name == "" {
+			continue
+		}
+		host, _, err := net.SplitHostPort(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse replica set member %q: %w", name, err)
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// replicationMetrics returns the zones of the other members of the replica set.
+func (m *MongoDBMetrics) replicationMetrics(ctx context.Context, zoneResolver *ipinfo.ZoneResolver) (map[string]string, error) {
+	hosts, err := m.replicaSetMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{replicationZonesKey: strings.Join(zoneResolver.Zones(ctx, hosts), ",")}, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbmetrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+)
+
+// fakeResolver maps IPs to their host names and resolves no host names.
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupHost(host string) ([]string, error) {
+	return nil, errors.New("no such host")
+}
+
+func (f fakeResolver) ParseIP(ip string) net.IP {
+	return net.ParseIP(ip)
+}
+
+func (f fakeResolver) LookupAddr(ip string) ([]string, error) {
+	return f[ip], nil
+}
+
+func member(name string, self bool) bson.D {
+	return bson.D{{Key: "name", Value: name}, {Key: "self", Value: self}}
+}
+
+func TestReplicationMetrics(t *testing.T) {
+	resolver := fakeResolver{
+		"10.0.0.1": {"primary.us-central1-a.c.fake-project.internal."},
+		"10.0.0.2": {"secondary-1.us-central1-b.c.fake-project.internal."},
+		"10.0.0.3": {"secondary-2.us-central1-c.c.fake-project.internal."},
+	}
+	tests := []struct {
+		name    string
+		status  any
+		err     error
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "ReplicaSet",
+			status: bson.D{
+				{Key: "set", Value: "rs0"},
+				{Key: "members", Value: bson.A{
+					member("10.0.0.1:27017", true),
+					member("10.0.0.2:27017", false),
+					member("10.0.0.3:27017", false),
+				}},
+			},
+			want: map[string]string{replicationZonesKey: "us-central1-b,us-central1-c"},
+		},
+		{
+			name: "UnresolvedMembers",
+			status: bson.D{
+				{Key: "members", Value: bson.A{
+					member("10.0.0.1:27017", true),
+					member("10.0.0.4:27017", false),
+				}},
+			},
+			want: map[string]string{replicationZonesKey: ""},
+		},
+		{
+			name:    "NotReplicaSet",
+			err:     errors.New("not running with --replSet"),
+			wantErr: true,
+		},
+		{
+			name:    "NoMembers",
+			status:  bson.D{{Key: "set", Value: "rs0"}},
+			wantErr: true,
+		},
+		{
+			name:    "UnexpectedType",
+			status:  "rs0",
+			wantErr: true,
+		},
+		{
+			name: "InvalidMemberName",
+			status: bson.D{
+				{Key: "members", Value: bson.A{member("10.0.0.2", false)}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MongoDBMetrics{
+				RunCommand: func(ctx context.Context, client *mongo.Client, dbName string, cmd bson.D, receiver any) (any, error) {
+					return tc.status, tc.err
+				},
+			}
+			got, err := m.replicationMetrics(context.Background(), ipinfo.NewZoneResolver(resolver))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("replicationMetrics() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("replicationMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mongodbmetrics
+
+import "github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+
+// metricSchema defines the metrics sent to Data Warehouse for MongoDB.
+var metricSchema = workloadmanager.NewSchema(
+	workloadmanager.MetricDefinition{Name: versionKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: replicationZonesKey, Type: workloadmanager.ListMetric},
+)
+
+// MetricSchema returns the schema of the metrics sent to Data Warehouse for MongoDB.
+func MetricSchema() *workloadmanager.Schema {
+	return metricSchema
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	lastBackupTimestampKey = "last_backup_timestamp"
+	// backupNamespace holds the most recent completed backup of each MySQL instance in the state store.
+	backupNamespace = "mysql_backup"
+	// The history table only exists if xtrabackup was run with the --history option.
+	xtrabackupHistoryQuery = "SELECT UNIX_TIMESTAMP(MAX(end_time)) FROM PERCONA_SCHEMA.xtrabackup_history"
+)
+
+// backupProcessNames are the executables which take MySQL backups.
+// mysqlsh is only considered a backup process when running one of the dump utilities.
+var backupProcessNames = map[string]bool{
+	"mysqldump":   true,
+	"mysqlpump":   true,
+	"xtrabackup":  true,
+	"mariabackup": true,
+	"mysqlsh":     true,
+}
+
+// processInfo holds the attributes of a running process used to detect backups.
+type processInfo struct {
+	name    string
+	cmdline []string
+	started time.Time
+}
+
+// listProcesses returns the processes running on the host.
+// Processes which exit while they are being listed are skipped.
+func listProcesses(ctx context.Context) ([]processInfo, error) {
+	ps, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var processes []processInfo
+	for _, p := range ps {
+		name, err := p.NameWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		cmdline, err := p.CmdlineSliceWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		createTime, err := p.CreateTimeWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		processes = append(processes, processInfo{name: name, cmdline: cmdline, started: time.UnixMilli(createTime)})
+	}
+	return processes, nil
+}
+
+// isBackupProcess reports whether p is taking a MySQL backup.
+func isBackupProcess(p processInfo) bool {
+	name := strings.TrimSuffix(strings.ToLower(filepath.Base(p.name)), ".exe")
+	if !backupProcessNames[name] {
+		return false
+	}
+	if name != "mysqlsh" {
+		return true
+	}
+	// For example util.dumpInstance(), util.dumpSchemas() or util dump-instance.
+	return strings.Contains(strings.ToLower(strings.Join(p.cmdline, " ")), "dump")
+}
+
+// runningBackupStarted returns the start time of the oldest backup process running on the host,
+// or the zero time if no backup is running or the processes can't be listed.
+func (m *MySQLMetrics) runningBackupStarted(ctx context.Context) time.Time {
+	if m.listProcesses == nil {
+		return time.Time{}
+	}
+	processes, err := m.listProcesses(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to list processes for MySQL backup detection", "error", err)
+		return time.Time{}
+	}
+	var oldest time.Time
+	for _, p := range processes {
+		if isBackupProcess(p) && (oldest.IsZero() || p.started.Before(oldest)) {
+			log.CtxLogger(ctx).Debugw("Found running MySQL backup process", "name", p.name, "started", p.started)
+			oldest = p.started
+		}
+	}
+	return oldest
+}
+
+// backupDirectoryModified returns the most recent modification time of the entries in the
+// configured backup directories. Entries modified at or after before are skipped unless before is zero.
+func (m *MySQLMetrics) backupDirectoryModified(ctx context.Context, before time.Time) time.Time {
+	var latest time.Time
+	for _, dir := range m.Config.GetMysqlConfiguration().GetBackupDirectories() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Failed to read MySQL backup directory", "directory", dir, "error", err)
+			continue
+		}
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			if !before.IsZero() && !info.ModTime().Before(before) {
+				continue
+			}
+			if info.ModTime().After(latest) {
+				latest = info.ModTime()
+			}
+		}
+	}
+	return latest
+}
+
+// xtrabackupHistoryEnd returns the end time of the most recent backup recorded by Percona XtraBackup.
+func (m *MySQLMetrics) xtrabackupHistoryEnd(ctx context.Context) (time.Time, error) {
+	rows, err := executeQuery(ctx, m.db, xtrabackupHistoryQuery)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query xtrabackup_history with error: %v", err)
+	}
+	if rows == nil {
+		return time.Time{}, fmt.Errorf("no rows returned from xtrabackup_history query")
+	}
+	defer rows.Close()
+
+	var endTime sql.NullInt64
+	if rows.Next() {
+		if err := rows.Scan(&endTime); err != nil {
+			return time.Time{}, fmt.Errorf("failed to scan row from xtrabackup_history with error: %v", err)
+		}
+	}
+	if !endTime.Valid {
+		return time.Time{}, nil
+	}
+	return time.Unix(endTime.Int64, 0), nil
+}
+
+// recordedBackup returns the completion time of the most recent backup recorded for the instance,
+// reading it from the state store after an agent restart.
+func (m *MySQLMetrics) recordedBackup(ctx context.Context) time.Time {
+	if !m.lastBackup.IsZero() || m.store == nil {
+		return m.lastBackup
+	}
+	if _, err := m.store.Get(backupNamespace, m.address(), &m.lastBackup); err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to read the last MySQL backup from the state store", "error", err)
+	}
+	return m.lastBackup
+}
+
+// recordBackup records completed as the completion time of the most recent backup of the instance.
+func (m *MySQLMetrics) recordBackup(ctx context.Context, completed time.Time) {
+	log.CtxLogger(ctx).Debugw("Recording completed MySQL backup", "completed", completed)
+	m.lastBackup = completed
+	if m.store == nil {
+		return
+	}
+	if err := m.store.Set(backupNamespace, m.address(), completed); err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to write the last MySQL backup to the state store", "error", err)
+	}
+}
+
+// lastBackupTimestamp returns the time of the most recent completed MySQL backup in RFC 3339
+// format, or an empty string if no backup was detected.
+//
+// A backup is completed once it is recorded in the Percona XtraBackup history, or once it is written
+// to a backup directory and no backup process is running anymore. Files written since a running
+// backup started are skipped, as they may belong to that backup. Completed backups are kept in the
+// state store, so they are still reported after the backup files are rotated or the agent restarts.
+func (m *MySQLMetrics) lastBackupTimestamp(ctx context.Context) string {
+	latest := m.recordedBackup(ctx)
+	completed := m.backupDirectoryModified(ctx, m.runningBackupStarted(ctx))
+	historyEnd, err := m.xtrabackupHistoryEnd(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to read Percona XtraBackup history", "error", err)
+	}
+	if historyEnd.After(completed) {
+		completed = historyEnd
+	}
+	if completed.After(latest) {
+		m.recordBackup(ctx, completed)
+		latest = completed
+	}
+	if latest.IsZero() {
+		return ""
+	}
+	return latest.UTC().Format(time.RFC3339)
+}
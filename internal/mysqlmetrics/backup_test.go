@@ -0,0 +1,332 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+type xtrabackupHistoryRows struct {
+	count     int
+	size      int
+	data      sql.NullInt64
+	shouldErr bool
+}
+
+func (f *xtrabackupHistoryRows) Scan(dest ...any) error {
+	if f.shouldErr {
+		return errors.New("test-error")
+	}
+	*dest[0].(*sql.NullInt64) = f.data
+	return nil
+}
+
+func (f *xtrabackupHistoryRows) Next() bool {
+	f.count++
+	return f.count <= f.size
+}
+
+func (f *xtrabackupHistoryRows) Close() error {
+	return nil
+}
+
+func fakeProcesses(processes []processInfo, err error) func(context.Context) ([]processInfo, error) {
+	return func(context.Context) ([]processInfo, error) {
+		return processes, err
+	}
+}
+
+func TestIsBackupProcess(t *testing.T) {
+	tests := []struct {
+		name string
+		p    processInfo
+		want bool
+	}{
+		{
+			name: "Mysqldump",
+			p:    processInfo{name: "mysqldump", cmdline: []string{"mysqldump", "--all-databases"}},
+			want: true,
+		},
+		{
+			name: "XtrabackupWindows",
+			p:    processInfo{name: "xtrabackup.exe"},
+			want: true,
+		},
+		{
+			name: "MysqlshDump",
+			p:    processInfo{name: "mysqlsh", cmdline: []string{"mysqlsh", "--", "util", "dump-instance", "/backups"}},
+			want: true,
+		},
+		{
+			name: "MysqlshInteractive",
+			p:    processInfo{name: "mysqlsh", cmdline: []string{"mysqlsh", "root@localhost"}},
+			want: false,
+		},
+		{
+			name: "Mysqld",
+			p:    processInfo{name: "mysqld"},
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isBackupProcess(tc.p); got != tc.want {
+				t.Errorf("isBackupProcess(%v) = %t, want %t", tc.p, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunningBackupStarted(t *testing.T) {
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name          string
+		listProcesses func(context.Context) ([]processInfo, error)
+		want          time.Time
+	}{
+		{
+			name: "OldestBackupProcess",
+			listProcesses: fakeProcesses([]processInfo{
+				{name: "mysqld", started: started.Add(-time.Hour)},
+				{name: "xtrabackup", started: started.Add(time.Hour)},
+				{name: "mysqldump", started: started},
+			}, nil),
+			want: started,
+		},
+		{
+			name:          "NoBackupProcess",
+			listProcesses: fakeProcesses([]processInfo{{name: "mysqld", started: started}}, nil),
+		},
+		{
+			name:          "ListError",
+			listProcesses: fakeProcesses(nil, errors.New("test-error")),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{listProcesses: tc.listProcesses}
+			if got := m.runningBackupStarted(context.Background()); !got.Equal(tc.want) {
+				t.Errorf("runningBackupStarted() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// writeBackupFiles creates files in dir with the given modification times.
+func writeBackupFiles(t *testing.T, dir string, files map[string]time.Time) {
+	t.Helper()
+	for name, modified := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, nil, 0600); err != nil {
+			t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+		}
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatalf("os.Chtimes(%s) returned unexpected error: %v", path, err)
+		}
+	}
+}
+
+func TestBackupDirectoryModified(t *testing.T) {
+	dir := t.TempDir()
+	want := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	writeBackupFiles(t, dir, map[string]time.Time{
+		"backup-1.sql": want.Add(-24 * time.Hour),
+		"backup-2.sql": want,
+	})
+	m := &MySQLMetrics{
+		Config: &configpb.Configuration{
+			MysqlConfiguration: &configpb.MySQLConfiguration{
+				BackupDirectories: []string{filepath.Join(dir, "missing"), dir},
+			},
+		},
+	}
+
+	if got := m.backupDirectoryModified(context.Background(), time.Time{}); !got.Equal(want) {
+		t.Errorf("backupDirectoryModified() = %v, want %v", got, want)
+	}
+	before := want.Add(-24 * time.Hour)
+	if got := m.backupDirectoryModified(context.Background(), want); !got.Equal(before) {
+		t.Errorf("backupDirectoryModified(%v) = %v, want %v", want, got, before)
+	}
+	if got := m.backupDirectoryModified(context.Background(), before); !got.IsZero() {
+		t.Errorf("backupDirectoryModified(%v) = %v, want zero time", before, got)
+	}
+}
+
+func TestXtrabackupHistoryEnd(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name: "HistoryFound",
+			db:   &testDB{xtrabackupHistoryRows: &xtrabackupHistoryRows{size: 1, data: sql.NullInt64{Int64: 1735787045, Valid: true}}},
+			want: time.Unix(1735787045, 0),
+		},
+		{
+			name: "EmptyHistory",
+			db:   &testDB{xtrabackupHistoryRows: &xtrabackupHistoryRows{size: 1}},
+		},
+		{
+			name:    "TableMissing",
+			db:      &testDB{xtrabackupHistoryErr: errors.New("Error 1146: Table 'PERCONA_SCHEMA.xtrabackup_history' doesn't exist")},
+			wantErr: true,
+		},
+		{
+			name:    "NilRows",
+			db:      &testDB{},
+			wantErr: true,
+		},
+		{
+			name:    "ScanError",
+			db:      &testDB{xtrabackupHistoryRows: &xtrabackupHistoryRows{size: 1, shouldErr: true}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{db: tc.db}
+			got, err := m.xtrabackupHistoryEnd(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("xtrabackupHistoryEnd() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("xtrabackupHistoryEnd() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLastBackupTimestamp(t *testing.T) {
+	completed := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	running := time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC)
+	historyEnd := time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	writeBackupFiles(t, dir, map[string]time.Time{
+		"completed.sql": completed,
+		"running.sql":   running.Add(time.Minute),
+	})
+	config := &configpb.Configuration{
+		MysqlConfiguration: &configpb.MySQLConfiguration{BackupDirectories: []string{dir}},
+	}
+	tests := []struct {
+		name string
+		m    *MySQLMetrics
+		want string
+	}{
+		{
+			name: "NoBackups",
+			m:    &MySQLMetrics{db: &testDB{}},
+			want: "",
+		},
+		{
+			name: "RunningBackupIsNotCompleted",
+			m: &MySQLMetrics{
+				db:            &testDB{},
+				listProcesses: fakeProcesses([]processInfo{{name: "xtrabackup", started: running}}, nil),
+			},
+			want: "",
+		},
+		{
+			name: "BackupDirectory",
+			m: &MySQLMetrics{
+				Config:        config,
+				db:            &testDB{},
+				listProcesses: fakeProcesses(nil, nil),
+			},
+			want: "2025-01-03T00:01:00Z",
+		},
+		{
+			name: "FilesOfRunningBackupAreSkipped",
+			m: &MySQLMetrics{
+				Config:        config,
+				db:            &testDB{},
+				listProcesses: fakeProcesses([]processInfo{{name: "mysqldump", started: running}}, nil),
+			},
+			want: "2025-01-02T00:00:00Z",
+		},
+		{
+			name: "XtrabackupHistoryIsMoreRecent",
+			m: &MySQLMetrics{
+				Config: config,
+				db: &testDB{
+					xtrabackupHistoryRows: &xtrabackupHistoryRows{size: 1, data: sql.NullInt64{Int64: historyEnd.Unix(), Valid: true}},
+				},
+			},
+			want: "2025-01-04T00:00:00Z",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.m.lastBackupTimestamp(context.Background()); got != tc.want {
+				t.Errorf("lastBackupTimestamp() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLastBackupTimestampIsPersisted(t *testing.T) {
+	dir := t.TempDir()
+	completed := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	writeBackupFiles(t, dir, map[string]time.Time{"backup.sql": completed})
+	store := statestore.New(filepath.Join(t.TempDir(), "state.json"))
+	m := &MySQLMetrics{
+		Config: &configpb.Configuration{
+			MysqlConfiguration: &configpb.MySQLConfiguration{BackupDirectories: []string{dir}},
+		},
+		db:    &testDB{},
+		store: store,
+	}
+	want := "2025-01-02T00:00:00Z"
+	if got := m.lastBackupTimestamp(context.Background()); got != want {
+		t.Fatalf("lastBackupTimestamp() = %q, want %q", got, want)
+	}
+
+	// The agent restarts after the backup files were rotated.
+	if err := os.Remove(filepath.Join(dir, "backup.sql")); err != nil {
+		t.Fatalf("os.Remove() returned unexpected error: %v", err)
+	}
+	restarted := &MySQLMetrics{Config: m.Config, db: &testDB{}, store: store}
+	if got := restarted.lastBackupTimestamp(context.Background()); got != want {
+		t.Errorf("lastBackupTimestamp() after a restart = %q, want %q", got, want)
+	}
+
+	// Another instance doesn't report the backup.
+	other := &MySQLMetrics{
+		Config: &configpb.Configuration{
+			MysqlConfiguration: &configpb.MySQLConfiguration{
+				ConnectionParameters: &configpb.ConnectionParameters{Port: 3307},
+			},
+		},
+		db:    &testDB{},
+		store: store,
+	}
+	if got := other.lastBackupTimestamp(context.Background()); got != "" {
+		t.Errorf("lastBackupTimestamp() for another instance = %q, want empty", got)
+	}
+}
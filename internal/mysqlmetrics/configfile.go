@@ -0,0 +1,230 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// maxIncludeDepth bounds nested !include directives, which may form a cycle.
+const maxIncludeDepth = 10
+
+// Global option files read by mysqld, in the order they are read. Options read later take precedence.
+var (
+	linuxOptionFiles = []string{"/etc/my.cnf", "/etc/mysql/my.cnf", "/usr/etc/my.cnf"}
+	// The MySQL installer writes the option file of each server to its directory under ProgramData.
+	windowsOptionFiles = []string{`C:\Windows\my.ini`, `C:\Windows\my.cnf`, `C:\my.ini`, `C:\my.cnf`, `C:\ProgramData\MySQL\MySQL Server *\my.ini`}
+)
+
+// defaultOptionFiles returns the glob patterns of the global option files based on the operating system.
+func defaultOptionFiles() []string {
+	return capabilities.ByOS(linuxOptionFiles, windowsOptionFiles)
+}
+
+// isServerGroup returns whether mysqld reads the options of the option group,
+// e.g. [mysqld-8.0] is only read by MySQL 8.0 and [mariadb] only by MariaDB.
+func isServerGroup(group string) bool {
+	switch group {
+	case "mysqld", "server", "mariadb", "mariadbd":
+		return true
+	}
+	return strings.HasPrefix(group, "mysqld-") || strings.HasPrefix(group, "mariadb-")
+}
+
+// optionFileParser reads the server options of MySQL option files.
+type optionFileParser struct {
+	options map[string]string
+	files   []string
+}
+
+// parse reads the server options of the option file at path and the files it includes.
+// Option names are normalized to use underscores, and options without a value are set to "".
+func (p *optionFileParser) parse(path string, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf("option files are included more than %d levels deep", maxIncludeDepth)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	p.files = append(p.files, path)
+
+	group := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Option files edited with Notepad start with a byte order mark.
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+		case strings.HasPrefix(line, "!includedir "):
+			dir := strings.TrimSpace(strings.TrimPrefix(line, "!includedir "))
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				log.Logger.Debugw("Unable to read MySQL option file directory", "directory", dir, "error", err)
+				continue
+			}
+			for _, e := range entries {
+				if e.IsDir() || (filepath.Ext(e.Name()) != ".cnf" && filepath.Ext(e.Name()) != ".ini") {
+					continue
+				}
+				if err := p.parse(filepath.Join(dir, e.Name()), depth+1); err != nil {
+					return err
+				}
+			}
+		case strings.HasPrefix(line, "!include "):
+			if err := p.parse(strings.TrimSpace(strings.TrimPrefix(line, "!include ")), depth+1); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		case strings.HasPrefix(line, "["):
+			group = strings.ToLower(strings.Trim(line, "[] "))
+		case isServerGroup(group):
+			name, value, _ := strings.Cut(line, "=")
+			name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+			value = strings.TrimSpace(value)
+			if i := strings.Index(value, " #"); i >= 0 && !strings.HasPrefix(value, `"`) && !strings.HasPrefix(value, "'") {
+				value = strings.TrimSpace(value[:i])
+			}
+			p.options[name] = strings.Trim(value, `"'`)
+		}
+	}
+	return scanner.Err()
+}
+
+// readOptionFiles returns the server options set in the option files matching the glob patterns,
+// and the files they were read from. Option files which don't exist are skipped.
+func readOptionFiles(patterns []string) (map[string]string, []string, error) {
+	p := &optionFileParser{options: make(map[string]string)}
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid MySQL option file pattern %s: %w", pattern, err)
+		}
+		for _, path := range paths {
+			if err := p.parse(path, 0); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return nil, nil, fmt.Errorf("reading MySQL option file %s: %w", path, err)
+			}
+		}
+	}
+	if len(p.files) == 0 {
+		return nil, nil, errors.New("no MySQL option file found")
+	}
+	return p.options, p.files, nil
+}
+
+// configFileMetrics returns the metrics which can be read from the option files. Only options which
+// are set in the files are reported, the server defaults are not assumed.
+func configFileMetrics(options map[string]string) map[string]string {
+	metrics := make(map[string]string)
+	if v, ok := options["innodb_buffer_pool_size"]; ok {
+		if size, err := units.ParseMemory(v, units.Byte); err == nil {
+			metrics[bufferPoolKey] = strconv.FormatInt(size, 10)
+		}
+	}
+	if v, ok := options["default_storage_engine"]; ok {
+		metrics[innoDBKey] = strconv.FormatBool(strings.EqualFold(v, "InnoDB"))
+	}
+	if _, ok := options["log_bin"]; ok {
+		metrics[logBinKey] = "true"
+	}
+	if _, ok := options["skip_log_bin"]; ok {
+		metrics[logBinKey] = "false"
+	}
+	if _, ok := options["disable_log_bin"]; ok {
+		metrics[logBinKey] = "false"
+	}
+	for key, option := range map[string]string{
+		binlogFormatKey: "binlog_format",
+		gtidModeKey:     "gtid_mode",
+		syncBinlogKey:   "sync_binlog",
+	} {
+		if v, ok := options[option]; ok {
+			metrics[key] = strings.ToUpper(v)
+		}
+	}
+	if seconds := binlogExpireLogsSeconds(options); seconds != "" {
+		metrics[binlogExpireLogsSecondsKey] = seconds
+	}
+	if v, ok := options["datadir"]; ok && v != "" {
+		metrics[workloadmanager.DataDirectoriesKey] = filepath.Clean(v)
+	}
+	return metrics
+}
+
+// CollectConfigFileMetricsOnce sends the metrics read from the MySQL option files to Data Warehouse.
+// It is used instead of CollectWlmMetricsOnce if the agent could not connect to MySQL, so the insight
+// is labeled as file-derived and holds fewer metrics.
+func (m *MySQLMetrics) CollectConfigFileMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
+	start := time.Now()
+	options, files, err := readOptionFiles(m.optionFiles)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect MySQL metrics from option files: %w", err)
+	}
+	metrics := workloadmanager.WorkloadMetrics{
+		WorkloadType:   workloadmanager.MYSQL,
+		Metrics:        configFileMetrics(options),
+		CollectionTime: start,
+	}
+	metrics.Metrics[workloadmanager.MetricsSourceKey] = workloadmanager.MetricsSourceConfigFile
+	metrics.Metrics[workloadmanager.ConfigFilesKey] = strings.Join(slices.Compact(files), ",")
+	if totalRAM, err := m.totalRAM(ctx); err == nil {
+		metrics.Metrics[totalRAMKey] = strconv.Itoa(totalRAM)
+	} else {
+		metrics.SetError(totalRAMKey, err)
+	}
+	if len(m.Config.GetMysqlConfiguration().GetInstances()) > 0 {
+		metrics.Metrics[workloadmanager.DatabaseInstanceKey] = m.address()
+	}
+	log.CtxLogger(ctx).Debugw("Collected MySQL metrics from option files", "files", files, "metrics", metrics.Metrics)
+	metrics.AddRecommendations(ctx, recommendations(metrics.Metrics))
+	metrics.CollectionDuration = time.Since(start)
+	if !dwActivated {
+		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
+		return &metrics, nil
+	}
+	res, err := workloadmanager.SendDataInsight(ctx, workloadmanager.SendDataInsightParams{
+		WLMetrics:           metrics,
+		CloudProps:          m.Config.GetCloudProperties(),
+		WLMService:          m.WLMClient,
+		StrictWorkloadTypes: m.Config.GetDataWarehouseStrictWorkloadTypes(),
+		Schema:              metricSchema,
+	})
+	if err != nil {
+		return &metrics, err
+	}
+	if res == nil {
+		log.CtxLogger(ctx).Warn("SendDataInsight did not return an error but the WriteInsight response is nil")
+		return &metrics, nil
+	}
+	log.CtxLogger(ctx).Debugw("WriteInsight response", "StatusCode", res.HTTPStatusCode)
+	return &metrics, nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	gcefake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/fake"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+}
+
+func TestReadOptionFiles(t *testing.T) {
+	dir := t.TempDir()
+	confDir := filepath.Join(dir, "conf.d")
+	if err := os.Mkdir(confDir, 0755); err != nil {
+		t.Fatalf("os.Mkdir(%s) returned unexpected error: %v", confDir, err)
+	}
+	myCnf := filepath.Join(dir, "my.cnf")
+	writeFile(t, myCnf, fmt.Sprintf(`# Global options
+[client]
+port = 3307
+
+[mysqld]
+datadir = "/var/lib/mysql"
+innodb-buffer-pool-size = 1G # one gigabyte
+log_bin
+
+!includedir %s
+`, confDir))
+	writeFile(t, filepath.Join(confDir, "server.cnf"), `[mysqld-8.0]
+innodb_buffer_pool_size = 2G
+[mysqldump]
+quick
+`)
+	writeFile(t, filepath.Join(confDir, "README"), "[mysqld]\nskip-log-bin\n")
+	override := filepath.Join(dir, "override.cnf")
+	writeFile(t, override, "[server]\nbinlog_format=row\n")
+	serverDir := filepath.Join(dir, "MySQL Server 8.0")
+	if err := os.Mkdir(serverDir, 0755); err != nil {
+		t.Fatalf("os.Mkdir(%s) returned unexpected error: %v", serverDir, err)
+	}
+	myIni := filepath.Join(serverDir, "my.ini")
+	writeFile(t, myIni, "\ufeff[mysqld]\r\nport=3306\r\ndatadir=C:/ProgramData/MySQL/MySQL Server 8.0/Data\r\n")
+
+	tests := []struct {
+		name        string
+		paths       []string
+		wantOptions map[string]string
+		wantFiles   []string
+		wantErr     bool
+	}{
+		{
+			name:  "IncludedAndLaterFilesTakePrecedence",
+			paths: []string{filepath.Join(dir, "missing.cnf"), myCnf, override},
+			wantOptions: map[string]string{
+				"datadir":                 "/var/lib/mysql",
+				"innodb_buffer_pool_size": "2G",
+				"log_bin":                 "",
+				"binlog_format":           "row",
+			},
+			wantFiles: []string{myCnf, filepath.Join(confDir, "server.cnf"), override},
+		},
+		{
+			name:  "GlobWithByteOrderMark",
+			paths: []string{filepath.Join(dir, "MySQL Server *", "my.ini")},
+			wantOptions: map[string]string{
+				"port":    "3306",
+				"datadir": "C:/ProgramData/MySQL/MySQL Server 8.0/Data",
+			},
+			wantFiles: []string{myIni},
+		},
+		{
+			name:    "NoOptionFile",
+			paths:   []string{filepath.Join(dir, "missing.cnf")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			options, files, err := readOptionFiles(tc.paths)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("readOptionFiles(%v) returned error: %v, want error: %v", tc.paths, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantOptions, options); diff != "" {
+				t.Errorf("readOptionFiles(%v) returned unexpected diff (-want +got):\n%s", tc.paths, diff)
+			}
+			if diff := cmp.Diff(tc.wantFiles, files); diff != "" {
+				t.Errorf("readOptionFiles(%v) returned unexpected files diff (-want +got):\n%s", tc.paths, diff)
+			}
+		})
+	}
+}
+
+func TestConfigFileMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		want    map[string]string
+	}{
+		{
+			name: "AllOptions",
+			options: map[string]string{
+				"innodb_buffer_pool_size": "128M",
+				"default_storage_engine":  "innodb",
+				"log_bin":                 "mysql-bin",
+				"binlog_format":           "row",
+				"gtid_mode":               "on",
+				"sync_binlog":             "1",
+				"expire_logs_days":        "7",
+				"datadir":                 "/var/lib/mysql/",
+			},
+			want: map[string]string{
+				bufferPoolKey:                      "134217728",
+				innoDBKey:                          "true",
+				logBinKey:                          "true",
+				binlogFormatKey:                    "ROW",
+				gtidModeKey:                        "ON",
+				syncBinlogKey:                      "1",
+				binlogExpireLogsSecondsKey:         "604800",
+				workloadmanager.DataDirectoriesKey: "/var/lib/mysql",
+			},
+		},
+		{
+			name:    "SkipLogBin",
+			options: map[string]string{"log_bin": "", "skip_log_bin": ""},
+			want:    map[string]string{logBinKey: "false"},
+		},
+		{
+			name:    "InvalidBufferPoolSize",
+			options: map[string]string{"innodb_buffer_pool_size": "large", "default_storage_engine": "MyISAM"},
+			want:    map[string]string{innoDBKey: "false"},
+		},
+		{
+			name:    "NoOptions",
+			options: map[string]string{},
+			want:    map[string]string{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := configFileMetrics(tc.options)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("configFileMetrics(%v) returned unexpected diff (-want +got):\n%s", tc.options, diff)
+			}
+			for name, value := range got {
+				if d, ok := metricSchema.Definition(name); !ok {
+					t.Errorf("configFileMetrics(%v) returned metric %s which is not defined in the schema", tc.options, name)
+				} else if err := d.Validate(value); err != nil {
+					t.Errorf("configFileMetrics(%v) returned an invalid metric: %v", tc.options, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectConfigFileMetricsOnce(t *testing.T) {
+	myCnf := filepath.Join(t.TempDir(), "my.cnf")
+	writeFile(t, myCnf, "[mysqld]\ninnodb_buffer_pool_size=1K\n")
+	newMetrics := func(optionFiles []string) *MySQLMetrics {
+		return &MySQLMetrics{
+			Config: &configpb.Configuration{
+				MysqlConfiguration: &configpb.MySQLConfiguration{
+					ConnectionParameters: &configpb.ConnectionParameters{Port: 3307},
+					Instances:            []*configpb.ConnectionParameters{{Port: 3307}},
+				},
+			},
+			totalMemory: fakeTotalMemory(4096, nil),
+			optionFiles: optionFiles,
+			WLMClient: &gcefake.TestWLM{
+				WriteInsightErrs: []error{nil},
+				WriteInsightResponses: []*wlm.WriteInsightResponse{
+					&wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		m           *MySQLMetrics
+		dwActivated bool
+		want        map[string]string
+		wantWrites  int
+		wantErr     bool
+	}{
+		{
+			name:        "Success",
+			m:           newMetrics([]string{myCnf}),
+			dwActivated: true,
+			want: map[string]string{
+				bufferPoolKey:                       "1024",
+				totalRAMKey:                         strconv.Itoa(4096),
+				workloadmanager.MetricsSourceKey:    workloadmanager.MetricsSourceConfigFile,
+				workloadmanager.ConfigFilesKey:      myCnf,
+				workloadmanager.DatabaseInstanceKey: "localhost:3307",
+				workloadmanager.RecommendationsKey:  `["Increase innodb_buffer_pool_size from 1kB to 2kB: it is 25% of the host memory, best practice is 50% to 80%."]`,
+			},
+			wantWrites: 1,
+		},
+		{
+			name:        "NoOptionFile",
+			m:           newMetrics(nil),
+			dwActivated: true,
+			wantErr:     true,
+		},
+		{
+			name: "DataWarehouseNotActivated",
+			m:    newMetrics([]string{myCnf}),
+			want: map[string]string{
+				bufferPoolKey:                       "1024",
+				totalRAMKey:                         strconv.Itoa(4096),
+				workloadmanager.MetricsSourceKey:    workloadmanager.MetricsSourceConfigFile,
+				workloadmanager.ConfigFilesKey:      myCnf,
+				workloadmanager.DatabaseInstanceKey: "localhost:3307",
+				workloadmanager.RecommendationsKey:  `["Increase innodb_buffer_pool_size from 1kB to 2kB: it is 25% of the host memory, best practice is 50% to 80%."]`,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.m.CollectConfigFileMetricsOnce(context.Background(), tc.dwActivated)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("CollectConfigFileMetricsOnce() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			var gotMetrics map[string]string
+			if got != nil {
+				gotMetrics = got.Metrics
+			}
+			if diff := cmp.Diff(tc.want, gotMetrics); diff != "" {
+				t.Errorf("CollectConfigFileMetricsOnce() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if got := tc.m.WLMClient.(*gcefake.TestWLM).WriteInsightCallCount; got != tc.wantWrites {
+				t.Errorf("CollectConfigFileMetricsOnce() wrote %d insights, want %d", got, tc.wantWrites)
+			}
+		})
+	}
+}
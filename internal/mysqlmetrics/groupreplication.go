@@ -0,0 +1,163 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	groupReplicationKey      = "group_replication_enabled"
+	groupReplicationModeKey  = "group_replication_mode"
+	groupMemberRoleKey       = "group_replication_member_role"
+	groupMemberStateKey      = "group_replication_member_state"
+	groupMembersKey          = "group_replication_members"
+	groupOnlineMembersKey    = "group_replication_online_members"
+	groupReplicationZonesKey = "group_replication_zones"
+	singlePrimaryMode        = "SINGLE_PRIMARY"
+	multiPrimaryMode         = "MULTI_PRIMARY"
+	groupMembersQuery        = "SELECT MEMBER_HOST, MEMBER_PORT, MEMBER_STATE, MEMBER_ROLE, MEMBER_ID = @@server_uuid FROM performance_schema.replication_group_members"
+	singlePrimaryModeQuery   = "SHOW GLOBAL VARIABLES LIKE 'group_replication_single_primary_mode'"
+)
+
+// groupMemberRoles maps the role of the server in a group to its replication role.
+var groupMemberRoles = map[string]string{
+	"PRIMARY":   sourceRole,
+	"SECONDARY": replicaRole,
+}
+
+// groupMember is a row of performance_schema.replication_group_members.
+type groupMember struct {
+	host  string
+	port  int64
+	state string
+	// role is empty prior to MySQL 8.0.2.
+	role  string
+	local bool
+}
+
+func (g groupMember) String() string {
+	return fmt.Sprintf("%s/%s/%s", memberAddress(g.host, g.port), g.role, g.state)
+}
+
+func memberAddress(host string, port int64) string {
+	if port == 0 {
+		return host
+	}
+	return host + ":" + strconv.FormatInt(port, 10)
+}
+
+// groupMembers returns the members of the replication group the server belongs to.
+// The table is empty if Group Replication is not running.
+func (m *MySQLMetrics) groupMembers(ctx context.Context) ([]groupMember, error) {
+	rows, err := executeQuery(ctx, m.db, groupMembersQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query replication group members: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from replication group members query")
+	}
+	defer rows.Close()
+	var members []groupMember
+	for rows.Next() {
+		var host, state, role sql.NullString
+		var port sql.NullInt64
+		var local sql.NullBool
+		if err := rows.Scan(&host, &port, &state, &role, &local); err != nil {
+			return nil, fmt.Errorf("failed to scan replication group member: %w", err)
+		}
+		members = append(members, groupMember{host: host.String, port: port.Int64, state: state.String, role: role.String, local: local.Bool})
+	}
+	return members, nil
+}
+
+// groupReplicationMode returns whether the group runs in single-primary or multi-primary mode.
+// Without the setting, the mode is derived from the number of primaries in the group.
+func (m *MySQLMetrics) groupReplicationMode(ctx context.Context, members []groupMember) string {
+	rows, err := executeQuery(ctx, m.db, singlePrimaryModeQuery)
+	if err == nil && rows != nil {
+		defer rows.Close()
+		if rows.Next() {
+			var name, value string
+			if err := rows.Scan(&name, &value); err == nil {
+				if strings.EqualFold(value, "ON") || value == "1" {
+					return singlePrimaryMode
+				}
+				return multiPrimaryMode
+			}
+		}
+	}
+	log.CtxLogger(ctx).Debugw("Could not read group_replication_single_primary_mode, deriving the mode from the member roles", "error", err)
+	primaries := 0
+	for _, member := range members {
+		if member.role == "PRIMARY" {
+			primaries++
+		}
+	}
+	if primaries > 1 {
+		return multiPrimaryMode
+	}
+	return singlePrimaryMode
+}
+
+// groupReplicationMetrics returns the topology of the Group Replication or InnoDB Cluster group the
+// server belongs to: its mode, the role and state of this and every other member, and the zones
+// of the other members. Only group_replication_enabled is returned if the server is not in a group.
+func (m *MySQLMetrics) groupReplicationMetrics(ctx context.Context, zoneResolver *ipinfo.ZoneResolver) (map[string]string, error) {
+	members, err := m.groupMembers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// A server which left or never joined a group reports itself as the only, OFFLINE member.
+	var local *groupMember
+	online := 0
+	var described, remoteHosts []string
+	for i, member := range members {
+		if member.state == "ONLINE" {
+			online++
+		}
+		if member.local {
+			local = &members[i]
+			continue
+		}
+		described = append(described, member.String())
+		remoteHosts = append(remoteHosts, member.host)
+	}
+	if local == nil || local.state == "OFFLINE" {
+		return map[string]string{groupReplicationKey: "false"}, nil
+	}
+	described = append(described, local.String())
+	sort.Strings(described)
+	return map[string]string{
+		groupReplicationKey:      "true",
+		groupReplicationModeKey:  m.groupReplicationMode(ctx, members),
+		groupMemberRoleKey:       local.role,
+		groupMemberStateKey:      local.state,
+		groupMembersKey:          strings.Join(described, ","),
+		groupOnlineMembersKey:    strconv.Itoa(online),
+		groupReplicationZonesKey: strings.Join(zoneResolver.Zones(ctx, remoteHosts), ","),
+	}, nil
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+)
+
+// groupMemberRows are the rows of performance_schema.replication_group_members.
+type groupMemberRows struct {
+	members []groupMember
+	count   int
+}
+
+func (r *groupMemberRows) Next() bool {
+	r.count++
+	return r.count <= len(r.members)
+}
+
+func (r *groupMemberRows) Scan(dest ...any) error {
+	member := r.members[r.count-1]
+	*dest[0].(*sql.NullString) = sql.NullString{String: member.host, Valid: true}
+	*dest[1].(*sql.NullInt64) = sql.NullInt64{Int64: member.port, Valid: true}
+	*dest[2].(*sql.NullString) = sql.NullString{String: member.state, Valid: true}
+	*dest[3].(*sql.NullString) = sql.NullString{String: member.role, Valid: member.role != ""}
+	*dest[4].(*sql.NullBool) = sql.NullBool{Bool: member.local, Valid: true}
+	return nil
+}
+
+func (r *groupMemberRows) Close() error {
+	return nil
+}
+
+// fakeResolver maps IPs to their host names and resolves no host names.
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupHost(host string) ([]string, error) {
+	return nil, errors.New("no such host")
+}
+
+func (f fakeResolver) ParseIP(ip string) net.IP {
+	return net.ParseIP(ip)
+}
+
+func (f fakeResolver) LookupAddr(ip string) ([]string, error) {
+	return f[ip], nil
+}
+
+func TestGroupReplicationMetrics(t *testing.T) {
+	resolver := fakeResolver{
+		"10.0.0.1": {"mysql-1.us-central1-a.c.fake-project.internal."},
+		"10.0.0.3": {"mysql-3.us-central1-c.c.fake-project.internal."},
+	}
+	threeMembers := []groupMember{
+		{host: "10.0.0.1", port: 3306, state: "ONLINE", role: "PRIMARY"},
+		{host: "10.0.0.2", port: 3306, state: "ONLINE", role: "SECONDARY", local: true},
+		{host: "10.0.0.3", port: 3306, state: "RECOVERING", role: "SECONDARY"},
+	}
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "NotInGroup",
+			db:   &testDB{groupMembersRows: &groupMemberRows{}},
+			want: map[string]string{groupReplicationKey: "false"},
+		},
+		{
+			name: "OfflineMember",
+			db: &testDB{groupMembersRows: &groupMemberRows{members: []groupMember{
+				{host: "mysql-1", port: 3306, state: "OFFLINE", local: true},
+			}}},
+			want: map[string]string{groupReplicationKey: "false"},
+		},
+		{
+			name: "SinglePrimary",
+			db: &testDB{
+				groupMembersRows:      &groupMemberRows{members: threeMembers},
+				singlePrimaryModeRows: &globalVarMockRows{size: 1, data: [][]string{{"group_replication_single_primary_mode", "ON"}}},
+			},
+			want: map[string]string{
+				groupReplicationKey:      "true",
+				groupReplicationModeKey:  singlePrimaryMode,
+				groupMemberRoleKey:       "SECONDARY",
+				groupMemberStateKey:      "ONLINE",
+				groupMembersKey:          "10.0.0.1:3306/PRIMARY/ONLINE,10.0.0.2:3306/SECONDARY/ONLINE,10.0.0.3:3306/SECONDARY/RECOVERING",
+				groupOnlineMembersKey:    "2",
+				groupReplicationZonesKey: "us-central1-a,us-central1-c",
+			},
+		},
+		{
+			name: "MultiPrimaryDerivedFromRoles",
+			db: &testDB{groupMembersRows: &groupMemberRows{members: []groupMember{
+				{host: "10.0.0.4", port: 3306, state: "ONLINE", role: "PRIMARY", local: true},
+				{host: "10.0.0.5", port: 3306, state: "ONLINE", role: "PRIMARY"},
+			}}},
+			want: map[string]string{
+				groupReplicationKey:      "true",
+				groupReplicationModeKey:  multiPrimaryMode,
+				groupMemberRoleKey:       "PRIMARY",
+				groupMemberStateKey:      "ONLINE",
+				groupMembersKey:          "10.0.0.4:3306/PRIMARY/ONLINE,10.0.0.5:3306/PRIMARY/ONLINE",
+				groupOnlineMembersKey:    "2",
+				groupReplicationZonesKey: "",
+			},
+		},
+		{
+			name:    "QueryError",
+			db:      &testDB{groupMembersErr: errors.New("Error 1227: Access denied")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{db: tc.db}
+			got, err := m.groupReplicationMetrics(context.Background(), ipinfo.NewZoneResolver(resolver))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("groupReplicationMetrics() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("groupReplicationMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -22,17 +22,29 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"net"
-	"runtime"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/connectionsecret"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/healthprobe"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/hostinfo"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlguard"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/tlsconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
 )
@@ -46,27 +58,17 @@ const (
 	sourceRole            = "source"
 	replicaRole           = "replica"
 	replicationZonesQuery = "SELECT HOST FROM information_schema.PROCESSLIST AS p WHERE p.COMMAND = 'Binlog Dump'"
-)
-
-type netInterface interface {
-	LookupHost(host string) ([]string, error)
-	ParseIP(ip string) net.IP
-	LookupAddr(ip string) ([]string, error)
-}
-
-type netImpl struct{}
+	defaultPort           = 3306
 
-func (n netImpl) LookupHost(host string) ([]string, error) {
-	return net.LookupHost(host)
-}
-
-func (n netImpl) ParseIP(ip string) net.IP {
-	return net.ParseIP(ip)
-}
+	logBinKey                  = "log_bin"
+	binlogFormatKey            = "binlog_format"
+	gtidModeKey                = "gtid_mode"
+	binlogExpireLogsSecondsKey = "binlog_expire_logs_seconds"
+	syncBinlogKey              = "sync_binlog"
+	binlogVariablesQuery       = "SHOW GLOBAL VARIABLES WHERE Variable_name IN ('log_bin', 'binlog_format', 'gtid_mode', 'expire_logs_days', 'binlog_expire_logs_seconds', 'sync_binlog')"
 
-func (n netImpl) LookupAddr(ip string) ([]string, error) {
-	return net.LookupAddr(ip)
-}
+	dataDirectoryQuery = "SELECT @@datadir"
+)
 
 // GceInterface defines an interface for the GCE client to allow faking.
 type GceInterface interface {
@@ -97,14 +99,31 @@ func (d dbWrapper) Ping() error {
 	return d.db.Ping()
 }
 
+func (d dbWrapper) Close() error {
+	return d.db.Close()
+}
+
 // MySQLMetrics contains variables and methods to collect metrics for MySQL databases running on the current host.
 type MySQLMetrics struct {
-	execute        commandlineexecutor.Execute
-	Config         *configpb.Configuration
-	db             dbInterface
+	totalMemory func(ctx context.Context) (int64, error)
+	Config      *configpb.Configuration
+	db          dbInterface
+	// proxySQL is the connection to the ProxySQL admin interface, nil if ProxySQL is not configured.
+	proxySQL       dbInterface
 	connect        func(ctx context.Context, dataSource string) (dbInterface, error)
 	WLMClient      workloadmanager.WLMWriter
 	DBcenterClient databasecenter.Client
+	// dataSource is the connection string used by InitDB, it is reused by the health probe handshake.
+	dataSource    string
+	listProcesses func(ctx context.Context) ([]processInfo, error)
+	// optionFiles are read by CollectConfigFileMetricsOnce if the agent cannot connect to MySQL.
+	optionFiles []string
+	// store keeps the most recent completed backup across agent restarts, nil to only keep it in memory.
+	store *statestore.Store
+	// lastBackup is the completion time of the most recent backup detected.
+	lastBackup time.Time
+	// slowLog is the position up to which the slow query log was analyzed.
+	slowLog slowLogTail
 }
 
 type engineResult struct {
@@ -117,6 +136,23 @@ type engineResult struct {
 	savepoints   string
 }
 
+// resolveConnectionSecret replaces the connection parameters with the ones in their secret
+// if it is a JSON connection secret. The configuration is copied, not modified.
+func (m *MySQLMetrics) resolveConnectionSecret(ctx context.Context, gceService GceInterface) error {
+	cp := m.Config.GetMysqlConfiguration().GetConnectionParameters()
+	resolved, err := connectionsecret.Resolve(ctx, cp, gceService)
+	if err != nil {
+		return fmt.Errorf("resolving connection secret: %w", err)
+	}
+	if resolved == cp {
+		return nil
+	}
+	config := proto.Clone(m.Config).(*configpb.Configuration)
+	config.MysqlConfiguration.ConnectionParameters = resolved
+	m.Config = config
+	return nil
+}
+
 // password gets the password for the MySQL database.
 // If the password is set in the configuration, it is used directly (not recommended).
 // Otherwise, if the secret configuration is set, the secret is fetched from GCE.
@@ -151,48 +187,148 @@ func (m *MySQLMetrics) dbDSN(ctx context.Context, gceService GceInterface) (stri
 	if err != nil {
 		return "", fmt.Errorf("initializing password: %w", err)
 	}
+	cp := m.Config.GetMysqlConfiguration().GetConnectionParameters()
 	cfg := mysql.Config{
-		User:   m.Config.GetMysqlConfiguration().GetConnectionParameters().GetUsername(),
+		User:   cp.GetUsername(),
 		Passwd: pw.SecretValue(),
-		Addr:   "localhost:3306", // using localhost because the agent is running on the same machine as the MySQL server
+		Addr:   m.address(), // defaults to localhost because the agent is running on the same machine as the MySQL server
 		DBName: "mysql",
+		// All queries issued by the agent run in read-only transactions.
+		Params: map[string]string{sqlguard.MySQLReadOnlyParam: sqlguard.MySQLReadOnlyValue},
+	}
+	// The address is only used with a network, the driver otherwise connects to the default port on localhost.
+	if cp.GetHost() != "" || cp.GetPort() != 0 {
+		cfg.Net = "tcp"
+	}
+	tlsName, err := m.registerTLSConfig(ctx, gceService)
+	if err != nil {
+		return "", err
 	}
+	cfg.TLSConfig = tlsName
 	return cfg.FormatDSN(), nil
 }
 
+// registerTLSConfig registers the TLS configuration of the instance with the driver, which only
+// references TLS configurations by name in DSNs. It returns "" if TLS is not enabled.
+func (m *MySQLMetrics) registerTLSConfig(ctx context.Context, gceService GceInterface) (string, error) {
+	host, _, err := net.SplitHostPort(m.address())
+	if err != nil {
+		return "", fmt.Errorf("parsing address %s: %w", m.address(), err)
+	}
+	tlsConfig, err := tlsconfig.NewWithSecrets(ctx, m.Config.GetMysqlConfiguration().GetTls(), host, gceService)
+	if err != nil || tlsConfig == nil {
+		return "", err
+	}
+	name := "workloadagent-" + m.address()
+	if err := mysql.RegisterTLSConfig(name, tlsConfig); err != nil {
+		return "", fmt.Errorf("registering TLS configuration: %w", err)
+	}
+	return name, nil
+}
+
+// address returns the host and port of the MySQL instance metrics are collected from.
+func (m *MySQLMetrics) address() string {
+	cp := m.Config.GetMysqlConfiguration().GetConnectionParameters()
+	host := cp.GetHost()
+	if host == "" {
+		host = "localhost"
+	}
+	port := cp.GetPort()
+	if port == 0 {
+		port = defaultPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
 // New creates a new MySQLMetrics object initialized with default values.
 func New(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) *MySQLMetrics {
 	return &MySQLMetrics{
-		execute:        commandlineexecutor.ExecuteCommand,
+		totalMemory:    hostinfo.TotalMemoryBytes,
 		Config:         config,
 		connect:        defaultConnect,
 		WLMClient:      wlmClient,
 		DBcenterClient: dbcenterClient,
+		listProcesses:  listProcesses,
+		optionFiles:    defaultOptionFiles(),
+		store:          statestore.Default(),
 	}
 }
 
 // InitDB initializes the MySQL database connection.
 func (m *MySQLMetrics) InitDB(ctx context.Context, gceService GceInterface) error {
+	if err := m.resolveConnectionSecret(ctx, gceService); err != nil {
+		return agenterrors.New(agenterrors.Authentication, err)
+	}
 	dbDSN, err := m.dbDSN(ctx, gceService)
 	if err != nil {
-		return fmt.Errorf("getting dbDSN: %w", err)
+		return agenterrors.New(agenterrors.Authentication, fmt.Errorf("getting dbDSN: %w", err))
 	}
+	m.dataSource = dbDSN
 	db, err := m.connect(ctx, dbDSN)
 	if err != nil {
-		return fmt.Errorf("connecting to MySQL: %w", err)
+		return agenterrors.New(agenterrors.Connection, fmt.Errorf("connecting to MySQL: %w", secretredact.Error(err, dbDSN)))
 	}
 	m.db = db
 	err = m.db.Ping()
 	if err != nil {
-		return fmt.Errorf("failed to ping MySQL connection: %v", err)
+		return agenterrors.New(agenterrors.Connection, fmt.Errorf("failed to ping MySQL connection: %w", secretredact.Error(err, dbDSN)))
 	}
 	log.CtxLogger(ctx).Debugw("MySQL connection ping success")
+	if m.Config.GetMysqlConfiguration().GetProxysql() != nil {
+		// The MySQL metrics are still collected if ProxySQL can't be reached.
+		if err := m.initProxySQL(ctx, gceService); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to connect to the ProxySQL admin interface", "error", err)
+		}
+	}
+
+	return nil
+}
 
+// Ping runs a trivial query to verify that the connection opened by InitDB can execute queries.
+func (m *MySQLMetrics) Ping(ctx context.Context) error {
+	rows, err := executeQuery(ctx, m.db, "SELECT 1")
+	if err != nil {
+		return fmt.Errorf("running test query: %w", err)
+	}
+	if rows != nil {
+		rows.Close()
+	}
 	return nil
 }
 
+// HealthProbeTarget returns the health probe target of the MySQL instance.
+// The handshake opens a new connection with the credentials used by InitDB.
+func (m *MySQLMetrics) HealthProbeTarget() healthprobe.Target {
+	return healthprobe.Target{
+		WorkloadType: workloadmanager.MYSQL,
+		Address:      m.address(),
+		Handshake: func(ctx context.Context) error {
+			if m.dataSource == "" {
+				return errors.New("no MySQL credentials")
+			}
+			db, err := m.connect(ctx, m.dataSource)
+			if err != nil {
+				return secretredact.Error(err, m.dataSource)
+			}
+			if c, ok := db.(io.Closer); ok {
+				defer c.Close()
+			}
+			return secretredact.Error(db.Ping(), m.dataSource)
+		},
+		Query: func(ctx context.Context) error {
+			if m.db == nil {
+				return errors.New("not connected to MySQL")
+			}
+			return m.Ping(ctx)
+		},
+	}
+}
+
 func executeQuery(ctx context.Context, db dbInterface, query string) (rowsInterface, error) {
-	return db.QueryContext(ctx, query)
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query)
+	querystats.Observe("mysql", query, time.Since(start), err)
+	return rows, err
 }
 
 func readEngine(ctx context.Context, rows rowsInterface) (engineResult, error) {
@@ -221,7 +357,7 @@ func readEngine(ctx context.Context, rows rowsInterface) (engineResult, error) {
 func (m *MySQLMetrics) isInnoDBStorageEngine(ctx context.Context) (bool, error) {
 	rows, err := executeQuery(ctx, m.db, "SHOW ENGINES")
 	if err != nil {
-		return false, fmt.Errorf("issue trying to show engines: %v", err)
+		return false, agenterrors.New(agenterrors.Query, fmt.Errorf("issue trying to show engines: %v", err))
 	}
 	if rows == nil {
 		return false, fmt.Errorf("no rows returned from show engines query")
@@ -251,7 +387,7 @@ func (m *MySQLMetrics) bufferPoolSize(ctx context.Context) (int64, error) {
 	rows, err := executeQuery(ctx, m.db, "SELECT @@innodb_buffer_pool_size")
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("MySQL buffer pool size error", "err", err)
-		return 0, fmt.Errorf("can't get buffer pool size in test MySQL connection: %v", err)
+		return 0, agenterrors.New(agenterrors.Query, fmt.Errorf("can't get buffer pool size in test MySQL connection: %v", err))
 	}
 	log.CtxLogger(ctx).Debugw("MySQL buffer pool size result", "rows", rows)
 	if rows == nil {
@@ -314,7 +450,7 @@ func host(ctx context.Context, rows rowsInterface) string {
 	return host.String
 }
 
-func (m *MySQLMetrics) replicationZones(ctx context.Context, currentRole string, netInterface netInterface) []string {
+func (m *MySQLMetrics) replicationZones(ctx context.Context, currentRole string, zoneResolver *ipinfo.ZoneResolver) []string {
 	// We only need to check the replication zones if the current role is the source.
 	if currentRole != sourceRole {
 		return nil
@@ -334,77 +470,21 @@ func (m *MySQLMetrics) replicationZones(ctx context.Context, currentRole string,
 		if host == "" {
 			continue
 		}
-		ip := netInterface.ParseIP(host)
-		isIP := ip != nil
-		if isIP {
-			zone := ipinfo.ZoneFromIP(ctx, host, netInterface.LookupAddr)
-			if zone != "" {
-				zones = append(zones, zone)
-			}
-		} else {
-			_, err := netInterface.LookupHost(host)
-			if err != nil {
-				log.CtxLogger(ctx).Debugw("MySQL error while looking up host", "host", host, "err", err)
-				continue
-			}
-			zone := ipinfo.ZoneFromHost(ctx, host)
-			if zone != "" {
-				zones = append(zones, zone)
-			}
+		if zone := zoneResolver.Zone(ctx, host); zone != "" {
+			zones = append(zones, zone)
 		}
 	}
 	return zones
 }
 
-func windowsTotalRAM(ctx context.Context, output string) (int, error) {
-	// Expected to be something like "TotalPhysicalMemory\n134876032413"
-	lines := strings.Split(output, "\n")
-	if len(lines) < 2 {
-		return 0, fmt.Errorf("not enough lines found in output for windows total RAM: %d", len(lines))
-	}
-	ramString := strings.TrimSpace(lines[1])
-	ram, err := strconv.Atoi(ramString)
+// totalRAM returns the physical memory of the host in bytes.
+func (m *MySQLMetrics) totalRAM(ctx context.Context) (int, error) {
+	ram, err := m.totalMemory(ctx)
 	if err != nil {
-		return 0, fmt.Errorf("failed to convert total RAM to integer: %v", err)
+		return 0, fmt.Errorf("failed to get total memory: %w", err)
 	}
-	return ram, nil
-}
-
-func (m *MySQLMetrics) totalRAM(ctx context.Context, isWindowsOS bool) (int, error) {
-	cmd := commandlineexecutor.Params{
-		Executable: "grep",
-		Args:       []string{"MemTotal", "/proc/meminfo"},
-	}
-	if isWindowsOS {
-		cmd = commandlineexecutor.Params{
-			Executable: "cmd",
-			Args:       []string{"/C", "wmic", "computersystem", "get", "totalphysicalmemory"},
-		}
-	}
-	log.CtxLogger(ctx).Debugw("getTotalRAM command", "command", cmd)
-	res := m.execute(ctx, cmd)
-	log.CtxLogger(ctx).Debugw("getTotalRAM result", "result", res)
-	if res.Error != nil {
-		return 0, fmt.Errorf("failed to execute command: %v", res.Error)
-	}
-	if isWindowsOS {
-		return windowsTotalRAM(ctx, res.StdOut)
-	}
-	// Expected to be something like "MemTotal:       1348760 kB"
-	lines := strings.Split(res.StdOut, "\n")
-	fields := strings.Fields(lines[0])
-	if len(fields) != 3 {
-		return 0, fmt.Errorf("found wrong number of fields in total RAM: %d", len(fields))
-	}
-	ram, err := strconv.Atoi(fields[1])
-	if err != nil {
-		return 0, fmt.Errorf("failed to convert total RAM to integer: %v", err)
-	}
-	units := fields[2]
-	if strings.ToUpper(units) == "KB" {
-		ram = ram * 1024
-	}
-	return ram, nil
+	log.CtxLogger(ctx).Debugw("MySQL host total RAM", "bytes", ram)
+	return int(ram), nil
 }
 
 // Get Version of MySQL
@@ -606,30 +686,103 @@ func (m *MySQLMetrics) auditingEnabled(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
-// CollectMetricsOnce collects metrics for MySQL databases running on the host.
-func (m *MySQLMetrics) CollectWlmMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
-	if !dwActivated {
-		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
-		return nil, nil
-	}
-	bufferPoolSize, err := m.bufferPoolSize(ctx)
+// binlogMetrics returns the binary log and GTID settings which are prerequisites for replication.
+func (m *MySQLMetrics) binlogMetrics(ctx context.Context) (map[string]string, error) {
+	rows, err := executeQuery(ctx, m.db, binlogVariablesQuery)
 	if err != nil {
-		log.CtxLogger(ctx).Warnf("Failed to get buffer pool size: %v", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to query binary log variables with error: %v", err)
 	}
-	isWindowsOS := runtime.GOOS == "windows"
-	totalRAM, err := m.totalRAM(ctx, isWindowsOS)
+	if rows == nil {
+		return nil, errors.New("no rows returned from binary log variables query")
+	}
+	defer rows.Close()
+
+	vars := make(map[string]string)
+	for rows.Next() {
+		var varName, varValue string
+		if err := rows.Scan(&varName, &varValue); err != nil {
+			return nil, fmt.Errorf("failed to scan row from binary log variables with error: %v", err)
+		}
+		vars[strings.ToLower(varName)] = varValue
+	}
+	log.CtxLogger(ctx).Debugw("MySQL binary log variables", "variables", vars)
+	return map[string]string{
+		logBinKey:                  strconv.FormatBool(strings.ToUpper(vars["log_bin"]) == "ON" || vars["log_bin"] == "1"),
+		binlogFormatKey:            vars["binlog_format"],
+		gtidModeKey:                vars["gtid_mode"],
+		binlogExpireLogsSecondsKey: binlogExpireLogsSeconds(vars),
+		syncBinlogKey:              vars["sync_binlog"],
+	}, nil
+}
+
+// binlogExpireLogsSeconds returns the binary log expiration period in seconds.
+// A non-zero binlog_expire_logs_seconds takes precedence over expire_logs_days,
+// which is only available prior to MySQL 8.4.
+func binlogExpireLogsSeconds(vars map[string]string) string {
+	if seconds := vars["binlog_expire_logs_seconds"]; seconds != "" && seconds != "0" {
+		return seconds
+	}
+	expiration, err := units.ParseDuration(vars["expire_logs_days"], units.Day)
 	if err != nil {
-		log.CtxLogger(ctx).Warnf("Failed to get total RAM: %v", err)
-		return nil, err
+		return vars["binlog_expire_logs_seconds"]
 	}
-	isInnoDBDefault, err := m.isInnoDBStorageEngine(ctx)
+	return units.FormatSeconds(expiration)
+}
+
+// dataDirectoryMetrics returns the data directory of the server, its disk layout is added by the WLM writer.
+func (m *MySQLMetrics) dataDirectoryMetrics(ctx context.Context) (map[string]string, error) {
+	rows, err := executeQuery(ctx, m.db, dataDirectoryQuery)
 	if err != nil {
-		log.CtxLogger(ctx).Warnf("Failed to get InnoDB default status: %v", err)
-		return nil, err
+		return nil, fmt.Errorf("failed to query datadir with error: %v", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from datadir query")
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, errors.New("no rows returned from datadir query")
+	}
+	var dataDir string
+	if err := rows.Scan(&dataDir); err != nil {
+		return nil, fmt.Errorf("failed to scan datadir with error: %v", err)
+	}
+	log.CtxLogger(ctx).Debugw("MySQL data directory", "datadir", dataDir)
+	return map[string]string{workloadmanager.DataDirectoriesKey: filepath.Clean(dataDir)}, nil
+}
+
+// CollectWlmMetricsOnce collects metrics for MySQL databases running on the host.
+// Failures of single metrics or collection steps are recorded in the Errors of the returned metrics
+// and the metrics which were collected are still sent. An error is returned if the metrics could not
+// be collected at all or could not be sent, in the latter case together with the collected metrics.
+// The metrics are collected but not sent if Data Warehouse is not activated.
+func (m *MySQLMetrics) CollectWlmMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
+	start := time.Now()
+	bufferPoolSize, bufferPoolErr := m.bufferPoolSize(ctx)
+	if bufferPoolErr != nil {
+		log.CtxLogger(ctx).Warnf("Failed to get buffer pool size: %v", bufferPoolErr)
+	}
+	totalRAM, totalRAMErr := m.totalRAM(ctx)
+	if totalRAMErr != nil {
+		log.CtxLogger(ctx).Warnf("Failed to get total RAM: %v", totalRAMErr)
+	}
+	isInnoDBDefault, innoDBErr := m.isInnoDBStorageEngine(ctx)
+	if innoDBErr != nil {
+		log.CtxLogger(ctx).Warnf("Failed to get InnoDB default status: %v", innoDBErr)
+	}
+	if bufferPoolErr != nil && totalRAMErr != nil && innoDBErr != nil {
+		return nil, fmt.Errorf("failed to collect any MySQL metrics: %w", bufferPoolErr)
 	}
 	currentRole := m.currentRole(ctx)
-	replicationZones := m.replicationZones(ctx, currentRole, &netImpl{})
+	zoneResolver := ipinfo.DefaultZoneResolver()
+	groupMetrics, groupErr := m.groupReplicationMetrics(ctx, zoneResolver)
+	if groupErr != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get Group Replication topology", "error", groupErr)
+	}
+	// Every member of a group has a replication channel, its role in the group takes precedence.
+	if role, ok := groupMemberRoles[groupMetrics[groupMemberRoleKey]]; ok {
+		currentRole = role
+	}
+	replicationZones := m.replicationZones(ctx, currentRole, zoneResolver)
 	log.CtxLogger(ctx).Debugw("Finished collecting MySQL metrics once. Next step is to send to WLM (DW).",
 		bufferPoolKey, bufferPoolSize,
 		totalRAMKey, totalRAM,
@@ -646,14 +799,81 @@ func (m *MySQLMetrics) CollectWlmMetricsOnce(ctx context.Context, dwActivated bo
 			currentRoleKey:      currentRole,
 			replicationZonesKey: strings.Join(replicationZones, ","),
 		},
+		CollectionTime: start,
+	}
+	if bufferPoolErr != nil {
+		metrics.SetError(bufferPoolKey, bufferPoolErr)
+	}
+	if totalRAMErr != nil {
+		metrics.SetError(totalRAMKey, totalRAMErr)
+	}
+	if innoDBErr != nil {
+		metrics.SetError(innoDBKey, innoDBErr)
+	}
+	metrics.AddMetrics("group_replication", groupMetrics, groupErr)
+	metrics.Metrics[lastBackupTimestampKey] = m.lastBackupTimestamp(ctx)
+	if currentRole == replicaRole {
+		lagMetrics, err := m.replicationLagMetrics(ctx)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to get replication lag", "error", err)
+		}
+		metrics.AddMetrics("replication_lag", lagMetrics, err)
+	}
+	if len(m.Config.GetMysqlConfiguration().GetInstances()) > 0 {
+		metrics.Metrics[workloadmanager.DatabaseInstanceKey] = m.address()
+	}
+	binlogMetrics, err := m.binlogMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get binary log configuration", "error", err)
+	}
+	metrics.AddMetrics("binlog", binlogMetrics, err)
+	semiSyncMetrics, err := m.semiSyncMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get semi-synchronous replication configuration", "error", err)
+	}
+	metrics.AddMetrics("semi_sync", semiSyncMetrics, err)
+	dataDirectoryMetrics, err := m.dataDirectoryMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get data directory", "error", err)
+	}
+	metrics.AddMetrics("data_directories", dataDirectoryMetrics, err)
+	if m.proxySQL != nil {
+		proxySQLMetrics, err := m.proxySQLMetrics(ctx)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to get ProxySQL topology", "error", err)
+		}
+		metrics.AddMetrics("proxysql", proxySQLMetrics, err)
+	}
+	if m.Config.GetMysqlConfiguration().GetQueryDigests().GetEnabled() {
+		// performance_schema may be disabled, the remaining metrics are still sent.
+		digestMetrics, err := m.queryDigestMetrics(ctx)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to collect MySQL statement digests", "error", err)
+		}
+		metrics.AddMetrics("query_digests", digestMetrics, err)
+	}
+	if m.Config.GetMysqlConfiguration().GetSlowQueryLog().GetEnabled() {
+		slowLogMetrics, err := m.slowQueryLogMetrics(ctx)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to analyze the MySQL slow query log", "error", err)
+		}
+		metrics.AddMetrics("slow_query_log", slowLogMetrics, err)
+	}
+	metrics.AddRecommendations(ctx, recommendations(metrics.Metrics))
+	metrics.CollectionDuration = time.Since(start)
+	if !dwActivated {
+		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
+		return &metrics, nil
 	}
 	res, err := workloadmanager.SendDataInsight(ctx, workloadmanager.SendDataInsightParams{
-		WLMetrics:  metrics,
-		CloudProps: m.Config.GetCloudProperties(),
-		WLMService: m.WLMClient,
+		WLMetrics:           metrics,
+		CloudProps:          m.Config.GetCloudProperties(),
+		WLMService:          m.WLMClient,
+		StrictWorkloadTypes: m.Config.GetDataWarehouseStrictWorkloadTypes(),
+		Schema:              metricSchema,
 	})
 	if err != nil {
-		return nil, err
+		return &metrics, err
 	}
 	if res == nil {
 		log.CtxLogger(ctx).Warn("SendDataInsight did not return an error but the WriteInsight response is nil")
@@ -691,6 +911,7 @@ func (m *MySQLMetrics) CollectDBCenterMetricsOnce(ctx context.Context) error {
 	}
 	// send metadata details to database center
 	err = m.DBcenterClient.SendMetadataToDatabaseCenter(ctx, databasecenter.DBCenterMetrics{EngineType: databasecenter.MYSQL,
+		Instance: m.address(),
 		Metrics: map[string]string{
 			databasecenter.MajorVersionKey:             majorVersion,
 			databasecenter.MinorVersionKey:             minorVersion,
@@ -27,12 +27,14 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
 	gcefake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/fake"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -78,6 +80,11 @@ func (t *testGCE) GetSecret(ctx context.Context, projectID, secretName string) (
 	return t.secret, t.err
 }
 
+// fakeTotalMemory returns the given total memory of the host.
+func fakeTotalMemory(bytes int64, err error) func(context.Context) (int64, error) {
+	return func(context.Context) (int64, error) { return bytes, err }
+}
+
 type testDB struct {
 	engineRows                 rowsInterface
 	engineErr                  error
@@ -99,6 +106,24 @@ type testDB struct {
 	requireSecureTransportErr  error
 	auditLogPluginRows         rowsInterface
 	auditLogPluginErr          error
+	queryDigestRows            rowsInterface
+	queryDigestErr             error
+	binlogRows                 rowsInterface
+	binlogErr                  error
+	dataDirectoryRows          rowsInterface
+	dataDirectoryErr           error
+	xtrabackupHistoryRows      rowsInterface
+	xtrabackupHistoryErr       error
+	groupMembersRows           rowsInterface
+	groupMembersErr            error
+	singlePrimaryModeRows      rowsInterface
+	singlePrimaryModeErr       error
+	semiSyncVariablesRows      rowsInterface
+	semiSyncVariablesErr       error
+	semiSyncStatusRows         rowsInterface
+	semiSyncStatusErr          error
+	slowLogVariablesRows       rowsInterface
+	slowLogVariablesErr        error
 }
 
 func (t *testDB) QueryContext(ctx context.Context, query string, args ...any) (rowsInterface, error) {
@@ -134,6 +159,33 @@ func (t *testDB) QueryContext(ctx context.Context, query string, args ...any) (r
 	`) {
 		return t.exposedToPublicAccessRows, t.exposedToPublicAccessErr
 	}
+	if query == xtrabackupHistoryQuery {
+		return t.xtrabackupHistoryRows, t.xtrabackupHistoryErr
+	}
+	if query == binlogVariablesQuery {
+		return t.binlogRows, t.binlogErr
+	}
+	if query == dataDirectoryQuery {
+		return t.dataDirectoryRows, t.dataDirectoryErr
+	}
+	if query == groupMembersQuery {
+		return t.groupMembersRows, t.groupMembersErr
+	}
+	if query == singlePrimaryModeQuery {
+		return t.singlePrimaryModeRows, t.singlePrimaryModeErr
+	}
+	if query == semiSyncVariablesQuery {
+		return t.semiSyncVariablesRows, t.semiSyncVariablesErr
+	}
+	if query == semiSyncStatusQuery {
+		return t.semiSyncStatusRows, t.semiSyncStatusErr
+	}
+	if query == queryDigestQuery {
+		return t.queryDigestRows, t.queryDigestErr
+	}
+	if query == slowLogVariablesQuery {
+		return t.slowLogVariablesRows, t.slowLogVariablesErr
+	}
 	if query == `SHOW GLOBAL VARIABLES LIKE 'require_secure_transport'` {
 		return t.requireSecureTransportRows, t.requireSecureTransportErr
 	}
@@ -787,90 +839,25 @@ func TestIsInnoDBStorageEngine(t *testing.T) {
 
 func TestTotalRAM(t *testing.T) {
 	tests := []struct {
-		name        string
-		m           MySQLMetrics
-		isWindowsOS bool
-		want        int
-		wantErr     bool
+		name    string
+		m       MySQLMetrics
+		want    int
+		wantErr bool
 	}{
 		{
 			name: "HappyPath",
-			m: MySQLMetrics{
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
-			},
-			want:    4025040 * 1024,
-			wantErr: false,
-		},
-		{
-			name: "HappyPathWindows",
-			m: MySQLMetrics{
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "TotalPhysicalMemory\n134876032413 ",
-					}
-				},
-			},
-			isWindowsOS: true,
-			want:        134876032413,
-			wantErr:     false,
-		},
-		{
-			name: "SingleLineWindows",
-			m: MySQLMetrics{
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "TotalPhysicalMemory: 134876032413 ",
-					}
-				},
-			},
-			isWindowsOS: true,
-			want:        0,
-			wantErr:     true,
+			m:    MySQLMetrics{totalMemory: fakeTotalMemory(4025040*1024, nil)},
+			want: 4025040 * 1024,
 		},
 		{
-			name: "NonIntWindows",
-			m: MySQLMetrics{
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "TotalPhysicalMemory\ntesttext ",
-					}
-				},
-			},
-			isWindowsOS: true,
-			want:        0,
-			wantErr:     true,
-		},
-		{
-			name: "TooManyFields",
-			m: MySQLMetrics{
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB testtext testtext\n",
-					}
-				},
-			},
-			want:    0,
-			wantErr: true,
-		},
-		{
-			name: "NonInt",
-			m: MySQLMetrics{
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        testtext kB\n",
-					}
-				},
-			},
+			name:    "Error",
+			m:       MySQLMetrics{totalMemory: fakeTotalMemory(0, errors.New("test-error"))},
 			want:    0,
 			wantErr: true,
 		},
 	}
 	for _, tc := range tests {
-		got, err := tc.m.totalRAM(context.Background(), tc.isWindowsOS)
+		got, err := tc.m.totalRAM(context.Background())
 		gotErr := err != nil
 		if gotErr != tc.wantErr {
 			t.Errorf("totalRAM(%s) = %v, wantErr %v", tc.name, err, tc.wantErr)
@@ -939,7 +926,7 @@ func TestDbDSN(t *testing.T) {
 				GetSecretResp: []string{"fake-password"},
 				GetSecretErr:  []error{nil},
 			},
-			want:    "test-user:fake-password@/mysql?allowNativePasswords=false&checkConnLiveness=false&maxAllowedPacket=0",
+			want:    "test-user:fake-password@/mysql?allowNativePasswords=false&checkConnLiveness=false&maxAllowedPacket=0&transaction_read_only=1",
 			wantErr: false,
 		},
 		{
@@ -955,9 +942,69 @@ func TestDbDSN(t *testing.T) {
 				},
 			},
 			gceService: &gcefake.TestGCE{},
-			want:       "test-user:fake-password@/mysql?allowNativePasswords=false&checkConnLiveness=false&maxAllowedPacket=0",
+			want:       "test-user:fake-password@/mysql?allowNativePasswords=false&checkConnLiveness=false&maxAllowedPacket=0&transaction_read_only=1",
+			wantErr:    false,
+		},
+		{
+			name: "HostAndPort",
+			m: MySQLMetrics{
+				Config: &configpb.Configuration{
+					MysqlConfiguration: &configpb.MySQLConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{
+							Username: "test-user",
+							Password: "fake-password",
+							Host:     "10.0.0.1",
+							Port:     3307,
+						},
+					},
+				},
+			},
+			gceService: &gcefake.TestGCE{},
+			want:       "test-user:fake-password@tcp(10.0.0.1:3307)/mysql?allowNativePasswords=false&checkConnLiveness=false&maxAllowedPacket=0&transaction_read_only=1",
+			wantErr:    false,
+		},
+		{
+			name: "TLS",
+			m: MySQLMetrics{
+				Config: &configpb.Configuration{
+					MysqlConfiguration: &configpb.MySQLConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{
+							Username: "test-user",
+							Password: "fake-password",
+							Host:     "10.0.0.1",
+						},
+						Tls: &configpb.TLSConfiguration{Enabled: true},
+					},
+				},
+			},
+			gceService: &gcefake.TestGCE{},
+			want:       "test-user:fake-password@tcp(10.0.0.1:3306)/mysql?allowNativePasswords=false&checkConnLiveness=false&tls=workloadagent-10.0.0.1%3A3306&maxAllowedPacket=0&transaction_read_only=1",
 			wantErr:    false,
 		},
+		{
+			name: "TLSClientCertificateError",
+			m: MySQLMetrics{
+				Config: &configpb.Configuration{
+					MysqlConfiguration: &configpb.MySQLConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{
+							Username: "test-user",
+							Password: "fake-password",
+						},
+						Tls: &configpb.TLSConfiguration{
+							Enabled:    true,
+							CertSecret: &configpb.SecretRef{ProjectId: "fake-project-id", SecretName: "fake-cert"},
+							KeySecret:  &configpb.SecretRef{ProjectId: "fake-project-id", SecretName: "fake-key"},
+						},
+					},
+				},
+			},
+			gceService: &gcefake.TestGCE{
+				GetSecretResp: []string{""},
+				GetSecretErr:  []error{errors.New("fake-error")},
+			},
+			want:    "",
+			wantErr: true,
+		},
 		{
 			name: "PasswordError",
 			m: MySQLMetrics{
@@ -1088,6 +1135,87 @@ func TestInitDB(t *testing.T) {
 	}
 }
 
+func TestInitDBJSONSecret(t *testing.T) {
+	config := &configpb.Configuration{
+		MysqlConfiguration: &configpb.MySQLConfiguration{
+			ConnectionParameters: &configpb.ConnectionParameters{
+				Username: "configured-user",
+				Secret: &configpb.SecretRef{
+					ProjectId:  "fake-project-id",
+					SecretName: "fake-secret-name",
+					Format:     configpb.SecretRef_JSON,
+				},
+			},
+		},
+	}
+	var gotDSN string
+	m := MySQLMetrics{
+		Config: config,
+		connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+			gotDSN = dataSource
+			return emptyDB, nil
+		},
+	}
+	gceService := &gcefake.TestGCE{
+		GetSecretResp: []string{`{"username": "secret-user", "password": "fake-password", "host": "10.0.0.5", "port": 3307}`},
+		GetSecretErr:  []error{nil},
+	}
+
+	if err := m.InitDB(context.Background(), gceService); err != nil {
+		t.Fatalf("InitDB() returned unexpected error: %v", err)
+	}
+	want := "secret-user:fake-password@tcp(10.0.0.5:3307)/mysql?allowNativePasswords=false&checkConnLiveness=false&maxAllowedPacket=0&transaction_read_only=1"
+	if gotDSN != want {
+		t.Errorf("InitDB() connected with DSN %q, want %q", gotDSN, want)
+	}
+	if got := config.GetMysqlConfiguration().GetConnectionParameters().GetUsername(); got != "configured-user" {
+		t.Errorf("InitDB() modified the configured username to %q, want configured-user", got)
+	}
+}
+
+func TestHealthProbeTargetHandshake(t *testing.T) {
+	tests := []struct {
+		name       string
+		dataSource string
+		connectErr error
+		wantErr    bool
+	}{
+		{name: "Success", dataSource: "user:fake-password@tcp(localhost:3306)/mysql"},
+		{name: "NotInitialized", wantErr: true},
+		{name: "ConnectError", dataSource: "user:fake-password@tcp(localhost:3306)/mysql", connectErr: errors.New("invalid DSN user:fake-password@tcp(localhost:3306)/mysql"), wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotDSN string
+			m := &MySQLMetrics{
+				Config:     &configpb.Configuration{},
+				dataSource: tc.dataSource,
+				connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+					gotDSN = dataSource
+					return emptyDB, tc.connectErr
+				},
+			}
+			target := m.HealthProbeTarget()
+			if target.Address != "localhost:3306" {
+				t.Errorf("HealthProbeTarget().Address = %q, want localhost:3306", target.Address)
+			}
+			err := target.Handshake(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Handshake() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if err != nil && strings.Contains(err.Error(), "fake-password") {
+				t.Errorf("Handshake() returned error %q containing the password", err)
+			}
+			if gotDSN != tc.dataSource {
+				t.Errorf("Handshake() connected with DSN %q, want %q", gotDSN, tc.dataSource)
+			}
+		})
+	}
+}
+
+// bufferPoolRecommendations is recommended for the default buffer pool size on the fake host.
+const bufferPoolRecommendations = `["Increase innodb_buffer_pool_size from 128MB to 1965MB: it is 3.3% of the host memory, best practice is 50% to 80%."]`
+
 func TestCollectWlmMetricsOnce(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -1118,11 +1246,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 					bufferPoolRows: &bufferPoolRows{count: 0, size: 1, data: 134217728, shouldErr: false},
 					bufferPoolErr:  nil,
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -1134,15 +1258,94 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 			wantMetrics: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.MYSQL,
 				Metrics: map[string]string{
-					bufferPoolKey:       "134217728",
-					currentRoleKey:      sourceRole,
-					totalRAMKey:         strconv.Itoa(4025040 * 1024),
-					innoDBKey:           "true",
-					replicationZonesKey: "",
+					bufferPoolKey:                      "134217728",
+					currentRoleKey:                     sourceRole,
+					totalRAMKey:                        strconv.Itoa(4025040 * 1024),
+					workloadmanager.RecommendationsKey: bufferPoolRecommendations,
+					innoDBKey:                          "true",
+					lastBackupTimestampKey:             "",
+					replicationZonesKey:                "",
+				},
+				Errors: map[string]string{
+					"semi_sync":         "failed to query semi-sync variables: no rows returned",
+					"group_replication": "no rows returned from replication group members query",
+					"binlog":            "no rows returned from binary log variables query",
+					"data_directories":  "no rows returned from datadir query",
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "BinlogAndQueryDigests",
+			m: MySQLMetrics{
+				Config: &configpb.Configuration{
+					MysqlConfiguration: &configpb.MySQLConfiguration{
+						QueryDigests: &configpb.MySQLQueryDigests{Enabled: proto.Bool(true)},
+					},
+				},
+				db: &testDB{
+					engineRows: &isInnoDBRows{
+						count: 0,
+						size:  1,
+						data: []sql.NullString{
+							sql.NullString{String: "InnoDB"},
+							sql.NullString{String: "DEFAULT"},
+							sql.NullString{String: "teststring3"},
+							sql.NullString{String: "teststring4"},
+							sql.NullString{String: "teststring5"},
+							sql.NullString{String: "teststring6"},
+						},
+					},
+					bufferPoolRows:  &bufferPoolRows{count: 0, size: 1, data: 134217728},
+					queryDigestRows: &queryDigestRows{data: testDigests},
+					binlogRows: &globalVarMockRows{
+						size: 2,
+						data: [][]string{{"log_bin", "ON"}, {"binlog_expire_logs_seconds", "2592000"}},
+					},
+					dataDirectoryRows: &versionRows{size: 1, data: []string{"/var/lib/mysql/"}},
+					groupMembersRows:  &groupMemberRows{},
+					semiSyncVariablesRows: &globalVarMockRows{
+						size: 2,
+						data: [][]string{{"rpl_semi_sync_source_enabled", "ON"}, {"rpl_semi_sync_source_timeout", "10000"}},
+					},
+				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
+				WLMClient: &gcefake.TestWLM{
+					WriteInsightErrs: []error{nil},
+					WriteInsightResponses: []*wlm.WriteInsightResponse{
+						&wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+					},
+				},
+				DBcenterClient: databasecenter.NewClient(&configpb.Configuration{}, nil),
+			},
+			wantMetrics: &workloadmanager.WorkloadMetrics{
+				WorkloadType: workloadmanager.MYSQL,
+				Metrics: map[string]string{
+					bufferPoolKey:                      "134217728",
+					currentRoleKey:                     sourceRole,
+					totalRAMKey:                        strconv.Itoa(4025040 * 1024),
+					workloadmanager.RecommendationsKey: bufferPoolRecommendations,
+					innoDBKey:                          "true",
+					replicationZonesKey:                "",
+					lastBackupTimestampKey:             "",
+					groupReplicationKey:                "false",
+					semiSyncSourceEnabledKey:           "true",
+					semiSyncReplicaEnabledKey:          "false",
+					semiSyncTimeoutKey:                 "10000",
+					semiSyncWaitCountKey:               "",
+					semiSyncWaitPointKey:               "",
+					topDigestsByLatencyKey:             "d2:9,d1:5,d3:1",
+					topDigestsByRowsExaminedKey:        "d2:300,d3:300,d1:10",
+					topDigestsByTmpTablesKey:           "d3:7,d2:2",
+					logBinKey:                          "true",
+					binlogFormatKey:                    "",
+					gtidModeKey:                        "",
+					binlogExpireLogsSecondsKey:         "2592000",
+					syncBinlogKey:                      "",
+					workloadmanager.DataDirectoriesKey: "/var/lib/mysql",
+				},
+			},
+		},
 		{
 			name: "BufferPoolSizeError",
 			m: MySQLMetrics{
@@ -1164,11 +1367,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 					bufferPoolRows: nil,
 					bufferPoolErr:  errors.New("test-error"),
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -1180,14 +1379,20 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 			wantMetrics: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.MYSQL,
 				Metrics: map[string]string{
-					bufferPoolKey:       "0",
-					currentRoleKey:      sourceRole,
-					totalRAMKey:         strconv.Itoa(4025040 * 1024),
-					innoDBKey:           "false",
-					replicationZonesKey: "",
+					currentRoleKey:         sourceRole,
+					totalRAMKey:            strconv.Itoa(4025040 * 1024),
+					innoDBKey:              "true",
+					lastBackupTimestampKey: "",
+					replicationZonesKey:    "",
+				},
+				Errors: map[string]string{
+					"semi_sync":         "failed to query semi-sync variables: no rows returned",
+					"group_replication": "no rows returned from replication group members query",
+					"binlog":            "no rows returned from binary log variables query",
+					"data_directories":  "no rows returned from datadir query",
+					bufferPoolKey:       "can't get buffer pool size in test MySQL connection: test-error",
 				},
 			},
-			wantErr: true,
 		}, {
 			name: "TotalRAMError",
 			m: MySQLMetrics{
@@ -1209,11 +1414,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 					bufferPoolRows: &bufferPoolRows{count: 0, size: 1, data: 134217728, shouldErr: false},
 					bufferPoolErr:  nil,
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						Error: errors.New("test-error"),
-					}
-				},
+				totalMemory: fakeTotalMemory(0, errors.New("test-error")),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -1225,14 +1426,20 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 			wantMetrics: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.MYSQL,
 				Metrics: map[string]string{
-					bufferPoolKey:       "134217728",
-					currentRoleKey:      sourceRole,
-					totalRAMKey:         "0",
-					innoDBKey:           "true",
-					replicationZonesKey: "",
+					bufferPoolKey:          "134217728",
+					currentRoleKey:         sourceRole,
+					innoDBKey:              "true",
+					lastBackupTimestampKey: "",
+					replicationZonesKey:    "",
+				},
+				Errors: map[string]string{
+					"semi_sync":         "failed to query semi-sync variables: no rows returned",
+					"group_replication": "no rows returned from replication group members query",
+					"binlog":            "no rows returned from binary log variables query",
+					"data_directories":  "no rows returned from datadir query",
+					totalRAMKey:         "failed to get total memory: test-error",
 				},
 			},
-			wantErr: true,
 		},
 		{
 			name: "IsInnoDBDefaultError",
@@ -1243,11 +1450,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 					bufferPoolRows: &bufferPoolRows{count: 0, size: 1, data: 134217728, shouldErr: false},
 					bufferPoolErr:  nil,
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -1259,11 +1462,38 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 			wantMetrics: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.MYSQL,
 				Metrics: map[string]string{
-					bufferPoolKey: "134217728",
-					totalRAMKey:   strconv.Itoa(4025040 * 1024),
-					innoDBKey:     "false",
+					bufferPoolKey:                      "134217728",
+					currentRoleKey:                     sourceRole,
+					totalRAMKey:                        strconv.Itoa(4025040 * 1024),
+					workloadmanager.RecommendationsKey: bufferPoolRecommendations,
+					lastBackupTimestampKey:             "",
+					replicationZonesKey:                "",
+				},
+				Errors: map[string]string{
+					"semi_sync":         "failed to query semi-sync variables: no rows returned",
+					"group_replication": "no rows returned from replication group members query",
+					"binlog":            "no rows returned from binary log variables query",
+					"data_directories":  "no rows returned from datadir query",
+					innoDBKey:           "issue trying to show engines: test-error",
 				},
 			},
+		},
+		{
+			name: "AllMetricsError",
+			m: MySQLMetrics{
+				db: &testDB{
+					engineErr:     errors.New("test-error"),
+					bufferPoolErr: errors.New("test-error"),
+				},
+				totalMemory: fakeTotalMemory(0, errors.New("test-error")),
+				WLMClient: &gcefake.TestWLM{
+					WriteInsightErrs: []error{nil},
+					WriteInsightResponses: []*wlm.WriteInsightResponse{
+						&wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+					},
+				},
+				DBcenterClient: databasecenter.NewClient(&configpb.Configuration{}, nil),
+			},
 			wantErr: true,
 		},
 		{
@@ -1287,11 +1517,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 					bufferPoolRows: &bufferPoolRows{count: 0, size: 1, data: 134217728, shouldErr: false},
 					bufferPoolErr:  nil,
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{errors.New("test-error")},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -1303,11 +1529,19 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 			wantMetrics: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.MYSQL,
 				Metrics: map[string]string{
-					bufferPoolKey:       "134217728",
-					currentRoleKey:      sourceRole,
-					totalRAMKey:         strconv.Itoa(4025040 * 1024),
-					innoDBKey:           "true",
-					replicationZonesKey: "",
+					bufferPoolKey:                      "134217728",
+					currentRoleKey:                     sourceRole,
+					totalRAMKey:                        strconv.Itoa(4025040 * 1024),
+					workloadmanager.RecommendationsKey: bufferPoolRecommendations,
+					innoDBKey:                          "true",
+					lastBackupTimestampKey:             "",
+					replicationZonesKey:                "",
+				},
+				Errors: map[string]string{
+					"semi_sync":         "failed to query semi-sync variables: no rows returned",
+					"group_replication": "no rows returned from replication group members query",
+					"binlog":            "no rows returned from binary log variables query",
+					"data_directories":  "no rows returned from datadir query",
 				},
 			},
 			wantErr: true,
@@ -1333,11 +1567,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 					bufferPoolRows: &bufferPoolRows{count: 0, size: 1, data: 134217728, shouldErr: false},
 					bufferPoolErr:  nil,
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs:      []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{nil},
@@ -1347,11 +1577,19 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 			wantMetrics: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.MYSQL,
 				Metrics: map[string]string{
-					bufferPoolKey:       "134217728",
-					currentRoleKey:      sourceRole,
-					totalRAMKey:         strconv.Itoa(4025040 * 1024),
-					innoDBKey:           "true",
-					replicationZonesKey: "",
+					bufferPoolKey:                      "134217728",
+					currentRoleKey:                     sourceRole,
+					totalRAMKey:                        strconv.Itoa(4025040 * 1024),
+					workloadmanager.RecommendationsKey: bufferPoolRecommendations,
+					innoDBKey:                          "true",
+					lastBackupTimestampKey:             "",
+					replicationZonesKey:                "",
+				},
+				Errors: map[string]string{
+					"semi_sync":         "failed to query semi-sync variables: no rows returned",
+					"group_replication": "no rows returned from replication group members query",
+					"binlog":            "no rows returned from binary log variables query",
+					"data_directories":  "no rows returned from datadir query",
 				},
 			},
 			wantErr: false,
@@ -1362,13 +1600,13 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 
 	for _, tc := range tests {
 		gotMetrics, err := tc.m.CollectWlmMetricsOnce(ctx, true)
-		if tc.wantErr {
-			if err == nil {
-				t.Errorf("CollectWlmMetricsOnce(%v) returned no error, want error", tc.name)
-			}
-			continue
+		if tc.wantErr && err == nil {
+			t.Errorf("CollectWlmMetricsOnce(%v) returned no error, want error", tc.name)
 		}
-		if diff := cmp.Diff(tc.wantMetrics, gotMetrics, protocmp.Transform()); diff != "" {
+		if !tc.wantErr && err != nil {
+			t.Errorf("CollectWlmMetricsOnce(%v) returned unexpected error: %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.wantMetrics, gotMetrics, protocmp.Transform(), cmpopts.IgnoreFields(workloadmanager.WorkloadMetrics{}, "CollectionTime", "CollectionDuration")); diff != "" {
 			t.Errorf("CollectWlmMetricsOnce(%v) returned diff (-want +got):\n%s", tc.name, diff)
 		}
 	}
@@ -1563,7 +1801,7 @@ func TestReplicationZones(t *testing.T) {
 				lookupAddrErr:   tc.lookupAddrErr,
 			}
 
-			got := m.replicationZones(context.Background(), tc.role, netMock)
+			got := m.replicationZones(context.Background(), tc.role, ipinfo.NewZoneResolver(netMock))
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("replicationZones() returned diff (-want +got):\n%s", diff)
 			}
@@ -2017,6 +2255,166 @@ func TestUnencryptedConnectionsAllowed(t *testing.T) {
 	}
 }
 
+func TestBinlogMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "MySQL8",
+			db: &testDB{
+				binlogRows: &globalVarMockRows{
+					size: 6,
+					data: [][]string{
+						{"binlog_expire_logs_seconds", "604800"},
+						{"binlog_format", "ROW"},
+						{"expire_logs_days", "0"},
+						{"gtid_mode", "ON"},
+						{"log_bin", "ON"},
+						{"sync_binlog", "1"},
+					},
+				},
+			},
+			want: map[string]string{
+				logBinKey:                  "true",
+				binlogFormatKey:            "ROW",
+				gtidModeKey:                "ON",
+				binlogExpireLogsSecondsKey: "604800",
+				syncBinlogKey:              "1",
+			},
+		},
+		{
+			name: "MySQL57ExpireLogsDays",
+			db: &testDB{
+				binlogRows: &globalVarMockRows{
+					size: 5,
+					data: [][]string{
+						{"binlog_format", "MIXED"},
+						{"expire_logs_days", "7"},
+						{"gtid_mode", "OFF"},
+						{"log_bin", "OFF"},
+						{"sync_binlog", "0"},
+					},
+				},
+			},
+			want: map[string]string{
+				logBinKey:                  "false",
+				binlogFormatKey:            "MIXED",
+				gtidModeKey:                "OFF",
+				binlogExpireLogsSecondsKey: "604800",
+				syncBinlogKey:              "0",
+			},
+		},
+		{
+			name: "NoVariables",
+			db: &testDB{
+				binlogRows: &globalVarMockRows{},
+			},
+			want: map[string]string{
+				logBinKey:                  "false",
+				binlogFormatKey:            "",
+				gtidModeKey:                "",
+				binlogExpireLogsSecondsKey: "",
+				syncBinlogKey:              "",
+			},
+		},
+		{
+			name:    "NilRows",
+			db:      &testDB{},
+			wantErr: true,
+		},
+		{
+			name: "QueryError",
+			db: &testDB{
+				binlogErr: errors.New("db query failed"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ScanError",
+			db: &testDB{
+				binlogRows: &globalVarMockRows{
+					size:    1,
+					data:    [][]string{{"log_bin", "ON"}},
+					scanErr: true,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := MySQLMetrics{db: tc.db}
+			got, err := m.binlogMetrics(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("binlogMetrics() got error: %v, want error presence: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("binlogMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDataDirectoryMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			db: &testDB{
+				dataDirectoryRows: &versionRows{size: 1, data: []string{"/var/lib/mysql/"}},
+			},
+			want: map[string]string{workloadmanager.DataDirectoriesKey: "/var/lib/mysql"},
+		},
+		{
+			name:    "NilRows",
+			db:      &testDB{},
+			wantErr: true,
+		},
+		{
+			name: "NoRows",
+			db: &testDB{
+				dataDirectoryRows: &versionRows{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "QueryError",
+			db: &testDB{
+				dataDirectoryErr: errors.New("db query failed"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "ScanError",
+			db: &testDB{
+				dataDirectoryRows: &versionRows{size: 1, shouldErr: true},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := MySQLMetrics{db: tc.db}
+			got, err := m.dataDirectoryMetrics(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("dataDirectoryMetrics() got error: %v, want error presence: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("dataDirectoryMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 // TestAuditingEnabled tests the auditingDisabled function.
 func TestAuditingEnabled(t *testing.T) {
 	ctx := context.Background()
@@ -2142,11 +2540,7 @@ func TestSendMetadataToDatabaseCenter(t *testing.T) {
 					// Mock for CheckAuditingDisabled - secure
 					auditLogPluginRows: &pluginStatusMockRows{size: 1, data: [][]string{{"ACTIVE"}}},
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -2190,9 +2584,7 @@ func TestSendMetadataToDatabaseCenter(t *testing.T) {
 					// Mock for CheckAuditingDisabled - insecure
 					auditLogPluginRows: &pluginStatusMockRows{size: 1, data: [][]string{{"DISABLED"}}},
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{StdOut: "MemTotal:        4025040 kB\n"}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -2240,11 +2632,7 @@ func TestSendMetadataToDatabaseCenter(t *testing.T) {
 					},
 					auditLogPluginRows: &pluginStatusMockRows{size: 0},
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -2326,11 +2714,7 @@ func TestCollectDBCenterMetricsOnce(t *testing.T) {
 					// Mock for CheckAuditingDisabled - secure
 					auditLogPluginRows: &pluginStatusMockRows{size: 1, data: [][]string{{"ACTIVE"}}},
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
@@ -2371,11 +2755,7 @@ func TestCollectDBCenterMetricsOnce(t *testing.T) {
 					// Mock for CheckAuditingDisabled - secure
 					auditLogPluginRows: &pluginStatusMockRows{size: 1, data: [][]string{{"ACTIVE"}}},
 				},
-				execute: func(context.Context, commandlineexecutor.Params) commandlineexecutor.Result {
-					return commandlineexecutor.Result{
-						StdOut: "MemTotal:        4025040 kB\n",
-					}
-				},
+				totalMemory: fakeTotalMemory(4025040*1024, nil),
 				WLMClient: &gcefake.TestWLM{
 					WriteInsightErrs: []error{nil},
 					WriteInsightResponses: []*wlm.WriteInsightResponse{
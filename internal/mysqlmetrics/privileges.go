@@ -0,0 +1,99 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// accessDeniedErrors are the MySQL error numbers returned for missing privileges.
+var accessDeniedErrors = map[uint16]bool{
+	1044: true, // ER_DBACCESS_DENIED_ERROR
+	1142: true, // ER_TABLEACCESS_DENIED_ERROR
+	1143: true, // ER_COLUMNACCESS_DENIED_ERROR
+	1227: true, // ER_SPECIFIC_ACCESS_DENIED_ERROR
+}
+
+// privilegeCheck probes a privilege needed by the collectors with queries which are denied without it.
+type privilegeCheck struct {
+	grant string
+	// queries are tried in order until one is not rejected for another reason, e.g. its syntax
+	// is not supported by the server version.
+	queries []string
+}
+
+// privilegeChecks returns the privileges needed by the configured collectors.
+func (m *MySQLMetrics) privilegeChecks() []privilegeCheck {
+	checks := []privilegeCheck{
+		{grant: "REPLICATION CLIENT ON *.*", queries: []string{"SHOW REPLICA STATUS", "SHOW SLAVE STATUS"}},
+		{grant: "PROCESS ON *.*", queries: []string{"SHOW ENGINE INNODB STATUS"}},
+		{grant: "SELECT ON mysql.user", queries: []string{"SELECT 1 FROM mysql.user LIMIT 1"}},
+		// The table only exists on hosts where xtrabackup recorded its history.
+		{grant: "SELECT ON PERCONA_SCHEMA.*", queries: []string{"SELECT 1 FROM PERCONA_SCHEMA.xtrabackup_history LIMIT 1"}},
+	}
+	if m.Config.GetMysqlConfiguration().GetQueryDigests().GetEnabled() {
+		checks = append(checks, privilegeCheck{grant: "SELECT ON performance_schema.*", queries: []string{"SELECT 1 FROM performance_schema.events_statements_summary_by_digest LIMIT 1"}})
+	}
+	return checks
+}
+
+// VerifyPrivileges returns an error listing the privileges needed by the collectors which the
+// configured user is missing. Queries which fail for other reasons are not reported, the
+// collectors report those failures themselves.
+func (m *MySQLMetrics) VerifyPrivileges(ctx context.Context) error {
+	var missing []string
+	for _, check := range m.privilegeChecks() {
+		for _, query := range check.queries {
+			err := m.probe(ctx, query)
+			if isAccessDenied(err) {
+				missing = append(missing, check.grant)
+				break
+			}
+			if err == nil {
+				break
+			}
+			log.CtxLogger(ctx).Debugw("Could not verify MySQL privilege", "grant", check.grant, "query", query, "error", err)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the MySQL user is missing privileges needed by the agent, grant %s to it or create a user with 'configure mysql create-monitoring-user'", strings.Join(missing, ", "))
+}
+
+// probe runs query and discards its result.
+func (m *MySQLMetrics) probe(ctx context.Context, query string) error {
+	rows, err := m.db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	if rows != nil {
+		rows.Close()
+	}
+	return nil
+}
+
+func isAccessDenied(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && accessDeniedErrors[mysqlErr.Number]
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"google.golang.org/protobuf/proto"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// privilegeDB fails the queries in errs and returns no rows for the others.
+type privilegeDB struct {
+	errs map[string]error
+}
+
+func (p *privilegeDB) QueryContext(ctx context.Context, query string, args ...any) (rowsInterface, error) {
+	return nil, p.errs[query]
+}
+
+func (p *privilegeDB) Ping() error {
+	return nil
+}
+
+func TestVerifyPrivileges(t *testing.T) {
+	denied := &mysql.MySQLError{Number: 1227, Message: "Access denied"}
+	tests := []struct {
+		name        string
+		config      *configpb.Configuration
+		errs        map[string]error
+		wantMissing []string
+	}{
+		{
+			name: "AllGranted",
+		},
+		{
+			name: "MissingReplicationClientAndProcess",
+			errs: map[string]error{
+				"SHOW REPLICA STATUS":       denied,
+				"SHOW ENGINE INNODB STATUS": denied,
+			},
+			wantMissing: []string{"REPLICATION CLIENT ON *.*", "PROCESS ON *.*"},
+		},
+		{
+			name: "OldServerFallsBackToSlaveStatus",
+			errs: map[string]error{
+				"SHOW REPLICA STATUS": &mysql.MySQLError{Number: 1064, Message: "syntax error"},
+				"SHOW SLAVE STATUS":   denied,
+			},
+			wantMissing: []string{"REPLICATION CLIENT ON *.*"},
+		},
+		{
+			name: "OtherErrorsAreNotReported",
+			errs: map[string]error{
+				"SELECT 1 FROM PERCONA_SCHEMA.xtrabackup_history LIMIT 1": &mysql.MySQLError{Number: 1146, Message: "table doesn't exist"},
+				"SHOW ENGINE INNODB STATUS":                               errors.New("connection reset"),
+			},
+		},
+		{
+			name: "PerformanceSchemaCheckedWithQueryDigests",
+			config: &configpb.Configuration{
+				MysqlConfiguration: &configpb.MySQLConfiguration{QueryDigests: &configpb.MySQLQueryDigests{Enabled: proto.Bool(true)}},
+			},
+			errs: map[string]error{
+				"SELECT 1 FROM performance_schema.events_statements_summary_by_digest LIMIT 1": &mysql.MySQLError{Number: 1142, Message: "SELECT command denied"},
+			},
+			wantMissing: []string{"SELECT ON performance_schema.*"},
+		},
+		{
+			name: "PerformanceSchemaNotCheckedWithoutQueryDigests",
+			errs: map[string]error{
+				"SELECT 1 FROM performance_schema.events_statements_summary_by_digest LIMIT 1": &mysql.MySQLError{Number: 1142, Message: "SELECT command denied"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{Config: tc.config, db: &privilegeDB{errs: tc.errs}}
+			err := m.VerifyPrivileges(context.Background())
+			if len(tc.wantMissing) == 0 {
+				if err != nil {
+					t.Errorf("VerifyPrivileges() returned unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("VerifyPrivileges() returned nil error, want error listing %v", tc.wantMissing)
+			}
+			for _, grant := range tc.wantMissing {
+				if !strings.Contains(err.Error(), grant) {
+					t.Errorf("VerifyPrivileges() returned error %q, want it to list %q", err, grant)
+				}
+			}
+		})
+	}
+}
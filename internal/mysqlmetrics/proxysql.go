@@ -0,0 +1,157 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/connectionsecret"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	proxySQLVersionKey         = "proxysql_version"
+	proxySQLMaxConnectionsKey  = "proxysql_max_connections"
+	proxySQLBackendsKey        = "proxysql_backends"
+	proxySQLOnlineBackendsKey  = "proxysql_online_backends"
+	proxySQLConnectionsUsedKey = "proxysql_connections_used"
+	proxySQLConnectionsFreeKey = "proxysql_connections_free"
+	proxySQLDefaultPort        = 6032
+
+	proxySQLVariablesQuery = "SELECT variable_name, variable_value FROM global_variables WHERE variable_name IN ('admin-version', 'mysql-max_connections')"
+	proxySQLServersQuery   = "SELECT hostgroup_id, hostname, port, status FROM runtime_mysql_servers ORDER BY hostgroup_id, hostname, port"
+	proxySQLPoolQuery      = "SELECT COALESCE(SUM(ConnUsed), 0), COALESCE(SUM(ConnFree), 0) FROM stats_mysql_connection_pool"
+)
+
+// proxySQLAddress returns the host and port of the configured ProxySQL admin interface.
+func (m *MySQLMetrics) proxySQLAddress() string {
+	cp := m.Config.GetMysqlConfiguration().GetProxysql()
+	host := cp.GetHost()
+	if host == "" {
+		host = "localhost"
+	}
+	port := cp.GetPort()
+	if port == 0 {
+		port = proxySQLDefaultPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+// initProxySQL connects to the configured ProxySQL admin interface.
+func (m *MySQLMetrics) initProxySQL(ctx context.Context, gceService GceInterface) error {
+	cp, err := connectionsecret.Resolve(ctx, m.Config.GetMysqlConfiguration().GetProxysql(), gceService)
+	if err != nil {
+		return fmt.Errorf("resolving ProxySQL connection secret: %w", err)
+	}
+	pw, err := connectionsecret.Password(ctx, cp, gceService)
+	if err != nil {
+		return fmt.Errorf("initializing ProxySQL password: %w", err)
+	}
+	// The admin interface only authenticates with mysql_native_password and has no mysql schema.
+	cfg := mysql.Config{
+		User:                 cp.GetUsername(),
+		Passwd:               pw.SecretValue(),
+		Net:                  "tcp",
+		Addr:                 m.proxySQLAddress(),
+		AllowNativePasswords: true,
+	}
+	dsn := cfg.FormatDSN()
+	db, err := m.connect(ctx, dsn)
+	if err != nil {
+		return fmt.Errorf("connecting to ProxySQL: %w", secretredact.Error(err, dsn))
+	}
+	if err := db.Ping(); err != nil {
+		return fmt.Errorf("failed to ping ProxySQL connection: %w", secretredact.Error(err, dsn))
+	}
+	m.proxySQL = db
+	return nil
+}
+
+// proxySQLMetrics returns the version and maximum number of client connections of the ProxySQL
+// instance in front of MySQL, its backend servers as hostgroup/host:port and the connections
+// currently used and kept free in its connection pool.
+func (m *MySQLMetrics) proxySQLMetrics(ctx context.Context) (map[string]string, error) {
+	vars, err := queryVariables(ctx, m.proxySQL, proxySQLVariablesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ProxySQL variables: %w", err)
+	}
+	metrics := map[string]string{
+		proxySQLVersionKey:        vars["admin-version"],
+		proxySQLMaxConnectionsKey: vars["mysql-max_connections"],
+	}
+
+	rows, err := executeQuery(ctx, m.proxySQL, proxySQLServersQuery)
+	if err != nil {
+		return metrics, fmt.Errorf("failed to query ProxySQL servers: %w", err)
+	}
+	if rows == nil {
+		return metrics, errors.New("no rows returned from ProxySQL servers query")
+	}
+	defer rows.Close()
+	var backends []string
+	online := 0
+	for rows.Next() {
+		var hostgroup, port int64
+		var host, status string
+		if err := rows.Scan(&hostgroup, &host, &port, &status); err != nil {
+			return metrics, fmt.Errorf("failed to scan ProxySQL server: %w", err)
+		}
+		backends = append(backends, fmt.Sprintf("%d/%s", hostgroup, net.JoinHostPort(host, strconv.FormatInt(port, 10))))
+		if status == "ONLINE" {
+			online++
+		}
+	}
+	metrics[proxySQLBackendsKey] = strings.Join(backends, ",")
+	metrics[proxySQLOnlineBackendsKey] = strconv.Itoa(online)
+
+	// The pool statistics only exist once ProxySQL has served traffic, the topology is still reported.
+	used, free, err := proxySQLPool(ctx, m.proxySQL)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to get ProxySQL connection pool statistics", "error", err)
+		return metrics, nil
+	}
+	metrics[proxySQLConnectionsUsedKey] = strconv.FormatInt(used, 10)
+	metrics[proxySQLConnectionsFreeKey] = strconv.FormatInt(free, 10)
+	return metrics, nil
+}
+
+// proxySQLPool returns the number of backend connections used and free across all hostgroups.
+func proxySQLPool(ctx context.Context, db dbInterface) (int64, int64, error) {
+	rows, err := executeQuery(ctx, db, proxySQLPoolQuery)
+	if err != nil {
+		return 0, 0, err
+	}
+	if rows == nil {
+		return 0, 0, errors.New("no rows returned")
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return 0, 0, errors.New("no rows returned")
+	}
+	var used, free int64
+	if err := rows.Scan(&used, &free); err != nil {
+		return 0, 0, err
+	}
+	return used, free, nil
+}
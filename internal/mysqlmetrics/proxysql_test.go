@@ -0,0 +1,213 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gcefake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/fake"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// valueRows returns rows of string and int64 values.
+type valueRows struct {
+	rows  [][]any
+	count int
+}
+
+func (r *valueRows) Next() bool {
+	r.count++
+	return r.count <= len(r.rows)
+}
+
+func (r *valueRows) Scan(dest ...any) error {
+	for i, v := range r.rows[r.count-1] {
+		switch d := dest[i].(type) {
+		case *string:
+			*d = v.(string)
+		case *int64:
+			*d = v.(int64)
+		}
+	}
+	return nil
+}
+
+func (r *valueRows) Close() error {
+	return nil
+}
+
+// proxySQLDB answers the ProxySQL admin interface queries.
+type proxySQLDB struct {
+	results map[string]rowsInterface
+	errs    map[string]error
+}
+
+func (d *proxySQLDB) QueryContext(ctx context.Context, query string, args ...any) (rowsInterface, error) {
+	return d.results[query], d.errs[query]
+}
+
+func (d *proxySQLDB) Ping() error {
+	return nil
+}
+
+func TestProxySQLMetrics(t *testing.T) {
+	variables := func() rowsInterface {
+		return &globalVarMockRows{size: 2, data: [][]string{
+			{"admin-version", "2.5.5-10-g195bd70"},
+			{"mysql-max_connections", "2048"},
+		}}
+	}
+	tests := []struct {
+		name    string
+		db      *proxySQLDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Topology",
+			db: &proxySQLDB{results: map[string]rowsInterface{
+				proxySQLVariablesQuery: variables(),
+				proxySQLServersQuery: &valueRows{rows: [][]any{
+					{int64(10), "10.0.0.5", int64(3306), "ONLINE"},
+					{int64(20), "10.0.0.6", int64(3306), "ONLINE"},
+					{int64(20), "10.0.0.7", int64(3306), "SHUNNED"},
+				}},
+				proxySQLPoolQuery: &valueRows{rows: [][]any{{int64(12), int64(30)}}},
+			}},
+			want: map[string]string{
+				proxySQLVersionKey:         "2.5.5-10-g195bd70",
+				proxySQLMaxConnectionsKey:  "2048",
+				proxySQLBackendsKey:        "10/10.0.0.5:3306,20/10.0.0.6:3306,20/10.0.0.7:3306",
+				proxySQLOnlineBackendsKey:  "2",
+				proxySQLConnectionsUsedKey: "12",
+				proxySQLConnectionsFreeKey: "30",
+			},
+		},
+		{
+			name: "PoolErrorIsIgnored",
+			db: &proxySQLDB{
+				results: map[string]rowsInterface{
+					proxySQLVariablesQuery: variables(),
+					proxySQLServersQuery:   &valueRows{rows: [][]any{{int64(10), "db.internal", int64(3306), "ONLINE"}}},
+				},
+				errs: map[string]error{proxySQLPoolQuery: errors.New("no such table: stats_mysql_connection_pool")},
+			},
+			want: map[string]string{
+				proxySQLVersionKey:        "2.5.5-10-g195bd70",
+				proxySQLMaxConnectionsKey: "2048",
+				proxySQLBackendsKey:       "10/db.internal:3306",
+				proxySQLOnlineBackendsKey: "1",
+			},
+		},
+		{
+			name: "ServersError",
+			db: &proxySQLDB{
+				results: map[string]rowsInterface{proxySQLVariablesQuery: variables()},
+				errs:    map[string]error{proxySQLServersQuery: errors.New("query failed")},
+			},
+			want: map[string]string{
+				proxySQLVersionKey:        "2.5.5-10-g195bd70",
+				proxySQLMaxConnectionsKey: "2048",
+			},
+			wantErr: true,
+		},
+		{
+			name:    "VariablesError",
+			db:      &proxySQLDB{errs: map[string]error{proxySQLVariablesQuery: errors.New("query failed")}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{proxySQL: tc.db}
+			got, err := m.proxySQLMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("proxySQLMetrics() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("proxySQLMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInitDBProxySQL(t *testing.T) {
+	tests := []struct {
+		name         string
+		proxySQL     *configpb.ConnectionParameters
+		connectErr   error
+		wantAddress  string
+		wantProxySQL bool
+	}{
+		{
+			name:         "DefaultAddress",
+			proxySQL:     &configpb.ConnectionParameters{Username: "radmin", Password: "radmin"},
+			wantAddress:  "tcp(localhost:6032)",
+			wantProxySQL: true,
+		},
+		{
+			name:         "ConfiguredAddress",
+			proxySQL:     &configpb.ConnectionParameters{Username: "radmin", Password: "radmin", Host: "10.0.0.9", Port: 16032},
+			wantAddress:  "tcp(10.0.0.9:16032)",
+			wantProxySQL: true,
+		},
+		{
+			name:         "ConnectionErrorIsNotFatal",
+			proxySQL:     &configpb.ConnectionParameters{Username: "radmin", Password: "radmin"},
+			connectErr:   errors.New("connection refused"),
+			wantAddress:  "tcp(localhost:6032)",
+			wantProxySQL: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var proxySQLDSN string
+			m := MySQLMetrics{
+				Config: &configpb.Configuration{
+					MysqlConfiguration: &configpb.MySQLConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{Username: "test-user", Password: "fake-password"},
+						Proxysql:             tc.proxySQL,
+					},
+				},
+				connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+					if !strings.Contains(dataSource, "/mysql?") {
+						proxySQLDSN = dataSource
+						return emptyDB, tc.connectErr
+					}
+					return emptyDB, nil
+				},
+			}
+
+			if err := m.InitDB(context.Background(), &gcefake.TestGCE{}); err != nil {
+				t.Fatalf("InitDB() returned unexpected error: %v", err)
+			}
+			if !strings.Contains(proxySQLDSN, tc.wantAddress) {
+				t.Errorf("InitDB() connected to ProxySQL with DSN %q, want address %s", proxySQLDSN, tc.wantAddress)
+			}
+			if gotProxySQL := m.proxySQL != nil; gotProxySQL != tc.wantProxySQL {
+				t.Errorf("InitDB() set the ProxySQL connection: %t, want %t", gotProxySQL, tc.wantProxySQL)
+			}
+		})
+	}
+}
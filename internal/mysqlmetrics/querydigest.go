@@ -0,0 +1,115 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	topDigestsByLatencyKey      = "top_digests_by_total_latency_ms"
+	topDigestsByRowsExaminedKey = "top_digests_by_rows_examined"
+	topDigestsByTmpTablesKey    = "top_digests_by_tmp_tables"
+	defaultQueryDigestTopN      = 5
+	picosecondsPerMillisecond   = 1000000000
+	// Only the digest hash is selected, DIGEST_TEXT and the query samples may contain literals.
+	queryDigestQuery = "SELECT DIGEST, SUM_TIMER_WAIT, SUM_ROWS_EXAMINED, SUM_CREATED_TMP_TABLES FROM performance_schema.events_statements_summary_by_digest WHERE DIGEST IS NOT NULL"
+)
+
+// queryDigest holds the aggregated statistics of a single statement digest.
+type queryDigest struct {
+	digest         string
+	totalLatencyPs uint64
+	rowsExamined   uint64
+	tmpTables      uint64
+}
+
+// queryDigests reads the statement digest summary from performance_schema.
+func (m *MySQLMetrics) queryDigests(ctx context.Context) ([]queryDigest, error) {
+	rows, err := executeQuery(ctx, m.db, queryDigestQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events_statements_summary_by_digest with error: %v", err)
+	}
+	defer rows.Close()
+
+	var digests []queryDigest
+	for rows.Next() {
+		var d queryDigest
+		if err := rows.Scan(&d.digest, &d.totalLatencyPs, &d.rowsExamined, &d.tmpTables); err != nil {
+			return nil, fmt.Errorf("failed to scan row from events_statements_summary_by_digest with error: %v", err)
+		}
+		digests = append(digests, d)
+	}
+	return digests, nil
+}
+
+// queryDigestMetrics returns the top statement digests by total latency, rows examined and
+// temporary tables created, formatted as comma separated digest:value pairs.
+func (m *MySQLMetrics) queryDigestMetrics(ctx context.Context) (map[string]string, error) {
+	digests, err := m.queryDigests(ctx)
+	if err != nil {
+		return nil, err
+	}
+	n := int(m.Config.GetMysqlConfiguration().GetQueryDigests().GetTopN())
+	if n <= 0 {
+		n = defaultQueryDigestTopN
+	}
+	log.CtxLogger(ctx).Debugw("Read MySQL statement digests", "count", len(digests), "topN", n)
+	return map[string]string{
+		topDigestsByLatencyKey:      topDigests(digests, n, func(d queryDigest) uint64 { return d.totalLatencyPs / picosecondsPerMillisecond }),
+		topDigestsByRowsExaminedKey: topDigests(digests, n, func(d queryDigest) uint64 { return d.rowsExamined }),
+		topDigestsByTmpTablesKey:    topDigests(digests, n, func(d queryDigest) uint64 { return d.tmpTables }),
+	}, nil
+}
+
+// topDigests ranks the digests by value in descending order and returns the first n with a
+// non-zero value as comma separated digest:value pairs.
+func topDigests(digests []queryDigest, n int, value func(queryDigest) uint64) string {
+	return topN(digests, n, func(d queryDigest) string { return d.digest }, value)
+}
+
+// topN ranks the items by value in descending order and returns the first n with a non-zero
+// value as comma separated key:value pairs. Items with equal values are ordered by key to keep
+// the result stable.
+func topN[T any](items []T, n int, key func(T) string, value func(T) uint64) string {
+	ranked := make([]T, 0, len(items))
+	for _, item := range items {
+		if value(item) > 0 {
+			ranked = append(ranked, item)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if value(ranked[i]) != value(ranked[j]) {
+			return value(ranked[i]) > value(ranked[j])
+		}
+		return key(ranked[i]) < key(ranked[j])
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	entries := make([]string, len(ranked))
+	for i, item := range ranked {
+		entries[i] = key(item) + ":" + strconv.FormatUint(value(item), 10)
+	}
+	return strings.Join(entries, ",")
+}
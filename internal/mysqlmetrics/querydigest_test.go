@@ -0,0 +1,127 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+type queryDigestRows struct {
+	count     int
+	data      []queryDigest
+	shouldErr bool
+}
+
+func (f *queryDigestRows) Scan(dest ...any) error {
+	if f.shouldErr {
+		return errors.New("test-error")
+	}
+	d := f.data[f.count-1]
+	*dest[0].(*string) = d.digest
+	*dest[1].(*uint64) = d.totalLatencyPs
+	*dest[2].(*uint64) = d.rowsExamined
+	*dest[3].(*uint64) = d.tmpTables
+	return nil
+}
+
+func (f *queryDigestRows) Next() bool {
+	f.count++
+	return f.count <= len(f.data)
+}
+
+func (f *queryDigestRows) Close() error {
+	return nil
+}
+
+var testDigests = []queryDigest{
+	{digest: "d1", totalLatencyPs: 5 * picosecondsPerMillisecond, rowsExamined: 10, tmpTables: 0},
+	{digest: "d2", totalLatencyPs: 9 * picosecondsPerMillisecond, rowsExamined: 300, tmpTables: 2},
+	{digest: "d3", totalLatencyPs: 1 * picosecondsPerMillisecond, rowsExamined: 300, tmpTables: 7},
+}
+
+func TestQueryDigestMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		topN    int32
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "DefaultTopN",
+			db:   &testDB{queryDigestRows: &queryDigestRows{data: testDigests}},
+			want: map[string]string{
+				topDigestsByLatencyKey:      "d2:9,d1:5,d3:1",
+				topDigestsByRowsExaminedKey: "d2:300,d3:300,d1:10",
+				topDigestsByTmpTablesKey:    "d3:7,d2:2",
+			},
+		},
+		{
+			name: "ConfiguredTopN",
+			topN: 1,
+			db:   &testDB{queryDigestRows: &queryDigestRows{data: testDigests}},
+			want: map[string]string{
+				topDigestsByLatencyKey:      "d2:9",
+				topDigestsByRowsExaminedKey: "d2:300",
+				topDigestsByTmpTablesKey:    "d3:7",
+			},
+		},
+		{
+			name: "NoDigests",
+			db:   &testDB{queryDigestRows: &queryDigestRows{}},
+			want: map[string]string{
+				topDigestsByLatencyKey:      "",
+				topDigestsByRowsExaminedKey: "",
+				topDigestsByTmpTablesKey:    "",
+			},
+		},
+		{
+			name:    "QueryError",
+			db:      &testDB{queryDigestErr: errors.New("test-error")},
+			wantErr: true,
+		},
+		{
+			name:    "ScanError",
+			db:      &testDB{queryDigestRows: &queryDigestRows{data: testDigests, shouldErr: true}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{
+				db: tc.db,
+				Config: &configpb.Configuration{
+					MysqlConfiguration: &configpb.MySQLConfiguration{
+						QueryDigests: &configpb.MySQLQueryDigests{TopN: tc.topN},
+					},
+				},
+			}
+			got, err := m.queryDigestMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("queryDigestMetrics() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("queryDigestMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	replicationLagKey        = "replication_lag_seconds"
+	replicaIORunningKey      = "replica_io_running"
+	replicaSQLRunningKey     = "replica_sql_running"
+	replicaStatusQuery       = "SHOW REPLICA STATUS"
+	legacyReplicaStatusQuery = "SHOW SLAVE STATUS"
+
+	semiSyncSourceEnabledKey  = "semi_sync_source_enabled"
+	semiSyncReplicaEnabledKey = "semi_sync_replica_enabled"
+	semiSyncSourceActiveKey   = "semi_sync_source_active"
+	semiSyncReplicaActiveKey  = "semi_sync_replica_active"
+	semiSyncTimeoutKey        = "semi_sync_timeout_ms"
+	semiSyncWaitCountKey      = "semi_sync_wait_for_replica_count"
+	semiSyncWaitPointKey      = "semi_sync_wait_point"
+	// The variables and status of the semi-synchronous replication plugins, which are named with the
+	// legacy terms prior to MySQL 8.0.26. They only exist while the plugins are installed.
+	semiSyncVariablesQuery = `SHOW GLOBAL VARIABLES WHERE Variable_name IN (
+  'rpl_semi_sync_source_enabled', 'rpl_semi_sync_master_enabled',
+  'rpl_semi_sync_replica_enabled', 'rpl_semi_sync_slave_enabled',
+  'rpl_semi_sync_source_timeout', 'rpl_semi_sync_master_timeout',
+  'rpl_semi_sync_source_wait_for_replica_count', 'rpl_semi_sync_master_wait_for_slave_count',
+  'rpl_semi_sync_source_wait_point', 'rpl_semi_sync_master_wait_point')`
+	semiSyncStatusQuery = `SHOW GLOBAL STATUS WHERE Variable_name IN (
+  'Rpl_semi_sync_source_status', 'Rpl_semi_sync_master_status',
+  'Rpl_semi_sync_replica_status', 'Rpl_semi_sync_slave_status')`
+)
+
+// columnsInterface is implemented by rows which report their column names, such as sql.Rows.
+type columnsInterface interface {
+	Columns() ([]string, error)
+}
+
+// replicaStatus returns the first row of SHOW REPLICA STATUS keyed by column name, falling back to
+// SHOW SLAVE STATUS prior to 8.0.22. The columns are named with the legacy terms by the latter, e.g.
+// Seconds_Behind_Master instead of Seconds_Behind_Source. A nil map is returned if the server is
+// not a replica.
+func (m *MySQLMetrics) replicaStatus(ctx context.Context) (map[string]string, error) {
+	status, err := queryReplicaStatus(ctx, m.db, replicaStatusQuery)
+	if err == nil {
+		return status, nil
+	}
+	legacyStatus, legacyErr := queryReplicaStatus(ctx, m.db, legacyReplicaStatusQuery)
+	if legacyErr != nil {
+		return nil, fmt.Errorf("failed to query replica status: %w", errors.Join(err, legacyErr))
+	}
+	return legacyStatus, nil
+}
+
+func queryReplicaStatus(ctx context.Context, db dbInterface, query string) (map[string]string, error) {
+	rows, err := executeQuery(ctx, db, query)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		return nil, fmt.Errorf("no rows returned from %s", query)
+	}
+	defer rows.Close()
+	cr, ok := rows.(columnsInterface)
+	if !ok {
+		return nil, fmt.Errorf("column names of %s are not available", query)
+	}
+	columns, err := cr.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if !rows.Next() {
+		return nil, nil
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", query, err)
+	}
+	status := make(map[string]string, len(columns))
+	for i, column := range columns {
+		if values[i].Valid {
+			status[column] = values[i].String
+		}
+	}
+	return status, nil
+}
+
+// firstOf returns the value of the first of the columns present in status.
+func firstOf(status map[string]string, columns ...string) (string, bool) {
+	for _, c := range columns {
+		if v, ok := status[c]; ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// replicationLagMetrics returns how far a replica is behind its source and whether its replication
+// threads are running. No metrics are returned for a server which is not a replica.
+func (m *MySQLMetrics) replicationLagMetrics(ctx context.Context) (map[string]string, error) {
+	status, err := m.replicaStatus(ctx)
+	if err != nil || status == nil {
+		return nil, err
+	}
+	ioRunning, _ := firstOf(status, "Replica_IO_Running", "Slave_IO_Running")
+	sqlRunning, _ := firstOf(status, "Replica_SQL_Running", "Slave_SQL_Running")
+	metrics := map[string]string{
+		replicaIORunningKey:  strconv.FormatBool(strings.EqualFold(ioRunning, "Yes")),
+		replicaSQLRunningKey: strconv.FormatBool(strings.EqualFold(sqlRunning, "Yes")),
+	}
+	// The lag is NULL while the replication threads are stopped.
+	if lag, ok := firstOf(status, "Seconds_Behind_Source", "Seconds_Behind_Master"); ok {
+		metrics[replicationLagKey] = lag
+	}
+	return metrics, nil
+}
+
+// queryVariables returns the name and value rows of a SHOW VARIABLES or SHOW STATUS statement,
+// keyed by the lower case name.
+func queryVariables(ctx context.Context, db dbInterface, query string) (map[string]string, error) {
+	rows, err := executeQuery(ctx, db, query)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned")
+	}
+	defer rows.Close()
+	vars := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, err
+		}
+		vars[strings.ToLower(name)] = value
+	}
+	return vars, nil
+}
+
+func isOn(value string) bool {
+	return strings.EqualFold(value, "ON") || value == "1"
+}
+
+// semiSyncMetrics returns whether semi-synchronous replication is enabled on the source and replica
+// side, whether it is currently active, and its timeout and acknowledgement settings. The plugins
+// are reported as disabled if they are not installed.
+func (m *MySQLMetrics) semiSyncMetrics(ctx context.Context) (map[string]string, error) {
+	vars, err := queryVariables(ctx, m.db, semiSyncVariablesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query semi-sync variables: %w", err)
+	}
+	sourceEnabled, _ := firstOf(vars, "rpl_semi_sync_source_enabled", "rpl_semi_sync_master_enabled")
+	replicaEnabled, _ := firstOf(vars, "rpl_semi_sync_replica_enabled", "rpl_semi_sync_slave_enabled")
+	timeout, _ := firstOf(vars, "rpl_semi_sync_source_timeout", "rpl_semi_sync_master_timeout")
+	waitCount, _ := firstOf(vars, "rpl_semi_sync_source_wait_for_replica_count", "rpl_semi_sync_master_wait_for_slave_count")
+	waitPoint, _ := firstOf(vars, "rpl_semi_sync_source_wait_point", "rpl_semi_sync_master_wait_point")
+	metrics := map[string]string{
+		semiSyncSourceEnabledKey:  strconv.FormatBool(isOn(sourceEnabled)),
+		semiSyncReplicaEnabledKey: strconv.FormatBool(isOn(replicaEnabled)),
+		semiSyncTimeoutKey:        timeout,
+		semiSyncWaitCountKey:      waitCount,
+		semiSyncWaitPointKey:      waitPoint,
+	}
+
+	// A source falls back to asynchronous replication when no replica acknowledges within the timeout.
+	status, err := queryVariables(ctx, m.db, semiSyncStatusQuery)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to query semi-sync status", "error", err)
+		return metrics, nil
+	}
+	sourceActive, _ := firstOf(status, "rpl_semi_sync_source_status", "rpl_semi_sync_master_status")
+	replicaActive, _ := firstOf(status, "rpl_semi_sync_replica_status", "rpl_semi_sync_slave_status")
+	metrics[semiSyncSourceActiveKey] = strconv.FormatBool(isOn(sourceActive))
+	metrics[semiSyncReplicaActiveKey] = strconv.FormatBool(isOn(replicaActive))
+	return metrics, nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// statusRows is a single row result with named columns, a nil value is NULL.
+type statusRows struct {
+	columns []string
+	values  []*string
+	read    bool
+}
+
+func (r *statusRows) Columns() ([]string, error) {
+	return r.columns, nil
+}
+
+func (r *statusRows) Next() bool {
+	if r.read || r.values == nil {
+		return false
+	}
+	r.read = true
+	return true
+}
+
+func (r *statusRows) Scan(dest ...any) error {
+	for i, v := range r.values {
+		if v != nil {
+			*dest[i].(*sql.NullString) = sql.NullString{String: *v, Valid: true}
+		}
+	}
+	return nil
+}
+
+func (r *statusRows) Close() error {
+	return nil
+}
+
+// replicaStatusDB answers the replica status queries.
+type replicaStatusDB struct {
+	replica    rowsInterface
+	replicaErr error
+	legacy     rowsInterface
+	legacyErr  error
+}
+
+func (d *replicaStatusDB) QueryContext(ctx context.Context, query string, args ...any) (rowsInterface, error) {
+	switch query {
+	case replicaStatusQuery:
+		return d.replica, d.replicaErr
+	case legacyReplicaStatusQuery:
+		return d.legacy, d.legacyErr
+	}
+	return nil, nil
+}
+
+func (d *replicaStatusDB) Ping() error {
+	return nil
+}
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestReplicationLagMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *replicaStatusDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Replica",
+			db: &replicaStatusDB{replica: &statusRows{
+				columns: []string{"Source_Host", "Replica_IO_Running", "Replica_SQL_Running", "Seconds_Behind_Source"},
+				values:  []*string{ptr("10.0.0.1"), ptr("Yes"), ptr("Yes"), ptr("12")},
+			}},
+			want: map[string]string{
+				replicaIORunningKey:  "true",
+				replicaSQLRunningKey: "true",
+				replicationLagKey:    "12",
+			},
+		},
+		{
+			name: "StoppedReplicaHasNoLag",
+			db: &replicaStatusDB{replica: &statusRows{
+				columns: []string{"Replica_IO_Running", "Replica_SQL_Running", "Seconds_Behind_Source"},
+				values:  []*string{ptr("Connecting"), ptr("No"), nil},
+			}},
+			want: map[string]string{
+				replicaIORunningKey:  "false",
+				replicaSQLRunningKey: "false",
+			},
+		},
+		{
+			name: "LegacyStatus",
+			db: &replicaStatusDB{
+				replicaErr: errors.New("Error 1064: You have an error in your SQL syntax"),
+				legacy: &statusRows{
+					columns: []string{"Slave_IO_Running", "Slave_SQL_Running", "Seconds_Behind_Master"},
+					values:  []*string{ptr("Yes"), ptr("Yes"), ptr("0")},
+				},
+			},
+			want: map[string]string{
+				replicaIORunningKey:  "true",
+				replicaSQLRunningKey: "true",
+				replicationLagKey:    "0",
+			},
+		},
+		{
+			name: "NotAReplica",
+			db:   &replicaStatusDB{replica: &statusRows{columns: []string{"Replica_IO_Running"}}},
+		},
+		{
+			name: "BothQueriesFail",
+			db: &replicaStatusDB{
+				replicaErr: errors.New("Error 1227: Access denied"),
+				legacyErr:  errors.New("Error 1227: Access denied"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{db: tc.db}
+			got, err := m.replicationLagMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("replicationLagMetrics() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("replicationLagMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSemiSyncMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "PluginsNotInstalled",
+			db: &testDB{
+				semiSyncVariablesRows: &globalVarMockRows{},
+				semiSyncStatusRows:    &globalVarMockRows{},
+			},
+			want: map[string]string{
+				semiSyncSourceEnabledKey:  "false",
+				semiSyncReplicaEnabledKey: "false",
+				semiSyncSourceActiveKey:   "false",
+				semiSyncReplicaActiveKey:  "false",
+				semiSyncTimeoutKey:        "",
+				semiSyncWaitCountKey:      "",
+				semiSyncWaitPointKey:      "",
+			},
+		},
+		{
+			name: "Source",
+			db: &testDB{
+				semiSyncVariablesRows: &globalVarMockRows{size: 4, data: [][]string{
+					{"rpl_semi_sync_source_enabled", "ON"},
+					{"rpl_semi_sync_source_timeout", "10000"},
+					{"rpl_semi_sync_source_wait_for_replica_count", "1"},
+					{"rpl_semi_sync_source_wait_point", "AFTER_SYNC"},
+				}},
+				semiSyncStatusRows: &globalVarMockRows{size: 1, data: [][]string{{"Rpl_semi_sync_source_status", "OFF"}}},
+			},
+			want: map[string]string{
+				semiSyncSourceEnabledKey:  "true",
+				semiSyncReplicaEnabledKey: "false",
+				semiSyncSourceActiveKey:   "false",
+				semiSyncReplicaActiveKey:  "false",
+				semiSyncTimeoutKey:        "10000",
+				semiSyncWaitCountKey:      "1",
+				semiSyncWaitPointKey:      "AFTER_SYNC",
+			},
+		},
+		{
+			name: "LegacyReplica",
+			db: &testDB{
+				semiSyncVariablesRows: &globalVarMockRows{size: 1, data: [][]string{{"rpl_semi_sync_slave_enabled", "ON"}}},
+				semiSyncStatusRows:    &globalVarMockRows{size: 1, data: [][]string{{"Rpl_semi_sync_slave_status", "ON"}}},
+			},
+			want: map[string]string{
+				semiSyncSourceEnabledKey:  "false",
+				semiSyncReplicaEnabledKey: "true",
+				semiSyncSourceActiveKey:   "false",
+				semiSyncReplicaActiveKey:  "true",
+				semiSyncTimeoutKey:        "",
+				semiSyncWaitCountKey:      "",
+				semiSyncWaitPointKey:      "",
+			},
+		},
+		{
+			name: "StatusErrorIsIgnored",
+			db: &testDB{
+				semiSyncVariablesRows: &globalVarMockRows{size: 1, data: [][]string{{"rpl_semi_sync_source_enabled", "ON"}}},
+				semiSyncStatusErr:     errors.New("query failed"),
+			},
+			want: map[string]string{
+				semiSyncSourceEnabledKey:  "true",
+				semiSyncReplicaEnabledKey: "false",
+				semiSyncTimeoutKey:        "",
+				semiSyncWaitCountKey:      "",
+				semiSyncWaitPointKey:      "",
+			},
+		},
+		{
+			name:    "VariablesError",
+			db:      &testDB{semiSyncVariablesErr: errors.New("query failed")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &MySQLMetrics{db: tc.db}
+			got, err := m.semiSyncMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("semiSyncMetrics() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("semiSyncMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
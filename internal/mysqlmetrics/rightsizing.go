@@ -0,0 +1,46 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+)
+
+// bufferPoolRatio is the best-practice size of the InnoDB buffer pool on a dedicated database host,
+// large enough to cache the working set while leaving memory for connections and the OS.
+var bufferPoolRatio = workloadmanager.MemoryRatio{
+	Setting: "innodb_buffer_pool_size",
+	Memory:  "the host memory",
+	Min:     0.5,
+	Max:     0.8,
+}
+
+// recommendations returns the right-sizing recommendations for the collected settings.
+func recommendations(metrics map[string]string) []string {
+	var recs []string
+	// The buffer pool is only sized for InnoDB, which is assumed if the default engine is unknown.
+	if metrics[innoDBKey] != "false" {
+		bufferPool, _ := strconv.ParseInt(metrics[bufferPoolKey], 10, 64)
+		totalRAM, _ := strconv.ParseInt(metrics[totalRAMKey], 10, 64)
+		if rec, ok := bufferPoolRatio.Recommend(bufferPool, totalRAM); ok {
+			recs = append(recs, rec)
+		}
+	}
+	return recs
+}
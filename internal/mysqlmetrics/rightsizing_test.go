@@ -0,0 +1,62 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRecommendations(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics map[string]string
+		want    []string
+	}{
+		{
+			name:    "SmallBufferPool",
+			metrics: map[string]string{bufferPoolKey: "134217728", totalRAMKey: "17179869184", innoDBKey: "true"},
+			want:    []string{"Increase innodb_buffer_pool_size from 128MB to 8GB: it is 0.8% of the host memory, best practice is 50% to 80%."},
+		},
+		{
+			name:    "LargeBufferPool",
+			metrics: map[string]string{bufferPoolKey: "16106127360", totalRAMKey: "17179869184"},
+			want:    []string{"Decrease innodb_buffer_pool_size from 15GB to 13107MB: it is 93.8% of the host memory, best practice is 50% to 80%."},
+		},
+		{
+			name:    "WellSizedBufferPool",
+			metrics: map[string]string{bufferPoolKey: "12884901888", totalRAMKey: "17179869184", innoDBKey: "true"},
+		},
+		{
+			name:    "NotInnoDB",
+			metrics: map[string]string{bufferPoolKey: "134217728", totalRAMKey: "17179869184", innoDBKey: "false"},
+		},
+		{
+			name:    "UnknownRAM",
+			metrics: map[string]string{bufferPoolKey: "134217728", innoDBKey: "true"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := recommendations(tc.metrics)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("recommendations(%v) returned unexpected diff (-want +got):\n%s", tc.metrics, diff)
+			}
+		})
+	}
+}
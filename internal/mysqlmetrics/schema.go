@@ -0,0 +1,77 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import "github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+
+// metricSchema defines the metrics sent to Data Warehouse for MySQL.
+var metricSchema = workloadmanager.NewSchema(
+	workloadmanager.MetricDefinition{Name: bufferPoolKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitBytes},
+	workloadmanager.MetricDefinition{Name: totalRAMKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitBytes},
+	workloadmanager.MetricDefinition{Name: innoDBKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: currentRoleKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{sourceRole, replicaRole}},
+	workloadmanager.MetricDefinition{Name: replicationZonesKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: lastBackupTimestampKey, Type: workloadmanager.TimestampMetric},
+	// Binary log.
+	workloadmanager.MetricDefinition{Name: logBinKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: binlogFormatKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{"ROW", "STATEMENT", "MIXED"}},
+	workloadmanager.MetricDefinition{Name: gtidModeKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{"OFF", "OFF_PERMISSIVE", "ON_PERMISSIVE", "ON"}},
+	workloadmanager.MetricDefinition{Name: binlogExpireLogsSecondsKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitSeconds},
+	workloadmanager.MetricDefinition{Name: syncBinlogKey, Type: workloadmanager.IntMetric},
+	// Replication.
+	workloadmanager.MetricDefinition{Name: replicationLagKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitSeconds},
+	workloadmanager.MetricDefinition{Name: replicaIORunningKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: replicaSQLRunningKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: semiSyncSourceEnabledKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: semiSyncReplicaEnabledKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: semiSyncSourceActiveKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: semiSyncReplicaActiveKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: semiSyncTimeoutKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitMilliseconds},
+	workloadmanager.MetricDefinition{Name: semiSyncWaitCountKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: semiSyncWaitPointKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{"AFTER_SYNC", "AFTER_COMMIT"}},
+	// Group Replication.
+	workloadmanager.MetricDefinition{Name: groupReplicationKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: groupReplicationModeKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{singlePrimaryMode, multiPrimaryMode}},
+	workloadmanager.MetricDefinition{Name: groupMemberRoleKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: groupMemberStateKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: groupMembersKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: groupOnlineMembersKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: groupReplicationZonesKey, Type: workloadmanager.ListMetric},
+	// Query digests, lists of digest:value entries.
+	workloadmanager.MetricDefinition{Name: topDigestsByLatencyKey, Type: workloadmanager.ListMetric, Unit: workloadmanager.UnitMilliseconds},
+	workloadmanager.MetricDefinition{Name: topDigestsByRowsExaminedKey, Type: workloadmanager.ListMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: topDigestsByTmpTablesKey, Type: workloadmanager.ListMetric, Unit: workloadmanager.UnitCount},
+	// Slow query log, the top fingerprints are lists of fingerprint:value entries.
+	workloadmanager.MetricDefinition{Name: slowQueryLogKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: slowQueriesKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: slowQueryMaxTimeKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitMilliseconds},
+	workloadmanager.MetricDefinition{Name: slowQueryFingerprintsKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: topSlowQueriesByCountKey, Type: workloadmanager.ListMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: topSlowQueriesByTotalTimeKey, Type: workloadmanager.ListMetric, Unit: workloadmanager.UnitMilliseconds},
+	// ProxySQL.
+	workloadmanager.MetricDefinition{Name: proxySQLVersionKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: proxySQLMaxConnectionsKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: proxySQLBackendsKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: proxySQLOnlineBackendsKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: proxySQLConnectionsUsedKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: proxySQLConnectionsFreeKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+)
+
+// MetricSchema returns the schema of the metrics sent to Data Warehouse for MySQL.
+func MetricSchema() *workloadmanager.Schema {
+	return metricSchema
+}
@@ -0,0 +1,265 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	slowQueryLogKey              = "slow_query_log"
+	slowQueriesKey               = "slow_queries"
+	slowQueryMaxTimeKey          = "slow_query_max_time_ms"
+	slowQueryFingerprintsKey     = "slow_query_fingerprints"
+	topSlowQueriesByCountKey     = "top_slow_queries_by_count"
+	topSlowQueriesByTotalTimeKey = "top_slow_queries_by_total_time_ms"
+	defaultSlowQueryTopN         = 5
+	// maxSlowLogRead bounds the part of the slow query log analyzed per collection,
+	// the rest is analyzed by the next collections.
+	maxSlowLogRead        = 64 << 20
+	slowLogVariablesQuery = "SHOW GLOBAL VARIABLES WHERE Variable_name IN ('slow_query_log', 'slow_query_log_file', 'datadir')"
+)
+
+var (
+	quotedLiteral = regexp.MustCompile(`'(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*"`)
+	numberLiteral = regexp.MustCompile(`\b(?:0x[0-9a-fA-F]+|\d+(?:\.\d+)?(?:[eE][-+]?\d+)?)\b`)
+	blockComment  = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	whitespace    = regexp.MustCompile(`\s+`)
+	// valueList matches lists of placeholders such as IN (?, ?, ?) and VALUES (?, ?), (?, ?).
+	valueList = regexp.MustCompile(`\(\s*\?(?:\s*,\s*\?)*\s*\)(?:\s*,\s*\(\s*\?(?:\s*,\s*\?)*\s*\))*`)
+)
+
+// slowQuery is an entry of the slow query log.
+type slowQuery struct {
+	fingerprint string
+	queryTime   time.Duration
+}
+
+// slowQueryFingerprint holds the statistics of the slow queries with the same fingerprint.
+type slowQueryFingerprint struct {
+	fingerprint string
+	count       uint64
+	totalTimeMs uint64
+}
+
+// slowLogTail is the position up to which the slow query log was analyzed.
+type slowLogTail struct {
+	path   string
+	offset int64
+}
+
+// slowLogPath returns the slow query log of the server, or an empty path if the log is disabled.
+func (m *MySQLMetrics) slowLogPath(ctx context.Context) (string, error) {
+	vars, err := queryVariables(ctx, m.db, slowLogVariablesQuery)
+	if err != nil {
+		return "", fmt.Errorf("failed to query slow query log variables: %w", err)
+	}
+	if !isOn(vars["slow_query_log"]) {
+		return "", nil
+	}
+	if path := m.Config.GetMysqlConfiguration().GetSlowQueryLog().GetPath(); path != "" {
+		return path, nil
+	}
+	path := vars["slow_query_log_file"]
+	if path == "" {
+		return "", errors.New("slow_query_log_file is not set")
+	}
+	// Relative paths are relative to the data directory.
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(vars["datadir"], path)
+	}
+	return path, nil
+}
+
+// slowQueryLogMetrics summarizes the slow queries logged since the previous collection.
+// The log is only read from its end on the first collection, so nothing is reported until the
+// second one.
+func (m *MySQLMetrics) slowQueryLogMetrics(ctx context.Context) (map[string]string, error) {
+	path, err := m.slowLogPath(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return map[string]string{slowQueryLogKey: "false"}, nil
+	}
+	metrics := map[string]string{slowQueryLogKey: "true"}
+	if m.slowLog.path != path {
+		info, err := os.Stat(path)
+		if err != nil {
+			return metrics, fmt.Errorf("reading slow query log: %w", err)
+		}
+		m.slowLog = slowLogTail{path: path, offset: info.Size()}
+		log.CtxLogger(ctx).Debugw("Started analyzing the MySQL slow query log", "path", path, "offset", info.Size())
+		return metrics, nil
+	}
+	queries, offset, err := readSlowLog(path, m.slowLog.offset)
+	if err != nil {
+		return metrics, fmt.Errorf("reading slow query log: %w", err)
+	}
+	m.slowLog.offset = offset
+	n := int(m.Config.GetMysqlConfiguration().GetSlowQueryLog().GetTopN())
+	if n <= 0 {
+		n = defaultSlowQueryTopN
+	}
+	log.CtxLogger(ctx).Debugw("Read MySQL slow query log", "path", path, "queries", len(queries), "offset", offset)
+	for k, v := range slowQueryMetrics(queries, n) {
+		metrics[k] = v
+	}
+	return metrics, nil
+}
+
+// readSlowLog parses the complete lines of the slow query log after offset and returns the
+// offset to continue from. A log smaller than offset has been rotated and is read from the start.
+func readSlowLog(path string, offset int64) ([]slowQuery, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, offset, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, offset, err
+	}
+	if info.Size() < offset {
+		offset = 0
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, err
+	}
+	data, err := io.ReadAll(io.LimitReader(f, maxSlowLogRead))
+	if err != nil {
+		return nil, offset, err
+	}
+	// An entry may still be being written, it is read once its line is complete.
+	end := bytes.LastIndexByte(data, '\n') + 1
+	if end == 0 && len(data) == maxSlowLogRead {
+		// A single line longer than the read limit is skipped.
+		end = len(data)
+	}
+	return parseSlowLog(bytes.NewReader(data[:end])), offset + int64(end), nil
+}
+
+// parseSlowLog returns the entries of a slow query log. Each entry starts with comment lines,
+// one of which holds the Query_time, followed by the statement.
+func parseSlowLog(r io.Reader) []slowQuery {
+	var queries []slowQuery
+	var current *slowQuery
+	var statement []string
+	flush := func() {
+		if current != nil && len(statement) > 0 {
+			current.fingerprint = fingerprint(strings.Join(statement, " "))
+			queries = append(queries, *current)
+		}
+		current = nil
+		statement = nil
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# Query_time:"):
+			flush()
+			fields := strings.Fields(strings.TrimPrefix(line, "# Query_time:"))
+			if len(fields) == 0 {
+				continue
+			}
+			seconds, err := strconv.ParseFloat(fields[0], 64)
+			if err != nil {
+				continue
+			}
+			current = &slowQuery{queryTime: time.Duration(seconds * float64(time.Second))}
+		case strings.HasPrefix(line, "#"):
+			// The Time and User@Host lines start the next entry.
+			if len(statement) > 0 {
+				flush()
+			}
+		case isSlowLogHeader(line):
+			flush()
+		case current == nil:
+			// Lines outside of an entry are ignored.
+		case len(statement) == 0 && (strings.HasPrefix(line, "SET timestamp=") || strings.HasPrefix(strings.ToLower(line), "use ")):
+			// The session statements written before the logged statement are not part of it.
+		default:
+			statement = append(statement, line)
+		}
+	}
+	flush()
+	return queries
+}
+
+// isSlowLogHeader reports whether line is part of the header written when the server starts.
+func isSlowLogHeader(line string) bool {
+	return strings.Contains(line, ", Version: ") || strings.HasPrefix(line, "Tcp port: ") || strings.HasPrefix(line, "Time ")
+}
+
+// fingerprint returns a hash of the statement with its literals, comments and value lists
+// removed, so that executions of the same statement with different values are grouped.
+func fingerprint(statement string) string {
+	s := blockComment.ReplaceAllString(statement, " ")
+	s = quotedLiteral.ReplaceAllString(s, "?")
+	s = numberLiteral.ReplaceAllString(s, "?")
+	s = valueList.ReplaceAllString(s, "(?+)")
+	s = whitespace.ReplaceAllString(strings.ToLower(s), " ")
+	s = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(s), ";"))
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:8])
+}
+
+// slowQueryMetrics returns the number of slow queries, the longest query time and the top n
+// fingerprints by count and by total query time.
+func slowQueryMetrics(queries []slowQuery, n int) map[string]string {
+	var maxTime time.Duration
+	byFingerprint := make(map[string]*slowQueryFingerprint)
+	for _, q := range queries {
+		maxTime = max(maxTime, q.queryTime)
+		f, ok := byFingerprint[q.fingerprint]
+		if !ok {
+			f = &slowQueryFingerprint{fingerprint: q.fingerprint}
+			byFingerprint[q.fingerprint] = f
+		}
+		f.count++
+		f.totalTimeMs += uint64(q.queryTime.Milliseconds())
+	}
+	fingerprints := make([]slowQueryFingerprint, 0, len(byFingerprint))
+	for _, f := range byFingerprint {
+		fingerprints = append(fingerprints, *f)
+	}
+	key := func(f slowQueryFingerprint) string { return f.fingerprint }
+	return map[string]string{
+		slowQueriesKey:               strconv.Itoa(len(queries)),
+		slowQueryMaxTimeKey:          strconv.FormatInt(maxTime.Milliseconds(), 10),
+		slowQueryFingerprintsKey:     strconv.Itoa(len(fingerprints)),
+		topSlowQueriesByCountKey:     topN(fingerprints, n, key, func(f slowQueryFingerprint) uint64 { return f.count }),
+		topSlowQueriesByTotalTimeKey: topN(fingerprints, n, key, func(f slowQueryFingerprint) uint64 { return f.totalTimeMs }),
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mysqlmetrics
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const slowLog = `/usr/sbin/mysqld, Version: 8.0.36 (MySQL Community Server - GPL). started with:
+Tcp port: 3306  Unix socket: /var/run/mysqld/mysqld.sock
+Time                 Id Command    Argument
+# Time: 2025-01-01T00:00:00.000000Z
+# User@Host: app[app] @ localhost []  Id:     8
+# Query_time: 2.500000  Lock_time: 0.000010 Rows_sent: 1  Rows_examined: 1000
+use shop;
+SET timestamp=1735689600;
+SELECT * FROM orders WHERE customer_id = 42 AND status = 'open';
+# Time: 2025-01-01T00:00:01.000000Z
+# User@Host: app[app] @ localhost []  Id:     9
+# Query_time: 1.000000  Lock_time: 0.000010 Rows_sent: 1  Rows_examined: 1000
+SET timestamp=1735689601;
+SELECT *
+FROM orders WHERE customer_id = 7 AND status = "closed";
+# Time: 2025-01-01T00:00:02.000000Z
+# User@Host: app[app] @ localhost []  Id:    10
+# Query_time: 3.000000  Lock_time: 0.000000 Rows_sent: 0  Rows_examined: 0
+SET timestamp=1735689602;
+INSERT INTO audit VALUES (1, 'a'), (2, 'b');
+# Time: 2025-01-01T00:00:03.000000Z
+# User@Host: app[app] @ localhost []  Id:    11
+# Query_time: 0.500000  Lock_time: 0.000000 Rows_sent: 0  Rows_examined: 0
+SET timestamp=1735689603;
+# administrator command: Quit;
+`
+
+func TestParseSlowLog(t *testing.T) {
+	orders := fingerprint("SELECT * FROM orders WHERE customer_id = 1 AND status = 'x'")
+	audit := fingerprint("INSERT INTO audit VALUES (3, 'c')")
+	want := []slowQuery{
+		{fingerprint: orders, queryTime: 2500 * time.Millisecond},
+		{fingerprint: orders, queryTime: time.Second},
+		{fingerprint: audit, queryTime: 3 * time.Second},
+	}
+	got := parseSlowLog(strings.NewReader(slowLog))
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(slowQuery{})); diff != "" {
+		t.Errorf("parseSlowLog() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		same bool
+	}{
+		{name: "DifferentLiterals", a: "SELECT * FROM t WHERE id = 1 AND name = 'a'", b: "select *  from t where id = 2 and name = \"it''s\";", same: true},
+		{name: "InLists", a: "SELECT * FROM t WHERE id IN (1, 2, 3)", b: "SELECT * FROM t WHERE id IN (4)", same: true},
+		{name: "Comments", a: "/* app */ SELECT 1", b: "SELECT 2", same: true},
+		{name: "NumberedIdentifiers", a: "SELECT * FROM t1", b: "SELECT * FROM t2", same: false},
+		{name: "DifferentTables", a: "SELECT * FROM orders", b: "SELECT * FROM customers", same: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fingerprint(tc.a) == fingerprint(tc.b); got != tc.same {
+				t.Errorf("fingerprint(%q) == fingerprint(%q) is %v, want %v", tc.a, tc.b, got, tc.same)
+			}
+		})
+	}
+}
+
+func TestSlowQueryMetrics(t *testing.T) {
+	queries := []slowQuery{
+		{fingerprint: "a", queryTime: 2500 * time.Millisecond},
+		{fingerprint: "a", queryTime: time.Second},
+		{fingerprint: "b", queryTime: 3 * time.Second},
+		{fingerprint: "c", queryTime: 100 * time.Millisecond},
+	}
+	want := map[string]string{
+		slowQueriesKey:               "4",
+		slowQueryMaxTimeKey:          "3000",
+		slowQueryFingerprintsKey:     "3",
+		topSlowQueriesByCountKey:     "a:2,b:1",
+		topSlowQueriesByTotalTimeKey: "a:3500,b:3000",
+	}
+	got := slowQueryMetrics(queries, 2)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("slowQueryMetrics() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	for name, value := range got {
+		if d, ok := metricSchema.Definition(name); !ok {
+			t.Errorf("slowQueryMetrics() returned metric %s which is not defined in the schema", name)
+		} else if err := d.Validate(value); err != nil {
+			t.Errorf("slowQueryMetrics() returned an invalid metric: %v", err)
+		}
+	}
+}
+
+func TestSlowQueryLogMetrics(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "slow.log")
+	writeFile(t, path, "# Query_time: 9.0\nSELECT 1;\n")
+	variables := func(enabled string) *globalVarMockRows {
+		return &globalVarMockRows{size: 3, data: [][]string{{"slow_query_log", enabled}, {"slow_query_log_file", "slow.log"}, {"datadir", dir}}}
+	}
+	m := &MySQLMetrics{
+		Config: &configpb.Configuration{MysqlConfiguration: &configpb.MySQLConfiguration{SlowQueryLog: &configpb.MySQLSlowQueryLog{Enabled: proto.Bool(true)}}},
+		db:     &testDB{slowLogVariablesRows: variables("ON")},
+	}
+	ctx := context.Background()
+
+	// The first collection only records the end of the log.
+	got, err := m.slowQueryLogMetrics(ctx)
+	if err != nil {
+		t.Fatalf("slowQueryLogMetrics() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{slowQueryLogKey: "true"}, got); diff != "" {
+		t.Errorf("slowQueryLogMetrics() returned unexpected diff on the first collection (-want +got):\n%s", diff)
+	}
+
+	// Only the complete entries appended since the previous collection are analyzed.
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("os.OpenFile(%s) returned unexpected error: %v", path, err)
+	}
+	if _, err := f.WriteString("# Query_time: 1.5\nSELECT * FROM t WHERE id = 1;\n# Query_time: 0.5\nSELECT * FROM t WHERE id = 2;\n# Query_time: 7"); err != nil {
+		t.Fatalf("WriteString() returned unexpected error: %v", err)
+	}
+	f.Close()
+	m.db = &testDB{slowLogVariablesRows: variables("ON")}
+	got, err = m.slowQueryLogMetrics(ctx)
+	if err != nil {
+		t.Fatalf("slowQueryLogMetrics() returned unexpected error: %v", err)
+	}
+	fp := fingerprint("SELECT * FROM t WHERE id = 3")
+	want := map[string]string{
+		slowQueryLogKey:              "true",
+		slowQueriesKey:               "2",
+		slowQueryMaxTimeKey:          "1500",
+		slowQueryFingerprintsKey:     "1",
+		topSlowQueriesByCountKey:     fp + ":2",
+		topSlowQueriesByTotalTimeKey: fp + ":2000",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("slowQueryLogMetrics() returned unexpected diff on the second collection (-want +got):\n%s", diff)
+	}
+
+	m.db = &testDB{slowLogVariablesRows: variables("OFF")}
+	got, err = m.slowQueryLogMetrics(ctx)
+	if err != nil {
+		t.Fatalf("slowQueryLogMetrics() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{slowQueryLogKey: "false"}, got); diff != "" {
+		t.Errorf("slowQueryLogMetrics() returned unexpected diff with the log disabled (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadSlowLogRotated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "slow.log")
+	writeFile(t, path, "# Query_time: 1.0\nSELECT 1;\n")
+	queries, offset, err := readSlowLog(path, 1000)
+	if err != nil {
+		t.Fatalf("readSlowLog() returned unexpected error: %v", err)
+	}
+	if len(queries) != 1 || offset != 28 {
+		t.Errorf("readSlowLog() = (%d queries, offset %d), want (1 query, offset 28)", len(queries), offset)
+	}
+}
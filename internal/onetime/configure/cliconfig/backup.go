@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cliconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// MaxBackups is the number of configuration backups kept next to the configuration file.
+const MaxBackups = 10
+
+// backup saves a timestamped copy of the current configuration file before it is overwritten
+// and prunes all but the newest MaxBackups copies. Nothing is saved if the file does not exist.
+func (c *Configure) backup(ctx context.Context) error {
+	content, err := os.ReadFile(c.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read configuration file %q: %w", c.Path, err)
+	}
+
+	backupPath := configuration.BackupPath(c.Path, time.Now())
+	// The backup is written as is; it may be the invalid configuration being repaired.
+	// It is only readable by its owner since the configuration may hold passwords.
+	if err := os.WriteFile(backupPath, content, 0600); err != nil {
+		return fmt.Errorf("unable to write configuration backup %q: %w", backupPath, err)
+	}
+	log.CtxLogger(ctx).Infow("Saved configuration backup", "path", backupPath)
+
+	backups, err := configuration.Backups(c.Path)
+	if err != nil {
+		return err
+	}
+	for len(backups) > MaxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			log.CtxLogger(ctx).Warnw("Unable to remove old configuration backup", "path", backups[0], "error", err)
+		}
+		backups = backups[1:]
+	}
+	return nil
+}
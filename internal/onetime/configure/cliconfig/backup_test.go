@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cliconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestWriteFileSavesBackup(t *testing.T) {
+	configPath := path.Join(t.TempDir(), "configuration.json")
+	if err := os.WriteFile(configPath, []byte(`{"log_level":"DEBUG"}`), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", configPath, err)
+	}
+	c := NewConfigure(configPath, log.Parameters{}, nil, nil)
+	c.Configuration = &cpb.Configuration{LogToCloud: proto.Bool(false)}
+
+	if err := c.WriteFile(context.Background()); err != nil {
+		t.Fatalf("WriteFile() returned unexpected error: %v", err)
+	}
+
+	backups, err := configuration.Backups(configPath)
+	if err != nil {
+		t.Fatalf("Backups(%s) returned unexpected error: %v", configPath, err)
+	}
+	if len(backups) != 1 {
+		t.Fatalf("Backups(%s) returned %d backups, want 1", configPath, len(backups))
+	}
+	got, err := os.ReadFile(backups[0])
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", backups[0], err)
+	}
+	if string(got) != `{"log_level":"DEBUG"}` {
+		t.Errorf("backup %s contains %q, want the previous configuration", backups[0], got)
+	}
+	if info, err := os.Stat(backups[0]); err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("os.Stat(%s) = (%v, %v), want a backup only readable by its owner", backups[0], info, err)
+	}
+}
+
+func TestWriteFilePrunesBackups(t *testing.T) {
+	configPath := path.Join(t.TempDir(), "configuration.json")
+	if err := os.WriteFile(configPath, []byte("{}"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", configPath, err)
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var old []string
+	for i := 0; i < MaxBackups; i++ {
+		p := configuration.BackupPath(configPath, start.Add(time.Duration(i)*time.Minute))
+		if err := os.WriteFile(p, []byte(fmt.Sprintf("%d", i)), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", p, err)
+		}
+		old = append(old, p)
+	}
+	c := NewConfigure(configPath, log.Parameters{}, nil, nil)
+	c.Configuration = &cpb.Configuration{}
+
+	if err := c.WriteFile(context.Background()); err != nil {
+		t.Fatalf("WriteFile() returned unexpected error: %v", err)
+	}
+
+	backups, err := configuration.Backups(configPath)
+	if err != nil {
+		t.Fatalf("Backups(%s) returned unexpected error: %v", configPath, err)
+	}
+	if len(backups) != MaxBackups {
+		t.Fatalf("Backups(%s) returned %d backups, want %d", configPath, len(backups), MaxBackups)
+	}
+	if diff := cmp.Diff(old[1:], backups[:MaxBackups-1]); diff != "" {
+		t.Errorf("Backups(%s) returned unexpected diff, want the oldest backup pruned (-want +got):\n%s", configPath, diff)
+	}
+}
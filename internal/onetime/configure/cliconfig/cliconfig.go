@@ -28,6 +28,7 @@ import (
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
@@ -39,7 +40,7 @@ type Marshaller interface {
 	Marshal(proto.Message) ([]byte, error)
 }
 
-// WriteConfigFile abstracts os.WriteFile function for testability.
+// WriteConfigFile abstracts configuration.WriteFileAtomic function for testability.
 type WriteConfigFile func(string, []byte, os.FileMode) error
 
 // Configure holds the configuration state for the CLI.
@@ -50,7 +51,20 @@ type Configure struct {
 	SQLServerConfigModified bool
 	RedisConfigModified     bool
 	MySQLConfigModified     bool
-	Lp                      log.Parameters
+	PostgresConfigModified  bool
+	ConfigRestored          bool
+	// CredentialsConfigModified is set when a workload is pointed at the local credential store.
+	CredentialsConfigModified bool
+	Lp                        log.Parameters
+	// JSONOutput collects console messages so they can be printed as a single JSON result.
+	JSONOutput bool
+	// ReadOnly is set by subcommands which only print the configuration and produce no result.
+	ReadOnly bool
+	// EnabledChanges holds the workloads enabled or disabled by this command,
+	// which are also applied to the running agent once the file is written.
+	EnabledChanges map[string]bool
+
+	messages []string
 
 	// Injected dependencies (unexported)
 	marshaller Marshaller
@@ -79,7 +93,7 @@ func NewConfigure(path string, lp log.Parameters, m Marshaller, fw WriteConfigFi
 		}
 	}
 	if fw == nil {
-		fw = os.WriteFile
+		fw = configuration.WriteFileAtomic
 	}
 
 	return &Configure{
@@ -108,6 +122,10 @@ func (c *Configure) WriteFile(ctx context.Context) error {
 		return fmt.Errorf("unable to indent marshalled json: %w", err)
 	}
 
+	if err := c.backup(ctx); err != nil {
+		return err
+	}
+
 	err = c.fileWriter(c.Path, buf.Bytes(), 0644)
 	if err != nil {
 		return fmt.Errorf("unable to write configuration file %q: %w", c.Path, err)
@@ -119,15 +137,43 @@ func (c *Configure) WriteFile(ctx context.Context) error {
 
 // IsConfigModified returns true if any of the configuration files are modified.
 func (c *Configure) IsConfigModified() bool {
-	return c.OracleConfigModified || c.SQLServerConfigModified || c.RedisConfigModified || c.MySQLConfigModified
+	return c.OracleConfigModified || c.SQLServerConfigModified || c.RedisConfigModified || c.MySQLConfigModified || c.PostgresConfigModified || c.ConfigRestored || c.CredentialsConfigModified
+}
+
+// RecordEnabledChange records that the command enabled or disabled the workload.
+func (c *Configure) RecordEnabledChange(workload string, enabled bool) {
+	if c.EnabledChanges == nil {
+		c.EnabledChanges = make(map[string]bool)
+	}
+	c.EnabledChanges[workload] = enabled
 }
 
 // LogToBoth logs the message to both the console and the log file.
+// With JSON output the console message is deferred until Result is printed.
 func (c *Configure) LogToBoth(ctx context.Context, msg string) {
-	fmt.Println(msg)
+	if c.JSONOutput {
+		c.messages = append(c.messages, msg)
+	} else {
+		fmt.Println(msg)
+	}
 	log.CtxLogger(ctx).Infof(msg)
 }
 
+// Result is the machine readable summary of a configure command.
+type Result struct {
+	ConfigModified bool     `json:"config_modified"`
+	Messages       []string `json:"messages"`
+}
+
+// Result returns the summary of the configure command run so far.
+func (c *Configure) Result() Result {
+	messages := c.messages
+	if messages == nil {
+		messages = []string{}
+	}
+	return Result{ConfigModified: c.IsConfigModified(), Messages: messages}
+}
+
 // ValidateOracle ensures that the Oracle configuration is initialized.
 func (c *Configure) ValidateOracle() {
 	if c.Configuration.OracleConfiguration == nil {
@@ -203,3 +249,18 @@ func (c *Configure) ValidateMySQLConnectionParams() {
 		c.Configuration.MysqlConfiguration.ConnectionParameters = &cpb.ConnectionParameters{}
 	}
 }
+
+// ValidatePostgres ensures that the Postgres configuration is initialized.
+func (c *Configure) ValidatePostgres() {
+	if c.Configuration.PostgresConfiguration == nil {
+		c.Configuration.PostgresConfiguration = &cpb.PostgresConfiguration{}
+	}
+}
+
+// ValidatePostgresConnectionParams ensures that the Postgres connection parameters are initialized.
+func (c *Configure) ValidatePostgresConnectionParams() {
+	c.ValidatePostgres()
+	if c.Configuration.PostgresConfiguration.ConnectionParameters == nil {
+		c.Configuration.PostgresConfiguration.ConnectionParameters = &cpb.ConnectionParameters{}
+	}
+}
@@ -452,3 +452,63 @@ func TestValidateMySQLConnectionParams(t *testing.T) {
 		})
 	}
 }
+
+func TestValidatePostgres(t *testing.T) {
+	tests := []struct {
+		name           string
+		configToModify *Configure
+		want           *Configure
+	}{
+		{
+			name: "ValidPostgresConfig",
+			configToModify: &Configure{
+				Configuration: &cpb.Configuration{},
+			},
+			want: &Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.configToModify.ValidatePostgres()
+			// Compare the configurations.
+			if diff := cmp.Diff(tc.want, tc.configToModify, protocmp.Transform(), cmpopts.IgnoreUnexported(Configure{})); diff != "" {
+				t.Errorf("ValidatePostgres() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidatePostgresConnectionParams(t *testing.T) {
+	tests := []struct {
+		name           string
+		configToModify *Configure
+		want           *Configure
+	}{
+		{
+			name: "ValidPostgresConnectionParams",
+			configToModify: &Configure{
+				Configuration: &cpb.Configuration{},
+			},
+			want: &Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						ConnectionParameters: &cpb.ConnectionParameters{},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.configToModify.ValidatePostgresConnectionParams()
+			// Compare the configurations.
+			if diff := cmp.Diff(tc.want, tc.configToModify, protocmp.Transform(), cmpopts.IgnoreUnexported(Configure{})); diff != "" {
+				t.Errorf("ValidatePostgresConnectionParams() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -19,16 +19,23 @@ limitations under the License.
 package configure
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"runtime"
+	"sort"
 
 	"github.com/spf13/cobra"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/control"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/credentials"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/mysql"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/oracle"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/postgres"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/redis"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/rollback"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/show"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/sqlserver"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -36,7 +43,7 @@ import (
 
 // NewCommand creates a new 'configure' command.
 func NewCommand(lp log.Parameters) *cobra.Command {
-	cfg := cliconfig.NewConfigure(configPath(runtime.GOOS), lp, nil, nil)
+	cfg := cliconfig.NewConfigure(configPath(capabilities.Host().OS), lp, nil, nil)
 
 	configureCmd := &cobra.Command{
 		Use:   "configure",
@@ -49,8 +56,13 @@ func NewCommand(lp log.Parameters) *cobra.Command {
 			}
 			log.SetupLoggingForOTE("google-cloud-workload-agent", "configure", cfg.Lp)
 
-			var err error
-			cfg.Configuration, err = configuration.ConfigFromFile(configPath(runtime.GOOS), os.ReadFile)
+			format, err := onetime.OutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			cfg.JSONOutput = format == onetime.FormatJSON
+
+			cfg.Configuration, err = configuration.ConfigFromFile(configPath(capabilities.Host().OS), os.ReadFile)
 			if err != nil {
 				return fmt.Errorf("failed to load configuration: %w", err)
 			}
@@ -58,11 +70,20 @@ func NewCommand(lp log.Parameters) *cobra.Command {
 		},
 		// PersistentPostRunE is called after each cli command is run.
 		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.ReadOnly {
+				return nil
+			}
 			if !cfg.IsConfigModified() {
 				cfg.LogToBoth(cmd.Context(), "No configuration changes to save.")
-				return nil
+			} else if err := cfg.WriteFile(cmd.Context()); err != nil {
+				return err
+			} else {
+				applyEnabledChanges(cmd.Context(), cfg, control.SetWorkloadEnabled)
+			}
+			if cfg.JSONOutput {
+				return onetime.PrintJSON(cmd.OutOrStdout(), cfg.Result())
 			}
-			return cfg.WriteFile(cmd.Context())
+			return nil
 		},
 	}
 
@@ -98,10 +119,33 @@ func NewCommand(lp log.Parameters) *cobra.Command {
 	configureCmd.AddCommand(sqlserver.NewCommand(cfg))
 	configureCmd.AddCommand(mysql.NewCommand(cfg))
 	configureCmd.AddCommand(redis.NewCommand(cfg))
+	configureCmd.AddCommand(postgres.NewCommand(cfg))
+	configureCmd.AddCommand(show.NewCommand(cfg))
+	configureCmd.AddCommand(rollback.NewCommand(cfg))
+	configureCmd.AddCommand(credentials.NewCommand(cfg))
 
 	return configureCmd
 }
 
+// applyEnabledChanges enables or disables workloads in the running agent, so the change
+// does not wait for the agent to reload the configuration file.
+func applyEnabledChanges(ctx context.Context, cfg *cliconfig.Configure, setWorkloadEnabled func(context.Context, string, string, bool) error) {
+	workloads := make([]string, 0, len(cfg.EnabledChanges))
+	for w := range cfg.EnabledChanges {
+		workloads = append(workloads, w)
+	}
+	sort.Strings(workloads)
+	for _, w := range workloads {
+		enabled := cfg.EnabledChanges[w]
+		if err := setWorkloadEnabled(ctx, control.SocketPath(), w, enabled); err != nil {
+			log.CtxLogger(ctx).Debugw("Unable to update the running agent", "workload", w, "error", err)
+			cfg.LogToBoth(ctx, fmt.Sprintf("Could not update the running agent, the %s change applies when the agent reloads its configuration.", w))
+			continue
+		}
+		cfg.LogToBoth(ctx, fmt.Sprintf("Applied %s enabled: %v to the running agent.", w, enabled))
+	}
+}
+
 // configPath determines the configuration path based on the OS.
 func configPath(goos string) string {
 	if goos == "windows" {
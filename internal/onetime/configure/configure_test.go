@@ -0,0 +1,82 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configure
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+func TestApplyEnabledChanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		changes      map[string]bool
+		err          error
+		want         map[string]bool
+		wantMessages []string
+	}{
+		{
+			name:         "NoChanges",
+			want:         map[string]bool{},
+			wantMessages: []string{},
+		},
+		{
+			name:    "AppliedToRunningAgent",
+			changes: map[string]bool{"mysql": true, "redis": false},
+			want:    map[string]bool{"mysql": true, "redis": false},
+			wantMessages: []string{
+				"Applied mysql enabled: true to the running agent.",
+				"Applied redis enabled: false to the running agent.",
+			},
+		},
+		{
+			name:    "AgentNotRunning",
+			changes: map[string]bool{"postgres": true},
+			err:     errors.New("connection refused"),
+			want:    map[string]bool{"postgres": true},
+			wantMessages: []string{
+				"Could not update the running agent, the postgres change applies when the agent reloads its configuration.",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := cliconfig.NewConfigure("", log.Parameters{}, nil, nil)
+			cfg.JSONOutput = true
+			cfg.EnabledChanges = tc.changes
+			got := map[string]bool{}
+			set := func(_ context.Context, _, workload string, enabled bool) error {
+				got[workload] = enabled
+				return tc.err
+			}
+
+			applyEnabledChanges(context.Background(), cfg, set)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("applyEnabledChanges() sent unexpected changes (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantMessages, cfg.Result().Messages); diff != "" {
+				t.Errorf("applyEnabledChanges() logged unexpected messages (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials implements the credentials subcommand, which stores database passwords
+// encrypted in the local credential store.
+package credentials
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentialstore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// storeSetter stores an encrypted credential.
+type storeSetter interface {
+	Set(name string, password secret.String) error
+}
+
+// newStoreFunc opens the credential store at storeFile, encrypting with the key in keyFile.
+type newStoreFunc func(storeFile, keyFile string) storeSetter
+
+// connectionParameters return the connection parameters of a workload, creating them if needed.
+var connectionParameters = map[string]func(cfg *cliconfig.Configure) *cpb.ConnectionParameters{
+	"mysql": func(cfg *cliconfig.Configure) *cpb.ConnectionParameters {
+		cfg.ValidateMySQLConnectionParams()
+		return cfg.Configuration.MysqlConfiguration.ConnectionParameters
+	},
+	"postgres": func(cfg *cliconfig.Configure) *cpb.ConnectionParameters {
+		cfg.ValidatePostgresConnectionParams()
+		return cfg.Configuration.PostgresConfiguration.ConnectionParameters
+	},
+	"redis": func(cfg *cliconfig.Configure) *cpb.ConnectionParameters {
+		cfg.ValidateRedisConnectionParams()
+		return cfg.Configuration.RedisConfiguration.ConnectionParameters
+	},
+	"mongodb": func(cfg *cliconfig.Configure) *cpb.ConnectionParameters {
+		if cfg.Configuration.MongoDbConfiguration == nil {
+			cfg.Configuration.MongoDbConfiguration = &cpb.MongoDBConfiguration{}
+		}
+		if cfg.Configuration.MongoDbConfiguration.ConnectionParameters == nil {
+			cfg.Configuration.MongoDbConfiguration.ConnectionParameters = &cpb.ConnectionParameters{}
+		}
+		return cfg.Configuration.MongoDbConfiguration.ConnectionParameters
+	},
+}
+
+// NewCommand creates a new 'credentials' command.
+func NewCommand(cfg *cliconfig.Configure) *cobra.Command {
+	credentialsCmd := &cobra.Command{
+		Use:   "credentials",
+		Short: "Manage the local encrypted credential store",
+		Long: `Manage database passwords stored encrypted in a local file, for environments
+which can't reach Secret Manager.
+
+By default passwords are encrypted with a key derived from the identity of the
+Compute Engine instance, so a copy of the store can't be decrypted on another
+machine. This protects the password if the file is disclosed, but not from
+users who can query the metadata server of this instance. Use --key-file to
+encrypt with a locally generated key instead.`,
+	}
+	credentialsCmd.AddCommand(newSetCmd(cfg, func(storeFile, keyFile string) storeSetter {
+		return credentialstore.New(storeFile, keyFile)
+	}))
+	return credentialsCmd
+}
+
+// newSetCmd stores the password of a workload and points its connection parameters at it.
+func newSetCmd(cfg *cliconfig.Configure, newStore newStoreFunc) *cobra.Command {
+	var name, storeFile, keyFile string
+	workloads := make([]string, 0, len(connectionParameters))
+	for w := range connectionParameters {
+		workloads = append(workloads, w)
+	}
+	sort.Strings(workloads)
+
+	setCmd := &cobra.Command{
+		Use:   "set <workload>",
+		Short: "Store the database password of a workload in the credential store.",
+		Long: fmt.Sprintf(`Reads the password from the first line of standard input, stores it encrypted
+in the credential store and configures the workload to read it from there.
+Any plaintext password in the configuration is removed.
+
+Supported workloads: %s.`, strings.Join(workloads, ", ")),
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: workloads,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			workload := args[0]
+			getParams, ok := connectionParameters[workload]
+			if !ok {
+				return fmt.Errorf("unsupported workload %q, want one of %s", workload, strings.Join(workloads, ", "))
+			}
+			password, err := ReadPassword(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				name = workload
+			}
+			if err := newStore(storeFile, keyFile).Set(name, password); err != nil {
+				return fmt.Errorf("storing the %s password: %w", workload, err)
+			}
+
+			cp := getParams(cfg)
+			cp.StoredCredential = &cpb.StoredCredentialRef{Name: name, StoreFile: storeFile, KeyFile: keyFile}
+			cp.Password = ""
+			cfg.CredentialsConfigModified = true
+			cfg.LogToBoth(cmd.Context(), fmt.Sprintf("Stored the %s password as credential %s.", workload, name))
+			if keyFile == "" {
+				cfg.LogToBoth(cmd.Context(), "The password is only obfuscated: the default key is derived from the instance identity, which is not secret. Use --key-file to encrypt it.")
+			}
+			return nil
+		},
+	}
+
+	setCmd.Flags().StringVar(&name, "name", "", "Name of the credential, defaults to the workload")
+	setCmd.Flags().StringVar(&storeFile, "store-file", "", fmt.Sprintf("Credential store file, defaults to %s", credentialstore.DefaultPath()))
+	setCmd.Flags().StringVar(&keyFile, "key-file", "", "File with the encryption key, generated if missing; defaults to a key derived from the non-secret instance identity, which only obfuscates the password")
+
+	return setCmd
+}
+
+// ReadPassword returns the first line of r, which holds a password piped to a subcommand.
+func ReadPassword(r io.Reader) (secret.String, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading the password: %w", err)
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		return "", errors.New("no password was provided on standard input")
+	}
+	return secret.String(line), nil
+}
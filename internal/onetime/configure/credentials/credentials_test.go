@@ -0,0 +1,129 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+type fakeStore struct {
+	stored map[string]string
+}
+
+func (f *fakeStore) Set(name string, password secret.String) error {
+	f.stored[name] = password.SecretValue()
+	return nil
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		config     *cpb.Configuration
+		wantConfig *cpb.Configuration
+		wantStored map[string]string
+		wantErr    bool
+	}{
+		{
+			name:  "MySQL",
+			args:  []string{"mysql"},
+			stdin: "mysql-password\n",
+			config: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{Username: "agent", Password: "plaintext"},
+				},
+			},
+			wantConfig: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{
+						Username:         "agent",
+						StoredCredential: &cpb.StoredCredentialRef{Name: "mysql"},
+					},
+				},
+			},
+			wantStored: map[string]string{"mysql": "mysql-password"},
+		},
+		{
+			name:   "NameAndKeyFile",
+			args:   []string{"postgres", "--name=pg", "--key-file=/etc/key", "--store-file=/etc/store.json"},
+			stdin:  "pg-password",
+			config: &cpb.Configuration{},
+			wantConfig: &cpb.Configuration{
+				PostgresConfiguration: &cpb.PostgresConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{
+						StoredCredential: &cpb.StoredCredentialRef{Name: "pg", StoreFile: "/etc/store.json", KeyFile: "/etc/key"},
+					},
+				},
+			},
+			wantStored: map[string]string{"pg": "pg-password"},
+		},
+		{
+			name:       "UnsupportedWorkload",
+			args:       []string{"oracle"},
+			stdin:      "password\n",
+			config:     &cpb.Configuration{},
+			wantConfig: &cpb.Configuration{},
+			wantStored: map[string]string{},
+			wantErr:    true,
+		},
+		{
+			name:       "EmptyPassword",
+			args:       []string{"redis"},
+			stdin:      "\n",
+			config:     &cpb.Configuration{},
+			wantConfig: &cpb.Configuration{},
+			wantStored: map[string]string{},
+			wantErr:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &cliconfig.Configure{Configuration: tc.config}
+			store := &fakeStore{stored: map[string]string{}}
+			cmd := newSetCmd(cfg, func(storeFile, keyFile string) storeSetter { return store })
+			cmd.SetArgs(tc.args)
+			cmd.SetIn(strings.NewReader(tc.stdin))
+			cmd.SetOut(bytes.NewBufferString(""))
+			cmd.SetErr(bytes.NewBufferString(""))
+
+			err := cmd.Execute()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Execute(%v) returned error %v, want error: %v", tc.args, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantConfig, cfg.Configuration, protocmp.Transform()); diff != "" {
+				t.Errorf("Execute(%v) returned unexpected configuration diff (-want +got):\n%s", tc.args, diff)
+			}
+			if diff := cmp.Diff(tc.wantStored, store.stored); diff != "" {
+				t.Errorf("Execute(%v) stored unexpected credentials (-want +got):\n%s", tc.args, diff)
+			}
+			if cfg.CredentialsConfigModified != !tc.wantErr {
+				t.Errorf("CredentialsConfigModified = %v, want %v", cfg.CredentialsConfigModified, !tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package monitoringuser implements the shared parts of the per-workload create-monitoring-user
+// subcommands, which create a database user with only the privileges the collectors need.
+package monitoringuser
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentialstore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/credentials"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	// PasswordPlaceholder stands for the generated password in the statements printed by a dry run.
+	PasswordPlaceholder = "<password>"
+)
+
+// identifierPattern matches the user names, and MySQL host patterns, which are accepted without quoting concerns.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z0-9_.%:-]+$`)
+
+// Request describes the monitoring user of a workload.
+type Request struct {
+	// Workload names the workload in messages and the stored credential, e.g. MySQL.
+	Workload string
+	// CredentialName is the name of the stored credential holding the password of the user.
+	CredentialName string
+	// Statements returns the statements which create the user with the given password.
+	Statements func(password string) []string
+	// Execute runs the statements as the administrator.
+	Execute func(ctx context.Context, adminPassword secret.String, statements []string) error
+	DryRun  bool
+	// Stdin holds the password of the administrator.
+	Stdin io.Reader
+	// StoreFile and KeyFile locate the credential store, see credentialstore.New.
+	StoreFile, KeyFile string
+}
+
+// ValidateIdentifier returns an error if name can't safely be used as a user name or host pattern.
+func ValidateIdentifier(flag, name string) error {
+	if !identifierPattern.MatchString(name) {
+		return fmt.Errorf("invalid --%s %q, only letters, digits and _ . %% : - are allowed", flag, name)
+	}
+	return nil
+}
+
+// Run prints the statements for a dry run. Otherwise it executes them as the administrator with a
+// generated password and stores the password in the local credential store, so that it is never
+// shown. It returns the reference to the stored credential, or nil for a dry run.
+func Run(ctx context.Context, cfg *cliconfig.Configure, r Request) (*cpb.StoredCredentialRef, error) {
+	if r.DryRun {
+		cfg.LogToBoth(ctx, fmt.Sprintf("-- Statements which create the %s monitoring user, replace %s with its password.", r.Workload, PasswordPlaceholder))
+		for _, s := range r.Statements(PasswordPlaceholder) {
+			cfg.LogToBoth(ctx, s+";")
+		}
+		return nil, nil
+	}
+
+	adminPassword, err := credentials.ReadPassword(r.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("reading the administrator password: %w", err)
+	}
+	// rand.Text has 128 bits of entropy and no characters which need quoting in SQL.
+	password := secret.String(rand.Text())
+	if err := r.Execute(ctx, adminPassword, r.Statements(password.SecretValue())); err != nil {
+		return nil, fmt.Errorf("creating the %s monitoring user: %w", r.Workload, secretredact.Error(err, password.SecretValue(), adminPassword.SecretValue()))
+	}
+	if err := credentialstore.New(r.StoreFile, r.KeyFile).Set(r.CredentialName, password); err != nil {
+		return nil, fmt.Errorf("the %s monitoring user was created but storing its password failed, run the command again to reset it: %w", r.Workload, err)
+	}
+	cfg.LogToBoth(ctx, fmt.Sprintf("Created the %s monitoring user and stored its password as credential %s.", r.Workload, r.CredentialName))
+	return &cpb.StoredCredentialRef{Name: r.CredentialName, StoreFile: r.StoreFile, KeyFile: r.KeyFile}, nil
+}
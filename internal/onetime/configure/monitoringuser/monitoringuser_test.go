@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package monitoringuser
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentialstore"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func statements(password string) []string {
+	return []string{"CREATE USER agent IDENTIFIED BY '" + password + "'", "GRANT PROCESS TO agent"}
+}
+
+func TestRunDryRun(t *testing.T) {
+	cfg := &cliconfig.Configure{JSONOutput: true}
+	executed := false
+	ref, err := Run(context.Background(), cfg, Request{
+		Workload:   "MySQL",
+		Statements: statements,
+		Execute: func(ctx context.Context, adminPassword secret.String, statements []string) error {
+			executed = true
+			return nil
+		},
+		DryRun: true,
+	})
+	if err != nil || ref != nil {
+		t.Fatalf("Run() = %v, %v, want nil, nil", ref, err)
+	}
+	if executed {
+		t.Error("Run() executed the statements of a dry run")
+	}
+	want := []string{
+		"CREATE USER agent IDENTIFIED BY '<password>';",
+		"GRANT PROCESS TO agent;",
+	}
+	if diff := cmp.Diff(want, cfg.Result().Messages[1:]); diff != "" {
+		t.Errorf("Run() printed unexpected statements (-want +got):\n%s", diff)
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	storeFile := filepath.Join(dir, "credentialstore.json")
+	keyFile := filepath.Join(dir, "credentialstore.key")
+	var gotAdmin string
+	var gotStatements []string
+	ref, err := Run(context.Background(), &cliconfig.Configure{JSONOutput: true}, Request{
+		Workload:       "MySQL",
+		CredentialName: "mysql",
+		Statements:     statements,
+		Execute: func(ctx context.Context, adminPassword secret.String, statements []string) error {
+			gotAdmin = adminPassword.SecretValue()
+			gotStatements = statements
+			return nil
+		},
+		Stdin:     strings.NewReader("admin-password\n"),
+		StoreFile: storeFile,
+		KeyFile:   keyFile,
+	})
+	if err != nil {
+		t.Fatalf("Run() returned unexpected error: %v", err)
+	}
+	want := &cpb.StoredCredentialRef{Name: "mysql", StoreFile: storeFile, KeyFile: keyFile}
+	if diff := cmp.Diff(want, ref, protocmp.Transform()); diff != "" {
+		t.Errorf("Run() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if gotAdmin != "admin-password" {
+		t.Errorf("Run() executed as administrator with password %q, want %q", gotAdmin, "admin-password")
+	}
+
+	stored, err := credentialstore.New(storeFile, keyFile).Get("mysql")
+	if err != nil {
+		t.Fatalf("Get() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(statements(stored.SecretValue()), gotStatements); diff != "" {
+		t.Errorf("Run() executed statements without the stored password (-want +got):\n%s", diff)
+	}
+	if len(stored.SecretValue()) < 20 {
+		t.Errorf("Run() generated a password of %d characters, want at least 20", len(stored.SecretValue()))
+	}
+}
+
+func TestRunExecuteErrorIsRedacted(t *testing.T) {
+	_, err := Run(context.Background(), &cliconfig.Configure{JSONOutput: true}, Request{
+		Workload:   "MySQL",
+		Statements: statements,
+		Execute: func(ctx context.Context, adminPassword secret.String, statements []string) error {
+			return errors.New("access denied for admin-password near " + statements[0])
+		},
+		Stdin: strings.NewReader("admin-password\n"),
+	})
+	if err == nil {
+		t.Fatal("Run() returned nil error, want error")
+	}
+	if strings.Contains(err.Error(), "admin-password") || !strings.Contains(err.Error(), "IDENTIFIED BY '<redacted>'") {
+		t.Errorf("Run() returned error %q, want the passwords redacted", err)
+	}
+}
+
+func TestValidateIdentifier(t *testing.T) {
+	for name, wantErr := range map[string]bool{
+		"workloadagent": false,
+		"10.0.0.%":      false,
+		"agent'@'%":     true,
+		`agent"; DROP`:  true,
+		"":              true,
+	} {
+		if err := ValidateIdentifier("username", name); (err != nil) != wantErr {
+			t.Errorf("ValidateIdentifier(%q) = %v, want error: %v", name, err, wantErr)
+		}
+	}
+}
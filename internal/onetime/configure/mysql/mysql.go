@@ -18,14 +18,30 @@ limitations under the License.
 package mysql
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"net"
+	"strconv"
 
+	"github.com/go-sql-driver/mysql"
 	"github.com/spf13/cobra"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/mysqlmetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/monitoringuser"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/testconnection"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
 
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
 
+// connectionTester tests the connection to the configured MySQL database.
+type connectionTester func(ctx context.Context, config *cpb.Configuration) error
+
+// statementExecutor runs statements on the configured MySQL database as an administrator.
+type statementExecutor func(ctx context.Context, config *cpb.Configuration, adminUser string, adminPassword secret.String, statements []string) error
+
 // NewCommand creates a new 'mysql' command.
 func NewCommand(cfg *cliconfig.Configure) *cobra.Command {
 	var enabled bool
@@ -45,6 +61,7 @@ for monitoring MySQL databases, including discovery and metrics collection.`,
 				cfg.LogToBoth(cmd.Context(), msg)
 				cfg.Configuration.MysqlConfiguration.Enabled = &enabled
 				cfg.MySQLConfigModified = true
+				cfg.RecordEnabledChange("mysql", enabled)
 			}
 		},
 	}
@@ -52,6 +69,8 @@ for monitoring MySQL databases, including discovery and metrics collection.`,
 	mysqlCmd.Flags().BoolVar(&enabled, "enabled", false, "Enable MySQL configuration")
 
 	mysqlCmd.AddCommand(newConnectionParamsCmd(cfg))
+	mysqlCmd.AddCommand(newTestConnectionCmd(cfg, testConnection))
+	mysqlCmd.AddCommand(newCreateMonitoringUserCmd(cfg, executeStatements))
 
 	return mysqlCmd
 }
@@ -116,3 +135,146 @@ the password in Google Cloud Secret Manager and using the --project-id and
 
 	return cpCmd
 }
+
+// newTestConnectionCmd tests the connection to the MySQL database with the configured parameters.
+func newTestConnectionCmd(cfg *cliconfig.Configure, test connectionTester) *cobra.Command {
+	return &cobra.Command{
+		Use:   "test-connection",
+		Short: "Test the connection to the MySQL database.",
+		Long: `Resolves the configured password or secret, connects to the MySQL database
+and runs a trivial query. Failures are reported with their most likely cause
+so that they can be fixed before MySQL monitoring is enabled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := test(cmd.Context(), cfg.Configuration)
+			return testconnection.Report(cmd.Context(), cfg, "MySQL", []testconnection.Result{{Target: "localhost:3306", Err: err}})
+		},
+	}
+}
+
+// testConnection connects to the MySQL database the same way the daemon does.
+func testConnection(ctx context.Context, config *cpb.Configuration) error {
+	gceService, err := endpoints.NewGCEClient(ctx, config.GetEndpoints())
+	if err != nil {
+		return fmt.Errorf("initializing GCE services: %w", err)
+	}
+	m := mysqlmetrics.New(ctx, config, nil, nil)
+	if err := m.InitDB(ctx, gceService); err != nil {
+		return err
+	}
+	return m.Ping(ctx)
+}
+
+// newCreateMonitoringUserCmd creates a MySQL user with the privileges needed by the collectors.
+func newCreateMonitoringUserCmd(cfg *cliconfig.Configure, exec statementExecutor) *cobra.Command {
+	var (
+		adminUser, username, userHost string
+		storeFile, keyFile            string
+		dryRun                        bool
+	)
+	cmd := &cobra.Command{
+		Use:   "create-monitoring-user",
+		Short: "Create a MySQL user with the least privileges needed by the agent.",
+		Long: `Connects to the configured MySQL database as an administrator and creates a
+user with only the privileges the collectors need, so that the agent does not
+need the root account. The administrator password is read from the first line
+of standard input and is not stored.
+
+The password of the new user is generated, stored in the local encrypted
+credential store and never shown; the connection parameters are updated to
+use the new user. Running the command again resets the password.
+
+With --dry-run the SQL statements are printed instead, for an administrator to
+review or run manually.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := monitoringuser.ValidateIdentifier("username", username); err != nil {
+				return err
+			}
+			if err := monitoringuser.ValidateIdentifier("user-host", userHost); err != nil {
+				return err
+			}
+			ref, err := monitoringuser.Run(cmd.Context(), cfg, monitoringuser.Request{
+				Workload:       "MySQL",
+				CredentialName: "mysql",
+				Statements: func(password string) []string {
+					return monitoringUserStatements(username, userHost, password)
+				},
+				Execute: func(ctx context.Context, adminPassword secret.String, statements []string) error {
+					return exec(ctx, cfg.Configuration, adminUser, adminPassword, statements)
+				},
+				DryRun:    dryRun,
+				Stdin:     cmd.InOrStdin(),
+				StoreFile: storeFile,
+				KeyFile:   keyFile,
+			})
+			if err != nil || ref == nil {
+				return err
+			}
+			cfg.ValidateMySQLConnectionParams()
+			cp := cfg.Configuration.MysqlConfiguration.ConnectionParameters
+			cp.Username = username
+			cp.Password = ""
+			cp.Secret = nil
+			cp.StoredCredential = ref
+			cfg.MySQLConfigModified = true
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&adminUser, "admin-username", "root", "Administrator who creates the user")
+	cmd.Flags().StringVar(&username, "username", "workloadagent", "Name of the user to create")
+	cmd.Flags().StringVar(&userHost, "user-host", "localhost", "Host the user connects from, % for any host")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the SQL statements instead of executing them")
+	cmd.Flags().StringVar(&storeFile, "store-file", "", "Credential store file, defaults to the agent configuration directory")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "File with the credential store key, defaults to a key derived from the instance identity")
+
+	return cmd
+}
+
+// monitoringUserStatements returns the statements which create the user and grant the privileges
+// read by the collectors. The user is updated if it already exists.
+func monitoringUserStatements(user, host, password string) []string {
+	account := fmt.Sprintf("'%s'@'%s'", user, host)
+	return []string{
+		fmt.Sprintf("CREATE USER IF NOT EXISTS %s IDENTIFIED BY '%s'", account, password),
+		fmt.Sprintf("ALTER USER %s IDENTIFIED BY '%s'", account, password),
+		// PROCESS lists the connections of replicas, REPLICATION CLIENT reads the replica status.
+		"GRANT PROCESS, REPLICATION CLIENT ON *.* TO " + account,
+		// Accounts are checked for missing passwords and access from any host.
+		"GRANT SELECT ON mysql.user TO " + account,
+		"GRANT SELECT ON performance_schema.* TO " + account,
+		// xtrabackup records its backups in PERCONA_SCHEMA.
+		"GRANT SELECT ON PERCONA_SCHEMA.* TO " + account,
+	}
+}
+
+// executeStatements runs the statements on the configured MySQL database as the administrator.
+func executeStatements(ctx context.Context, config *cpb.Configuration, adminUser string, adminPassword secret.String, statements []string) error {
+	cp := config.GetMysqlConfiguration().GetConnectionParameters()
+	c := mysql.NewConfig()
+	c.User = adminUser
+	c.Passwd = adminPassword.SecretValue()
+	// Like the collectors, the default port on localhost is used unless a host or port is configured.
+	if cp.GetHost() != "" || cp.GetPort() != 0 {
+		host, port := cp.GetHost(), int(cp.GetPort())
+		if host == "" {
+			host = "localhost"
+		}
+		if port == 0 {
+			port = 3306
+		}
+		c.Net = "tcp"
+		c.Addr = net.JoinHostPort(host, strconv.Itoa(port))
+	}
+	db, err := sql.Open("mysql", c.FormatDSN())
+	if err != nil {
+		return fmt.Errorf("opening MySQL connection: %w", err)
+	}
+	defer db.Close()
+	for i, s := range statements {
+		// The statement is not part of the error, it may contain the password.
+		if _, err := db.ExecContext(ctx, s); err != nil {
+			return fmt.Errorf("executing statement %d of %d: %w", i+1, len(statements), err)
+		}
+	}
+	return nil
+}
@@ -18,6 +18,9 @@ package mysql
 
 import (
 	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -26,6 +29,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
 
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
@@ -55,6 +59,7 @@ func TestNewCommand(t *testing.T) {
 					},
 				},
 				MySQLConfigModified: true,
+				EnabledChanges:      map[string]bool{"mysql": true},
 			},
 		},
 		{
@@ -183,3 +188,156 @@ func TestNewCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestTestConnectionCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		testErr error
+		wantErr bool
+	}{
+		{
+			name: "Success",
+		},
+		{
+			name:    "Failure",
+			testErr: errors.New("Error 1045 (28000): Access denied for user 'test-user'@'localhost' (using password: YES)"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					MysqlConfiguration: &cpb.MySQLConfiguration{
+						ConnectionParameters: &cpb.ConnectionParameters{Username: "test-user"},
+					},
+				},
+			}
+			test := func(ctx context.Context, config *cpb.Configuration) error {
+				if diff := cmp.Diff(cfg.Configuration, config, protocmp.Transform()); diff != "" {
+					t.Errorf("test-connection used unexpected configuration (-want +got):\n%s", diff)
+				}
+				return tc.testErr
+			}
+			cmd := newTestConnectionCmd(cfg, test)
+			cmd.SetArgs([]string{})
+			cmd.SetOut(bytes.NewBufferString(""))
+			err := cmd.Execute()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("newTestConnectionCmd().Execute() = %v, want error: %t", err, tc.wantErr)
+			}
+			if cfg.IsConfigModified() {
+				t.Error("newTestConnectionCmd().Execute() modified the configuration")
+			}
+		})
+	}
+}
+
+func TestCreateMonitoringUserCmd(t *testing.T) {
+	dir := t.TempDir()
+	storeFile := filepath.Join(dir, "credentialstore.json")
+	keyFile := filepath.Join(dir, "credentialstore.key")
+	tests := []struct {
+		name       string
+		args       []string
+		execErr    error
+		wantAdmin  string
+		wantConfig *cpb.Configuration
+		wantErr    bool
+	}{
+		{
+			name:      "CreateUser",
+			args:      []string{"--admin-username=admin", "--store-file=" + storeFile, "--key-file=" + keyFile},
+			wantAdmin: "admin",
+			wantConfig: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{
+						Host:             "10.0.0.1",
+						Username:         "workloadagent",
+						StoredCredential: &cpb.StoredCredentialRef{Name: "mysql", StoreFile: storeFile, KeyFile: keyFile},
+					},
+				},
+			},
+		},
+		{
+			name: "DryRun",
+			args: []string{"--dry-run"},
+			wantConfig: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{Host: "10.0.0.1", Username: "root", Password: "root-password"},
+				},
+			},
+		},
+		{
+			name:      "ExecuteError",
+			args:      []string{"--store-file=" + storeFile, "--key-file=" + keyFile},
+			execErr:   errors.New("Error 1227 (42000): Access denied"),
+			wantAdmin: "root",
+			wantConfig: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{Host: "10.0.0.1", Username: "root", Password: "root-password"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidUsername",
+			args:    []string{"--username=agent'@'%"},
+			wantErr: true,
+			wantConfig: &cpb.Configuration{
+				MysqlConfiguration: &cpb.MySQLConfiguration{
+					ConnectionParameters: &cpb.ConnectionParameters{Host: "10.0.0.1", Username: "root", Password: "root-password"},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					MysqlConfiguration: &cpb.MySQLConfiguration{
+						ConnectionParameters: &cpb.ConnectionParameters{Host: "10.0.0.1", Username: "root", Password: "root-password"},
+					},
+				},
+				JSONOutput: true,
+			}
+			var gotAdmin string
+			exec := func(ctx context.Context, config *cpb.Configuration, adminUser string, adminPassword secret.String, statements []string) error {
+				gotAdmin = adminUser
+				return tc.execErr
+			}
+			cmd := newCreateMonitoringUserCmd(cfg, exec)
+			cmd.SetArgs(tc.args)
+			cmd.SetIn(strings.NewReader("admin-password\n"))
+			cmd.SetOut(bytes.NewBufferString(""))
+			cmd.SetErr(bytes.NewBufferString(""))
+
+			err := cmd.Execute()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("newCreateMonitoringUserCmd().Execute() = %v, want error: %t", err, tc.wantErr)
+			}
+			if gotAdmin != tc.wantAdmin {
+				t.Errorf("newCreateMonitoringUserCmd().Execute() connected as %q, want %q", gotAdmin, tc.wantAdmin)
+			}
+			if diff := cmp.Diff(tc.wantConfig, cfg.Configuration, protocmp.Transform()); diff != "" {
+				t.Errorf("newCreateMonitoringUserCmd().Execute() returned unexpected configuration diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMonitoringUserStatements(t *testing.T) {
+	want := []string{
+		"CREATE USER IF NOT EXISTS 'agent'@'%' IDENTIFIED BY 'secret'",
+		"ALTER USER 'agent'@'%' IDENTIFIED BY 'secret'",
+		"GRANT PROCESS, REPLICATION CLIENT ON *.* TO 'agent'@'%'",
+		"GRANT SELECT ON mysql.user TO 'agent'@'%'",
+		"GRANT SELECT ON performance_schema.* TO 'agent'@'%'",
+		"GRANT SELECT ON PERCONA_SCHEMA.* TO 'agent'@'%'",
+	}
+	if diff := cmp.Diff(want, monitoringUserStatements("agent", "%", "secret")); diff != "" {
+		t.Errorf("monitoringUserStatements() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -43,6 +43,7 @@ for monitoring Oracle databases, including discovery and metrics collection.`,
 				cfg.LogToBoth(cmd.Context(), msg)
 				cfg.Configuration.OracleConfiguration.Enabled = &enabled
 				cfg.OracleConfigModified = true
+				cfg.RecordEnabledChange("oracle", enabled)
 			}
 		},
 	}
@@ -51,6 +52,7 @@ for monitoring Oracle databases, including discovery and metrics collection.`,
 
 	oracleCmd.AddCommand(DiscoveryCommand(cfg))
 	oracleCmd.AddCommand(MetricsCommand(cfg))
+	oracleCmd.AddCommand(TestConnectionCommand(cfg))
 
 	return oracleCmd
 }
@@ -51,6 +51,7 @@ func TestNewCommand(t *testing.T) {
 					},
 				},
 				OracleConfigModified: true,
+				EnabledChanges:       map[string]bool{"oracle": true},
 			},
 		},
 		{
@@ -70,6 +71,7 @@ func TestNewCommand(t *testing.T) {
 					},
 				},
 				OracleConfigModified: true,
+				EnabledChanges:       map[string]bool{"oracle": false},
 			},
 		},
 		{
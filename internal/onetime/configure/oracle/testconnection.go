@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oracle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/testconnection"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/oraclemetrics"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// connectionChecker tests the connections to the configured Oracle databases.
+// The returned map is keyed by service name.
+type connectionChecker func(ctx context.Context, config *cpb.Configuration) (map[string]error, error)
+
+// TestConnectionCommand creates a new 'test-connection' command for Oracle.
+func TestConnectionCommand(cfg *cliconfig.Configure) *cobra.Command {
+	return newTestConnectionCmd(cfg, checkConnections)
+}
+
+func newTestConnectionCmd(cfg *cliconfig.Configure, check connectionChecker) *cobra.Command {
+	return &cobra.Command{
+		Use:   "test-connection",
+		Short: "Test the connections to the Oracle databases.",
+		Long: `Resolves the secrets of the configured Oracle metrics connection parameters,
+connects to each database and runs a trivial query. Failures are reported with
+their most likely cause so that they can be fixed before Oracle metrics are enabled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			errs, err := check(cmd.Context(), cfg.Configuration)
+			if err != nil {
+				return testconnection.Report(cmd.Context(), cfg, "Oracle", []testconnection.Result{{Target: "all databases", Err: err}})
+			}
+			return testconnection.Report(cmd.Context(), cfg, "Oracle", testconnection.FromMap(errs))
+		},
+	}
+}
+
+// checkConnections connects to the Oracle databases the same way the daemon does.
+func checkConnections(ctx context.Context, config *cpb.Configuration) (map[string]error, error) {
+	gceService, err := endpoints.NewGCEClient(ctx, config.GetEndpoints())
+	if err != nil {
+		return nil, fmt.Errorf("initializing GCE services: %w", err)
+	}
+	return oraclemetrics.CheckConnections(ctx, gceService, config)
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package postgres implements the postgres subcommand.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	// Register the pq driver for Postgres with the database/sql package.
+	_ "github.com/lib/pq"
+	"github.com/spf13/cobra"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/postgresmetrics"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/monitoringuser"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/testconnection"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// connectionTester tests the connection to the configured Postgres database.
+type connectionTester func(ctx context.Context, config *cpb.Configuration) error
+
+// statementExecutor runs statements on the configured Postgres database as an administrator.
+type statementExecutor func(ctx context.Context, config *cpb.Configuration, adminUser string, adminPassword secret.String, statements []string) error
+
+// NewCommand creates a new 'postgres' command.
+func NewCommand(cfg *cliconfig.Configure) *cobra.Command {
+	var enabled bool
+
+	postgresCmd := &cobra.Command{
+		Use:   "postgres",
+		Short: "Configure Postgres settings",
+		Long: `Configure Postgres settings for the Google Cloud Agent for Compute Workloads.
+
+This command allows you to enable and configure various features
+for monitoring Postgres databases, including discovery and metrics collection.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg.ValidatePostgres()
+
+			if cmd.Flags().Changed("enabled") {
+				msg := fmt.Sprintf("Postgres Enabled: %v", enabled)
+				cfg.LogToBoth(cmd.Context(), msg)
+				cfg.Configuration.PostgresConfiguration.Enabled = &enabled
+				cfg.PostgresConfigModified = true
+				cfg.RecordEnabledChange("postgres", enabled)
+			}
+		},
+	}
+
+	postgresCmd.Flags().BoolVar(&enabled, "enabled", false, "Enable Postgres configuration")
+
+	postgresCmd.AddCommand(newConnectionParamsCmd(cfg))
+	postgresCmd.AddCommand(newTestConnectionCmd(cfg, testConnection))
+	postgresCmd.AddCommand(newCreateMonitoringUserCmd(cfg, executeStatements))
+
+	return postgresCmd
+}
+
+// newConnectionParamsCmd adds connection parameters for a Postgres database.
+func newConnectionParamsCmd(cfg *cliconfig.Configure) *cobra.Command {
+	var username, projectID, secretName, password string
+	cpCmd := &cobra.Command{
+		Use:   "connection-params",
+		Short: "Add connection parameters for a Postgres database.",
+		Long: `Sets the username, password, and Secret Manager details
+for connecting to the Postgres database specified in the main configuration.
+
+Existing connection parameters will be overwritten by the provided flags.
+
+WARNING: Using the --password flag is not recommended for security reasons
+as it can expose the password in shell history or logs. Please prefer storing
+the password in Google Cloud Secret Manager and using the --project-id and
+--secret-name flags instead.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg.ValidatePostgresConnectionParams()
+			cp := cfg.Configuration.PostgresConfiguration.ConnectionParameters
+
+			if cmd.Flags().Changed("username") {
+				msg := fmt.Sprintf("Setting Postgres Username: %v", username)
+				cfg.LogToBoth(cmd.Context(), msg)
+				cp.Username = username
+				cfg.PostgresConfigModified = true
+			}
+			if cmd.Flags().Changed("password") {
+				msg := fmt.Sprintf("Setting Postgres Password: %v", password)
+				cfg.LogToBoth(cmd.Context(), msg)
+				cp.Password = password
+				cfg.PostgresConfigModified = true
+			}
+
+			spChanged := cmd.Flags().Changed("project-id")
+			snChanged := cmd.Flags().Changed("secret-name")
+			if (spChanged || snChanged) && (cp.Secret == nil) {
+				cp.Secret = &cpb.SecretRef{}
+			}
+			if spChanged {
+				msg := fmt.Sprintf("Setting Postgres Project ID: %v", projectID)
+				cfg.LogToBoth(cmd.Context(), msg)
+				cp.Secret.ProjectId = projectID
+				cfg.PostgresConfigModified = true
+			}
+			if snChanged {
+				msg := fmt.Sprintf("Setting Postgres Secret Name: %v", secretName)
+				cfg.LogToBoth(cmd.Context(), msg)
+				cp.Secret.SecretName = secretName
+				cfg.PostgresConfigModified = true
+			}
+		},
+	}
+
+	// Add flags for the connection
+	cpCmd.Flags().StringVar(&username, "username", "", "Database username")
+	cpCmd.Flags().StringVar(&projectID, "project-id", "", "Project ID")
+	cpCmd.Flags().StringVar(&secretName, "secret-name", "", "Secret name")
+	cpCmd.Flags().StringVar(&password, "password", "", "Password")
+
+	return cpCmd
+}
+
+// newTestConnectionCmd tests the connection to the Postgres database with the configured parameters.
+func newTestConnectionCmd(cfg *cliconfig.Configure, test connectionTester) *cobra.Command {
+	return &cobra.Command{
+		Use:   "test-connection",
+		Short: "Test the connection to the Postgres database.",
+		Long: `Resolves the configured password or secret, connects to the Postgres database
+and runs a trivial query. Failures are reported with their most likely cause
+so that they can be fixed before Postgres monitoring is enabled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := test(cmd.Context(), cfg.Configuration)
+			return testconnection.Report(cmd.Context(), cfg, "Postgres", []testconnection.Result{{Target: "localhost:5432", Err: err}})
+		},
+	}
+}
+
+// testConnection connects to the Postgres database the same way the daemon does.
+func testConnection(ctx context.Context, config *cpb.Configuration) error {
+	gceService, err := endpoints.NewGCEClient(ctx, config.GetEndpoints())
+	if err != nil {
+		return fmt.Errorf("initializing GCE services: %w", err)
+	}
+	m := postgresmetrics.New(ctx, config, nil, nil)
+	if err := m.InitDB(ctx, gceService); err != nil {
+		return err
+	}
+	return m.Ping(ctx)
+}
+
+// newCreateMonitoringUserCmd creates a Postgres role with the privileges needed by the collectors.
+func newCreateMonitoringUserCmd(cfg *cliconfig.Configure, exec statementExecutor) *cobra.Command {
+	var (
+		adminUser, username string
+		storeFile, keyFile  string
+		dryRun              bool
+	)
+	cmd := &cobra.Command{
+		Use:   "create-monitoring-user",
+		Short: "Create a Postgres role with the least privileges needed by the agent.",
+		Long: `Connects to the configured Postgres database as an administrator and creates a
+login role which is a member of pg_monitor, so that the agent does not need a
+superuser. The administrator password is read from the first line of standard
+input and is not stored.
+
+The password of the new role is generated, stored in the local encrypted
+credential store and never shown; the connection parameters are updated to
+use the new role. Running the command again resets the password.
+
+With --dry-run the SQL statements are printed instead, for an administrator to
+review or run manually.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := monitoringuser.ValidateIdentifier("username", username); err != nil {
+				return err
+			}
+			ref, err := monitoringuser.Run(cmd.Context(), cfg, monitoringuser.Request{
+				Workload:       "Postgres",
+				CredentialName: "postgres",
+				Statements: func(password string) []string {
+					return monitoringUserStatements(username, password)
+				},
+				Execute: func(ctx context.Context, adminPassword secret.String, statements []string) error {
+					return exec(ctx, cfg.Configuration, adminUser, adminPassword, statements)
+				},
+				DryRun:    dryRun,
+				Stdin:     cmd.InOrStdin(),
+				StoreFile: storeFile,
+				KeyFile:   keyFile,
+			})
+			if err != nil || ref == nil {
+				return err
+			}
+			cfg.ValidatePostgresConnectionParams()
+			cp := cfg.Configuration.PostgresConfiguration.ConnectionParameters
+			cp.Username = username
+			cp.Password = ""
+			cp.Secret = nil
+			cp.StoredCredential = ref
+			cfg.PostgresConfigModified = true
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&adminUser, "admin-username", "postgres", "Administrator who creates the role")
+	cmd.Flags().StringVar(&username, "username", "workloadagent", "Name of the role to create")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the SQL statements instead of executing them")
+	cmd.Flags().StringVar(&storeFile, "store-file", "", "Credential store file, defaults to the agent configuration directory")
+	cmd.Flags().StringVar(&keyFile, "key-file", "", "File with the credential store key, defaults to a key derived from the instance identity")
+
+	return cmd
+}
+
+// monitoringUserStatements returns the statements which create the role and grant it the
+// privileges read by the collectors. The role is updated if it already exists.
+func monitoringUserStatements(user, password string) []string {
+	role := `"` + user + `"`
+	return []string{
+		fmt.Sprintf("DO $$BEGIN IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = '%s') THEN CREATE ROLE %s; END IF; END$$", user, role),
+		fmt.Sprintf("ALTER ROLE %s WITH LOGIN PASSWORD '%s'", role, password),
+		// pg_monitor reads pg_settings, pg_stat_replication, pg_stat_archiver and the statistics of all tables.
+		"GRANT pg_monitor TO " + role,
+	}
+}
+
+// executeStatements runs the statements on the configured Postgres database as the administrator.
+func executeStatements(ctx context.Context, config *cpb.Configuration, adminUser string, adminPassword secret.String, statements []string) error {
+	cp := config.GetPostgresConfiguration().GetConnectionParameters()
+	host, port := cp.GetHost(), int(cp.GetPort())
+	if host == "" {
+		host = "localhost"
+	}
+	if port == 0 {
+		port = 5432
+	}
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=postgres", quote(host), port, quote(adminUser), quote(adminPassword.SecretValue()))
+	tls := config.GetPostgresConfiguration().GetTls()
+	switch {
+	case tls.GetEnabled() && tls.GetInsecureSkipVerify():
+		dsn += " sslmode=require"
+	case tls.GetEnabled():
+		dsn += " sslmode=verify-full"
+		if tls.GetCaFile() != "" {
+			dsn += " sslrootcert=" + quote(tls.GetCaFile())
+		}
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return fmt.Errorf("opening Postgres connection: %w", err)
+	}
+	defer db.Close()
+	if err := db.PingContext(ctx); err != nil && !tls.GetEnabled() {
+		// Like the collectors, fall back to an unencrypted connection to servers without SSL.
+		if db, err = sql.Open("postgres", dsn+" sslmode=disable"); err != nil {
+			return fmt.Errorf("opening Postgres connection: %w", err)
+		}
+		defer db.Close()
+	}
+	for i, s := range statements {
+		// The statement is not part of the error, it may contain the password.
+		if _, err := db.ExecContext(ctx, s); err != nil {
+			return fmt.Errorf("executing statement %d of %d: %w", i+1, len(statements), err)
+		}
+	}
+	return nil
+}
+
+// quote quotes a connection string value.
+func quote(v string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(v, `\`, `\\`), `'`, `\'`) + "'"
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/secret"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestNewCommand(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           string
+		configToModify *cliconfig.Configure
+		wantErr        string
+		want           *cliconfig.Configure
+	}{
+		{
+			name: "EnablePostgres",
+			args: "--enabled",
+			configToModify: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(false),
+					},
+				},
+			},
+			want: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(true),
+					},
+				},
+				PostgresConfigModified: true,
+				EnabledChanges:         map[string]bool{"postgres": true},
+			},
+		},
+		{
+			name: "WrongFlag",
+			args: "--wrong_flag=true",
+			configToModify: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(true),
+					},
+				},
+				PostgresConfigModified: false,
+			},
+			wantErr: "unknown flag: --wrong_flag",
+			want: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(true),
+					},
+				},
+				PostgresConfigModified: false,
+			},
+		},
+		{
+			name: "MissingPostgresConfiguration",
+			args: "connection-params --username=test-user --password=test-password",
+			configToModify: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{},
+			},
+			want: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						ConnectionParameters: &cpb.ConnectionParameters{
+							Username: "test-user",
+							Password: "test-password",
+						},
+					},
+				},
+				PostgresConfigModified: true,
+			},
+		},
+		{
+			name: "AddNewConnectionParams",
+			args: "connection-params --username=test-user --password=test-password --project-id=test-project --secret-name=test-secret",
+			configToModify: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(true),
+					},
+				},
+				PostgresConfigModified: false,
+			},
+			want: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: &cpb.ConnectionParameters{
+							Username: "test-user",
+							Password: "test-password",
+							Secret: &cpb.SecretRef{
+								ProjectId:  "test-project",
+								SecretName: "test-secret",
+							},
+						},
+					},
+				},
+				PostgresConfigModified: true,
+			},
+		},
+		{
+			name: "UpdateConnectionParams",
+			args: "connection-params --username=new-user --secret-name=new-secret",
+			configToModify: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: &cpb.ConnectionParameters{
+							Username: "old-user",
+							Password: "old-password",
+							Secret: &cpb.SecretRef{
+								ProjectId:  "old-project",
+								SecretName: "old-secret",
+							},
+						},
+					},
+				},
+				PostgresConfigModified: false,
+			},
+			want: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					PostgresConfiguration: &cpb.PostgresConfiguration{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: &cpb.ConnectionParameters{
+							Username: "new-user",
+							Password: "old-password",
+							Secret: &cpb.SecretRef{
+								ProjectId:  "old-project",
+								SecretName: "new-secret",
+							},
+						},
+					},
+				},
+				PostgresConfigModified: true,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			// 'configToModify' is the configuration that will be modified by the command.
+			cmd := NewCommand(tc.configToModify)
+			// Set the args for the command.
+			cmd.SetArgs(strings.Split(tc.args, " "))
+			// Capture stdout to avoid printing during tests.
+			cmd.SetOut(bytes.NewBufferString(""))
+			// Execute the command.
+			err := cmd.Execute()
+			if err != nil && err.Error() != tc.wantErr {
+				t.Errorf("NewCommand().Execute() = %v, want: %v", err, tc.wantErr)
+			}
+
+			// Compare the configurations.
+			if diff := cmp.Diff(tc.want, tc.configToModify, protocmp.Transform(), cmpopts.IgnoreUnexported(cliconfig.Configure{})); diff != "" {
+				t.Errorf("NewCommand().Execute() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMonitoringUserStatements(t *testing.T) {
+	want := []string{
+		`DO $$BEGIN IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = 'agent') THEN CREATE ROLE "agent"; END IF; END$$`,
+		`ALTER ROLE "agent" WITH LOGIN PASSWORD 'secret'`,
+		`GRANT pg_monitor TO "agent"`,
+	}
+	if diff := cmp.Diff(want, monitoringUserStatements("agent", "secret")); diff != "" {
+		t.Errorf("monitoringUserStatements() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestCreateMonitoringUserCmd(t *testing.T) {
+	dir := t.TempDir()
+	storeFile := filepath.Join(dir, "credentialstore.json")
+	keyFile := filepath.Join(dir, "credentialstore.key")
+	cfg := &cliconfig.Configure{
+		Configuration: &cpb.Configuration{
+			PostgresConfiguration: &cpb.PostgresConfiguration{
+				ConnectionParameters: &cpb.ConnectionParameters{Username: "postgres", Password: "postgres-password"},
+			},
+		},
+		JSONOutput: true,
+	}
+	var gotStatements []string
+	exec := func(ctx context.Context, config *cpb.Configuration, adminUser string, adminPassword secret.String, statements []string) error {
+		gotStatements = statements
+		return nil
+	}
+	cmd := newCreateMonitoringUserCmd(cfg, exec)
+	cmd.SetArgs([]string{"--username=monitor", "--store-file=" + storeFile, "--key-file=" + keyFile})
+	cmd.SetIn(strings.NewReader("admin-password\n"))
+	cmd.SetOut(bytes.NewBufferString(""))
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("newCreateMonitoringUserCmd().Execute() returned unexpected error: %v", err)
+	}
+
+	want := &cpb.Configuration{
+		PostgresConfiguration: &cpb.PostgresConfiguration{
+			ConnectionParameters: &cpb.ConnectionParameters{
+				Username:         "monitor",
+				StoredCredential: &cpb.StoredCredentialRef{Name: "postgres", StoreFile: storeFile, KeyFile: keyFile},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, cfg.Configuration, protocmp.Transform()); diff != "" {
+		t.Errorf("newCreateMonitoringUserCmd().Execute() returned unexpected configuration diff (-want +got):\n%s", diff)
+	}
+	if !cfg.PostgresConfigModified {
+		t.Error("newCreateMonitoringUserCmd().Execute() did not mark the configuration as modified")
+	}
+	if len(gotStatements) != 3 || !strings.Contains(gotStatements[2], `"monitor"`) {
+		t.Errorf("newCreateMonitoringUserCmd().Execute() executed %q, want the statements for role monitor", gotStatements)
+	}
+}
@@ -18,15 +18,23 @@ limitations under the License.
 package redis
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/spf13/cobra"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/testconnection"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/redismetrics"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/osinfo"
 
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
 
+// connectionTester tests the connection to the configured Redis database.
+type connectionTester func(ctx context.Context, config *cpb.Configuration) error
+
 // NewCommand creates a new 'redis' command.
 func NewCommand(cfg *cliconfig.Configure) *cobra.Command {
 	var enabled bool
@@ -46,6 +54,7 @@ for monitoring Redis databases, including discovery and metrics collection.`,
 				cfg.LogToBoth(cmd.Context(), msg)
 				cfg.Configuration.RedisConfiguration.Enabled = &enabled
 				cfg.RedisConfigModified = true
+				cfg.RecordEnabledChange("redis", enabled)
 			}
 		},
 	}
@@ -53,6 +62,7 @@ for monitoring Redis databases, including discovery and metrics collection.`,
 	redisCmd.Flags().BoolVar(&enabled, "enabled", false, "Enable Redis configuration")
 
 	redisCmd.AddCommand(newConnectionParamsCmd(cfg))
+	redisCmd.AddCommand(newTestConnectionCmd(cfg, testConnection))
 
 	return redisCmd
 }
@@ -60,15 +70,19 @@ for monitoring Redis databases, including discovery and metrics collection.`,
 // newConnectionParamsCmd adds connection parameters for a Redis database.
 func newConnectionParamsCmd(cfg *cliconfig.Configure) *cobra.Command {
 	var (
-		port                            int
-		projectID, secretName, password string
+		port                               int
+		host, username                     string
+		projectID, secretName, password    string
+		tlsEnabled, tlsInsecureSkipVerify  bool
+		tlsCAFile, tlsCertFile, tlsKeyFile string
 	)
 
 	cpCmd := &cobra.Command{
 		Use:   "connection-params",
 		Short: "Add connection parameters for a Redis database.",
-		Long: `Sets the port, password, and Secret Manager details
+		Long: `Sets the host, port, ACL username, password, Secret Manager and TLS details
 for connecting to the Redis database specified in the main configuration.
+Without a username, the password authenticates the default user (requirepass).
 
 Existing connection parameters will be overwritten by the provided flags.
 
@@ -80,12 +94,24 @@ the password in Google Cloud Secret Manager and using the --project-id and
 			cfg.ValidateRedisConnectionParams()
 			cp := cfg.Configuration.RedisConfiguration.ConnectionParameters
 
+			if cmd.Flags().Changed("host") {
+				msg := fmt.Sprintf("Setting Redis Host: %v", host)
+				cfg.LogToBoth(cmd.Context(), msg)
+				cp.Host = host
+				cfg.RedisConfigModified = true
+			}
 			if cmd.Flags().Changed("port") {
 				msg := fmt.Sprintf("Setting Redis Port: %v", port)
 				cfg.LogToBoth(cmd.Context(), msg)
 				cp.Port = int32(port)
 				cfg.RedisConfigModified = true
 			}
+			if cmd.Flags().Changed("username") {
+				msg := fmt.Sprintf("Setting Redis Username: %v", username)
+				cfg.LogToBoth(cmd.Context(), msg)
+				cp.Username = username
+				cfg.RedisConfigModified = true
+			}
 			if cmd.Flags().Changed("password") {
 				msg := fmt.Sprintf("Setting Redis Password: %v", password)
 				cfg.LogToBoth(cmd.Context(), msg)
@@ -110,14 +136,88 @@ the password in Google Cloud Secret Manager and using the --project-id and
 				cp.Secret.SecretName = secretName
 				cfg.RedisConfigModified = true
 			}
+
+			tlsFlags := []string{"tls", "tls-ca-file", "tls-cert-file", "tls-key-file", "tls-insecure-skip-verify"}
+			for _, f := range tlsFlags {
+				if cmd.Flags().Changed(f) && cfg.Configuration.RedisConfiguration.Tls == nil {
+					cfg.Configuration.RedisConfiguration.Tls = &cpb.TLSConfiguration{}
+				}
+			}
+			tlsCfg := cfg.Configuration.RedisConfiguration.Tls
+			if cmd.Flags().Changed("tls") {
+				msg := fmt.Sprintf("Setting Redis TLS: %v", tlsEnabled)
+				cfg.LogToBoth(cmd.Context(), msg)
+				tlsCfg.Enabled = tlsEnabled
+				cfg.RedisConfigModified = true
+			}
+			if cmd.Flags().Changed("tls-ca-file") {
+				msg := fmt.Sprintf("Setting Redis TLS CA File: %v", tlsCAFile)
+				cfg.LogToBoth(cmd.Context(), msg)
+				tlsCfg.CaFile = tlsCAFile
+				cfg.RedisConfigModified = true
+			}
+			if cmd.Flags().Changed("tls-cert-file") {
+				msg := fmt.Sprintf("Setting Redis TLS Cert File: %v", tlsCertFile)
+				cfg.LogToBoth(cmd.Context(), msg)
+				tlsCfg.CertFile = tlsCertFile
+				cfg.RedisConfigModified = true
+			}
+			if cmd.Flags().Changed("tls-key-file") {
+				msg := fmt.Sprintf("Setting Redis TLS Key File: %v", tlsKeyFile)
+				cfg.LogToBoth(cmd.Context(), msg)
+				tlsCfg.KeyFile = tlsKeyFile
+				cfg.RedisConfigModified = true
+			}
+			if cmd.Flags().Changed("tls-insecure-skip-verify") {
+				msg := fmt.Sprintf("Setting Redis TLS Insecure Skip Verify: %v", tlsInsecureSkipVerify)
+				cfg.LogToBoth(cmd.Context(), msg)
+				tlsCfg.InsecureSkipVerify = tlsInsecureSkipVerify
+				cfg.RedisConfigModified = true
+			}
 		},
 	}
 
 	// Add flags for the connection
+	cpCmd.Flags().StringVar(&host, "host", "", "Host, defaults to localhost")
 	cpCmd.Flags().IntVar(&port, "port", configuration.DefaultRedisPort, "Port")
+	cpCmd.Flags().StringVar(&username, "username", "", "ACL username")
 	cpCmd.Flags().StringVar(&projectID, "project-id", "", "Project ID")
 	cpCmd.Flags().StringVar(&secretName, "secret-name", "", "Secret name")
 	cpCmd.Flags().StringVar(&password, "password", "", "Password")
+	cpCmd.Flags().BoolVar(&tlsEnabled, "tls", false, "Connect over TLS")
+	cpCmd.Flags().StringVar(&tlsCAFile, "tls-ca-file", "", "PEM file with the CA certificates which sign the server certificate")
+	cpCmd.Flags().StringVar(&tlsCertFile, "tls-cert-file", "", "PEM file with the client certificate")
+	cpCmd.Flags().StringVar(&tlsKeyFile, "tls-key-file", "", "PEM file with the client key")
+	cpCmd.Flags().BoolVar(&tlsInsecureSkipVerify, "tls-insecure-skip-verify", false, "Skip verification of the server certificate")
 
 	return cpCmd
 }
+
+// newTestConnectionCmd tests the connection to the Redis database with the configured parameters.
+func newTestConnectionCmd(cfg *cliconfig.Configure, test connectionTester) *cobra.Command {
+	return &cobra.Command{
+		Use:   "test-connection",
+		Short: "Test the connection to the Redis database.",
+		Long: `Resolves the configured password or secret, connects to the Redis database
+and runs a trivial command. Failures are reported with their most likely cause
+so that they can be fixed before Redis monitoring is enabled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target := redismetrics.New(cmd.Context(), cfg.Configuration, nil, osinfo.Data{}).Address()
+			err := test(cmd.Context(), cfg.Configuration)
+			return testconnection.Report(cmd.Context(), cfg, "Redis", []testconnection.Result{{Target: target, Err: err}})
+		},
+	}
+}
+
+// testConnection connects to the Redis database the same way the daemon does.
+func testConnection(ctx context.Context, config *cpb.Configuration) error {
+	gceService, err := endpoints.NewGCEClient(ctx, config.GetEndpoints())
+	if err != nil {
+		return fmt.Errorf("initializing GCE services: %w", err)
+	}
+	r := redismetrics.New(ctx, config, nil, osinfo.Data{})
+	if err := r.InitDB(ctx, gceService); err != nil {
+		return err
+	}
+	return r.Ping(ctx)
+}
@@ -55,6 +55,7 @@ func TestNewCommand(t *testing.T) {
 					},
 				},
 				RedisConfigModified: true,
+				EnabledChanges:      map[string]bool{"redis": true},
 			},
 		},
 		{
@@ -124,6 +125,33 @@ func TestNewCommand(t *testing.T) {
 				RedisConfigModified: true,
 			},
 		},
+		{
+			name: "ACLUserAndTLS",
+			args: "connection-params --host=10.0.0.1 --username=agent --tls --tls-ca-file=/etc/redis/ca.pem",
+			configToModify: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					RedisConfiguration: &cpb.RedisConfiguration{
+						Enabled: proto.Bool(true),
+					},
+				},
+			},
+			want: &cliconfig.Configure{
+				Configuration: &cpb.Configuration{
+					RedisConfiguration: &cpb.RedisConfiguration{
+						Enabled: proto.Bool(true),
+						ConnectionParameters: &cpb.ConnectionParameters{
+							Host:     "10.0.0.1",
+							Username: "agent",
+						},
+						Tls: &cpb.TLSConfiguration{
+							Enabled: true,
+							CaFile:  "/etc/redis/ca.pem",
+						},
+					},
+				},
+				RedisConfigModified: true,
+			},
+		},
 		{
 			name: "UpdateConnectionParams",
 			args: "connection-params --password=new-password --project-id=new-project",
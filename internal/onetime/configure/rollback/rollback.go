@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rollback implements the configure rollback subcommand.
+package rollback
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+)
+
+// NewCommand creates a new 'rollback' command.
+func NewCommand(cfg *cliconfig.Configure) *cobra.Command {
+	return newRollbackCmd(cfg, os.ReadFile)
+}
+
+func newRollbackCmd(cfg *cliconfig.Configure, read configuration.ReadConfigFile) *cobra.Command {
+	var list bool
+	var backup string
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Restore a previous configuration",
+		Long: `Restores the configuration file from a backup.
+
+A backup of configuration.json is saved every time a configure command
+changes it. By default the most recent backup is restored; use --list to
+see the available backups and --backup to restore a specific one.
+
+The configuration being replaced is itself backed up, so a rollback can
+be undone by running rollback again.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			backups, err := configuration.Backups(cfg.Path)
+			if err != nil {
+				return err
+			}
+			if list {
+				if len(backups) == 0 {
+					cfg.LogToBoth(cmd.Context(), "No configuration backups found.")
+				}
+				for _, b := range backups {
+					cfg.LogToBoth(cmd.Context(), b)
+				}
+				return nil
+			}
+
+			if backup == "" {
+				if len(backups) == 0 {
+					return fmt.Errorf("no backups of %s found", cfg.Path)
+				}
+				backup = backups[len(backups)-1]
+			} else if !contains(backups, backup) {
+				return fmt.Errorf("%s is not a backup of %s, use --list to see the available backups", backup, cfg.Path)
+			}
+
+			restored, err := configuration.ConfigFromFile(backup, read)
+			if err != nil {
+				return fmt.Errorf("failed to load backup: %w", err)
+			}
+			cfg.Configuration = restored
+			cfg.ConfigRestored = true
+			cfg.LogToBoth(cmd.Context(), fmt.Sprintf("Restoring configuration from %s", backup))
+			return nil
+		},
+	}
+	rollbackCmd.Flags().BoolVar(&list, "list", false, "List the available configuration backups, oldest first")
+	rollbackCmd.Flags().StringVar(&backup, "backup", "", "Path of the backup to restore, defaults to the most recent backup")
+	return rollbackCmd
+}
+
+// contains reports whether backup refers to one of the backups.
+func contains(backups []string, backup string) bool {
+	abs, err := filepath.Abs(backup)
+	if err != nil {
+		return false
+	}
+	for _, b := range backups {
+		if bAbs, err := filepath.Abs(b); err == nil && bAbs == abs {
+			return true
+		}
+	}
+	return false
+}
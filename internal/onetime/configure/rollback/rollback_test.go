@@ -0,0 +1,106 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollback
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestRollback(t *testing.T) {
+	dir := t.TempDir()
+	configPath := path.Join(dir, "configuration.json")
+	older := configuration.BackupPath(configPath, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	newer := configuration.BackupPath(configPath, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	for p, content := range map[string]string{
+		older: `{"log_level":"DEBUG"}`,
+		newer: `{"log_level":"ERROR"}`,
+	} {
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", p, err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    *cpb.Configuration
+		wantErr bool
+	}{
+		{
+			name: "MostRecentBackup",
+			want: &cpb.Configuration{LogLevel: cpb.Configuration_ERROR},
+		},
+		{
+			name: "SpecificBackup",
+			args: []string{"--backup", older},
+			want: &cpb.Configuration{LogLevel: cpb.Configuration_DEBUG},
+		},
+		{
+			name:    "NotABackup",
+			args:    []string{"--backup", configPath},
+			wantErr: true,
+		},
+		{
+			name: "List",
+			args: []string{"--list"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &cliconfig.Configure{Path: configPath}
+			cmd := newRollbackCmd(cfg, os.ReadFile)
+			cmd.SetOut(new(bytes.Buffer))
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tc.args)
+
+			err := cmd.Execute()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Execute(%v) returned error %v, want error: %v", tc.args, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, cfg.Configuration, protocmp.Transform()); diff != "" {
+				t.Errorf("Execute(%v) restored unexpected configuration (-want +got):\n%s", tc.args, diff)
+			}
+			if wantRestored := tc.want != nil; cfg.ConfigRestored != wantRestored {
+				t.Errorf("Execute(%v) set ConfigRestored to %v, want %v", tc.args, cfg.ConfigRestored, wantRestored)
+			}
+		})
+	}
+}
+
+func TestRollbackWithoutBackups(t *testing.T) {
+	cfg := &cliconfig.Configure{Path: path.Join(t.TempDir(), "configuration.json")}
+	cmd := newRollbackCmd(cfg, os.ReadFile)
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err == nil {
+		t.Error("Execute() returned nil error without any backups, want error")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package show implements the configure show subcommand.
+package show
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// workloads maps the values accepted by --workload to the part of the configuration they select.
+var workloads = map[string]func(*cpb.Configuration) proto.Message{
+	"mongodb":   func(c *cpb.Configuration) proto.Message { return c.GetMongoDbConfiguration() },
+	"mysql":     func(c *cpb.Configuration) proto.Message { return c.GetMysqlConfiguration() },
+	"openshift": func(c *cpb.Configuration) proto.Message { return c.GetOpenshiftConfiguration() },
+	"oracle":    func(c *cpb.Configuration) proto.Message { return c.GetOracleConfiguration() },
+	"postgres":  func(c *cpb.Configuration) proto.Message { return c.GetPostgresConfiguration() },
+	"redis":     func(c *cpb.Configuration) proto.Message { return c.GetRedisConfiguration() },
+	"sqlserver": func(c *cpb.Configuration) proto.Message { return c.GetSqlserverConfiguration() },
+}
+
+// NewCommand creates a new 'show' command.
+func NewCommand(cfg *cliconfig.Configure) *cobra.Command {
+	return newShowCmd(cfg, os.ReadFile)
+}
+
+func newShowCmd(cfg *cliconfig.Configure, read configuration.ReadConfigFile) *cobra.Command {
+	var workload string
+	showCmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the effective configuration",
+		Long: `Prints the configuration the agent will run with: the values from the
+configuration file merged with the agent defaults.
+
+Passwords are redacted. Use --workload to print only the configuration
+of a single workload.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg.ReadOnly = true
+			effective, err := configuration.Load(cfg.Path, read, nil)
+			if err != nil {
+				return fmt.Errorf("failed to load configuration: %w", err)
+			}
+			effective = configuration.Redact(effective)
+
+			var msg proto.Message = effective
+			if workload != "" {
+				selectWorkload, ok := workloads[strings.ToLower(workload)]
+				if !ok {
+					return fmt.Errorf("unknown workload %q, must be one of: %s", workload, strings.Join(workloadNames(), ", "))
+				}
+				msg = selectWorkload(effective)
+			}
+
+			content, err := protojson.MarshalOptions{
+				Multiline:     true,
+				Indent:        "  ",
+				UseProtoNames: true,
+			}.Marshal(msg)
+			if err != nil {
+				return fmt.Errorf("failed to marshal configuration: %w", err)
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(content))
+			return nil
+		},
+	}
+	showCmd.Flags().StringVar(&workload, "workload", "", fmt.Sprintf("Only show the configuration of this workload (%s)", strings.Join(workloadNames(), ", ")))
+	return showCmd
+}
+
+func workloadNames() []string {
+	names := make([]string, 0, len(workloads))
+	for name := range workloads {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package show
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const testConfig = `{
+  "log_level": "DEBUG",
+  "mysql_configuration": {
+    "enabled": true,
+    "connection_parameters": {
+      "username": "root",
+      "password": "secret-password"
+    }
+  }
+}`
+
+func readTestConfig(string) ([]byte, error) {
+	return []byte(testConfig), nil
+}
+
+func TestShowWorkload(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    proto.Message
+		wantErr bool
+	}{
+		{
+			name: "MySQL",
+			args: []string{"--workload=mysql"},
+			want: &cpb.MySQLConfiguration{
+				Enabled: proto.Bool(true),
+				ConnectionParameters: &cpb.ConnectionParameters{
+					Username: "root",
+					Password: configuration.RedactedValue,
+				},
+			},
+		},
+		{
+			name: "WorkloadIsCaseInsensitive",
+			args: []string{"--workload=MySQL"},
+			want: &cpb.MySQLConfiguration{
+				Enabled: proto.Bool(true),
+				ConnectionParameters: &cpb.ConnectionParameters{
+					Username: "root",
+					Password: configuration.RedactedValue,
+				},
+			},
+		},
+		{
+			name:    "UnknownWorkload",
+			args:    []string{"--workload=db2"},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &cliconfig.Configure{Path: "configuration.json"}
+			cmd := newShowCmd(cfg, readTestConfig)
+			out := new(bytes.Buffer)
+			cmd.SetOut(out)
+			cmd.SetErr(new(bytes.Buffer))
+			cmd.SetArgs(tc.args)
+
+			err := cmd.Execute()
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Execute(%v) returned error %v, want error: %v", tc.args, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			got := proto.Clone(tc.want)
+			proto.Reset(got)
+			if err := protojson.Unmarshal(out.Bytes(), got); err != nil {
+				t.Fatalf("protojson.Unmarshal(%s) returned unexpected error: %v", out.String(), err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("Execute(%v) printed unexpected configuration (-want +got):\n%s", tc.args, diff)
+			}
+			if !cfg.ReadOnly {
+				t.Errorf("Execute(%v) did not mark the configure command as read only", tc.args)
+			}
+		})
+	}
+}
+
+func TestShowAppliesDefaults(t *testing.T) {
+	cfg := &cliconfig.Configure{Path: "configuration.json"}
+	cmd := newShowCmd(cfg, readTestConfig)
+	out := new(bytes.Buffer)
+	cmd.SetOut(out)
+	cmd.SetArgs([]string{})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("Execute() returned unexpected error: %v", err)
+	}
+	got := &cpb.Configuration{}
+	if err := protojson.Unmarshal(out.Bytes(), got); err != nil {
+		t.Fatalf("protojson.Unmarshal(%s) returned unexpected error: %v", out.String(), err)
+	}
+	if got.GetLogLevel() != cpb.Configuration_DEBUG {
+		t.Errorf("Execute() printed log_level %v, want the file value %v", got.GetLogLevel(), cpb.Configuration_DEBUG)
+	}
+	if got.GetDataWarehouseEndpoint() == "" {
+		t.Error("Execute() printed an empty data_warehouse_endpoint, want the default endpoint")
+	}
+	if strings.Contains(out.String(), "secret-password") {
+		t.Errorf("Execute() printed the password in clear text:\n%s", out.String())
+	}
+}
@@ -52,6 +52,7 @@ This command allows you to enable and configure various features for monitoring
 				cfg.LogToBoth(cmd.Context(), msg)
 				cfg.Configuration.SqlserverConfiguration.Enabled = &enabled
 				cfg.SQLServerConfigModified = true
+				cfg.RecordEnabledChange("sqlserver", enabled)
 			}
 			if cmd.Flags().Changed("collection-timeout") {
 				msg := fmt.Sprintf("SQL Server Collection Timeout: %v", collectionTimeout)
@@ -88,5 +89,6 @@ This command allows you to enable and configure various features for monitoring
 	sqlserverCmd.Flags().BoolVar(&remoteCollection, "remote-collection", false, "Enable remote collection")
 
 	sqlserverCmd.AddCommand(CollectionConfigCommand(cfg))
+	sqlserverCmd.AddCommand(TestConnectionCommand(cfg))
 	return sqlserverCmd
 }
@@ -69,6 +69,7 @@ func TestNewCommand(t *testing.T) {
 					},
 				},
 				SQLServerConfigModified: true,
+				EnabledChanges:          map[string]bool{"sqlserver": true},
 			},
 		},
 	}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlserver
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/testconnection"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// connectionChecker tests the connections to the configured SQL Server instances.
+// The returned map is keyed by host and port.
+type connectionChecker func(ctx context.Context, config *cpb.SQLServerConfiguration) map[string]error
+
+// TestConnectionCommand creates a new 'test-connection' command for SQL Server.
+func TestConnectionCommand(cfg *cliconfig.Configure) *cobra.Command {
+	return newTestConnectionCmd(cfg, func(ctx context.Context, config *cpb.SQLServerConfiguration) map[string]error {
+		return sqlservermetrics.CheckConnections(ctx, config, cfg.Configuration.GetEndpoints())
+	})
+}
+
+func newTestConnectionCmd(cfg *cliconfig.Configure, check connectionChecker) *cobra.Command {
+	return &cobra.Command{
+		Use:   "test-connection",
+		Short: "Test the connections to the SQL Server instances.",
+		Long: `Resolves the secrets of the configured SQL Server credentials, connects to
+each instance and runs a trivial query. Failures are reported with their most
+likely cause so that they can be fixed before SQL Server monitoring is enabled.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			errs := check(cmd.Context(), cfg.Configuration.GetSqlserverConfiguration())
+			return testconnection.Report(cmd.Context(), cfg, "SQL Server", testconnection.FromMap(errs))
+		},
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package testconnection implements the shared parts of the per-workload test-connection subcommands.
+package testconnection
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+)
+
+// Cause is the category of a failed connection test.
+type Cause string
+
+const (
+	// CauseSecret means the password could not be read from Secret Manager.
+	CauseSecret Cause = "SECRET"
+	// CauseDNS means the database host name could not be resolved.
+	CauseDNS Cause = "DNS"
+	// CauseNetwork means the database could not be reached.
+	CauseNetwork Cause = "NETWORK"
+	// CauseTimeout means the database did not respond in time.
+	CauseTimeout Cause = "TIMEOUT"
+	// CauseTLS means the TLS handshake with the database failed.
+	CauseTLS Cause = "TLS"
+	// CauseAuthentication means the database rejected the credentials.
+	CauseAuthentication Cause = "AUTHENTICATION"
+	// CausePermission means the user is missing privileges required by the agent.
+	CausePermission Cause = "PERMISSION"
+	// CauseUnknown means the failure could not be classified.
+	CauseUnknown Cause = "UNKNOWN"
+)
+
+// hints are shown to the user alongside a failed connection test.
+var hints = map[Cause]string{
+	CauseSecret:         "verify the secret name and project ID and that the service account has the Secret Manager Secret Accessor role",
+	CauseDNS:            "verify that the configured host name resolves from this machine",
+	CauseNetwork:        "verify that the database is running and listening on the configured host and port",
+	CauseTimeout:        "verify that no firewall is dropping traffic to the configured host and port",
+	CauseTLS:            "verify the TLS configuration and certificates of the database server",
+	CauseAuthentication: "verify the configured username and password",
+	CausePermission:     "grant the configured user the privileges required by the agent",
+	CauseUnknown:        "check the agent logs for details",
+}
+
+// Driver specific error fragments, matched case-insensitively.
+var (
+	secretPatterns = []string{
+		"failed to get secret",
+		"secret manager",
+	}
+	authenticationPatterns = []string{
+		"error 1045",                     // MySQL: access denied for user (using password).
+		"password authentication failed", // Postgres: 28P01.
+		"no pg_hba.conf entry",           // Postgres: 28000.
+		"wrongpass",                      // Redis: invalid username-password pair.
+		"noauth",                         // Redis: authentication required.
+		"ora-01017",                      // Oracle: invalid username/password.
+		"ora-28000",                      // Oracle: account is locked.
+		"login failed for user",          // SQL Server: 18456.
+	}
+	permissionPatterns = []string{
+		"error 1044", // MySQL: access denied to database.
+		"error 1142", // MySQL: command denied to user.
+		"error 1227", // MySQL: access denied, missing privilege.
+		"permission denied",
+		"noperm",                // Redis ACL.
+		"ora-01031",             // Oracle: insufficient privileges.
+		"ora-01045",             // Oracle: lacks CREATE SESSION privilege.
+		"permission was denied", // SQL Server: 229.
+	}
+	tlsPatterns = []string{
+		"tls:",
+		"x509:",
+		"ssl is not enabled",
+	}
+	dnsPatterns = []string{
+		"no such host",
+		"ora-12154", // Oracle: could not resolve the connect identifier.
+	}
+	networkPatterns = []string{
+		"connection refused",
+		"no route to host",
+		"network is unreachable",
+		"ora-12541", // Oracle: no listener.
+		"ora-12514", // Oracle: listener does not know of the service.
+	}
+)
+
+// Result holds the outcome of testing the connection to a single database.
+type Result struct {
+	Target string
+	Err    error
+}
+
+// Diagnose returns the most likely cause of a failed connection test.
+func Diagnose(err error) Cause {
+	if err == nil {
+		return ""
+	}
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, secretPatterns):
+		return CauseSecret
+	case containsAny(msg, authenticationPatterns):
+		return CauseAuthentication
+	case containsAny(msg, permissionPatterns):
+		return CausePermission
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) || containsAny(msg, dnsPatterns) {
+		return CauseDNS
+	}
+	var recordErr tls.RecordHeaderError
+	var unknownAuthErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certErr x509.CertificateInvalidError
+	if errors.As(err, &recordErr) || errors.As(err, &unknownAuthErr) || errors.As(err, &hostnameErr) || errors.As(err, &certErr) || containsAny(msg, tlsPatterns) {
+		return CauseTLS
+	}
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return CauseTimeout
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || containsAny(msg, networkPatterns) {
+		return CauseNetwork
+	}
+	return CauseUnknown
+}
+
+// Report logs the outcome of each connection test and returns an error if any test failed.
+func Report(ctx context.Context, cfg *cliconfig.Configure, workload string, results []Result) error {
+	if len(results) == 0 {
+		cfg.LogToBoth(ctx, fmt.Sprintf("No %s connections are configured.", workload))
+		return fmt.Errorf("no %s connections are configured", workload)
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Target < results[j].Target })
+	failed := 0
+	for _, r := range results {
+		if r.Err == nil {
+			cfg.LogToBoth(ctx, fmt.Sprintf("%s connection to %s succeeded.", workload, r.Target))
+			continue
+		}
+		failed++
+		cause := Diagnose(r.Err)
+		cfg.LogToBoth(ctx, fmt.Sprintf("%s connection to %s failed (%s): %v", workload, r.Target, cause, r.Err))
+		cfg.LogToBoth(ctx, fmt.Sprintf("Hint: %s.", hints[cause]))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d %s connection tests failed", failed, len(results), workload)
+	}
+	return nil
+}
+
+// FromMap converts per-target errors into results.
+func FromMap(errs map[string]error) []Result {
+	results := make([]Result, 0, len(errs))
+	for target, err := range errs {
+		results = append(results, Result{Target: target, Err: err})
+	}
+	return results
+}
+
+func containsAny(s string, patterns []string) bool {
+	for _, p := range patterns {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+	return false
+}
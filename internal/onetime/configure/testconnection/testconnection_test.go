@@ -0,0 +1,167 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testconnection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Cause
+	}{
+		{
+			name: "NoError",
+			err:  nil,
+			want: "",
+		},
+		{
+			name: "Secret",
+			err:  errors.New("getting dbDSN: initializing password: failed to get secret: not found"),
+			want: CauseSecret,
+		},
+		{
+			name: "MySQLAuthentication",
+			err:  errors.New("Error 1045 (28000): Access denied for user 'root'@'localhost' (using password: YES)"),
+			want: CauseAuthentication,
+		},
+		{
+			name: "PostgresAuthentication",
+			err:  errors.New(`pq: password authentication failed for user "postgres"`),
+			want: CauseAuthentication,
+		},
+		{
+			name: "RedisAuthentication",
+			err:  errors.New("WRONGPASS invalid username-password pair or user is disabled."),
+			want: CauseAuthentication,
+		},
+		{
+			name: "OracleAuthentication",
+			err:  errors.New("ORA-01017: invalid username/password; logon denied"),
+			want: CauseAuthentication,
+		},
+		{
+			name: "SQLServerAuthentication",
+			err:  errors.New("mssql: login error: Login failed for user 'sa'."),
+			want: CauseAuthentication,
+		},
+		{
+			name: "MySQLPermission",
+			err:  errors.New("Error 1142 (42000): SELECT command denied to user 'agent'@'localhost' for table 'user'"),
+			want: CausePermission,
+		},
+		{
+			name: "PostgresPermission",
+			err:  errors.New("pq: permission denied for table pg_authid"),
+			want: CausePermission,
+		},
+		{
+			name: "DNS",
+			err:  fmt.Errorf("dial tcp: %w", &net.DNSError{Err: "no such host", Name: "db.example.com"}),
+			want: CauseDNS,
+		},
+		{
+			name: "TLS",
+			err:  errors.New("tls: failed to verify certificate: x509: certificate signed by unknown authority"),
+			want: CauseTLS,
+		},
+		{
+			name: "Timeout",
+			err:  fmt.Errorf("running test query: %w", context.DeadlineExceeded),
+			want: CauseTimeout,
+		},
+		{
+			name: "ConnectionRefused",
+			err:  &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			want: CauseNetwork,
+		},
+		{
+			name: "OracleNoListener",
+			err:  errors.New("ORA-12541: TNS:no listener"),
+			want: CauseNetwork,
+		},
+		{
+			name: "Unknown",
+			err:  errors.New("something unexpected"),
+			want: CauseUnknown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Diagnose(tc.err); got != tc.want {
+				t.Errorf("Diagnose(%v) = %q, want %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	tests := []struct {
+		name         string
+		results      []Result
+		wantErr      bool
+		wantMessages []string
+	}{
+		{
+			name:         "NoConnections",
+			wantErr:      true,
+			wantMessages: []string{"No MySQL connections are configured."},
+		},
+		{
+			name:         "Success",
+			results:      []Result{{Target: "localhost:3306"}},
+			wantMessages: []string{"MySQL connection to localhost:3306 succeeded."},
+		},
+		{
+			name: "PartialFailure",
+			results: []Result{
+				{Target: "b:3306", Err: errors.New("Error 1045 (28000): Access denied")},
+				{Target: "a:3306"},
+			},
+			wantErr: true,
+			wantMessages: []string{
+				"MySQL connection to a:3306 succeeded.",
+				"MySQL connection to b:3306 failed (AUTHENTICATION): Error 1045 (28000): Access denied",
+				"Hint: verify the configured username and password.",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &cliconfig.Configure{JSONOutput: true}
+			err := Report(context.Background(), cfg, "MySQL", tc.results)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Report() = %v, want error: %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.wantMessages, cfg.Result().Messages); diff != "" {
+				t.Errorf("Report() logged unexpected messages (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
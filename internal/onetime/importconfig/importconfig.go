@@ -0,0 +1,180 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package importconfig implements the one time execution mode which translates the configuration
+// of a legacy Oracle or SQL Server agent into the workload agent configuration.
+package importconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/migration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// Supported legacy agents.
+const (
+	SourceOracle    = "oracle"
+	SourceSQLServer = "sqlserver"
+)
+
+// ImportConfig has args for the import-config subcommand.
+type ImportConfig struct {
+	source, file, configPath string
+	overwrite, dryRun        bool
+	lp                       log.Parameters
+
+	readFile  func(string) ([]byte, error)
+	writeFile cliconfig.WriteConfigFile
+}
+
+// NewCommand creates a new import-config command.
+func NewCommand(lp log.Parameters) *cobra.Command {
+	i := &ImportConfig{
+		lp:       lp,
+		readFile: os.ReadFile,
+	}
+	cmd := &cobra.Command{
+		Use:   "import-config",
+		Short: "Import the configuration of a legacy Oracle or SQL Server agent",
+		Long: `Reads the configuration file of a legacy Oracle or SQL Server agent and translates it
+into the workload agent configuration.
+
+The existing configuration is backed up before it is written. The configuration of other
+workloads is kept. Use --overwrite to replace a workload configuration which is already set.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return i.importConfigHandler(cmd)
+		},
+	}
+	cmd.Flags().StringVar(&i.source, "source", "", "Legacy agent the configuration is imported from: oracle or sqlserver")
+	cmd.Flags().StringVar(&i.file, "file", "", "Path of the legacy agent configuration file")
+	cmd.Flags().StringVar(&i.configPath, "config", configuration.ConfigPath(), "Configuration path")
+	cmd.Flags().BoolVar(&i.overwrite, "overwrite", false, "Replace the workload configuration if it is already set")
+	cmd.Flags().BoolVar(&i.dryRun, "dry-run", false, "Print the resulting configuration without writing it")
+	cmd.MarkFlagRequired("source")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func (i *ImportConfig) importConfigHandler(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	format, err := onetime.OutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	onetime.SetValues(configuration.AgentName, &i.lp, cmd, "import-config")
+	log.SetupLoggingForOTE("google-cloud-workload-agent", cmd.Name(), i.lp)
+
+	cfg := cliconfig.NewConfigure(i.configPath, i.lp, nil, i.writeFile)
+	cfg.JSONOutput = format == onetime.FormatJSON
+	if err := i.importConfig(ctx, cfg); err != nil {
+		return err
+	}
+	if cfg.JSONOutput {
+		return onetime.PrintJSON(cmd.OutOrStdout(), cfg.Result())
+	}
+	return nil
+}
+
+// importConfig merges the legacy configuration into the configuration at cfg.Path and writes it.
+func (i *ImportConfig) importConfig(ctx context.Context, cfg *cliconfig.Configure) error {
+	if i.source != SourceOracle && i.source != SourceSQLServer {
+		return fmt.Errorf("invalid --source %q, must be %s or %s", i.source, SourceOracle, SourceSQLServer)
+	}
+	config, err := i.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	switch i.source {
+	case SourceOracle:
+		if config.GetOracleConfiguration() != nil && !i.overwrite {
+			return fmt.Errorf("%s already contains an Oracle configuration, use --overwrite to replace it", i.configPath)
+		}
+		oracleCfg, err := i.oracleConfiguration()
+		if err != nil {
+			return err
+		}
+		config.OracleConfiguration = oracleCfg
+		cfg.OracleConfigModified = true
+	case SourceSQLServer:
+		if config.GetSqlserverConfiguration() != nil && !i.overwrite {
+			return fmt.Errorf("%s already contains a SQL Server configuration, use --overwrite to replace it", i.configPath)
+		}
+		if err := migration.ImportSQLServerConfiguration(i.file, config); err != nil {
+			return fmt.Errorf("importing SQL Server agent configuration %s: %w", i.file, err)
+		}
+		cfg.SQLServerConfigModified = true
+	}
+	cfg.Configuration = config
+	cfg.LogToBoth(ctx, fmt.Sprintf("Imported the %s agent configuration from %s.", i.source, i.file))
+
+	if i.dryRun {
+		content, err := protojson.MarshalOptions{Multiline: true, UseProtoNames: true}.Marshal(config)
+		if err != nil {
+			return fmt.Errorf("marshalling configuration: %w", err)
+		}
+		cfg.LogToBoth(ctx, string(content))
+		return nil
+	}
+	return cfg.WriteFile(ctx)
+}
+
+// loadConfig reads the configuration at configPath, or returns an empty configuration if the file
+// does not exist yet.
+func (i *ImportConfig) loadConfig() (*cpb.Configuration, error) {
+	content, err := i.readFile(i.configPath)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && len(content) == 0) {
+		return &cpb.Configuration{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading configuration %s: %w", i.configPath, err)
+	}
+	return configuration.ConfigFromFile(i.configPath, func(string) ([]byte, error) { return content, nil })
+}
+
+// oracleConfiguration reads the oracle_configuration of the legacy Oracle agent configuration.
+// Settings of the legacy agent which have no equivalent in the workload agent are ignored.
+func (i *ImportConfig) oracleConfiguration() (*cpb.OracleConfiguration, error) {
+	content, err := i.readFile(i.file)
+	if err != nil {
+		return nil, fmt.Errorf("reading Oracle agent configuration %s: %w", i.file, err)
+	}
+	legacy := &cpb.Configuration{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(content, legacy); err != nil {
+		return nil, fmt.Errorf("parsing Oracle agent configuration %s: %w", i.file, err)
+	}
+	oracleCfg := legacy.GetOracleConfiguration()
+	if oracleCfg == nil {
+		return nil, fmt.Errorf("%s does not contain an oracle_configuration", i.file)
+	}
+	if oracleCfg.Enabled == nil {
+		oracleCfg.Enabled = proto.Bool(true)
+	}
+	return oracleCfg, nil
+}
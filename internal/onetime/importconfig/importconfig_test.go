@@ -0,0 +1,248 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package importconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/configure/cliconfig"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	legacyOracleConfig = `{
+		"log_level": "DEBUG",
+		"cloud_properties": {"project_id": "legacy-project"},
+		"legacy_setting": true,
+		"oracle_configuration": {
+			"oracle_discovery": {"enabled": true},
+			"oracle_metrics": {
+				"enabled": true,
+				"collection_frequency": "60s",
+				"connection_parameters": [
+					{"username": "oracle_user", "service_name": "orcl", "secret": {"project_id": "test-project", "secret_name": "oracle-secret"}}
+				]
+			}
+		}
+	}`
+	legacySQLServerConfig = `{
+		"collection_configuration": {"collect_sql_metrics": true, "sql_metrics_collection_interval_in_seconds": 3600},
+		"collection_timeout_seconds": 10,
+		"retry_interval_in_seconds": 3600,
+		"credential_configuration": [
+			{"sql_configurations": [{"host": "sql_host", "user_name": "sql_user", "port_number": 1433}], "local_collection": true}
+		]
+	}`
+)
+
+var importedOracleConfiguration = &cpb.OracleConfiguration{
+	Enabled:         proto.Bool(true),
+	OracleDiscovery: &cpb.OracleDiscovery{Enabled: proto.Bool(true)},
+	OracleMetrics: &cpb.OracleMetrics{
+		Enabled:             proto.Bool(true),
+		CollectionFrequency: &durationpb.Duration{Seconds: 60},
+		ConnectionParameters: []*cpb.ConnectionParameters{
+			{Username: "oracle_user", ServiceName: "orcl", Secret: &cpb.SecretRef{ProjectId: "test-project", SecretName: "oracle-secret"}},
+		},
+	},
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportConfigOracle(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     *cpb.Configuration
+	}{
+		{
+			name: "NoExistingConfiguration",
+			want: &cpb.Configuration{
+				OracleConfiguration: importedOracleConfiguration,
+			},
+		},
+		{
+			name:     "KeepsOtherWorkloads",
+			existing: `{"log_level": "WARNING", "redis_configuration": {"enabled": true}}`,
+			want: &cpb.Configuration{
+				LogLevel:            cpb.Configuration_WARNING,
+				RedisConfiguration:  &cpb.RedisConfiguration{Enabled: proto.Bool(true)},
+				OracleConfiguration: importedOracleConfiguration,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "configuration.json")
+			if tc.existing != "" {
+				configPath = writeFile(t, "configuration.json", tc.existing)
+			}
+			i := &ImportConfig{
+				source:     SourceOracle,
+				file:       writeFile(t, "oracle.json", legacyOracleConfig),
+				configPath: configPath,
+				readFile:   os.ReadFile,
+			}
+			cfg := cliconfig.NewConfigure(configPath, log.Parameters{}, nil, nil)
+			if err := i.importConfig(context.Background(), cfg); err != nil {
+				t.Fatalf("importConfig() returned unexpected error: %v", err)
+			}
+			if !cfg.OracleConfigModified {
+				t.Error("importConfig() did not mark the Oracle configuration as modified")
+			}
+			got, err := configuration.ConfigFromFile(configPath, os.ReadFile)
+			if err != nil {
+				t.Fatalf("ConfigFromFile(%s) returned unexpected error: %v", configPath, err)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), protocmp.IgnoreFields(&cpb.Configuration{}, "schema_version")); diff != "" {
+				t.Errorf("importConfig() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestImportConfigSQLServer(t *testing.T) {
+	configPath := writeFile(t, "configuration.json", `{"mysql_configuration": {"enabled": true}}`)
+	i := &ImportConfig{
+		source:     SourceSQLServer,
+		file:       writeFile(t, "sqlserver.json", legacySQLServerConfig),
+		configPath: configPath,
+		readFile:   os.ReadFile,
+	}
+	cfg := cliconfig.NewConfigure(configPath, log.Parameters{}, nil, nil)
+	if err := i.importConfig(context.Background(), cfg); err != nil {
+		t.Fatalf("importConfig() returned unexpected error: %v", err)
+	}
+	got, err := configuration.ConfigFromFile(configPath, os.ReadFile)
+	if err != nil {
+		t.Fatalf("ConfigFromFile(%s) returned unexpected error: %v", configPath, err)
+	}
+	if !got.GetMysqlConfiguration().GetEnabled() {
+		t.Error("importConfig() dropped the existing MySQL configuration")
+	}
+	creds := got.GetSqlserverConfiguration().GetCredentialConfigurations()
+	if len(creds) != 1 || creds[0].GetConnectionParameters()[0].GetHost() != "sql_host" {
+		t.Errorf("importConfig() imported credential configurations %v, want one for sql_host", creds)
+	}
+}
+
+func TestImportConfigErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		source    string
+		legacy    string
+		existing  string
+		overwrite bool
+		wantErr   bool
+	}{
+		{
+			name:    "InvalidSource",
+			source:  "db2",
+			legacy:  legacyOracleConfig,
+			wantErr: true,
+		},
+		{
+			name:    "MissingOracleConfiguration",
+			source:  SourceOracle,
+			legacy:  `{"log_level": "DEBUG"}`,
+			wantErr: true,
+		},
+		{
+			name:    "MalformedLegacyConfiguration",
+			source:  SourceOracle,
+			legacy:  `{"oracle_configuration":`,
+			wantErr: true,
+		},
+		{
+			name:     "ExistingOracleConfiguration",
+			source:   SourceOracle,
+			legacy:   legacyOracleConfig,
+			existing: `{"oracle_configuration": {"enabled": false}}`,
+			wantErr:  true,
+		},
+		{
+			name:      "ExistingOracleConfigurationOverwrite",
+			source:    SourceOracle,
+			legacy:    legacyOracleConfig,
+			existing:  `{"oracle_configuration": {"enabled": false}}`,
+			overwrite: true,
+		},
+		{
+			name:     "ExistingSQLServerConfiguration",
+			source:   SourceSQLServer,
+			legacy:   legacySQLServerConfig,
+			existing: `{"sqlserver_configuration": {"enabled": true}}`,
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "configuration.json")
+			if tc.existing != "" {
+				configPath = writeFile(t, "configuration.json", tc.existing)
+			}
+			i := &ImportConfig{
+				source:     tc.source,
+				file:       writeFile(t, "legacy.json", tc.legacy),
+				configPath: configPath,
+				overwrite:  tc.overwrite,
+				readFile:   os.ReadFile,
+			}
+			cfg := cliconfig.NewConfigure(configPath, log.Parameters{}, nil, nil)
+			err := i.importConfig(context.Background(), cfg)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("importConfig() returned error %v, want error: %t", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportConfigDryRun(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "configuration.json")
+	i := &ImportConfig{
+		source:     SourceOracle,
+		file:       writeFile(t, "oracle.json", legacyOracleConfig),
+		configPath: configPath,
+		dryRun:     true,
+		readFile:   os.ReadFile,
+	}
+	cfg := cliconfig.NewConfigure(configPath, log.Parameters{}, nil, nil)
+	cfg.JSONOutput = true
+	if err := i.importConfig(context.Background(), cfg); err != nil {
+		t.Fatalf("importConfig() returned unexpected error: %v", err)
+	}
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		t.Errorf("importConfig() with --dry-run wrote %s, want no file", configPath)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
@@ -70,7 +71,7 @@ func configureUsageMetricsForOTE(cp *cpb.CloudProperties, name, version, image s
 	usagemetrics.SetAgentProperties(&cpb.AgentProperties{
 		Name:            name,
 		Version:         version,
-		LogUsageMetrics: true,
+		LogUsageMetrics: proto.Bool(true),
 	})
 	// Override the imageURL with value passed in args.
 	if image != "" && cp != nil {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
@@ -30,7 +31,7 @@ import (
 func TestLogUsageHandler(t *testing.T) {
 	// Prevent requests to the compute endpoint during test execution
 	usagemetrics.SetAgentProperties(&cpb.AgentProperties{
-		LogUsageMetrics: false,
+		LogUsageMetrics: proto.Bool(false),
 	})
 
 	tests := []struct {
@@ -121,7 +122,7 @@ func TestLogUsageHandler(t *testing.T) {
 func TestLogUsageStatus(t *testing.T) {
 	// Prevent requests to the compute endpoint during test execution
 	usagemetrics.SetAgentProperties(&cpb.AgentProperties{
-		LogUsageMetrics: false,
+		LogUsageMetrics: proto.Bool(false),
 	})
 
 	tests := []struct {
@@ -19,8 +19,14 @@ limitations under the License.
 package onetime
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
@@ -61,15 +67,22 @@ func ConfigureUsageMetricsForOTE(cp *cpb.CloudProperties, name, version string)
 	usagemetrics.SetAgentProperties(&cpb.AgentProperties{
 		Name:            name,
 		Version:         version,
-		LogUsageMetrics: true,
+		LogUsageMetrics: proto.Bool(true),
 	})
 	usagemetrics.SetCloudProperties(cp)
 }
 
+const (
+	// FormatText prints the results of a one time execution as human readable text.
+	FormatText = "text"
+	// FormatJSON prints the results of a one time execution as JSON.
+	FormatJSON = "json"
+)
+
 // Persistent flags (defined at the ote command level)
 var (
-	logFile, logLevel string
-	logToCloud        bool
+	logFile, logLevel, outputFormat string
+	logToCloud                      bool
 )
 
 // Register registers the persistent flags for the command.
@@ -77,6 +90,50 @@ func Register(osType string, cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVarP(&logFile, "log-file", "f", "", "Set the file path for logging")
 	cmd.PersistentFlags().StringVarP(&logLevel, "log-level", "l", "info", "Set the logging level (debug, info, warn, error)")
 	cmd.PersistentFlags().BoolVarP(&logToCloud, "log-to-cloud", "c", true, "Enable logging to the cloud")
+	cmd.PersistentFlags().StringVar(&outputFormat, "format", FormatText, "Set the output format (text, json)")
+}
+
+// OutputFormat returns the output format requested for the command.
+// Commands which have not registered the persistent flags use the text format.
+func OutputFormat(cmd *cobra.Command) (string, error) {
+	flag := cmd.Flag("format")
+	if flag == nil {
+		return FormatText, nil
+	}
+	switch format := flag.Value.String(); format {
+	case FormatText, FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q, must be one of: text, json", format)
+	}
+}
+
+// PrintJSON writes v to w as indented JSON.
+// Proto messages are marshalled with protojson so that field names match the configuration file.
+func PrintJSON(w io.Writer, v any) error {
+	var content []byte
+	var err error
+	if msg, ok := v.(proto.Message); ok {
+		content, err = protojson.MarshalOptions{Multiline: true, Indent: "  ", UseProtoNames: true}.Marshal(msg)
+	} else {
+		content, err = json.MarshalIndent(v, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("marshalling JSON output: %w", err)
+	}
+	_, err = fmt.Fprintln(w, string(content))
+	return err
+}
+
+// PrintError writes err to w as JSON when the JSON output format was requested.
+// Text output is left to the logger so that the existing behavior is unchanged.
+func PrintError(w io.Writer, err error) {
+	if outputFormat != FormatJSON {
+		return
+	}
+	PrintJSON(w, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
 }
 
 // SetValues sets the persistent flags for the subcommand.
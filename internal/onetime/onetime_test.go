@@ -17,6 +17,8 @@ limitations under the License.
 package onetime
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -86,3 +88,100 @@ func TestSetValues(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		register bool
+		format   string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name: "flagNotRegistered",
+			want: FormatText,
+		},
+		{
+			name:     "defaultValue",
+			register: true,
+			want:     FormatText,
+		},
+		{
+			name:     "json",
+			register: true,
+			format:   "json",
+			want:     FormatJSON,
+		},
+		{
+			name:     "unsupported",
+			register: true,
+			format:   "yaml",
+			wantErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			if test.register {
+				Register("linux", cmd)
+				defer cmd.PersistentFlags().Set("format", FormatText)
+			}
+			if test.format != "" {
+				cmd.PersistentFlags().Set("format", test.format)
+			}
+			got, err := OutputFormat(cmd)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("OutputFormat() returned error %v, want error: %t", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("OutputFormat() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPrintJSON(t *testing.T) {
+	var buf bytes.Buffer
+	v := struct {
+		Name string `json:"name"`
+	}{Name: "test"}
+	if err := PrintJSON(&buf, v); err != nil {
+		t.Fatalf("PrintJSON() returned unexpected error: %v", err)
+	}
+	want := "{\n  \"name\": \"test\"\n}\n"
+	if got := buf.String(); got != want {
+		t.Errorf("PrintJSON() wrote %q, want %q", got, want)
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "text",
+			format: FormatText,
+			want:   "",
+		},
+		{
+			name:   "json",
+			format: FormatJSON,
+			want:   "{\n  \"error\": \"failed\"\n}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputFormat = test.format
+			defer func() { outputFormat = FormatText }()
+			var buf bytes.Buffer
+			PrintError(&buf, errors.New("failed"))
+			if got := buf.String(); got != test.want {
+				t.Errorf("PrintError() wrote %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -21,14 +21,15 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"runtime"
 	"slices"
 	"strings"
 
 	"cloud.google.com/go/artifactregistry/apiv1"
 	"github.com/spf13/cobra"
 	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime"
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -42,6 +43,12 @@ const (
 	requiredScope    = "https://www.googleapis.com/auth/cloud-platform"
 )
 
+// repositories are the Artifact Registry repositories of the agent packages of each architecture.
+var repositories = map[string]string{
+	capabilities.AMD64: "google-cloud-workload-agent-x86-64",
+	capabilities.ARM64: "google-cloud-workload-agent-aarch64",
+}
+
 // NewCommand creates a new status command.
 func NewCommand(cloudProps *cpb.CloudProperties) *cobra.Command {
 	var config string
@@ -52,7 +59,11 @@ func NewCommand(cloudProps *cpb.CloudProperties) *cobra.Command {
 		Long:  "Print the status of the agent, including version, service status, and configuration validity.",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ctx := cmd.Context()
-			arClient, err := newARClient(ctx)
+			format, err := onetime.OutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			arClient, err := NewARClient(ctx)
 			if err != nil {
 				return err
 			}
@@ -60,6 +71,9 @@ func NewCommand(cloudProps *cpb.CloudProperties) *cobra.Command {
 				defer c.Client.Close()
 			}
 			status := agentStatus(ctx, arClient, commandlineexecutor.ExecuteCommand, cloudProps, config, os.ReadFile)
+			if format == onetime.FormatJSON {
+				return onetime.PrintJSON(cmd.OutOrStdout(), status)
+			}
 			statushelper.PrintStatus(ctx, status, compact)
 			return nil
 		},
@@ -69,8 +83,8 @@ func NewCommand(cloudProps *cpb.CloudProperties) *cobra.Command {
 	return cmd
 }
 
-// newARClient creates a new artifact registry client.
-func newARClient(ctx context.Context) (statushelper.ARClientInterface, error) {
+// NewARClient creates a new artifact registry client.
+func NewARClient(ctx context.Context) (statushelper.ARClientInterface, error) {
 	arClient, err := artifactregistry.NewClient(ctx)
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("Could not create artifact registry client", "error", err)
@@ -93,7 +107,7 @@ func agentStatus(ctx context.Context, arClient statushelper.ARClientInterface, e
 		agentStatus.CloudApiAccessFullScopesGranted = spb.State_ERROR_STATE
 		agentStatus.AvailableVersion = "Error: could not fetch latest version"
 	} else {
-		agentStatus.AvailableVersion, err = statushelper.LatestVersionArtifactRegistry(ctx, arClient, "workload-agent-products", getRepositoryLocation(cloudProps), "google-cloud-workload-agent-x86-64", agentPackageName)
+		agentStatus.AvailableVersion, err = LatestVersion(ctx, arClient, cloudProps)
 		if err != nil {
 			log.CtxLogger(ctx).Errorw("Could not fetch latest version", "error", err)
 			agentStatus.AvailableVersion = "Error: could not fetch latest version"
@@ -108,7 +122,7 @@ func agentStatus(ctx context.Context, arClient statushelper.ARClientInterface, e
 
 	agentStatus.SystemdServiceEnabled = spb.State_FAILURE_STATE
 	agentStatus.SystemdServiceRunning = spb.State_FAILURE_STATE
-	enabled, running, err := statushelper.CheckAgentEnabledAndRunning(ctx, agentPackageName, runtime.GOOS, exec)
+	enabled, running, err := statushelper.CheckAgentEnabledAndRunning(ctx, agentPackageName, capabilities.Host().OS, exec)
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("Could not check agent enabled and running", "error", err)
 		agentStatus.SystemdServiceEnabled = spb.State_ERROR_STATE
@@ -124,12 +138,7 @@ func agentStatus(ctx context.Context, arClient statushelper.ARClientInterface, e
 
 	path := config
 	if len(path) == 0 {
-		switch runtime.GOOS {
-		case "linux":
-			path = configuration.LinuxConfigPath
-		case "windows":
-			path = configuration.WindowsConfigPath
-		}
+		path = configuration.ConfigPath()
 	}
 	agentStatus.ConfigurationFilePath = path
 	content, err := readFile(path)
@@ -146,13 +155,27 @@ func agentStatus(ctx context.Context, arClient statushelper.ARClientInterface, e
 		}
 	}
 
-	agentStatus.KernelVersion, err = statushelper.KernelVersion(ctx, runtime.GOOS, exec)
-	if err != nil && runtime.GOOS == "linux" {
+	agentStatus.KernelVersion, err = statushelper.KernelVersion(ctx, capabilities.Host().OS, exec)
+	if err != nil && capabilities.Host().IsLinux() {
 		log.CtxLogger(ctx).Errorw("Could not fetch kernel version", "error", err)
 	}
 	return agentStatus
 }
 
+// LatestVersion returns the latest agent version published to the package repository closest to the instance.
+func LatestVersion(ctx context.Context, arClient statushelper.ARClientInterface, cloudProps *cpb.CloudProperties) (string, error) {
+	return statushelper.LatestVersionArtifactRegistry(ctx, arClient, "workload-agent-products", getRepositoryLocation(cloudProps), repository(capabilities.Host().Arch), agentPackageName)
+}
+
+// repository returns the repository of the agent packages for arch, packages built for amd64 are
+// used on any other architecture.
+func repository(arch string) string {
+	if r, ok := repositories[arch]; ok {
+		return r
+	}
+	return repositories[capabilities.AMD64]
+}
+
 // getRepositoryLocation returns the repository location based on the cloud properties.
 func getRepositoryLocation(cp *cpb.CloudProperties) string {
 	if cp.GetZone() == "" {
@@ -102,15 +102,15 @@ func TestNewARClient(t *testing.T) {
 	// Force the client to fail by pointing to a non-existent credentials file.
 	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "non-existent-file")
 
-	client, err := newARClient(ctx)
+	client, err := NewARClient(ctx)
 	if err == nil {
 		if c, ok := client.(io.Closer); ok {
 			c.Close()
 		}
-		t.Fatal("newARClient() succeeded, want error")
+		t.Fatal("NewARClient() succeeded, want error")
 	}
 	if client != nil {
-		t.Error("newARClient() returned a non-nil client on error")
+		t.Error("NewARClient() returned a non-nil client on error")
 	}
 }
 
@@ -458,3 +458,19 @@ func TestGetRepositoryLocation(t *testing.T) {
 		})
 	}
 }
+
+func TestRepository(t *testing.T) {
+	tests := []struct {
+		arch string
+		want string
+	}{
+		{arch: "amd64", want: "google-cloud-workload-agent-x86-64"},
+		{arch: "arm64", want: "google-cloud-workload-agent-aarch64"},
+		{arch: "386", want: "google-cloud-workload-agent-x86-64"},
+	}
+	for _, tc := range tests {
+		if got := repository(tc.arch); got != tc.want {
+			t.Errorf("repository(%q) = %q, want %q", tc.arch, got, tc.want)
+		}
+	}
+}
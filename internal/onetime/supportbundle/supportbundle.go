@@ -0,0 +1,332 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supportbundle implements the one time execution mode which collects the agent logs,
+// configuration and environment into a single zip archive for support cases.
+package supportbundle
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/api/storage/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/audit"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication/discovery"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// environmentVariables are included in the bundle when set, with any credentials in their values redacted.
+var environmentVariables = []string{
+	"GOOGLE_APPLICATION_CREDENTIALS",
+	"HTTP_PROXY",
+	"HTTPS_PROXY",
+	"NO_PROXY",
+}
+
+// SupportBundle has args for the supportbundle subcommand.
+type SupportBundle struct {
+	configPath, outputDir, bucket string
+	lp                            log.Parameters
+	cloudProps                    *cpb.CloudProperties
+
+	readFile func(string) ([]byte, error)
+	glob     func(string) ([]string, error)
+	hostname func() (string, error)
+	now      func() time.Time
+	discover func(context.Context) (servicecommunication.DiscoveryResult, error)
+	upload   func(ctx context.Context, bucket, object, path string) error
+}
+
+// Result describes the created support bundle.
+type Result struct {
+	Path   string   `json:"path"`
+	GCSURI string   `json:"gcsUri,omitempty"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// NewCommand creates a new supportbundle command.
+func NewCommand(lp log.Parameters, cloudProps *cpb.CloudProperties) *cobra.Command {
+	s := &SupportBundle{
+		lp:         lp,
+		cloudProps: cloudProps,
+		readFile:   os.ReadFile,
+		glob:       filepath.Glob,
+		hostname:   os.Hostname,
+		now:        time.Now,
+		upload:     uploadToGCS,
+	}
+	cmd := &cobra.Command{
+		Use:   "supportbundle",
+		Short: "Collect diagnostic information for support cases",
+		Long: `Collects the agent logs, the redacted configuration, a discovery snapshot,
+recent collection results and environment information into a single zip archive.
+
+Passwords are redacted. Use --bucket to also upload the archive to Cloud Storage.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return s.supportBundleHandler(cmd)
+		},
+	}
+	cmd.Flags().StringVar(&s.configPath, "config", configuration.ConfigPath(), "Configuration path")
+	cmd.Flags().StringVar(&s.outputDir, "path", os.TempDir(), "Directory the support bundle is written to")
+	cmd.Flags().StringVar(&s.bucket, "bucket", "", "Cloud Storage bucket the support bundle is uploaded to (optional)")
+	return cmd
+}
+
+func (s *SupportBundle) supportBundleHandler(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	format, err := onetime.OutputFormat(cmd)
+	if err != nil {
+		return err
+	}
+	onetime.SetValues(configuration.AgentName, &s.lp, cmd, "supportbundle")
+	log.SetupLoggingForOTE("google-cloud-workload-agent", cmd.Name(), s.lp)
+	if s.discover == nil {
+		s.discover = discovery.Service{ProcessLister: discovery.DefaultProcessLister{}}.Discover
+	}
+
+	res, err := s.create(ctx)
+	if err != nil {
+		return err
+	}
+	if format == onetime.FormatJSON {
+		return onetime.PrintJSON(cmd.OutOrStdout(), res)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Support bundle written to %s\n", res.Path)
+	if res.GCSURI != "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "Support bundle uploaded to %s\n", res.GCSURI)
+	}
+	for _, e := range res.Errors {
+		fmt.Fprintf(cmd.OutOrStdout(), "Not collected: %s\n", e)
+	}
+	return nil
+}
+
+// create writes the support bundle and uploads it if a bucket is configured.
+// Parts of the bundle which can't be collected are listed in the result and in errors.txt.
+func (s *SupportBundle) create(ctx context.Context) (*Result, error) {
+	host, err := s.hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	name := fmt.Sprintf("supportbundle-%s-%s", host, s.now().UTC().Format("20060102-150405"))
+	bundlePath := filepath.Join(s.outputDir, name+".zip")
+	f, err := os.OpenFile(bundlePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("creating support bundle: %w", err)
+	}
+	defer f.Close()
+
+	b := &bundle{zw: zip.NewWriter(f), dir: name}
+	config := s.addConfiguration(b)
+	s.addLogs(b, config)
+	s.addDiscovery(ctx, b)
+	s.addCollectionResults(b, config)
+	s.addEnvironment(b, host)
+	if len(b.errors) > 0 {
+		b.add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+	}
+	if err := b.zw.Close(); err != nil {
+		return nil, fmt.Errorf("writing support bundle: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("writing support bundle: %w", err)
+	}
+	log.CtxLogger(ctx).Infow("Support bundle written", "path", bundlePath)
+
+	res := &Result{Path: bundlePath, Errors: b.errors}
+	if s.bucket != "" {
+		if err := s.upload(ctx, s.bucket, name+".zip", bundlePath); err != nil {
+			return nil, fmt.Errorf("uploading support bundle to gs://%s: %w", s.bucket, err)
+		}
+		res.GCSURI = fmt.Sprintf("gs://%s/%s.zip", s.bucket, name)
+		log.CtxLogger(ctx).Infow("Support bundle uploaded", "uri", res.GCSURI)
+	}
+	return res, nil
+}
+
+// addConfiguration adds the redacted effective configuration and returns it for locating the other files.
+// The default configuration is returned if the configuration file can't be loaded.
+func (s *SupportBundle) addConfiguration(b *bundle) *cpb.Configuration {
+	config, err := configuration.Load(s.configPath, s.readFile, s.cloudProps)
+	if err != nil {
+		b.fail("configuration", err)
+		return &cpb.Configuration{}
+	}
+	content, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", UseProtoNames: true}.Marshal(configuration.Redact(config))
+	if err != nil {
+		b.fail("configuration", err)
+		return config
+	}
+	b.add("configuration.json", content)
+	return config
+}
+
+// addLogs adds the daemon, one time execution and audit logs, including rotated files.
+func (s *SupportBundle) addLogs(b *bundle, config *cpb.Configuration) {
+	pattern := "/var/log/google-cloud-workload-agent*.log*"
+	if capabilities.Host().IsWindows() {
+		pattern = fmt.Sprintf(`%s\Google\google-cloud-workload-agent\logs\*.log*`, log.CreateWindowsLogBasePath())
+	}
+	paths, err := s.glob(pattern)
+	if err != nil {
+		b.fail("logs", err)
+		return
+	}
+	if p := audit.FromConfig(config).Path(); p != "" && !slices.Contains(paths, p) {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		s.addFile(b, "logs", p)
+	}
+}
+
+// addDiscovery adds the processes found by common discovery, with credentials in their arguments redacted.
+func (s *SupportBundle) addDiscovery(ctx context.Context, b *bundle) {
+	res, err := s.discover(ctx)
+	if err != nil {
+		b.fail("discovery", err)
+		return
+	}
+	lines := make([]string, 0, len(res.Processes))
+	for _, p := range res.Processes {
+		lines = append(lines, secretredact.String(p.String()))
+	}
+	sort.Strings(lines)
+	b.add("discovery.txt", []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// addCollectionResults adds the insights exported to a local file instead of Data Warehouse.
+func (s *SupportBundle) addCollectionResults(b *bundle, config *cpb.Configuration) {
+	if export := config.GetDataWarehouseExport(); export.GetEnabled() && export.GetFilePath() != "" {
+		s.addFile(b, "collection", export.GetFilePath())
+	}
+}
+
+// environment is written to environment.json.
+type environment struct {
+	AgentName       string            `json:"agentName"`
+	AgentVersion    string            `json:"agentVersion"`
+	OS              string            `json:"os"`
+	Arch            string            `json:"arch"`
+	Tools           []string          `json:"tools,omitempty"`
+	GoVersion       string            `json:"goVersion"`
+	Hostname        string            `json:"hostname"`
+	CollectedAt     time.Time         `json:"collectedAt"`
+	CloudProperties json.RawMessage   `json:"cloudProperties,omitempty"`
+	Variables       map[string]string `json:"variables,omitempty"`
+}
+
+// addEnvironment adds information on the agent, host and cloud properties.
+func (s *SupportBundle) addEnvironment(b *bundle, host string) {
+	env := environment{
+		AgentName:    configuration.AgentName,
+		AgentVersion: configuration.AgentVersion,
+		OS:           capabilities.Host().OS,
+		Arch:         capabilities.Host().Arch,
+		Tools:        capabilities.Host().Tools(),
+		GoVersion:    runtime.Version(),
+		Hostname:     host,
+		CollectedAt:  s.now().UTC(),
+	}
+	if s.cloudProps != nil {
+		if cp, err := protojson.Marshal(s.cloudProps); err == nil {
+			env.CloudProperties = cp
+		}
+	}
+	for _, v := range environmentVariables {
+		if value, ok := os.LookupEnv(v); ok {
+			if env.Variables == nil {
+				env.Variables = make(map[string]string)
+			}
+			env.Variables[v] = secretredact.String(value)
+		}
+	}
+	content, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		b.fail("environment", err)
+		return
+	}
+	b.add("environment.json", content)
+	if capabilities.Host().IsLinux() {
+		s.addFile(b, "", "/etc/os-release")
+	}
+}
+
+// addFile adds the file at p to dir in the bundle with credentials redacted.
+func (s *SupportBundle) addFile(b *bundle, dir, p string) {
+	content, err := s.readFile(p)
+	if err != nil {
+		b.fail(p, err)
+		return
+	}
+	b.add(path.Join(dir, filepath.Base(p)), []byte(secretredact.String(string(content))))
+}
+
+// bundle writes the files of a support bundle below a single top level directory.
+type bundle struct {
+	zw     *zip.Writer
+	dir    string
+	errors []string
+}
+
+func (b *bundle) add(name string, content []byte) {
+	w, err := b.zw.Create(path.Join(b.dir, name))
+	if err == nil {
+		_, err = w.Write(content)
+	}
+	if err != nil {
+		b.fail(name, err)
+	}
+}
+
+func (b *bundle) fail(part string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %v", part, err))
+}
+
+// uploadToGCS uploads the file to the bucket using the application default credentials.
+func uploadToGCS(ctx context.Context, bucket, object, file string) error {
+	s, err := storage.NewService(ctx)
+	if err != nil {
+		return fmt.Errorf("creating Cloud Storage client: %w", err)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = s.Objects.Insert(bucket, &storage.Object{Name: object}).Media(f).Context(ctx).Do()
+	return err
+}
@@ -18,21 +18,167 @@ limitations under the License.
 package version
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/onetime/status"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/statushelper"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
 
+// sharedProtosModule provides the protos the agent exchanges with Google Cloud services.
+const sharedProtosModule = "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos"
+
+// versionInfo is the JSON representation of the agent version.
+type versionInfo struct {
+	Name                string `json:"name"`
+	Version             string `json:"version"`
+	BuildChange         string `json:"build_change"`
+	Commit              string `json:"commit,omitempty"`
+	BuildDate           string `json:"build_date,omitempty"`
+	GoVersion           string `json:"go_version"`
+	ConfigSchemaVersion int    `json:"config_schema_version"`
+	SharedProtosVersion string `json:"shared_protos_version,omitempty"`
+	LatestVersion       string `json:"latest_version,omitempty"`
+	UpdateAvailable     bool   `json:"update_available,omitempty"`
+}
+
 // NewCommand creates a new version command.
-func NewCommand() *cobra.Command {
-	return &cobra.Command{
+func NewCommand(cloudProps *cpb.CloudProperties) *cobra.Command {
+	var checkUpdate bool
+	cmd := &cobra.Command{
 		Use:   "version",
 		Short: "Print agent version information",
+		Long: `Prints the agent version, the commit and date it was built at and the versions
+of the configuration schema and protos it understands.
+
+Use --check-update to check the package repository for a newer agent version.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Println(fmt.Sprintf("%s version %s.%s\n", configuration.AgentName, configuration.AgentVersion, configuration.AgentBuildChange))
+			format, err := onetime.OutputFormat(cmd)
+			if err != nil {
+				return err
+			}
+			info := newVersionInfo(debug.ReadBuildInfo)
+			if checkUpdate {
+				checkForUpdate(cmd.Context(), &info, func(ctx context.Context) (string, error) {
+					arClient, err := status.NewARClient(ctx)
+					if err != nil {
+						return "", err
+					}
+					if c, ok := arClient.(*statushelper.ArtifactRegistryClient); ok {
+						defer c.Client.Close()
+					}
+					return status.LatestVersion(ctx, arClient, cloudProps)
+				})
+			}
+			if format == onetime.FormatJSON {
+				return onetime.PrintJSON(cmd.OutOrStdout(), info)
+			}
+			printText(cmd.OutOrStdout(), info)
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&checkUpdate, "check-update", false, "Check the package repository for a newer agent version")
+	return cmd
+}
+
+// newVersionInfo returns the version of the agent and the details recorded in its build info.
+func newVersionInfo(readBuildInfo func() (*debug.BuildInfo, bool)) versionInfo {
+	info := versionInfo{
+		Name:                configuration.AgentName,
+		Version:             configuration.AgentVersion,
+		BuildChange:         configuration.AgentBuildChange,
+		GoVersion:           runtime.Version(),
+		ConfigSchemaVersion: configuration.CurrentSchemaVersion,
+	}
+	bi, ok := readBuildInfo()
+	if !ok {
+		return info
+	}
+	modified := false
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			info.Commit = s.Value
+		case "vcs.time":
+			info.BuildDate = s.Value
+		case "vcs.modified":
+			modified = s.Value == "true"
+		}
+	}
+	if modified && info.Commit != "" {
+		info.Commit += "-dirty"
+	}
+	for _, dep := range bi.Deps {
+		if dep.Path == sharedProtosModule {
+			info.SharedProtosVersion = dep.Version
+		}
+	}
+	return info
+}
 
+// checkForUpdate records the latest published version in info and warns if the installed agent is stale.
+// Failures are logged since the version information is still useful without the update check.
+func checkForUpdate(ctx context.Context, info *versionInfo, latestVersion func(context.Context) (string, error)) {
+	latest, err := latestVersion(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Could not check for a newer agent version", "error", err)
+		return
+	}
+	info.LatestVersion = latest
+	installed := fmt.Sprintf("%s-%s", info.Version, info.BuildChange)
+	if compareVersions(latest, installed) > 0 {
+		info.UpdateAvailable = true
+		log.CtxLogger(ctx).Warnw("The installed agent is out of date", "installed", installed, "latest", latest)
+	}
+}
+
+// compareVersions compares versions such as "1.2-12345" part by part numerically.
+// It returns a negative number if a is older than b, zero if they are equal and a positive number if a is newer.
+func compareVersions(a, b string) int {
+	split := func(v string) []string {
+		return strings.FieldsFunc(v, func(r rune) bool { return r == '.' || r == '-' })
+	}
+	aParts, bParts := split(a), split(b)
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		var aNum, bNum int
+		if i < len(aParts) {
+			aNum, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bNum, _ = strconv.Atoi(bParts[i])
+		}
+		if aNum != bNum {
+			return aNum - bNum
+		}
+	}
+	return 0
+}
+
+func printText(w io.Writer, info versionInfo) {
+	fmt.Fprintf(w, "%s version %s.%s\n", info.Name, info.Version, info.BuildChange)
+	if info.Commit != "" {
+		fmt.Fprintf(w, "Commit: %s\n", info.Commit)
+	}
+	if info.BuildDate != "" {
+		fmt.Fprintf(w, "Build date: %s\n", info.BuildDate)
+	}
+	fmt.Fprintf(w, "Go version: %s\n", info.GoVersion)
+	fmt.Fprintf(w, "Configuration schema version: %d\n", info.ConfigSchemaVersion)
+	if info.SharedProtosVersion != "" {
+		fmt.Fprintf(w, "Shared protos version: %s\n", info.SharedProtosVersion)
+	}
+	if info.UpdateAvailable {
+		fmt.Fprintf(w, "WARNING: a newer agent version %s is available, please update the agent.\n", info.LatestVersion)
+	}
 }
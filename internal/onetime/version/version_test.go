@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+)
+
+func TestNewVersionInfo(t *testing.T) {
+	base := versionInfo{
+		Name:                configuration.AgentName,
+		Version:             configuration.AgentVersion,
+		BuildChange:         configuration.AgentBuildChange,
+		GoVersion:           runtime.Version(),
+		ConfigSchemaVersion: configuration.CurrentSchemaVersion,
+	}
+	tests := []struct {
+		name      string
+		buildInfo *debug.BuildInfo
+		ok        bool
+		want      func(versionInfo) versionInfo
+	}{
+		{
+			name: "NoBuildInfo",
+			want: func(v versionInfo) versionInfo { return v },
+		},
+		{
+			name: "VCSAndDeps",
+			buildInfo: &debug.BuildInfo{
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "abc123"},
+					{Key: "vcs.time", Value: "2025-01-02T03:04:05Z"},
+					{Key: "vcs.modified", Value: "false"},
+				},
+				Deps: []*debug.Module{
+					{Path: sharedProtosModule, Version: "v0.0.0-20251031163745-e32dea35788e"},
+				},
+			},
+			ok: true,
+			want: func(v versionInfo) versionInfo {
+				v.Commit = "abc123"
+				v.BuildDate = "2025-01-02T03:04:05Z"
+				v.SharedProtosVersion = "v0.0.0-20251031163745-e32dea35788e"
+				return v
+			},
+		},
+		{
+			name: "ModifiedWorkingTree",
+			buildInfo: &debug.BuildInfo{
+				Settings: []debug.BuildSetting{
+					{Key: "vcs.revision", Value: "abc123"},
+					{Key: "vcs.modified", Value: "true"},
+				},
+			},
+			ok: true,
+			want: func(v versionInfo) versionInfo {
+				v.Commit = "abc123-dirty"
+				return v
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := newVersionInfo(func() (*debug.BuildInfo, bool) { return tc.buildInfo, tc.ok })
+			if diff := cmp.Diff(tc.want(base), got); diff != "" {
+				t.Errorf("newVersionInfo() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckForUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		latest string
+		err    error
+		want   versionInfo
+	}{
+		{
+			name:   "UpToDate",
+			latest: "1.2-100",
+			want:   versionInfo{Version: "1.2", BuildChange: "100", LatestVersion: "1.2-100"},
+		},
+		{
+			name:   "Stale",
+			latest: "1.10-5",
+			want:   versionInfo{Version: "1.2", BuildChange: "100", LatestVersion: "1.10-5", UpdateAvailable: true},
+		},
+		{
+			name: "CheckFailed",
+			err:  errors.New("permission denied"),
+			want: versionInfo{Version: "1.2", BuildChange: "100"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := versionInfo{Version: "1.2", BuildChange: "100"}
+			checkForUpdate(context.Background(), &got, func(context.Context) (string, error) { return tc.latest, tc.err })
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("checkForUpdate() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.2-100", b: "1.2-100", want: 0},
+		{a: "1.10-1", b: "1.9-500", want: 1},
+		{a: "1.2", b: "1.2-1", want: -1},
+		{a: "2.0-0", b: "1.99-99", want: 1},
+	}
+	for _, tc := range tests {
+		got := compareVersions(tc.a, tc.b)
+		if (got > 0) != (tc.want > 0) || (got < 0) != (tc.want < 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, want sign of %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestPrintTextWarnsWhenStale(t *testing.T) {
+	var buf bytes.Buffer
+	printText(&buf, versionInfo{Name: "workloadagent", Version: "1.2", BuildChange: "0", LatestVersion: "1.3-1", UpdateAvailable: true})
+	if !strings.Contains(buf.String(), "newer agent version 1.3-1 is available") {
+		t.Errorf("printText() = %q, want a warning about version 1.3-1", buf.String())
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+)
+
+// datafileDirectoriesQuery returns the distinct directories of the datafiles as a JSON array.
+// Queried from the root of a CDB, V$DATAFILE also lists the datafiles of the pluggable databases.
+const datafileDirectoriesQuery = `SELECT JSON_ARRAYAGG(dir ORDER BY dir) AS json_output
+FROM (SELECT DISTINCT SUBSTR(name, 1, INSTR(name, '/', -1) - 1) AS dir FROM V$DATAFILE);`
+
+// collectDatafileDirectories returns the directories holding the datafiles of the database.
+func (d DiscoveryService) collectDatafileDirectories(ctx context.Context, username string, envVars map[string]string) ([]string, error) {
+	stdout, err := d.runSQLQuery(ctx, username, envVars, datafileDirectoriesQuery)
+	if err != nil {
+		return nil, err
+	}
+	// JSON_ARRAYAGG returns NULL, printed as an empty line, if the database has no datafiles.
+	if strings.TrimSpace(stdout) == "" {
+		return nil, nil
+	}
+	var dirs []string
+	if err := json.Unmarshal([]byte(stdout), &dirs); err != nil {
+		return nil, fmt.Errorf("parsing JSON output from datafile directories query: %w", err)
+	}
+	return dirs, nil
+}
+
+// DataDirectoryDetails returns the datafile directories of the discovered databases to report in
+// the Oracle insight. The disk layout of the directories is added by the WLM writer.
+func DataDirectoryDetails(discovery *odpb.Discovery) map[string]string {
+	seen := make(map[string]bool)
+	for _, root := range discovery.GetDatabases() {
+		for _, dir := range rootDatabase(root).GetDatafileDirectories() {
+			seen[dir] = true
+		}
+	}
+	if len(seen) == 0 {
+		return nil
+	}
+	dirs := make([]string, 0, len(seen))
+	for dir := range seen {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return map[string]string{workloadmanager.DataDirectoriesKey: strings.Join(dirs, ",")}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+func TestCollectDatafileDirectories(t *testing.T) {
+	envVars := map[string]string{
+		"ORACLE_HOME": "/opt/oracle/product/19c/db",
+		"ORACLE_SID":  "orcl1",
+	}
+	tests := []struct {
+		name    string
+		result  commandlineexecutor.Result
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			result: commandlineexecutor.Result{StdOut: `["+DATA/ORCL/DATAFILE","/u02/oradata/ORCL"]`},
+			want:   []string{"+DATA/ORCL/DATAFILE", "/u02/oradata/ORCL"},
+		},
+		{
+			name:   "NoDatafiles",
+			result: commandlineexecutor.Result{StdOut: "\n"},
+		},
+		{
+			name:    "InvalidJSON",
+			result:  commandlineexecutor.Result{StdOut: "invalid JSON output"},
+			wantErr: true,
+		},
+		{
+			name:    "CommandFailure",
+			result:  commandlineexecutor.Result{Error: errors.New("command failed")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := New(func(d *DiscoveryService) {
+				d.executeCommand = func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+					return tc.result
+				}
+			})
+			got, err := d.collectDatafileDirectories(context.Background(), "oracle", envVars)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("collectDatafileDirectories() returned error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("collectDatafileDirectories() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiscoverDatabasesDatafileDirectories(t *testing.T) {
+	dbJSON := `{"dbid":12345,"name":"ORCL","db_unique_name":"ORCL","instances":[{"instance_number":1,"name":"orcl1","hostname":"test-host","version":"19.0.0.0.0","edition":"EE","database_type":"SINGLE"}],"cdb":"NO","con_id":0,"database_role":"PRIMARY"}`
+	d := New(readFileFunc("ORACLE_HOME=/opt/oracle/product/19c/db\x00ORACLE_SID=orcl1\x00", nil), func(d *DiscoveryService) {
+		d.executeCommand = func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+			if strings.Contains(params.Stdin, "V$DATAFILE") {
+				return commandlineexecutor.Result{StdOut: `["/u02/oradata/ORCL"]`}
+			}
+			return commandlineexecutor.Result{StdOut: dbJSON}
+		}
+	})
+	processes := []servicecommunication.ProcessWrapper{
+		processStub{pid: 123, name: "ora_pmon_orcl1", username: "oracle"},
+	}
+
+	dbroots, err := d.discoverDatabases(context.Background(), processes)
+	if err != nil {
+		t.Fatalf("discoverDatabases() returned unexpected error: %v", err)
+	}
+	if len(dbroots) != 1 {
+		t.Fatalf("discoverDatabases() returned %v, want a single database", dbroots)
+	}
+	want := []string{"/u02/oradata/ORCL"}
+	if diff := cmp.Diff(want, dbroots[0].GetDb().GetDatafileDirectories()); diff != "" {
+		t.Errorf("discoverDatabases() returned unexpected datafile directories diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestDataDirectoryDetails(t *testing.T) {
+	tests := []struct {
+		name      string
+		discovery *odpb.Discovery
+		want      map[string]string
+	}{
+		{
+			name: "MultipleDatabases",
+			discovery: &odpb.Discovery{
+				Databases: []*odpb.Discovery_DatabaseRoot{
+					{
+						TenancyType: &odpb.Discovery_DatabaseRoot_Cdb{Cdb: &odpb.Discovery_DatabaseRoot_ContainerDatabase{
+							Root: &odpb.Discovery_Database{DatafileDirectories: []string{"/u02/oradata/CDB1", "/u02/oradata/CDB1/PDB1"}},
+						}},
+					},
+					{
+						TenancyType: &odpb.Discovery_DatabaseRoot_Db{Db: &odpb.Discovery_Database{
+							DatafileDirectories: []string{"/u02/oradata/CDB1", "+DATA/ORCL/DATAFILE"},
+						}},
+					},
+				},
+			},
+			want: map[string]string{workloadmanager.DataDirectoriesKey: "+DATA/ORCL/DATAFILE,/u02/oradata/CDB1,/u02/oradata/CDB1/PDB1"},
+		},
+		{
+			name:      "NoDirectories",
+			discovery: &odpb.Discovery{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := DataDirectoryDetails(tc.discovery)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("DataDirectoryDetails() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
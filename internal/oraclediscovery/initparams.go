@@ -0,0 +1,101 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+)
+
+// InitParameters are the initialization parameters snapshotted for best-practice evaluation.
+var InitParameters = []string{
+	"sga_target",
+	"pga_aggregate_target",
+	"filesystemio_options",
+	"db_block_size",
+	"processes",
+}
+
+// initParametersQuery returns the values of the InitParameters as a JSON object keyed by parameter name.
+func initParametersQuery() string {
+	names := make([]string, 0, len(InitParameters))
+	for _, p := range InitParameters {
+		names = append(names, fmt.Sprintf("'%s'", p))
+	}
+	return fmt.Sprintf(`SELECT JSON_OBJECTAGG(KEY name VALUE value) AS json_output
+	FROM V$PARAMETER
+	WHERE name IN (%s);`, strings.Join(names, ", "))
+}
+
+// collectInitParameters returns the snapshot of the InitParameters of the instance.
+func (d DiscoveryService) collectInitParameters(ctx context.Context, username string, envVars map[string]string) (map[string]string, error) {
+	stdout, err := d.runSQLQuery(ctx, username, envVars, initParametersQuery())
+	if err != nil {
+		return nil, err
+	}
+	params := make(map[string]string)
+	// JSON_OBJECTAGG returns NULL, printed as an empty line, if none of the parameters exist.
+	if strings.TrimSpace(stdout) == "" {
+		return params, nil
+	}
+	if err := json.Unmarshal([]byte(stdout), &params); err != nil {
+		return nil, fmt.Errorf("parsing JSON output from init parameters query: %w", err)
+	}
+	return params, nil
+}
+
+// rootDatabase returns the non-CDB or the root of the CDB.
+func rootDatabase(root *odpb.Discovery_DatabaseRoot) *odpb.Discovery_Database {
+	if db := root.GetDb(); db != nil {
+		return db
+	}
+	return root.GetCdb().GetRoot()
+}
+
+// setInitParameters sets the init parameters on the instances of the database root.
+func setInitParameters(root *odpb.Discovery_DatabaseRoot, params map[string]string) {
+	for _, instance := range rootDatabase(root).GetInstances() {
+		instance.InitParameters = params
+	}
+}
+
+// InitParameterDetails returns the init parameters of the discovered instances to report in the
+// Oracle insight. Each parameter is reported under its own key as "SID=value" pairs, e.g.
+// "oracle_sga_target": "orcl1=4294967296,orcl2=8589934592".
+func InitParameterDetails(discovery *odpb.Discovery) map[string]string {
+	values := make(map[string][]string)
+	for _, root := range discovery.GetDatabases() {
+		for _, instance := range rootDatabase(root).GetInstances() {
+			for _, name := range InitParameters {
+				if v, ok := instance.GetInitParameters()[name]; ok {
+					values[name] = append(values[name], fmt.Sprintf("%s=%s", instance.GetOracleSid(), v))
+				}
+			}
+		}
+	}
+	details := make(map[string]string)
+	for _, name := range InitParameters {
+		sort.Strings(values[name])
+		details["oracle_"+name] = strings.Join(values[name], ",")
+	}
+	return details
+}
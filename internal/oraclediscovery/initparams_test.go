@@ -0,0 +1,146 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+const initParametersJSON = `{"sga_target":"4294967296","pga_aggregate_target":"1073741824","filesystemio_options":"SETALL","db_block_size":"8192","processes":"640"}`
+
+var wantInitParameters = map[string]string{
+	"sga_target":           "4294967296",
+	"pga_aggregate_target": "1073741824",
+	"filesystemio_options": "SETALL",
+	"db_block_size":        "8192",
+	"processes":            "640",
+}
+
+func TestCollectInitParameters(t *testing.T) {
+	envVars := map[string]string{
+		"ORACLE_HOME": "/opt/oracle/product/19c/db",
+		"ORACLE_SID":  "orcl1",
+	}
+	tests := []struct {
+		name    string
+		result  commandlineexecutor.Result
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:   "Success",
+			result: commandlineexecutor.Result{StdOut: initParametersJSON},
+			want:   wantInitParameters,
+		},
+		{
+			name:   "NoParameters",
+			result: commandlineexecutor.Result{StdOut: "\n"},
+			want:   map[string]string{},
+		},
+		{
+			name:    "InvalidJSON",
+			result:  commandlineexecutor.Result{StdOut: "invalid JSON output"},
+			wantErr: true,
+		},
+		{
+			name:    "CommandFailure",
+			result:  commandlineexecutor.Result{Error: errors.New("command failed")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := New(func(d *DiscoveryService) {
+				d.executeCommand = func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+					return tc.result
+				}
+			})
+			got, err := d.collectInitParameters(context.Background(), "oracle", envVars)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("collectInitParameters() returned error: %v, wantErr: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("collectInitParameters() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiscoverDatabasesInitParameters(t *testing.T) {
+	dbJSON := `{"dbid":12345,"name":"ORCL","db_unique_name":"ORCL","instances":[{"instance_number":1,"name":"orcl1","hostname":"test-host","version":"19.0.0.0.0","edition":"EE","database_type":"SINGLE"}],"cdb":"NO","con_id":0,"database_role":"PRIMARY"}`
+	d := New(readFileFunc("ORACLE_HOME=/opt/oracle/product/19c/db\x00ORACLE_SID=orcl1\x00", nil), func(d *DiscoveryService) {
+		d.executeCommand = func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+			if strings.Contains(params.Stdin, "V$PARAMETER") {
+				return commandlineexecutor.Result{StdOut: initParametersJSON}
+			}
+			return commandlineexecutor.Result{StdOut: dbJSON}
+		}
+	})
+	processes := []servicecommunication.ProcessWrapper{
+		processStub{pid: 123, name: "ora_pmon_orcl1", username: "oracle"},
+	}
+
+	dbroots, err := d.discoverDatabases(context.Background(), processes)
+	if err != nil {
+		t.Fatalf("discoverDatabases() returned unexpected error: %v", err)
+	}
+	if len(dbroots) != 1 || len(dbroots[0].GetDb().GetInstances()) != 1 {
+		t.Fatalf("discoverDatabases() returned %v, want a single database with a single instance", dbroots)
+	}
+	got := dbroots[0].GetDb().GetInstances()[0].GetInitParameters()
+	if diff := cmp.Diff(wantInitParameters, got); diff != "" {
+		t.Errorf("discoverDatabases() returned unexpected init parameters diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestInitParameterDetails(t *testing.T) {
+	discovery := &odpb.Discovery{
+		Databases: []*odpb.Discovery_DatabaseRoot{
+			{
+				TenancyType: &odpb.Discovery_DatabaseRoot_Cdb{Cdb: &odpb.Discovery_DatabaseRoot_ContainerDatabase{
+					Root: &odpb.Discovery_Database{Instances: []*odpb.Discovery_Database_Instance{
+						{OracleSid: "orcl2", InitParameters: map[string]string{"sga_target": "8589934592", "processes": "300"}},
+					}},
+				}},
+			},
+			{
+				TenancyType: &odpb.Discovery_DatabaseRoot_Db{Db: &odpb.Discovery_Database{
+					Instances: []*odpb.Discovery_Database_Instance{{OracleSid: "orcl1", InitParameters: wantInitParameters}},
+				}},
+			},
+		},
+	}
+	want := map[string]string{
+		"oracle_sga_target":           "orcl1=4294967296,orcl2=8589934592",
+		"oracle_pga_aggregate_target": "orcl1=1073741824",
+		"oracle_filesystemio_options": "orcl1=SETALL",
+		"oracle_db_block_size":        "orcl1=8192",
+		"oracle_processes":            "orcl1=640,orcl2=300",
+	}
+	got := InitParameterDetails(discovery)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("InitParameterDetails() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,383 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// Keys of the Oracle listener details reported in the Oracle insight.
+const (
+	ListenersKey         = "oracle_listeners"
+	ListenerEndpointsKey = "oracle_listener_endpoints"
+	ListenerServicesKey  = "oracle_listener_services"
+	ListenerTLSKey       = "oracle_listener_tls"
+	TNSAliasesKey        = "oracle_tns_aliases"
+)
+
+// netParameter is a parameter of an Oracle Net configuration file such as listener.ora.
+// A parameter either has a value or nested parameters; the values of lists such as
+// (SSL_CIPHER_SUITES = (A, B)) are stored in unnamed nested parameters.
+type netParameter struct {
+	name     string
+	value    string
+	children []*netParameter
+}
+
+// child returns the first nested parameter with the given name, compared case-insensitively.
+func (p *netParameter) child(name string) *netParameter {
+	for _, c := range p.children {
+		if strings.EqualFold(c.name, name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// descendants returns all parameters nested at any depth within p with the given name.
+func (p *netParameter) descendants(name string) []*netParameter {
+	var found []*netParameter
+	for _, c := range p.children {
+		if strings.EqualFold(c.name, name) {
+			found = append(found, c)
+		}
+		found = append(found, c.descendants(name)...)
+	}
+	return found
+}
+
+// values returns the comma or space separated values of p and its unnamed nested parameters.
+func (p *netParameter) values() []string {
+	raw := []string{p.value}
+	for _, c := range p.children {
+		if c.name == "" {
+			raw = append(raw, c.value)
+		}
+	}
+	var values []string
+	for _, r := range raw {
+		values = append(values, strings.FieldsFunc(r, func(c rune) bool {
+			return c == ',' || c == ' '
+		})...)
+	}
+	return values
+}
+
+// netConfigParser parses the tokens of an Oracle Net configuration file.
+type netConfigParser struct {
+	tokens []string
+	pos    int
+}
+
+// parseNetConfig parses the content of an Oracle Net configuration file into its top level parameters.
+// Comments, line breaks and the case of keywords are not significant. Malformed content does not
+// fail the parsing, unbalanced parentheses are closed at the end of the file.
+func parseNetConfig(content string) []*netParameter {
+	p := &netConfigParser{tokens: tokenizeNetConfig(content)}
+	var params []*netParameter
+	for p.pos < len(p.tokens) {
+		var names []string
+		for p.pos < len(p.tokens) && p.peek(0) != "=" {
+			if tok := p.next(); tok != "(" && tok != ")" {
+				names = append(names, tok)
+			}
+		}
+		if p.pos >= len(p.tokens) {
+			break
+		}
+		p.next() // Skip "=".
+		param := &netParameter{name: strings.Join(names, " ")}
+		if p.peek(0) == "(" {
+			for p.peek(0) == "(" {
+				param.children = append(param.children, p.parseGroup())
+			}
+		} else if p.pos < len(p.tokens) {
+			param.value = p.next()
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// parseGroup parses a parenthesized parameter, e.g. (PORT = 1521).
+func (p *netConfigParser) parseGroup() *netParameter {
+	p.next() // Skip "(".
+	param := &netParameter{}
+	if tok := p.peek(0); tok != "(" && tok != ")" && tok != "=" && p.peek(1) == "=" {
+		param.name = p.next()
+		p.next()
+	}
+	var words []string
+	for p.pos < len(p.tokens) {
+		switch p.peek(0) {
+		case ")":
+			p.next()
+			param.value = strings.Join(words, " ")
+			return param
+		case "(":
+			param.children = append(param.children, p.parseGroup())
+		case "=":
+			p.next()
+		default:
+			words = append(words, p.next())
+		}
+	}
+	param.value = strings.Join(words, " ")
+	return param
+}
+
+func (p *netConfigParser) peek(offset int) string {
+	if p.pos+offset >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *netConfigParser) next() string {
+	tok := p.tokens[p.pos]
+	p.pos++
+	return tok
+}
+
+// tokenizeNetConfig splits the content of an Oracle Net configuration file into parentheses,
+// equal signs and words. Comments starting with # are dropped and quotes are removed.
+func tokenizeNetConfig(content string) []string {
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		quoted := false
+		for _, c := range line {
+			switch {
+			case c == '"':
+				quoted = !quoted
+			case quoted:
+				word.WriteRune(c)
+			case c == '(' || c == ')' || c == '=':
+				flush()
+				tokens = append(tokens, string(c))
+			case c == ' ' || c == '\t' || c == '\r':
+				flush()
+			default:
+				word.WriteRune(c)
+			}
+		}
+		flush()
+	}
+	return tokens
+}
+
+// findNetParameter returns the first top level parameter with the given name, compared case-insensitively.
+func findNetParameter(params []*netParameter, name string) *netParameter {
+	for _, p := range params {
+		if strings.EqualFold(p.name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// addressEndpoints returns the endpoints of all ADDRESS parameters nested within p.
+func addressEndpoints(ctx context.Context, p *netParameter) []*odpb.Discovery_Listener_Endpoint {
+	var endpoints []*odpb.Discovery_Listener_Endpoint
+	for _, a := range p.descendants("ADDRESS") {
+		address := make(map[string]string)
+		for _, c := range a.children {
+			address[strings.ToUpper(c.name)] = c.value
+		}
+		endpoints = append(endpoints, newListenerEndpoint(ctx, address))
+	}
+	return endpoints
+}
+
+// networkAdminDir returns the directory holding the Oracle Net configuration files of the listener.
+func networkAdminDir(listener *odpb.Discovery_Listener, envVars map[string]string) string {
+	if listener.GetParameterFile() != "" {
+		return filepath.Dir(listener.GetParameterFile())
+	}
+	return filepath.Join(envVars["ORACLE_HOME"], "network", "admin")
+}
+
+// readNetConfig reads and parses an Oracle Net configuration file.
+// A file which cannot be read is treated as empty, as all of the files are optional.
+func (d DiscoveryService) readNetConfig(ctx context.Context, path string) []*netParameter {
+	content, err := d.readFile(path)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to read Oracle Net configuration file", "path", path, "error", err)
+		return nil
+	}
+	return parseNetConfig(string(content))
+}
+
+// addNetConfiguration adds the configured endpoints, TLS settings and net service names
+// from the Oracle Net configuration files of the listener.
+func (d DiscoveryService) addNetConfiguration(ctx context.Context, listener *odpb.Discovery_Listener, alias string, envVars map[string]string) {
+	if listener.GetId().GetAlias() != "" {
+		alias = listener.GetId().GetAlias()
+	}
+	dir := networkAdminDir(listener, envVars)
+	listenerOra := d.readNetConfig(ctx, filepath.Join(dir, "listener.ora"))
+	sqlnetOra := d.readNetConfig(ctx, filepath.Join(dir, "sqlnet.ora"))
+	tnsnamesOra := d.readNetConfig(ctx, filepath.Join(dir, "tnsnames.ora"))
+
+	if p := findNetParameter(listenerOra, alias); p != nil {
+		listener.ConfiguredEndpoints = addressEndpoints(ctx, p)
+	}
+	listener.TlsConfiguration = tlsConfiguration(sqlnetOra, listenerOra)
+	listener.TnsAliases = tnsAliases(ctx, tnsnamesOra)
+}
+
+// tlsConfiguration returns the TLS settings from the given configuration files, in increasing order
+// of precedence. Nil is returned if none of the files contain TLS settings.
+func tlsConfiguration(files ...[]*netParameter) *odpb.Discovery_Listener_TlsConfiguration {
+	tls := &odpb.Discovery_Listener_TlsConfiguration{}
+	found := false
+	for _, params := range files {
+		if p := findNetParameter(params, "WALLET_LOCATION"); p != nil {
+			if dirs := p.descendants("DIRECTORY"); len(dirs) > 0 {
+				tls.WalletLocation = dirs[0].value
+				found = true
+			}
+		}
+		if p := findNetParameter(params, "SSL_CLIENT_AUTHENTICATION"); p != nil {
+			tls.SslClientAuthentication = p.value
+			found = true
+		}
+		if p := findNetParameter(params, "SSL_VERSION"); p != nil {
+			tls.SslVersion = p.value
+			found = true
+		}
+		if p := findNetParameter(params, "SSL_CIPHER_SUITES"); p != nil {
+			tls.SslCipherSuites = p.values()
+			found = true
+		}
+	}
+	if !found {
+		return nil
+	}
+	return tls
+}
+
+// tnsAliases returns the net service names defined in a tnsnames.ora file.
+// Parameters without a connect descriptor are skipped.
+func tnsAliases(ctx context.Context, params []*netParameter) []*odpb.Discovery_Listener_TnsAlias {
+	var aliases []*odpb.Discovery_Listener_TnsAlias
+	for _, p := range params {
+		if len(p.children) == 0 {
+			continue
+		}
+		var serviceName, sid string
+		if s := p.descendants("SERVICE_NAME"); len(s) > 0 {
+			serviceName = s[0].value
+		}
+		if s := p.descendants("SID"); len(s) > 0 {
+			sid = s[0].value
+		}
+		endpoints := addressEndpoints(ctx, p)
+		// Multiple aliases can share a connect descriptor, e.g. "ORCL, ORCL.EXAMPLE.COM = (...)".
+		for _, name := range strings.Split(p.name, ",") {
+			if name = strings.TrimSpace(name); name == "" {
+				continue
+			}
+			aliases = append(aliases, &odpb.Discovery_Listener_TnsAlias{
+				Name:        name,
+				Endpoints:   endpoints,
+				ServiceName: serviceName,
+				Sid:         sid,
+			})
+		}
+	}
+	return aliases
+}
+
+// endpointString returns the endpoint as a URL-like string, e.g. "tcps://localhost:2484".
+func endpointString(e *odpb.Discovery_Listener_Endpoint) string {
+	switch {
+	case e.GetTcp() != nil:
+		return fmt.Sprintf("tcp://%s:%d", e.GetTcp().GetHost(), e.GetTcp().GetPort())
+	case e.GetTcps() != nil:
+		return fmt.Sprintf("tcps://%s:%d", e.GetTcps().GetHost(), e.GetTcps().GetPort())
+	case e.GetIpc() != nil:
+		return "ipc://" + e.GetIpc().GetKey()
+	case e.GetNmp() != nil:
+		return fmt.Sprintf("nmp://%s/%s", e.GetNmp().GetServer(), e.GetNmp().GetPipe())
+	}
+	return ""
+}
+
+// ListenerDetails returns the listener details of the discovery to report in the Oracle insight.
+// The endpoints are the ones the listeners are listening on, falling back to the configured
+// endpoints for listeners without any. TLS is reported as enabled if any listener has a TCPS endpoint.
+func ListenerDetails(discovery *odpb.Discovery) map[string]string {
+	var endpoints []string
+	services := make(map[string]bool)
+	aliases := make(map[string]bool)
+	tls := false
+	for _, l := range discovery.GetListeners() {
+		listenerEndpoints := l.GetEndpoints()
+		if len(listenerEndpoints) == 0 {
+			listenerEndpoints = l.GetConfiguredEndpoints()
+		}
+		for _, e := range listenerEndpoints {
+			if e.GetTcps() != nil {
+				tls = true
+			}
+			if s := endpointString(e); s != "" {
+				endpoints = append(endpoints, fmt.Sprintf("%s=%s", l.GetId().GetAlias(), s))
+			}
+		}
+		for _, s := range l.GetServices() {
+			services[s.GetName()] = true
+		}
+		for _, a := range l.GetTnsAliases() {
+			aliases[strings.ToUpper(a.GetName())] = true
+		}
+	}
+	sort.Strings(endpoints)
+	return map[string]string{
+		ListenersKey:         strconv.Itoa(len(discovery.GetListeners())),
+		ListenerEndpointsKey: strings.Join(endpoints, ","),
+		ListenerServicesKey:  strings.Join(sortedKeys(services), ","),
+		ListenerTLSKey:       strconv.FormatBool(tls),
+		TNSAliasesKey:        strings.Join(sortedKeys(aliases), ","),
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
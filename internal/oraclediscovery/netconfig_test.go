@@ -0,0 +1,276 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+)
+
+func tcpEndpoint(host string, port int32) *odpb.Discovery_Listener_Endpoint {
+	return &odpb.Discovery_Listener_Endpoint{
+		Protocol: &odpb.Discovery_Listener_Endpoint_Tcp{Tcp: &odpb.Discovery_Listener_TCPProtocol{Host: host, Port: port}},
+	}
+}
+
+func tcpsEndpoint(host string, port int32) *odpb.Discovery_Listener_Endpoint {
+	return &odpb.Discovery_Listener_Endpoint{
+		Protocol: &odpb.Discovery_Listener_Endpoint_Tcps{Tcps: &odpb.Discovery_Listener_TCPProtocol{Host: host, Port: port}},
+	}
+}
+
+func ipcEndpoint(key string) *odpb.Discovery_Listener_Endpoint {
+	return &odpb.Discovery_Listener_Endpoint{
+		Protocol: &odpb.Discovery_Listener_Endpoint_Ipc{Ipc: &odpb.Discovery_Listener_IPCProtocol{Key: key}},
+	}
+}
+
+// readFilesFunc serves the given files and fails to read any other file.
+func readFilesFunc(files map[string]string) func(d *DiscoveryService) {
+	return func(d *DiscoveryService) {
+		d.readFile = func(path string) ([]byte, error) {
+			content, ok := files[path]
+			if !ok {
+				return nil, os.ErrNotExist
+			}
+			return []byte(content), nil
+		}
+	}
+}
+
+func TestParseNetConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []*netParameter
+	}{
+		{
+			name:    "Empty",
+			content: "# Only a comment",
+		},
+		{
+			name:    "SimpleValues",
+			content: "SSL_VERSION = 1.2\nSSL_CLIENT_AUTHENTICATION=FALSE # Comment",
+			want: []*netParameter{
+				{name: "SSL_VERSION", value: "1.2"},
+				{name: "SSL_CLIENT_AUTHENTICATION", value: "FALSE"},
+			},
+		},
+		{
+			name:    "NestedValues",
+			content: "L =\n  (DESCRIPTION =\n    (ADDRESS = (PROTOCOL = TCP)(PORT = 1521))\n  )",
+			want: []*netParameter{
+				{name: "L", children: []*netParameter{
+					{name: "DESCRIPTION", children: []*netParameter{
+						{name: "ADDRESS", children: []*netParameter{
+							{name: "PROTOCOL", value: "TCP"},
+							{name: "PORT", value: "1521"},
+						}},
+					}},
+				}},
+			},
+		},
+		{
+			name:    "ListValue",
+			content: "NAMES.DIRECTORY_PATH = (TNSNAMES, EZCONNECT)",
+			want: []*netParameter{
+				{name: "NAMES.DIRECTORY_PATH", children: []*netParameter{{value: "TNSNAMES, EZCONNECT"}}},
+			},
+		},
+		{
+			name:    "QuotedValue",
+			content: `(DIRECTORY = "/path with spaces")`,
+			want: []*netParameter{
+				{name: "DIRECTORY", value: "/path with spaces"},
+			},
+		},
+		{
+			name:    "UnbalancedParentheses",
+			content: "L = (ADDRESS = (PORT = 1521)",
+			want: []*netParameter{
+				{name: "L", children: []*netParameter{
+					{name: "ADDRESS", children: []*netParameter{{name: "PORT", value: "1521"}}},
+				}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := parseNetConfig(tc.content)
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(netParameter{})); diff != "" {
+				t.Errorf("parseNetConfig(%q) returned unexpected diff (-want +got):\n%s", tc.content, diff)
+			}
+		})
+	}
+}
+
+func TestAddNetConfiguration(t *testing.T) {
+	readTestData := func(name string) string {
+		t.Helper()
+		content, err := testData.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatalf("testData.ReadFile(%s) returned unexpected error: %v", name, err)
+		}
+		return string(content)
+	}
+	listenerOra := readTestData("listener.ora")
+	sqlnetOra := readTestData("sqlnet.ora")
+	tnsnamesOra := readTestData("tnsnames.ora")
+	envVars := map[string]string{"ORACLE_HOME": "/opt/oracle/product/19c/db"}
+	tnsAliases := []*odpb.Discovery_Listener_TnsAlias{
+		{Name: "ORCL", Endpoints: []*odpb.Discovery_Listener_Endpoint{tcpEndpoint("172.16.115.2", 1521)}, ServiceName: "orcl"},
+		{Name: "ORCL.EXAMPLE.COM", Endpoints: []*odpb.Discovery_Listener_Endpoint{tcpEndpoint("172.16.115.2", 1521)}, ServiceName: "orcl"},
+		{Name: "ORCL_TLS", Endpoints: []*odpb.Discovery_Listener_Endpoint{tcpsEndpoint("172.16.115.2", 2484)}, Sid: "orcl1"},
+	}
+
+	tests := []struct {
+		name     string
+		listener *odpb.Discovery_Listener
+		alias    string
+		files    map[string]string
+		want     *odpb.Discovery_Listener
+	}{
+		{
+			name: "AllFiles",
+			listener: &odpb.Discovery_Listener{
+				Id:            &odpb.Discovery_Listener_ListenerId{Alias: "listener"},
+				ParameterFile: "/u01/admin/listener.ora",
+			},
+			files: map[string]string{
+				"/u01/admin/listener.ora": listenerOra,
+				"/u01/admin/sqlnet.ora":   sqlnetOra,
+				"/u01/admin/tnsnames.ora": tnsnamesOra,
+			},
+			want: &odpb.Discovery_Listener{
+				Id:            &odpb.Discovery_Listener_ListenerId{Alias: "listener"},
+				ParameterFile: "/u01/admin/listener.ora",
+				ConfiguredEndpoints: []*odpb.Discovery_Listener_Endpoint{
+					ipcEndpoint("LISTENER"),
+					tcpEndpoint("172.16.115.2", 1521),
+					tcpsEndpoint("172.16.115.2", 2484),
+				},
+				TlsConfiguration: &odpb.Discovery_Listener_TlsConfiguration{
+					WalletLocation:          "/u01/app/oracle/wallet",
+					SslClientAuthentication: "FALSE",
+					SslVersion:              "1.2",
+					SslCipherSuites:         []string{"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"},
+				},
+				TnsAliases: tnsAliases,
+			},
+		},
+		{
+			name:     "DefaultNetworkAdminDirectory",
+			listener: &odpb.Discovery_Listener{},
+			alias:    "LISTENER2",
+			files: map[string]string{
+				"/opt/oracle/product/19c/db/network/admin/listener.ora": listenerOra,
+			},
+			want: &odpb.Discovery_Listener{
+				ConfiguredEndpoints: []*odpb.Discovery_Listener_Endpoint{tcpEndpoint("172.16.115.2", 1522)},
+				TlsConfiguration: &odpb.Discovery_Listener_TlsConfiguration{
+					WalletLocation:          "/u01/app/oracle/wallet",
+					SslClientAuthentication: "FALSE",
+				},
+			},
+		},
+		{
+			name: "NoFiles",
+			listener: &odpb.Discovery_Listener{
+				Id:            &odpb.Discovery_Listener_ListenerId{Alias: "LISTENER"},
+				ParameterFile: "/u01/admin/listener.ora",
+			},
+			want: &odpb.Discovery_Listener{
+				Id:            &odpb.Discovery_Listener_ListenerId{Alias: "LISTENER"},
+				ParameterFile: "/u01/admin/listener.ora",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := New(readFilesFunc(tc.files))
+			d.addNetConfiguration(context.Background(), tc.listener, tc.alias, envVars)
+			if diff := cmp.Diff(tc.want, tc.listener, protocmp.Transform()); diff != "" {
+				t.Errorf("addNetConfiguration() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestListenerDetails(t *testing.T) {
+	tests := []struct {
+		name      string
+		discovery *odpb.Discovery
+		want      map[string]string
+	}{
+		{
+			name:      "NoListeners",
+			discovery: &odpb.Discovery{},
+			want: map[string]string{
+				ListenersKey:         "0",
+				ListenerEndpointsKey: "",
+				ListenerServicesKey:  "",
+				ListenerTLSKey:       "false",
+				TNSAliasesKey:        "",
+			},
+		},
+		{
+			name: "Listeners",
+			discovery: &odpb.Discovery{
+				Listeners: []*odpb.Discovery_Listener{
+					{
+						Id:        &odpb.Discovery_Listener_ListenerId{Alias: "LISTENER"},
+						Endpoints: []*odpb.Discovery_Listener_Endpoint{ipcEndpoint("LISTENER"), tcpEndpoint("10.0.0.1", 1521)},
+						// Configured endpoints are ignored when the listener is listening.
+						ConfiguredEndpoints: []*odpb.Discovery_Listener_Endpoint{tcpsEndpoint("10.0.0.1", 2484)},
+						Services: []*odpb.Discovery_Listener_Service{
+							{Name: "orcl"},
+							{Name: "orclXDB"},
+						},
+						TnsAliases: []*odpb.Discovery_Listener_TnsAlias{{Name: "orcl"}},
+					},
+					{
+						Id:                  &odpb.Discovery_Listener_ListenerId{Alias: "LISTENER_TLS"},
+						ConfiguredEndpoints: []*odpb.Discovery_Listener_Endpoint{tcpsEndpoint("10.0.0.1", 2484)},
+						Services:            []*odpb.Discovery_Listener_Service{{Name: "orcl"}},
+						TnsAliases:          []*odpb.Discovery_Listener_TnsAlias{{Name: "ORCL"}, {Name: "ORCL_TLS"}},
+					},
+				},
+			},
+			want: map[string]string{
+				ListenersKey:         "2",
+				ListenerEndpointsKey: "LISTENER=ipc://LISTENER,LISTENER=tcp://10.0.0.1:1521,LISTENER_TLS=tcps://10.0.0.1:2484",
+				ListenerServicesKey:  "orcl,orclXDB",
+				ListenerTLSKey:       "true",
+				TNSAliasesKey:        "ORCL,ORCL_TLS",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ListenerDetails(tc.discovery)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ListenerDetails() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// Keys of the Oracle home patch details reported in the Oracle insight.
+const (
+	ReleaseUpdatesKey = "oracle_release_updates"
+	OneOffPatchesKey  = "oracle_one_off_patches"
+)
+
+const (
+	// opatchTimeout is the timeout in seconds of "opatch lsinventory", which reads the whole inventory.
+	opatchTimeout = 300
+	// opatchTimeLayout is the layout of the patch application times, e.g. "Wed Nov 08 10:05:10 UTC 2023".
+	opatchTimeLayout = "Mon Jan 02 15:04:05 MST 2006"
+)
+
+var (
+	opatchVersionRegex = regexp.MustCompile(`^OPatch version\s*:\s*(\S+)`)
+	patchRegex         = regexp.MustCompile(`^Patch\s+(\d+)\s*:\s*applied on (.+)$`)
+	patchDescRegex     = regexp.MustCompile(`^Patch description:\s*"(.*)"`)
+	bundlePatchRegex   = regexp.MustCompile(`(?i)(release update|patch set update|bundle patch)`)
+	releaseUpdateRegex = regexp.MustCompile(`(?i)^(database|db) (release update|patch set update|bundle patch)( revision)?\s*:?\s*(\d+(\.\d+)+)`)
+)
+
+// discoverOracleHomes returns the patch inventories of the Oracle homes of the database processes.
+// Oracle homes whose inventory cannot be read are skipped.
+func (d DiscoveryService) discoverOracleHomes(ctx context.Context, allProcesses []servicecommunication.ProcessWrapper) []*odpb.Discovery_OracleHome {
+	var homes []*odpb.Discovery_OracleHome
+	seen := make(map[string]bool)
+	for _, p := range d.signatures.Filter(servicecommunication.Oracle, allProcesses) {
+		environFilePath := fmt.Sprintf("/proc/%d/environ", p.Pid())
+		envVars, err := d.extractOracleEnvVars(environFilePath, "ORACLE_HOME")
+		if err != nil || envVars["ORACLE_HOME"] == "" {
+			log.CtxLogger(ctx).Debugw("Failed to extract ORACLE_HOME environment variable", "error", err, "process_id", p.Pid())
+			continue
+		}
+		path := envVars["ORACLE_HOME"]
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		username, err := p.Username()
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Unable to extract username", "error", err, "process_id", p.Pid())
+			continue
+		}
+		home, err := d.lsinventory(ctx, username, path)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to read the patch inventory of the Oracle home", "oracle_home", path, "error", err)
+			continue
+		}
+		homes = append(homes, home)
+	}
+	return homes
+}
+
+// lsinventory runs "opatch lsinventory" for the Oracle home as the owner of the home.
+func (d DiscoveryService) lsinventory(ctx context.Context, username string, path string) (*odpb.Discovery_OracleHome, error) {
+	params := commandlineexecutor.Params{
+		Executable: fmt.Sprintf("%s/OPatch/opatch", path),
+		Args:       []string{"lsinventory"},
+		Env:        []string{fmt.Sprintf("ORACLE_HOME=%s", path)},
+		User:       username,
+		Timeout:    opatchTimeout,
+	}
+	result := d.executeCommand(ctx, params)
+	if result.Error != nil {
+		log.CtxLogger(ctx).Debugw("Failed to execute command", "params", params, "result", result)
+		return nil, fmt.Errorf("executing command to get the patch inventory: %w", result.Error)
+	}
+	home, err := parseLsinventoryOutput(ctx, strings.NewReader(result.StdOut))
+	if err != nil {
+		return nil, fmt.Errorf("parsing output from 'opatch lsinventory' command: %w", err)
+	}
+	home.Path = path
+	return home, nil
+}
+
+// parseLsinventoryOutput parses the output of the 'opatch lsinventory' command.
+func parseLsinventoryOutput(ctx context.Context, r io.Reader) (*odpb.Discovery_OracleHome, error) {
+	home := &odpb.Discovery_OracleHome{}
+	var patch *odpb.Discovery_OracleHome_Patch
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if m := opatchVersionRegex.FindStringSubmatch(line); m != nil {
+			home.OpatchVersion = m[1]
+			continue
+		}
+		if m := patchRegex.FindStringSubmatch(line); m != nil {
+			patch = &odpb.Discovery_OracleHome_Patch{Id: m[1], OneOff: true}
+			if applied, err := time.Parse(opatchTimeLayout, strings.TrimSpace(m[2])); err == nil {
+				patch.AppliedTime = timestamppb.New(applied)
+			} else {
+				log.CtxLogger(ctx).Debugw("Failed to parse patch application time", "patch", m[1], "error", err)
+			}
+			home.Patches = append(home.Patches, patch)
+			continue
+		}
+		if m := patchDescRegex.FindStringSubmatch(line); m != nil && patch != nil {
+			patch.Description = m[1]
+			patch.OneOff = !bundlePatchRegex.MatchString(m[1])
+			if ru := releaseUpdateRegex.FindStringSubmatch(m[1]); ru != nil && compareVersions(ru[4], home.GetReleaseUpdate()) > 0 {
+				home.ReleaseUpdate = ru[4]
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading 'opatch lsinventory' output: %w", err)
+	}
+	if home.GetOpatchVersion() == "" {
+		return nil, errIncompleteData
+	}
+	return home, nil
+}
+
+// compareVersions compares two dotted version numbers, e.g. "19.21.0.0.231017".
+// An empty version is lower than any other version.
+func compareVersions(a, b string) int {
+	if a == "" || b == "" {
+		return len(a) - len(b)
+	}
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			return x - y
+		}
+	}
+	return 0
+}
+
+// PatchDetails returns the patch level details of the discovered Oracle homes to report in the
+// Oracle insight. Release updates are reported per Oracle home, one-off patches as patch IDs.
+func PatchDetails(discovery *odpb.Discovery) map[string]string {
+	var releaseUpdates []string
+	oneOffs := make(map[string]bool)
+	for _, h := range discovery.GetOracleHomes() {
+		if h.GetReleaseUpdate() != "" {
+			releaseUpdates = append(releaseUpdates, fmt.Sprintf("%s=%s", h.GetPath(), h.GetReleaseUpdate()))
+		}
+		for _, p := range h.GetPatches() {
+			if p.GetOneOff() {
+				oneOffs[p.GetId()] = true
+			}
+		}
+	}
+	sort.Strings(releaseUpdates)
+	return map[string]string{
+		ReleaseUpdatesKey: strings.Join(releaseUpdates, ","),
+		OneOffPatchesKey:  strings.Join(sortedKeys(oneOffs), ","),
+	}
+}
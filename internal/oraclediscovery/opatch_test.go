@@ -0,0 +1,223 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclediscovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tpb "google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	odpb "github.com/GoogleCloudPlatform/workloadagent/protos/oraclediscovery"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+const lsinventorySuccessFilePath = "testdata/opatch_lsinventory_success.txt"
+
+func wantOracleHome(path string) *odpb.Discovery_OracleHome {
+	return &odpb.Discovery_OracleHome{
+		Path:          path,
+		OpatchVersion: "12.2.0.1.37",
+		ReleaseUpdate: "19.21.0.0.231017",
+		Patches: []*odpb.Discovery_OracleHome_Patch{
+			{
+				Id:          "34672698",
+				Description: "ORA-00800  SOFT EXTERNAL ERROR, ARGUMENTS  [SET PRIORITY FAILED], [VKTM] , DISM(16)",
+				AppliedTime: tpb.New(time.Date(2023, time.November, 8, 10, 12, 44, 0, time.UTC)),
+				OneOff:      true,
+			},
+			{
+				Id:          "35648110",
+				Description: "OJVM RELEASE UPDATE: 19.21.0.0.231017 (35648110)",
+				AppliedTime: tpb.New(time.Date(2023, time.November, 8, 10, 10, 32, 0, time.UTC)),
+			},
+			{
+				Id:          "35643107",
+				Description: "Database Release Update : 19.21.0.0.231017 (35643107)",
+				AppliedTime: tpb.New(time.Date(2023, time.November, 8, 10, 5, 10, 0, time.UTC)),
+			},
+			{
+				Id:          "29585399",
+				Description: "OCW RELEASE UPDATE 19.3.0.0.0 (29585399)",
+				AppliedTime: tpb.New(time.Date(2019, time.April, 18, 7, 21, 17, 0, time.UTC)),
+			},
+		},
+	}
+}
+
+func TestParseLsinventoryOutput(t *testing.T) {
+	success, err := testData.ReadFile(lsinventorySuccessFilePath)
+	if err != nil {
+		t.Fatalf("testData.ReadFile(%s) returned unexpected error: %v", lsinventorySuccessFilePath, err)
+	}
+	tests := []struct {
+		name    string
+		output  string
+		want    *odpb.Discovery_OracleHome
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			output: string(success),
+			want:   wantOracleHome(""),
+		},
+		{
+			name:   "PatchSetUpdates",
+			output: "OPatch version    : 12.2.0.1.17\nPatch  28729262     : applied on Tue Jan 15 10:00:00 UTC 2019\nPatch description:  \"DATABASE PATCH SET UPDATE 12.1.0.2.190115\"\nPatch  27338041     : applied on Mon Apr 16 10:00:00 UTC 2018\nPatch description:  \"DATABASE PATCH SET UPDATE 12.1.0.2.180417\"",
+			want: &odpb.Discovery_OracleHome{
+				OpatchVersion: "12.2.0.1.17",
+				ReleaseUpdate: "12.1.0.2.190115",
+				Patches: []*odpb.Discovery_OracleHome_Patch{
+					{Id: "28729262", Description: "DATABASE PATCH SET UPDATE 12.1.0.2.190115", AppliedTime: tpb.New(time.Date(2019, time.January, 15, 10, 0, 0, 0, time.UTC))},
+					{Id: "27338041", Description: "DATABASE PATCH SET UPDATE 12.1.0.2.180417", AppliedTime: tpb.New(time.Date(2018, time.April, 16, 10, 0, 0, 0, time.UTC))},
+				},
+			},
+		},
+		{
+			name:   "NoInterimPatches",
+			output: "OPatch version    : 12.2.0.1.37\nThere are no Interim patches installed in this Oracle Home.\n\nOPatch succeeded.",
+			want:   &odpb.Discovery_OracleHome{OpatchVersion: "12.2.0.1.37"},
+		},
+		{
+			name:    "Failure",
+			output:  "OPatch failed with error code 73",
+			wantErr: errIncompleteData,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseLsinventoryOutput(context.Background(), strings.NewReader(tc.output))
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("parseLsinventoryOutput() returned error: %v, want: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+				t.Errorf("parseLsinventoryOutput() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiscoverOracleHomes(t *testing.T) {
+	success, err := testData.ReadFile(lsinventorySuccessFilePath)
+	if err != nil {
+		t.Fatalf("testData.ReadFile(%s) returned unexpected error: %v", lsinventorySuccessFilePath, err)
+	}
+	tests := []struct {
+		name      string
+		processes []servicecommunication.ProcessWrapper
+		envFile   string
+		opatchErr error
+		want      []*odpb.Discovery_OracleHome
+	}{
+		{
+			name: "NoProcesses",
+		},
+		{
+			name: "SharedOracleHome",
+			processes: []servicecommunication.ProcessWrapper{
+				processStub{pid: 123, name: "ora_pmon_orcl", username: "oracle"},
+				processStub{pid: 456, name: "ora_pmon_orcl2", username: "oracle"},
+			},
+			envFile: "ORACLE_HOME=/opt/oracle/product/19c/db\x00ORACLE_SID=orcl\x00",
+			want:    []*odpb.Discovery_OracleHome{wantOracleHome("/opt/oracle/product/19c/db")},
+		},
+		{
+			name: "NoOracleHome",
+			processes: []servicecommunication.ProcessWrapper{
+				processStub{pid: 123, name: "ora_pmon_orcl", username: "oracle"},
+			},
+			envFile: "ORACLE_SID=orcl\x00",
+		},
+		{
+			name: "OpatchFailure",
+			processes: []servicecommunication.ProcessWrapper{
+				processStub{pid: 123, name: "ora_pmon_orcl", username: "oracle"},
+			},
+			envFile:   "ORACLE_HOME=/opt/oracle/product/19c/db\x00",
+			opatchErr: errors.New("opatch failed"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			d := New(readFileFunc(tc.envFile, nil), func(d *DiscoveryService) {
+				d.executeCommand = func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+					calls++
+					if params.Executable != "/opt/oracle/product/19c/db/OPatch/opatch" {
+						return commandlineexecutor.Result{Error: fmt.Errorf("unexpected command %s", params.Executable), ExitCode: 1}
+					}
+					if tc.opatchErr != nil {
+						return commandlineexecutor.Result{Error: tc.opatchErr, ExitCode: 1}
+					}
+					return commandlineexecutor.Result{StdOut: string(success)}
+				}
+			})
+			got := d.discoverOracleHomes(context.Background(), tc.processes)
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("discoverOracleHomes() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if calls > 1 {
+				t.Errorf("discoverOracleHomes() ran opatch %d times, want at most once", calls)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "19.21.0.0.231017", b: "19.21.0.0.231017", want: 0},
+		{a: "19.21.0.0.231017", b: "19.3.0.0.0", want: 1},
+		{a: "12.1.0.2.180417", b: "12.1.0.2.190115", want: -1},
+		{a: "19.21", b: "19.21.0.1", want: -1},
+		{a: "", b: "19.3.0.0.0", want: -1},
+		{a: "19.3.0.0.0", b: "", want: 1},
+	}
+	for _, tc := range tests {
+		got := compareVersions(tc.a, tc.b)
+		if (got > 0) != (tc.want > 0) || (got < 0) != (tc.want < 0) {
+			t.Errorf("compareVersions(%q, %q) = %d, want sign of %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestPatchDetails(t *testing.T) {
+	discovery := &odpb.Discovery{
+		OracleHomes: []*odpb.Discovery_OracleHome{
+			wantOracleHome("/u01/db2"),
+			{Path: "/u01/db1", ReleaseUpdate: "19.3.0.0.0", Patches: []*odpb.Discovery_OracleHome_Patch{{Id: "34672698", OneOff: true}, {Id: "30000000", OneOff: true}}},
+			{Path: "/u01/unpatched"},
+		},
+	}
+	want := map[string]string{
+		ReleaseUpdatesKey: "/u01/db1=19.3.0.0.0,/u01/db2=19.21.0.0.231017",
+		OneOffPatchesKey:  "30000000,34672698",
+	}
+	got := PatchDetails(discovery)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("PatchDetails() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
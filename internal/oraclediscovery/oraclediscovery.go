@@ -83,7 +83,6 @@ var (
 	errIncompleteData     = errors.New("required fields are missing")
 	errDatabaseNotReady   = errors.New("database not ready")
 	sqlPlusArgs           = []string{"-LOGON", "-SILENT", "-NOLOGINTIME", "/", "as", "sysdba"}
-	oraProcessPrefixes    = []string{"ora_pmon_", "db_pmon_"}
 	oraListenerProc       = []string{"tnslsnr"}
 	retryableOraErrors    = []string{
 		"ORA-01012", // not logged on
@@ -131,6 +130,7 @@ type DiscoveryService struct {
 	backoffRandomizationFactor float64
 	backoffMultiplier          float64
 	backoffMaxInterval         time.Duration
+	signatures                 *servicecommunication.Registry
 }
 
 // New creates a new DiscoveryService.
@@ -145,6 +145,7 @@ func New(options ...func(*DiscoveryService)) *DiscoveryService {
 		backoffRandomizationFactor: backoffRandomizationFactor,
 		backoffMultiplier:          backoffMultiplier,
 		backoffMaxInterval:         backoffMaxInterval,
+		signatures:                 servicecommunication.DefaultRegistry(),
 	}
 	for _, option := range options {
 		option(&d)
@@ -152,7 +153,14 @@ func New(options ...func(*DiscoveryService)) *DiscoveryService {
 	return &d
 }
 
-// Discover returns the discovery proto containing discovered databases, listeners, and host metadata.
+// WithSignatures overrides the workload signatures used to find the Oracle database processes.
+func WithSignatures(r *servicecommunication.Registry) func(*DiscoveryService) {
+	return func(d *DiscoveryService) {
+		d.signatures = r
+	}
+}
+
+// Discover returns the discovery proto containing discovered databases, listeners, Oracle homes and host metadata.
 func (d DiscoveryService) Discover(ctx context.Context, cloudProps *cpb.CloudProperties, processes []servicecommunication.ProcessWrapper) (*odpb.Discovery, error) {
 	discovery := &odpb.Discovery{}
 	var err error
@@ -170,6 +178,7 @@ func (d DiscoveryService) Discover(ctx context.Context, cloudProps *cpb.CloudPro
 	if len(discovery.Listeners) == 0 {
 		return nil, nil
 	}
+	discovery.OracleHomes = d.discoverOracleHomes(ctx, processes)
 	discovery.Host, err = d.discoverHostMetadata(ctx, cloudProps)
 	if err != nil {
 		return nil, fmt.Errorf("discovering host metadata: %w", err)
@@ -226,10 +235,7 @@ func (d DiscoveryService) extractOracleEnvVars(environFilePath string, varsToExt
 
 func (d DiscoveryService) discoverDatabases(ctx context.Context, allProcesses []servicecommunication.ProcessWrapper) ([]*odpb.Discovery_DatabaseRoot, error) {
 	var dbroots []*odpb.Discovery_DatabaseRoot
-	procs, err := d.findProcessesByName(ctx, oraProcessPrefixes, allProcesses)
-	if err != nil {
-		return nil, err
-	}
+	procs := d.signatures.Filter(servicecommunication.Oracle, allProcesses)
 	log.CtxLogger(ctx).Infof("found %d Oracle processes: %+v", len(procs), procs)
 	for _, p := range procs {
 		environFilePath := fmt.Sprintf("/proc/%d/environ", p.Pid())
@@ -247,17 +253,45 @@ func (d DiscoveryService) discoverDatabases(ctx context.Context, allProcesses []
 		if err != nil {
 			return nil, fmt.Errorf("fetching database information: %w", err)
 		}
-		dbroots = append(dbroots, createDBRoot(db, envVars))
+		dbroot := createDBRoot(db, envVars)
+		if params, err := d.collectInitParameters(ctx, username, envVars); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to collect init parameters", "error", err, "SID", envVars["ORACLE_SID"])
+		} else {
+			setInitParameters(dbroot, params)
+		}
+		if dirs, err := d.collectDatafileDirectories(ctx, username, envVars); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to collect datafile directories", "error", err, "SID", envVars["ORACLE_SID"])
+		} else {
+			rootDatabase(dbroot).DatafileDirectories = dirs
+		}
+		dbroots = append(dbroots, dbroot)
 	}
 	return dbroots, nil
 }
 
 func (d DiscoveryService) executeSQLQuery(ctx context.Context, username string, envVars map[string]string) (database, error) {
+	stdout, err := d.runSQLQuery(ctx, username, envVars, query)
+	if err != nil {
+		return database{}, err
+	}
+
+	var db database
+	if err := json.Unmarshal([]byte(stdout), &db); err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to parse JSON output from SQL query", "sid", envVars["ORACLE_SID"], "output", stdout)
+		return database{}, fmt.Errorf("parsing JSON output from SQL query: %w", err)
+	}
+
+	return db, nil
+}
+
+// runSQLQuery runs sqlQuery with sqlplus as sysdba and returns its output.
+// The query is retried while the database is not ready.
+func (d DiscoveryService) runSQLQuery(ctx context.Context, username string, envVars map[string]string, sqlQuery string) (string, error) {
 	if _, ok := envVars["ORACLE_HOME"]; !ok {
-		return database{}, errOracleHomeNotFound
+		return "", errOracleHomeNotFound
 	}
 	if _, ok := envVars["ORACLE_SID"]; !ok {
-		return database{}, errOracleSIDNotFound
+		return "", errOracleSIDNotFound
 	}
 
 	var vars []string
@@ -267,7 +301,7 @@ func (d DiscoveryService) executeSQLQuery(ctx context.Context, username string,
 	params := commandlineexecutor.Params{
 		Executable: fmt.Sprintf("%s/bin/sqlplus", envVars["ORACLE_HOME"]),
 		Args:       sqlPlusArgs,
-		Stdin:      fmt.Sprintf("%s\n%s", sqlSettings, query),
+		Stdin:      fmt.Sprintf("%s\n%s", sqlSettings, sqlQuery),
 		Env:        vars,
 		User:       username,
 	}
@@ -299,18 +333,11 @@ func (d DiscoveryService) executeSQLQuery(ctx context.Context, username string,
 	}
 	if err := backoff.Retry(operation, backoff.WithContext(b, ctx)); err != nil {
 		if errors.Is(err, errDatabaseNotReady) {
-			return database{}, fmt.Errorf("retries exceeded while waiting for database to be ready for SID %q", envVars["ORACLE_SID"])
+			return "", fmt.Errorf("retries exceeded while waiting for database to be ready for SID %q", envVars["ORACLE_SID"])
 		}
-		return database{}, err
-	}
-
-	var db database
-	if err := json.Unmarshal([]byte(result.StdOut), &db); err != nil {
-		log.CtxLogger(ctx).Errorw("Failed to parse JSON output from SQL query", "params", params, "result", result)
-		return database{}, fmt.Errorf("parsing JSON output from SQL query: %w", err)
+		return "", err
 	}
-
-	return db, nil
+	return result.StdOut, nil
 }
 
 // createDBRoot creates a database root proto message for the given database.
@@ -436,6 +463,7 @@ func (d DiscoveryService) createListener(ctx context.Context, username string, a
 		return nil, fmt.Errorf("parsing output from 'lsnrctl services' command: %w", err)
 	}
 
+	d.addNetConfiguration(ctx, listener, alias, envVars)
 	return listener, nil
 }
 
@@ -687,15 +715,11 @@ func parseHandlerState(state string) odpb.Discovery_Listener_Service_DatabaseIns
 }
 
 func parseListenerEndpoint(ctx context.Context, input string) *odpb.Discovery_Listener_Endpoint {
-	endpoint := &odpb.Discovery_Listener_Endpoint{}
-	var protocol, host, server, pipe, key string
-	var port int
-	var err error
-
 	segments := strings.FieldsFunc(input, func(r rune) bool {
 		return r == '(' || r == ')'
 	})
 
+	address := make(map[string]string)
 	for _, segment := range segments {
 		if segment == "" {
 			continue
@@ -704,52 +728,50 @@ func parseListenerEndpoint(ctx context.Context, input string) *odpb.Discovery_Li
 		if len(pair) != 2 {
 			continue
 		}
-		value := pair[1]
-		switch strings.ToUpper(pair[0]) {
-		case "PROTOCOL":
-			protocol = value
-		case "KEY":
-			key = value
-		case "HOST":
-			host = value
-		case "PORT":
-			port, err = strconv.Atoi(value)
-			if err != nil {
-				log.CtxLogger(ctx).Warnw("Failed to parse port", "error", err, "port_string", value)
-			}
-		case "SERVER":
-			server = value
-		case "PIPE":
-			pipe = value
+		address[strings.ToUpper(pair[0])] = pair[1]
+	}
+	return newListenerEndpoint(ctx, address)
+}
+
+// newListenerEndpoint creates an endpoint from the parameters of a protocol address,
+// keyed by their upper case names (e.g. PROTOCOL, HOST and PORT).
+func newListenerEndpoint(ctx context.Context, address map[string]string) *odpb.Discovery_Listener_Endpoint {
+	endpoint := &odpb.Discovery_Listener_Endpoint{}
+	var port int
+	if value, ok := address["PORT"]; ok {
+		var err error
+		port, err = strconv.Atoi(value)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to parse port", "error", err, "port_string", value)
 		}
-		switch strings.ToUpper(protocol) {
-		case "IPC":
-			endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Ipc{
-				Ipc: &odpb.Discovery_Listener_IPCProtocol{
-					Key: key,
-				},
-			}
-		case "TCP":
-			endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Tcp{
-				Tcp: &odpb.Discovery_Listener_TCPProtocol{
-					Host: host,
-					Port: int32(port),
-				},
-			}
-		case "TCPS":
-			endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Tcps{
-				Tcps: &odpb.Discovery_Listener_TCPProtocol{
-					Host: host,
-					Port: int32(port),
-				},
-			}
-		case "NMP":
-			endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Nmp{
-				Nmp: &odpb.Discovery_Listener_NMPProtocol{
-					Server: server,
-					Pipe:   pipe,
-				},
-			}
+	}
+	switch strings.ToUpper(address["PROTOCOL"]) {
+	case "IPC":
+		endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Ipc{
+			Ipc: &odpb.Discovery_Listener_IPCProtocol{
+				Key: address["KEY"],
+			},
+		}
+	case "TCP":
+		endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Tcp{
+			Tcp: &odpb.Discovery_Listener_TCPProtocol{
+				Host: address["HOST"],
+				Port: int32(port),
+			},
+		}
+	case "TCPS":
+		endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Tcps{
+			Tcps: &odpb.Discovery_Listener_TCPProtocol{
+				Host: address["HOST"],
+				Port: int32(port),
+			},
+		}
+	case "NMP":
+		endpoint.Protocol = &odpb.Discovery_Listener_Endpoint_Nmp{
+			Nmp: &odpb.Discovery_Listener_NMPProtocol{
+				Server: address["SERVER"],
+				Pipe:   address["PIPE"],
+			},
 		}
 	}
 	return endpoint
@@ -191,7 +191,7 @@ func TestFindProcessesByName(t *testing.T) {
 				processStub{username: "user2", pid: 456, name: "ora_pmon_testdb2"},
 				processStub{username: "user3", pid: 789, name: "not_oracle_process"},
 			},
-			searchName: oraProcessPrefixes,
+			searchName: []string{"ora_pmon_", "db_pmon_"},
 			wantNames:  []string{"ora_pmon_testdb1", "ora_pmon_testdb2"},
 		},
 		{
@@ -199,14 +199,14 @@ func TestFindProcessesByName(t *testing.T) {
 			processes: []servicecommunication.ProcessWrapper{
 				processStub{username: "user1", pid: 123, name: "not_oracle_process"},
 			},
-			searchName: oraProcessPrefixes,
+			searchName: []string{"ora_pmon_", "db_pmon_"},
 		},
 		{
 			name: "process with no Name",
 			processes: []servicecommunication.ProcessWrapper{
 				processStub{username: "user1", pid: 123},
 			},
-			searchName: oraProcessPrefixes,
+			searchName: []string{"ora_pmon_", "db_pmon_"},
 		},
 		{
 			name: "oracle db_pmon and non-oracle processes",
@@ -215,7 +215,7 @@ func TestFindProcessesByName(t *testing.T) {
 				processStub{username: "user2", pid: 456, name: "db_pmon_testdb2"},
 				processStub{username: "user3", pid: 789, name: "not_oracle_process"},
 			},
-			searchName: oraProcessPrefixes,
+			searchName: []string{"ora_pmon_", "db_pmon_"},
 			wantNames:  []string{"db_pmon_testdb1", "db_pmon_testdb2"},
 		},
 		{
@@ -225,7 +225,7 @@ func TestFindProcessesByName(t *testing.T) {
 				processStub{username: "user2", pid: 456, name: "ora_pmon_testdb2"},
 				processStub{username: "user3", pid: 789, name: "not_oracle_process"},
 			},
-			searchName: oraProcessPrefixes,
+			searchName: []string{"ora_pmon_", "db_pmon_"},
 			wantNames:  []string{"db_pmon_testdb1", "ora_pmon_testdb2"},
 		},
 		{
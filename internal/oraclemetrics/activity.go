@@ -0,0 +1,259 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclemetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/timeseries"
+
+	mrpb "google.golang.org/genproto/googleapis/monitoring/v3"
+	tspb "google.golang.org/protobuf/types/known/timestamppb"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	defaultActivityTopN   = 5
+	defaultActivityWindow = time.Minute
+	cpuWaitClass          = "CPU"
+
+	// Active Session History samples the active sessions every second, so each sample accounts for
+	// one second of DB time. It has no snapshots.
+	ashActivityQuery = `SELECT CASE WHEN session_state = 'ON CPU' THEN 'CPU' ELSE wait_class END, COUNT(*), NULL, NULL
+FROM v$active_session_history
+WHERE sample_time > SYSTIMESTAMP - NUMTODSINTERVAL(:1, 'SECOND')
+GROUP BY CASE WHEN session_state = 'ON CPU' THEN 'CPU' ELSE wait_class END`
+
+	// The non-idle wait time and the DB CPU time between the two latest Statspack snapshots of the
+	// instance, with the IDs of the snapshots.
+	statspackActivityQuery = `WITH snaps AS (
+  SELECT snap_id, ROW_NUMBER() OVER (ORDER BY snap_id DESC) AS rn
+  FROM perfstat.stats$snapshot
+  WHERE dbid = (SELECT dbid FROM v$database) AND instance_number = (SELECT instance_number FROM v$instance)
+)
+SELECT n.wait_class, SUM(e.time_waited_micro - b.time_waited_micro) / 1000000, b.snap_id, e.snap_id
+FROM perfstat.stats$system_event e
+JOIN perfstat.stats$system_event b ON b.event_id = e.event_id AND b.dbid = e.dbid AND b.instance_number = e.instance_number
+JOIN v$event_name n ON n.event_id = e.event_id
+WHERE e.snap_id = (SELECT snap_id FROM snaps WHERE rn = 1) AND b.snap_id = (SELECT snap_id FROM snaps WHERE rn = 2)
+  AND e.dbid = (SELECT dbid FROM v$database) AND e.instance_number = (SELECT instance_number FROM v$instance)
+  AND n.wait_class <> 'Idle'
+GROUP BY n.wait_class, b.snap_id, e.snap_id
+UNION ALL
+SELECT 'CPU', (e.value - b.value) / 1000000, b.snap_id, e.snap_id
+FROM perfstat.stats$sys_time_model e
+JOIN perfstat.stats$sys_time_model b ON b.stat_id = e.stat_id AND b.dbid = e.dbid AND b.instance_number = e.instance_number
+JOIN perfstat.stats$time_model_statname s ON s.stat_id = e.stat_id
+WHERE s.stat_name = 'DB CPU' AND e.snap_id = (SELECT snap_id FROM snaps WHERE rn = 1) AND b.snap_id = (SELECT snap_id FROM snaps WHERE rn = 2)
+  AND e.dbid = (SELECT dbid FROM v$database) AND e.instance_number = (SELECT instance_number FROM v$instance)`
+)
+
+var errDiagnosticsPackNotLicensed = errors.New("sampling Active Session History requires diagnostics_pack_licensed")
+
+// waitClassTime is the DB time spent in a wait class, or on CPU.
+type waitClassTime struct {
+	waitClass string
+	seconds   float64
+}
+
+// snapshotInterval is the interval between two Statspack snapshots, zero for Active Session History.
+type snapshotInterval struct {
+	begin, end int64
+}
+
+// activitySource returns the source the activity is sampled from.
+// Active Session History is never sampled unless the Diagnostics Pack license is acknowledged.
+func activitySource(cfg *configpb.OracleActivitySampling) (configpb.OracleActivitySampling_Source, error) {
+	switch cfg.GetSource() {
+	case configpb.OracleActivitySampling_SOURCE_UNSPECIFIED:
+		if cfg.GetDiagnosticsPackLicensed() {
+			return configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY, nil
+		}
+		return configpb.OracleActivitySampling_STATSPACK, nil
+	case configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY:
+		if !cfg.GetDiagnosticsPackLicensed() {
+			return cfg.GetSource(), errDiagnosticsPackNotLicensed
+		}
+	}
+	return cfg.GetSource(), nil
+}
+
+// activityQuery returns the query and its arguments for the source.
+// Active Session History is sampled over the window.
+func activityQuery(source configpb.OracleActivitySampling_Source, window time.Duration) (string, []any) {
+	if source == configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY {
+		return ashActivityQuery, []any{int64(window.Seconds())}
+	}
+	return statspackActivityQuery, nil
+}
+
+// readActivity returns the DB time of each wait class reported by query and the snapshot interval
+// it was measured over.
+func readActivity(ctx context.Context, db *sql.DB, query string, args ...any) ([]waitClassTime, snapshotInterval, error) {
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query, args...)
+	querystats.Observe("oracle", "activity", time.Since(start), err)
+	if err != nil {
+		return nil, snapshotInterval{}, fmt.Errorf("querying activity: %w", err)
+	}
+	defer rows.Close()
+
+	var waits []waitClassTime
+	var interval snapshotInterval
+	for rows.Next() {
+		var waitClass sql.NullString
+		var seconds sql.NullFloat64
+		var begin, end sql.NullInt64
+		if err := rows.Scan(&waitClass, &seconds, &begin, &end); err != nil {
+			return nil, snapshotInterval{}, fmt.Errorf("scanning activity: %w", err)
+		}
+		if end.Valid {
+			interval = snapshotInterval{begin: begin.Int64, end: end.Int64}
+		}
+		if !waitClass.Valid || seconds.Float64 <= 0 {
+			continue
+		}
+		waits = append(waits, waitClassTime{waitClass: waitClass.String, seconds: seconds.Float64})
+	}
+	return waits, interval, rows.Err()
+}
+
+// newSnapshot reports whether interval ends with a snapshot not yet sent for the service, and
+// records it as sent.
+func (c *MetricCollector) newSnapshot(serviceName string, interval snapshotInterval) bool {
+	if interval.end == 0 || interval.end == c.activitySnapIDs[serviceName] {
+		return false
+	}
+	c.activitySnapIDs[serviceName] = interval.end
+	return true
+}
+
+// activityTimeSeries returns the total DB time and the DB time of the top n wait classes.
+func (c *MetricCollector) activityTimeSeries(waits []waitClassTime, n int, labels map[string]string, timestamp *tspb.Timestamp) []*mrpb.TimeSeries {
+	sort.Slice(waits, func(i, j int) bool {
+		if waits[i].seconds != waits[j].seconds {
+			return waits[i].seconds > waits[j].seconds
+		}
+		return waits[i].waitClass < waits[j].waitClass
+	})
+	var dbTime float64
+	for _, w := range waits {
+		dbTime += w.seconds
+	}
+	cloudProps := convertCloudProperties(c.Config.GetCloudProperties())
+	ts := []*mrpb.TimeSeries{timeseries.BuildFloat64(timeseries.Params{
+		CloudProp:    cloudProps,
+		MetricType:   metricURL + "/activity/db_time",
+		MetricLabels: labels,
+		Timestamp:    timestamp,
+		Float64Value: dbTime,
+	})}
+	for _, w := range waits[:min(n, len(waits))] {
+		waitLabels := maps.Clone(labels)
+		waitLabels["wait_class"] = w.waitClass
+		ts = append(ts, timeseries.BuildFloat64(timeseries.Params{
+			CloudProp:    cloudProps,
+			MetricType:   metricURL + "/activity/wait_class_time",
+			MetricLabels: waitLabels,
+			Timestamp:    timestamp,
+			Float64Value: w.seconds,
+		}))
+	}
+	return ts
+}
+
+// SendActivityMetricsToCloudMonitoring samples the activity of each database and sends its DB time
+// and top wait classes to Cloud Monitoring. Nothing is sampled unless activity sampling is enabled.
+// Statspack snapshots are usually taken hourly, so the interval between the two latest snapshots is
+// only sent once, labeled with the snapshot IDs.
+func (c *MetricCollector) SendActivityMetricsToCloudMonitoring(ctx context.Context) []*mrpb.TimeSeries {
+	metricsCfg := c.Config.GetOracleConfiguration().GetOracleMetrics()
+	cfg := metricsCfg.GetActivitySampling()
+	if !cfg.GetEnabled() {
+		return nil
+	}
+	source, err := activitySource(cfg)
+	if err != nil {
+		if !c.activityMsgLogged {
+			log.CtxLogger(ctx).Warnw("Oracle activity sampling is disabled", "error", err)
+			c.activityMsgLogged = true
+		}
+		return nil
+	}
+	window := metricsCfg.GetCollectionFrequency().AsDuration()
+	if window <= 0 {
+		window = defaultActivityWindow
+	}
+	n := int(cfg.GetTopN())
+	if n <= 0 {
+		n = defaultActivityTopN
+	}
+	query, args := activityQuery(source, window)
+
+	var ts []*mrpb.TimeSeries
+	for serviceName, db := range c.connections {
+		if c.shouldSkipQuery(ctx, serviceName, "activity") {
+			continue
+		}
+		dbInfo, err := fetchDatabaseInfo(ctx, db)
+		if err != nil {
+			log.CtxLogger(ctx).Errorw("Failed to fetch database information from v$database view", "error", err, "service_name", serviceName)
+			continue
+		}
+		queryCtx, cancel := context.WithTimeout(ctx, time.Second*time.Duration(metricsCfg.GetQueryTimeout().GetSeconds()))
+		waits, interval, err := readActivity(queryCtx, db, query, args...)
+		cancel()
+		if err != nil {
+			c.failCount[fmt.Sprintf("%s:activity", serviceName)]++
+			log.CtxLogger(ctx).Errorw("Failed to sample Oracle activity", "service_name", serviceName, "source", source, "error", err)
+			continue
+		}
+		delete(c.failCount, fmt.Sprintf("%s:activity", serviceName))
+		labels := map[string]string{"dbid": dbInfo.DBID, "db_unique_name": dbInfo.DBUniqueName, "pdb_name": dbInfo.PdbName, "source": source.String()}
+		if source == configpb.OracleActivitySampling_STATSPACK {
+			if !c.newSnapshot(serviceName, interval) {
+				log.CtxLogger(ctx).Debugw("No new Statspack snapshot since the last collection", "service_name", serviceName, "snap_id", interval.end)
+				continue
+			}
+			labels["begin_snap_id"] = strconv.FormatInt(interval.begin, 10)
+			labels["end_snap_id"] = strconv.FormatInt(interval.end, 10)
+		}
+		ts = append(ts, c.activityTimeSeries(waits, n, labels, tspb.Now())...)
+	}
+	if len(ts) == 0 {
+		return nil
+	}
+	sent, batchCount, err := cloudmonitoring.SendTimeSeries(ctx, ts, c.TimeSeriesCreator, c.BackOffs, c.Config.GetCloudProperties().GetProjectId())
+	if err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to send activity metrics to Cloud Monitoring", "error", err)
+		usagemetrics.Error(usagemetrics.OracleMetricCollectionFailure)
+		return nil
+	}
+	log.CtxLogger(ctx).Debugw("Successfully sent activity metrics to Cloud Monitoring", "sent", sent, "batches", batchCount)
+	return ts
+}
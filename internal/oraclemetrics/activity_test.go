@@ -0,0 +1,279 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oraclemetrics
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlguard"
+	cmfake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring/fake"
+
+	mpb "google.golang.org/genproto/googleapis/api/metric"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestActivitySource(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *configpb.OracleActivitySampling
+		want    configpb.OracleActivitySampling_Source
+		wantErr bool
+	}{
+		{
+			name: "DefaultsToActiveSessionHistoryWhenLicensed",
+			cfg:  &configpb.OracleActivitySampling{DiagnosticsPackLicensed: true},
+			want: configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY,
+		},
+		{
+			name: "DefaultsToStatspackWhenNotLicensed",
+			cfg:  &configpb.OracleActivitySampling{},
+			want: configpb.OracleActivitySampling_STATSPACK,
+		},
+		{
+			name: "StatspackWhenLicensed",
+			cfg:  &configpb.OracleActivitySampling{DiagnosticsPackLicensed: true, Source: configpb.OracleActivitySampling_STATSPACK},
+			want: configpb.OracleActivitySampling_STATSPACK,
+		},
+		{
+			name:    "ActiveSessionHistoryNotLicensed",
+			cfg:     &configpb.OracleActivitySampling{Source: configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY},
+			want:    configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := activitySource(tc.cfg)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("activitySource(%v) returned error: %v, want error: %v", tc.cfg, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("activitySource(%v) = %v, want %v", tc.cfg, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestActivityQuery(t *testing.T) {
+	query, args := activityQuery(configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY, 90*time.Second)
+	if query != ashActivityQuery {
+		t.Errorf("activityQuery(ACTIVE_SESSION_HISTORY) returned query %q, want %q", query, ashActivityQuery)
+	}
+	if diff := cmp.Diff([]any{int64(90)}, args); diff != "" {
+		t.Errorf("activityQuery(ACTIVE_SESSION_HISTORY) returned unexpected args diff (-want +got):\n%s", diff)
+	}
+	query, args = activityQuery(configpb.OracleActivitySampling_STATSPACK, 90*time.Second)
+	if query != statspackActivityQuery || args != nil {
+		t.Errorf("activityQuery(STATSPACK) = (%q, %v), want (%q, nil)", query, args, statspackActivityQuery)
+	}
+	for _, q := range []string{ashActivityQuery, statspackActivityQuery} {
+		if err := sqlguard.ValidateQuery(q); err != nil {
+			t.Errorf("sqlguard.ValidateQuery(%q) returned unexpected error: %v", q, err)
+		}
+	}
+}
+
+func TestReadActivity(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("cannot create a sqlite testing database")
+	}
+	defer db.Close()
+	for _, stmt := range []string{
+		`CREATE TABLE activity (wait_class TEXT, seconds REAL, begin_snap_id INTEGER, end_snap_id INTEGER)`,
+		`INSERT INTO activity VALUES ('CPU', 12, 41, 42), ('User I/O', 30.5, 41, 42), ('Commit', 0, 41, 42), (NULL, 3, 41, 42)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("db.Exec(%q) returned unexpected error: %v", stmt, err)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		want         []waitClassTime
+		wantInterval snapshotInterval
+	}{
+		{
+			name:         "Statspack",
+			query:        "SELECT wait_class, seconds, begin_snap_id, end_snap_id FROM activity WHERE seconds >= ?",
+			want:         []waitClassTime{{waitClass: "CPU", seconds: 12}, {waitClass: "User I/O", seconds: 30.5}},
+			wantInterval: snapshotInterval{begin: 41, end: 42},
+		},
+		{
+			name:  "WithoutSnapshots",
+			query: "SELECT wait_class, seconds, NULL, NULL FROM activity WHERE seconds >= ?",
+			want:  []waitClassTime{{waitClass: "CPU", seconds: 12}, {waitClass: "User I/O", seconds: 30.5}},
+		},
+		{
+			name:         "OnlyIdleWaitClasses",
+			query:        "SELECT wait_class, seconds, begin_snap_id, end_snap_id FROM activity WHERE seconds <= ?",
+			wantInterval: snapshotInterval{begin: 41, end: 42},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, gotInterval, err := readActivity(context.Background(), db, tc.query, 0)
+			if err != nil {
+				t.Fatalf("readActivity() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(waitClassTime{})); diff != "" {
+				t.Errorf("readActivity() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if gotInterval != tc.wantInterval {
+				t.Errorf("readActivity() returned interval %+v, want %+v", gotInterval, tc.wantInterval)
+			}
+		})
+	}
+
+	if _, _, err := readActivity(context.Background(), db, "SELECT wait_class, seconds, NULL, NULL FROM missing"); err == nil {
+		t.Error("readActivity() returned nil error for a missing table, want error")
+	}
+}
+
+func TestNewSnapshot(t *testing.T) {
+	c := &MetricCollector{activitySnapIDs: map[string]int64{}}
+	tests := []struct {
+		name        string
+		serviceName string
+		interval    snapshotInterval
+		want        bool
+	}{
+		{
+			name:        "NoSnapshots",
+			serviceName: "service_name1",
+		},
+		{
+			name:        "FirstSnapshot",
+			serviceName: "service_name1",
+			interval:    snapshotInterval{begin: 41, end: 42},
+			want:        true,
+		},
+		{
+			name:        "SameSnapshot",
+			serviceName: "service_name1",
+			interval:    snapshotInterval{begin: 41, end: 42},
+		},
+		{
+			name:        "SameSnapshotOfAnotherService",
+			serviceName: "service_name2",
+			interval:    snapshotInterval{begin: 41, end: 42},
+			want:        true,
+		},
+		{
+			name:        "NewSnapshot",
+			serviceName: "service_name1",
+			interval:    snapshotInterval{begin: 42, end: 43},
+			want:        true,
+		},
+	}
+	// The cases run in order, each sees the snapshots recorded by the previous ones.
+	for _, tc := range tests {
+		if got := c.newSnapshot(tc.serviceName, tc.interval); got != tc.want {
+			t.Errorf("%s: newSnapshot(%q, %+v) = %v, want %v", tc.name, tc.serviceName, tc.interval, got, tc.want)
+		}
+	}
+}
+
+func TestActivityTimeSeries(t *testing.T) {
+	waits := []waitClassTime{
+		{waitClass: "Commit", seconds: 2},
+		{waitClass: "CPU", seconds: 12},
+		{waitClass: "User I/O", seconds: 30},
+		{waitClass: "Concurrency", seconds: 2},
+	}
+	labels := map[string]string{"dbid": "1", "source": "STATSPACK"}
+	got := defaultCollector.activityTimeSeries(waits, 3, labels, defaultTimestamp)
+
+	wantMetrics := []*mpb.Metric{
+		{Type: metricURL + "/activity/db_time", Labels: map[string]string{"dbid": "1", "source": "STATSPACK"}},
+		{Type: metricURL + "/activity/wait_class_time", Labels: map[string]string{"dbid": "1", "source": "STATSPACK", "wait_class": "User I/O"}},
+		{Type: metricURL + "/activity/wait_class_time", Labels: map[string]string{"dbid": "1", "source": "STATSPACK", "wait_class": "CPU"}},
+		{Type: metricURL + "/activity/wait_class_time", Labels: map[string]string{"dbid": "1", "source": "STATSPACK", "wait_class": "Commit"}},
+	}
+	wantValues := []float64{46, 30, 12, 2}
+	var gotMetrics []*mpb.Metric
+	var gotValues []float64
+	for _, ts := range got {
+		gotMetrics = append(gotMetrics, ts.GetMetric())
+		gotValues = append(gotValues, ts.GetPoints()[0].GetValue().GetDoubleValue())
+	}
+	if diff := cmp.Diff(wantMetrics, gotMetrics, protocmp.Transform()); diff != "" {
+		t.Errorf("activityTimeSeries() returned unexpected metrics diff (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(wantValues, gotValues); diff != "" {
+		t.Errorf("activityTimeSeries() returned unexpected values diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestSendActivityMetricsToCloudMonitoring(t *testing.T) {
+	db, err := setupSQLiteDB(t)
+	if err != nil {
+		t.Fatalf("Failed to setup testing SQLite DB: %v", err)
+	}
+	tests := []struct {
+		name          string
+		sampling      *configpb.OracleActivitySampling
+		wantFailCount int
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name: "ActiveSessionHistoryNotLicensed",
+			sampling: &configpb.OracleActivitySampling{
+				Enabled: proto.Bool(true),
+				Source:  configpb.OracleActivitySampling_ACTIVE_SESSION_HISTORY,
+			},
+		},
+		{
+			name:          "StatspackQueryFails",
+			sampling:      &configpb.OracleActivitySampling{Enabled: proto.Bool(true)},
+			wantFailCount: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &MetricCollector{
+				Config: &configpb.Configuration{
+					OracleConfiguration: &configpb.OracleConfiguration{
+						OracleMetrics: &configpb.OracleMetrics{ActivitySampling: tc.sampling},
+					},
+				},
+				TimeSeriesCreator: &cmfake.TimeSeriesCreator{},
+				connections:       map[string]*sql.DB{"service_name1": db},
+				failCount:         map[string]int{},
+				skipMsgLogged:     map[string]bool{},
+				activitySnapIDs:   map[string]int64{},
+			}
+
+			got := c.SendActivityMetricsToCloudMonitoring(context.Background())
+			if got != nil {
+				t.Errorf("SendActivityMetricsToCloudMonitoring() = %v, want nil", got)
+			}
+			if gotFailCount := c.failCount["service_name1:activity"]; gotFailCount != tc.wantFailCount {
+				t.Errorf("SendActivityMetricsToCloudMonitoring() fail count = %d, want %d", gotFailCount, tc.wantFailCount)
+			}
+		})
+	}
+}
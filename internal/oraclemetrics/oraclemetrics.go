@@ -31,9 +31,13 @@ import (
 
 	"golang.org/x/exp/maps"
 	"github.com/gammazero/workerpool"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/audit"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlguard"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/cloudmonitoring"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/metadataserver"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/recovery"
@@ -95,6 +99,8 @@ type (
 		connections             map[string]*sql.DB
 		failCount               map[string]int
 		skipMsgLogged           map[string]bool
+		activityMsgLogged       bool
+		activitySnapIDs         map[string]int64
 	}
 
 	// connectionParameters holds connection parameters for the database.
@@ -141,22 +147,27 @@ type (
 	}
 )
 
+// connectionURL returns the go-ora connection URL for the connection parameters.
+func connectionURL(params connectionParameters) string {
+	urlOptions := map[string]string{
+		"dba privilege": "sysdg", // sysdg system privilege is required to connect to a closed standby.
+	}
+	return go_ora.BuildUrl(params.Host, params.Port, params.ServiceName, params.Username, params.Password.SecretValue(), urlOptions)
+}
+
 // openConnections connects to all the databases specified in the connection parameters.
 func openConnections(ctx context.Context, conParams []connectionParameters) map[string]*sql.DB {
 	connections := make(map[string]*sql.DB)
 	for _, params := range conParams {
-		urlOptions := map[string]string{
-			"dba privilege": "sysdg", // sysdg system privilege is required to connect to a closed standby.
-		}
-		connStr := go_ora.BuildUrl(params.Host, params.Port, params.ServiceName, params.Username, params.Password.SecretValue(), urlOptions)
-		conn, err := sql.Open("oracle", connStr)
+		url := connectionURL(params)
+		conn, err := sql.Open("oracle", url)
 		if err != nil {
-			log.CtxLogger(ctx).Errorw("Failed to open database connection", "error", err, "connection_parameters", params)
+			log.CtxLogger(ctx).Errorw("Failed to open database connection", "error", secretredact.Error(err, url), "connection_parameters", params)
 			usagemetrics.Error(usagemetrics.OracleConnectionFailure)
 			continue
 		}
 		if err := conn.PingContext(ctx); err != nil {
-			log.CtxLogger(ctx).Errorw("Failed to ping the database", "error", err, "connection_parameters", params)
+			log.CtxLogger(ctx).Errorw("Failed to ping the database", "error", secretredact.Error(err, url), "connection_parameters", params)
 			usagemetrics.Error(usagemetrics.OraclePingFailure)
 			continue
 		}
@@ -165,15 +176,44 @@ func openConnections(ctx context.Context, conParams []connectionParameters) map[
 	return connections
 }
 
+// CheckConnections connects to every configured database and runs a trivial query.
+// The returned map is keyed by service name; a nil value means the connection succeeded.
+func CheckConnections(ctx context.Context, gceService gceInterface, config *configpb.Configuration) (map[string]error, error) {
+	conParams, err := readConnectionParameters(ctx, gceService, config)
+	if err != nil {
+		return nil, fmt.Errorf("fetching secret data from Secret Manager: %w", err)
+	}
+	results := make(map[string]error)
+	for _, params := range conParams {
+		results[params.ServiceName] = checkConnection(ctx, params)
+	}
+	return results, nil
+}
+
+// checkConnection opens a connection to a single database and runs a trivial query.
+func checkConnection(ctx context.Context, params connectionParameters) error {
+	url := connectionURL(params)
+	conn, err := sql.Open("oracle", url)
+	if err != nil {
+		return fmt.Errorf("opening database connection: %w", secretredact.Error(err, url))
+	}
+	defer conn.Close()
+	var one int
+	if err := conn.QueryRowContext(ctx, "SELECT 1 FROM DUAL").Scan(&one); err != nil {
+		return fmt.Errorf("running test query: %w", secretredact.Error(err, url))
+	}
+	return nil
+}
+
 // New initializes and returns the MetricCollector struct.
 func New(ctx context.Context, config *configpb.Configuration) (*MetricCollector, error) {
-	gceService, err := gce.NewGCEClient(ctx)
+	gceService, err := endpoints.NewGCEClient(ctx, config.GetEndpoints())
 	if err != nil {
 		usagemetrics.Error(usagemetrics.GCEServiceCreationFailure)
 		return nil, fmt.Errorf("initializing GCE services: %w", err)
 	}
 
-	metricClient, err := monitoring.NewMetricClient(ctx)
+	metricClient, err := monitoring.NewMetricClient(ctx, endpoints.MonitoringOptions(config.GetEndpoints())...)
 	if err != nil {
 		usagemetrics.Error(usagemetrics.MetricClientCreationFailure)
 		return nil, fmt.Errorf("creating metric service client: %w", err)
@@ -186,15 +226,20 @@ func New(ctx context.Context, config *configpb.Configuration) (*MetricCollector,
 	}
 	log.CtxLogger(ctx).Debugw("Successfully read connection parameters", "connection_parameters", conParams)
 
+	var creator cloudmonitoring.TimeSeriesCreator = metricClient
+	if l := audit.FromConfig(config); l != nil {
+		creator = audit.NewTimeSeriesCreator(creator, l, config.GetEndpoints())
+	}
 	return &MetricCollector{
 		connections:       openConnections(ctx, conParams),
 		Config:            config,
-		TimeSeriesCreator: metricClient,
+		TimeSeriesCreator: creator,
 		BackOffs:          cloudmonitoring.NewDefaultBackOffIntervals(),
 		GCEService:        gceService,
 		startTime:         tspb.Now(),
 		failCount:         make(map[string]int),
 		skipMsgLogged:     make(map[string]bool),
+		activitySnapIDs:   make(map[string]int64),
 	}, nil
 }
 
@@ -362,8 +407,17 @@ func executeQueryAndSendMetrics(ctx context.Context, opts queryOptions) []*mrpb.
 		return nil
 	}
 
+	// Queries are validated when the configuration is loaded, this guards against any path that bypasses it.
+	if err := sqlguard.ValidateQuery(opts.query.GetSql()); err != nil {
+		log.CtxLogger(ctx).Errorw("Refusing to execute query which is not read-only", "query_name", queryName, "error", err)
+		opts.collector.failCount[fmt.Sprintf("%s:%s", opts.serviceName, queryName)]++
+		return nil
+	}
+
 	// TODO:  Evaluate adding a backoff mechanism for retrying database queries.
+	start := time.Now()
 	rows, err := opts.db.QueryContext(ctxTimeout, opts.query.GetSql())
+	querystats.Observe("oracle", queryName, time.Since(start), err)
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("Failed to execute query", "query_name", queryName, "error", err)
 		opts.collector.failCount[fmt.Sprintf("%s:%s", opts.serviceName, queryName)]++
@@ -1010,6 +1010,22 @@ func TestExecuteQueryAndSendMetrics(t *testing.T) {
 				Disabled:     proto.Bool(false),
 			},
 		},
+		{
+			name: "Query is not read-only",
+			query: &configpb.Query{
+				Name: "testQuery",
+				Columns: []*configpb.Column{
+					&configpb.Column{
+						ValueType:  configpb.ValueType_VALUE_INT64,
+						Name:       "testCol",
+						MetricType: configpb.MetricType_METRIC_GAUGE,
+					},
+				},
+				Sql:          "DELETE FROM test_table",
+				DatabaseRole: configpb.Query_PRIMARY,
+				Disabled:     proto.Bool(false),
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package outbound configures the proxy and trusted certificate authorities of outbound connections.
+//
+// The settings are applied through the environment, which also covers the gRPC clients of the
+// shared libraries, such as Cloud Logging and Secret Manager, which cannot be given a transport.
+// Once Install has been called, http.DefaultTransport and the transports cloned from it look up
+// the proxy of each request from the settings last applied. The gRPC clients read the proxy
+// environment variables on their first connection and Go loads the trusted certificate
+// authorities on the first TLS handshake, so later changes of these take effect when the agent
+// service is restarted.
+package outbound
+
+import (
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"golang.org/x/net/http/httpproxy"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// envKeys are the environment variables set by Apply.
+var envKeys = []string{"HTTPS_PROXY", "NO_PROXY", "SSL_CERT_FILE"}
+
+// metadataHosts are always reached without the proxy.
+var metadataHosts = []string{"metadata.google.internal", "169.254.169.254"}
+
+// certFiles are the system certificate authority files, in the order searched by Go on Linux.
+var certFiles = []string{
+	"/etc/ssl/certs/ca-certificates.crt",
+	"/etc/pki/tls/certs/ca-bundle.crt",
+	"/etc/ssl/ca-bundle.pem",
+	"/etc/pki/tls/cacert.pem",
+	"/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	"/etc/ssl/cert.pem",
+}
+
+var (
+	mu sync.Mutex
+	// original holds the envKeys values before the first Apply, restored when a setting is removed.
+	original map[string]string
+	// applied holds the envKeys values set by the last Apply.
+	applied   map[string]string
+	proxyFunc atomic.Pointer[func(*url.URL) (*url.URL, error)]
+)
+
+// Install makes http.DefaultTransport look up the proxy of each request from the network
+// settings last applied. It must be called before the first outbound connection.
+func Install() {
+	if t, ok := http.DefaultTransport.(*http.Transport); ok {
+		t.Proxy = proxy
+	}
+	updateProxy()
+}
+
+func proxy(req *http.Request) (*url.URL, error) {
+	f := proxyFunc.Load()
+	if f == nil {
+		return http.ProxyFromEnvironment(req)
+	}
+	return (*f)(req.URL)
+}
+
+// updateProxy reads the proxy environment variables, which http.ProxyFromEnvironment reads once.
+func updateProxy() {
+	f := httpproxy.FromEnvironment().ProxyFunc()
+	proxyFunc.Store(&f)
+}
+
+// BundlePath is the file combining the system certificate authorities with those of ca_bundle_file.
+func BundlePath() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("google-cloud-workload-agent-ca-%d.pem", os.Getuid()))
+}
+
+// Apply exports the network settings as the environment variables read by the Go runtime.
+// SSL_CERT_FILE replaces the system certificate authority file, so the certificate authorities of
+// ca_bundle_file are written to BundlePath after the system ones. A setting removed since the
+// previous call is restored to its value before the first call.
+func Apply(network *cpb.Network, setenv func(key, value string) error, readFile func(string) ([]byte, error), writeFile func(name string, data []byte) error) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if original == nil {
+		original = make(map[string]string)
+		for _, k := range envKeys {
+			original[k] = os.Getenv(k)
+		}
+		applied = maps.Clone(original)
+	}
+	env := maps.Clone(original)
+	if proxy := network.GetHttpsProxy(); proxy != "" {
+		env["HTTPS_PROXY"] = proxy
+		env["NO_PROXY"] = noProxy(network.GetNoProxy())
+	}
+	if bundle := network.GetCaBundleFile(); bundle != "" {
+		if capabilities.Host().IsWindows() {
+			return fmt.Errorf("ca_bundle_file is not supported on Windows, add the certificate authorities to the machine certificate store instead")
+		}
+		data, err := combinedBundle(bundle, original["SSL_CERT_FILE"], readFile)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(BundlePath(), data); err != nil {
+			return fmt.Errorf("writing the certificate authorities: %w", err)
+		}
+		env["SSL_CERT_FILE"] = BundlePath()
+	}
+	for _, k := range envKeys {
+		if env[k] == applied[k] {
+			continue
+		}
+		if err := setenv(k, env[k]); err != nil {
+			return fmt.Errorf("setting %s: %w", k, err)
+		}
+		applied[k] = env[k]
+	}
+	updateProxy()
+	return nil
+}
+
+// combinedBundle returns the system certificate authorities followed by those of bundle.
+// systemFile is the SSL_CERT_FILE set outside of the agent, if any.
+func combinedBundle(bundle, systemFile string, readFile func(string) ([]byte, error)) ([]byte, error) {
+	custom, err := readFile(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("reading ca_bundle_file: %w", err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(custom) {
+		return nil, fmt.Errorf("ca_bundle_file %s contains no PEM certificates", bundle)
+	}
+	files := certFiles
+	if systemFile != "" {
+		files = []string{systemFile}
+	}
+	for _, f := range files {
+		if system, err := readFile(f); err == nil {
+			return slices.Concat(system, []byte("\n"), custom), nil
+		}
+	}
+	return custom, nil
+}
+
+// WriteFile replaces name with a file holding data, readable only by its owner. The file is
+// written next to name and renamed, so a file planted at name by another user is not written through.
+func WriteFile(name string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// noProxy returns the configured no_proxy list extended with the metadata server hosts.
+func noProxy(configured string) string {
+	var hosts []string
+	seen := make(map[string]bool)
+	for _, h := range append(strings.Split(configured, ","), metadataHosts...) {
+		h = strings.TrimSpace(h)
+		if h == "" || seen[h] {
+			continue
+		}
+		seen[h] = true
+		hosts = append(hosts, h)
+	}
+	return strings.Join(hosts, ",")
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outbound
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const testCA = `-----BEGIN CERTIFICATE-----
+MIIBPDCB76ADAgECAhRUElrs8N0k9RZ0K4n5Irxjh93gIDAFBgMrZXAwEzERMA8G
+A1UEAwwIcHJveHktY2EwIBcNMjYxMDE2MTYxOTM0WhgPMjEyNjA5MjIxNjE5MzRa
+MBMxETAPBgNVBAMMCHByb3h5LWNhMCowBQYDK2VwAyEAxlZe++HurvhjEjaaAn5Z
+6K4OWINBmZVvYBISFoG0mtWjUzBRMB0GA1UdDgQWBBT2PZK7Xjovsh6mGbjEkWFD
+nIatwjAfBgNVHSMEGDAWgBT2PZK7Xjovsh6mGbjEkWFDnIatwjAPBgNVHRMBAf8E
+BTADAQH/MAUGAytlcANBAN/zYOLOXpohQVKzd1s0L0ZDzfIe8Ar9TGRBBrBt61FU
+HjursONHhVrHPcMktYO3j3XCGO91V5JHghKs5N7anwM=
+-----END CERTIFICATE-----
+`
+
+// resetEnv clears the environment read by Apply and the state of previous calls.
+func resetEnv(t *testing.T, environ map[string]string) {
+	t.Helper()
+	for _, k := range envKeys {
+		t.Setenv(k, environ[k])
+	}
+	original, applied = nil, nil
+	t.Cleanup(func() { original, applied = nil, nil })
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		network    *cpb.Network
+		environ    map[string]string
+		files      map[string]string
+		setenvErr  error
+		writeErr   error
+		want       map[string]string
+		wantBundle string
+		wantErr    bool
+	}{
+		{
+			name: "NotConfigured",
+			want: map[string]string{},
+		},
+		{
+			name:    "Proxy",
+			network: &cpb.Network{HttpsProxy: "http://proxy:3128"},
+			want: map[string]string{
+				"HTTPS_PROXY": "http://proxy:3128",
+				"NO_PROXY":    "metadata.google.internal,169.254.169.254",
+			},
+		},
+		{
+			name:    "ProxyWithNoProxy",
+			network: &cpb.Network{HttpsProxy: "http://proxy:3128", NoProxy: "db.internal, 169.254.169.254"},
+			want: map[string]string{
+				"HTTPS_PROXY": "http://proxy:3128",
+				"NO_PROXY":    "db.internal,169.254.169.254,metadata.google.internal",
+			},
+		},
+		{
+			name:    "NoProxyWithoutProxyIsIgnored",
+			network: &cpb.Network{NoProxy: "db.internal"},
+			want:    map[string]string{},
+		},
+		{
+			name:      "SetenvError",
+			network:   &cpb.Network{HttpsProxy: "http://proxy:3128"},
+			setenvErr: errors.New("setenv failed"),
+			want:      map[string]string{},
+			wantErr:   true,
+		},
+		{
+			name:    "CABundleIsAddedToSystemFile",
+			network: &cpb.Network{CaBundleFile: "/etc/ssl/proxy-ca.pem"},
+			files: map[string]string{
+				"/etc/ssl/proxy-ca.pem":              testCA,
+				"/etc/ssl/certs/ca-certificates.crt": "system",
+				"/etc/pki/tls/certs/ca-bundle.crt":   "other system",
+			},
+			want:       map[string]string{"SSL_CERT_FILE": BundlePath()},
+			wantBundle: "system\n" + testCA,
+		},
+		{
+			name:    "CABundleIsAddedToFirstSystemFileFound",
+			network: &cpb.Network{CaBundleFile: "/etc/ssl/proxy-ca.pem"},
+			files: map[string]string{
+				"/etc/ssl/proxy-ca.pem":            testCA,
+				"/etc/pki/tls/certs/ca-bundle.crt": "system",
+			},
+			want:       map[string]string{"SSL_CERT_FILE": BundlePath()},
+			wantBundle: "system\n" + testCA,
+		},
+		{
+			name:    "CABundleIsAddedToSSLCertFile",
+			network: &cpb.Network{CaBundleFile: "/etc/ssl/proxy-ca.pem"},
+			environ: map[string]string{"SSL_CERT_FILE": "/opt/certs.pem"},
+			files: map[string]string{
+				"/etc/ssl/proxy-ca.pem":              testCA,
+				"/etc/ssl/certs/ca-certificates.crt": "system",
+				"/opt/certs.pem":                     "custom system",
+			},
+			want:       map[string]string{"SSL_CERT_FILE": BundlePath()},
+			wantBundle: "custom system\n" + testCA,
+		},
+		{
+			name:       "CABundleWithoutSystemFile",
+			network:    &cpb.Network{CaBundleFile: "/etc/ssl/proxy-ca.pem"},
+			files:      map[string]string{"/etc/ssl/proxy-ca.pem": testCA},
+			want:       map[string]string{"SSL_CERT_FILE": BundlePath()},
+			wantBundle: testCA,
+		},
+		{
+			name:    "MissingCABundle",
+			network: &cpb.Network{CaBundleFile: "/etc/ssl/proxy-ca.pem"},
+			want:    map[string]string{},
+			wantErr: true,
+		},
+		{
+			name:    "CABundleWithoutCertificates",
+			network: &cpb.Network{CaBundleFile: "/etc/ssl/proxy-ca.pem"},
+			files:   map[string]string{"/etc/ssl/proxy-ca.pem": "not a certificate"},
+			want:    map[string]string{},
+			wantErr: true,
+		},
+		{
+			name:     "WriteError",
+			network:  &cpb.Network{CaBundleFile: "/etc/ssl/proxy-ca.pem"},
+			files:    map[string]string{"/etc/ssl/proxy-ca.pem": testCA},
+			writeErr: errors.New("write failed"),
+			want:     map[string]string{},
+			wantErr:  true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if runtime.GOOS == "windows" && tc.network.GetCaBundleFile() != "" {
+				t.Skip("ca_bundle_file is not supported on Windows")
+			}
+			resetEnv(t, tc.environ)
+			got := map[string]string{}
+			setenv := func(key, value string) error {
+				if tc.setenvErr != nil {
+					return tc.setenvErr
+				}
+				got[key] = value
+				return nil
+			}
+			readFile := func(name string) ([]byte, error) {
+				if content, ok := tc.files[name]; ok {
+					return []byte(content), nil
+				}
+				return nil, os.ErrNotExist
+			}
+			var gotBundle string
+			writeFile := func(name string, data []byte) error {
+				if tc.writeErr != nil {
+					return tc.writeErr
+				}
+				gotBundle = string(data)
+				return nil
+			}
+			err := Apply(tc.network, setenv, readFile, writeFile)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Apply() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Apply() set unexpected environment (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantBundle, gotBundle); diff != "" {
+				t.Errorf("Apply() wrote unexpected certificate authorities (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestApplyRestoresRemovedSettings(t *testing.T) {
+	resetEnv(t, map[string]string{"NO_PROXY": "db.internal"})
+	got := map[string]string{}
+	setenv := func(key, value string) error {
+		got[key] = value
+		return nil
+	}
+	if err := Apply(&cpb.Network{HttpsProxy: "http://proxy:3128"}, setenv, os.ReadFile, WriteFile); err != nil {
+		t.Fatalf("Apply() returned unexpected error: %v", err)
+	}
+	if err := Apply(nil, setenv, os.ReadFile, WriteFile); err != nil {
+		t.Fatalf("Apply() returned unexpected error: %v", err)
+	}
+	want := map[string]string{"HTTPS_PROXY": "", "NO_PROXY": "db.internal"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Apply() set unexpected environment (-want +got):\n%s", diff)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	resetEnv(t, nil)
+	transport := http.DefaultTransport.(*http.Transport)
+	prev := transport.Proxy
+	t.Cleanup(func() {
+		transport.Proxy = prev
+		proxyFunc.Store(nil)
+	})
+	Install()
+
+	req, err := http.NewRequest("GET", "https://storage.googleapis.com/bucket", nil)
+	if err != nil {
+		t.Fatalf("http.NewRequest() returned unexpected error: %v", err)
+	}
+	tests := []struct {
+		name    string
+		network *cpb.Network
+		want    string
+	}{
+		{
+			name:    "Proxy",
+			network: &cpb.Network{HttpsProxy: "http://proxy:3128"},
+			want:    "http://proxy:3128",
+		},
+		{
+			name:    "ProxyChanged",
+			network: &cpb.Network{HttpsProxy: "http://other-proxy:3128"},
+			want:    "http://other-proxy:3128",
+		},
+		{
+			name:    "NoProxy",
+			network: &cpb.Network{HttpsProxy: "http://proxy:3128", NoProxy: "googleapis.com"},
+		},
+		{
+			name: "ProxyRemoved",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Apply(tc.network, os.Setenv, os.ReadFile, WriteFile); err != nil {
+				t.Fatalf("Apply() returned unexpected error: %v", err)
+			}
+			u, err := transport.Proxy(req)
+			if err != nil {
+				t.Fatalf("Proxy() returned unexpected error: %v", err)
+			}
+			var got string
+			if u != nil {
+				got = u.String()
+			}
+			if got != tc.want {
+				t.Errorf("Proxy() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(name, []byte("planted"), 0644); err != nil {
+		t.Fatalf("os.WriteFile() returned unexpected error: %v", err)
+	}
+	if err := WriteFile(name, []byte(testCA)); err != nil {
+		t.Fatalf("WriteFile() returned unexpected error: %v", err)
+	}
+	got, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("os.ReadFile() returned unexpected error: %v", err)
+	}
+	if string(got) != testCA {
+		t.Errorf("WriteFile() wrote %q, want %q", got, testCA)
+	}
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatalf("os.Stat() returned unexpected error: %v", err)
+	}
+	if got := info.Mode().Perm(); got != 0600 {
+		t.Errorf("WriteFile() created file with mode %v, want 0600", got)
+	}
+	entries, err := os.ReadDir(filepath.Dir(name))
+	if err != nil {
+		t.Fatalf("os.ReadDir() returned unexpected error: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("WriteFile() left %d files, want 1", len(entries))
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package pacemaker collects the state of the Pacemaker cluster a database host belongs to.
+package pacemaker
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+// Validation detail keys of the cluster.
+const (
+	ManagerKey           = "host_cluster_manager"
+	DCKey                = "host_cluster_dc"
+	QuorumKey            = "host_cluster_quorum"
+	NodesKey             = "host_cluster_nodes"
+	OnlineNodesKey       = "host_cluster_online_nodes"
+	StonithEnabledKey    = "host_cluster_stonith_enabled"
+	FenceAgentsKey       = "host_cluster_fence_agents"
+	DatabaseResourcesKey = "host_cluster_database_resources"
+)
+
+// databaseAgents are the types of the resource agents which manage databases.
+var databaseAgents = map[string]bool{
+	"pgsql":   true,
+	"pgsqlms": true, // PostgreSQL Automatic Failover.
+	"mysql":   true,
+	"mariadb": true,
+	"galera":  true,
+	"oracle":  true,
+	"oralsnr": true,
+	"redis":   true,
+	"ag":      true, // SQL Server availability groups.
+	"fci":     true, // SQL Server failover cluster instances.
+}
+
+// crmMon is the XML output of crm_mon. Pacemaker 2 wraps it in a pacemaker-result element and
+// earlier versions in a crm_mon element, the elements read here are the same in both.
+type crmMon struct {
+	Summary struct {
+		CurrentDC struct {
+			Present    bool   `xml:"present,attr"`
+			Name       string `xml:"name,attr"`
+			WithQuorum bool   `xml:"with_quorum,attr"`
+		} `xml:"current_dc"`
+		ClusterOptions struct {
+			StonithEnabled bool `xml:"stonith-enabled,attr"`
+		} `xml:"cluster_options"`
+	} `xml:"summary"`
+	Nodes []struct {
+		Name        string `xml:"name,attr"`
+		Online      bool   `xml:"online,attr"`
+		Standby     bool   `xml:"standby,attr"`
+		Maintenance bool   `xml:"maintenance,attr"`
+		Pending     bool   `xml:"pending,attr"`
+		Unclean     bool   `xml:"unclean,attr"`
+	} `xml:"nodes>node"`
+	Resources resources `xml:"resources"`
+}
+
+// resources holds the resources of the cluster, which may be nested in groups, clones and bundles.
+type resources struct {
+	Resources []resource  `xml:"resource"`
+	Groups    []resources `xml:"group"`
+	Clones    []resources `xml:"clone"`
+	Bundles   []struct {
+		Replicas []resources `xml:"replica"`
+	} `xml:"bundle"`
+}
+
+type resource struct {
+	ID    string `xml:"id,attr"`
+	Agent string `xml:"resource_agent,attr"`
+	Role  string `xml:"role,attr"`
+	Nodes []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"node"`
+}
+
+// all returns the resources and the resources nested in groups, clones and bundles.
+func (r resources) all() []resource {
+	all := slices.Clone(r.Resources)
+	for _, nested := range append(slices.Clone(r.Groups), r.Clones...) {
+		all = append(all, nested.all()...)
+	}
+	for _, bundle := range r.Bundles {
+		for _, replica := range bundle.Replicas {
+			all = append(all, replica.all()...)
+		}
+	}
+	return all
+}
+
+// Status runs crm_mon and returns the state of the cluster as validation details.
+// The XML output option was renamed in Pacemaker 2.0.3, the previous option is used as fallback.
+func Status(ctx context.Context, exec commandlineexecutor.Execute) (map[string]string, error) {
+	res := exec(ctx, commandlineexecutor.Params{Executable: "crm_mon", Args: []string{"--one-shot", "--output-as=xml"}})
+	if res.Error != nil {
+		res = exec(ctx, commandlineexecutor.Params{Executable: "crm_mon", Args: []string{"--one-shot", "--as-xml"}})
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("running crm_mon: %w", res.Error)
+	}
+	return Parse([]byte(res.StdOut))
+}
+
+// Parse returns the validation details of the crm_mon XML output.
+func Parse(out []byte) (map[string]string, error) {
+	var mon crmMon
+	if err := xml.Unmarshal(out, &mon); err != nil {
+		return nil, fmt.Errorf("parsing crm_mon output: %w", err)
+	}
+	if len(mon.Nodes) == 0 {
+		return nil, errors.New("crm_mon reported no nodes")
+	}
+	details := map[string]string{
+		ManagerKey:        "pacemaker",
+		QuorumKey:         strconv.FormatBool(mon.Summary.CurrentDC.WithQuorum),
+		StonithEnabledKey: strconv.FormatBool(mon.Summary.ClusterOptions.StonithEnabled),
+	}
+	if mon.Summary.CurrentDC.Present {
+		details[DCKey] = mon.Summary.CurrentDC.Name
+	}
+
+	var nodes []string
+	online := 0
+	for _, n := range mon.Nodes {
+		state := "offline"
+		switch {
+		case n.Unclean:
+			state = "unclean"
+		case n.Pending:
+			state = "pending"
+		case n.Online && n.Maintenance:
+			state = "maintenance"
+		case n.Online && n.Standby:
+			state = "standby"
+		case n.Online:
+			state = "online"
+		}
+		if n.Online {
+			online++
+		}
+		nodes = append(nodes, n.Name+":"+state)
+	}
+	details[NodesKey] = strings.Join(nodes, ",")
+	details[OnlineNodesKey] = strconv.Itoa(online)
+
+	var fenceAgents, databaseResources []string
+	for _, r := range mon.Resources.all() {
+		if strings.HasPrefix(r.Agent, "stonith:") {
+			if !slices.Contains(fenceAgents, r.Agent) {
+				fenceAgents = append(fenceAgents, r.Agent)
+			}
+			continue
+		}
+		if !databaseAgents[r.Agent[strings.LastIndex(r.Agent, ":")+1:]] {
+			continue
+		}
+		// e.g. pgsql=ocf:heartbeat:pgsql/Promoted@node1, stopped resources run on no node.
+		description := fmt.Sprintf("%s=%s/%s", r.ID, r.Agent, r.Role)
+		var running []string
+		for _, n := range r.Nodes {
+			running = append(running, n.Name)
+		}
+		if len(running) > 0 {
+			description += "@" + strings.Join(running, "+")
+		}
+		databaseResources = append(databaseResources, description)
+	}
+	slices.Sort(fenceAgents)
+	details[FenceAgentsKey] = strings.Join(fenceAgents, ",")
+	details[DatabaseResourcesKey] = strings.Join(databaseResources, ",")
+	return details, nil
+}
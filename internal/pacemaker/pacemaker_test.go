@@ -0,0 +1,186 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pacemaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+const crmMonXML = `<?xml version="1.0"?>
+<pacemaker-result api-version="2.20" request="crm_mon --one-shot --output-as=xml">
+  <summary>
+    <stack type="corosync"/>
+    <current_dc present="true" version="2.1.2" name="db-1" id="1" with_quorum="true"/>
+    <nodes_configured number="3"/>
+    <resources_configured number="5" disabled="0" blocked="0"/>
+    <cluster_options stonith-enabled="true" symmetric-cluster="true" no-quorum-policy="stop" maintenance-mode="false"/>
+  </summary>
+  <nodes>
+    <node name="db-1" id="1" online="true" standby="false" maintenance="false" pending="false" unclean="false" is_dc="true" type="member"/>
+    <node name="db-2" id="2" online="true" standby="true" maintenance="false" pending="false" unclean="false" is_dc="false" type="member"/>
+    <node name="db-3" id="3" online="false" standby="false" maintenance="false" pending="false" unclean="true" is_dc="false" type="member"/>
+  </nodes>
+  <resources>
+    <resource id="fence-db-1" resource_agent="stonith:fence_gce" role="Started" active="true" nodes_running_on="1">
+      <node name="db-2" id="2" cached="true"/>
+    </resource>
+    <resource id="fence-db-2" resource_agent="stonith:fence_gce" role="Started" active="true" nodes_running_on="1">
+      <node name="db-1" id="1" cached="true"/>
+    </resource>
+    <clone id="pgsql-clone" multi_state="true" unique="false">
+      <resource id="pgsql" resource_agent="ocf:heartbeat:pgsql" role="Promoted" active="true" nodes_running_on="1">
+        <node name="db-1" id="1" cached="true"/>
+      </resource>
+      <resource id="pgsql" resource_agent="ocf:heartbeat:pgsql" role="Stopped" active="false" nodes_running_on="0"/>
+    </clone>
+    <group id="vip-group" number_resources="1">
+      <resource id="vip" resource_agent="ocf:heartbeat:IPaddr2" role="Started" active="true" nodes_running_on="1">
+        <node name="db-1" id="1" cached="true"/>
+      </resource>
+    </group>
+  </resources>
+</pacemaker-result>`
+
+// legacyCrmMonXML is the output of crm_mon --as-xml prior to Pacemaker 2.0.3.
+const legacyCrmMonXML = `<?xml version="1.0"?>
+<crm_mon version="1.1.23">
+  <summary>
+    <current_dc present="true" version="1.1.23" name="db-1" id="1" with_quorum="false"/>
+    <cluster_options stonith-enabled="false" symmetric-cluster="true" no-quorum-policy="ignore"/>
+  </summary>
+  <nodes>
+    <node name="db-1" id="1" online="true" standby="false" maintenance="true" pending="false" unclean="false"/>
+  </nodes>
+  <resources>
+    <group id="mysql-group" number_resources="1">
+      <resource id="mysqld" resource_agent="ocf::heartbeat:mysql" role="Started" active="true" nodes_running_on="1">
+        <node name="db-1" id="1" cached="false"/>
+      </resource>
+    </group>
+  </resources>
+</crm_mon>`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Pacemaker2",
+			out:  crmMonXML,
+			want: map[string]string{
+				ManagerKey:           "pacemaker",
+				DCKey:                "db-1",
+				QuorumKey:            "true",
+				NodesKey:             "db-1:online,db-2:standby,db-3:unclean",
+				OnlineNodesKey:       "2",
+				StonithEnabledKey:    "true",
+				FenceAgentsKey:       "stonith:fence_gce",
+				DatabaseResourcesKey: "pgsql=ocf:heartbeat:pgsql/Promoted@db-1,pgsql=ocf:heartbeat:pgsql/Stopped",
+			},
+		},
+		{
+			name: "Legacy",
+			out:  legacyCrmMonXML,
+			want: map[string]string{
+				ManagerKey:           "pacemaker",
+				DCKey:                "db-1",
+				QuorumKey:            "false",
+				NodesKey:             "db-1:maintenance",
+				OnlineNodesKey:       "1",
+				StonithEnabledKey:    "false",
+				FenceAgentsKey:       "",
+				DatabaseResourcesKey: "mysqld=ocf::heartbeat:mysql/Started@db-1",
+			},
+		},
+		{
+			name:    "NoNodes",
+			out:     `<pacemaker-result><summary/></pacemaker-result>`,
+			wantErr: true,
+		},
+		{
+			name:    "InvalidXML",
+			out:     "crm_mon: Error: cluster is not available on this node",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse([]byte(tc.out))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Parse() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Parse() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		exec    commandlineexecutor.Execute
+		wantDC  string
+		wantErr bool
+	}{
+		{
+			name: "OutputAs",
+			exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{StdOut: crmMonXML}
+			},
+			wantDC: "db-1",
+		},
+		{
+			name: "AsXMLFallback",
+			exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				if params.Args[1] == "--output-as=xml" {
+					return commandlineexecutor.Result{Error: errors.New("unrecognized option")}
+				}
+				return commandlineexecutor.Result{StdOut: legacyCrmMonXML}
+			},
+			wantDC: "db-1",
+		},
+		{
+			name: "NotInstalled",
+			exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: errors.New("executable file not found")}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Status(context.Background(), tc.exec)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Status() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if got[DCKey] != tc.wantDC {
+				t.Errorf("Status() returned DC %q, want %q", got[DCKey], tc.wantDC)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package packages collects the database servers, client libraries and agents installed on a
+// database host. Conflicting agents and mismatched client libraries are common support issues.
+package packages
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+// Validation detail keys of the package inventory.
+const (
+	// PackageManagerKey holds the package manager the inventory was read from, "dpkg" or "rpm".
+	PackageManagerKey = "host_package_manager"
+	// DatabasePackagesKey holds the installed database packages, e.g. "libpq5=16.2-1,mysql-client-8.0=8.0.36-0ubuntu0.22.04.1".
+	DatabasePackagesKey = "host_database_packages"
+	// MonitoringAgentsKey holds the installed monitoring and backup agents, e.g. "datadog-agent=1:7.50.3-1".
+	MonitoringAgentsKey = "host_monitoring_agents"
+)
+
+// databasePackages match the names of database servers and client libraries.
+var databasePackages = regexp.MustCompile(`^(` + strings.Join([]string{
+	`oracle-instantclient.*`,
+	`mysql(-community)?-(client|server|common|shell|router|libs)(-.*)?`,
+	`libmysqlclient.*`,
+	`mariadb(-client|-server|-common|-connector-c)?(-.*)?`,
+	`percona-server-(client|server).*`,
+	`postgresql\d*(-client|-server|-libs|-contrib)?(-\d+)?`,
+	`libpq\d*`,
+	`pgbouncer`,
+	`proxysql\d*`,
+	`mongodb-(org|mongosh|database-tools).*`,
+	`mongosh`,
+	`redis(-server|-tools)?`,
+	`mssql-(server|tools\d*)`,
+	`msodbcsql\d*`,
+	`unixodbc`,
+}, "|") + `)$`)
+
+// monitoringAgents match the names of monitoring and backup agents which run alongside databases.
+var monitoringAgents = regexp.MustCompile(`^(` + strings.Join([]string{
+	`google-cloud-ops-agent`,
+	`stackdriver-agent`,
+	`google-fluentd`,
+	`datadog-agent`,
+	`newrelic-infra`,
+	`dynatrace-oneagent`,
+	`zabbix-agent2?`,
+	`splunkforwarder`,
+	`pmm2?-client`,
+	`(prometheus-)?(node|mysqld|postgres|redis|mongodb)[-_]exporter`,
+	`pgbackrest`,
+	`barman(-cli)?`,
+	`percona-xtrabackup.*`,
+}, "|") + `)$`)
+
+// Package is an installed package.
+type Package struct {
+	Name    string
+	Version string
+}
+
+// Inventory queries dpkg, or rpm if dpkg is not installed, and returns the installed database
+// packages and monitoring agents as validation details.
+func Inventory(ctx context.Context, exec commandlineexecutor.Execute) (map[string]string, error) {
+	manager := "dpkg"
+	res := exec(ctx, commandlineexecutor.Params{Executable: "dpkg-query", Args: []string{"--show", "--showformat=${Package}\t${Version}\t${Status}\n"}})
+	if res.Error != nil {
+		manager = "rpm"
+		res = exec(ctx, commandlineexecutor.Params{Executable: "rpm", Args: []string{"--query", "--all", "--queryformat", "%{NAME}\t%{VERSION}-%{RELEASE}\tinstalled\n"}})
+	}
+	if res.Error != nil {
+		return nil, fmt.Errorf("querying the installed packages: %w", res.Error)
+	}
+	packages, err := Parse(res.StdOut)
+	if err != nil {
+		return nil, err
+	}
+	return Details(manager, packages), nil
+}
+
+// Parse returns the installed packages in the tab separated name, version and status output of
+// dpkg-query or rpm. Packages which are removed but whose configuration files remain are skipped.
+func Parse(out string) ([]Package, error) {
+	var packages []Package
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		// The status of dpkg is "<wanted> <error> <state>", e.g. "install ok installed".
+		if !strings.HasSuffix(fields[2], "installed") || strings.HasSuffix(fields[2], "not-installed") {
+			continue
+		}
+		packages = append(packages, Package{Name: fields[0], Version: fields[1]})
+	}
+	if len(packages) == 0 {
+		return nil, errors.New("no installed packages found")
+	}
+	return packages, nil
+}
+
+// Details returns the database packages and monitoring agents of packages as validation details.
+func Details(manager string, packages []Package) map[string]string {
+	var database, agents []string
+	for _, p := range packages {
+		name := strings.ToLower(p.Name)
+		switch {
+		case databasePackages.MatchString(name):
+			database = append(database, p.Name+"="+p.Version)
+		case monitoringAgents.MatchString(name):
+			agents = append(agents, p.Name+"="+p.Version)
+		}
+	}
+	sort.Strings(database)
+	sort.Strings(agents)
+	return map[string]string{
+		PackageManagerKey:   manager,
+		DatabasePackagesKey: strings.Join(database, ","),
+		MonitoringAgentsKey: strings.Join(agents, ","),
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package packages
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+const dpkgOutput = "adduser\t3.118ubuntu5\tinstall ok installed\n" +
+	"libpq5\t16.2-1.pgdg22.04+1\tinstall ok installed\n" +
+	"mysql-client-8.0\t8.0.36-0ubuntu0.22.04.1\tinstall ok installed\n" +
+	"postgresql-14\t14.11-1.pgdg22.04+1\tdeinstall ok config-files\n" +
+	"postgresql-16\t16.2-1.pgdg22.04+1\tinstall ok installed\n" +
+	"datadog-agent\t1:7.50.3-1\tinstall ok installed\n" +
+	"google-cloud-ops-agent\t2.46.0~ubuntu22.04\tinstall ok installed\n" +
+	"prometheus-postgres-exporter\t0.10.1-1\tinstall ok installed\n"
+
+const rpmOutput = "bash\t5.1.8-6.el9\tinstalled\n" +
+	"oracle-instantclient-basic\t21.13.0.0.0-1\tinstalled\n" +
+	"mysql-community-server\t8.0.36-1.el9\tinstalled\n" +
+	"postgresql16-libs\t16.2-1PGDG.rhel9\tinstalled\n" +
+	"zabbix-agent2\t6.4.12-release1.el9\tinstalled\n"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		out     string
+		want    []Package
+		wantErr bool
+	}{
+		{
+			name: "RemovedPackagesAreSkipped",
+			out:  "libpq5\t16.2-1\tinstall ok installed\npostgresql-14\t14.11-1\tdeinstall ok config-files\nfoo\t1.0\tunknown ok not-installed\n",
+			want: []Package{{Name: "libpq5", Version: "16.2-1"}},
+		},
+		{
+			name: "MalformedLinesAreSkipped",
+			out:  "warning: some message\nbash\t5.1.8-6.el9\tinstalled\n",
+			want: []Package{{Name: "bash", Version: "5.1.8-6.el9"}},
+		},
+		{
+			name:    "NoPackages",
+			out:     "",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Parse(tc.out)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Parse() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Parse() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInventory(t *testing.T) {
+	tests := []struct {
+		name    string
+		exec    commandlineexecutor.Execute
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Dpkg",
+			exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				if params.Executable != "dpkg-query" {
+					return commandlineexecutor.Result{Error: errors.New("unexpected command")}
+				}
+				return commandlineexecutor.Result{StdOut: dpkgOutput}
+			},
+			want: map[string]string{
+				PackageManagerKey:   "dpkg",
+				DatabasePackagesKey: "libpq5=16.2-1.pgdg22.04+1,mysql-client-8.0=8.0.36-0ubuntu0.22.04.1,postgresql-16=16.2-1.pgdg22.04+1",
+				MonitoringAgentsKey: "datadog-agent=1:7.50.3-1,google-cloud-ops-agent=2.46.0~ubuntu22.04,prometheus-postgres-exporter=0.10.1-1",
+			},
+		},
+		{
+			name: "RpmFallback",
+			exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				if params.Executable != "rpm" {
+					return commandlineexecutor.Result{Error: errors.New("executable file not found")}
+				}
+				return commandlineexecutor.Result{StdOut: rpmOutput}
+			},
+			want: map[string]string{
+				PackageManagerKey:   "rpm",
+				DatabasePackagesKey: "mysql-community-server=8.0.36-1.el9,oracle-instantclient-basic=21.13.0.0.0-1,postgresql16-libs=16.2-1PGDG.rhel9",
+				MonitoringAgentsKey: "zabbix-agent2=6.4.12-release1.el9",
+			},
+		},
+		{
+			name: "NoPackageManager",
+			exec: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: errors.New("executable file not found")}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := Inventory(context.Background(), tc.exec)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("Inventory() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Inventory() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
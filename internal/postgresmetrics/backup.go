@@ -0,0 +1,180 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	archiveModeKey           = "archive_mode"
+	archiveCommandSetKey     = "archive_command_set"
+	restoreCommandSetKey     = "restore_command_set"
+	backupToolsKey           = "backup_tools"
+	lastArchivedTimestampKey = "last_archived_timestamp"
+
+	pgBackRest   = "pgbackrest"
+	barman       = "barman"
+	pgBaseBackup = "pg_basebackup"
+
+	// restore_command is only a server setting from Postgres 12, earlier versions read it from recovery.conf.
+	backupSettingsQuery   = "SELECT name, setting FROM pg_settings WHERE name IN ('archive_mode', 'archive_command', 'restore_command')"
+	lastArchivedQuery     = "SELECT EXTRACT(EPOCH FROM last_archived_time)::bigint FROM pg_stat_archiver"
+	baseBackupClientQuery = "SELECT COUNT(*) FROM pg_stat_replication WHERE application_name = 'pg_basebackup'"
+)
+
+// Configuration files which indicate that a backup tool is set up on the host.
+var (
+	pgBackRestConfigPaths = []string{"/etc/pgbackrest.conf", "/etc/pgbackrest/pgbackrest.conf"}
+	barmanConfigPaths     = []string{"/etc/barman.conf", "/etc/barman/barman.conf"}
+)
+
+// backupSettings reads the WAL archiving and recovery settings.
+func (m *PostgresMetrics) backupSettings(ctx context.Context) (map[string]string, error) {
+	rows, err := executeQuery(ctx, m.db, backupSettingsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query backup settings from pg_settings: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from backup settings query")
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var name, setting string
+		if err := rows.Scan(&name, &setting); err != nil {
+			return nil, fmt.Errorf("failed to scan backup setting: %w", err)
+		}
+		settings[name] = setting
+	}
+	return settings, nil
+}
+
+// lastArchivedTime returns the time the most recent WAL file was successfully archived.
+func (m *PostgresMetrics) lastArchivedTime(ctx context.Context) (time.Time, error) {
+	rows, err := executeQuery(ctx, m.db, lastArchivedQuery)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query pg_stat_archiver: %w", err)
+	}
+	if rows == nil {
+		return time.Time{}, errors.New("no rows returned from pg_stat_archiver query")
+	}
+	defer rows.Close()
+
+	var lastArchived sql.NullInt64
+	if rows.Next() {
+		if err := rows.Scan(&lastArchived); err != nil {
+			return time.Time{}, fmt.Errorf("failed to scan last_archived_time: %w", err)
+		}
+	}
+	if !lastArchived.Valid {
+		return time.Time{}, nil
+	}
+	return time.Unix(lastArchived.Int64, 0), nil
+}
+
+// baseBackupRunning reports whether a pg_basebackup client is streaming from the server.
+func (m *PostgresMetrics) baseBackupRunning(ctx context.Context) (bool, error) {
+	rows, err := executeQuery(ctx, m.db, baseBackupClientQuery)
+	if err != nil {
+		return false, fmt.Errorf("failed to query pg_stat_replication: %w", err)
+	}
+	if rows == nil {
+		return false, errors.New("no rows returned from pg_stat_replication query")
+	}
+	defer rows.Close()
+
+	var count int
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return false, fmt.Errorf("failed to scan pg_basebackup client count: %w", err)
+		}
+	}
+	return count > 0, nil
+}
+
+// backupTools returns the backup tools configured for the server, sorted by name.
+func (m *PostgresMetrics) backupTools(ctx context.Context, archiveCommand string) []string {
+	archiveCommand = strings.ToLower(archiveCommand)
+	var tools []string
+	if strings.Contains(archiveCommand, pgBackRest) || anyFileExists(pgBackRestConfigPaths) {
+		tools = append(tools, pgBackRest)
+	}
+	if strings.Contains(archiveCommand, barman) || anyFileExists(barmanConfigPaths) {
+		tools = append(tools, barman)
+	}
+	running, err := m.baseBackupRunning(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to check for pg_basebackup clients", "err", err)
+	}
+	if running {
+		tools = append(tools, pgBaseBackup)
+	}
+	sort.Strings(tools)
+	return tools
+}
+
+func anyFileExists(paths []string) bool {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// backupMetrics returns the WAL archiving, point in time recovery and backup tool configuration.
+// The archive and restore commands are reported by presence only as they may contain credentials.
+func (m *PostgresMetrics) backupMetrics(ctx context.Context) (map[string]string, error) {
+	settings, err := m.backupSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	archiveCommand := settings["archive_command"]
+	// An archive_command of "(disabled)" is reported while archive_mode is off.
+	archiveCommandSet := archiveCommand != "" && archiveCommand != "(disabled)"
+
+	lastArchived := ""
+	archived, err := m.lastArchivedTime(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to get the last archived WAL time", "err", err)
+	}
+	if !archived.IsZero() {
+		lastArchived = archived.UTC().Format(time.RFC3339)
+	}
+
+	metrics := map[string]string{
+		archiveModeKey:           settings["archive_mode"],
+		archiveCommandSetKey:     strconv.FormatBool(archiveCommandSet),
+		restoreCommandSetKey:     strconv.FormatBool(settings["restore_command"] != ""),
+		backupToolsKey:           strings.Join(m.backupTools(ctx, archiveCommand), ","),
+		lastArchivedTimestampKey: lastArchived,
+	}
+	log.CtxLogger(ctx).Debugw("Postgres backup configuration", "metrics", metrics)
+	return metrics, nil
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// settingsRows for name and setting pairs from pg_settings.
+type settingsRows struct {
+	data    [][]string
+	count   int
+	scanErr error
+}
+
+func (m *settingsRows) Next() bool {
+	m.count++
+	return m.count <= len(m.data)
+}
+
+func (m *settingsRows) Scan(dest ...any) error {
+	if m.scanErr != nil {
+		return m.scanErr
+	}
+	*(dest[0].(*string)) = m.data[m.count-1][0]
+	*(dest[1].(*string)) = m.data[m.count-1][1]
+	return nil
+}
+
+func (m *settingsRows) Close() error { return nil }
+
+// archiverRows for the last archived time from pg_stat_archiver.
+type archiverRows struct {
+	value   sql.NullInt64
+	read    bool
+	scanErr error
+}
+
+func (m *archiverRows) Next() bool {
+	if !m.read {
+		m.read = true
+		return true
+	}
+	return false
+}
+
+func (m *archiverRows) Scan(dest ...any) error {
+	if m.scanErr != nil {
+		return m.scanErr
+	}
+	*(dest[0].(*sql.NullInt64)) = m.value
+	return nil
+}
+
+func (m *archiverRows) Close() error { return nil }
+
+func TestBackupMetrics(t *testing.T) {
+	configDir := t.TempDir()
+	pgBackRestConfig := filepath.Join(configDir, "pgbackrest.conf")
+	if err := os.WriteFile(pgBackRestConfig, nil, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", pgBackRestConfig, err)
+	}
+
+	tests := []struct {
+		name             string
+		db               *testDB
+		pgBackRestConfig string
+		want             map[string]string
+		wantErr          bool
+	}{
+		{
+			name: "ArchivingWithPgBackRest",
+			db: &testDB{
+				settingsRows: &settingsRows{data: [][]string{
+					{"archive_command", "pgbackrest --stanza=main archive-push %p"},
+					{"archive_mode", "on"},
+					{"restore_command", ""},
+				}},
+				archiverRows: &archiverRows{value: sql.NullInt64{Int64: 1735787045, Valid: true}},
+			},
+			want: map[string]string{
+				archiveModeKey:           "on",
+				archiveCommandSetKey:     "true",
+				restoreCommandSetKey:     "false",
+				backupToolsKey:           "pgbackrest",
+				lastArchivedTimestampKey: "2025-01-02T03:04:05Z",
+			},
+		},
+		{
+			name: "PgBackRestConfigFileAndBaseBackup",
+			db: &testDB{
+				settingsRows: &settingsRows{data: [][]string{
+					{"archive_command", "(disabled)"},
+					{"archive_mode", "off"},
+					{"restore_command", "cp /archive/%f %p"},
+				}},
+				archiverRows:   &archiverRows{},
+				basebackupRows: &hbaRulesRows{value: 1},
+			},
+			pgBackRestConfig: pgBackRestConfig,
+			want: map[string]string{
+				archiveModeKey:           "off",
+				archiveCommandSetKey:     "false",
+				restoreCommandSetKey:     "true",
+				backupToolsKey:           "pg_basebackup,pgbackrest",
+				lastArchivedTimestampKey: "",
+			},
+		},
+		{
+			name: "BarmanAndArchiverError",
+			db: &testDB{
+				settingsRows: &settingsRows{data: [][]string{
+					{"archive_command", "barman-wal-archive backup-host pg %p"},
+					{"archive_mode", "always"},
+				}},
+				archiverErr:   errors.New("permission denied"),
+				basebackupErr: errors.New("permission denied"),
+			},
+			want: map[string]string{
+				archiveModeKey:           "always",
+				archiveCommandSetKey:     "true",
+				restoreCommandSetKey:     "false",
+				backupToolsKey:           "barman",
+				lastArchivedTimestampKey: "",
+			},
+		},
+		{
+			name:    "SettingsQueryError",
+			db:      &testDB{settingsErr: errors.New("query failed")},
+			wantErr: true,
+		},
+		{
+			name:    "SettingsScanError",
+			db:      &testDB{settingsRows: &settingsRows{data: [][]string{{"archive_mode", "on"}}, scanErr: errors.New("scan failed")}},
+			wantErr: true,
+		},
+		{
+			name:    "NilRows",
+			db:      &testDB{},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			origPgBackRest, origBarman := pgBackRestConfigPaths, barmanConfigPaths
+			defer func() { pgBackRestConfigPaths, barmanConfigPaths = origPgBackRest, origBarman }()
+			pgBackRestConfigPaths = []string{filepath.Join(configDir, "missing.conf"), tc.pgBackRestConfig}
+			barmanConfigPaths = []string{filepath.Join(configDir, "barman.conf")}
+
+			m := &PostgresMetrics{db: tc.db}
+			got, err := m.backupMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("backupMetrics() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("backupMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
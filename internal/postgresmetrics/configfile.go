@@ -0,0 +1,266 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	autoConfigFile = "postgresql.auto.conf"
+	// maxIncludeDepth bounds nested include directives, which may form a cycle.
+	maxIncludeDepth = 10
+)
+
+// Locations of postgresql.conf used by the Debian and Red Hat packages and the Windows installer.
+var (
+	linuxConfigFiles = []string{
+		"/etc/postgresql/*/*/postgresql.conf",
+		"/var/lib/pgsql/*/data/postgresql.conf",
+		"/var/lib/pgsql/data/postgresql.conf",
+		"/var/lib/postgresql/*/main/postgresql.conf",
+	}
+	windowsConfigFiles = []string{`C:\Program Files\PostgreSQL\*\data\postgresql.conf`}
+)
+
+// defaultConfigFiles returns the glob patterns of postgresql.conf based on the operating system.
+func defaultConfigFiles() []string {
+	return capabilities.ByOS(linuxConfigFiles, windowsConfigFiles)
+}
+
+// configFileParser reads the settings of Postgres configuration files.
+type configFileParser struct {
+	settings map[string]string
+	files    []string
+}
+
+// parseSetting returns the name and value of a configuration file line, ok is false for
+// blank and comment lines. Quoted values are unquoted and trailing comments are removed.
+func parseSetting(line string) (name, value string, ok bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || line[0] == '#' {
+		return "", "", false
+	}
+	i := strings.IndexAny(line, "= \t")
+	if i < 0 {
+		return "", "", false
+	}
+	name = strings.ToLower(line[:i])
+	value = strings.TrimLeft(line[i:], " \t")
+	value = strings.TrimLeft(strings.TrimPrefix(value, "="), " \t")
+	if strings.HasPrefix(value, "'") {
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			if value[i] == '\'' {
+				// A quote is escaped by doubling it.
+				if i+1 < len(value) && value[i+1] == '\'' {
+					b.WriteByte('\'')
+					i++
+					continue
+				}
+				break
+			}
+			b.WriteByte(value[i])
+		}
+		return name, b.String(), true
+	}
+	if i := strings.IndexByte(value, '#'); i >= 0 {
+		value = value[:i]
+	}
+	return name, strings.TrimSpace(value), true
+}
+
+// parse reads the settings of the configuration file at path and the files it includes.
+// Relative include paths are resolved against the directory of the including file.
+func (p *configFileParser) parse(path string, depth int) error {
+	if depth > maxIncludeDepth {
+		return fmt.Errorf("configuration files are included more than %d levels deep", maxIncludeDepth)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	p.files = append(p.files, path)
+
+	resolve := func(include string) string {
+		if filepath.IsAbs(include) {
+			return include
+		}
+		return filepath.Join(filepath.Dir(path), include)
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, value, ok := parseSetting(scanner.Text())
+		if !ok {
+			continue
+		}
+		switch name {
+		case "include":
+			if err := p.parse(resolve(value), depth+1); err != nil {
+				return err
+			}
+		case "include_if_exists":
+			if err := p.parse(resolve(value), depth+1); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		case "include_dir":
+			dir := resolve(value)
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				return err
+			}
+			for _, e := range entries {
+				if e.IsDir() || strings.HasPrefix(e.Name(), ".") || filepath.Ext(e.Name()) != ".conf" {
+					continue
+				}
+				if err := p.parse(filepath.Join(dir, e.Name()), depth+1); err != nil {
+					return err
+				}
+			}
+		default:
+			p.settings[name] = value
+		}
+	}
+	return scanner.Err()
+}
+
+// readConfigFile returns the settings of the configuration file at path and of postgresql.auto.conf
+// in the data directory, which takes precedence, together with the files they were read from.
+func readConfigFile(path string) (map[string]string, []string, error) {
+	p := &configFileParser{settings: make(map[string]string)}
+	if err := p.parse(path, 0); err != nil {
+		return nil, nil, fmt.Errorf("reading Postgres configuration file %s: %w", path, err)
+	}
+	autoConf := filepath.Join(dataDirectory(path, p.settings), autoConfigFile)
+	if err := p.parse(autoConf, 0); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, fmt.Errorf("reading Postgres configuration file %s: %w", autoConf, err)
+	}
+	return p.settings, p.files, nil
+}
+
+// dataDirectory returns the data directory of the server using the configuration file at path.
+// Without a data_directory setting the configuration file is located in the data directory.
+func dataDirectory(path string, settings map[string]string) string {
+	if dir := settings["data_directory"]; dir != "" {
+		return filepath.Clean(dir)
+	}
+	return filepath.Dir(path)
+}
+
+// findConfigFile returns the settings of the configuration file of the server listening on port.
+func findConfigFile(ctx context.Context, patterns []string, port string) (string, map[string]string, []string, error) {
+	for _, pattern := range patterns {
+		paths, err := filepath.Glob(pattern)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		for _, path := range paths {
+			settings, files, err := readConfigFile(path)
+			if err != nil {
+				log.CtxLogger(ctx).Debugw("Unable to read Postgres configuration file", "path", path, "error", err)
+				continue
+			}
+			filePort := settings["port"]
+			if filePort == "" {
+				filePort = strconv.Itoa(defaultPort)
+			}
+			if filePort == port {
+				return path, settings, files, nil
+			}
+		}
+	}
+	return "", nil, nil, fmt.Errorf("no Postgres configuration file found for port %s", port)
+}
+
+// configFileMetrics returns the metrics which can be read from the configuration files. Only settings
+// which are set in the files are reported, the server defaults are not assumed.
+func configFileMetrics(path string, settings map[string]string) map[string]string {
+	metrics := map[string]string{
+		workloadmanager.DataDirectoriesKey: dataDirectory(path, settings),
+	}
+	if v, ok := settings["work_mem"]; ok {
+		if workMem, err := units.ParseMemory(v, units.Kilobyte); err == nil {
+			metrics[workMemKey] = strconv.FormatInt(workMem, 10)
+		}
+	}
+	if v, ok := settings["archive_mode"]; ok {
+		metrics[archiveModeKey] = v
+	}
+	if v, ok := settings["archive_command"]; ok {
+		metrics[archiveCommandSetKey] = strconv.FormatBool(v != "")
+	}
+	return metrics
+}
+
+// CollectConfigFileMetricsOnce sends the metrics read from the Postgres configuration files to Data
+// Warehouse. It is used instead of CollectWlmMetricsOnce if the agent could not connect to Postgres,
+// so the insight is labeled as file-derived and holds fewer metrics.
+func (m *PostgresMetrics) CollectConfigFileMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
+	start := time.Now()
+	_, port := m.hostPort()
+	path, settings, files, err := findConfigFile(ctx, m.configFiles, port)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect Postgres metrics from configuration files: %w", err)
+	}
+	metrics := workloadmanager.WorkloadMetrics{
+		WorkloadType:   workloadmanager.POSTGRES,
+		Metrics:        configFileMetrics(path, settings),
+		CollectionTime: start,
+	}
+	metrics.Metrics[workloadmanager.MetricsSourceKey] = workloadmanager.MetricsSourceConfigFile
+	metrics.Metrics[workloadmanager.ConfigFilesKey] = strings.Join(files, ",")
+	if len(m.Config.GetPostgresConfiguration().GetInstances()) > 0 {
+		metrics.Metrics[workloadmanager.DatabaseInstanceKey] = net.JoinHostPort(m.hostPort())
+	}
+	log.CtxLogger(ctx).Debugw("Collected Postgres metrics from configuration files", "files", files, "metrics", metrics.Metrics)
+	metrics.CollectionDuration = time.Since(start)
+	if !dwActivated {
+		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
+		return &metrics, nil
+	}
+	res, err := workloadmanager.SendDataInsight(ctx, workloadmanager.SendDataInsightParams{
+		WLMetrics:           metrics,
+		CloudProps:          m.Config.GetCloudProperties(),
+		WLMService:          m.WLMClient,
+		StrictWorkloadTypes: m.Config.GetDataWarehouseStrictWorkloadTypes(),
+		Schema:              metricSchema,
+	})
+	if err != nil {
+		return &metrics, err
+	}
+	if res == nil {
+		log.CtxLogger(ctx).Warn("SendDataInsight did not return an error but the WriteInsight response is nil")
+		return &metrics, nil
+	}
+	log.CtxLogger(ctx).Debugw("WriteInsight response", "StatusCode", res.HTTPStatusCode)
+	return &metrics, nil
+}
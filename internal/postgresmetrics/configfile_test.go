@@ -0,0 +1,259 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	gcefake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/fake"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("os.MkdirAll(%s) returned unexpected error: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+}
+
+func TestParseSetting(t *testing.T) {
+	tests := []struct {
+		line      string
+		wantName  string
+		wantValue string
+		wantOK    bool
+	}{
+		{line: "work_mem = 8MB", wantName: "work_mem", wantValue: "8MB", wantOK: true},
+		{line: "port=5433  # (change requires restart)", wantName: "port", wantValue: "5433", wantOK: true},
+		{line: "Archive_Mode on", wantName: "archive_mode", wantValue: "on", wantOK: true},
+		{line: "archive_command = 'test ! -f /mnt/%f && cp %p /mnt/%f # it''s' # comment", wantName: "archive_command", wantValue: "test ! -f /mnt/%f && cp %p /mnt/%f # it's", wantOK: true},
+		{line: "   # work_mem = 4MB"},
+		{line: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.line, func(t *testing.T) {
+			name, value, ok := parseSetting(tc.line)
+			if name != tc.wantName || value != tc.wantValue || ok != tc.wantOK {
+				t.Errorf("parseSetting(%q) = (%q, %q, %v), want (%q, %q, %v)", tc.line, name, value, ok, tc.wantName, tc.wantValue, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestFindConfigFile(t *testing.T) {
+	root := t.TempDir()
+	// A Debian cluster on the default port with the data directory outside of the configuration directory.
+	debianConf := filepath.Join(root, "etc", "16", "main", "postgresql.conf")
+	debianData := filepath.Join(root, "lib", "16", "main")
+	writeFile(t, debianConf, fmt.Sprintf("data_directory = '%s'\ninclude_dir = 'conf.d'\nwork_mem = 4MB\n", debianData))
+	writeFile(t, filepath.Join(root, "etc", "16", "main", "conf.d", "tuning.conf"), "work_mem = 16MB\n")
+	writeFile(t, filepath.Join(debianData, autoConfigFile), "work_mem = '32MB'\n")
+	// A Red Hat cluster with the configuration files in the data directory.
+	redHatConf := filepath.Join(root, "pgsql", "data", "postgresql.conf")
+	writeFile(t, redHatConf, "port = 5433\ninclude_if_exists = 'missing.conf'\narchive_mode = on\n")
+
+	tests := []struct {
+		name         string
+		port         string
+		wantPath     string
+		wantSettings map[string]string
+		wantFiles    []string
+		wantErr      bool
+	}{
+		{
+			name:     "AutoConfTakesPrecedence",
+			port:     "5432",
+			wantPath: debianConf,
+			wantSettings: map[string]string{
+				"data_directory": debianData,
+				"work_mem":       "32MB",
+			},
+			wantFiles: []string{
+				debianConf,
+				filepath.Join(root, "etc", "16", "main", "conf.d", "tuning.conf"),
+				filepath.Join(debianData, autoConfigFile),
+			},
+		},
+		{
+			name:         "MatchingPort",
+			port:         "5433",
+			wantPath:     redHatConf,
+			wantSettings: map[string]string{"port": "5433", "archive_mode": "on"},
+			wantFiles:    []string{redHatConf},
+		},
+		{
+			name:    "NoMatchingPort",
+			port:    "5434",
+			wantErr: true,
+		},
+	}
+	patterns := []string{filepath.Join(root, "etc", "*", "*", "postgresql.conf"), filepath.Join(root, "pgsql", "data", "postgresql.conf")}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path, settings, files, err := findConfigFile(context.Background(), patterns, tc.port)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("findConfigFile(%s) returned error: %v, want error: %v", tc.port, err, tc.wantErr)
+			}
+			if path != tc.wantPath {
+				t.Errorf("findConfigFile(%s) returned path %q, want %q", tc.port, path, tc.wantPath)
+			}
+			if diff := cmp.Diff(tc.wantSettings, settings); diff != "" {
+				t.Errorf("findConfigFile(%s) returned unexpected diff (-want +got):\n%s", tc.port, diff)
+			}
+			if diff := cmp.Diff(tc.wantFiles, files); diff != "" {
+				t.Errorf("findConfigFile(%s) returned unexpected files diff (-want +got):\n%s", tc.port, diff)
+			}
+		})
+	}
+}
+
+func TestConfigFileMetrics(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]string
+		want     map[string]string
+	}{
+		{
+			name: "AllSettings",
+			settings: map[string]string{
+				"data_directory":  "/var/lib/postgresql/16/main/",
+				"work_mem":        "8MB",
+				"archive_mode":    "on",
+				"archive_command": "cp %p /mnt/%f",
+			},
+			want: map[string]string{
+				workloadmanager.DataDirectoriesKey: "/var/lib/postgresql/16/main",
+				workMemKey:                         "8388608",
+				archiveModeKey:                     "on",
+				archiveCommandSetKey:               "true",
+			},
+		},
+		{
+			name:     "WorkMemWithoutUnit",
+			settings: map[string]string{"work_mem": "64", "archive_command": ""},
+			want: map[string]string{
+				workloadmanager.DataDirectoriesKey: "/var/lib/pgsql/data",
+				workMemKey:                         "65536",
+				archiveCommandSetKey:               "false",
+			},
+		},
+		{
+			name:     "InvalidWorkMem",
+			settings: map[string]string{"work_mem": "lots"},
+			want:     map[string]string{workloadmanager.DataDirectoriesKey: "/var/lib/pgsql/data"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := configFileMetrics("/var/lib/pgsql/data/postgresql.conf", tc.settings)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("configFileMetrics(%v) returned unexpected diff (-want +got):\n%s", tc.settings, diff)
+			}
+			for name, value := range got {
+				if d, ok := metricSchema.Definition(name); !ok {
+					t.Errorf("configFileMetrics(%v) returned metric %s which is not defined in the schema", tc.settings, name)
+				} else if err := d.Validate(value); err != nil {
+					t.Errorf("configFileMetrics(%v) returned an invalid metric: %v", tc.settings, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCollectConfigFileMetricsOnce(t *testing.T) {
+	dataDir := t.TempDir()
+	conf := filepath.Join(dataDir, "postgresql.conf")
+	writeFile(t, conf, "work_mem = 1kB\n")
+	newMetrics := func(configFiles []string) *PostgresMetrics {
+		return &PostgresMetrics{
+			Config:      &configpb.Configuration{PostgresConfiguration: &configpb.PostgresConfiguration{}},
+			configFiles: configFiles,
+			WLMClient: &gcefake.TestWLM{
+				WriteInsightErrs: []error{nil},
+				WriteInsightResponses: []*wlm.WriteInsightResponse{
+					&wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		m           *PostgresMetrics
+		dwActivated bool
+		want        map[string]string
+		wantWrites  int
+		wantErr     bool
+	}{
+		{
+			name:        "Success",
+			m:           newMetrics([]string{conf}),
+			dwActivated: true,
+			want: map[string]string{
+				workMemKey:                         "1024",
+				workloadmanager.DataDirectoriesKey: dataDir,
+				workloadmanager.MetricsSourceKey:   workloadmanager.MetricsSourceConfigFile,
+				workloadmanager.ConfigFilesKey:     conf,
+			},
+			wantWrites: 1,
+		},
+		{
+			name:        "NoConfigFile",
+			m:           newMetrics(nil),
+			dwActivated: true,
+			wantErr:     true,
+		},
+		{
+			name: "DataWarehouseNotActivated",
+			m:    newMetrics([]string{conf}),
+			want: map[string]string{
+				workMemKey:                         "1024",
+				workloadmanager.DataDirectoriesKey: dataDir,
+				workloadmanager.MetricsSourceKey:   workloadmanager.MetricsSourceConfigFile,
+				workloadmanager.ConfigFilesKey:     conf,
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.m.CollectConfigFileMetricsOnce(context.Background(), tc.dwActivated)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("CollectConfigFileMetricsOnce() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			var gotMetrics map[string]string
+			if got != nil {
+				gotMetrics = got.Metrics
+			}
+			if diff := cmp.Diff(tc.want, gotMetrics); diff != "" {
+				t.Errorf("CollectConfigFileMetricsOnce() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if got := tc.m.WLMClient.(*gcefake.TestWLM).WriteInsightCallCount; got != tc.wantWrites {
+				t.Errorf("CollectConfigFileMetricsOnce() wrote %d insights, want %d", got, tc.wantWrites)
+			}
+		})
+	}
+}
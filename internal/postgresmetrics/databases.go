@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	defaultDatabase = "postgres"
+	databasesQuery  = "SELECT datname FROM pg_database WHERE datallowconn AND NOT datistemplate"
+)
+
+// databaseNames returns the databases which accept connections, excluding templates.
+func (m *PostgresMetrics) databaseNames(ctx context.Context) ([]string, error) {
+	rows, err := executeQuery(ctx, m.db, databasesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pg_database: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from pg_database query")
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan database name: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// quoteDSNValue quotes a value for use in a key/value connection string.
+func quoteDSNValue(v string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(v, `\`, `\\`), `'`, `\'`) + "'"
+}
+
+// forEachDatabase calls fn with a connection to each database which accepts connections.
+// Catalogs such as pg_extension and pg_stat_user_tables are local to a database, so a short
+// lived connection is opened to each database other than the one the agent is connected to.
+// Databases which can't be connected to are skipped.
+func (m *PostgresMetrics) forEachDatabase(ctx context.Context, fn func(name string, db dbInterface)) error {
+	names, err := m.databaseNames(ctx)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == defaultDatabase {
+			fn(name, m.db)
+			continue
+		}
+		// Later keys take precedence in the connection string.
+		db, err := m.connect(ctx, fmt.Sprintf("%s dbname=%s", m.dataSource, quoteDSNValue(name)))
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Failed to connect to Postgres database", "database", name, "err", err)
+			continue
+		}
+		fn(name, db)
+		if c, ok := db.(io.Closer); ok {
+			c.Close()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// databaseRows for single column results such as database names.
+type databaseRows struct {
+	data  []string
+	count int
+}
+
+func (m *databaseRows) Next() bool {
+	m.count++
+	return m.count <= len(m.data)
+}
+
+func (m *databaseRows) Scan(dest ...any) error {
+	*(dest[0].(*string)) = m.data[m.count-1]
+	return nil
+}
+
+func (m *databaseRows) Close() error { return nil }
+
+func TestQuoteDSNValue(t *testing.T) {
+	if got, want := quoteDSNValue(`it's a \db`), `'it\'s a \\db'`; got != want {
+		t.Errorf("quoteDSNValue() = %s, want %s", got, want)
+	}
+}
+
+func TestForEachDatabaseClosesConnections(t *testing.T) {
+	appDB := &testDB{}
+	m := &PostgresMetrics{
+		db:         &testDB{databasesRows: &databaseRows{data: []string{"postgres", "app"}}},
+		dataSource: "dataSource",
+		connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+			return appDB, nil
+		},
+	}
+
+	var visited []string
+	if err := m.forEachDatabase(context.Background(), func(name string, db dbInterface) {
+		visited = append(visited, name)
+	}); err != nil {
+		t.Fatalf("forEachDatabase() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"postgres", "app"}, visited); diff != "" {
+		t.Errorf("forEachDatabase() visited unexpected databases (-want +got):\n%s", diff)
+	}
+	if !appDB.closed {
+		t.Error("forEachDatabase() did not close the connection to database app")
+	}
+	if m.db.(*testDB).closed {
+		t.Error("forEachDatabase() closed the agent's connection to database postgres")
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+)
+
+const dataDirectoryQuery = "SHOW data_directory"
+
+// dataDirectoryMetrics returns the data directory (PGDATA) of the server.
+// Its disk layout is added to the insight by the WLM writer.
+func (m *PostgresMetrics) dataDirectoryMetrics(ctx context.Context) (map[string]string, error) {
+	rows, err := executeQuery(ctx, m.db, dataDirectoryQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query data_directory: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from data_directory query")
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, errors.New("no rows returned from data_directory query")
+	}
+	var dataDir string
+	if err := rows.Scan(&dataDir); err != nil {
+		return nil, fmt.Errorf("failed to scan data_directory: %w", err)
+	}
+	return map[string]string{workloadmanager.DataDirectoriesKey: filepath.Clean(dataDir)}, nil
+}
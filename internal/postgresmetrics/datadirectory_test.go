@@ -0,0 +1,73 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+)
+
+func TestDataDirectoryMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			db:   &testDB{dataDirectoryRows: &genericMockRows{value: "/var/lib/postgresql/16/main/"}},
+			want: map[string]string{workloadmanager.DataDirectoriesKey: "/var/lib/postgresql/16/main"},
+		},
+		{
+			name:    "QueryError",
+			db:      &testDB{dataDirectoryErr: errors.New("query failed")},
+			wantErr: true,
+		},
+		{
+			name:    "NoRows",
+			db:      &testDB{},
+			wantErr: true,
+		},
+		{
+			name:    "EmptyRows",
+			db:      &testDB{dataDirectoryRows: &genericMockRows{read: true}},
+			wantErr: true,
+		},
+		{
+			name:    "ScanError",
+			db:      &testDB{dataDirectoryRows: &genericMockRows{scanErr: errors.New("scan failed")}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{db: tc.db}
+			got, err := m.dataDirectoryMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("dataDirectoryMetrics() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("dataDirectoryMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	extensionsKey   = "extensions"
+	extensionsQuery = "SELECT extname, extversion FROM pg_extension"
+)
+
+// installedExtensions returns the version of each extension installed in the database db is connected to.
+func installedExtensions(ctx context.Context, db dbInterface) (map[string]string, error) {
+	rows, err := executeQuery(ctx, db, extensionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pg_extension: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from pg_extension query")
+	}
+	defer rows.Close()
+
+	extensions := make(map[string]string)
+	for rows.Next() {
+		var name, version string
+		if err := rows.Scan(&name, &version); err != nil {
+			return nil, fmt.Errorf("failed to scan extension: %w", err)
+		}
+		extensions[name] = version
+	}
+	return extensions, nil
+}
+
+// extensions returns the installed extensions and their versions for each database.
+// Databases which can't be read are skipped.
+func (m *PostgresMetrics) extensions(ctx context.Context) (map[string]map[string]string, error) {
+	extensions := make(map[string]map[string]string)
+	err := m.forEachDatabase(ctx, func(name string, db dbInterface) {
+		installed, err := installedExtensions(ctx, db)
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Failed to list Postgres extensions", "database", name, "err", err)
+			return
+		}
+		extensions[name] = installed
+	})
+	if err != nil {
+		return nil, err
+	}
+	return extensions, nil
+}
+
+// extensionMetrics returns the installed extensions per database as a JSON object,
+// for example {"postgres":{"pg_stat_statements":"1.10","plpgsql":"1.0"}}.
+func (m *PostgresMetrics) extensionMetrics(ctx context.Context) (map[string]string, error) {
+	extensions, err := m.extensions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	extensionsJSON, err := json.Marshal(extensions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal extensions: %w", err)
+	}
+	log.CtxLogger(ctx).Debugw("Postgres extensions", "extensions", string(extensionsJSON))
+	return map[string]string{extensionsKey: string(extensionsJSON)}, nil
+}
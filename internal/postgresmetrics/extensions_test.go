@@ -0,0 +1,103 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtensionMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		db        *testDB
+		databases map[string]*testDB
+		connErr   error
+		want      map[string]string
+		wantErr   bool
+	}{
+		{
+			name: "MultipleDatabases",
+			db: &testDB{
+				databasesRows:  &databaseRows{data: []string{"postgres", "app db"}},
+				extensionsRows: &settingsRows{data: [][]string{{"plpgsql", "1.0"}}},
+			},
+			databases: map[string]*testDB{
+				"dataSource dbname='app db'": &testDB{
+					extensionsRows: &settingsRows{data: [][]string{{"plpgsql", "1.0"}, {"postgis", "3.4.2"}, {"pg_stat_statements", "1.10"}}},
+				},
+			},
+			want: map[string]string{
+				extensionsKey: `{"app db":{"pg_stat_statements":"1.10","plpgsql":"1.0","postgis":"3.4.2"},"postgres":{"plpgsql":"1.0"}}`,
+			},
+		},
+		{
+			name: "DatabaseConnectionErrorIsSkipped",
+			db: &testDB{
+				databasesRows:  &databaseRows{data: []string{"postgres", "restricted"}},
+				extensionsRows: &settingsRows{data: [][]string{{"pgaudit", "16.0"}}},
+			},
+			connErr: errors.New("permission denied for database restricted"),
+			want: map[string]string{
+				extensionsKey: `{"postgres":{"pgaudit":"16.0"}}`,
+			},
+		},
+		{
+			name: "ExtensionQueryErrorIsSkipped",
+			db: &testDB{
+				databasesRows: &databaseRows{data: []string{"postgres"}},
+				extensionsErr: errors.New("query failed"),
+			},
+			want: map[string]string{
+				extensionsKey: `{}`,
+			},
+		},
+		{
+			name:    "DatabasesQueryError",
+			db:      &testDB{databasesErr: errors.New("query failed")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{
+				db:         tc.db,
+				dataSource: "dataSource",
+				connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+					if tc.connErr != nil {
+						return nil, tc.connErr
+					}
+					db, ok := tc.databases[dataSource]
+					if !ok {
+						t.Fatalf("connect() called with unexpected data source %q", dataSource)
+					}
+					return db, nil
+				},
+			}
+			got, err := m.extensionMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("extensionMetrics() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("extensionMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
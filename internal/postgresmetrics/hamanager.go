@@ -0,0 +1,289 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+)
+
+const (
+	haManagerKey        = "ha_manager"
+	haManagerVersionKey = "ha_manager_version"
+	haClusterNameKey    = "ha_cluster_name"
+	haLeaderKey         = "ha_leader"
+	haMembersKey        = "ha_members"
+	haHealthyMembersKey = "ha_healthy_members"
+
+	patroni           = "patroni"
+	repmgr            = "repmgr"
+	defaultPatroniURL = "http://localhost:8008"
+)
+
+// patroniClient queries the Patroni REST API.
+var patroniClient = &http.Client{Timeout: 10 * time.Second}
+
+// processInfo holds the attributes of a running process used to detect the HA manager.
+type processInfo struct {
+	name    string
+	cmdline []string
+}
+
+// listProcesses returns the processes running on the host.
+// Processes which exit while they are being listed are skipped.
+func listProcesses(ctx context.Context) ([]processInfo, error) {
+	ps, err := process.ProcessesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var processes []processInfo
+	for _, p := range ps {
+		name, err := p.NameWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		cmdline, err := p.CmdlineSliceWithContext(ctx)
+		if err != nil {
+			continue
+		}
+		processes = append(processes, processInfo{name: name, cmdline: cmdline})
+	}
+	return processes, nil
+}
+
+// haManager returns the HA manager running on the host, patroni or repmgr, or "" if there is none.
+// For repmgr, the configuration file repmgrd was started with is also returned.
+// Patroni runs as a Python script, so it is also detected by the script in its command line.
+func haManager(processes []processInfo) (string, string) {
+	for _, p := range processes {
+		if p.name == patroni || (len(p.cmdline) > 1 && filepath.Base(p.cmdline[1]) == patroni) {
+			return patroni, ""
+		}
+		if p.name == "repmgrd" {
+			return repmgr, repmgrConfigFile(p.cmdline)
+		}
+	}
+	return "", ""
+}
+
+// repmgrConfigFile returns the configuration file passed with -f or --config-file, or "" if
+// repmgr searches the default locations.
+func repmgrConfigFile(cmdline []string) string {
+	for i, arg := range cmdline {
+		switch {
+		case (arg == "-f" || arg == "--config-file") && i+1 < len(cmdline):
+			return cmdline[i+1]
+		case strings.HasPrefix(arg, "--config-file="):
+			return strings.TrimPrefix(arg, "--config-file=")
+		}
+	}
+	return ""
+}
+
+// haMember is a member of the cluster managed by the HA manager.
+type haMember struct {
+	name    string
+	role    string
+	state   string
+	healthy bool
+}
+
+// haMemberMetrics returns the leader, the members as name:role:state and the number of healthy members.
+func haMemberMetrics(members []haMember, leaderRole string) map[string]string {
+	metrics := map[string]string{haLeaderKey: ""}
+	var list []string
+	healthy := 0
+	for _, member := range members {
+		list = append(list, fmt.Sprintf("%s:%s:%s", member.name, member.role, member.state))
+		if member.healthy {
+			healthy++
+		}
+		if member.role == leaderRole && member.healthy {
+			metrics[haLeaderKey] = member.name
+		}
+	}
+	metrics[haMembersKey] = strings.Join(list, ",")
+	metrics[haHealthyMembersKey] = strconv.Itoa(healthy)
+	return metrics
+}
+
+// patroniStatus is the response of the /patroni endpoint.
+type patroniStatus struct {
+	Patroni struct {
+		Version string `json:"version"`
+		Scope   string `json:"scope"`
+	} `json:"patroni"`
+}
+
+// patroniCluster is the response of the /cluster endpoint.
+type patroniCluster struct {
+	Members []struct {
+		Name  string `json:"name"`
+		Role  string `json:"role"`
+		State string `json:"state"`
+	} `json:"members"`
+}
+
+func getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := patroniClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %s", url, res.Status)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// patroniMetrics returns the Patroni version, its cluster scope and the members of the cluster.
+// Members which are running or streaming are healthy.
+func (m *PostgresMetrics) patroniMetrics(ctx context.Context) (map[string]string, error) {
+	url := strings.TrimSuffix(m.Config.GetPostgresConfiguration().GetPatroniUrl(), "/")
+	if url == "" {
+		url = defaultPatroniURL
+	}
+	var status patroniStatus
+	if err := getJSON(ctx, url+"/patroni", &status); err != nil {
+		return nil, fmt.Errorf("failed to get Patroni status: %w", err)
+	}
+	var cluster patroniCluster
+	if err := getJSON(ctx, url+"/cluster", &cluster); err != nil {
+		return nil, fmt.Errorf("failed to get Patroni cluster: %w", err)
+	}
+	var members []haMember
+	for _, member := range cluster.Members {
+		members = append(members, haMember{
+			name:    member.Name,
+			role:    member.Role,
+			state:   member.State,
+			healthy: member.State == "running" || member.State == "streaming",
+		})
+	}
+	metrics := haMemberMetrics(members, "leader")
+	metrics[haManagerVersionKey] = status.Patroni.Version
+	metrics[haClusterNameKey] = status.Patroni.Scope
+	return metrics, nil
+}
+
+// repmgrMetrics returns the repmgr version and the nodes listed by repmgr cluster show.
+// repmgr has no cluster name. Nodes are healthy if they are running as their registered role.
+func (m *PostgresMetrics) repmgrMetrics(ctx context.Context, configFile string) (map[string]string, error) {
+	args := []string{"cluster", "show"}
+	if configFile != "" {
+		args = append([]string{"-f", configFile}, args...)
+	}
+	res := m.execute(ctx, commandlineexecutor.Params{Executable: "repmgr", Args: args})
+	if res.Error != nil {
+		return nil, fmt.Errorf("failed to run repmgr cluster show: %w", res.Error)
+	}
+	members, err := parseRepmgrClusterShow(res.StdOut)
+	if err != nil {
+		return nil, err
+	}
+	metrics := haMemberMetrics(members, "primary")
+	metrics[haManagerVersionKey] = ""
+	if res := m.execute(ctx, commandlineexecutor.Params{Executable: "repmgr", Args: []string{"--version"}}); res.Error == nil {
+		metrics[haManagerVersionKey] = strings.TrimPrefix(strings.TrimSpace(res.StdOut), "repmgr ")
+	}
+	return metrics, nil
+}
+
+// parseRepmgrClusterShow parses the table printed by repmgr cluster show. The status of a node is
+// prefixed with * for a running primary, ! if it is running as a different role than registered,
+// - if it failed and ? if it is unreachable.
+func parseRepmgrClusterShow(out string) ([]haMember, error) {
+	var header []string
+	var members []haMember
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(strings.TrimSpace(line), "-") {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		if header == nil {
+			header = fields
+			continue
+		}
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(fields) {
+				row[column] = fields[i]
+			}
+		}
+		status := row["Status"]
+		state := strings.TrimSpace(strings.TrimLeft(status, "*!-? "))
+		members = append(members, haMember{
+			name:    row["Name"],
+			role:    row["Role"],
+			state:   state,
+			healthy: state == "running" && !strings.HasPrefix(status, "!"),
+		})
+	}
+	if header == nil {
+		return nil, errors.New("repmgr cluster show returned no output")
+	}
+	return members, nil
+}
+
+// haManagerMetrics returns the details of the HA manager running on the host.
+// It returns nil if no HA manager is running.
+func (m *PostgresMetrics) haManagerMetrics(ctx context.Context) (map[string]string, error) {
+	if m.listProcesses == nil {
+		return nil, nil
+	}
+	processes, err := m.listProcesses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", err)
+	}
+	manager, configFile := haManager(processes)
+	var metrics map[string]string
+	switch manager {
+	case patroni:
+		metrics, err = m.patroniMetrics(ctx)
+	case repmgr:
+		metrics, err = m.repmgrMetrics(ctx, configFile)
+	default:
+		return nil, nil
+	}
+	if metrics == nil {
+		metrics = make(map[string]string)
+	}
+	metrics[haManagerKey] = manager
+	return metrics, err
+}
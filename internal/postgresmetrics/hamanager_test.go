@@ -0,0 +1,227 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const repmgrClusterShow = ` ID | Name  | Role    | Status        | Upstream | Location | Priority | Timeline | Connection string
+----+-------+---------+---------------+----------+----------+----------+----------+--------------------------------------
+ 1  | node1 | primary | * running     |          | default  | 100      | 1        | host=node1 dbname=repmgr user=repmgr
+ 2  | node2 | standby |   running     | node1    | default  | 100      | 1        | host=node2 dbname=repmgr user=repmgr
+ 3  | node3 | standby | ? unreachable | ? node1  | default  | 100      |          | host=node3 dbname=repmgr user=repmgr
+`
+
+func TestHAManager(t *testing.T) {
+	tests := []struct {
+		name           string
+		processes      []processInfo
+		wantManager    string
+		wantConfigFile string
+	}{
+		{
+			name:      "None",
+			processes: []processInfo{{name: "postgres", cmdline: []string{"/usr/lib/postgresql/16/bin/postgres"}}},
+		},
+		{
+			name:        "Patroni",
+			processes:   []processInfo{{name: "patroni", cmdline: []string{"/usr/bin/patroni", "/etc/patroni.yml"}}},
+			wantManager: patroni,
+		},
+		{
+			name:        "PatroniScript",
+			processes:   []processInfo{{name: "python3", cmdline: []string{"/usr/bin/python3", "/usr/local/bin/patroni", "/etc/patroni.yml"}}},
+			wantManager: patroni,
+		},
+		{
+			name:           "Repmgr",
+			processes:      []processInfo{{name: "repmgrd", cmdline: []string{"repmgrd", "-f", "/etc/repmgr/16/repmgr.conf", "--daemonize"}}},
+			wantManager:    repmgr,
+			wantConfigFile: "/etc/repmgr/16/repmgr.conf",
+		},
+		{
+			name:           "RepmgrLongOption",
+			processes:      []processInfo{{name: "repmgrd", cmdline: []string{"repmgrd", "--config-file=/etc/repmgr.conf"}}},
+			wantManager:    repmgr,
+			wantConfigFile: "/etc/repmgr.conf",
+		},
+		{
+			name:        "RepmgrDefaultConfigFile",
+			processes:   []processInfo{{name: "repmgrd", cmdline: []string{"repmgrd"}}},
+			wantManager: repmgr,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotManager, gotConfigFile := haManager(tc.processes)
+			if gotManager != tc.wantManager || gotConfigFile != tc.wantConfigFile {
+				t.Errorf("haManager() = (%q, %q), want (%q, %q)", gotManager, gotConfigFile, tc.wantManager, tc.wantConfigFile)
+			}
+		})
+	}
+}
+
+func TestParseRepmgrClusterShow(t *testing.T) {
+	want := []haMember{
+		{name: "node1", role: "primary", state: "running", healthy: true},
+		{name: "node2", role: "standby", state: "running", healthy: true},
+		{name: "node3", role: "standby", state: "unreachable", healthy: false},
+	}
+	got, err := parseRepmgrClusterShow(repmgrClusterShow)
+	if err != nil {
+		t.Fatalf("parseRepmgrClusterShow() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(haMember{})); diff != "" {
+		t.Errorf("parseRepmgrClusterShow() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestPatroniMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/patroni":
+			w.Write([]byte(`{"state": "running", "role": "primary", "server_version": 160002, "patroni": {"version": "3.2.2", "scope": "demo", "name": "pg1"}}`))
+		case "/cluster":
+			w.Write([]byte(`{"members": [
+  {"name": "pg1", "role": "leader", "state": "running", "host": "10.0.0.1", "port": 5432, "timeline": 1},
+  {"name": "pg2", "role": "sync_standby", "state": "streaming", "host": "10.0.0.2", "port": 5432, "timeline": 1, "lag": 0},
+  {"name": "pg3", "role": "replica", "state": "stopped", "host": "10.0.0.3", "port": 5432}
+]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		url     string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Cluster",
+			url:  server.URL + "/",
+			want: map[string]string{
+				haManagerVersionKey: "3.2.2",
+				haClusterNameKey:    "demo",
+				haLeaderKey:         "pg1",
+				haMembersKey:        "pg1:leader:running,pg2:sync_standby:streaming,pg3:replica:stopped",
+				haHealthyMembersKey: "2",
+			},
+		},
+		{
+			name:    "NotFound",
+			url:     server.URL + "/missing",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{Config: &configpb.Configuration{
+				PostgresConfiguration: &configpb.PostgresConfiguration{PatroniUrl: tc.url},
+			}}
+			got, err := m.patroniMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("patroniMetrics() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("patroniMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHAManagerMetrics(t *testing.T) {
+	tests := []struct {
+		name      string
+		processes []processInfo
+		listErr   error
+		execute   commandlineexecutor.Execute
+		want      map[string]string
+		wantErr   bool
+	}{
+		{
+			name:      "NoHAManager",
+			processes: []processInfo{{name: "postgres"}},
+		},
+		{
+			name:      "Repmgr",
+			processes: []processInfo{{name: "repmgrd", cmdline: []string{"repmgrd", "-f", "/etc/repmgr.conf"}}},
+			execute: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				if cmp.Equal(params.Args, []string{"-f", "/etc/repmgr.conf", "cluster", "show"}) {
+					return commandlineexecutor.Result{StdOut: repmgrClusterShow}
+				}
+				if cmp.Equal(params.Args, []string{"--version"}) {
+					return commandlineexecutor.Result{StdOut: "repmgr 5.4.1\n"}
+				}
+				return commandlineexecutor.Result{Error: errors.New("unexpected command")}
+			},
+			want: map[string]string{
+				haManagerKey:        repmgr,
+				haManagerVersionKey: "5.4.1",
+				haLeaderKey:         "node1",
+				haMembersKey:        "node1:primary:running,node2:standby:running,node3:standby:unreachable",
+				haHealthyMembersKey: "2",
+			},
+		},
+		{
+			name:      "RepmgrError",
+			processes: []processInfo{{name: "repmgrd"}},
+			execute: func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+				return commandlineexecutor.Result{Error: errors.New("repmgr: command not found")}
+			},
+			want:    map[string]string{haManagerKey: repmgr},
+			wantErr: true,
+		},
+		{
+			name:    "ListError",
+			listErr: errors.New("permission denied"),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{
+				execute: tc.execute,
+				listProcesses: func(ctx context.Context) ([]processInfo, error) {
+					return tc.processes, tc.listErr
+				},
+			}
+			got, err := m.haManagerMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("haManagerMetrics() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("haManagerMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
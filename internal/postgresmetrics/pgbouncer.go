@@ -0,0 +1,191 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/connectionsecret"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	pgBouncerVersionKey   = "pgbouncer_version"
+	pgBouncerDatabasesKey = "pgbouncer_databases"
+	pgBouncerBackendsKey  = "pgbouncer_backends"
+	pgBouncerPoolSizesKey = "pgbouncer_pool_sizes"
+	pgBouncerPoolModesKey = "pgbouncer_pool_modes"
+	pgBouncerDefaultPort  = 6432
+	// pgBouncerAdminDatabase is the pseudo database of the admin console, it has no backend.
+	pgBouncerAdminDatabase = "pgbouncer"
+
+	// The admin console only supports the simple query protocol, which lib/pq uses for queries
+	// without arguments. The columns of SHOW DATABASES differ between PgBouncer versions.
+	pgBouncerVersionQuery   = "SHOW VERSION"
+	pgBouncerDatabasesQuery = "SHOW DATABASES"
+)
+
+// columnsInterface is implemented by rows which report their column names, such as sql.Rows.
+type columnsInterface interface {
+	Columns() ([]string, error)
+}
+
+// initPgBouncer connects to the admin console of the configured PgBouncer instance.
+// Like InitDB, it falls back to an unencrypted connection if PgBouncer doesn't support SSL.
+func (m *PostgresMetrics) initPgBouncer(ctx context.Context, gceService GceInterface) error {
+	cp, err := connectionsecret.Resolve(ctx, m.Config.GetPostgresConfiguration().GetPgbouncer(), gceService)
+	if err != nil {
+		return fmt.Errorf("resolving PgBouncer connection secret: %w", err)
+	}
+	pw, err := connectionsecret.Password(ctx, cp, gceService)
+	if err != nil {
+		return fmt.Errorf("initializing PgBouncer password: %w", err)
+	}
+	host := cp.GetHost()
+	if host == "" {
+		host = "localhost"
+	}
+	port := cp.GetPort()
+	if port == 0 {
+		port = pgBouncerDefaultPort
+	}
+	user := cp.GetUsername()
+	if user == "" {
+		user = pgBouncerAdminDatabase
+	}
+	// PgBouncer rejects unknown startup parameters, so the read-only parameter of InitDB is not set.
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s", host, port, user, pw.SecretValue(), pgBouncerAdminDatabase)
+
+	var errs []error
+	for _, dataSource := range []string{dsn, dsn + " sslmode=disable"} {
+		db, err := m.connect(ctx, dataSource)
+		if err != nil {
+			return fmt.Errorf("connecting to PgBouncer: %w", secretredact.Error(err, dsn))
+		}
+		// The admin console doesn't answer the empty query sent by Ping.
+		if _, err := pgBouncerVersion(ctx, db); err != nil {
+			errs = append(errs, secretredact.Error(err, dsn))
+			continue
+		}
+		m.pgBouncer = db
+		return nil
+	}
+	return fmt.Errorf("failed to query PgBouncer admin console: %w", errors.Join(errs...))
+}
+
+func pgBouncerVersion(ctx context.Context, db dbInterface) (string, error) {
+	rows, err := executeQuery(ctx, db, pgBouncerVersionQuery)
+	if err != nil {
+		return "", err
+	}
+	if rows == nil {
+		return "", errors.New("no rows returned from PgBouncer version query")
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return "", errors.New("no rows returned from PgBouncer version query")
+	}
+	var version string
+	if err := rows.Scan(&version); err != nil {
+		return "", fmt.Errorf("failed to scan PgBouncer version: %w", err)
+	}
+	return version, nil
+}
+
+// pgBouncerDatabases returns the rows of SHOW DATABASES keyed by column name.
+func pgBouncerDatabases(ctx context.Context, db dbInterface) ([]map[string]string, error) {
+	rows, err := executeQuery(ctx, db, pgBouncerDatabasesQuery)
+	if err != nil {
+		return nil, err
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from PgBouncer databases query")
+	}
+	defer rows.Close()
+	cr, ok := rows.(columnsInterface)
+	if !ok {
+		return nil, errors.New("column names of PgBouncer databases are not available")
+	}
+	columns, err := cr.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var databases []map[string]string
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan PgBouncer database: %w", err)
+		}
+		database := make(map[string]string, len(columns))
+		for i, column := range columns {
+			database[column] = values[i].String
+		}
+		databases = append(databases, database)
+	}
+	return databases, nil
+}
+
+// pgBouncerMetrics returns the version of the PgBouncer instance in front of Postgres and the
+// databases it pools connections for: their backends as name=host:port/database, their pool sizes
+// as name:size and the pool modes they override the default pool mode with.
+func (m *PostgresMetrics) pgBouncerMetrics(ctx context.Context) (map[string]string, error) {
+	version, err := pgBouncerVersion(ctx, m.pgBouncer)
+	if err != nil {
+		return nil, err
+	}
+	databases, err := pgBouncerDatabases(ctx, m.pgBouncer)
+	if err != nil {
+		return map[string]string{pgBouncerVersionKey: version}, err
+	}
+	var backends, poolSizes, poolModes []string
+	for _, d := range databases {
+		if d["name"] == pgBouncerAdminDatabase {
+			continue
+		}
+		// Databases without a host connect over the Unix socket of the local server.
+		host := d["host"]
+		if host == "" {
+			host = "localhost"
+		}
+		backends = append(backends, fmt.Sprintf("%s=%s/%s", d["name"], net.JoinHostPort(host, d["port"]), d["database"]))
+		poolSizes = append(poolSizes, d["name"]+":"+d["pool_size"])
+		if mode := d["pool_mode"]; mode != "" && !slices.Contains(poolModes, mode) {
+			poolModes = append(poolModes, mode)
+		}
+	}
+	slices.Sort(poolModes)
+	log.CtxLogger(ctx).Debugw("Collected PgBouncer topology", "version", version, "databases", len(backends))
+	return map[string]string{
+		pgBouncerVersionKey:   version,
+		pgBouncerDatabasesKey: strconv.Itoa(len(backends)),
+		pgBouncerBackendsKey:  strings.Join(backends, ","),
+		pgBouncerPoolSizesKey: strings.Join(poolSizes, ","),
+		pgBouncerPoolModesKey: strings.Join(poolModes, ","),
+	}, nil
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	gcefake "github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/fake"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// showRows is the result of a SHOW command of the PgBouncer admin console.
+type showRows struct {
+	columns []string
+	rows    [][]string
+	count   int
+}
+
+func (r *showRows) Columns() ([]string, error) {
+	return r.columns, nil
+}
+
+func (r *showRows) Next() bool {
+	r.count++
+	return r.count <= len(r.rows)
+}
+
+func (r *showRows) Scan(dest ...any) error {
+	for i, v := range r.rows[r.count-1] {
+		switch d := dest[i].(type) {
+		case *string:
+			*d = v
+		case *sql.NullString:
+			*d = sql.NullString{String: v, Valid: v != ""}
+		}
+	}
+	return nil
+}
+
+func (r *showRows) Close() error {
+	return nil
+}
+
+// pgBouncerDB answers the PgBouncer admin console queries.
+type pgBouncerDB struct {
+	databases    *showRows
+	databasesErr error
+	versionErr   error
+}
+
+func (d *pgBouncerDB) QueryContext(ctx context.Context, query string, args ...any) (rowsInterface, error) {
+	switch query {
+	case pgBouncerVersionQuery:
+		return &showRows{columns: []string{"version"}, rows: [][]string{{"PgBouncer 1.21.0"}}}, d.versionErr
+	case pgBouncerDatabasesQuery:
+		return d.databases, d.databasesErr
+	}
+	return nil, nil
+}
+
+func (d *pgBouncerDB) Ping() error {
+	return nil
+}
+
+func TestPgBouncerMetrics(t *testing.T) {
+	columns := []string{"name", "host", "port", "database", "force_user", "pool_size", "min_pool_size", "reserve_pool", "pool_mode", "max_connections", "current_connections"}
+	tests := []struct {
+		name    string
+		db      *pgBouncerDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Topology",
+			db: &pgBouncerDB{databases: &showRows{columns: columns, rows: [][]string{
+				{"app", "10.0.0.5", "5432", "app", "", "20", "0", "0", "transaction", "0", "3"},
+				{"reports", "", "5432", "app", "", "5", "0", "0", "", "0", "1"},
+				{"pgbouncer", "", "6432", "pgbouncer", "pgbouncer", "2", "0", "0", "statement", "0", "0"},
+			}}},
+			want: map[string]string{
+				pgBouncerVersionKey:   "PgBouncer 1.21.0",
+				pgBouncerDatabasesKey: "2",
+				pgBouncerBackendsKey:  "app=10.0.0.5:5432/app,reports=localhost:5432/app",
+				pgBouncerPoolSizesKey: "app:20,reports:5",
+				pgBouncerPoolModesKey: "transaction",
+			},
+		},
+		{
+			name: "NoDatabases",
+			db:   &pgBouncerDB{databases: &showRows{columns: columns}},
+			want: map[string]string{
+				pgBouncerVersionKey:   "PgBouncer 1.21.0",
+				pgBouncerDatabasesKey: "0",
+				pgBouncerBackendsKey:  "",
+				pgBouncerPoolSizesKey: "",
+				pgBouncerPoolModesKey: "",
+			},
+		},
+		{
+			name:    "DatabasesError",
+			db:      &pgBouncerDB{databasesErr: errors.New("query failed")},
+			want:    map[string]string{pgBouncerVersionKey: "PgBouncer 1.21.0"},
+			wantErr: true,
+		},
+		{
+			name:    "VersionError",
+			db:      &pgBouncerDB{versionErr: errors.New("query failed")},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{pgBouncer: tc.db}
+			got, err := m.pgBouncerMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("pgBouncerMetrics() returned error %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("pgBouncerMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInitDBPgBouncer(t *testing.T) {
+	tests := []struct {
+		name          string
+		pgBouncer     *configpb.ConnectionParameters
+		pgBouncerDB   *pgBouncerDB
+		wantDSN       string
+		wantPgBouncer bool
+	}{
+		{
+			name:          "DefaultAddress",
+			pgBouncer:     &configpb.ConnectionParameters{Password: "pw"},
+			pgBouncerDB:   &pgBouncerDB{},
+			wantDSN:       "host=localhost port=6432 user=pgbouncer password=pw dbname=pgbouncer",
+			wantPgBouncer: true,
+		},
+		{
+			name:          "ConfiguredAddress",
+			pgBouncer:     &configpb.ConnectionParameters{Username: "stats", Password: "pw", Host: "10.0.0.9", Port: 16432},
+			pgBouncerDB:   &pgBouncerDB{},
+			wantDSN:       "host=10.0.0.9 port=16432 user=stats password=pw dbname=pgbouncer",
+			wantPgBouncer: true,
+		},
+		{
+			name:          "QueryErrorIsNotFatal",
+			pgBouncer:     &configpb.ConnectionParameters{Password: "pw"},
+			pgBouncerDB:   &pgBouncerDB{versionErr: errors.New("pq: SSL is not enabled on the server")},
+			wantDSN:       "host=localhost port=6432 user=pgbouncer password=pw dbname=pgbouncer sslmode=disable",
+			wantPgBouncer: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var pgBouncerDSN string
+			m := PostgresMetrics{
+				Config: &configpb.Configuration{
+					PostgresConfiguration: &configpb.PostgresConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{Username: "test-user", Password: "fake-password"},
+						Pgbouncer:            tc.pgBouncer,
+					},
+				},
+				connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+					if strings.Contains(dataSource, "dbname=pgbouncer") {
+						pgBouncerDSN = dataSource
+						return tc.pgBouncerDB, nil
+					}
+					return emptyDB, nil
+				},
+			}
+
+			if err := m.InitDB(context.Background(), &gcefake.TestGCE{}); err != nil {
+				t.Fatalf("InitDB() returned unexpected error: %v", err)
+			}
+			if pgBouncerDSN != tc.wantDSN {
+				t.Errorf("InitDB() connected to PgBouncer with DSN %q, want %q", pgBouncerDSN, tc.wantDSN)
+			}
+			if gotPgBouncer := m.pgBouncer != nil; gotPgBouncer != tc.wantPgBouncer {
+				t.Errorf("InitDB() set the PgBouncer connection: %t, want %t", gotPgBouncer, tc.wantPgBouncer)
+			}
+		})
+	}
+}
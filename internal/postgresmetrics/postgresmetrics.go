@@ -22,12 +22,25 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	// Register the pq driver for Postgres with the database/sql package.
 	_ "github.com/lib/pq"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/connectionsecret"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/healthprobe"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/hostinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlguard"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
@@ -36,10 +49,8 @@ import (
 )
 
 const (
-	workMemKey = "work_mem"
-	kilobyte   = 1024
-	megabyte   = 1024 * 1024
-	gigabyte   = 1024 * 1024 * 1024
+	workMemKey  = "work_mem"
+	defaultPort = 5432
 )
 
 // GceInterface defines an interface for gce.GCEClient to allow faking
@@ -71,14 +82,48 @@ func (d dbWrapper) Ping() error {
 	return d.db.Ping()
 }
 
+func (d dbWrapper) Close() error {
+	return d.db.Close()
+}
+
 // PostgresMetrics contains variables and methods to collect metrics for Postgres databases running on the current host.
 type PostgresMetrics struct {
-	execute        commandlineexecutor.Execute
-	Config         *configpb.Configuration
-	db             dbInterface
+	execute commandlineexecutor.Execute
+	Config  *configpb.Configuration
+	db      dbInterface
+	// pgBouncer is the connection to the PgBouncer admin console, nil if PgBouncer is not configured.
+	pgBouncer      dbInterface
 	connect        func(ctx context.Context, dataSource string) (dbInterface, error)
 	WLMClient      workloadmanager.WLMWriter
 	DBcenterClient databasecenter.Client
+	// dataSource is the connection string used by InitDB, it is reused to connect to the other databases.
+	dataSource string
+	// hasClientCert is set when connections present a TLS client certificate.
+	hasClientCert bool
+	listProcesses func(ctx context.Context) ([]processInfo, error)
+	// configFiles are the glob patterns of the configuration files read by CollectConfigFileMetricsOnce
+	// if the agent cannot connect to Postgres.
+	configFiles []string
+	// statements holds the pg_stat_statements statistics read by the previous collection.
+	statements  *statementSnapshot
+	totalMemory func(ctx context.Context) (int64, error)
+}
+
+// resolveConnectionSecret replaces the connection parameters with the ones in their secret
+// if it is a JSON connection secret. The configuration is copied, not modified.
+func (m *PostgresMetrics) resolveConnectionSecret(ctx context.Context, gceService GceInterface) error {
+	cp := m.Config.GetPostgresConfiguration().GetConnectionParameters()
+	resolved, err := connectionsecret.Resolve(ctx, cp, gceService)
+	if err != nil {
+		return fmt.Errorf("resolving connection secret: %w", err)
+	}
+	if resolved == cp {
+		return nil
+	}
+	config := proto.Clone(m.Config).(*configpb.Configuration)
+	config.PostgresConfiguration.ConnectionParameters = resolved
+	m.Config = config
+	return nil
 }
 
 // password gets the password for the Postgres database.
@@ -115,16 +160,34 @@ func (m *PostgresMetrics) dbDSN(ctx context.Context, gceService GceInterface) (s
 	if err != nil {
 		return "", fmt.Errorf("initializing password: %w", err)
 	}
-	user := m.Config.GetPostgresConfiguration().GetConnectionParameters().GetUsername()
+	cp := m.Config.GetPostgresConfiguration().GetConnectionParameters()
+	user := cp.GetUsername()
 	if user == "" {
 		user = "postgres"
 	}
-	host := "localhost"
-	port := "5432"
-	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=postgres", host, port, user, pw.SecretValue())
+	host, port := m.hostPort()
+	// All queries issued by the agent run in read-only transactions.
+	psqlInfo := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=postgres %s=%s", host, port, user, pw.SecretValue(), sqlguard.PostgresReadOnlyParam, sqlguard.PostgresReadOnlyValue)
+	if params := sslParams(m.Config.GetPostgresConfiguration().GetTls(), m.hasClientCert); params != "" {
+		psqlInfo += " " + params
+	}
 	return psqlInfo, nil
 }
 
+// hostPort returns the host and port of the Postgres instance metrics are collected from.
+func (m *PostgresMetrics) hostPort() (string, string) {
+	cp := m.Config.GetPostgresConfiguration().GetConnectionParameters()
+	host := cp.GetHost()
+	if host == "" {
+		host = "localhost"
+	}
+	port := cp.GetPort()
+	if port == 0 {
+		port = defaultPort
+	}
+	return host, strconv.Itoa(int(port))
+}
+
 // New creates a new PostgresMetrics object initialized with default values.
 func New(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, dbcenterClient databasecenter.Client) *PostgresMetrics {
 	return &PostgresMetrics{
@@ -133,47 +196,111 @@ func New(ctx context.Context, config *configpb.Configuration, wlmClient workload
 		connect:        defaultConnect,
 		WLMClient:      wlmClient,
 		DBcenterClient: dbcenterClient,
+		listProcesses:  listProcesses,
+		configFiles:    defaultConfigFiles(),
+		totalMemory:    hostinfo.TotalMemoryBytes,
 	}
 }
 
 // InitDB initializes the Postgres database connection.
 func (m *PostgresMetrics) InitDB(ctx context.Context, gceService GceInterface) error {
+	if err := m.resolveConnectionSecret(ctx, gceService); err != nil {
+		return agenterrors.New(agenterrors.Authentication, err)
+	}
+	if err := m.initTLS(ctx, gceService); err != nil {
+		return agenterrors.New(agenterrors.Authentication, fmt.Errorf("configuring TLS: %w", err))
+	}
 	dbDSN, err := m.dbDSN(ctx, gceService)
 	if err != nil {
-		return fmt.Errorf("getting dbDSN: %w", err)
+		return agenterrors.New(agenterrors.Authentication, fmt.Errorf("getting dbDSN: %w", err))
 	}
 	db, err := m.connect(ctx, dbDSN)
 	if err != nil {
-		return fmt.Errorf("connecting to Postgres: %w", err)
+		return agenterrors.New(agenterrors.Connection, fmt.Errorf("connecting to Postgres: %w", secretredact.Error(err, dbDSN)))
 	}
 	m.db = db
+	m.dataSource = dbDSN
 	err = m.db.Ping()
+	if err != nil && m.Config.GetPostgresConfiguration().GetTls().GetEnabled() {
+		// A configured TLS connection is never downgraded.
+		return agenterrors.New(agenterrors.Connection, fmt.Errorf("failed to ping Postgres connection: %w", secretredact.Error(err, dbDSN)))
+	}
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("Failed to ping Postgres connection, trying to connect without SSL")
 		db, err = m.connect(ctx, fmt.Sprintf("%s sslmode=disable", dbDSN))
 		if err != nil {
-			return fmt.Errorf("connecting to Postgres without SSL: %w", err)
+			return agenterrors.New(agenterrors.Connection, fmt.Errorf("connecting to Postgres without SSL: %w", secretredact.Error(err, dbDSN)))
 		}
 		m.db = db
+		m.dataSource = fmt.Sprintf("%s sslmode=disable", dbDSN)
 		err = m.db.Ping()
 		if err != nil {
-			return fmt.Errorf("failed to ping Postgres connection: %w", err)
+			return agenterrors.New(agenterrors.Connection, fmt.Errorf("failed to ping Postgres connection: %w", secretredact.Error(err, dbDSN)))
 		}
 	}
 	log.CtxLogger(ctx).Debugw("Postgres connection ping success")
+	if m.Config.GetPostgresConfiguration().GetPgbouncer() != nil {
+		// The Postgres metrics are still collected if PgBouncer can't be reached.
+		if err := m.initPgBouncer(ctx, gceService); err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to connect to the PgBouncer admin console", "error", err)
+		}
+	}
+
+	return nil
+}
 
+// Ping runs a trivial query to verify that the connection opened by InitDB can execute queries.
+func (m *PostgresMetrics) Ping(ctx context.Context) error {
+	rows, err := executeQuery(ctx, m.db, "SELECT 1")
+	if err != nil {
+		return fmt.Errorf("running test query: %w", err)
+	}
+	if rows != nil {
+		rows.Close()
+	}
 	return nil
 }
 
+// HealthProbeTarget returns the health probe target of the Postgres instance.
+// The handshake opens a new connection with the connection string used by InitDB.
+func (m *PostgresMetrics) HealthProbeTarget() healthprobe.Target {
+	return healthprobe.Target{
+		WorkloadType: workloadmanager.POSTGRES,
+		Address:      net.JoinHostPort(m.hostPort()),
+		Handshake: func(ctx context.Context) error {
+			if m.dataSource == "" {
+				return errors.New("no Postgres connection string")
+			}
+			db, err := m.connect(ctx, m.dataSource)
+			if err != nil {
+				return secretredact.Error(err, m.dataSource)
+			}
+			if c, ok := db.(io.Closer); ok {
+				defer c.Close()
+			}
+			return secretredact.Error(db.Ping(), m.dataSource)
+		},
+		Query: func(ctx context.Context) error {
+			if m.db == nil {
+				return errors.New("not connected to Postgres")
+			}
+			return m.Ping(ctx)
+		},
+	}
+}
+
 func executeQuery(ctx context.Context, db dbInterface, query string) (rowsInterface, error) {
-	return db.QueryContext(ctx, query)
+	start := time.Now()
+	rows, err := db.QueryContext(ctx, query)
+	querystats.Observe("postgres", query, time.Since(start), err)
+	return rows, err
 }
 
 func (m *PostgresMetrics) getWorkMem(ctx context.Context) (int, error) {
 	// Default value is "4MB". Minimum value is "64KB".
 	rows, err := executeQuery(ctx, m.db, "SHOW work_mem")
 	if err != nil {
-		return 0, fmt.Errorf("issue trying to show work_mem: %w", err)
+		return 0, agenterrors.New(agenterrors.Query, fmt.Errorf("issue trying to show work_mem: %w", err))
 	}
 	log.CtxLogger(ctx).Debugw("Postgres show work_mem result", "rows", rows)
 	defer rows.Close()
@@ -185,29 +312,13 @@ func (m *PostgresMetrics) getWorkMem(ctx context.Context) (int, error) {
 		return 0, err
 	}
 
-	var unit string
-	var multiplier int
-	workMemLower := strings.ToLower(workMem)
-	if strings.Contains(workMemLower, "kb") {
-		unit = "kb"
-		multiplier = kilobyte
-	} else if strings.Contains(workMemLower, "mb") {
-		unit = "mb"
-		multiplier = megabyte
-	} else if strings.Contains(workMemLower, "gb") {
-		unit = "gb"
-		multiplier = gigabyte
-	} else {
-		return 0, fmt.Errorf("unknown units in work_mem: %s", workMem)
-	}
-	workMemMagnitude, err := strconv.Atoi(strings.ReplaceAll(workMemLower, unit, ""))
+	workMemBytes, err := units.ParseMemory(workMem, units.Kilobyte)
 	if err != nil {
-		return 0, err
+		return 0, agenterrors.New(agenterrors.Parse, err)
 	}
-	workMemBytes := workMemMagnitude * multiplier
 
-	log.CtxLogger(ctx).Debugw("Postgres getWorkMem", "workMem", workMem, "workMemMagnitude", workMemMagnitude, "unit", unit, "workMemBytes", workMemBytes)
-	return workMemBytes, nil
+	log.CtxLogger(ctx).Debugw("Postgres getWorkMem", "workMem", workMem, "workMemBytes", workMemBytes)
+	return int(workMemBytes), nil
 }
 
 // Get Version of Postgres
@@ -343,11 +454,12 @@ func (m *PostgresMetrics) exposedToPublicAccess(ctx context.Context) (bool, erro
 }
 
 // CollectWlmMetricsOnce collects metrics for Postgres databases running on the host.
+// Failures of single metrics or collection steps are recorded in the Errors of the returned metrics
+// and the metrics which were collected are still sent. An error is returned if the metrics could not
+// be collected at all or could not be sent, in the latter case together with the collected metrics.
+// The metrics are collected but not sent if Data Warehouse is not activated.
 func (m *PostgresMetrics) CollectWlmMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
-	if !dwActivated {
-		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
-		return nil, nil
-	}
+	start := time.Now()
 	workMemBytes, err := m.getWorkMem(ctx)
 	if err != nil {
 		log.CtxLogger(ctx).Warnf("Failed to get work mem: %w", err)
@@ -360,14 +472,80 @@ func (m *PostgresMetrics) CollectWlmMetricsOnce(ctx context.Context, dwActivated
 		Metrics: map[string]string{
 			workMemKey: strconv.Itoa(workMemBytes),
 		},
+		CollectionTime: start,
+	}
+	if len(m.Config.GetPostgresConfiguration().GetInstances()) > 0 {
+		metrics.Metrics[workloadmanager.DatabaseInstanceKey] = net.JoinHostPort(m.hostPort())
+	}
+	memoryMetrics, err := m.memoryMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get max_connections and host memory", "error", err)
+	}
+	metrics.AddMetrics("memory", memoryMetrics, err)
+	extensionMetrics, err := m.extensionMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get installed extensions", "error", err)
+	}
+	metrics.AddMetrics("extensions", extensionMetrics, err)
+	vacuumMetrics, err := m.vacuumMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get vacuum health", "error", err)
+	}
+	metrics.AddMetrics("vacuum", vacuumMetrics, err)
+	if !m.Config.GetPostgresConfiguration().GetStatements().GetDisabled() {
+		statementMetrics, err := m.statementMetrics(ctx)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to summarize pg_stat_statements", "error", err)
+		}
+		metrics.AddMetrics("statements", statementMetrics, err)
+	}
+	backupMetrics, err := m.backupMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get backup configuration", "error", err)
+	}
+	metrics.AddMetrics("backup", backupMetrics, err)
+	replicationMetrics, err := m.replicationMetrics(ctx, ipinfo.DefaultZoneResolver())
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get replication zones", "error", err)
+	}
+	metrics.AddMetrics("replication", replicationMetrics, err)
+	lagMetrics, err := m.replicationLagMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get replication lag", "error", err)
+	}
+	metrics.AddMetrics("replication_lag", lagMetrics, err)
+	dataDirectoryMetrics, err := m.dataDirectoryMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get data directory", "error", err)
+	}
+	metrics.AddMetrics("data_directories", dataDirectoryMetrics, err)
+	haMetrics, err := m.haManagerMetrics(ctx)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to get HA manager details", "error", err)
+	}
+	metrics.AddMetrics("ha_manager", haMetrics, err)
+	if m.pgBouncer != nil {
+		pgBouncerMetrics, err := m.pgBouncerMetrics(ctx)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to get PgBouncer topology", "error", err)
+		}
+		metrics.AddMetrics("pgbouncer", pgBouncerMetrics, err)
+	}
+	metrics.AddRecommendations(ctx, recommendations(metrics.Metrics))
+	metrics.CollectionDuration = time.Since(start)
+	if !dwActivated {
+		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
+		return &metrics, nil
 	}
 	res, err := workloadmanager.SendDataInsight(ctx, workloadmanager.SendDataInsightParams{
-		WLMetrics:  metrics,
-		CloudProps: m.Config.GetCloudProperties(),
-		WLMService: m.WLMClient,
+		WLMetrics:           metrics,
+		CloudProps:          m.Config.GetCloudProperties(),
+		WLMService:          m.WLMClient,
+		StrictWorkloadTypes: m.Config.GetDataWarehouseStrictWorkloadTypes(),
+		Schema:              metricSchema,
 	})
 	if err != nil {
-		return nil, err
+		return &metrics, err
 	}
 	if res == nil {
 		log.CtxLogger(ctx).Warn("SendDataInsight did not return an error but the WriteInsight response is nil")
@@ -398,6 +576,7 @@ func (m *PostgresMetrics) CollectDBCenterMetricsOnce(ctx context.Context) error
 	}
 	// Send metadata details to database center
 	err = m.DBcenterClient.SendMetadataToDatabaseCenter(ctx, databasecenter.DBCenterMetrics{EngineType: databasecenter.POSTGRES,
+		Instance: net.JoinHostPort(m.hostPort()),
 		Metrics: map[string]string{
 			databasecenter.MajorVersionKey:             majorVersion,
 			databasecenter.MinorVersionKey:             minorVersion,
@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
@@ -36,17 +37,45 @@ import (
 )
 
 type testDB struct {
-	workMemRows    rowsInterface
-	workMemErr     error
-	versionRows    rowsInterface
-	versionErr     error
-	pingErr        error
-	pgauditLogRows rowsInterface
-	pgauditLogErr  error
-	sslRows        rowsInterface
-	sslErr         error
-	hbaRulesRows   rowsInterface
-	hbaRulesErr    error
+	workMemRows       rowsInterface
+	workMemErr        error
+	versionRows       rowsInterface
+	versionErr        error
+	pingErr           error
+	pgauditLogRows    rowsInterface
+	pgauditLogErr     error
+	sslRows           rowsInterface
+	sslErr            error
+	hbaRulesRows      rowsInterface
+	hbaRulesErr       error
+	settingsRows      rowsInterface
+	settingsErr       error
+	archiverRows      rowsInterface
+	archiverErr       error
+	basebackupRows    rowsInterface
+	basebackupErr     error
+	databasesRows     rowsInterface
+	databasesErr      error
+	extensionsRows    rowsInterface
+	extensionsErr     error
+	xidAgeRows        rowsInterface
+	xidAgeErr         error
+	tablesRows        rowsInterface
+	tablesErr         error
+	standbyRows       rowsInterface
+	standbyErr        error
+	standbyLagRows    rowsInterface
+	standbyLagErr     error
+	dataDirectoryRows rowsInterface
+	dataDirectoryErr  error
+	statementsRows    rowsInterface
+	statementsErr     error
+	statementsQuery   string
+	resetRows         rowsInterface
+	resetErr          error
+	maxConnRows       rowsInterface
+	maxConnErr        error
+	closed            bool
 }
 
 var emptyDB = &testDB{}
@@ -64,6 +93,46 @@ func (t *testDB) QueryContext(ctx context.Context, query string, args ...any) (r
 	if query == "SHOW pgaudit.log" {
 		return t.pgauditLogRows, t.pgauditLogErr
 	}
+	if query == datfrozenxidAgeQuery {
+		return t.xidAgeRows, t.xidAgeErr
+	}
+	if query == largestTablesQuery {
+		return t.tablesRows, t.tablesErr
+	}
+	if query == databasesQuery {
+		return t.databasesRows, t.databasesErr
+	}
+	if query == extensionsQuery {
+		return t.extensionsRows, t.extensionsErr
+	}
+	if query == backupSettingsQuery {
+		return t.settingsRows, t.settingsErr
+	}
+	if query == lastArchivedQuery {
+		return t.archiverRows, t.archiverErr
+	}
+	if query == baseBackupClientQuery {
+		return t.basebackupRows, t.basebackupErr
+	}
+	if query == standbyHostsQuery {
+		return t.standbyRows, t.standbyErr
+	}
+	if query == standbyLagQuery {
+		return t.standbyLagRows, t.standbyLagErr
+	}
+	if query == dataDirectoryQuery {
+		return t.dataDirectoryRows, t.dataDirectoryErr
+	}
+	if query == maxConnectionsQuery {
+		return t.maxConnRows, t.maxConnErr
+	}
+	if strings.Contains(query, "FROM pg_stat_statements WHERE") {
+		t.statementsQuery = query
+		return t.statementsRows, t.statementsErr
+	}
+	if query == statementsResetQuery {
+		return t.resetRows, t.resetErr
+	}
 	if strings.Contains(query, "FROM pg_hba_file_rules()") {
 		return t.hbaRulesRows, t.hbaRulesErr
 	}
@@ -74,6 +143,11 @@ func (t *testDB) Ping() error {
 	return t.pingErr
 }
 
+func (t *testDB) Close() error {
+	t.closed = true
+	return nil
+}
+
 type workMemRows struct {
 	count     int
 	size      int
@@ -644,6 +718,18 @@ func TestExposedToPublicAccess_Postgres(t *testing.T) {
 	}
 }
 
+// stepErrorsWithoutRows are the collection step errors of a database returning no rows for their queries.
+var stepErrorsWithoutRows = map[string]string{
+	"backup":           "no rows returned from backup settings query",
+	"data_directories": "no rows returned from data_directory query",
+	"extensions":       "no rows returned from pg_database query",
+	"memory":           "no rows returned from max_connections query",
+	"replication":      "no rows returned from pg_stat_replication query",
+	"replication_lag":  "no rows returned from standby lag query",
+	"statements":       "no rows returned from pg_extension query",
+	"vacuum":           "no rows returned from datfrozenxid age query",
+}
+
 func TestCollectWlmMetricsOnce(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -671,6 +757,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 				Metrics: map[string]string{
 					workMemKey: strconv.Itoa(80 * 1024 * 1024),
 				},
+				Errors: stepErrorsWithoutRows,
 			},
 			wantErr: false,
 		},
@@ -694,6 +781,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 				Metrics: map[string]string{
 					workMemKey: strconv.Itoa(64 * 1024),
 				},
+				Errors: stepErrorsWithoutRows,
 			},
 			wantErr: false,
 		},
@@ -717,6 +805,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 				Metrics: map[string]string{
 					workMemKey: strconv.Itoa(4 * 1024 * 1024 * 1024),
 				},
+				Errors: stepErrorsWithoutRows,
 			},
 			wantErr: false,
 		},
@@ -779,6 +868,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 				Metrics: map[string]string{
 					workMemKey: strconv.Itoa(64 * 1024 * 1024),
 				},
+				Errors: stepErrorsWithoutRows,
 			},
 			wantErr: false,
 		},
@@ -794,7 +884,7 @@ func TestCollectWlmMetricsOnce(t *testing.T) {
 			}
 			continue
 		}
-		if diff := cmp.Diff(tc.wantMetrics, gotMetrics, protocmp.Transform()); diff != "" {
+		if diff := cmp.Diff(tc.wantMetrics, gotMetrics, protocmp.Transform(), cmpopts.IgnoreFields(workloadmanager.WorkloadMetrics{}, "CollectionTime", "CollectionDuration")); diff != "" {
 			t.Errorf("CollectMetricsOnce(%v) returned diff (-want +got):\n%s", tc.name, diff)
 		}
 	}
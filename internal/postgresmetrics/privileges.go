@@ -0,0 +1,56 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// monitorRoleQuery reports whether the current user can read the statistics granted by pg_monitor.
+const monitorRoleQuery = `SELECT current_user, rolsuper OR pg_has_role(current_user, 'pg_monitor', 'MEMBER')
+FROM pg_roles WHERE rolname = current_user`
+
+// VerifyPrivileges returns an error if the configured user is neither a superuser nor a member of
+// pg_monitor, without which the statistics of other sessions and the replication views are hidden.
+func (m *PostgresMetrics) VerifyPrivileges(ctx context.Context) error {
+	rows, err := executeQuery(ctx, m.db, monitorRoleQuery)
+	if err != nil {
+		// pg_monitor was added in Postgres 10, older servers are not checked.
+		log.CtxLogger(ctx).Debugw("Could not verify Postgres privileges", "error", err)
+		return nil
+	}
+	if rows == nil {
+		return nil
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil
+	}
+	var user string
+	var monitor bool
+	if err := rows.Scan(&user, &monitor); err != nil {
+		log.CtxLogger(ctx).Debugw("Could not verify Postgres privileges", "error", err)
+		return nil
+	}
+	if monitor {
+		return nil
+	}
+	return fmt.Errorf("the Postgres user %q is missing privileges needed by the agent, run 'GRANT pg_monitor TO %s' or create a user with 'configure postgres create-monitoring-user'", user, user)
+}
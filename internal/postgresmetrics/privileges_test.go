@@ -0,0 +1,98 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// monitorRoleRows returns a single row with the user and its pg_monitor membership.
+type monitorRoleRows struct {
+	user    string
+	monitor bool
+	read    bool
+}
+
+func (r *monitorRoleRows) Next() bool {
+	if r.read {
+		return false
+	}
+	r.read = true
+	return true
+}
+
+func (r *monitorRoleRows) Scan(dest ...any) error {
+	*dest[0].(*string) = r.user
+	*dest[1].(*bool) = r.monitor
+	return nil
+}
+
+func (r *monitorRoleRows) Close() error {
+	return nil
+}
+
+// monitorRoleDB answers monitorRoleQuery.
+type monitorRoleDB struct {
+	testDB
+	rows rowsInterface
+	err  error
+}
+
+func (d *monitorRoleDB) QueryContext(ctx context.Context, query string, args ...any) (rowsInterface, error) {
+	if query == monitorRoleQuery {
+		return d.rows, d.err
+	}
+	return d.testDB.QueryContext(ctx, query, args...)
+}
+
+func TestVerifyPrivileges(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *monitorRoleDB
+		wantErr bool
+	}{
+		{
+			name: "MonitorMember",
+			db:   &monitorRoleDB{rows: &monitorRoleRows{user: "workloadagent", monitor: true}},
+		},
+		{
+			name:    "NotMonitorMember",
+			db:      &monitorRoleDB{rows: &monitorRoleRows{user: "workloadagent"}},
+			wantErr: true,
+		},
+		{
+			name: "QueryErrorIsIgnored",
+			db:   &monitorRoleDB{err: errors.New(`role "pg_monitor" does not exist`)},
+		},
+		{
+			name: "NoRows",
+			db:   &monitorRoleDB{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{db: tc.db}
+			err := m.VerifyPrivileges(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("VerifyPrivileges() returned error %v, want error: %v", err, tc.wantErr)
+			}
+		})
+	}
+}
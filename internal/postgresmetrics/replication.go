@@ -0,0 +1,104 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+)
+
+const (
+	replicationZonesKey = "replication_zones"
+	// The lag of a standby, both are only reported on standbys.
+	replicationLagBytesKey   = "replication_replay_lag_bytes"
+	replicationLagSecondsKey = "replication_lag_seconds"
+
+	// Standbys connected over a Unix socket have no client address, they run on this host.
+	// Base backups also stream from pg_stat_replication but are not standbys.
+	standbyHostsQuery = "SELECT COALESCE(client_hostname, host(client_addr)) FROM pg_stat_replication WHERE client_addr IS NOT NULL AND application_name <> 'pg_basebackup'"
+
+	// standbyLagQuery returns no rows on a primary. The WAL received but not yet replayed is the
+	// lag in bytes. The time since the last replayed transaction is only lag while WAL is waiting to
+	// be replayed, an idle primary sends no transactions.
+	standbyLagQuery = `SELECT
+  COALESCE(pg_wal_lsn_diff(pg_last_wal_receive_lsn(), pg_last_wal_replay_lsn()), 0)::bigint,
+  CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+    ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END::bigint
+WHERE pg_is_in_recovery()`
+)
+
+// standbyHosts returns the host names, or addresses if unknown, of the standbys streaming from the server.
+func (m *PostgresMetrics) standbyHosts(ctx context.Context) ([]string, error) {
+	rows, err := executeQuery(ctx, m.db, standbyHostsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pg_stat_replication: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from pg_stat_replication query")
+	}
+	defer rows.Close()
+
+	var hosts []string
+	for rows.Next() {
+		var host string
+		if err := rows.Scan(&host); err != nil {
+			return nil, fmt.Errorf("failed to scan standby host: %w", err)
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// replicationMetrics returns the zones of the standbys streaming from the server.
+func (m *PostgresMetrics) replicationMetrics(ctx context.Context, zoneResolver *ipinfo.ZoneResolver) (map[string]string, error) {
+	hosts, err := m.standbyHosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		replicationZonesKey: strings.Join(zoneResolver.Zones(ctx, hosts), ","),
+	}, nil
+}
+
+// replicationLagMetrics returns how far a standby is behind its primary in bytes of WAL and in
+// seconds. No metrics are returned on a primary.
+func (m *PostgresMetrics) replicationLagMetrics(ctx context.Context) (map[string]string, error) {
+	rows, err := executeQuery(ctx, m.db, standbyLagQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query standby lag: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from standby lag query")
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, nil
+	}
+	var lagBytes, lagSeconds int64
+	if err := rows.Scan(&lagBytes, &lagSeconds); err != nil {
+		return nil, fmt.Errorf("failed to scan standby lag: %w", err)
+	}
+	return map[string]string{
+		replicationLagBytesKey:   strconv.FormatInt(lagBytes, 10),
+		replicationLagSecondsKey: strconv.FormatInt(lagSeconds, 10),
+	}, nil
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+)
+
+// standbyRows for the standby hosts from pg_stat_replication.
+type standbyRows struct {
+	data    []string
+	count   int
+	scanErr error
+}
+
+func (m *standbyRows) Next() bool {
+	m.count++
+	return m.count <= len(m.data)
+}
+
+func (m *standbyRows) Scan(dest ...any) error {
+	if m.scanErr != nil {
+		return m.scanErr
+	}
+	*(dest[0].(*string)) = m.data[m.count-1]
+	return nil
+}
+
+func (m *standbyRows) Close() error { return nil }
+
+// fakeResolver maps IPs to their host names and resolves no host names.
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupHost(host string) ([]string, error) {
+	return nil, errors.New("no such host")
+}
+
+func (f fakeResolver) ParseIP(ip string) net.IP {
+	return net.ParseIP(ip)
+}
+
+func (f fakeResolver) LookupAddr(ip string) ([]string, error) {
+	return f[ip], nil
+}
+
+func TestReplicationMetrics(t *testing.T) {
+	resolver := fakeResolver{
+		"10.0.0.2": {"standby-1.us-central1-b.c.fake-project.internal."},
+		"10.0.0.3": {"standby-2.us-central1-c.c.fake-project.internal."},
+	}
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Standbys",
+			db:   &testDB{standbyRows: &standbyRows{data: []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"}}},
+			want: map[string]string{replicationZonesKey: "us-central1-b,us-central1-c"},
+		},
+		{
+			name: "NoStandbys",
+			db:   &testDB{standbyRows: &standbyRows{}},
+			want: map[string]string{replicationZonesKey: ""},
+		},
+		{
+			name:    "QueryError",
+			db:      &testDB{standbyErr: errors.New("query failed")},
+			wantErr: true,
+		},
+		{
+			name:    "NoRows",
+			db:      &testDB{},
+			wantErr: true,
+		},
+		{
+			name:    "ScanError",
+			db:      &testDB{standbyRows: &standbyRows{data: []string{"10.0.0.2"}, scanErr: errors.New("scan failed")}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{db: tc.db}
+			got, err := m.replicationMetrics(context.Background(), ipinfo.NewZoneResolver(resolver))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("replicationMetrics() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("replicationMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// standbyLagRows is the single row of the standby lag query.
+type standbyLagRows struct {
+	lagBytes, lagSeconds int64
+	read                 bool
+}
+
+func (m *standbyLagRows) Next() bool {
+	if m.read {
+		return false
+	}
+	m.read = true
+	return true
+}
+
+func (m *standbyLagRows) Scan(dest ...any) error {
+	*(dest[0].(*int64)) = m.lagBytes
+	*(dest[1].(*int64)) = m.lagSeconds
+	return nil
+}
+
+func (m *standbyLagRows) Close() error { return nil }
+
+func TestReplicationLagMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *testDB
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "Standby",
+			db:   &testDB{standbyLagRows: &standbyLagRows{lagBytes: 16384, lagSeconds: 3}},
+			want: map[string]string{replicationLagBytesKey: "16384", replicationLagSecondsKey: "3"},
+		},
+		{
+			name: "Primary",
+			db:   &testDB{standbyLagRows: &standbyLagRows{read: true}},
+		},
+		{
+			name:    "QueryError",
+			db:      &testDB{standbyLagErr: errors.New("function pg_last_wal_receive_lsn() does not exist")},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{db: tc.db}
+			got, err := m.replicationLagMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("replicationLagMetrics() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("replicationLagMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
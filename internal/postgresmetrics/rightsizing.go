@@ -0,0 +1,85 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+)
+
+const (
+	maxConnectionsQuery = "SHOW max_connections"
+
+	maxConnectionsKey = "max_connections"
+	totalRAMKey       = "total_ram"
+)
+
+// workMemRatio is the best-practice size of work_mem relative to the host memory available to each
+// connection. A query may use work_mem for each of its sorts and hashes, so all connections using it
+// at once must fit in a fraction of the host memory.
+var workMemRatio = workloadmanager.MemoryRatio{
+	Setting: "work_mem",
+	Memory:  "the host memory per connection (host memory / max_connections)",
+	Max:     0.25,
+}
+
+// memoryMetrics returns the maximum number of connections and the physical memory of the host.
+func (m *PostgresMetrics) memoryMetrics(ctx context.Context) (map[string]string, error) {
+	rows, err := executeQuery(ctx, m.db, maxConnectionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query max_connections: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from max_connections query")
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		return nil, errors.New("no rows returned from max_connections query")
+	}
+	var maxConnections string
+	if err := rows.Scan(&maxConnections); err != nil {
+		return nil, fmt.Errorf("failed to scan max_connections: %w", err)
+	}
+	if _, err := strconv.Atoi(maxConnections); err != nil {
+		return nil, fmt.Errorf("failed to parse max_connections %q: %w", maxConnections, err)
+	}
+	metrics := map[string]string{maxConnectionsKey: maxConnections}
+	totalRAM, err := m.totalMemory(ctx)
+	if err != nil {
+		return metrics, fmt.Errorf("failed to get total memory: %w", err)
+	}
+	metrics[totalRAMKey] = strconv.FormatInt(totalRAM, 10)
+	return metrics, nil
+}
+
+// recommendations returns the right-sizing recommendations for the collected settings.
+func recommendations(metrics map[string]string) []string {
+	var recs []string
+	workMem, _ := strconv.ParseInt(metrics[workMemKey], 10, 64)
+	maxConnections, _ := strconv.ParseInt(metrics[maxConnectionsKey], 10, 64)
+	totalRAM, _ := strconv.ParseInt(metrics[totalRAMKey], 10, 64)
+	if maxConnections > 0 {
+		if rec, ok := workMemRatio.Recommend(workMem, totalRAM/maxConnections); ok {
+			recs = append(recs, rec)
+		}
+	}
+	return recs
+}
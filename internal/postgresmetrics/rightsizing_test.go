@@ -0,0 +1,113 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMemoryMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		db          *testDB
+		totalMemory func(context.Context) (int64, error)
+		want        map[string]string
+		wantErr     bool
+	}{
+		{
+			name:        "Success",
+			db:          &testDB{maxConnRows: &genericMockRows{value: "100"}},
+			totalMemory: func(context.Context) (int64, error) { return 8589934592, nil },
+			want:        map[string]string{maxConnectionsKey: "100", totalRAMKey: "8589934592"},
+		},
+		{
+			name:        "TotalMemoryError",
+			db:          &testDB{maxConnRows: &genericMockRows{value: "100"}},
+			totalMemory: func(context.Context) (int64, error) { return 0, errors.New("no meminfo") },
+			want:        map[string]string{maxConnectionsKey: "100"},
+			wantErr:     true,
+		},
+		{
+			name:    "QueryError",
+			db:      &testDB{maxConnErr: errors.New("query failed")},
+			wantErr: true,
+		},
+		{
+			name:    "NoRows",
+			db:      &testDB{},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidMaxConnections",
+			db:      &testDB{maxConnRows: &genericMockRows{value: "many"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{db: tc.db, totalMemory: tc.totalMemory}
+			got, err := m.memoryMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("memoryMetrics() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("memoryMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			for name, value := range got {
+				if d, ok := metricSchema.Definition(name); !ok {
+					t.Errorf("memoryMetrics() returned metric %s which is not defined in the schema", name)
+				} else if err := d.Validate(value); err != nil {
+					t.Errorf("memoryMetrics() returned an invalid metric: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRecommendations(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics map[string]string
+		want    []string
+	}{
+		{
+			name:    "LargeWorkMem",
+			metrics: map[string]string{workMemKey: "268435456", maxConnectionsKey: "100", totalRAMKey: "8589934592"},
+			want:    []string{"Decrease work_mem from 256MB to 20MB: it is 312.5% of the host memory per connection (host memory / max_connections), best practice is at most 25%."},
+		},
+		{
+			name:    "WellSizedWorkMem",
+			metrics: map[string]string{workMemKey: "4194304", maxConnectionsKey: "100", totalRAMKey: "8589934592"},
+		},
+		{
+			name:    "UnknownMaxConnections",
+			metrics: map[string]string{workMemKey: "268435456", totalRAMKey: "8589934592"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := recommendations(tc.metrics)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("recommendations(%v) returned unexpected diff (-want +got):\n%s", tc.metrics, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import "github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+
+// metricSchema defines the metrics sent to Data Warehouse for Postgres.
+var metricSchema = workloadmanager.NewSchema(
+	workloadmanager.MetricDefinition{Name: workMemKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitBytes},
+	workloadmanager.MetricDefinition{Name: maxConnectionsKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: totalRAMKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitBytes},
+	workloadmanager.MetricDefinition{Name: extensionsKey, Type: workloadmanager.JSONMetric},
+	// Vacuum.
+	workloadmanager.MetricDefinition{Name: maxDatfrozenxidAgeKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: xidWraparoundHeadroomKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: maxDeadTupleRatioKey, Type: workloadmanager.FloatMetric, Unit: workloadmanager.UnitRatio},
+	workloadmanager.MetricDefinition{Name: largestTablesKey, Type: workloadmanager.JSONMetric},
+	// pg_stat_statements.
+	workloadmanager.MetricDefinition{Name: statementsTrackedKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: statementsResetKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: statementsCallsDeltaKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: statementsExecTimeDeltaKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitMilliseconds},
+	workloadmanager.MetricDefinition{Name: topStatementsByExecTimeKey, Type: workloadmanager.JSONMetric},
+	workloadmanager.MetricDefinition{Name: topStatementsByCallsKey, Type: workloadmanager.JSONMetric},
+	// Backup.
+	workloadmanager.MetricDefinition{Name: archiveModeKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{"on", "off", "always"}},
+	workloadmanager.MetricDefinition{Name: archiveCommandSetKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: restoreCommandSetKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: backupToolsKey, Type: workloadmanager.ListMetric, AllowedValues: []string{pgBackRest, barman}},
+	workloadmanager.MetricDefinition{Name: lastArchivedTimestampKey, Type: workloadmanager.TimestampMetric},
+	// Replication.
+	workloadmanager.MetricDefinition{Name: replicationZonesKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: replicationLagBytesKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitBytes},
+	workloadmanager.MetricDefinition{Name: replicationLagSecondsKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitSeconds},
+	// HA manager.
+	workloadmanager.MetricDefinition{Name: haManagerKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{patroni, repmgr}},
+	workloadmanager.MetricDefinition{Name: haManagerVersionKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: haClusterNameKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: haLeaderKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: haMembersKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: haHealthyMembersKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	// PgBouncer.
+	workloadmanager.MetricDefinition{Name: pgBouncerVersionKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: pgBouncerDatabasesKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitCount},
+	workloadmanager.MetricDefinition{Name: pgBouncerBackendsKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: pgBouncerPoolSizesKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: pgBouncerPoolModesKey, Type: workloadmanager.ListMetric},
+)
+
+// MetricSchema returns the schema of the metrics sent to Data Warehouse for Postgres.
+func MetricSchema() *workloadmanager.Schema {
+	return metricSchema
+}
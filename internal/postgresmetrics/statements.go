@@ -0,0 +1,261 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	statementsTrackedKey       = "pg_stat_statements_tracked"
+	statementsResetKey         = "pg_stat_statements_reset"
+	statementsCallsDeltaKey    = "pg_stat_statements_calls_delta"
+	statementsExecTimeDeltaKey = "pg_stat_statements_exec_time_delta_ms"
+	topStatementsByExecTimeKey = "top_statements_by_total_exec_time"
+	topStatementsByCallsKey    = "top_statements_by_calls"
+	defaultStatementsTopN      = 5
+	pgStatStatements           = "pg_stat_statements"
+	// Only the query ID and a hash of the query text are selected, the text of statements which
+	// are not normalized, such as utility statements, may contain literals.
+	statementsQuery = "SELECT queryid::text, md5(query), calls, %s FROM pg_stat_statements WHERE queryid IS NOT NULL"
+	// pg_stat_statements_info exists as of version 1.9 of the extension.
+	statementsResetQuery = "SELECT COALESCE(EXTRACT(EPOCH FROM stats_reset)::bigint, 0) FROM pg_stat_statements_info"
+)
+
+// statementStats holds the cumulative statistics of a statement.
+type statementStats struct {
+	queryHash  string
+	calls      int64
+	execTimeMs float64
+}
+
+// statementSnapshot holds the statistics read by the previous collection.
+type statementSnapshot struct {
+	reset int64
+	stats map[string]statementStats
+}
+
+// statementSummary is a statement in the top statements rankings.
+// The deltas are the changes since the previous collection.
+type statementSummary struct {
+	QueryID         string `json:"queryId"`
+	QueryHash       string `json:"queryHash"`
+	Calls           int64  `json:"calls"`
+	TotalExecTimeMs int64  `json:"totalExecTimeMs"`
+	CallsDelta      *int64 `json:"callsDelta,omitempty"`
+	ExecTimeDeltaMs *int64 `json:"execTimeDeltaMs,omitempty"`
+}
+
+// extensionVersionAtLeast reports whether version, such as 1.10, is at least major.minor.
+func extensionVersionAtLeast(version string, major, minor int) bool {
+	majorStr, minorStr, _ := strings.Cut(version, ".")
+	gotMajor, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return false
+	}
+	gotMinor, _ := strconv.Atoi(minorStr)
+	return gotMajor > major || (gotMajor == major && gotMinor >= minor)
+}
+
+// readStatements returns the statistics of each statement aggregated by query ID, and when they
+// were last reset or 0 if the version of the extension doesn't report it.
+func readStatements(ctx context.Context, db dbInterface, version string) (map[string]statementStats, int64, error) {
+	// total_time was split into planning and execution time in version 1.8.
+	execTime := "total_time"
+	if extensionVersionAtLeast(version, 1, 8) {
+		execTime = "total_exec_time"
+	}
+	rows, err := executeQuery(ctx, db, fmt.Sprintf(statementsQuery, execTime))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query pg_stat_statements: %w", err)
+	}
+	if rows == nil {
+		return nil, 0, errors.New("no rows returned from pg_stat_statements query")
+	}
+	defer rows.Close()
+
+	// The statistics are kept per user and database, the same statement can have multiple entries.
+	stats := make(map[string]statementStats)
+	for rows.Next() {
+		var queryID, queryHash string
+		var calls int64
+		var execTimeMs float64
+		if err := rows.Scan(&queryID, &queryHash, &calls, &execTimeMs); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan statement: %w", err)
+		}
+		s, ok := stats[queryID]
+		if !ok {
+			s.queryHash = queryHash
+		}
+		s.calls += calls
+		s.execTimeMs += execTimeMs
+		stats[queryID] = s
+	}
+
+	if !extensionVersionAtLeast(version, 1, 9) {
+		return stats, 0, nil
+	}
+	resetRows, err := executeQuery(ctx, db, statementsResetQuery)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query pg_stat_statements_info: %w", err)
+	}
+	if resetRows == nil {
+		return stats, 0, nil
+	}
+	defer resetRows.Close()
+	var reset int64
+	if resetRows.Next() {
+		if err := resetRows.Scan(&reset); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan stats_reset: %w", err)
+		}
+	}
+	return stats, reset, nil
+}
+
+// statementMetrics summarizes pg_stat_statements if it is installed in any database, and
+// returns no metrics otherwise.
+func (m *PostgresMetrics) statementMetrics(ctx context.Context) (map[string]string, error) {
+	var stats map[string]statementStats
+	var reset int64
+	var readErr error
+	found := false
+	read := func(db dbInterface, version string) {
+		found = true
+		stats, reset, readErr = readStatements(ctx, db, version)
+	}
+
+	installed, err := installedExtensions(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+	if version, ok := installed[pgStatStatements]; ok {
+		read(m.db, version)
+	} else {
+		// The view is only queryable in the databases the extension was created in.
+		err := m.forEachDatabase(ctx, func(name string, db dbInterface) {
+			if found {
+				return
+			}
+			installed, err := installedExtensions(ctx, db)
+			if err != nil {
+				return
+			}
+			if version, ok := installed[pgStatStatements]; ok {
+				log.CtxLogger(ctx).Debugw("Reading pg_stat_statements", "database", name)
+				read(db, version)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !found {
+		log.CtxLogger(ctx).Debug("pg_stat_statements is not installed")
+		return nil, nil
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	n := int(m.Config.GetPostgresConfiguration().GetStatements().GetTopN())
+	if n <= 0 {
+		n = defaultStatementsTopN
+	}
+	return m.summarizeStatements(stats, reset, n)
+}
+
+// summarizeStatements returns the top n statements by total execution time and by calls, and
+// the changes since the previous collection. If the statistics were reset since, or a statement
+// has fewer calls than before because it was evicted, its current statistics are the change.
+func (m *PostgresMetrics) summarizeStatements(stats map[string]statementStats, reset int64, n int) (map[string]string, error) {
+	prev := m.statements
+	m.statements = &statementSnapshot{reset: reset, stats: stats}
+	wasReset := prev != nil && reset != prev.reset
+
+	var totalCallsDelta int64
+	var totalExecTimeDelta float64
+	summaries := make([]statementSummary, 0, len(stats))
+	for queryID, s := range stats {
+		summary := statementSummary{
+			QueryID:         queryID,
+			QueryHash:       s.queryHash,
+			Calls:           s.calls,
+			TotalExecTimeMs: int64(math.Round(s.execTimeMs)),
+		}
+		if prev != nil {
+			callsDelta, execTimeDelta := s.calls, s.execTimeMs
+			if p, ok := prev.stats[queryID]; ok && !wasReset && s.calls >= p.calls {
+				callsDelta -= p.calls
+				execTimeDelta -= p.execTimeMs
+			}
+			execTimeDeltaMs := int64(math.Round(execTimeDelta))
+			summary.CallsDelta = &callsDelta
+			summary.ExecTimeDeltaMs = &execTimeDeltaMs
+			totalCallsDelta += callsDelta
+			totalExecTimeDelta += execTimeDelta
+		}
+		summaries = append(summaries, summary)
+	}
+
+	byExecTime, err := topStatements(summaries, n, func(s statementSummary) int64 { return s.TotalExecTimeMs })
+	if err != nil {
+		return nil, err
+	}
+	byCalls, err := topStatements(summaries, n, func(s statementSummary) int64 { return s.Calls })
+	if err != nil {
+		return nil, err
+	}
+	metrics := map[string]string{
+		statementsTrackedKey:       strconv.Itoa(len(stats)),
+		topStatementsByExecTimeKey: byExecTime,
+		topStatementsByCallsKey:    byCalls,
+	}
+	if prev != nil {
+		metrics[statementsResetKey] = strconv.FormatBool(wasReset)
+		metrics[statementsCallsDeltaKey] = strconv.FormatInt(totalCallsDelta, 10)
+		metrics[statementsExecTimeDeltaKey] = strconv.FormatInt(int64(math.Round(totalExecTimeDelta)), 10)
+	}
+	return metrics, nil
+}
+
+// topStatements ranks the statements by value in descending order and returns the first n as a
+// JSON array. Statements with equal values are ordered by query ID to keep the result stable.
+func topStatements(summaries []statementSummary, n int, value func(statementSummary) int64) (string, error) {
+	ranked := append([]statementSummary(nil), summaries...)
+	sort.Slice(ranked, func(i, j int) bool {
+		if value(ranked[i]) != value(ranked[j]) {
+			return value(ranked[i]) > value(ranked[j])
+		}
+		return ranked[i].QueryID < ranked[j].QueryID
+	})
+	if len(ranked) > n {
+		ranked = ranked[:n]
+	}
+	b, err := json.Marshal(ranked)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal statements: %w", err)
+	}
+	return string(b), nil
+}
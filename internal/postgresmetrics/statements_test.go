@@ -0,0 +1,245 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// statementRow is a row of the pg_stat_statements query.
+type statementRow struct {
+	queryID    string
+	queryHash  string
+	calls      int64
+	execTimeMs float64
+}
+
+// statementRows for the pg_stat_statements query.
+type statementRows struct {
+	data  []statementRow
+	count int
+}
+
+func (m *statementRows) Next() bool {
+	m.count++
+	return m.count <= len(m.data)
+}
+
+func (m *statementRows) Scan(dest ...any) error {
+	r := m.data[m.count-1]
+	*(dest[0].(*string)) = r.queryID
+	*(dest[1].(*string)) = r.queryHash
+	*(dest[2].(*int64)) = r.calls
+	*(dest[3].(*float64)) = r.execTimeMs
+	return nil
+}
+
+func (m *statementRows) Close() error { return nil }
+
+func TestExtensionVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "1.10", want: true},
+		{version: "1.8", want: true},
+		{version: "1.7", want: false},
+		{version: "2.0", want: true},
+		{version: "invalid", want: false},
+	}
+	for _, tc := range tests {
+		if got := extensionVersionAtLeast(tc.version, 1, 8); got != tc.want {
+			t.Errorf("extensionVersionAtLeast(%q, 1, 8) = %v, want %v", tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestStatementMetrics(t *testing.T) {
+	rows := func() *statementRows {
+		return &statementRows{data: []statementRow{
+			{queryID: "1", queryHash: "h1", calls: 10, execTimeMs: 100.4},
+			{queryID: "2", queryHash: "h2", calls: 500, execTimeMs: 20},
+			// The same statement run by another user.
+			{queryID: "1", queryHash: "h1", calls: 5, execTimeMs: 50},
+		}}
+	}
+	tests := []struct {
+		name         string
+		db           *testDB
+		databases    map[string]*testDB
+		want         map[string]string
+		wantExecTime string
+		wantErr      bool
+	}{
+		{
+			name: "InstalledInConnectedDatabase",
+			db: &testDB{
+				extensionsRows: &settingsRows{data: [][]string{{"pg_stat_statements", "1.10"}}},
+				statementsRows: rows(),
+				resetRows:      &xidAgeRows{value: 1700000000},
+			},
+			want: map[string]string{
+				statementsTrackedKey:       "2",
+				topStatementsByExecTimeKey: `[{"queryId":"1","queryHash":"h1","calls":15,"totalExecTimeMs":150},{"queryId":"2","queryHash":"h2","calls":500,"totalExecTimeMs":20}]`,
+				topStatementsByCallsKey:    `[{"queryId":"2","queryHash":"h2","calls":500,"totalExecTimeMs":20},{"queryId":"1","queryHash":"h1","calls":15,"totalExecTimeMs":150}]`,
+			},
+			wantExecTime: "total_exec_time",
+		},
+		{
+			name: "InstalledInOtherDatabase",
+			db: &testDB{
+				extensionsRows: &settingsRows{data: [][]string{{"plpgsql", "1.0"}}},
+				databasesRows:  &databaseRows{data: []string{"postgres", "app"}},
+			},
+			databases: map[string]*testDB{
+				"dataSource dbname='app'": &testDB{
+					extensionsRows: &settingsRows{data: [][]string{{"pg_stat_statements", "1.7"}}},
+					statementsRows: &statementRows{data: []statementRow{{queryID: "3", queryHash: "h3", calls: 1, execTimeMs: 1}}},
+				},
+			},
+			want: map[string]string{
+				statementsTrackedKey:       "1",
+				topStatementsByExecTimeKey: `[{"queryId":"3","queryHash":"h3","calls":1,"totalExecTimeMs":1}]`,
+				topStatementsByCallsKey:    `[{"queryId":"3","queryHash":"h3","calls":1,"totalExecTimeMs":1}]`,
+			},
+		},
+		{
+			name: "NotInstalled",
+			db: &testDB{
+				extensionsRows: &settingsRows{data: [][]string{{"plpgsql", "1.0"}}},
+				databasesRows:  &databaseRows{data: []string{"postgres"}},
+			},
+		},
+		{
+			name: "StatementsQueryError",
+			db: &testDB{
+				extensionsRows: &settingsRows{data: [][]string{{"pg_stat_statements", "1.10"}}},
+				statementsErr:  errors.New("permission denied"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{
+				db:         tc.db,
+				dataSource: "dataSource",
+				connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+					db, ok := tc.databases[dataSource]
+					if !ok {
+						return nil, fmt.Errorf("unexpected data source %q", dataSource)
+					}
+					return db, nil
+				},
+			}
+			got, err := m.statementMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("statementMetrics() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("statementMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if tc.wantExecTime != "" && tc.db.statementsQuery != fmt.Sprintf(statementsQuery, tc.wantExecTime) {
+				t.Errorf("statementMetrics() ran query %q, want %q", tc.db.statementsQuery, fmt.Sprintf(statementsQuery, tc.wantExecTime))
+			}
+			for name, value := range got {
+				if d, ok := metricSchema.Definition(name); !ok {
+					t.Errorf("statementMetrics() returned metric %s which is not defined in the schema", name)
+				} else if err := d.Validate(value); err != nil {
+					t.Errorf("statementMetrics() returned an invalid metric: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestSummarizeStatementsDeltas(t *testing.T) {
+	m := &PostgresMetrics{}
+	collections := []struct {
+		name  string
+		stats map[string]statementStats
+		reset int64
+		want  map[string]string
+	}{
+		{
+			name:  "FirstCollection",
+			stats: map[string]statementStats{"1": {queryHash: "h1", calls: 10, execTimeMs: 100}},
+			reset: 1,
+			want: map[string]string{
+				statementsTrackedKey:       "1",
+				topStatementsByExecTimeKey: `[{"queryId":"1","queryHash":"h1","calls":10,"totalExecTimeMs":100}]`,
+				topStatementsByCallsKey:    `[{"queryId":"1","queryHash":"h1","calls":10,"totalExecTimeMs":100}]`,
+			},
+		},
+		{
+			name: "Deltas",
+			stats: map[string]statementStats{
+				"1": {queryHash: "h1", calls: 15, execTimeMs: 130},
+				"2": {queryHash: "h2", calls: 2, execTimeMs: 4},
+			},
+			reset: 1,
+			want: map[string]string{
+				statementsTrackedKey:       "2",
+				statementsResetKey:         "false",
+				statementsCallsDeltaKey:    "7",
+				statementsExecTimeDeltaKey: "34",
+				topStatementsByExecTimeKey: `[{"queryId":"1","queryHash":"h1","calls":15,"totalExecTimeMs":130,"callsDelta":5,"execTimeDeltaMs":30}]`,
+				topStatementsByCallsKey:    `[{"queryId":"1","queryHash":"h1","calls":15,"totalExecTimeMs":130,"callsDelta":5,"execTimeDeltaMs":30}]`,
+			},
+		},
+		{
+			name:  "Reset",
+			stats: map[string]statementStats{"1": {queryHash: "h1", calls: 20, execTimeMs: 200}},
+			reset: 2,
+			want: map[string]string{
+				statementsTrackedKey:       "1",
+				statementsResetKey:         "true",
+				statementsCallsDeltaKey:    "20",
+				statementsExecTimeDeltaKey: "200",
+				topStatementsByExecTimeKey: `[{"queryId":"1","queryHash":"h1","calls":20,"totalExecTimeMs":200,"callsDelta":20,"execTimeDeltaMs":200}]`,
+				topStatementsByCallsKey:    `[{"queryId":"1","queryHash":"h1","calls":20,"totalExecTimeMs":200,"callsDelta":20,"execTimeDeltaMs":200}]`,
+			},
+		},
+		{
+			name:  "Evicted",
+			stats: map[string]statementStats{"1": {queryHash: "h1", calls: 3, execTimeMs: 6}},
+			reset: 2,
+			want: map[string]string{
+				statementsTrackedKey:       "1",
+				statementsResetKey:         "false",
+				statementsCallsDeltaKey:    "3",
+				statementsExecTimeDeltaKey: "6",
+				topStatementsByExecTimeKey: `[{"queryId":"1","queryHash":"h1","calls":3,"totalExecTimeMs":6,"callsDelta":3,"execTimeDeltaMs":6}]`,
+				topStatementsByCallsKey:    `[{"queryId":"1","queryHash":"h1","calls":3,"totalExecTimeMs":6,"callsDelta":3,"execTimeDeltaMs":6}]`,
+			},
+		},
+	}
+	for _, c := range collections {
+		got, err := m.summarizeStatements(c.stats, c.reset, 1)
+		if err != nil {
+			t.Fatalf("summarizeStatements(%s) returned unexpected error: %v", c.name, err)
+		}
+		if diff := cmp.Diff(c.want, got); diff != "" {
+			t.Errorf("summarizeStatements(%s) returned unexpected diff (-want +got):\n%s", c.name, diff)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+
+	"github.com/lib/pq"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/tlsconfig"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// sslParams returns the connection string parameters for the TLS configuration, or "" if TLS is not enabled.
+// The CA file is passed by path unless a client certificate is configured, in which case the
+// clientCertConnector inlines it together with the certificate.
+func sslParams(cfg *configpb.TLSConfiguration, hasClientCert bool) string {
+	if !cfg.GetEnabled() {
+		return ""
+	}
+	if cfg.GetInsecureSkipVerify() {
+		// Without a root certificate lib/pq does not verify the server certificate in this mode.
+		return "sslmode=require"
+	}
+	params := "sslmode=verify-full"
+	if cfg.GetCaFile() != "" && !hasClientCert {
+		params += " sslrootcert=" + quoteDSNValue(cfg.GetCaFile())
+	}
+	return params
+}
+
+// clientCertConnector opens connections which present the current client certificate.
+// lib/pq only accepts client certificates in the connection string, so they are inlined
+// for each new connection and a rotated certificate is used without reopening the pool.
+type clientCertConnector struct {
+	dataSource string
+	cert       *tlsconfig.ClientCertificate
+	// caFile is read for each connection because inlined certificates can't be mixed with file paths.
+	caFile string
+}
+
+func (c clientCertConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	dataSource, err := c.inlineDataSource(ctx)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := pq.NewConnector(dataSource)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(ctx)
+}
+
+func (c clientCertConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+// inlineDataSource returns the connection string with the client certificate, key and CA certificates inlined.
+func (c clientCertConnector) inlineDataSource(ctx context.Context) (string, error) {
+	certPEM, keyPEM := c.cert.PEM(ctx)
+	dataSource := fmt.Sprintf("%s sslinline=true sslcert=%s sslkey=%s", c.dataSource, quoteDSNValue(string(certPEM)), quoteDSNValue(string(keyPEM)))
+	if c.caFile != "" {
+		ca, err := os.ReadFile(c.caFile)
+		if err != nil {
+			return "", fmt.Errorf("reading CA file: %w", err)
+		}
+		dataSource += " sslrootcert=" + quoteDSNValue(string(ca))
+	}
+	return dataSource, nil
+}
+
+// initTLS loads the client certificate if TLS with a client certificate is configured,
+// and makes new connections present it.
+func (m *PostgresMetrics) initTLS(ctx context.Context, gceService GceInterface) error {
+	cfg := m.Config.GetPostgresConfiguration().GetTls()
+	if !cfg.GetEnabled() {
+		return nil
+	}
+	cert, err := tlsconfig.NewClientCertificate(ctx, cfg, gceService)
+	if err != nil || cert == nil {
+		return err
+	}
+	connector := clientCertConnector{cert: cert}
+	if !cfg.GetInsecureSkipVerify() {
+		connector.caFile = cfg.GetCaFile()
+	}
+	m.hasClientCert = true
+	m.connect = func(ctx context.Context, dataSource string) (dbInterface, error) {
+		c := connector
+		c.dataSource = dataSource
+		return dbWrapper{db: sql.OpenDB(c)}, nil
+	}
+	return nil
+}
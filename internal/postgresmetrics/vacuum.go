@@ -0,0 +1,164 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	maxDatfrozenxidAgeKey    = "max_datfrozenxid_age"
+	xidWraparoundHeadroomKey = "xid_wraparound_headroom"
+	maxDeadTupleRatioKey     = "max_dead_tuple_ratio"
+	largestTablesKey         = "largest_tables_vacuum"
+	largestTablesLimit       = 10
+	// Postgres refuses to assign new transaction IDs before the oldest unfrozen XID is 2^31 transactions old.
+	xidWraparoundLimit   = 1 << 31
+	datfrozenxidAgeQuery = "SELECT COALESCE(MAX(age(datfrozenxid)), 0) FROM pg_database"
+)
+
+var largestTablesQuery = fmt.Sprintf(`SELECT schemaname || '.' || relname, pg_total_relation_size(relid), n_live_tup, n_dead_tup,
+	EXTRACT(EPOCH FROM last_autovacuum)::bigint, EXTRACT(EPOCH FROM last_autoanalyze)::bigint
+	FROM pg_stat_user_tables ORDER BY pg_total_relation_size(relid) DESC LIMIT %d`, largestTablesLimit)
+
+// tableVacuumStats holds the vacuum health of a single table.
+type tableVacuumStats struct {
+	Database        string  `json:"database"`
+	Table           string  `json:"table"`
+	SizeBytes       int64   `json:"sizeBytes"`
+	DeadTupleRatio  float64 `json:"deadTupleRatio"`
+	LastAutovacuum  string  `json:"lastAutovacuum,omitempty"`
+	LastAutoanalyze string  `json:"lastAutoanalyze,omitempty"`
+}
+
+// maxDatfrozenxidAge returns the transaction ID age of the oldest unfrozen database.
+func (m *PostgresMetrics) maxDatfrozenxidAge(ctx context.Context) (int64, error) {
+	rows, err := executeQuery(ctx, m.db, datfrozenxidAgeQuery)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query datfrozenxid age: %w", err)
+	}
+	if rows == nil {
+		return 0, errors.New("no rows returned from datfrozenxid age query")
+	}
+	defer rows.Close()
+
+	var age int64
+	if !rows.Next() {
+		return 0, errors.New("no rows returned from datfrozenxid age query")
+	}
+	if err := rows.Scan(&age); err != nil {
+		return 0, fmt.Errorf("failed to scan datfrozenxid age: %w", err)
+	}
+	return age, nil
+}
+
+// formatEpoch formats a nullable epoch timestamp in RFC 3339 format.
+func formatEpoch(epoch sql.NullInt64) string {
+	if !epoch.Valid {
+		return ""
+	}
+	return time.Unix(epoch.Int64, 0).UTC().Format(time.RFC3339)
+}
+
+// largestTables returns the vacuum health of the largest tables in the database db is connected to.
+func largestTables(ctx context.Context, database string, db dbInterface) ([]tableVacuumStats, error) {
+	rows, err := executeQuery(ctx, db, largestTablesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pg_stat_user_tables: %w", err)
+	}
+	if rows == nil {
+		return nil, errors.New("no rows returned from pg_stat_user_tables query")
+	}
+	defer rows.Close()
+
+	var tables []tableVacuumStats
+	for rows.Next() {
+		var name string
+		var size, live, dead int64
+		var lastAutovacuum, lastAutoanalyze sql.NullInt64
+		if err := rows.Scan(&name, &size, &live, &dead, &lastAutovacuum, &lastAutoanalyze); err != nil {
+			return nil, fmt.Errorf("failed to scan table statistics: %w", err)
+		}
+		ratio := 0.0
+		if live+dead > 0 {
+			ratio = math.Round(float64(dead)/float64(live+dead)*10000) / 10000
+		}
+		tables = append(tables, tableVacuumStats{
+			Database:        database,
+			Table:           name,
+			SizeBytes:       size,
+			DeadTupleRatio:  ratio,
+			LastAutovacuum:  formatEpoch(lastAutovacuum),
+			LastAutoanalyze: formatEpoch(lastAutoanalyze),
+		})
+	}
+	return tables, nil
+}
+
+// vacuumMetrics returns the transaction ID wraparound headroom and the dead tuple ratio and
+// last autovacuum and autoanalyze times of the largest tables across all databases.
+func (m *PostgresMetrics) vacuumMetrics(ctx context.Context) (map[string]string, error) {
+	age, err := m.maxDatfrozenxidAge(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := []tableVacuumStats{}
+	err = m.forEachDatabase(ctx, func(name string, db dbInterface) {
+		t, err := largestTables(ctx, name, db)
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Failed to get Postgres table statistics", "database", name, "err", err)
+			return
+		}
+		tables = append(tables, t...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(tables, func(i, j int) bool { return tables[i].SizeBytes > tables[j].SizeBytes })
+	if len(tables) > largestTablesLimit {
+		tables = tables[:largestTablesLimit]
+	}
+	maxRatio := 0.0
+	for _, t := range tables {
+		maxRatio = math.Max(maxRatio, t.DeadTupleRatio)
+	}
+	tablesJSON, err := json.Marshal(tables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal table statistics: %w", err)
+	}
+
+	metrics := map[string]string{
+		maxDatfrozenxidAgeKey:    strconv.FormatInt(age, 10),
+		xidWraparoundHeadroomKey: strconv.FormatInt(xidWraparoundLimit-age, 10),
+		maxDeadTupleRatioKey:     units.FormatRatio(maxRatio),
+		largestTablesKey:         string(tablesJSON),
+	}
+	log.CtxLogger(ctx).Debugw("Postgres vacuum health", "metrics", metrics)
+	return metrics, nil
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package postgresmetrics
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// xidAgeRows for the datfrozenxid age query.
+type xidAgeRows struct {
+	value int64
+	read  bool
+}
+
+func (m *xidAgeRows) Next() bool {
+	if !m.read {
+		m.read = true
+		return true
+	}
+	return false
+}
+
+func (m *xidAgeRows) Scan(dest ...any) error {
+	*(dest[0].(*int64)) = m.value
+	return nil
+}
+
+func (m *xidAgeRows) Close() error { return nil }
+
+// tableRow is a row of the largest tables query.
+type tableRow struct {
+	name            string
+	size, live      int64
+	dead            int64
+	lastAutovacuum  sql.NullInt64
+	lastAutoanalyze sql.NullInt64
+}
+
+// tablesRows for the largest tables query.
+type tablesRows struct {
+	data    []tableRow
+	count   int
+	scanErr error
+}
+
+func (m *tablesRows) Next() bool {
+	m.count++
+	return m.count <= len(m.data)
+}
+
+func (m *tablesRows) Scan(dest ...any) error {
+	if m.scanErr != nil {
+		return m.scanErr
+	}
+	r := m.data[m.count-1]
+	*(dest[0].(*string)) = r.name
+	*(dest[1].(*int64)) = r.size
+	*(dest[2].(*int64)) = r.live
+	*(dest[3].(*int64)) = r.dead
+	*(dest[4].(*sql.NullInt64)) = r.lastAutovacuum
+	*(dest[5].(*sql.NullInt64)) = r.lastAutoanalyze
+	return nil
+}
+
+func (m *tablesRows) Close() error { return nil }
+
+func TestVacuumMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		db         *testDB
+		databases  map[string]*testDB
+		want       map[string]string
+		wantTables []tableVacuumStats
+		wantErr    bool
+	}{
+		{
+			name: "LargestTablesAcrossDatabases",
+			db: &testDB{
+				xidAgeRows:    &xidAgeRows{value: 200000000},
+				databasesRows: &databaseRows{data: []string{"postgres", "app"}},
+				tablesRows: &tablesRows{data: []tableRow{
+					{name: "public.small", size: 8192, live: 0, dead: 0},
+				}},
+			},
+			databases: map[string]*testDB{
+				"dataSource dbname='app'": &testDB{
+					tablesRows: &tablesRows{data: []tableRow{
+						{
+							name:            "public.orders",
+							size:            1073741824,
+							live:            750,
+							dead:            250,
+							lastAutovacuum:  sql.NullInt64{Int64: 1735787045, Valid: true},
+							lastAutoanalyze: sql.NullInt64{Int64: 1735700645, Valid: true},
+						},
+					}},
+				},
+			},
+			want: map[string]string{
+				maxDatfrozenxidAgeKey:    "200000000",
+				xidWraparoundHeadroomKey: "1947483648",
+				maxDeadTupleRatioKey:     "0.25",
+			},
+			wantTables: []tableVacuumStats{
+				{
+					Database:        "app",
+					Table:           "public.orders",
+					SizeBytes:       1073741824,
+					DeadTupleRatio:  0.25,
+					LastAutovacuum:  "2025-01-02T03:04:05Z",
+					LastAutoanalyze: "2025-01-01T03:04:05Z",
+				},
+				{Database: "postgres", Table: "public.small", SizeBytes: 8192},
+			},
+		},
+		{
+			name: "TableStatisticsErrorIsSkipped",
+			db: &testDB{
+				xidAgeRows:    &xidAgeRows{value: 1000},
+				databasesRows: &databaseRows{data: []string{"postgres"}},
+				tablesErr:     errors.New("query failed"),
+			},
+			want: map[string]string{
+				maxDatfrozenxidAgeKey:    "1000",
+				xidWraparoundHeadroomKey: "2147482648",
+				maxDeadTupleRatioKey:     "0",
+			},
+			wantTables: []tableVacuumStats{},
+		},
+		{
+			name:    "XIDAgeError",
+			db:      &testDB{xidAgeErr: errors.New("query failed")},
+			wantErr: true,
+		},
+		{
+			name: "DatabasesQueryError",
+			db: &testDB{
+				xidAgeRows:   &xidAgeRows{value: 1000},
+				databasesErr: errors.New("query failed"),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &PostgresMetrics{
+				db:         tc.db,
+				dataSource: "dataSource",
+				connect: func(ctx context.Context, dataSource string) (dbInterface, error) {
+					db, ok := tc.databases[dataSource]
+					if !ok {
+						t.Fatalf("connect() called with unexpected data source %q", dataSource)
+					}
+					return db, nil
+				},
+			}
+			got, err := m.vacuumMetrics(context.Background())
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("vacuumMetrics() returned error %v, want error %t", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			var gotTables []tableVacuumStats
+			if err := json.Unmarshal([]byte(got[largestTablesKey]), &gotTables); err != nil {
+				t.Fatalf("json.Unmarshal(%q) returned unexpected error: %v", got[largestTablesKey], err)
+			}
+			delete(got, largestTablesKey)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("vacuumMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantTables, gotTables); diff != "" {
+				t.Errorf("vacuumMetrics() returned unexpected table statistics diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
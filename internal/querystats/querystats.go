@@ -0,0 +1,184 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package querystats records the execution latency and errors of the queries the collectors
+// run against the monitored databases, so that slow databases affecting the agent are visible.
+package querystats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxQueryLength is the length after which query texts are truncated.
+const maxQueryLength = 100
+
+// Buckets are the upper bounds of the latency histogram buckets.
+var Buckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+	30 * time.Second,
+}
+
+// Stat holds the recorded executions of a single query.
+type Stat struct {
+	Workload   string  `json:"workload"`
+	Query      string  `json:"query"`
+	Count      int64   `json:"count"`
+	Errors     int64   `json:"errors"`
+	SumSeconds float64 `json:"sum_seconds"`
+	MaxSeconds float64 `json:"max_seconds"`
+	// BucketCounts holds the cumulative number of executions for each of Buckets.
+	BucketCounts []int64 `json:"bucket_counts"`
+}
+
+type key struct {
+	workload string
+	query    string
+}
+
+// Registry records query executions. It is safe for concurrent use.
+type Registry struct {
+	mu    sync.Mutex
+	stats map[key]*Stat
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{stats: make(map[key]*Stat)}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Observe records an execution of query for workload in the default registry.
+func Observe(workload, query string, latency time.Duration, err error) {
+	defaultRegistry.Observe(workload, query, latency, err)
+}
+
+// Snapshot returns the stats of the default registry.
+func Snapshot() []Stat {
+	return defaultRegistry.Snapshot()
+}
+
+// WritePrometheus writes the stats of the default registry in the Prometheus text format.
+func WritePrometheus(w io.Writer) error {
+	return defaultRegistry.WritePrometheus(w)
+}
+
+// Observe records an execution of query for workload which took latency and failed with err, if not nil.
+// The query may be its text or a name identifying it; whitespace is collapsed and long texts are truncated.
+func (r *Registry) Observe(workload, query string, latency time.Duration, err error) {
+	k := key{workload: workload, query: normalize(query)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.stats[k]
+	if !ok {
+		s = &Stat{Workload: k.workload, Query: k.query, BucketCounts: make([]int64, len(Buckets))}
+		r.stats[k] = s
+	}
+	s.Count++
+	if err != nil {
+		s.Errors++
+	}
+	seconds := latency.Seconds()
+	s.SumSeconds += seconds
+	if seconds > s.MaxSeconds {
+		s.MaxSeconds = seconds
+	}
+	for i, b := range Buckets {
+		if latency <= b {
+			s.BucketCounts[i]++
+		}
+	}
+}
+
+// Snapshot returns a copy of the recorded stats, sorted by workload and query.
+func (r *Registry) Snapshot() []Stat {
+	r.mu.Lock()
+	stats := make([]Stat, 0, len(r.stats))
+	for _, s := range r.stats {
+		c := *s
+		c.BucketCounts = append([]int64(nil), s.BucketCounts...)
+		stats = append(stats, c)
+	}
+	r.mu.Unlock()
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Workload != stats[j].Workload {
+			return stats[i].Workload < stats[j].Workload
+		}
+		return stats[i].Query < stats[j].Query
+	})
+	return stats
+}
+
+// WritePrometheus writes the recorded stats as a latency histogram and an error counter
+// in the Prometheus text exposition format.
+func (r *Registry) WritePrometheus(w io.Writer) error {
+	stats := r.Snapshot()
+	var b strings.Builder
+	b.WriteString("# HELP workloadagent_query_duration_seconds Execution latency of the queries run against monitored databases.\n")
+	b.WriteString("# TYPE workloadagent_query_duration_seconds histogram\n")
+	for _, s := range stats {
+		labels := fmt.Sprintf(`workload="%s",query="%s"`, escape(s.Workload), escape(s.Query))
+		for i, bound := range Buckets {
+			fmt.Fprintf(&b, "workloadagent_query_duration_seconds_bucket{%s,le=\"%s\"} %d\n", labels, formatFloat(bound.Seconds()), s.BucketCounts[i])
+		}
+		fmt.Fprintf(&b, "workloadagent_query_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", labels, s.Count)
+		fmt.Fprintf(&b, "workloadagent_query_duration_seconds_sum{%s} %s\n", labels, formatFloat(s.SumSeconds))
+		fmt.Fprintf(&b, "workloadagent_query_duration_seconds_count{%s} %d\n", labels, s.Count)
+	}
+	b.WriteString("# HELP workloadagent_query_errors_total Number of failed queries run against monitored databases.\n")
+	b.WriteString("# TYPE workloadagent_query_errors_total counter\n")
+	for _, s := range stats {
+		fmt.Fprintf(&b, "workloadagent_query_errors_total{workload=\"%s\",query=\"%s\"} %d\n", escape(s.Workload), escape(s.Query), s.Errors)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// normalize collapses the whitespace of query and truncates it to maxQueryLength.
+func normalize(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if r := []rune(query); len(r) > maxQueryLength {
+		query = string(r[:maxQueryLength]) + "..."
+	}
+	return query
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escape escapes a Prometheus label value.
+func escape(v string) string {
+	return labelEscaper.Replace(v)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package querystats
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestObserve(t *testing.T) {
+	r := NewRegistry()
+	r.Observe("mysql", "SELECT 1", 3*time.Millisecond, nil)
+	r.Observe("mysql", "SELECT\n\t1", 200*time.Millisecond, errors.New("query failed"))
+	r.Observe("mysql", "SELECT 1", time.Minute, nil)
+	r.Observe("postgres", "SELECT 1", 20*time.Millisecond, nil)
+
+	want := []Stat{
+		{
+			Workload:     "mysql",
+			Query:        "SELECT 1",
+			Count:        3,
+			Errors:       1,
+			SumSeconds:   60.203,
+			MaxSeconds:   60,
+			BucketCounts: []int64{1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2},
+		},
+		{
+			Workload:     "postgres",
+			Query:        "SELECT 1",
+			Count:        1,
+			SumSeconds:   0.02,
+			MaxSeconds:   0.02,
+			BucketCounts: []int64{0, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		},
+	}
+	if diff := cmp.Diff(want, r.Snapshot()); diff != "" {
+		t.Errorf("Snapshot() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "WhitespaceIsCollapsed",
+			query: "  SELECT *\n\tFROM   pg_settings ",
+			want:  "SELECT * FROM pg_settings",
+		},
+		{
+			name:  "LongQueryIsTruncated",
+			query: "SELECT " + strings.Repeat("a", 200),
+			want:  "SELECT " + strings.Repeat("a", maxQueryLength-len("SELECT ")) + "...",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := normalize(tc.query); got != tc.want {
+				t.Errorf("normalize(%q) = %q, want %q", tc.query, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	r := NewRegistry()
+	r.Observe("oracle", `query "a"`, 2*time.Second, errors.New("query failed"))
+
+	var b strings.Builder
+	if err := r.WritePrometheus(&b); err != nil {
+		t.Fatalf("WritePrometheus() returned unexpected error: %v", err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"# TYPE workloadagent_query_duration_seconds histogram\n",
+		`workloadagent_query_duration_seconds_bucket{workload="oracle",query="query \"a\"",le="1"} 0` + "\n",
+		`workloadagent_query_duration_seconds_bucket{workload="oracle",query="query \"a\"",le="2.5"} 1` + "\n",
+		`workloadagent_query_duration_seconds_bucket{workload="oracle",query="query \"a\"",le="+Inf"} 1` + "\n",
+		`workloadagent_query_duration_seconds_sum{workload="oracle",query="query \"a\""} 2` + "\n",
+		`workloadagent_query_duration_seconds_count{workload="oracle",query="query \"a\""} 1` + "\n",
+		"# TYPE workloadagent_query_errors_total counter\n",
+		`workloadagent_query_errors_total{workload="oracle",query="query \"a\""} 1` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WritePrometheus() output does not contain %q, got:\n%s", want, got)
+		}
+	}
+}
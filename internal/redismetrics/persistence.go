@@ -0,0 +1,114 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redismetrics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	appendfsync       = "appendfsync"
+	aofUseRDBPreamble = "aof-use-rdb-preamble"
+	statusOK          = "ok"
+
+	appendfsyncKey             = "appendfsync"
+	aofUseRDBPreambleKey       = "aof_use_rdb_preamble"
+	savePointsKey              = "save_points"
+	lastRDBSaveTimestampKey    = "last_rdb_save_timestamp"
+	lastAOFRewriteTimestampKey = "last_aof_rewrite_timestamp"
+)
+
+// configValue returns the value of a single Redis configuration parameter, or "" if it is not set.
+func (r *RedisMetrics) configValue(ctx context.Context, name string) string {
+	values, err := r.db.ConfigGet(ctx, name).Result()
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to get Redis configuration parameter", "name", name, "error", err)
+		return ""
+	}
+	return values[name]
+}
+
+// parseInfo parses the "field:value" lines of an INFO section.
+func parseInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// lastRDBSave returns the time of the last successful RDB save, or the zero time if unknown.
+func lastRDBSave(fields map[string]string) time.Time {
+	sec, err := strconv.ParseInt(fields["rdb_last_save_time"], 10, 64)
+	if err != nil || sec <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// lastAOFRewrite returns the time of the last successful AOF rewrite, or the zero time if unknown.
+//
+// Redis does not report when a rewrite happened, only how many have been performed since startup.
+// A rewrite is therefore dated to the collection which first observes the counter increase.
+func (r *RedisMetrics) lastAOFRewrite(fields map[string]string, now time.Time) time.Time {
+	rewrites, err := strconv.ParseInt(fields["aof_rewrites"], 10, 64)
+	if err != nil {
+		return r.lastAOFRewriteTime
+	}
+	if r.aofRewritesSeen && rewrites > r.aofRewrites && fields["aof_last_bgrewrite_status"] == statusOK {
+		r.lastAOFRewriteTime = now
+	}
+	r.aofRewrites = rewrites
+	r.aofRewritesSeen = true
+	return r.lastAOFRewriteTime
+}
+
+// persistenceMetrics returns the durability settings of Redis and when data was last persisted.
+func (r *RedisMetrics) persistenceMetrics(ctx context.Context, now time.Time) map[string]string {
+	metrics := map[string]string{
+		appendfsyncKey:             r.configValue(ctx, appendfsync),
+		aofUseRDBPreambleKey:       r.configValue(ctx, aofUseRDBPreamble),
+		savePointsKey:              r.configValue(ctx, save),
+		lastRDBSaveTimestampKey:    "",
+		lastAOFRewriteTimestampKey: "",
+	}
+
+	info, err := r.db.Info(ctx, "persistence").Result()
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to get Redis persistence info", "error", err)
+		return metrics
+	}
+	fields := parseInfo(info)
+	if t := lastRDBSave(fields); !t.IsZero() {
+		metrics[lastRDBSaveTimestampKey] = t.UTC().Format(time.RFC3339)
+	}
+	if t := r.lastAOFRewrite(fields, now); !t.IsZero() {
+		metrics[lastAOFRewriteTimestampKey] = t.UTC().Format(time.RFC3339)
+	}
+	return metrics
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redismetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestPersistenceMetrics(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		configs map[string]string
+		save    string
+		info    string
+		infoErr error
+		want    map[string]string
+	}{
+		{
+			name:    "AllSettings",
+			configs: map[string]string{appendfsync: "everysec", aofUseRDBPreamble: "yes"},
+			save:    "3600 1 300 100",
+			info:    "# Persistence\r\nrdb_last_save_time:1714560000\r\nrdb_last_bgsave_status:ok\r\naof_rewrites:0\r\naof_last_bgrewrite_status:ok\r\n",
+			want: map[string]string{
+				appendfsyncKey:             "everysec",
+				aofUseRDBPreambleKey:       "yes",
+				savePointsKey:              "3600 1 300 100",
+				lastRDBSaveTimestampKey:    "2024-05-01T10:40:00Z",
+				lastAOFRewriteTimestampKey: "",
+			},
+		},
+		{
+			name:    "InfoError",
+			configs: map[string]string{appendfsync: "always"},
+			infoErr: errors.New("info failed"),
+			want: map[string]string{
+				appendfsyncKey:             "always",
+				aofUseRDBPreambleKey:       "",
+				savePointsKey:              "",
+				lastRDBSaveTimestampKey:    "",
+				lastAOFRewriteTimestampKey: "",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db := &testDB{
+				saveConfig:      redis.NewMapStringStringResult(map[string]string{save: tc.save}, nil),
+				configs:         tc.configs,
+				persistenceInfo: redis.NewStringResult(tc.info, tc.infoErr),
+			}
+			r := &RedisMetrics{db: db}
+			got := r.persistenceMetrics(context.Background(), now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("persistenceMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLastAOFRewrite(t *testing.T) {
+	first := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	second := first.Add(time.Minute)
+	third := second.Add(time.Minute)
+	r := &RedisMetrics{}
+
+	if got := r.lastAOFRewrite(map[string]string{"aof_rewrites": "2", "aof_last_bgrewrite_status": "ok"}, first); !got.IsZero() {
+		t.Errorf("lastAOFRewrite() on first collection = %v, want zero time", got)
+	}
+	if got := r.lastAOFRewrite(map[string]string{"aof_rewrites": "3", "aof_last_bgrewrite_status": "ok"}, second); !got.Equal(second) {
+		t.Errorf("lastAOFRewrite() after a rewrite = %v, want %v", got, second)
+	}
+	if got := r.lastAOFRewrite(map[string]string{"aof_rewrites": "4", "aof_last_bgrewrite_status": "err"}, third); !got.Equal(second) {
+		t.Errorf("lastAOFRewrite() after a failed rewrite = %v, want %v", got, second)
+	}
+	if got := r.lastAOFRewrite(map[string]string{}, third); !got.Equal(second) {
+		t.Errorf("lastAOFRewrite() without rewrite count = %v, want %v", got, second)
+	}
+}
@@ -19,15 +19,22 @@ package redismetrics
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/connectionsecret"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/tlsconfig"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
@@ -52,6 +59,7 @@ const (
 	appendonly             = "appendonly"
 	yes                    = "yes"
 	up                     = "up"
+	defaultHost            = "localhost"
 	defaultPort            = 6379
 	persistenceKey         = "persistence_enabled"
 	replicationKey         = "replication_enabled"
@@ -80,18 +88,44 @@ type RedisMetrics struct {
 	WLMClient   workloadmanager.WLMWriter
 	OSData      osinfo.Data
 	CurrentRole string
+	// capabilities of the host, the service settings are read with systemctl if it has systemd.
+	// All tools are assumed to be available if it is nil.
+	capabilities *capabilities.Capabilities
+
+	// aofRewrites is the AOF rewrite count seen by the previous collection, used to date rewrites.
+	aofRewrites        int64
+	aofRewritesSeen    bool
+	lastAOFRewriteTime time.Time
 }
 
 // New creates a new RedisMetrics object initialized with default values.
 func New(ctx context.Context, config *configpb.Configuration, wlmClient workloadmanager.WLMWriter, osData osinfo.Data) *RedisMetrics {
 	return &RedisMetrics{
-		Config:    config,
-		execute:   commandlineexecutor.ExecuteCommand,
-		WLMClient: wlmClient,
-		OSData:    osData,
+		Config:       config,
+		execute:      commandlineexecutor.ExecuteCommand,
+		WLMClient:    wlmClient,
+		OSData:       osData,
+		capabilities: capabilities.Host(),
 	}
 }
 
+// resolveConnectionSecret replaces the connection parameters with the ones in their secret
+// if it is a JSON connection secret. The configuration is copied, not modified.
+func (r *RedisMetrics) resolveConnectionSecret(ctx context.Context, gceService gceInterface) error {
+	cp := r.Config.GetRedisConfiguration().GetConnectionParameters()
+	resolved, err := connectionsecret.Resolve(ctx, cp, gceService)
+	if err != nil {
+		return fmt.Errorf("resolving connection secret: %w", err)
+	}
+	if resolved == cp {
+		return nil
+	}
+	config := proto.Clone(r.Config).(*configpb.Configuration)
+	config.RedisConfiguration.ConnectionParameters = resolved
+	r.Config = config
+	return nil
+}
+
 // password gets the password for the Redis database.
 // If the password is set in the configuration, it is used directly (not recommended).
 // Otherwise, if the secret configuration is set, the secret is fetched from GCE.
@@ -112,23 +146,60 @@ func (r *RedisMetrics) password(ctx context.Context, gceService gceInterface) (s
 	return secret.String(pw), nil
 }
 
+// Address returns the host:port of the configured Redis database.
+func (r *RedisMetrics) Address() string {
+	cp := r.Config.GetRedisConfiguration().GetConnectionParameters()
+	host := cp.GetHost()
+	if host == "" {
+		host = defaultHost
+	}
+	port := cp.GetPort()
+	if port == 0 {
+		port = defaultPort
+	}
+	return net.JoinHostPort(host, strconv.Itoa(int(port)))
+}
+
+// tlsConfig returns the TLS configuration for the connection, or nil if TLS is not enabled.
+func (r *RedisMetrics) tlsConfig(ctx context.Context, gceService gceInterface) (*tls.Config, error) {
+	host := r.Config.GetRedisConfiguration().GetConnectionParameters().GetHost()
+	if host == "" {
+		host = defaultHost
+	}
+	return tlsconfig.NewWithSecrets(ctx, r.Config.GetRedisConfiguration().GetTls(), host, gceService)
+}
+
 // InitDB initializes the Redis database client.
+// The username, if configured, authenticates as an ACL user instead of the default user.
 func (r *RedisMetrics) InitDB(ctx context.Context, gceService gceInterface) error {
+	if err := r.resolveConnectionSecret(ctx, gceService); err != nil {
+		return agenterrors.New(agenterrors.Authentication, err)
+	}
 	pw, err := r.password(ctx, gceService)
 	if err != nil {
-		return fmt.Errorf("failed to get password: %v", err)
+		return agenterrors.New(agenterrors.Authentication, fmt.Errorf("failed to get password: %v", err))
 	}
-	port := r.Config.GetRedisConfiguration().GetConnectionParameters().GetPort()
-	if port == 0 {
-		port = defaultPort
+	tlsConfig, err := r.tlsConfig(ctx, gceService)
+	if err != nil {
+		return fmt.Errorf("failed to configure TLS: %w", err)
 	}
 	r.db = redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("localhost:%d", port),
-		Password: pw.SecretValue(),
+		Addr:      r.Address(),
+		Username:  r.Config.GetRedisConfiguration().GetConnectionParameters().GetUsername(),
+		Password:  pw.SecretValue(),
+		TLSConfig: tlsConfig,
 	})
 	return nil
 }
 
+// Ping runs a trivial command to verify that the client created by InitDB can reach and authenticate to Redis.
+func (r *RedisMetrics) Ping(ctx context.Context) error {
+	if err := r.db.Info(ctx, "server").Err(); err != nil {
+		return fmt.Errorf("running test command: %w", err)
+	}
+	return nil
+}
+
 func (r *RedisMetrics) getCurrentRole(ctx context.Context) string {
 	replication := r.db.Info(ctx, "replication")
 	log.CtxLogger(ctx).Debugf("replication: %v", replication)
@@ -163,7 +234,7 @@ func getIP(s string) (string, error) {
 	return "", errors.New("ip not found")
 }
 
-func (r *RedisMetrics) replicationZones(ctx context.Context, currentRole string, netLookupAddr func(ip string) ([]string, error)) []string {
+func (r *RedisMetrics) replicationZones(ctx context.Context, currentRole string, zoneResolver *ipinfo.ZoneResolver) []string {
 	var workerIPs []string
 	replication := r.db.Info(ctx, "replication")
 	log.CtxLogger(ctx).Debugf("replication: %v", replication)
@@ -184,8 +255,7 @@ func (r *RedisMetrics) replicationZones(ctx context.Context, currentRole string,
 			workerIPs = append(workerIPs, ip)
 		}
 	}
-	zones := ipinfo.ZonesFromIPs(ctx, workerIPs, netLookupAddr)
-	return zones
+	return zoneResolver.Zones(ctx, workerIPs)
 }
 
 func (r *RedisMetrics) replicationModeActive(ctx context.Context, currentRole string) bool {
@@ -240,7 +310,19 @@ func (r *RedisMetrics) persistenceEnabled(ctx context.Context) bool {
 	return false
 }
 
+// hasSystemd returns true if the Redis service settings can be read with systemctl.
+func (r *RedisMetrics) hasSystemd(ctx context.Context) bool {
+	if r.capabilities != nil && !r.capabilities.Has(capabilities.Systemd) {
+		log.CtxLogger(ctx).Debugw("Redis service settings are not collected on hosts without systemd")
+		return false
+	}
+	return true
+}
+
 func (r *RedisMetrics) serviceEnabled(ctx context.Context) bool {
+	if !r.hasSystemd(ctx) {
+		return false
+	}
 	processName := RedisProcessName
 	if r.OSData.OSVendor == "debian" {
 		processName = RedisServerProcessName
@@ -261,6 +343,9 @@ func (r *RedisMetrics) serviceEnabled(ctx context.Context) bool {
 }
 
 func (r *RedisMetrics) serviceRestart(ctx context.Context) bool {
+	if !r.hasSystemd(ctx) {
+		return false
+	}
 	processName := RedisProcessName
 	if r.OSData.OSVendor == "debian" {
 		processName = RedisServerProcessName
@@ -277,13 +362,16 @@ func (r *RedisMetrics) serviceRestart(ctx context.Context) bool {
 }
 
 // CollectMetricsOnce collects metrics for Redis databases running on the host.
+// An error is returned if the metrics could not be sent, together with the collected metrics.
 func (r *RedisMetrics) CollectMetricsOnce(ctx context.Context, dwActivated bool) (*workloadmanager.WorkloadMetrics, error) {
+	start := time.Now()
 	currentRole := r.getCurrentRole(ctx)
 	replicationOn := r.replicationModeActive(ctx, currentRole)
 	persistenceOn := r.persistenceEnabled(ctx)
 	serviceEnabled := r.serviceEnabled(ctx)
 	serviceRestart := r.serviceRestart(ctx)
-	replicationZones := r.replicationZones(ctx, currentRole, net.LookupAddr)
+	replicationZones := r.replicationZones(ctx, currentRole, ipinfo.DefaultZoneResolver())
+	persistence := r.persistenceMetrics(ctx, start)
 	log.CtxLogger(ctx).Debugw("Finished collecting metrics once. Next step is to send to WLM (DW).",
 		replicationKey, replicationOn,
 		persistenceKey, persistenceOn,
@@ -291,6 +379,7 @@ func (r *RedisMetrics) CollectMetricsOnce(ctx context.Context, dwActivated bool)
 		serviceRestartKey, serviceRestart,
 		replicationZonesKey, strings.Join(replicationZones, ","),
 		currentRoleKey, currentRole,
+		"persistence", persistence,
 	)
 	metrics := workloadmanager.WorkloadMetrics{
 		WorkloadType: workloadmanager.REDIS,
@@ -302,18 +391,29 @@ func (r *RedisMetrics) CollectMetricsOnce(ctx context.Context, dwActivated bool)
 			replicationZonesKey: strings.Join(replicationZones, ","),
 			currentRoleKey:      currentRole,
 		},
+		CollectionTime: start,
+	}
+	for k, v := range persistence {
+		metrics.Metrics[k] = v
+	}
+	for k, v := range r.memoryMetrics(ctx) {
+		metrics.Metrics[k] = v
 	}
+	metrics.AddRecommendations(ctx, recommendations(metrics.Metrics))
+	metrics.CollectionDuration = time.Since(start)
 	if !dwActivated {
 		log.CtxLogger(ctx).Debugw("Data Warehouse is not activated, not sending metrics to Data Warehouse")
 		return &metrics, nil
 	}
 	res, err := workloadmanager.SendDataInsight(ctx, workloadmanager.SendDataInsightParams{
-		WLMetrics:  metrics,
-		CloudProps: r.Config.GetCloudProperties(),
-		WLMService: r.WLMClient,
+		WLMetrics:           metrics,
+		CloudProps:          r.Config.GetCloudProperties(),
+		WLMService:          r.WLMClient,
+		StrictWorkloadTypes: r.Config.GetDataWarehouseStrictWorkloadTypes(),
+		Schema:              metricSchema,
 	})
 	if err != nil {
-		return nil, err
+		return &metrics, err
 	}
 	if res == nil {
 		log.CtxLogger(ctx).Warn("SendDataInsight did not return an error but the WriteInsight response is nil")
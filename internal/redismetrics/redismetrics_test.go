@@ -20,12 +20,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"os"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
@@ -51,9 +56,19 @@ type testDB struct {
 	cfgErr       error
 	addr         string
 	db           int
+	// configs holds the values of configuration parameters other than save and appendonly.
+	configs         map[string]string
+	persistenceInfo *redis.StringCmd
+	memoryInfo      *redis.StringCmd
 }
 
 func (t *testDB) Info(ctx context.Context, args ...string) *redis.StringCmd {
+	if len(args) > 0 && args[0] == "persistence" && t.persistenceInfo != nil {
+		return t.persistenceInfo
+	}
+	if len(args) > 0 && args[0] == "memory" && t.memoryInfo != nil {
+		return t.memoryInfo
+	}
 	return t.info
 }
 
@@ -64,13 +79,36 @@ func (t *testDB) ConfigGet(ctx context.Context, key string) *redis.MapStringStri
 	if key == save {
 		return t.saveConfig
 	}
-	return nil
+	values := map[string]string{}
+	if v, ok := t.configs[key]; ok {
+		values[key] = v
+	}
+	return redis.NewMapStringStringResult(values, nil)
 }
 
 func (t *testDB) String() string {
 	return fmt.Sprintf("Redis<%s db:%d>", t.addr, t.db)
 }
 
+// fakeResolver maps IPs to their host names and resolves no host names.
+type fakeResolver map[string][]string
+
+func (f fakeResolver) LookupHost(host string) ([]string, error) {
+	return nil, errors.New("no such host")
+}
+
+func (f fakeResolver) ParseIP(ip string) net.IP {
+	return net.ParseIP(ip)
+}
+
+func (f fakeResolver) LookupAddr(ip string) ([]string, error) {
+	names, ok := f[ip]
+	if !ok {
+		return nil, errors.New("invalid IP")
+	}
+	return names, nil
+}
+
 func TestInitPassword(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -233,12 +271,17 @@ func TestCollectMetricsOnce(t *testing.T) {
 			want: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.REDIS,
 				Metrics: map[string]string{
-					replicationKey:      "true",
-					persistenceKey:      "true",
-					serviceEnabledKey:   "true",
-					serviceRestartKey:   "true",
-					replicationZonesKey: "",
-					currentRoleKey:      main,
+					replicationKey:             "true",
+					persistenceKey:             "true",
+					serviceEnabledKey:          "true",
+					serviceRestartKey:          "true",
+					replicationZonesKey:        "",
+					currentRoleKey:             main,
+					appendfsyncKey:             "",
+					aofUseRDBPreambleKey:       "",
+					savePointsKey:              "3600 1 300 100 60 10000",
+					lastRDBSaveTimestampKey:    "",
+					lastAOFRewriteTimestampKey: "",
 				},
 			},
 			wantErr: false,
@@ -299,12 +342,17 @@ func TestCollectMetricsOnce(t *testing.T) {
 			want: &workloadmanager.WorkloadMetrics{
 				WorkloadType: workloadmanager.REDIS,
 				Metrics: map[string]string{
-					replicationKey:      "true",
-					persistenceKey:      "true",
-					serviceEnabledKey:   "true",
-					serviceRestartKey:   "true",
-					replicationZonesKey: "",
-					currentRoleKey:      main,
+					replicationKey:             "true",
+					persistenceKey:             "true",
+					serviceEnabledKey:          "true",
+					serviceRestartKey:          "true",
+					replicationZonesKey:        "",
+					currentRoleKey:             main,
+					appendfsyncKey:             "",
+					aofUseRDBPreambleKey:       "",
+					savePointsKey:              "3600 1 300 100 60 10000",
+					lastRDBSaveTimestampKey:    "",
+					lastAOFRewriteTimestampKey: "",
 				},
 			},
 			wantErr: false,
@@ -332,7 +380,7 @@ func TestCollectMetricsOnce(t *testing.T) {
 				}
 				return
 			}
-			if diff := cmp.Diff(tc.want, got, protocmp.Transform()); diff != "" {
+			if diff := cmp.Diff(tc.want, got, protocmp.Transform(), cmpopts.IgnoreFields(workloadmanager.WorkloadMetrics{}, "CollectionTime", "CollectionDuration")); diff != "" {
 				t.Errorf("CollectMetricsOnce(%v) = %v, want %v", tc.name, got, tc.want)
 			}
 		})
@@ -468,6 +516,12 @@ func TestPersistenceEnabled(t *testing.T) {
 	}
 }
 
+// withoutSystemd are the capabilities of a Linux host which doesn't run systemd.
+var withoutSystemd = capabilities.Probe(capabilities.Linux, capabilities.AMD64,
+	func(string) (string, error) { return "", errors.New("executable file not found") },
+	func(string) (os.FileInfo, error) { return nil, os.ErrNotExist },
+)
+
 func TestServiceEnabled(t *testing.T) {
 	tests := []struct {
 		name string
@@ -507,6 +561,17 @@ func TestServiceEnabled(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "NoSystemd",
+			r: RedisMetrics{
+				OSData: osinfo.Data{OSName: "linux", OSVendor: "debian", OSVersion: "12"},
+				execute: func(ctx context.Context, p commandlineexecutor.Params) commandlineexecutor.Result {
+					return commandlineexecutor.Result{StdOut: "enabled"}
+				},
+				capabilities: withoutSystemd,
+			},
+			want: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -558,6 +623,17 @@ func TestServiceRestart(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "NoSystemd",
+			r: RedisMetrics{
+				OSData: osinfo.Data{OSName: "linux", OSVendor: "debian", OSVersion: "12"},
+				execute: func(ctx context.Context, p commandlineexecutor.Params) commandlineexecutor.Result {
+					return commandlineexecutor.Result{StdOut: "Restart=always\n"}
+				},
+				capabilities: withoutSystemd,
+			},
+			want: false,
+		},
 	}
 
 	for _, tc := range tests {
@@ -627,6 +703,35 @@ func TestInitDB(t *testing.T) {
 			want:       fmt.Sprintf("Redis<%s db:%d>", "localhost:1234", 0),
 			wantErr:    false,
 		},
+		{
+			name: "ConfigHostAndACLUser",
+			r: RedisMetrics{
+				Config: &configpb.Configuration{
+					RedisConfiguration: &configpb.RedisConfiguration{
+						ConnectionParameters: &configpb.ConnectionParameters{
+							Host:     "10.0.0.1",
+							Username: "agent",
+							Password: "fake-password",
+						},
+					},
+				},
+			},
+			gceService: &gcefake.TestGCE{},
+			want:       fmt.Sprintf("Redis<%s db:%d>", "10.0.0.1:6379", 0),
+			wantErr:    false,
+		},
+		{
+			name: "TLSError",
+			r: RedisMetrics{
+				Config: &configpb.Configuration{
+					RedisConfiguration: &configpb.RedisConfiguration{
+						Tls: &configpb.TLSConfiguration{Enabled: true, CaFile: "/does/not/exist.pem"},
+					},
+				},
+			},
+			gceService: &gcefake.TestGCE{},
+			wantErr:    true,
+		},
 		{
 			name: "PasswordError",
 			r: RedisMetrics{
@@ -716,19 +821,12 @@ slave1:ip=5.6.7.8,port=6380,state=online`,
 			r := RedisMetrics{
 				db: testDB,
 			}
-			// Mock net.LookupAddr for testing purposes
-			netLookupAddr := func(ip string) ([]string, error) {
-				switch ip {
-				case "1.2.3.4":
-					return []string{"hostname.test-zone.c.fake-project.internal."}, nil
-				case "5.6.7.8":
-					return []string{"hostname.test-zone2.c.fake-project.internal."}, nil
-				default:
-					return nil, errors.New("invalid IP")
-				}
+			resolver := fakeResolver{
+				"1.2.3.4": {"hostname.test-zone.c.fake-project.internal."},
+				"5.6.7.8": {"hostname.test-zone2.c.fake-project.internal."},
 			}
 
-			got := r.replicationZones(context.Background(), tc.role, netLookupAddr)
+			got := r.replicationZones(context.Background(), tc.role, ipinfo.NewZoneResolver(resolver))
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("replicationZones() test %v returned diff (-want +got):\n%s", tc.name, diff)
 			}
@@ -787,3 +885,51 @@ func TestGetIP(t *testing.T) {
 		})
 	}
 }
+
+func TestTLSConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		config         *configpb.RedisConfiguration
+		wantNil        bool
+		wantServerName string
+		wantInsecure   bool
+	}{
+		{
+			name:    "Disabled",
+			config:  &configpb.RedisConfiguration{},
+			wantNil: true,
+		},
+		{
+			name:           "DefaultHost",
+			config:         &configpb.RedisConfiguration{Tls: &configpb.TLSConfiguration{Enabled: true}},
+			wantServerName: "localhost",
+		},
+		{
+			name: "ConfiguredHost",
+			config: &configpb.RedisConfiguration{
+				ConnectionParameters: &configpb.ConnectionParameters{Host: "redis.example.com"},
+				Tls:                  &configpb.TLSConfiguration{Enabled: true, InsecureSkipVerify: true},
+			},
+			wantServerName: "redis.example.com",
+			wantInsecure:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &RedisMetrics{Config: &configpb.Configuration{RedisConfiguration: tc.config}}
+			got, err := r.tlsConfig(context.Background(), nil)
+			if err != nil {
+				t.Fatalf("tlsConfig() returned unexpected error: %v", err)
+			}
+			if tc.wantNil {
+				if got != nil {
+					t.Errorf("tlsConfig() = %v, want nil", got)
+				}
+				return
+			}
+			if got.ServerName != tc.wantServerName || got.InsecureSkipVerify != tc.wantInsecure {
+				t.Errorf("tlsConfig() = {ServerName: %q, InsecureSkipVerify: %v}, want {ServerName: %q, InsecureSkipVerify: %v}", got.ServerName, got.InsecureSkipVerify, tc.wantServerName, tc.wantInsecure)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redismetrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	maxmemoryKey         = "maxmemory"
+	maxmemoryPolicyKey   = "maxmemory_policy"
+	totalSystemMemoryKey = "total_system_memory"
+)
+
+// maxmemoryRatio is the best-practice limit of the Redis dataset relative to the host memory, which
+// leaves room for fragmentation, client buffers and the copy-on-write pages of persistence forks.
+var maxmemoryRatio = workloadmanager.MemoryRatio{
+	Setting: "maxmemory",
+	Memory:  "the host memory",
+	Max:     0.75,
+}
+
+// memoryMetrics returns the memory limit of Redis and the memory of the host it reports.
+func (r *RedisMetrics) memoryMetrics(ctx context.Context) map[string]string {
+	info, err := r.db.Info(ctx, "memory").Result()
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Failed to get Redis memory info", "error", err)
+		return nil
+	}
+	fields := parseInfo(info)
+	metrics := make(map[string]string)
+	for key, field := range map[string]string{
+		maxmemoryKey:         "maxmemory",
+		maxmemoryPolicyKey:   "maxmemory_policy",
+		totalSystemMemoryKey: "total_system_memory",
+	} {
+		if v, ok := fields[field]; ok {
+			metrics[key] = v
+		}
+	}
+	return metrics
+}
+
+// recommendations returns the right-sizing recommendations for the collected settings.
+func recommendations(metrics map[string]string) []string {
+	totalSystemMemory, err := strconv.ParseInt(metrics[totalSystemMemoryKey], 10, 64)
+	if err != nil || totalSystemMemory <= 0 {
+		return nil
+	}
+	maxmemory, err := strconv.ParseInt(metrics[maxmemoryKey], 10, 64)
+	if err != nil {
+		return nil
+	}
+	if maxmemory == 0 {
+		return []string{fmt.Sprintf("Set maxmemory: it is unlimited, best practice is at most %s of the host memory (%s).",
+			units.FormatPercent(maxmemoryRatio.Max), units.FormatMemory(totalSystemMemory))}
+	}
+	if rec, ok := maxmemoryRatio.Recommend(maxmemory, totalSystemMemory); ok {
+		return []string{rec}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redismetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMemoryMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    string
+		infoErr error
+		want    map[string]string
+	}{
+		{
+			name: "AllFields",
+			info: "# Memory\r\nused_memory:1048576\r\ntotal_system_memory:8589934592\r\nmaxmemory:0\r\nmaxmemory_policy:noeviction\r\n",
+			want: map[string]string{
+				maxmemoryKey:         "0",
+				maxmemoryPolicyKey:   "noeviction",
+				totalSystemMemoryKey: "8589934592",
+			},
+		},
+		{
+			name: "MissingFields",
+			info: "# Memory\r\nused_memory:1048576\r\nmaxmemory:1073741824\r\n",
+			want: map[string]string{maxmemoryKey: "1073741824"},
+		},
+		{
+			name:    "InfoError",
+			infoErr: errors.New("info failed"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &RedisMetrics{db: &testDB{memoryInfo: redis.NewStringResult(tc.info, tc.infoErr)}}
+			got := r.memoryMetrics(context.Background())
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("memoryMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			for name, value := range got {
+				if d, ok := metricSchema.Definition(name); !ok {
+					t.Errorf("memoryMetrics() returned metric %s which is not defined in the schema", name)
+				} else if err := d.Validate(value); err != nil {
+					t.Errorf("memoryMetrics() returned an invalid metric: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestRecommendations(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics map[string]string
+		want    []string
+	}{
+		{
+			name:    "UnlimitedMaxmemory",
+			metrics: map[string]string{maxmemoryKey: "0", totalSystemMemoryKey: "8589934592"},
+			want:    []string{"Set maxmemory: it is unlimited, best practice is at most 75% of the host memory (8GB)."},
+		},
+		{
+			name:    "LargeMaxmemory",
+			metrics: map[string]string{maxmemoryKey: "8589934592", totalSystemMemoryKey: "8589934592"},
+			want:    []string{"Decrease maxmemory from 8GB to 6GB: it is 100% of the host memory, best practice is at most 75%."},
+		},
+		{
+			name:    "WellSizedMaxmemory",
+			metrics: map[string]string{maxmemoryKey: "4294967296", totalSystemMemoryKey: "8589934592"},
+		},
+		{
+			name:    "UnknownHostMemory",
+			metrics: map[string]string{maxmemoryKey: "0"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := recommendations(tc.metrics)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("recommendations(%v) returned unexpected diff (-want +got):\n%s", tc.metrics, diff)
+			}
+		})
+	}
+}
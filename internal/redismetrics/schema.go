@@ -0,0 +1,44 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redismetrics
+
+import "github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
+
+// metricSchema defines the metrics sent to Data Warehouse for Redis.
+var metricSchema = workloadmanager.NewSchema(
+	workloadmanager.MetricDefinition{Name: replicationKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: persistenceKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: serviceEnabledKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: serviceRestartKey, Type: workloadmanager.BoolMetric},
+	workloadmanager.MetricDefinition{Name: replicationZonesKey, Type: workloadmanager.ListMetric},
+	workloadmanager.MetricDefinition{Name: currentRoleKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{main, worker}},
+	// Persistence.
+	workloadmanager.MetricDefinition{Name: appendfsyncKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{"always", "everysec", "no"}},
+	workloadmanager.MetricDefinition{Name: aofUseRDBPreambleKey, Type: workloadmanager.EnumMetric, AllowedValues: []string{"yes", "no"}},
+	workloadmanager.MetricDefinition{Name: savePointsKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: lastRDBSaveTimestampKey, Type: workloadmanager.TimestampMetric},
+	workloadmanager.MetricDefinition{Name: lastAOFRewriteTimestampKey, Type: workloadmanager.TimestampMetric},
+	// Memory.
+	workloadmanager.MetricDefinition{Name: maxmemoryKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitBytes},
+	workloadmanager.MetricDefinition{Name: maxmemoryPolicyKey, Type: workloadmanager.StringMetric},
+	workloadmanager.MetricDefinition{Name: totalSystemMemoryKey, Type: workloadmanager.IntMetric, Unit: workloadmanager.UnitBytes},
+)
+
+// MetricSchema returns the schema of the metrics sent to Data Warehouse for Redis.
+func MetricSchema() *workloadmanager.Schema {
+	return metricSchema
+}
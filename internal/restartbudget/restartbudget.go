@@ -0,0 +1,155 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package restartbudget limits how often the agent restarts a service which keeps panicking.
+package restartbudget
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	// DefaultMaxRestartsPerHour is the number of restarts within an hour after which a service is quarantined.
+	DefaultMaxRestartsPerHour = 5
+
+	window = time.Hour
+)
+
+// Backoff is the backoff.BackOff of a recovery.RecoverableRoutine running a service.
+//
+// Restarts after a panic are delayed exponentially. Once the service panicked more often
+// than its budget allows within an hour, it is quarantined: it is not restarted again and
+// a ServiceQuarantined usage metric is reported.
+type Backoff struct {
+	service     string
+	maxRestarts int
+	exponential *backoff.ExponentialBackOff
+	now         func() time.Time
+
+	mu          sync.Mutex
+	restarts    []time.Time
+	total       int
+	quarantined bool
+}
+
+var (
+	registryMu sync.Mutex
+	// registry holds the latest Backoff of every service, reported by Statuses.
+	registry = make(map[string]*Backoff)
+)
+
+// Status is the restart budget state of a service as reported on the control socket.
+type Status struct {
+	Service     string
+	Panics      int
+	Quarantined bool
+}
+
+// New returns a Backoff for the named service with the restart budget of config.
+// It replaces the Backoff previously created for the service in the statuses.
+func New(service string, config *cpb.ServiceRecovery) *Backoff {
+	maxRestarts := int(config.GetMaxRestartsPerHour())
+	if maxRestarts <= 0 {
+		maxRestarts = DefaultMaxRestartsPerHour
+	}
+	exponential := backoff.NewExponentialBackOff()
+	// The restart budget decides when to give up instead of the elapsed time.
+	exponential.MaxElapsedTime = 0
+	b := &Backoff{
+		service:     service,
+		maxRestarts: maxRestarts,
+		exponential: exponential,
+		now:         time.Now,
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[service] = b
+	return b
+}
+
+// Statuses returns the status of every service with a restart budget, sorted by service name.
+func Statuses() []Status {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	statuses := make([]Status, 0, len(registry))
+	for service, b := range registry {
+		statuses = append(statuses, Status{Service: service, Panics: b.Panics(), Quarantined: b.Quarantined()})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Service < statuses[j].Service })
+	return statuses
+}
+
+// NextBackOff is called after the service panicked and returns how long to wait before restarting it,
+// or backoff.Stop once the service is quarantined.
+func (b *Backoff) NextBackOff() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.quarantined {
+		return backoff.Stop
+	}
+
+	now := b.now()
+	recent := b.restarts[:0]
+	for _, t := range b.restarts {
+		if now.Sub(t) < window {
+			recent = append(recent, t)
+		}
+	}
+	b.restarts = append(recent, now)
+	b.total++
+
+	if len(b.restarts) > b.maxRestarts {
+		b.quarantined = true
+		log.Logger.Errorw("Service panicked too often and is quarantined, restart the agent to start it again",
+			"service", b.service, "restartsInLastHour", len(b.restarts)-1, "maxRestartsPerHour", b.maxRestarts, "totalPanics", b.total)
+		usagemetrics.Error(usagemetrics.ServiceQuarantined)
+		return backoff.Stop
+	}
+	next := b.exponential.NextBackOff()
+	log.Logger.Warnw("Restarting service after a panic",
+		"service", b.service, "restartsInLastHour", len(b.restarts), "maxRestartsPerHour", b.maxRestarts, "totalPanics", b.total, "delay", next)
+	return next
+}
+
+// Reset resets the exponential delay once the service ran for its expected minimum duration.
+// Restarts within the last hour still count against the budget.
+func (b *Backoff) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.exponential.Reset()
+}
+
+// Panics returns the total number of times the service panicked.
+func (b *Backoff) Panics() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
+
+// Quarantined reports whether the service has been quarantined.
+func (b *Backoff) Quarantined() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.quarantined
+}
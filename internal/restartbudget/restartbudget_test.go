@@ -0,0 +1,124 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restartbudget
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/google/go-cmp/cmp"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestNextBackOff(t *testing.T) {
+	tests := []struct {
+		name            string
+		config          *cpb.ServiceRecovery
+		panics          []time.Duration
+		wantQuarantined bool
+	}{
+		{
+			name:   "WithinDefaultBudget",
+			panics: []time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute},
+		},
+		{
+			name:            "DefaultBudgetExhausted",
+			panics:          []time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute, 5 * time.Minute},
+			wantQuarantined: true,
+		},
+		{
+			name:   "OldRestartsExpire",
+			panics: []time.Duration{0, time.Minute, 2 * time.Minute, 3 * time.Minute, 4 * time.Minute, 61 * time.Minute},
+		},
+		{
+			name:            "ConfiguredBudget",
+			config:          &cpb.ServiceRecovery{MaxRestartsPerHour: 1},
+			panics:          []time.Duration{0, 30 * time.Minute},
+			wantQuarantined: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			b := New("test service", tc.config)
+			var got time.Duration
+			for _, after := range tc.panics {
+				b.now = func() time.Time { return start.Add(after) }
+				got = b.NextBackOff()
+			}
+
+			if gotStop := got == backoff.Stop; gotStop != tc.wantQuarantined {
+				t.Errorf("NextBackOff() = %v after %d panics, want backoff.Stop: %t", got, len(tc.panics), tc.wantQuarantined)
+			}
+			if b.Quarantined() != tc.wantQuarantined {
+				t.Errorf("Quarantined() = %t, want %t", b.Quarantined(), tc.wantQuarantined)
+			}
+			if b.Panics() != len(tc.panics) {
+				t.Errorf("Panics() = %d, want %d", b.Panics(), len(tc.panics))
+			}
+		})
+	}
+}
+
+func TestQuarantinedStaysStopped(t *testing.T) {
+	b := New("test service", &cpb.ServiceRecovery{MaxRestartsPerHour: 1})
+	b.NextBackOff()
+	b.NextBackOff()
+	b.Reset()
+	b.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if got := b.NextBackOff(); got != backoff.Stop {
+		t.Errorf("NextBackOff() of a quarantined service = %v, want backoff.Stop", got)
+	}
+}
+
+func TestResetKeepsBudget(t *testing.T) {
+	b := New("test service", &cpb.ServiceRecovery{MaxRestartsPerHour: 2})
+	first := b.NextBackOff()
+	b.NextBackOff()
+	b.Reset()
+	if got := b.NextBackOff(); got != backoff.Stop {
+		t.Errorf("NextBackOff() after Reset() = %v, want backoff.Stop as the budget is exhausted", got)
+	}
+	if first <= 0 || first > backoff.DefaultMaxInterval {
+		t.Errorf("NextBackOff() of the first panic = %v, want a delay in (0, %v]", first, backoff.DefaultMaxInterval)
+	}
+}
+
+func TestStatuses(t *testing.T) {
+	healthy := New("TestStatusesHealthy", &cpb.ServiceRecovery{MaxRestartsPerHour: 1})
+	healthy.NextBackOff()
+	quarantined := New("TestStatusesQuarantined", &cpb.ServiceRecovery{MaxRestartsPerHour: 1})
+	quarantined.NextBackOff()
+	quarantined.NextBackOff()
+
+	want := map[string]Status{
+		"TestStatusesHealthy":     {Service: "TestStatusesHealthy", Panics: 1},
+		"TestStatusesQuarantined": {Service: "TestStatusesQuarantined", Panics: 2, Quarantined: true},
+	}
+	got := make(map[string]Status)
+	for _, s := range Statuses() {
+		if _, ok := want[s.Service]; ok {
+			got[s.Service] = s
+		}
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Statuses() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
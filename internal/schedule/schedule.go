@@ -0,0 +1,89 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule spreads the periodic collections of the agent services across a fleet,
+// so that agents started at the same time do not write to Data Warehouse at the same time.
+package schedule
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	// MaxStartOffset is the longest random delay of the first collection of a service.
+	MaxStartOffset = 5 * time.Minute
+	// MaxJitter is the largest fraction of the collection frequency an interval is adjusted by.
+	MaxJitter = 0.5
+)
+
+// random returns a pseudo-random number in [0, 1).
+var random = rand.Float64
+
+// StartOffset returns a random delay for the first collection of a service collecting every frequency.
+// It is shorter than both frequency and MaxStartOffset, and zero if randomized starts are disabled.
+func StartOffset(config *cpb.CollectionSchedule, frequency time.Duration) time.Duration {
+	if config != nil && config.RandomizeStart != nil && !config.GetRandomizeStart() {
+		return 0
+	}
+	limit := min(frequency, MaxStartOffset)
+	if limit <= 0 {
+		return 0
+	}
+	return time.Duration(random() * float64(limit))
+}
+
+// WaitStart waits for the StartOffset of a service collecting every frequency and restarts
+// ticker, so that the following collections keep the offset. It returns false if ctx is done first.
+func WaitStart(ctx context.Context, ticker *time.Ticker, config *cpb.CollectionSchedule, frequency time.Duration) bool {
+	offset := StartOffset(config, frequency)
+	if offset == 0 {
+		return ctx.Err() == nil
+	}
+	log.CtxLogger(ctx).Debugw("Delaying the first collection", "offset", offset)
+	timer := time.NewTimer(offset)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		ticker.Reset(frequency)
+		return true
+	}
+}
+
+// Interval returns frequency randomly shortened or lengthened by the configured jitter.
+func Interval(config *cpb.CollectionSchedule, frequency time.Duration) time.Duration {
+	jitter := min(config.GetJitter(), MaxJitter)
+	if jitter <= 0 {
+		return frequency
+	}
+	return time.Duration(float64(frequency) * (1 + jitter*(2*random()-1)))
+}
+
+// Jitter resets ticker to the next jittered Interval.
+// The ticker is left unchanged if no jitter is configured.
+func Jitter(ticker *time.Ticker, config *cpb.CollectionSchedule, frequency time.Duration) {
+	if config.GetJitter() <= 0 || frequency <= 0 {
+		return
+	}
+	ticker.Reset(Interval(config, frequency))
+}
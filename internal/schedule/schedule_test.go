@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+var origRandom = random
+
+func TestStartOffset(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *cpb.CollectionSchedule
+		frequency time.Duration
+		random    float64
+		want      time.Duration
+	}{
+		{
+			name:      "DefaultRandomizesStart",
+			frequency: time.Minute,
+			random:    0.5,
+			want:      30 * time.Second,
+		},
+		{
+			name:      "LimitedToMaxStartOffset",
+			config:    &cpb.CollectionSchedule{RandomizeStart: proto.Bool(true)},
+			frequency: time.Hour,
+			random:    0.5,
+			want:      MaxStartOffset / 2,
+		},
+		{
+			name:      "Disabled",
+			config:    &cpb.CollectionSchedule{RandomizeStart: proto.Bool(false)},
+			frequency: time.Hour,
+			random:    0.5,
+			want:      0,
+		},
+		{
+			name:   "NoFrequency",
+			random: 0.5,
+			want:   0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			random = func() float64 { return tc.random }
+			defer func() { random = origRandom }()
+			if got := StartOffset(tc.config, tc.frequency); got != tc.want {
+				t.Errorf("StartOffset(%v, %v) = %v, want %v", tc.config, tc.frequency, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *cpb.CollectionSchedule
+		random float64
+		want   time.Duration
+	}{
+		{
+			name:   "NoJitter",
+			random: 0,
+			want:   10 * time.Minute,
+		},
+		{
+			name:   "Shortened",
+			config: &cpb.CollectionSchedule{Jitter: 0.1},
+			random: 0,
+			want:   9 * time.Minute,
+		},
+		{
+			name:   "Lengthened",
+			config: &cpb.CollectionSchedule{Jitter: 0.1},
+			random: 0.75,
+			want:   10*time.Minute + 30*time.Second,
+		},
+		{
+			name:   "LimitedToMaxJitter",
+			config: &cpb.CollectionSchedule{Jitter: 2},
+			random: 0,
+			want:   5 * time.Minute,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			random = func() float64 { return tc.random }
+			defer func() { random = origRandom }()
+			if got := Interval(tc.config, 10*time.Minute); got != tc.want {
+				t.Errorf("Interval(%v, 10m) = %v, want %v", tc.config, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWaitStart(t *testing.T) {
+	random = func() float64 { return 0.5 }
+	defer func() { random = origRandom }()
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	if !WaitStart(context.Background(), ticker, nil, 20*time.Millisecond) {
+		t.Error("WaitStart() = false, want true")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if WaitStart(ctx, ticker, nil, time.Hour) {
+		t.Error("WaitStart() with a cancelled context = true, want false")
+	}
+	if WaitStart(ctx, ticker, &cpb.CollectionSchedule{RandomizeStart: proto.Bool(false)}, time.Hour) {
+		t.Error("WaitStart() with a cancelled context and no offset = true, want false")
+	}
+}
@@ -19,8 +19,12 @@ package datawarehouseactivation
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"sort"
 	"time"
 
+	"google.golang.org/api/googleapi"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
@@ -29,6 +33,13 @@ import (
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
 
+const (
+	// DefaultCheckInterval is how often the activation is checked while Data Warehouse is not activated.
+	DefaultCheckInterval = 5 * time.Minute
+	// DefaultRecheckInterval is how often the activation is verified again once Data Warehouse is activated.
+	DefaultRecheckInterval = time.Hour
+)
+
 // Service is used to perform data warehouse activation checks.
 type Service struct {
 	Config *cpb.Configuration
@@ -54,8 +65,9 @@ func (s activationStatus) String() string {
 }
 
 // checkActivation checks if the data warehouse is activated by sending an empty insight.
-// The data warehouse returns a 201 status code if it is activated.
-func (s Service) checkActivation(ctx context.Context) bool {
+// The data warehouse returns a 201 status code if it is activated. An error is returned if the
+// check is inconclusive, e.g. because Data Warehouse could not be reached.
+func (s Service) checkActivation(ctx context.Context) (bool, error) {
 	res, err := workloadmanager.QuietSendDataInsight(ctx, workloadmanager.SendDataInsightParams{
 		WLMetrics: workloadmanager.WorkloadMetrics{
 			WorkloadType: workloadmanager.UNKNOWN,
@@ -66,18 +78,29 @@ func (s Service) checkActivation(ctx context.Context) bool {
 	})
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("Context for DW Status being not activated.", "error", err)
-		return false
+		// The API is disabled for the project or the agent is not authorized to use it.
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code >= http.StatusBadRequest && apiErr.Code < http.StatusInternalServerError {
+			return false, nil
+		}
+		return false, err
 	}
 	if res == nil {
-		return false
+		return false, nil
 	}
 	log.CtxLogger(ctx).Debugw("WriteInsight response", "StatusCode", res.HTTPStatusCode)
-	return res.HTTPStatusCode == 201
+	return res.HTTPStatusCode == http.StatusCreated, nil
 }
 
+// dwActivationLoop checks the activation and reports whether it differs from currentStatus.
+// An inconclusive check keeps an activated status, so a transient failure does not stop the collectors.
 func (s Service) dwActivationLoop(ctx context.Context, currentStatus activationStatus) (servicecommunication.DataWarehouseActivationResult, bool) {
 	log.CtxLogger(ctx).Infow("dwActivationLoop started.", "Current status", currentStatus)
-	dwActivationStatus := s.checkActivation(ctx)
+	dwActivationStatus, err := s.checkActivation(ctx)
+	if err != nil && currentStatus == activated {
+		log.CtxLogger(ctx).Debugw("Data warehouse activation check was inconclusive, keeping the current status.", "error", err)
+		return servicecommunication.DataWarehouseActivationResult{Activated: true}, false
+	}
 	status := notActivated
 	if dwActivationStatus {
 		status = activated
@@ -90,23 +113,43 @@ func (s Service) dwActivationLoop(ctx context.Context, currentStatus activationS
 	return servicecommunication.DataWarehouseActivationResult{Activated: dwActivationStatus}, statusChanged
 }
 
-func (s Service) sendActivationResult(ctx context.Context, chs map[string]chan<- *servicecommunication.Message, result servicecommunication.DataWarehouseActivationResult) {
-	var fullChs []string
+// sendActivationResult publishes result to the channels and returns the channels which were full.
+func (s Service) sendActivationResult(ctx context.Context, chs map[string]chan<- *servicecommunication.Message, result servicecommunication.DataWarehouseActivationResult) map[string]chan<- *servicecommunication.Message {
+	fullChs := make(map[string]chan<- *servicecommunication.Message)
 	msg := &servicecommunication.Message{Origin: servicecommunication.DWActivation, DWActivationResult: result}
 	for key, ch := range chs {
 		select {
 		case ch <- msg:
 		default:
-			fullChs = append(fullChs, key)
+			fullChs[key] = ch
 		}
 	}
 	if len(fullChs) > 0 {
-		log.CtxLogger(ctx).Debugf("DataWarehouseActivationCheck found %d full channels that it was unable to write to. Service(s) with full channels: %v", len(fullChs), fullChs)
+		log.CtxLogger(ctx).Debugf("DataWarehouseActivationCheck found %d full channels that it was unable to write to, retrying with the next check. Service(s) with full channels: %v", len(fullChs), sortedKeys(fullChs))
+	}
+	return fullChs
+}
+
+// checkInterval returns how often the activation is checked while Data Warehouse is not activated.
+func (s Service) checkInterval() time.Duration {
+	if d := s.Config.GetDataWarehouseActivation().GetCheckInterval().AsDuration(); d > 0 {
+		return d
 	}
+	return DefaultCheckInterval
+}
+
+// recheckInterval returns how often the activation is verified again once Data Warehouse is activated.
+func (s Service) recheckInterval() time.Duration {
+	if d := s.Config.GetDataWarehouseActivation().GetRecheckInterval().AsDuration(); d > 0 {
+		return d
+	}
+	return DefaultRecheckInterval
 }
 
 // DataWarehouseActivationCheck runs the data warehouse activation check periodically
-// and publishes the result to the workload agent service channels.
+// and publishes changes of the activation status to the workload agent service channels.
+// Once activated, the activation is verified again every recheck interval so the services
+// stop sending insights if Data Warehouse is deactivated.
 func (s Service) DataWarehouseActivationCheck(ctx context.Context, a any) {
 	currentStatus := notYetChecked
 	log.CtxLogger(ctx).Info("DataWarehouseActivationCheck started")
@@ -116,9 +159,8 @@ func (s Service) DataWarehouseActivationCheck(ctx context.Context, a any) {
 		log.CtxLogger(ctx).Warn("args is not of type chan servicecommunication.Message")
 		return
 	}
-	frequency := 5 * time.Minute
-	ticker := time.NewTicker(frequency)
-	defer ticker.Stop()
+	// undelivered holds the channels which have not received the current status yet.
+	var undelivered map[string]chan<- *servicecommunication.Message
 	for {
 		result, statusChanged := s.dwActivationLoop(ctx, currentStatus)
 		// If the status has changed, send the new information across the channels.
@@ -127,24 +169,37 @@ func (s Service) DataWarehouseActivationCheck(ctx context.Context, a any) {
 			if result.Activated {
 				currentStatus = activated
 			}
-			s.sendActivationResult(ctx, chs, result)
+			undelivered = chs
+		}
+		if len(undelivered) > 0 {
+			undelivered = s.sendActivationResult(ctx, undelivered, result)
 		}
 
-		// We can stop checking if the data warehouse is activated once we know that it is.
-		if result.Activated {
-			return
+		interval := s.checkInterval()
+		if result.Activated && len(undelivered) == 0 {
+			interval = s.recheckInterval()
 		}
+		timer := time.NewTimer(interval)
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			log.CtxLogger(ctx).Info("DataWarehouseActivationCheck cancellation requested")
 			return
-		case <-ticker.C:
-			log.CtxLogger(ctx).Debug("DataWarehouseActivationCheck ticker fired")
-			continue
+		case <-timer.C:
+			log.CtxLogger(ctx).Debug("DataWarehouseActivationCheck timer fired")
 		}
 	}
 }
 
+func sortedKeys(chs map[string]chan<- *servicecommunication.Message) []string {
+	keys := make([]string, 0, len(chs))
+	for k := range chs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // ErrorCode returns the error code for DataWarehouseActivationCheck.
 func (s Service) ErrorCode() int {
 	return usagemetrics.DataWarehouseActivationServiceFailure
@@ -18,6 +18,7 @@ package datawarehouseactivation
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -27,6 +28,8 @@ import (
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
 
+	dpb "google.golang.org/protobuf/types/known/durationpb"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
 )
 
@@ -45,6 +48,22 @@ func (f *fakeWLMWriter) WriteInsightAndGetResponse(project, location string, wri
 
 var ActivatedClient *fakeWLMWriter = &fakeWLMWriter{writeInsightResponse: &wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}}}
 var NilResponseClient *fakeWLMWriter = &fakeWLMWriter{writeInsightResponse: nil}
+var DisabledAPIClient *fakeWLMWriter = &fakeWLMWriter{writeInsightError: &googleapi.Error{Code: 403, Message: "Data Warehouse API has not been used in project"}}
+var UnreachableClient *fakeWLMWriter = &fakeWLMWriter{writeInsightError: errors.New("dial tcp: connection refused")}
+
+// switchingWLMWriter reports Data Warehouse as activated until deactivated is closed.
+type switchingWLMWriter struct {
+	deactivated chan struct{}
+}
+
+func (f *switchingWLMWriter) WriteInsightAndGetResponse(project, location string, writeInsightRequest *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	select {
+	case <-f.deactivated:
+		return DisabledAPIClient.WriteInsightAndGetResponse(project, location, writeInsightRequest)
+	default:
+		return ActivatedClient.WriteInsightAndGetResponse(project, location, writeInsightRequest)
+	}
+}
 
 func TestDataWarehouseActivationCheck(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -67,6 +86,91 @@ func TestDataWarehouseActivationCheck(t *testing.T) {
 	}
 }
 
+func TestDataWarehouseActivationCheckDeactivation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := make(chan *servicecommunication.Message, 1)
+	client := &switchingWLMWriter{deactivated: make(chan struct{})}
+	service := Service{
+		Config: &cpb.Configuration{
+			DataWarehouseActivation: &cpb.DataWarehouseActivation{
+				CheckInterval:   dpb.New(10 * time.Millisecond),
+				RecheckInterval: dpb.New(10 * time.Millisecond),
+			},
+		},
+		Client: client,
+	}
+	go service.DataWarehouseActivationCheck(ctx, map[string]chan<- *servicecommunication.Message{"test": ch})
+
+	for _, want := range []bool{true, false} {
+		select {
+		case <-time.After(10 * time.Second):
+			t.Fatalf("DataWarehouseActivationCheck did not publish Activated = %v within the timeout", want)
+		case msg := <-ch:
+			if msg.DWActivationResult.Activated != want {
+				t.Errorf("DataWarehouseActivationCheck published message with DWStatus = %v, want %v", msg.DWActivationResult.Activated, want)
+			}
+		}
+		if want {
+			close(client.deactivated)
+		}
+	}
+}
+
+func TestSendActivationResult(t *testing.T) {
+	free := make(chan *servicecommunication.Message, 1)
+	full := make(chan *servicecommunication.Message, 1)
+	full <- &servicecommunication.Message{}
+	chs := map[string]chan<- *servicecommunication.Message{"free": free, "full": full}
+
+	got := Service{}.sendActivationResult(context.Background(), chs, servicecommunication.DataWarehouseActivationResult{Activated: true})
+	if len(got) != 1 || got["full"] == nil {
+		t.Errorf("sendActivationResult() returned full channels %v, want only \"full\"", sortedKeys(got))
+	}
+	if msg := <-free; !msg.DWActivationResult.Activated {
+		t.Errorf("sendActivationResult() published message with DWStatus = %v, want true", msg.DWActivationResult.Activated)
+	}
+}
+
+func TestIntervals(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *cpb.Configuration
+		wantCheck   time.Duration
+		wantRecheck time.Duration
+	}{
+		{
+			name:        "Defaults",
+			config:      &cpb.Configuration{},
+			wantCheck:   DefaultCheckInterval,
+			wantRecheck: DefaultRecheckInterval,
+		},
+		{
+			name: "Configured",
+			config: &cpb.Configuration{
+				DataWarehouseActivation: &cpb.DataWarehouseActivation{
+					CheckInterval:   dpb.New(time.Minute),
+					RecheckInterval: dpb.New(6 * time.Hour),
+				},
+			},
+			wantCheck:   time.Minute,
+			wantRecheck: 6 * time.Hour,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := Service{Config: tc.config}
+			if got := s.checkInterval(); got != tc.wantCheck {
+				t.Errorf("checkInterval() = %v, want %v", got, tc.wantCheck)
+			}
+			if got := s.recheckInterval(); got != tc.wantRecheck {
+				t.Errorf("recheckInterval() = %v, want %v", got, tc.wantRecheck)
+			}
+		})
+	}
+}
+
 func TestDataWarehouseActivationCheck_InvalidArgs(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -124,6 +228,33 @@ func TestDataWarehouseActivation(t *testing.T) {
 			want:              servicecommunication.DataWarehouseActivationResult{Activated: true},
 			wantStatusChanged: false,
 		},
+		{
+			name: "APIDisabled",
+			s: &Service{
+				Client: DisabledAPIClient,
+			},
+			startingStatus:    activated,
+			want:              servicecommunication.DataWarehouseActivationResult{Activated: false},
+			wantStatusChanged: true,
+		},
+		{
+			name: "UnreachableKeepsActivation",
+			s: &Service{
+				Client: UnreachableClient,
+			},
+			startingStatus:    activated,
+			want:              servicecommunication.DataWarehouseActivationResult{Activated: true},
+			wantStatusChanged: false,
+		},
+		{
+			name: "UnreachableNotYetChecked",
+			s: &Service{
+				Client: UnreachableClient,
+			},
+			startingStatus:    notYetChecked,
+			want:              servicecommunication.DataWarehouseActivationResult{Activated: false},
+			wantStatusChanged: true,
+		},
 		{
 			name: "AlreadyNotActivated",
 			s: &Service{
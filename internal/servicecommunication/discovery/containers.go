@@ -0,0 +1,215 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	defaultKubeletURL = "http://localhost:10255/pods"
+	// serviceAccountTokenPath holds the token of the pod the agent runs in, if any.
+	serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// podLogsDir holds a directory named <namespace>_<name>_<uid> for each pod on the node.
+	podLogsDir     = "/var/log/pods"
+	kubeletTimeout = 5 * time.Second
+)
+
+var (
+	// podUIDPattern matches the pod UID in the cgroup paths of the cgroupfs driver, like
+	// /kubepods/burstable/pod<uid>/<id>, and of the systemd driver, like
+	// /kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod<uid_with_underscores>.slice/cri-containerd-<id>.scope.
+	podUIDPattern = regexp.MustCompile(`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})`)
+	// containerIDPattern matches the ID of docker, containerd and CRI-O containers.
+	containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+)
+
+// readDir abstracts os.ReadDir for testability.
+type readDir func(string) ([]os.DirEntry, error)
+
+// pod holds the name and namespace of a Kubernetes pod.
+type pod struct {
+	name, namespace string
+}
+
+// kubeletPodList is the subset of the kubelet /pods response used for attribution.
+type kubeletPodList struct {
+	Items []struct {
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+			UID       string `json:"uid"`
+		} `json:"metadata"`
+	} `json:"items"`
+}
+
+// cgroupPath returns the cgroup of a process from the content of /proc/<pid>/cgroup.
+// The unified hierarchy of cgroup v2 is preferred over the first non-root v1 hierarchy.
+func cgroupPath(content string) string {
+	var v1Path string
+	for _, line := range strings.Split(strings.TrimSpace(content), "\n") {
+		// Each line is hierarchy-ID:controller-list:cgroup-path.
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			return parts[2]
+		}
+		if v1Path == "" && parts[2] != "/" {
+			v1Path = parts[2]
+		}
+	}
+	return v1Path
+}
+
+// containerInfo returns the container described by a cgroup path.
+// ok is false if the path doesn't belong to a container.
+func containerInfo(path string) (info servicecommunication.ContainerInfo, ok bool) {
+	info.CgroupPath = path
+	info.ContainerID = containerIDPattern.FindString(path)
+	if m := podUIDPattern.FindStringSubmatch(path); m != nil {
+		// The systemd driver replaces the dashes of the pod UID with underscores.
+		info.PodUID = strings.ReplaceAll(m[1], "_", "-")
+	}
+	return info, info.ContainerID != "" || info.PodUID != ""
+}
+
+// attributeContainers returns the containers of the processes. The containers of processes which
+// were already running are taken from the previous result; only started processes are looked up.
+func (d Service) attributeContainers(ctx context.Context, previous servicecommunication.DiscoveryResult, processes []servicecommunication.ProcessWrapper, started []int32) map[int32]servicecommunication.ContainerInfo {
+	attribution := d.Config.GetCommonDiscovery().GetContainerAttribution()
+	if !capabilities.Host().Has(capabilities.Procfs) || (attribution != nil && attribution.Enabled != nil && !attribution.GetEnabled()) {
+		return nil
+	}
+	read := d.ReadFile
+	if read == nil {
+		read = os.ReadFile
+	}
+	isStarted := make(map[int32]bool, len(started))
+	for _, pid := range started {
+		isStarted[pid] = true
+	}
+
+	containers := make(map[int32]servicecommunication.ContainerInfo)
+	unresolved := false
+	for _, p := range processes {
+		pid := p.Pid()
+		if !isStarted[pid] {
+			if info, ok := previous.Containers[pid]; ok {
+				containers[pid] = info
+			}
+			continue
+		}
+		content, err := read(fmt.Sprintf("/proc/%d/cgroup", pid))
+		if err != nil {
+			// Processes can exit before their cgroup is read.
+			continue
+		}
+		if info, ok := containerInfo(cgroupPath(string(content))); ok {
+			containers[pid] = info
+			unresolved = unresolved || info.PodUID != ""
+		}
+	}
+	if !unresolved {
+		return containers
+	}
+
+	pods, err := d.kubeletPods(ctx, attribution.GetKubeletUrl())
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to list pods from the kubelet, falling back to the pod log directories", "error", err)
+		pods = d.logDirPods()
+	}
+	for pid, info := range containers {
+		if p, ok := pods[info.PodUID]; ok && info.PodName == "" {
+			info.PodName, info.PodNamespace = p.name, p.namespace
+			containers[pid] = info
+		}
+	}
+	return containers
+}
+
+// kubeletPods returns the pods on the node keyed by UID, as listed by the kubelet API.
+// The service account token of the agent is sent when the agent runs in a pod.
+func (d Service) kubeletPods(ctx context.Context, url string) (map[string]pod, error) {
+	if url == "" {
+		url = defaultKubeletURL
+	}
+	ctx, cancel := context.WithTimeout(ctx, kubeletTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	read := d.ReadFile
+	if read == nil {
+		read = os.ReadFile
+	}
+	if token, err := read(serviceAccountTokenPath); err == nil {
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kubelet returned status %s", res.Status)
+	}
+	var list kubeletPodList
+	if err := json.NewDecoder(res.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("decoding kubelet pods: %w", err)
+	}
+	pods := make(map[string]pod, len(list.Items))
+	for _, item := range list.Items {
+		pods[item.Metadata.UID] = pod{name: item.Metadata.Name, namespace: item.Metadata.Namespace}
+	}
+	return pods, nil
+}
+
+// logDirPods returns the pods on the node keyed by UID, as named by their log directories.
+func (d Service) logDirPods() map[string]pod {
+	list := d.ReadDir
+	if list == nil {
+		list = os.ReadDir
+	}
+	entries, err := list(podLogsDir)
+	if err != nil {
+		return nil
+	}
+	pods := make(map[string]pod, len(entries))
+	for _, e := range entries {
+		// Namespaces and pod names can't contain underscores.
+		parts := strings.Split(e.Name(), "_")
+		if len(parts) != 3 {
+			continue
+		}
+		pods[parts[2]] = pod{name: parts[1], namespace: parts[0]}
+	}
+	return pods
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+const (
+	podUID      = "1a2b3c4d-0000-1111-2222-333344445555"
+	containerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	// systemdCgroup is the cgroup v2 path of a container in a GKE pod.
+	systemdCgroup = "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1a2b3c4d_0000_1111_2222_333344445555.slice/cri-containerd-" + containerID + ".scope"
+	// cgroupfsCgroup is the cgroup v1 path of a container in a pod.
+	cgroupfsCgroup = "/kubepods/burstable/pod" + podUID + "/" + containerID
+	// dockerCgroup is the cgroup v1 path of a docker container outside Kubernetes.
+	dockerCgroup = "/docker/" + containerID
+)
+
+func TestCgroupPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "CgroupV2",
+			content: "0::" + systemdCgroup + "\n",
+			want:    systemdCgroup,
+		},
+		{
+			name:    "CgroupV1",
+			content: "12:pids:" + cgroupfsCgroup + "\n11:memory:" + cgroupfsCgroup + "\n1:name=systemd:/\n",
+			want:    cgroupfsCgroup,
+		},
+		{
+			name:    "HybridPrefersUnified",
+			content: "1:name=systemd:/init.scope\n0::" + systemdCgroup + "\n",
+			want:    systemdCgroup,
+		},
+		{
+			name:    "Root",
+			content: "0::/\n",
+			want:    "/",
+		},
+		{
+			name:    "Malformed",
+			content: "not a cgroup file",
+			want:    "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := cgroupPath(tc.content); got != tc.want {
+				t.Errorf("cgroupPath(%q) = %q, want %q", tc.content, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestContainerInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		path   string
+		want   servicecommunication.ContainerInfo
+		wantOK bool
+	}{
+		{
+			name:   "SystemdPod",
+			path:   systemdCgroup,
+			want:   servicecommunication.ContainerInfo{CgroupPath: systemdCgroup, ContainerID: containerID, PodUID: podUID},
+			wantOK: true,
+		},
+		{
+			name:   "CgroupfsPod",
+			path:   cgroupfsCgroup,
+			want:   servicecommunication.ContainerInfo{CgroupPath: cgroupfsCgroup, ContainerID: containerID, PodUID: podUID},
+			wantOK: true,
+		},
+		{
+			name:   "Docker",
+			path:   dockerCgroup,
+			want:   servicecommunication.ContainerInfo{CgroupPath: dockerCgroup, ContainerID: containerID},
+			wantOK: true,
+		},
+		{
+			name:   "HostService",
+			path:   "/system.slice/mysql.service",
+			want:   servicecommunication.ContainerInfo{CgroupPath: "/system.slice/mysql.service"},
+			wantOK: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := containerInfo(tc.path)
+			if ok != tc.wantOK {
+				t.Errorf("containerInfo(%q) returned ok %t, want %t", tc.path, ok, tc.wantOK)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("containerInfo(%q) returned unexpected diff (-want +got):\n%s", tc.path, diff)
+			}
+		})
+	}
+}
+
+// cgroupFiles returns a readFile which serves the /proc/<pid>/cgroup files in cgroups.
+func cgroupFiles(cgroups map[int32]string) readFile {
+	return func(path string) ([]byte, error) {
+		for pid, cgroup := range cgroups {
+			if path == fmt.Sprintf("/proc/%d/cgroup", pid) {
+				return []byte("0::" + cgroup + "\n"), nil
+			}
+		}
+		return nil, os.ErrNotExist
+	}
+}
+
+func TestAttributeContainers(t *testing.T) {
+	kubelet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items": [{"metadata": {"name": "mysql-0", "namespace": "db", "uid": %q}}]}`, podUID)
+	}))
+	defer kubelet.Close()
+	failingKubelet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer failingKubelet.Close()
+	logsDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(logsDir, "db_mysql-0_"+podUID), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	processes := []servicecommunication.ProcessWrapper{
+		processStub{pid: 1, name: "systemd"},
+		processStub{pid: 2, name: "mysqld"},
+		processStub{pid: 3, name: "redis-server"},
+	}
+	cgroups := map[int32]string{1: "/init.scope", 2: systemdCgroup, 3: dockerCgroup}
+	mysqlContainer := servicecommunication.ContainerInfo{CgroupPath: systemdCgroup, ContainerID: containerID, PodUID: podUID, PodName: "mysql-0", PodNamespace: "db"}
+	redisContainer := servicecommunication.ContainerInfo{CgroupPath: dockerCgroup, ContainerID: containerID}
+
+	tests := []struct {
+		name     string
+		d        Service
+		previous servicecommunication.DiscoveryResult
+		started  []int32
+		want     map[int32]servicecommunication.ContainerInfo
+	}{
+		{
+			name: "Kubelet",
+			d: Service{
+				ReadFile: cgroupFiles(cgroups),
+				Config: &cpb.Configuration{CommonDiscovery: &cpb.CommonDiscovery{
+					ContainerAttribution: &cpb.ContainerAttribution{KubeletUrl: kubelet.URL},
+				}},
+			},
+			started: []int32{1, 2, 3},
+			want:    map[int32]servicecommunication.ContainerInfo{2: mysqlContainer, 3: redisContainer},
+		},
+		{
+			name: "LogDirFallback",
+			d: Service{
+				ReadFile: cgroupFiles(cgroups),
+				ReadDir:  func(string) ([]os.DirEntry, error) { return os.ReadDir(logsDir) },
+				Config: &cpb.Configuration{CommonDiscovery: &cpb.CommonDiscovery{
+					ContainerAttribution: &cpb.ContainerAttribution{KubeletUrl: failingKubelet.URL},
+				}},
+			},
+			started: []int32{1, 2, 3},
+			want:    map[int32]servicecommunication.ContainerInfo{2: mysqlContainer, 3: redisContainer},
+		},
+		{
+			name: "PodNotFound",
+			d: Service{
+				ReadFile: cgroupFiles(cgroups),
+				ReadDir:  func(string) ([]os.DirEntry, error) { return nil, errors.New("no pod logs") },
+				Config: &cpb.Configuration{CommonDiscovery: &cpb.CommonDiscovery{
+					ContainerAttribution: &cpb.ContainerAttribution{KubeletUrl: failingKubelet.URL},
+				}},
+			},
+			started: []int32{2},
+			want: map[int32]servicecommunication.ContainerInfo{
+				2: {CgroupPath: systemdCgroup, ContainerID: containerID, PodUID: podUID},
+			},
+		},
+		{
+			name: "RunningProcessesAreNotLookedUp",
+			d: Service{
+				ReadFile: func(string) ([]byte, error) { return nil, errors.New("unexpected read") },
+			},
+			previous: servicecommunication.DiscoveryResult{
+				Containers: map[int32]servicecommunication.ContainerInfo{2: mysqlContainer, 4: redisContainer},
+			},
+			want: map[int32]servicecommunication.ContainerInfo{2: mysqlContainer},
+		},
+		{
+			name: "Disabled",
+			d: Service{
+				ReadFile: cgroupFiles(cgroups),
+				Config: &cpb.Configuration{CommonDiscovery: &cpb.CommonDiscovery{
+					ContainerAttribution: &cpb.ContainerAttribution{Enabled: proto.Bool(false)},
+				}},
+			},
+			started: []int32{1, 2, 3},
+			want:    nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.d.attributeContainers(context.Background(), tc.previous, processes, tc.started)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("attributeContainers() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -68,6 +68,7 @@ func (DefaultProcessLister) listAllProcesses() ([]servicecommunication.ProcessWr
 type Service struct {
 	ProcessLister   processLister
 	ReadFile        readFile
+	ReadDir         readDir
 	Hostname        hostname
 	Config          *cpb.Configuration
 	InitialInterval time.Duration
@@ -104,6 +105,21 @@ func (p gopsProcess) Environ() ([]string, error) {
 	return p.process.Environ()
 }
 
+// ListeningPorts returns the ports the process listens on.
+func (p gopsProcess) ListeningPorts() ([]int32, error) {
+	conns, err := p.process.Connections()
+	if err != nil {
+		return nil, err
+	}
+	var ports []int32
+	for _, c := range conns {
+		if c.Status == "LISTEN" {
+			ports = append(ports, int32(c.Laddr.Port))
+		}
+	}
+	return ports, nil
+}
+
 // String returns the string representation of the process.
 func (p gopsProcess) String() string {
 	username, _ := p.Username()
@@ -129,7 +145,8 @@ func setupBackoff(initialInterval, maxInterval time.Duration) backoff.BackOff {
 	return b
 }
 
-func (d Service) commonDiscoveryLoop(ctx context.Context) (servicecommunication.DiscoveryResult, error) {
+// commonDiscoveryLoop lists the processes on the host and diffs them against the previous result.
+func (d Service) commonDiscoveryLoop(ctx context.Context, previous servicecommunication.DiscoveryResult) (servicecommunication.DiscoveryResult, error) {
 	processes, err := d.ProcessLister.listAllProcesses()
 	if err != nil {
 		return servicecommunication.DiscoveryResult{}, err
@@ -137,7 +154,40 @@ func (d Service) commonDiscoveryLoop(ctx context.Context) (servicecommunication.
 	if len(processes) < 1 {
 		return servicecommunication.DiscoveryResult{}, errors.New("no processes found")
 	}
-	return servicecommunication.DiscoveryResult{Processes: processes}, nil
+	started, exited := diffProcesses(previous.Processes, processes)
+	return servicecommunication.DiscoveryResult{
+		Processes:  processes,
+		Containers: d.attributeContainers(ctx, previous, processes, started),
+		Started:    started,
+		Exited:     exited,
+	}, nil
+}
+
+// diffProcesses returns the PIDs of the processes in current which are not in previous and
+// of those in previous which are not in current. Processes are identified by their PID.
+func diffProcesses(previous, current []servicecommunication.ProcessWrapper) (started, exited []int32) {
+	previousPids := make(map[int32]bool, len(previous))
+	for _, p := range previous {
+		previousPids[p.Pid()] = true
+	}
+	currentPids := make(map[int32]bool, len(current))
+	for _, p := range current {
+		currentPids[p.Pid()] = true
+		if !previousPids[p.Pid()] {
+			started = append(started, p.Pid())
+		}
+	}
+	for _, p := range previous {
+		if !currentPids[p.Pid()] {
+			exited = append(exited, p.Pid())
+		}
+	}
+	return started, exited
+}
+
+// Discover runs a single common discovery and returns the processes found on the host.
+func (d Service) Discover(ctx context.Context) (servicecommunication.DiscoveryResult, error) {
+	return d.commonDiscoveryLoop(ctx, servicecommunication.DiscoveryResult{})
 }
 
 // CommonDiscovery returns a CommonDiscoveryResult and any errors encountered during the discovery process.
@@ -164,19 +214,34 @@ func (d Service) CommonDiscovery(ctx context.Context, a any) {
 	discoveryBackoff := setupBackoff(d.InitialInterval, maxInterval)
 	ticker := time.NewTicker(maxInterval)
 	defer ticker.Stop()
+	// last is the last result with changes. Subscribers which haven't received it yet, because they
+	// were added or their channel was full, get it instead of a heartbeat.
+	var last servicecommunication.DiscoveryResult
+	delivered := make(map[string]bool)
 	for {
 		ticker.Reset(discoveryBackoff.NextBackOff())
 
-		discoveryResult, err := d.commonDiscoveryLoop(ctx)
+		discoveryResult, err := d.commonDiscoveryLoop(ctx, last)
 		if err != nil {
 			log.CtxLogger(ctx).Errorw("Failed to perform common discovery", "error", err)
 			return
 		}
-		log.CtxLogger(ctx).Infof("CommonDiscovery found %d processes.", len(discoveryResult.Processes))
+		if len(discoveryResult.Started) > 0 || len(discoveryResult.Exited) > 0 {
+			log.CtxLogger(ctx).Infof("CommonDiscovery found %d processes, %d started and %d exited since the previous change.", len(discoveryResult.Processes), len(discoveryResult.Started), len(discoveryResult.Exited))
+			last = discoveryResult
+			clear(delivered)
+		} else {
+			log.CtxLogger(ctx).Debugf("CommonDiscovery found %d processes, none started or exited.", len(discoveryResult.Processes))
+		}
 		var fullChs []string
 		for key, ch := range chs {
+			msg := &servicecommunication.Message{Origin: servicecommunication.Discovery, DiscoveryResult: last}
+			if delivered[key] {
+				msg = &servicecommunication.Message{Origin: servicecommunication.Discovery, DiscoveryResult: servicecommunication.DiscoveryResult{Unchanged: true}}
+			}
 			select {
-			case ch <- &servicecommunication.Message{Origin: servicecommunication.Discovery, DiscoveryResult: discoveryResult}:
+			case ch <- msg:
+				delivered[key] = true
 			default:
 				fullChs = append(fullChs, key)
 			}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -388,7 +389,7 @@ func TestCommonDiscoveryLoop(t *testing.T) {
 
 	ctx := context.Background()
 	for _, tc := range tests {
-		result, gotErr := tc.d.commonDiscoveryLoop(ctx)
+		result, gotErr := tc.d.commonDiscoveryLoop(ctx, servicecommunication.DiscoveryResult{})
 		if gotErr != nil {
 			if tc.wantErr == nil {
 				t.Errorf("TestCommonDiscovery() with name %s  got error: %v, want: nil", tc.name, gotErr)
@@ -464,10 +465,6 @@ func TestCommonDiscoveryFullChannel(t *testing.T) {
 }
 
 func TestCommonDiscovery(t *testing.T) {
-	ch1 := make(chan *servicecommunication.Message, 1)
-	ch2 := make(chan *servicecommunication.Message, 1)
-	sendChs := map[string]chan<- *servicecommunication.Message{"test1": ch1, "test2": ch2}
-	receiveChs := []<-chan *servicecommunication.Message{ch1, ch2}
 	tests := []struct {
 		name        string
 		d           *Service
@@ -584,12 +581,23 @@ func TestCommonDiscovery(t *testing.T) {
 	}
 
 	for _, tc := range tests {
+		ch1 := make(chan *servicecommunication.Message, 1)
+		ch2 := make(chan *servicecommunication.Message, 1)
+		sendChs := map[string]chan<- *servicecommunication.Message{"test1": ch1, "test2": ch2}
+		receiveChs := []<-chan *servicecommunication.Message{ch1, ch2}
 		start := time.Now()
 		ctx, cancel := context.WithCancel(context.Background())
 		go tc.d.CommonDiscovery(ctx, sendChs)
-		for range tc.iterations {
+		for i := range tc.iterations {
 			for _, ch := range receiveChs {
 				result := <-ch
+				// The processes don't change, so only the first cycle sends the full result.
+				if i > 0 {
+					if !result.DiscoveryResult.Unchanged {
+						t.Errorf("TestCommonDiscovery() with name %s cycle %d got a full result, want a heartbeat", tc.name, i)
+					}
+					continue
+				}
 				ValidateResult(result.DiscoveryResult.Processes, tc.want.DiscoveryResult.Processes, tc.name, t)
 			}
 		}
@@ -603,3 +611,97 @@ func TestCommonDiscovery(t *testing.T) {
 		}
 	}
 }
+
+// changingProcessLister returns the next list of processes on each call.
+type changingProcessLister struct {
+	mu    *sync.Mutex
+	calls *int
+	lists [][]processStub
+}
+
+func (f changingProcessLister) listAllProcesses() ([]servicecommunication.ProcessWrapper, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	processes := f.lists[min(*f.calls, len(f.lists)-1)]
+	*f.calls++
+	return fakeProcessLister{processes: processes}.listAllProcesses()
+}
+
+func TestCommonDiscoveryBroadcastsChanges(t *testing.T) {
+	d := &Service{
+		ProcessLister: changingProcessLister{
+			mu:    &sync.Mutex{},
+			calls: new(int),
+			lists: [][]processStub{
+				{{pid: 1, name: "init"}},
+				{{pid: 1, name: "init"}},
+				{{pid: 1, name: "init"}, {pid: 2, name: "mysqld"}},
+			},
+		},
+		Config: &cpb.Configuration{
+			CommonDiscovery: &cpb.CommonDiscovery{
+				Enabled:             proto.Bool(true),
+				CollectionFrequency: &dpb.Duration{Nanos: 1000 * 1000 * 10},
+			},
+		},
+		InitialInterval: 10 * time.Millisecond,
+	}
+	ch := make(chan *servicecommunication.Message, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.CommonDiscovery(ctx, map[string]chan<- *servicecommunication.Message{"test": ch})
+
+	// Heartbeats may be dropped while the channel is full, full results are sent again.
+	if got := (<-ch).DiscoveryResult; got.Unchanged || !cmp.Equal(got.Started, []int32{1}) {
+		t.Errorf("CommonDiscovery() first sent unchanged %t, started %v, want the full result with started [1]", got.Unchanged, got.Started)
+	}
+	got := (<-ch).DiscoveryResult
+	for got.Unchanged {
+		got = (<-ch).DiscoveryResult
+	}
+	if !cmp.Equal(got.Started, []int32{2}) || len(got.Exited) != 0 {
+		t.Errorf("CommonDiscovery() sent started %v, exited %v after the change, want started [2]", got.Started, got.Exited)
+	}
+	if got := (<-ch).DiscoveryResult; !got.Unchanged {
+		t.Errorf("CommonDiscovery() sent started %v after the change was delivered, want a heartbeat", got.Started)
+	}
+}
+
+func TestDiffProcesses(t *testing.T) {
+	tests := []struct {
+		name        string
+		previous    []servicecommunication.ProcessWrapper
+		current     []servicecommunication.ProcessWrapper
+		wantStarted []int32
+		wantExited  []int32
+	}{
+		{
+			name:        "FirstCycle",
+			current:     []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			wantStarted: []int32{1, 2},
+		},
+		{
+			name:     "NoChanges",
+			previous: []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			current:  []servicecommunication.ProcessWrapper{processStub{pid: 2}, processStub{pid: 1}},
+		},
+		{
+			name:        "StartedAndExited",
+			previous:    []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			current:     []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 3}},
+			wantStarted: []int32{3},
+			wantExited:  []int32{2},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			gotStarted, gotExited := diffProcesses(tc.previous, tc.current)
+			if diff := cmp.Diff(tc.wantStarted, gotStarted); diff != "" {
+				t.Errorf("diffProcesses() returned unexpected started diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantExited, gotExited); diff != "" {
+				t.Errorf("diffProcesses() returned unexpected exited diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
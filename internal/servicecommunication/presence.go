@@ -0,0 +1,81 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecommunication
+
+import (
+	"context"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/lifecycle"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// DefaultAbsentCycles is the number of consecutive discovery cycles a workload must be missing
+// from before it is considered gone.
+const DefaultAbsentCycles = 3
+
+// Presence tracks whether a workload runs on the host across discovery cycles.
+//
+// A workload is present as soon as one of its processes is discovered, but is only considered
+// gone after it has been missing for AbsentCycles consecutive cycles, so that a process restart
+// between two cycles does not toggle it. The zero value is ready to use.
+type Presence struct {
+	// AbsentCycles defaults to DefaultAbsentCycles.
+	AbsentCycles int
+
+	mu      sync.Mutex
+	present bool
+	absent  int
+}
+
+// Observe records whether the workload was found in a discovery cycle and returns whether it is
+// considered present. Transitions are emitted as lifecycle events.
+func (p *Presence) Observe(ctx context.Context, workload string, found bool) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if found {
+		p.absent = 0
+		if !p.present {
+			p.present = true
+			lifecycle.Emit(ctx, lifecycle.Event{Type: lifecycle.WorkloadDetected, Workload: workload})
+		}
+		return true
+	}
+	if !p.present {
+		return false
+	}
+	p.absent++
+	threshold := p.AbsentCycles
+	if threshold <= 0 {
+		threshold = DefaultAbsentCycles
+	}
+	if p.absent < threshold {
+		log.CtxLogger(ctx).Debugw("Workload missing from discovery, waiting before marking it gone", "workload", workload, "absentCycles", p.absent, "threshold", threshold)
+		return true
+	}
+	p.present = false
+	p.absent = 0
+	lifecycle.Emit(ctx, lifecycle.Event{Type: lifecycle.WorkloadDisappeared, Workload: workload})
+	return false
+}
+
+// Present returns whether the workload is considered present.
+func (p *Presence) Present() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.present
+}
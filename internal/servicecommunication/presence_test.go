@@ -0,0 +1,76 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecommunication
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPresenceObserve(t *testing.T) {
+	tests := []struct {
+		name         string
+		absentCycles int
+		found        []bool
+		want         []bool
+	}{
+		{
+			name:  "NeverFound",
+			found: []bool{false, false},
+			want:  []bool{false, false},
+		},
+		{
+			name:  "FoundImmediately",
+			found: []bool{true},
+			want:  []bool{true},
+		},
+		{
+			name:  "BriefRestartDoesNotToggle",
+			found: []bool{true, false, false, true, false},
+			want:  []bool{true, true, true, true, true},
+		},
+		{
+			name:  "GoneAfterDefaultAbsentCycles",
+			found: []bool{true, false, false, false, false},
+			want:  []bool{true, true, true, false, false},
+		},
+		{
+			name:         "CustomAbsentCycles",
+			absentCycles: 1,
+			found:        []bool{true, false, true},
+			want:         []bool{true, false, true},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := &Presence{AbsentCycles: tc.absentCycles}
+			var got []bool
+			for _, found := range tc.found {
+				got = append(got, p.Observe(context.Background(), "MySQL", found))
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Observe() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if p.Present() != got[len(got)-1] {
+				t.Errorf("Present() = %v, want %v", p.Present(), got[len(got)-1])
+			}
+		})
+	}
+}
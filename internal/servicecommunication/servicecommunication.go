@@ -39,9 +39,30 @@ type ProcessWrapper interface {
 	String() string
 }
 
+// ContainerInfo describes the container a process runs in.
+type ContainerInfo struct {
+	CgroupPath  string
+	ContainerID string
+	// The pod is only set for containers run by Kubernetes. The name and namespace are empty if
+	// the pod could not be looked up.
+	PodUID       string
+	PodName      string
+	PodNamespace string
+}
+
 // DiscoveryResult holds the results of a discovery operation.
 type DiscoveryResult struct {
 	Processes []ProcessWrapper
+	// Containers holds the container of each process running in a container, keyed by PID.
+	Containers map[int32]ContainerInfo
+	// Started and Exited hold the PIDs of the processes which started and exited since the
+	// previous result with changes.
+	Started []int32
+	Exited  []int32
+	// Unchanged is set on heartbeats, which are sent instead of the full result when no process
+	// started or exited since the previous cycle. Heartbeats carry no processes; the processes of
+	// the last full result still apply.
+	Unchanged bool
 }
 
 // DataWarehouseActivationResult holds the results of a data warehouse activation check.
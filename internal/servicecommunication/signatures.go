@@ -0,0 +1,230 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecommunication
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// Workloads which can be detected by their signatures.
+const (
+	Oracle   = "oracle"
+	MySQL    = "mysql"
+	Postgres = "postgres"
+	Redis    = "redis"
+	MongoDB  = "mongodb"
+	// Connection poolers are not workloads of their own, their topology is reported with the
+	// insight of the database they pool connections for.
+	ProxySQL  = "proxysql"
+	PgBouncer = "pgbouncer"
+	// Pacemaker is the cluster manager of hosts running databases under Linux HA.
+	Pacemaker = "pacemaker"
+	// Legacy agents collect some of the workloads also collected by this agent.
+	LegacyOracleAgent = "legacy_oracle_agent"
+	SQLServerAgent    = "sqlserver_agent"
+)
+
+// DefaultSignatures are the built-in signatures of the processes of each workload.
+// Process names on Windows include the .exe extension and are not case sensitive.
+var DefaultSignatures = []*cpb.WorkloadSignature{
+	{Workload: Oracle, ProcessNamePatterns: []string{`^(ora|db)_pmon_`}},
+	{Workload: MySQL, ProcessNamePatterns: []string{`^mysqld$`, `(?i)^mysqld\.exe$`}},
+	{Workload: Postgres, ProcessNamePatterns: []string{`postgres`, `(?i)^postgres\.exe$`}},
+	{Workload: Redis, ProcessNamePatterns: []string{`^redis-server$`}},
+	{Workload: MongoDB, ProcessNamePatterns: []string{`mongod`}},
+	{Workload: ProxySQL, ProcessNamePatterns: []string{`^proxysql$`}},
+	{Workload: PgBouncer, ProcessNamePatterns: []string{`^pgbouncer$`, `(?i)^pgbouncer\.exe$`}},
+	{Workload: Pacemaker, ProcessNamePatterns: []string{`^pacemakerd$`}},
+	{Workload: LegacyOracleAgent, ProcessNamePatterns: []string{`^google_cloud_oracle_agent$`}},
+	{Workload: SQLServerAgent, ProcessNamePatterns: []string{`^google-cloud-sql-server-agent$`, `(?i)^google-cloud-sql-server-agent\.exe$`}},
+}
+
+// PortLister is implemented by processes which can report the ports they listen on.
+// Signatures with ports never match processes which don't implement it.
+type PortLister interface {
+	ListeningPorts() ([]int32, error)
+}
+
+// signature is a compiled WorkloadSignature.
+type signature struct {
+	workload     string
+	processNames []*regexp.Regexp
+	cmdlines     []*regexp.Regexp
+	envMarkers   []string
+	ports        []int32
+}
+
+// Registry matches processes against the signatures of the workloads.
+type Registry struct {
+	signatures []signature
+}
+
+// NewRegistry returns a registry of the built-in signatures extended by custom.
+func NewRegistry(custom []*cpb.WorkloadSignature) (*Registry, error) {
+	r := &Registry{}
+	for _, s := range append(slices.Clone(DefaultSignatures), custom...) {
+		compiled, err := compile(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s workload signature: %w", s.GetWorkload(), err)
+		}
+		r.signatures = append(r.signatures, compiled)
+	}
+	return r, nil
+}
+
+// DefaultRegistry returns the registry of the built-in signatures.
+func DefaultRegistry() *Registry {
+	r, err := NewRegistry(nil)
+	if err != nil {
+		// The built-in signatures are always valid.
+		panic(err)
+	}
+	return r
+}
+
+// RegistryFromConfig returns the registry of the workload signatures in the common discovery
+// configuration. The built-in signatures are used if the configured signatures are invalid.
+func RegistryFromConfig(ctx context.Context, config *cpb.Configuration) *Registry {
+	r, err := NewRegistry(config.GetCommonDiscovery().GetWorkloadSignatures())
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Ignoring the configured workload signatures", "error", err)
+		return DefaultRegistry()
+	}
+	return r
+}
+
+func compile(s *cpb.WorkloadSignature) (signature, error) {
+	switch s.GetWorkload() {
+	case Oracle, MySQL, Postgres, Redis, MongoDB, ProxySQL, PgBouncer, Pacemaker, LegacyOracleAgent, SQLServerAgent:
+	default:
+		return signature{}, fmt.Errorf("unknown workload %q", s.GetWorkload())
+	}
+	if len(s.GetProcessNamePatterns()) == 0 && len(s.GetCmdlinePatterns()) == 0 && len(s.GetEnvironmentMarkers()) == 0 && len(s.GetPorts()) == 0 {
+		return signature{}, fmt.Errorf("no matching criteria")
+	}
+	sig := signature{
+		workload:   s.GetWorkload(),
+		envMarkers: s.GetEnvironmentMarkers(),
+		ports:      s.GetPorts(),
+	}
+	var err error
+	if sig.processNames, err = compilePatterns(s.GetProcessNamePatterns()); err != nil {
+		return signature{}, err
+	}
+	if sig.cmdlines, err = compilePatterns(s.GetCmdlinePatterns()); err != nil {
+		return signature{}, err
+	}
+	return sig, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var res []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// Matches returns true if the process matches any signature of the workload.
+func (r *Registry) Matches(workload string, p ProcessWrapper) bool {
+	for _, s := range r.signatures {
+		if s.workload == workload && s.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter returns the processes which match any signature of the workload.
+func (r *Registry) Filter(workload string, processes []ProcessWrapper) []ProcessWrapper {
+	var matched []ProcessWrapper
+	for _, p := range processes {
+		if r.Matches(workload, p) {
+			matched = append(matched, p)
+		}
+	}
+	return matched
+}
+
+// matches returns true if every criterion of the signature which is set matches the process.
+// Process attributes which can't be read don't match.
+func (s signature) matches(p ProcessWrapper) bool {
+	if len(s.processNames) > 0 {
+		name, err := p.Name()
+		if err != nil || !anyMatch(s.processNames, name) {
+			return false
+		}
+	}
+	if len(s.cmdlines) > 0 {
+		args, err := p.CmdlineSlice()
+		if err != nil || !anyMatch(s.cmdlines, strings.Join(args, " ")) {
+			return false
+		}
+	}
+	if len(s.envMarkers) > 0 {
+		env, err := p.Environ()
+		if err != nil || !hasEnvMarker(env, s.envMarkers) {
+			return false
+		}
+	}
+	if len(s.ports) > 0 {
+		pl, ok := p.(PortLister)
+		if !ok {
+			return false
+		}
+		ports, err := pl.ListeningPorts()
+		if err != nil || !slices.ContainsFunc(ports, func(port int32) bool { return slices.Contains(s.ports, port) }) {
+			return false
+		}
+	}
+	return true
+}
+
+func anyMatch(res []*regexp.Regexp, s string) bool {
+	for _, re := range res {
+		if re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasEnvMarker returns true if any marker is set in env. A marker is either a variable name,
+// which matches any value, or NAME=value.
+func hasEnvMarker(env, markers []string) bool {
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		for _, m := range markers {
+			if m == kv || m == name {
+				return true
+			}
+		}
+	}
+	return false
+}
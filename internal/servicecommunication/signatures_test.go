@@ -0,0 +1,256 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecommunication
+
+import (
+	"errors"
+	"testing"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// processStub is a test double for ProcessWrapper.
+type processStub struct {
+	name    string
+	args    []string
+	environ []string
+	err     error
+}
+
+func (p processStub) Username() (string, error)       { return "", p.err }
+func (p processStub) Pid() int32                      { return 1 }
+func (p processStub) Name() (string, error)           { return p.name, p.err }
+func (p processStub) CmdlineSlice() ([]string, error) { return p.args, p.err }
+func (p processStub) Environ() ([]string, error)      { return p.environ, p.err }
+func (p processStub) String() string                  { return p.name }
+
+// listeningProcessStub is a test double for a ProcessWrapper which implements PortLister.
+type listeningProcessStub struct {
+	processStub
+	ports []int32
+}
+
+func (p listeningProcessStub) ListeningPorts() ([]int32, error) { return p.ports, p.err }
+
+func TestRegistryMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		custom   []*cpb.WorkloadSignature
+		workload string
+		process  ProcessWrapper
+		want     bool
+	}{
+		{
+			name:     "BuiltInMySQL",
+			workload: MySQL,
+			process:  processStub{name: "mysqld"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInMySQLWindows",
+			workload: MySQL,
+			process:  processStub{name: "MySQLD.exe"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInMySQLIsExact",
+			workload: MySQL,
+			process:  processStub{name: "mysqld_custom"},
+			want:     false,
+		},
+		{
+			name:     "BuiltInOracle",
+			workload: Oracle,
+			process:  processStub{name: "ora_pmon_orcl"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInPostgres",
+			workload: Postgres,
+			process:  processStub{name: "postgres"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInPostgresWindows",
+			workload: Postgres,
+			process:  processStub{name: "Postgres.exe"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInRedis",
+			workload: Redis,
+			process:  processStub{name: "redis-server"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInMongoDB",
+			workload: MongoDB,
+			process:  processStub{name: "mongod"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInProxySQL",
+			workload: ProxySQL,
+			process:  processStub{name: "proxysql"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInPgBouncer",
+			workload: PgBouncer,
+			process:  processStub{name: "pgbouncer"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInPgBouncerWindows",
+			workload: PgBouncer,
+			process:  processStub{name: "pgbouncer.exe"},
+			want:     true,
+		},
+		{
+			name:     "BuiltInSQLServerAgentWindows",
+			workload: SQLServerAgent,
+			process:  processStub{name: "Google-Cloud-SQL-Server-Agent.exe"},
+			want:     true,
+		},
+		{
+			name:     "PgBouncerIsNotPostgres",
+			workload: Postgres,
+			process:  processStub{name: "pgbouncer"},
+			want:     false,
+		},
+		{
+			name:     "OtherWorkload",
+			workload: Redis,
+			process:  processStub{name: "mysqld"},
+			want:     false,
+		},
+		{
+			name:     "NameError",
+			workload: MySQL,
+			process:  processStub{name: "mysqld", err: errors.New("process exited")},
+			want:     false,
+		},
+		{
+			name:     "CustomProcessName",
+			custom:   []*cpb.WorkloadSignature{{Workload: MySQL, ProcessNamePatterns: []string{`^mysqld_custom$`}}},
+			workload: MySQL,
+			process:  processStub{name: "mysqld_custom"},
+			want:     true,
+		},
+		{
+			name:     "CustomCmdline",
+			custom:   []*cpb.WorkloadSignature{{Workload: Redis, CmdlinePatterns: []string{`--port 6380`}}},
+			workload: Redis,
+			process:  processStub{name: "cache", args: []string{"cache", "--port", "6380"}},
+			want:     true,
+		},
+		{
+			name:     "CustomEnvironmentName",
+			custom:   []*cpb.WorkloadSignature{{Workload: Postgres, EnvironmentMarkers: []string{"PGDATA"}}},
+			workload: Postgres,
+			process:  processStub{name: "db", environ: []string{"HOME=/var/lib", "PGDATA=/data"}},
+			want:     true,
+		},
+		{
+			name:     "CustomEnvironmentValueMismatch",
+			custom:   []*cpb.WorkloadSignature{{Workload: Postgres, EnvironmentMarkers: []string{"PGDATA=/other"}}},
+			workload: Postgres,
+			process:  processStub{name: "db", environ: []string{"PGDATA=/data"}},
+			want:     false,
+		},
+		{
+			name:     "CustomPort",
+			custom:   []*cpb.WorkloadSignature{{Workload: MySQL, Ports: []int32{3306}}},
+			workload: MySQL,
+			process:  listeningProcessStub{processStub: processStub{name: "db"}, ports: []int32{22, 3306}},
+			want:     true,
+		},
+		{
+			name:     "CustomPortWithoutPortLister",
+			custom:   []*cpb.WorkloadSignature{{Workload: MySQL, Ports: []int32{3306}}},
+			workload: MySQL,
+			process:  processStub{name: "db"},
+			want:     false,
+		},
+		{
+			name: "AllCriteriaMustMatch",
+			custom: []*cpb.WorkloadSignature{{
+				Workload:            MySQL,
+				ProcessNamePatterns: []string{`^db$`},
+				CmdlinePatterns:     []string{`--mysql`},
+			}},
+			workload: MySQL,
+			process:  processStub{name: "db", args: []string{"db", "--postgres"}},
+			want:     false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewRegistry(tc.custom)
+			if err != nil {
+				t.Fatalf("NewRegistry() returned unexpected error: %v", err)
+			}
+			if got := r.Matches(tc.workload, tc.process); got != tc.want {
+				t.Errorf("Matches(%q, %v) = %t, want %t", tc.workload, tc.process, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewRegistryErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		custom []*cpb.WorkloadSignature
+	}{
+		{
+			name:   "UnknownWorkload",
+			custom: []*cpb.WorkloadSignature{{Workload: "db2", ProcessNamePatterns: []string{`^db2sysc$`}}},
+		},
+		{
+			name:   "NoCriteria",
+			custom: []*cpb.WorkloadSignature{{Workload: MySQL}},
+		},
+		{
+			name:   "InvalidProcessNamePattern",
+			custom: []*cpb.WorkloadSignature{{Workload: MySQL, ProcessNamePatterns: []string{`(`}}},
+		},
+		{
+			name:   "InvalidCmdlinePattern",
+			custom: []*cpb.WorkloadSignature{{Workload: MySQL, CmdlinePatterns: []string{`[`}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := NewRegistry(tc.custom); err == nil {
+				t.Errorf("NewRegistry(%v) returned nil error, want error", tc.custom)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	processes := []ProcessWrapper{
+		processStub{name: "mysqld"},
+		processStub{name: "redis-server"},
+		processStub{name: "mysqld_custom"},
+	}
+	r := DefaultRegistry()
+	got := r.Filter(MySQL, processes)
+	if len(got) != 1 || got[0].String() != "mysqld" {
+		t.Errorf("Filter(%q) = %v, want [mysqld]", MySQL, got)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sqlguard enforces that the SQL executed by the agent against monitored databases is read-only.
+package sqlguard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MySQLReadOnlyParam is the MySQL session variable which makes every transaction in the session read-only.
+	// It is set through the DSN so that it is applied to every pooled connection.
+	MySQLReadOnlyParam = "transaction_read_only"
+	// MySQLReadOnlyValue is the value for MySQLReadOnlyParam which enables read-only transactions.
+	MySQLReadOnlyValue = "1"
+	// PostgresReadOnlyParam is the Postgres run-time parameter which makes every transaction in the session read-only.
+	// lib/pq passes unrecognized connection string keys to the server as run-time parameters.
+	PostgresReadOnlyParam = "default_transaction_read_only"
+	// PostgresReadOnlyValue is the value for PostgresReadOnlyParam which enables read-only transactions.
+	PostgresReadOnlyValue = "on"
+)
+
+var (
+	// ErrEmptyQuery is returned when the query does not contain a statement.
+	ErrEmptyQuery = errors.New("query is empty")
+	// ErrMultipleStatements is returned when the query contains more than one statement.
+	ErrMultipleStatements = errors.New("query contains multiple statements")
+
+	// allowedLeadingKeywords are the keywords a read-only statement may start with.
+	allowedLeadingKeywords = map[string]bool{
+		"SELECT": true,
+		"WITH":   true,
+		"SHOW":   true,
+	}
+
+	// deniedKeywords are DML, DDL, DCL and procedural keywords which must not appear anywhere in a query.
+	// INTO is included because SELECT ... INTO creates tables in Postgres and writes files in MySQL.
+	deniedKeywords = map[string]bool{
+		"ALTER":     true,
+		"BEGIN":     true,
+		"CALL":      true,
+		"COMMIT":    true,
+		"CREATE":    true,
+		"DECLARE":   true,
+		"DELETE":    true,
+		"DROP":      true,
+		"EXEC":      true,
+		"EXECUTE":   true,
+		"GRANT":     true,
+		"INSERT":    true,
+		"INTO":      true,
+		"LOCK":      true,
+		"MERGE":     true,
+		"RENAME":    true,
+		"REVOKE":    true,
+		"ROLLBACK":  true,
+		"SAVEPOINT": true,
+		"TRUNCATE":  true,
+		"UPDATE":    true,
+		"UPSERT":    true,
+	}
+)
+
+// ValidateQuery returns an error if the query is not a single read-only statement.
+// Comments, string literals and quoted identifiers are ignored so that column aliases
+// and literal values such as 'user commits' do not cause false positives.
+func ValidateQuery(query string) error {
+	stripped, err := strip(query)
+	if err != nil {
+		return err
+	}
+	stripped = strings.TrimSpace(stripped)
+	stripped = strings.TrimSpace(strings.TrimSuffix(stripped, ";"))
+	if stripped == "" {
+		return ErrEmptyQuery
+	}
+	if strings.Contains(stripped, ";") {
+		return ErrMultipleStatements
+	}
+	words := keywords(stripped)
+	if len(words) == 0 || !allowedLeadingKeywords[words[0]] {
+		return errors.New("query must start with one of SELECT, WITH or SHOW")
+	}
+	for _, w := range words {
+		if deniedKeywords[w] {
+			return fmt.Errorf("query contains the keyword %s which is not allowed in read-only queries", w)
+		}
+	}
+	return nil
+}
+
+// strip removes comments, string literals and quoted identifiers from the query.
+// Removed sections are replaced by a single space to keep neighbouring tokens apart.
+func strip(query string) (string, error) {
+	var b strings.Builder
+	r := []rune(query)
+	for i := 0; i < len(r); i++ {
+		switch {
+		case r[i] == '-' && i+1 < len(r) && r[i+1] == '-':
+			for i < len(r) && r[i] != '\n' {
+				i++
+			}
+			b.WriteRune(' ')
+		case r[i] == '/' && i+1 < len(r) && r[i+1] == '*':
+			closed := false
+			for i += 2; i+1 < len(r); i++ {
+				if r[i] == '*' && r[i+1] == '/' {
+					i++
+					closed = true
+					break
+				}
+			}
+			if !closed {
+				return "", errors.New("query contains an unterminated comment")
+			}
+			b.WriteRune(' ')
+		case r[i] == '\'' || r[i] == '"' || r[i] == '`':
+			quote := r[i]
+			closed := false
+			for i++; i < len(r); i++ {
+				if r[i] != quote {
+					continue
+				}
+				// A doubled quote is an escaped quote inside the literal.
+				if i+1 < len(r) && r[i+1] == quote {
+					i++
+					continue
+				}
+				closed = true
+				break
+			}
+			if !closed {
+				return "", errors.New("query contains an unterminated quoted string")
+			}
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// keywords returns the upper-cased identifier tokens of the query in order.
+func keywords(query string) []string {
+	isWordRune := func(c rune) bool {
+		return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '$' || c == '#'
+	}
+	fields := strings.FieldsFunc(query, func(c rune) bool { return !isWordRune(c) })
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		words = append(words, strings.ToUpper(f))
+	}
+	return words
+}
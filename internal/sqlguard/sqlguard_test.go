@@ -0,0 +1,137 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sqlguard
+
+import (
+	"testing"
+)
+
+func TestValidateQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{
+			name:  "Select",
+			query: "SELECT @@innodb_buffer_pool_size",
+		},
+		{
+			name:  "SelectWithTrailingSemicolon",
+			query: "SELECT 1 FROM dual;",
+		},
+		{
+			name:  "Show",
+			query: "SHOW work_mem",
+		},
+		{
+			name:  "With",
+			query: "WITH t AS (SELECT 1 AS a) SELECT a FROM t",
+		},
+		{
+			name:  "LowerCase",
+			query: "select name, value from v$pgastat",
+		},
+		{
+			name:  "KeywordInStringLiteral",
+			query: "SELECT value FROM v$sysstat WHERE name = 'user commits'",
+		},
+		{
+			name:  "KeywordInQuotedIdentifier",
+			query: `SELECT "UPDATE" FROM t`,
+		},
+		{
+			name:  "KeywordInComment",
+			query: "SELECT 1 -- DROP TABLE t\nFROM dual",
+		},
+		{
+			name:  "KeywordInBlockComment",
+			query: "SELECT /* DELETE */ 1 FROM dual",
+		},
+		{
+			name:  "KeywordAsIdentifierPrefix",
+			query: "SELECT update_time, create_options, file#, serial# FROM t",
+		},
+		{
+			name:  "EscapedQuoteInLiteral",
+			query: "SELECT 'it''s' FROM dual",
+		},
+		{
+			name:    "Empty",
+			query:   "  ;  ",
+			wantErr: true,
+		},
+		{
+			name:    "Insert",
+			query:   "INSERT INTO t VALUES (1)",
+			wantErr: true,
+		},
+		{
+			name:    "Update",
+			query:   "update t set a = 1",
+			wantErr: true,
+		},
+		{
+			name:    "SelectForUpdate",
+			query:   "SELECT a FROM t FOR UPDATE",
+			wantErr: true,
+		},
+		{
+			name:    "SelectInto",
+			query:   "SELECT * INTO new_table FROM t",
+			wantErr: true,
+		},
+		{
+			name:    "DataModifyingCTE",
+			query:   "WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d",
+			wantErr: true,
+		},
+		{
+			name:    "MultipleStatements",
+			query:   "SELECT 1; DROP TABLE t",
+			wantErr: true,
+		},
+		{
+			name:    "PLSQLBlock",
+			query:   "BEGIN NULL; END;",
+			wantErr: true,
+		},
+		{
+			name:    "Truncate",
+			query:   "TRUNCATE TABLE t",
+			wantErr: true,
+		},
+		{
+			name:    "UnterminatedLiteral",
+			query:   "SELECT 'abc FROM dual",
+			wantErr: true,
+		},
+		{
+			name:    "UnterminatedComment",
+			query:   "SELECT 1 /* FROM dual",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateQuery(tc.query)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("ValidateQuery(%q) = %v, wantErr %v", tc.query, err, tc.wantErr)
+			}
+		})
+	}
+}
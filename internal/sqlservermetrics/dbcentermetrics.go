@@ -19,6 +19,8 @@ package sqlservermetrics
 import (
 	"context"
 	"fmt"
+	"net"
+	"strconv"
 
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlcollector"
@@ -27,8 +29,9 @@ import (
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 )
 
-func (s *SQLServerMetrics) dbCenterMetrics(ctx context.Context) databasecenter.DBCenterMetrics {
-	metrics := map[string]string{}
+// dbCenterMetrics collects the Database Center metrics of each configured SQL Server instance.
+func (s *SQLServerMetrics) dbCenterMetrics(ctx context.Context) []databasecenter.DBCenterMetrics {
+	var instances []databasecenter.DBCenterMetrics
 	sip := sourceInstanceProperties()
 	for _, credentialCfg := range s.Config.GetCredentialConfigurations() {
 		for _, sqlCfg := range sqlConfigFromCredential(credentialCfg) {
@@ -41,7 +44,7 @@ func (s *SQLServerMetrics) dbCenterMetrics(ctx context.Context) databasecenter.D
 			if projectID == "" {
 				projectID = sip.ProjectID
 			}
-			pswd, err := secretValue(ctx, projectID, sqlCfg.SecretName)
+			pswd, err := secretValue(ctx, s.Endpoints, projectID, sqlCfg.SecretName)
 			if err != nil {
 				usagemetrics.Error(usagemetrics.SecretManagerValueError)
 				log.Logger.Errorw("Failed to get secret value", "error", err)
@@ -63,16 +66,19 @@ func (s *SQLServerMetrics) dbCenterMetrics(ctx context.Context) databasecenter.D
 				log.Logger.Errorw("Failed to get version", "error", err)
 				continue
 			}
-			metrics[databasecenter.MajorVersionKey] = majorVersion
-			metrics[databasecenter.MinorVersionKey] = minorVersion
+			metrics := map[string]string{
+				databasecenter.MajorVersionKey: majorVersion,
+				databasecenter.MinorVersionKey: minorVersion,
+			}
 			sqlcollector.PopulateSignals(ctxWithTimeout, c, metrics)
 			// End collection for dbcenter metrics.
 			log.Logger.Debug("Completed collecting SQL Server rules for dbcenter metrics.")
+			instances = append(instances, databasecenter.DBCenterMetrics{
+				EngineType: databasecenter.SQLSERVER,
+				Instance:   net.JoinHostPort(sqlCfg.Host, strconv.Itoa(int(sqlCfg.PortNumber))),
+				Metrics:    metrics,
+			})
 		}
 	}
-
-	return databasecenter.DBCenterMetrics{
-		EngineType: databasecenter.SQLSERVER,
-		Metrics:    metrics,
-	}
+	return instances
 }
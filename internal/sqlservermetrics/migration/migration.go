@@ -21,12 +21,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"runtime"
 	"time"
 
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	sqlserverpb "github.com/GoogleCloudPlatform/workloadagent/protos/sqlserveragent"
 )
@@ -49,13 +49,28 @@ type migration struct {
 func Migrate() error {
 	backupPath := configBackupPathLinux
 	configPath := configPathLinux
-	if runtime.GOOS == "windows" {
+	if capabilities.Host().IsWindows() {
 		backupPath = configBackupPathWindows
 		configPath = configPathWindows
 	}
 	return migrateSQLServerConfigurations(backupPath, configPath)
 }
 
+// ImportSQLServerConfiguration reads the sql-server-agent configuration at legacyPath and
+// replaces the SQL Server configuration of config with its translation.
+// Agent wide settings of config, such as the log level, are kept.
+func ImportSQLServerConfiguration(legacyPath string, config *configpb.Configuration) error {
+	m := &migration{}
+	backCfg, err := m.LoadConfigBackup(legacyPath)
+	if err != nil {
+		return err
+	}
+	migrated := &configpb.Configuration{}
+	m.Migrate(backCfg, migrated)
+	config.SqlserverConfiguration = migrated.GetSqlserverConfiguration()
+	return nil
+}
+
 // MigrateSQLServerConfigurations migrates the old sql-server-agent configurations to the new configuration format.
 func migrateSQLServerConfigurations(backupPath, configPath string) error {
 	// return nil if config backup does not exist
@@ -94,7 +109,7 @@ func (m *migration) Migrate(backCfg *sqlserverpb.Configuration, config *configpb
 	config.LogLevel = m.logLevel(backCfg.GetLogLevel())
 	config.LogToCloud = proto.Bool(backCfg.GetLogToCloud())
 	config.AgentProperties = &configpb.AgentProperties{
-		LogUsageMetrics: !backCfg.GetDisableLogUsage(),
+		LogUsageMetrics: proto.Bool(!backCfg.GetDisableLogUsage()),
 	}
 	config.SqlserverConfiguration = &configpb.SQLServerConfiguration{
 		Enabled:                 proto.Bool(true),
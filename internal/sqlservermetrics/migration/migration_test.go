@@ -93,7 +93,7 @@ func TestMigrateSQLServerConfigurations(t *testing.T) {
 		LogLevel:   configpb.Configuration_DEBUG,
 		LogToCloud: proto.Bool(true),
 		AgentProperties: &configpb.AgentProperties{
-			LogUsageMetrics: true,
+			LogUsageMetrics: proto.Bool(true),
 		},
 		SqlserverConfiguration: &configpb.SQLServerConfiguration{
 			Enabled: proto.Bool(true),
@@ -189,6 +189,35 @@ func TestMigrateSQLServerConfigurations(t *testing.T) {
 	}
 }
 
+func TestImportSQLServerConfiguration(t *testing.T) {
+	legacyPath := path.Join(t.TempDir(), "configuration.json")
+	content := []byte(`{
+		"log_level": "DEBUG",
+		"collection_configuration": {"collect_sql_metrics": true},
+		"credential_configuration": [
+			{"sql_configurations": [{"host": "test_host", "user_name": "test_user_name", "port_number": 1433}], "local_collection": true}
+		]
+	}`)
+	if err := os.WriteFile(legacyPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	config := &configpb.Configuration{LogLevel: configpb.Configuration_WARNING}
+	if err := ImportSQLServerConfiguration(legacyPath, config); err != nil {
+		t.Fatalf("ImportSQLServerConfiguration() returned unexpected error: %v", err)
+	}
+	if config.GetLogLevel() != configpb.Configuration_WARNING {
+		t.Errorf("ImportSQLServerConfiguration() changed the log level to %v, want WARNING", config.GetLogLevel())
+	}
+	got := config.GetSqlserverConfiguration().GetCredentialConfigurations()
+	if len(got) != 1 || got[0].GetConnectionParameters()[0].GetHost() != "test_host" {
+		t.Errorf("ImportSQLServerConfiguration() imported credential configurations %v, want one for test_host", got)
+	}
+
+	if err := ImportSQLServerConfiguration(path.Join(t.TempDir(), "missing.json"), config); err == nil {
+		t.Error("ImportSQLServerConfiguration() with a missing file returned nil error, want error")
+	}
+}
+
 func TestMigrate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -235,7 +264,7 @@ func TestMigrate(t *testing.T) {
 				LogLevel:   configpb.Configuration_DEBUG,
 				LogToCloud: proto.Bool(true),
 				AgentProperties: &configpb.AgentProperties{
-					LogUsageMetrics: true,
+					LogUsageMetrics: proto.Bool(true),
 				},
 				SqlserverConfiguration: &configpb.SQLServerConfiguration{
 					Enabled: proto.Bool(true),
@@ -332,7 +361,7 @@ func TestMigrate(t *testing.T) {
 				LogLevel:   configpb.Configuration_DEBUG,
 				LogToCloud: proto.Bool(true),
 				AgentProperties: &configpb.AgentProperties{
-					LogUsageMetrics: true,
+					LogUsageMetrics: proto.Bool(true),
 				},
 				SqlserverConfiguration: &configpb.SQLServerConfiguration{
 					Enabled: proto.Bool(true),
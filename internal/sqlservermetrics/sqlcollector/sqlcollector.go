@@ -283,6 +283,32 @@ var SQLMetrics = []SQLMetricsStruct{
 			return res
 		},
 	},
+	{
+		Name: "DB_AVAILABILITY_GROUP_REPLICAS",
+		Query: `SELECT ag.name AS availability_group,
+							ar.replica_server_name,
+							ars.role_desc,
+							ars.synchronization_health_desc,
+							ar.availability_mode_desc,
+							ars.connected_state_desc
+						FROM sys.availability_groups ag
+							INNER JOIN sys.availability_replicas ar ON ag.group_id = ar.group_id
+							LEFT JOIN sys.dm_hadr_availability_replica_states ars ON ar.replica_id = ars.replica_id`,
+		Fields: func(fields [][]any) []map[string]string {
+			res := []map[string]string{}
+			for _, f := range fields {
+				res = append(res, map[string]string{
+					"availability_group":     handleNilString(f[0]),
+					"replica_server_name":    handleNilString(f[1]),
+					"role":                   handleNilString(f[2]),
+					"synchronization_health": handleNilString(f[3]),
+					"availability_mode":      handleNilString(f[4]),
+					"connected_state":        handleNilString(f[5]),
+				})
+			}
+			return res
+		},
+	},
 }
 
 // handleNilString converts generic string to the desired string output,
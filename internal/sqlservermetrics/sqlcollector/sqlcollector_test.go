@@ -216,6 +216,45 @@ func TestFields(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "DB_AVAILABILITY_GROUP_REPLICAS",
+			input: [][]any{
+				{
+					"test_ag",
+					"SQL-1",
+					"PRIMARY",
+					"HEALTHY",
+					"SYNCHRONOUS_COMMIT",
+					"CONNECTED",
+				},
+				{
+					"test_ag",
+					"SQL-2",
+					nil,
+					nil,
+					"ASYNCHRONOUS_COMMIT",
+					nil,
+				},
+			},
+			want: []map[string]string{
+				{
+					"availability_group":     "test_ag",
+					"replica_server_name":    "SQL-1",
+					"role":                   "PRIMARY",
+					"synchronization_health": "HEALTHY",
+					"availability_mode":      "SYNCHRONOUS_COMMIT",
+					"connected_state":        "CONNECTED",
+				},
+				{
+					"availability_group":     "test_ag",
+					"replica_server_name":    "SQL-2",
+					"role":                   "unknown",
+					"synchronization_health": "unknown",
+					"availability_mode":      "ASYNCHRONOUS_COMMIT",
+					"connected_state":        "unknown",
+				},
+			},
+		},
 	}
 	for idx, tc := range testcases {
 		got := SQLMetrics[idx].Fields(tc.input)
@@ -19,27 +19,32 @@ package sqlcollector
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	// Required for loading sqlserver driver.
 	_ "github.com/microsoft/go-mssqldb"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/querystats"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/secretredact"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlserverutils"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 )
 
 // V1 that execute cmd and connect to SQL server.
 type V1 struct {
-	dbConn  *sql.DB
-	windows bool
+	dbConn       *sql.DB
+	windows      bool
+	zoneResolver *ipinfo.ZoneResolver
 }
 
 // NewV1 initializes a V1 instance.
 func NewV1(driver, conn string, windows bool) (*V1, error) {
 	dbConn, err := sql.Open(driver, conn)
 	if err != nil {
-		return nil, err
+		return nil, secretredact.Error(err, conn)
 	}
-	return &V1{dbConn: dbConn, windows: windows}, nil
+	return &V1{dbConn: dbConn, windows: windows, zoneResolver: ipinfo.DefaultZoneResolver()}, nil
 }
 
 // CollectSQLMetrics collects SQL metrics from target sql server.
@@ -49,7 +54,9 @@ func (c *V1) CollectSQLMetrics(ctx context.Context, timeout time.Duration) []sql
 		func() {
 			ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
 			defer cancel()
+			start := time.Now()
 			queryResult, err := c.executeSQL(ctxWithTimeout, rule.Query)
+			querystats.Observe("sqlserver", rule.Name, time.Since(start), err)
 			if err != nil {
 				log.Logger.Errorw("Failed to run sql query", "query", rule.Query, "error", err)
 				return
@@ -67,20 +74,45 @@ func (c *V1) CollectSQLMetrics(ctx context.Context, timeout time.Duration) []sql
 				}
 				queryResult[0] = append(queryResult[0], os)
 			}
+			fields := rule.Fields(queryResult)
+			if rule.Name == "DB_AVAILABILITY_GROUP_REPLICAS" {
+				c.addReplicaZones(ctx, fields)
+			}
 			details = append(details, sqlserverutils.MetricDetails{
 				Name:   rule.Name,
-				Fields: rule.Fields(queryResult),
+				Fields: fields,
 			})
 		}()
 	}
 	return details
 }
 
+// addReplicaZones adds the GCE zone of each availability group replica to its fields.
+// Replica server names may include a named instance, e.g. HOST\INSTANCE, which is not part of the host name.
+func (c *V1) addReplicaZones(ctx context.Context, fields []map[string]string) {
+	for _, f := range fields {
+		f["zone"] = "unknown"
+		if c.zoneResolver == nil {
+			continue
+		}
+		host, _, _ := strings.Cut(f["replica_server_name"], `\`)
+		if zone := c.zoneResolver.Zone(ctx, host); zone != "" {
+			f["zone"] = zone
+		}
+	}
+}
+
 // Close closes the database collection.
 func (c *V1) Close() error {
 	return c.dbConn.Close()
 }
 
+// Ping runs a trivial query to verify that the target SQL Server can execute queries.
+func (c *V1) Ping(ctx context.Context) error {
+	_, err := c.executeSQL(ctx, "SELECT 1")
+	return err
+}
+
 func (c *V1) executeSQL(ctx context.Context, query string) ([][]any, error) {
 	err := c.dbConn.PingContext(ctx)
 	if err != nil {
@@ -19,12 +19,14 @@ package sqlcollector
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	_ "github.com/microsoft/go-mssqldb"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/ipinfo"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlserverutils"
 )
 
@@ -240,3 +242,51 @@ func TestClose(t *testing.T) {
 		t.Errorf("Close() = %v, want nil", err)
 	}
 }
+
+type fakeResolver struct {
+	hosts map[string][]string
+	addrs map[string][]string
+}
+
+func (f fakeResolver) LookupHost(host string) ([]string, error) {
+	addrs, ok := f.hosts[host]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return addrs, nil
+}
+
+func (f fakeResolver) ParseIP(ip string) net.IP {
+	return net.ParseIP(ip)
+}
+
+func (f fakeResolver) LookupAddr(ip string) ([]string, error) {
+	return f.addrs[ip], nil
+}
+
+func TestAddReplicaZones(t *testing.T) {
+	c := V1{
+		zoneResolver: ipinfo.NewZoneResolver(fakeResolver{
+			hosts: map[string][]string{"SQL-1": {"10.0.0.1"}, "SQL-2": {"10.0.0.2"}},
+			addrs: map[string][]string{
+				"10.0.0.1": {"sql-1.us-central1-a.c.fake-project.internal."},
+				"10.0.0.2": {"sql-2.us-central1-b.c.fake-project.internal."},
+			},
+		}),
+	}
+	fields := []map[string]string{
+		{"replica_server_name": "SQL-1"},
+		{"replica_server_name": `SQL-2\INSTANCE`},
+		{"replica_server_name": "SQL-3"},
+	}
+	want := []map[string]string{
+		{"replica_server_name": "SQL-1", "zone": "us-central1-a"},
+		{"replica_server_name": `SQL-2\INSTANCE`, "zone": "us-central1-b"},
+		{"replica_server_name": "SQL-3", "zone": "unknown"},
+	}
+
+	c.addReplicaZones(context.Background(), fields)
+	if diff := cmp.Diff(want, fields); diff != "" {
+		t.Errorf("addReplicaZones() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -25,14 +25,16 @@ import (
 	"time"
 
 	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/metadataserver"
 
 	bo "github.com/cenkalti/backoff/v4"
 	retry "github.com/sethvargo/go-retry"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/wlm"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 
 	"github.com/GoogleCloudPlatform/workloadagent/internal/databasecenter"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlcollector"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlserverutils"
 	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
@@ -40,7 +42,7 @@ import (
 
 // instanceProperties represents properties of instance.
 type instanceProperties struct {
-	Name          string
+	Location      string
 	Instance      string
 	InstanceID    string
 	ProjectID     string
@@ -52,11 +54,25 @@ type instanceProperties struct {
 // SQLServerMetrics contains variables and methods to collect metrics for SQL Server databases.
 type SQLServerMetrics struct {
 	Config         *configpb.SQLServerConfiguration
+	Endpoints      *configpb.Endpoints
+	Filter         *workloadmanager.Filter
 	DBcenterClient databasecenter.Client
+	// WLMClient writes the insights to Data Warehouse.
+	WLMClient workloadmanager.WLMWriter
+	// LegacyAgents suppresses the collection while a legacy agent which takes precedence collects SQL Server.
+	LegacyAgents *workloadmanager.LegacyAgents
 }
 
 // CollectMetricsOnce collects metrics for SQL Server databases running on the host.
 func (s *SQLServerMetrics) CollectMetricsOnce(ctx context.Context, dwActivated bool) {
+	if s.Filter.ExcludesWorkloadType(string(workloadmanager.SQLSERVER)) {
+		log.Logger.Debug("SQL Server is excluded from Data Warehouse, skipping the collection.")
+		return
+	}
+	if s.LegacyAgents.Suppresses(string(workloadmanager.SQLSERVER)) {
+		log.Logger.Debug("A legacy agent collects SQL Server, skipping the collection.")
+		return
+	}
 	log.Logger.Info("SQLServerMetrics SQL Collection starts.")
 
 	if err := s.sqlCollection(ctx, dwActivated); err != nil {
@@ -74,11 +90,12 @@ func (s *SQLServerMetrics) CollectMetricsOnce(ctx context.Context, dwActivated b
 // CollectDBCenterMetricsOnce collects metrics for SQL Server databases running on the host.
 func (s *SQLServerMetrics) CollectDBCenterMetricsOnce(ctx context.Context) {
 	log.Logger.Info("SQLServerMetrics DBCenter Collection starts.")
-	// Send metadata details to database center
-	err := s.DBcenterClient.SendMetadataToDatabaseCenter(ctx, s.dbCenterMetrics(ctx))
-	if err != nil {
-		log.CtxLogger(ctx).Info("Unable to send information to Database Center, please refer to documentation to make sure that all prerequisites are met")
-		log.CtxLogger(ctx).Debugf("Failed to send metadata to database center: %v", err)
+	// Send metadata details of each instance to database center
+	for _, metrics := range s.dbCenterMetrics(ctx) {
+		if err := s.DBcenterClient.SendMetadataToDatabaseCenter(ctx, metrics); err != nil {
+			log.CtxLogger(ctx).Info("Unable to send information to Database Center, please refer to documentation to make sure that all prerequisites are met")
+			log.CtxLogger(ctx).Debugf("Failed to send metadata to database center: %v", err)
+		}
 	}
 	log.Logger.Info("SQLServerMetrics DBCenter Collection ends.")
 }
@@ -87,9 +104,8 @@ func (s *SQLServerMetrics) CollectDBCenterMetricsOnce(ctx context.Context) {
 func sourceInstanceProperties() instanceProperties {
 	properties := metadataserver.ReadCloudPropertiesWithRetry(bo.NewConstantBackOff(30 * time.Second))
 	location := string(properties.Zone[0:strings.LastIndex(properties.Zone, "-")])
-	name := fmt.Sprintf("projects/%s/locations/%s", properties.ProjectID, location)
 	return instanceProperties{
-		Name:          name,
+		Location:      location,
 		ProjectID:     properties.ProjectID,
 		ProjectNumber: properties.NumericProjectID,
 		InstanceID:    properties.InstanceID,
@@ -110,12 +126,11 @@ func (s *SQLServerMetrics) initCollection(ctx context.Context, windows bool) (*w
 		return nil, fmt.Errorf("empty credentials")
 	}
 
-	wlm, err := wlm.NewWorkloadManager(ctx)
-	if err != nil {
-		return nil, err
+	if s.WLMClient == nil {
+		return nil, fmt.Errorf("the Data Warehouse client is not available")
 	}
 
-	return wlm, nil
+	return wlm.NewWorkloadManager(s.WLMClient), nil
 }
 
 // ValidateCredCfgSQL validates if the configuration file is valid for SQL collection.
@@ -229,9 +244,38 @@ func validateCredCfgGuest(remote, windows bool, guestCfg *sqlserverutils.GuestCo
 	return nil
 }
 
+// CheckConnections connects to every configured SQL Server instance and runs a trivial query.
+// The returned map is keyed by host and port; a nil value means the connection succeeded.
+func CheckConnections(ctx context.Context, config *configpb.SQLServerConfiguration, e *configpb.Endpoints) map[string]error {
+	results := make(map[string]error)
+	for _, credentialCfg := range config.GetCredentialConfigurations() {
+		for _, sqlCfg := range sqlConfigFromCredential(credentialCfg) {
+			target := fmt.Sprintf("%s:%d", sqlCfg.Host, sqlCfg.PortNumber)
+			projectID := sqlCfg.ProjectID
+			if projectID == "" {
+				projectID = sourceInstanceProperties().ProjectID
+			}
+			pswd, err := secretValue(ctx, e, projectID, sqlCfg.SecretName)
+			if err != nil {
+				results[target] = fmt.Errorf("failed to get secret: %w", err)
+				continue
+			}
+			conn := fmt.Sprintf("server=%s;user id=%s;password=%s;port=%d;", sqlCfg.Host, sqlCfg.Username, pswd, sqlCfg.PortNumber)
+			c, err := sqlcollector.NewV1("sqlserver", conn, false)
+			if err != nil {
+				results[target] = err
+				continue
+			}
+			results[target] = c.Ping(ctx)
+			c.Close()
+		}
+	}
+	return results
+}
+
 // secretValue gets secret value from Secret Manager.
-func secretValue(ctx context.Context, projectID string, secretName string) (string, error) {
-	gceClient, err := gce.NewGCEClient(ctx)
+func secretValue(ctx context.Context, e *configpb.Endpoints, projectID string, secretName string) (string, error) {
+	gceClient, err := endpoints.NewGCEClient(ctx, e)
 	if err != nil {
 		return "", err
 	}
@@ -240,7 +284,13 @@ func secretValue(ctx context.Context, projectID string, secretName string) (stri
 
 // updateCollectedData constructs writeinsightrequest from given collected details.
 // The func will be called by both guest and sql collections.
-func updateCollectedData(wlmService wlm.WorkloadManagerService, sourceProps, targetProps instanceProperties, details []sqlserverutils.MetricDetails) {
+// Validation detail fields excluded by filter are not sent.
+func updateCollectedData(wlmService wlm.WorkloadManagerService, filter *workloadmanager.Filter, sourceProps, targetProps instanceProperties, details []sqlserverutils.MetricDetails) {
+	for i, detail := range details {
+		for j, fields := range detail.Fields {
+			details[i].Fields[j] = filter.Details(fields)
+		}
+	}
 	sqlservervalidation := wlm.InitializeSQLServerValidation(sourceProps.ProjectID, targetProps.Instance)
 	sqlservervalidation = wlm.UpdateValidationDetails(sqlservervalidation, details)
 	writeInsightRequest := wlm.InitializeWriteInsightRequest(sqlservervalidation, targetProps.InstanceID)
@@ -250,9 +300,9 @@ func updateCollectedData(wlmService wlm.WorkloadManagerService, sourceProps, tar
 }
 
 // sendRequestToWLM sends request to workloadmanager.
-func sendRequestToWLM(ctx context.Context, wlmService wlm.WorkloadManagerService, location string, retries int32, retryFrequency time.Duration) {
+func sendRequestToWLM(ctx context.Context, wlmService wlm.WorkloadManagerService, project, location string, retries int32, retryFrequency time.Duration) {
 	sendRequest := func(ctx context.Context) error {
-		if _, err := wlmService.SendRequest(location); err != nil {
+		if _, err := wlmService.SendRequest(project, location); err != nil {
 			return err
 		}
 		return nil
@@ -22,12 +22,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/guestoscollector"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlcollector"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlserverutils"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
-	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 )
 
 // osCollection is the linux implementation of OSCollection.
@@ -52,7 +54,7 @@ func (s *SQLServerMetrics) osCollection(ctx context.Context, dwActivated bool) e
 	}
 
 	sip := sourceInstanceProperties()
-	disks, err := allDisks(ctx, sip)
+	disks, err := allDisks(ctx, s.Endpoints, sip)
 	if err != nil {
 		return fmt.Errorf("failed to collect disk info: %w", err)
 	}
@@ -66,14 +68,14 @@ func (s *SQLServerMetrics) osCollection(ctx context.Context, dwActivated bool) e
 	}
 	log.Logger.Debug("Collecting guest rules completes")
 	// linux collector only supports local collections
-	updateCollectedData(wlm, sip, sip, details)
+	updateCollectedData(wlm, s.Filter, sip, sip, details)
 
 	log.Logger.Debugf("Source vm %s is sending os collected data on target machine, %s, to workload manager.", sip.Instance, sip.Instance)
 	if !dwActivated {
 		log.Logger.Debug("Data Warehouse is not activated, not sending metrics to Data Warehouse")
 		return nil
 	}
-	sendRequestToWLM(ctx, wlm, sip.Name, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
+	sendRequestToWLM(ctx, wlm, sip.ProjectID, sip.Location, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
 
 	return nil
 }
@@ -104,7 +106,7 @@ func (s *SQLServerMetrics) sqlCollection(ctx context.Context, dwActivated bool)
 			if projectID == "" {
 				projectID = sip.ProjectID
 			}
-			pswd, err := secretValue(ctx, projectID, sqlCfg.SecretName)
+			pswd, err := secretValue(ctx, s.Endpoints, projectID, sqlCfg.SecretName)
 			if err != nil {
 				usagemetrics.Error(usagemetrics.SecretManagerValueError)
 				log.Logger.Errorw("Failed to get secret value", "error", err)
@@ -147,21 +149,21 @@ func (s *SQLServerMetrics) sqlCollection(ctx context.Context, dwActivated bool)
 			validationDetails = details
 		}
 		// linux collector only supports local collections
-		updateCollectedData(wlm, sip, sip, validationDetails)
+		updateCollectedData(wlm, s.Filter, sip, sip, validationDetails)
 		log.Logger.Debugf("Source vm %s is sending collected sql data on target machine, %s, to workload manager.", sip.Instance, sip.Instance)
 		if !dwActivated {
 			log.Logger.Debug("Data Warehouse is not activated, not sending metrics to Data Warehouse")
 			continue
 		}
-		sendRequestToWLM(ctx, wlm, sip.Name, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
+		sendRequestToWLM(ctx, wlm, sip.ProjectID, sip.Location, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
 
 	}
 	return nil
 }
 
 // allDisks attempts to call compute api to return all possible disks.
-func allDisks(ctx context.Context, ip instanceProperties) ([]*sqlserverutils.Disks, error) {
-	tempGCE, err := gce.NewGCEClient(ctx)
+func allDisks(ctx context.Context, e *configpb.Endpoints, ip instanceProperties) ([]*sqlserverutils.Disks, error) {
+	tempGCE, err := endpoints.NewGCEClient(ctx, e)
 	if err != nil {
 		return nil, err
 	}
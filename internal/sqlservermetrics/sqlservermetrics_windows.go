@@ -78,7 +78,7 @@ func (s *SQLServerMetrics) osCollection(ctx context.Context, dwActivated bool) e
 				if projectID == "" {
 					projectID = sip.ProjectID
 				}
-				pswd, err := secretValue(ctx, projectID, guestCfg.GuestSecretName)
+				pswd, err := secretValue(ctx, s.Endpoints, projectID, guestCfg.GuestSecretName)
 				if err != nil {
 					usagemetrics.Error(usagemetrics.SecretManagerValueError)
 					log.Logger.Errorw("Collection failed", "target", guestCfg.ServerName, "error", fmt.Errorf("failed to get secret value: %v", err))
@@ -109,12 +109,12 @@ func (s *SQLServerMetrics) osCollection(ctx context.Context, dwActivated bool) e
 		log.Logger.Debug("Collecting guest rules completes")
 
 		log.Logger.Debugf("Source vm %s is sending os collected data on target machine, %s, to workload manager.", sip.Instance, targetInstanceProps.Instance)
-		updateCollectedData(wlm, sip, targetInstanceProps, details)
+		updateCollectedData(wlm, s.Filter, sip, targetInstanceProps, details)
 		if !dwActivated {
 			log.Logger.Debug("Data Warehouse is not activated, not sending metrics to Data Warehouse")
 			continue
 		}
-		sendRequestToWLM(ctx, wlm, sip.Name, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
+		sendRequestToWLM(ctx, wlm, sip.ProjectID, sip.Location, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
 
 		// Local collection.
 		// Exit the loop. Only take the first credential in the credentialconfiguration array.
@@ -153,7 +153,7 @@ func (s *SQLServerMetrics) sqlCollection(ctx context.Context, dwActivated bool)
 			if projectID == "" {
 				projectID = sip.ProjectID
 			}
-			pswd, err := secretValue(ctx, projectID, sqlCfg.SecretName)
+			pswd, err := secretValue(ctx, s.Endpoints, projectID, sqlCfg.SecretName)
 			if err != nil {
 				usagemetrics.Error(usagemetrics.SecretManagerValueError)
 				log.Logger.Errorw("Failed to get secret value", "error", err)
@@ -212,13 +212,13 @@ func (s *SQLServerMetrics) sqlCollection(ctx context.Context, dwActivated bool)
 				Instance:   credentialCfg.GetVmProperties().GetInstanceName(),
 			}
 		}
-		updateCollectedData(wlm, sip, targetInstanceProps, validationDetails)
+		updateCollectedData(wlm, s.Filter, sip, targetInstanceProps, validationDetails)
 		log.Logger.Debugf("Source vm %s is sending collected sql data on target machine, %s, to workload manager.", sip.Instance, targetInstanceProps.Instance)
 		if !dwActivated {
 			log.Logger.Debug("Data Warehouse is not activated, not sending metrics to Data Warehouse")
 			continue
 		}
-		sendRequestToWLM(ctx, wlm, sip.Name, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
+		sendRequestToWLM(ctx, wlm, sip.ProjectID, sip.Location, s.Config.GetMaxRetries(), s.Config.GetRetryFrequency().AsDuration())
 	}
 	return nil
 }
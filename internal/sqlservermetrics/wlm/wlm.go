@@ -18,43 +18,44 @@ limitations under the License.
 package wlm
 
 import (
-	"context"
-	"fmt"
-
-	"google.golang.org/api/option"
 	wlmngr "google.golang.org/api/workloadmanager/v1"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlserverutils"
-)
-
-const (
-	basePath = "https://workloadmanager-datawarehouse.googleapis.com/"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/workloadmanager"
 )
 
 // WorkloadManagerService the interface of WLM.
 type WorkloadManagerService interface {
-	SendRequest(string) (*wlmngr.WriteInsightResponse, error)
+	SendRequest(project, location string) (*wlmngr.WriteInsightResponse, error)
 	UpdateRequest(*wlmngr.WriteInsightRequest)
 }
 
-// WLM struct which contains workloadmanager service.
+// WLM writes SQL Server insights with the Data Warehouse client of the agent.
 type WLM struct {
-	wlmService *wlmngr.Service
-	Request    *wlmngr.WriteInsightRequest
+	writer  workloadmanager.WLMWriter
+	Request *wlmngr.WriteInsightRequest
 }
 
-// NewWorkloadManager creates new WLM and it return non-nil error if any error was caught.
-func NewWorkloadManager(ctx context.Context) (*WLM, error) {
-	wlm, err := wlmngr.NewService(ctx, option.WithEndpoint(basePath))
-	if err != nil {
-		return nil, fmt.Errorf("%v error creating WLM client", err)
-	}
-	return &WLM{wlmService: wlm}, nil
+// NewWorkloadManager creates a new WLM writing with writer.
+func NewWorkloadManager(writer workloadmanager.WLMWriter) *WLM {
+	return &WLM{writer: writer}
 }
 
-// SendRequest sends request to wlmngr.
-func (wlm *WLM) SendRequest(location string) (*wlmngr.WriteInsightResponse, error) {
-	return wlm.wlmService.Projects.Locations.Insights.WriteInsight(location, wlm.Request).Do()
+// SendRequest writes the request to Data Warehouse for project and location.
+func (wlm *WLM) SendRequest(project, location string) (*wlmngr.WriteInsightResponse, error) {
+	req, err := workloadmanager.SQLServerInsightRequest(wlm.Request)
+	if err != nil {
+		return nil, err
+	}
+	res, err := wlm.writer.WriteInsightAndGetResponse(project, location, req)
+	if err != nil {
+		return nil, err
+	}
+	// Writers which do not send the insight, such as the filter, return no response.
+	if res == nil {
+		return &wlmngr.WriteInsightResponse{}, nil
+	}
+	return &wlmngr.WriteInsightResponse{ServerResponse: res.ServerResponse}, nil
 }
 
 // UpdateRequest updates WLM request.
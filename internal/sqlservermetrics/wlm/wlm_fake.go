@@ -31,7 +31,7 @@ type MockWlmService struct {
 }
 
 // SendRequest mock function.
-func (m *MockWlmService) SendRequest(location string) (*workloadmanager.WriteInsightResponse, error) {
+func (m *MockWlmService) SendRequest(project, location string) (*workloadmanager.WriteInsightResponse, error) {
 	if m.Request == nil {
 		return nil, fmt.Errorf("any error")
 	}
@@ -17,13 +17,17 @@ limitations under the License.
 package wlm
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/workloadmanager/v1"
 	"google.golang.org/protobuf/testing/protocmp"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/sqlservermetrics/sqlserverutils"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
 )
 
 func TestInitializeSQLServerValidation(t *testing.T) {
@@ -100,11 +104,80 @@ func TestUpdateRequest(t *testing.T) {
 
 func TestMockWLMService(t *testing.T) {
 	w := MockWlmService{}
-	if _, err := w.SendRequest(""); err == nil {
+	if _, err := w.SendRequest("", ""); err == nil {
 		t.Errorf("Mocked SendRequest() returned no error. Want an error to present")
 	}
 	w.UpdateRequest(w.InitializeMockWriteInsightRequest())
-	if _, err := w.SendRequest(""); err != nil {
+	if _, err := w.SendRequest("", ""); err != nil {
 		t.Errorf("Mocked SendRequest() returned unexpected error: %v", err)
 	}
 }
+
+type fakeWriter struct {
+	project, location string
+	req               *dwpb.WriteInsightRequest
+	res               *wlm.WriteInsightResponse
+	err               error
+}
+
+func (f *fakeWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	f.project, f.location, f.req = project, location, req
+	return f.res, f.err
+}
+
+func TestSendRequest(t *testing.T) {
+	request := InitializeWriteInsightRequest(InitializeSQLServerValidation("test-project", "test-instance"), "test-instance-id")
+	tests := []struct {
+		name     string
+		request  *workloadmanager.WriteInsightRequest
+		writer   *fakeWriter
+		wantCode int
+		wantErr  bool
+	}{
+		{
+			name:     "Success",
+			request:  request,
+			writer:   &fakeWriter{res: &wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}}},
+			wantCode: 201,
+		},
+		{
+			name:    "NotWritten",
+			request: request,
+			writer:  &fakeWriter{},
+		},
+		{
+			name:    "WriteError",
+			request: request,
+			writer:  &fakeWriter{err: errors.New("write error")},
+			wantErr: true,
+		},
+		{
+			name:    "NoValidation",
+			request: &workloadmanager.WriteInsightRequest{},
+			writer:  &fakeWriter{},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := NewWorkloadManager(tc.writer)
+			w.UpdateRequest(tc.request)
+			res, err := w.SendRequest("test-project", "us-central1")
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("SendRequest() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if res.HTTPStatusCode != tc.wantCode {
+				t.Errorf("SendRequest() returned status code %d, want %d", res.HTTPStatusCode, tc.wantCode)
+			}
+			if tc.writer.project != "test-project" || tc.writer.location != "us-central1" {
+				t.Errorf("SendRequest() wrote to %s/%s, want test-project/us-central1", tc.writer.project, tc.writer.location)
+			}
+			if tc.writer.req.GetInsight().GetSqlserverValidation() == "" {
+				t.Error("SendRequest() wrote an insight without a SQL Server validation")
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package statestore persists the state collectors keep across collection cycles, such as growth
+// rates, deduplication hashes and last sent timestamps, in a local file.
+//
+// The file holds a checksum of its content. A file which can't be parsed or doesn't match its
+// checksum is moved aside and the store starts empty, so corrupted state is never used.
+package statestore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+const (
+	// LinuxPath is the default path of the state store on linux.
+	LinuxPath = `/var/lib/google-cloud-workload-agent/state.json`
+	// WindowsPath is the default path of the state store on windows.
+	WindowsPath = `C:\Program Files\Google\google-cloud-workload-agent\state\state.json`
+
+	// version is incremented when the format of the file changes, older files are reset.
+	version = 1
+	// corruptSuffix is appended to the name of a corrupted file when it is moved aside.
+	corruptSuffix = ".corrupt"
+)
+
+// stateFile is the content of the state store file.
+type stateFile struct {
+	Version int `json:"version"`
+	// Checksum is the hex encoded SHA-256 of Data.
+	Checksum string          `json:"checksum"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// Store reads and writes the state of the collectors, grouped in namespaces, in a state file.
+// A Store is safe for concurrent use.
+type Store struct {
+	path string
+
+	mu sync.Mutex
+	// state is read from the file on first use.
+	state map[string]map[string]json.RawMessage
+}
+
+var (
+	defaultStore     *Store
+	defaultStoreOnce sync.Once
+)
+
+// DefaultPath returns the default path of the state store based on the operating system.
+func DefaultPath() string {
+	return capabilities.ByOS(LinuxPath, WindowsPath)
+}
+
+// Default returns the Store at DefaultPath shared by the collectors of the agent.
+func Default() *Store {
+	defaultStoreOnce.Do(func() {
+		defaultStore = New(DefaultPath())
+	})
+	return defaultStore
+}
+
+// New returns a Store for the state file at path. The file and its directory are created on the first write.
+func New(path string) *Store {
+	return &Store{path: path}
+}
+
+// Get unmarshals the value of key in namespace into v and returns whether the key was found.
+func (s *Store) Get(namespace, key string, v any) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	raw, ok := s.state[namespace][key]
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return false, fmt.Errorf("unmarshalling state %s/%s: %w", namespace, key, err)
+	}
+	return true, nil
+}
+
+// Set stores v as the value of key in namespace and writes the state file.
+func (s *Store) Set(namespace, key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("marshalling state %s/%s: %w", namespace, key, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	if s.state[namespace] == nil {
+		s.state[namespace] = make(map[string]json.RawMessage)
+	}
+	s.state[namespace][key] = raw
+	return s.write()
+}
+
+// Delete removes key from namespace and writes the state file.
+func (s *Store) Delete(namespace, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	if _, ok := s.state[namespace][key]; !ok {
+		return nil
+	}
+	delete(s.state[namespace], key)
+	if len(s.state[namespace]) == 0 {
+		delete(s.state, namespace)
+	}
+	return s.write()
+}
+
+// Keys returns the keys stored in namespace.
+func (s *Store) Keys(namespace string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	keys := make([]string, 0, len(s.state[namespace]))
+	for key := range s.state[namespace] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// load reads the state file unless it has been read. A missing file results in an empty state,
+// a corrupted file is moved aside and results in an empty state. s.mu must be held.
+func (s *Store) load() {
+	if s.state != nil {
+		return
+	}
+	s.state = make(map[string]map[string]json.RawMessage)
+	content, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		log.Logger.Warnw("Unable to read the state store, starting with an empty state", "path", s.path, "error", err)
+		return
+	}
+	state, err := parse(content)
+	if err != nil {
+		corruptPath := s.path + corruptSuffix
+		log.Logger.Warnw("Resetting the corrupted state store", "path", s.path, "movedTo", corruptPath, "error", err)
+		if err := os.Rename(s.path, corruptPath); err != nil {
+			log.Logger.Warnw("Unable to move the corrupted state store aside", "path", s.path, "error", err)
+		}
+		return
+	}
+	s.state = state
+}
+
+// parse returns the state in the content of a state file after verifying its version and checksum.
+func parse(content []byte) (map[string]map[string]json.RawMessage, error) {
+	var f stateFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, fmt.Errorf("parsing state file: %w", err)
+	}
+	if f.Version != version {
+		return nil, fmt.Errorf("unsupported state file version %d", f.Version)
+	}
+	if f.Checksum != checksum(f.Data) {
+		return nil, errors.New("state file does not match its checksum")
+	}
+	state := make(map[string]map[string]json.RawMessage)
+	if err := json.Unmarshal(f.Data, &state); err != nil {
+		return nil, fmt.Errorf("parsing state: %w", err)
+	}
+	return state, nil
+}
+
+// write replaces the state file with the current state. The file is written to a temporary file
+// first, so a crash while writing doesn't leave a partial file behind. s.mu must be held.
+func (s *Store) write() error {
+	data, err := json.Marshal(s.state)
+	if err != nil {
+		return fmt.Errorf("marshalling state: %w", err)
+	}
+	content, err := json.Marshal(stateFile{Version: version, Checksum: checksum(data), Data: data})
+	if err != nil {
+		return fmt.Errorf("marshalling state file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("creating state store directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("creating temporary state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing temporary state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing temporary state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replacing state file %s: %w", s.path, err)
+	}
+	return nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
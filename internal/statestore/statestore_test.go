@@ -0,0 +1,163 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package statestore
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testState struct {
+	Sum  string    `json:"sum"`
+	Sent time.Time `json:"sent"`
+}
+
+func TestSetAndGet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "state.json")
+	want := testState{Sum: "abc", Sent: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}
+	s := New(path)
+	if err := s.Set("dedup", "mysql", want); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	if err := s.Set("dedup", "redis", want); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	if err := s.Delete("dedup", "redis"); err != nil {
+		t.Fatalf("Delete() returned unexpected error: %v", err)
+	}
+
+	// The state is read from the file by a new store, as after an agent restart.
+	s = New(path)
+	var got testState
+	found, err := s.Get("dedup", "mysql", &got)
+	if err != nil || !found {
+		t.Fatalf("Get(dedup, mysql) = %v, %v, want true, nil", found, err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get(dedup, mysql) returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if found, err := s.Get("dedup", "redis", &got); err != nil || found {
+		t.Errorf("Get(dedup, redis) = %v, %v, want false, nil", found, err)
+	}
+	if found, err := s.Get("growth", "mysql", &got); err != nil || found {
+		t.Errorf("Get(growth, mysql) = %v, %v, want false, nil", found, err)
+	}
+	if diff := cmp.Diff([]string{"mysql"}, s.Keys("dedup")); diff != "" {
+		t.Errorf("Keys(dedup) returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetInvalidValue(t *testing.T) {
+	s := New(filepath.Join(t.TempDir(), "state.json"))
+	if err := s.Set("dedup", "mysql", "not a struct"); err != nil {
+		t.Fatalf("Set() returned unexpected error: %v", err)
+	}
+	var got testState
+	if _, err := s.Get("dedup", "mysql", &got); err == nil {
+		t.Error("Get() returned nil error for a value of another type, want error")
+	}
+}
+
+func TestCorruptedStateIsReset(t *testing.T) {
+	valid := func(t *testing.T, path string) []byte {
+		t.Helper()
+		if err := New(path).Set("dedup", "mysql", testState{Sum: "abc"}); err != nil {
+			t.Fatalf("Set() returned unexpected error: %v", err)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+		}
+		return content
+	}
+	tests := []struct {
+		name    string
+		corrupt func(content []byte) []byte
+	}{
+		{
+			name:    "Truncated",
+			corrupt: func(content []byte) []byte { return content[:len(content)/2] },
+		},
+		{
+			name: "ChecksumMismatch",
+			corrupt: func(content []byte) []byte {
+				return []byte(string(content[:len(content)-10]) + `"x":{}}}`)
+			},
+		},
+		{
+			name:    "UnsupportedVersion",
+			corrupt: func([]byte) []byte { return []byte(`{"version":0,"data":{}}`) },
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state.json")
+			content := tc.corrupt(valid(t, path))
+			if err := os.WriteFile(path, content, 0600); err != nil {
+				t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+			}
+
+			s := New(path)
+			var got testState
+			if found, err := s.Get("dedup", "mysql", &got); err != nil || found {
+				t.Errorf("Get(dedup, mysql) = %v, %v for a corrupted state, want false, nil", found, err)
+			}
+			moved, err := os.ReadFile(path + corruptSuffix)
+			if err != nil {
+				t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path+corruptSuffix, err)
+			}
+			if string(moved) != string(content) {
+				t.Errorf("corrupted state file was moved with content %q, want %q", moved, content)
+			}
+
+			// The reset store is usable.
+			if err := s.Set("dedup", "redis", testState{Sum: "def"}); err != nil {
+				t.Fatalf("Set() returned unexpected error: %v", err)
+			}
+			keys := New(path).Keys("dedup")
+			sort.Strings(keys)
+			if diff := cmp.Diff([]string{"redis"}, keys); diff != "" {
+				t.Errorf("Keys(dedup) returned unexpected diff after a reset (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSetWriteError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", file, err)
+	}
+	s := New(filepath.Join(file, "state.json"))
+	if err := s.Set("dedup", "mysql", testState{}); err == nil {
+		t.Error("Set() returned nil error for a state file below a regular file, want error")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	if Default() != Default() {
+		t.Error("Default() returned different stores, want the same store")
+	}
+	if Default().path != DefaultPath() {
+		t.Errorf("Default().path = %q, want %q", Default().path, DefaultPath())
+	}
+}
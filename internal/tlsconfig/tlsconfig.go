@@ -0,0 +1,209 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tlsconfig builds the TLS client configuration of database connections from the agent configuration.
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// SecretRefreshInterval is how often client certificates stored in Secret Manager are read again.
+const SecretRefreshInterval = 5 * time.Minute
+
+// SecretGetter reads secrets from Secret Manager.
+type SecretGetter interface {
+	GetSecret(ctx context.Context, projectID, secretName string) (string, error)
+}
+
+// New returns the TLS configuration for connecting to serverName, or nil if TLS is not enabled.
+// The client certificate can only be read from files.
+func New(cfg *configpb.TLSConfiguration, serverName string) (*tls.Config, error) {
+	return NewWithSecrets(context.Background(), cfg, serverName, nil)
+}
+
+// NewWithSecrets returns the TLS configuration for connecting to serverName, or nil if TLS is not
+// enabled. The client certificate is read from files or from Secret Manager through secrets, and
+// reloaded for new connections once it is rotated.
+func NewWithSecrets(ctx context.Context, cfg *configpb.TLSConfiguration, serverName string, secrets SecretGetter) (*tls.Config, error) {
+	if !cfg.GetEnabled() {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         serverName,
+		InsecureSkipVerify: cfg.GetInsecureSkipVerify(),
+	}
+	if cfg.GetCaFile() != "" {
+		pem, err := os.ReadFile(cfg.GetCaFile())
+		if err != nil {
+			return nil, fmt.Errorf("reading CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", cfg.GetCaFile())
+		}
+		tlsConfig.RootCAs = pool
+	}
+	clientCert, err := NewClientCertificate(ctx, cfg, secrets)
+	if err != nil {
+		return nil, err
+	}
+	if clientCert != nil {
+		tlsConfig.GetClientCertificate = func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return clientCert.Certificate(info.Context())
+		}
+	}
+	return tlsConfig, nil
+}
+
+// ClientCertificate is a client certificate which is reloaded once it is rotated.
+// Certificate files are reloaded when their modification time changes and certificates stored in
+// Secret Manager are read again after SecretRefreshInterval. If reloading fails, the previous
+// certificate is kept.
+type ClientCertificate struct {
+	cfg     *configpb.TLSConfiguration
+	secrets SecretGetter
+	now     func() time.Time
+
+	mu                      sync.Mutex
+	certPEM, keyPEM         []byte
+	cert                    *tls.Certificate
+	certModTime, keyModTime time.Time
+	lastRead                time.Time
+}
+
+// NewClientCertificate loads the client certificate configured in cfg, or returns nil if none is configured.
+func NewClientCertificate(ctx context.Context, cfg *configpb.TLSConfiguration, secrets SecretGetter) (*ClientCertificate, error) {
+	fromSecrets := cfg.GetCertSecret() != nil || cfg.GetKeySecret() != nil
+	if !fromSecrets && cfg.GetCertFile() == "" && cfg.GetKeyFile() == "" {
+		return nil, nil
+	}
+	if fromSecrets && secrets == nil {
+		return nil, errors.New("client certificates stored in Secret Manager are not supported for this connection")
+	}
+	c := &ClientCertificate{cfg: cfg, secrets: secrets, now: time.Now}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.reload(ctx); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Certificate returns the current client certificate.
+func (c *ClientCertificate) Certificate(ctx context.Context) (*tls.Certificate, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reloadIfRotated(ctx)
+	return c.cert, nil
+}
+
+// PEM returns the current client certificate and key in PEM format.
+func (c *ClientCertificate) PEM(ctx context.Context) (cert, key []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reloadIfRotated(ctx)
+	return c.certPEM, c.keyPEM
+}
+
+// reloadIfRotated reloads the certificate if it may have been rotated. Errors are logged and the
+// current certificate is kept. c.mu must be held.
+func (c *ClientCertificate) reloadIfRotated(ctx context.Context) {
+	if c.fromSecrets() {
+		if c.now().Sub(c.lastRead) < SecretRefreshInterval {
+			return
+		}
+	} else {
+		certInfo, certErr := os.Stat(c.cfg.GetCertFile())
+		keyInfo, keyErr := os.Stat(c.cfg.GetKeyFile())
+		if certErr == nil && keyErr == nil && certInfo.ModTime().Equal(c.certModTime) && keyInfo.ModTime().Equal(c.keyModTime) {
+			return
+		}
+	}
+	if err := c.reload(ctx); err != nil {
+		log.CtxLogger(ctx).Warnw("Could not reload the TLS client certificate, using the previous one", "error", err)
+	}
+}
+
+// reload reads the certificate and key. c.mu must be held.
+func (c *ClientCertificate) reload(ctx context.Context) error {
+	var certPEM, keyPEM []byte
+	var certModTime, keyModTime time.Time
+	if c.fromSecrets() {
+		// Read attempts are rate limited even if they fail.
+		c.lastRead = c.now()
+		cert, err := c.readSecret(ctx, c.cfg.GetCertSecret())
+		if err != nil {
+			return fmt.Errorf("reading client certificate: %w", err)
+		}
+		key, err := c.readSecret(ctx, c.cfg.GetKeySecret())
+		if err != nil {
+			return fmt.Errorf("reading client key: %w", err)
+		}
+		certPEM, keyPEM = []byte(cert), []byte(key)
+	} else {
+		var err error
+		if certPEM, certModTime, err = readFile(c.cfg.GetCertFile()); err != nil {
+			return fmt.Errorf("loading client certificate: %w", err)
+		}
+		if keyPEM, keyModTime, err = readFile(c.cfg.GetKeyFile()); err != nil {
+			return fmt.Errorf("loading client certificate: %w", err)
+		}
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("loading client certificate: %w", err)
+	}
+	c.cert, c.certPEM, c.keyPEM = &cert, certPEM, keyPEM
+	c.certModTime, c.keyModTime = certModTime, keyModTime
+	return nil
+}
+
+func (c *ClientCertificate) fromSecrets() bool {
+	return c.cfg.GetCertSecret() != nil || c.cfg.GetKeySecret() != nil
+}
+
+func (c *ClientCertificate) readSecret(ctx context.Context, ref *configpb.SecretRef) (string, error) {
+	if ref.GetProjectId() == "" || ref.GetSecretName() == "" {
+		return "", errors.New("the secret project ID and name must both be set")
+	}
+	return c.secrets.GetSecret(ctx, ref.GetProjectId(), ref.GetSecretName())
+}
+
+// readFile returns the content of a file and its modification time.
+func readFile(path string) ([]byte, time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return content, info.ModTime(), nil
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	configpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+// writeCertificate writes a self-signed certificate and its key to dir and returns their paths.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+	certPEM, keyPEM := newCertificate(t, "test")
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", certFile, err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", keyFile, err)
+	}
+	return certFile, keyFile
+}
+
+// newCertificate returns a self-signed certificate for commonName and its key in PEM format.
+func newCertificate(t *testing.T, commonName string) (certPEM, keyPEM []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() returned unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() returned unexpected error: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("x509.MarshalECPrivateKey() returned unexpected error: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+	invalidFile := filepath.Join(dir, "invalid.pem")
+	if err := os.WriteFile(invalidFile, []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", invalidFile, err)
+	}
+
+	tests := []struct {
+		name      string
+		cfg       *configpb.TLSConfiguration
+		wantNil   bool
+		wantRoots bool
+		wantCert  bool
+		wantErr   bool
+	}{
+		{
+			name:    "NotConfigured",
+			wantNil: true,
+		},
+		{
+			name:    "Disabled",
+			cfg:     &configpb.TLSConfiguration{CaFile: certFile},
+			wantNil: true,
+		},
+		{
+			name: "SystemRoots",
+			cfg:  &configpb.TLSConfiguration{Enabled: true},
+		},
+		{
+			name:      "CustomCAAndClientCertificate",
+			cfg:       &configpb.TLSConfiguration{Enabled: true, CaFile: certFile, CertFile: certFile, KeyFile: keyFile},
+			wantRoots: true,
+			wantCert:  true,
+		},
+		{
+			name:    "MissingCAFile",
+			cfg:     &configpb.TLSConfiguration{Enabled: true, CaFile: filepath.Join(dir, "missing.pem")},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidCAFile",
+			cfg:     &configpb.TLSConfiguration{Enabled: true, CaFile: invalidFile},
+			wantErr: true,
+		},
+		{
+			name:    "ClientCertificateInSecretManager",
+			cfg:     &configpb.TLSConfiguration{Enabled: true, CertSecret: &configpb.SecretRef{ProjectId: "p", SecretName: "cert"}},
+			wantErr: true,
+		},
+		{
+			name:    "MissingClientKey",
+			cfg:     &configpb.TLSConfiguration{Enabled: true, CertFile: certFile},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := New(tc.cfg, "db.example.com")
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("New() returned error %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if tc.wantNil {
+				if got != nil {
+					t.Errorf("New() = %v, want nil", got)
+				}
+				return
+			}
+			if got.ServerName != "db.example.com" {
+				t.Errorf("New().ServerName = %q, want %q", got.ServerName, "db.example.com")
+			}
+			if gotRoots := got.RootCAs != nil; gotRoots != tc.wantRoots {
+				t.Errorf("New().RootCAs set = %v, want %v", gotRoots, tc.wantRoots)
+			}
+			if gotCert := got.GetClientCertificate != nil; gotCert != tc.wantCert {
+				t.Errorf("New() client certificate set = %v, want %v", gotCert, tc.wantCert)
+			}
+		})
+	}
+}
+
+// commonName returns the subject common name of the certificate.
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() returned unexpected error: %v", err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestClientCertificateReloadsFiles(t *testing.T) {
+	ctx := context.Background()
+	certFile, keyFile := writeCertificate(t, t.TempDir())
+	c, err := NewClientCertificate(ctx, &configpb.TLSConfiguration{CertFile: certFile, KeyFile: keyFile}, nil)
+	if err != nil {
+		t.Fatalf("NewClientCertificate() returned unexpected error: %v", err)
+	}
+
+	certPEM, keyPEM := newCertificate(t, "rotated")
+	for path, content := range map[string][]byte{certFile: certPEM, keyFile: keyPEM} {
+		if err := os.WriteFile(path, content, 0600); err != nil {
+			t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+		}
+		// The modification time may not change within the resolution of the file system.
+		if err := os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("os.Chtimes(%s) returned unexpected error: %v", path, err)
+		}
+	}
+	got, err := c.Certificate(ctx)
+	if err != nil {
+		t.Fatalf("Certificate() returned unexpected error: %v", err)
+	}
+	if name := commonName(t, got); name != "rotated" {
+		t.Errorf("Certificate() after rotation has common name %q, want %q", name, "rotated")
+	}
+
+	// A partially written rotation keeps the previous certificate.
+	if err := os.WriteFile(keyFile, []byte("not a key"), 0600); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", keyFile, err)
+	}
+	if err := os.Chtimes(keyFile, time.Now(), time.Now().Add(2*time.Minute)); err != nil {
+		t.Fatalf("os.Chtimes(%s) returned unexpected error: %v", keyFile, err)
+	}
+	got, err = c.Certificate(ctx)
+	if err != nil {
+		t.Fatalf("Certificate() returned unexpected error: %v", err)
+	}
+	if name := commonName(t, got); name != "rotated" {
+		t.Errorf("Certificate() after a failed reload has common name %q, want %q", name, "rotated")
+	}
+}
+
+type fakeSecrets struct {
+	values map[string]string
+	reads  int
+}
+
+func (f *fakeSecrets) GetSecret(ctx context.Context, projectID, secretName string) (string, error) {
+	f.reads++
+	v, ok := f.values[secretName]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return v, nil
+}
+
+func TestClientCertificateRefreshesSecrets(t *testing.T) {
+	ctx := context.Background()
+	certPEM, keyPEM := newCertificate(t, "initial")
+	secrets := &fakeSecrets{values: map[string]string{"cert": string(certPEM), "key": string(keyPEM)}}
+	cfg := &configpb.TLSConfiguration{
+		CertSecret: &configpb.SecretRef{ProjectId: "test-project", SecretName: "cert"},
+		KeySecret:  &configpb.SecretRef{ProjectId: "test-project", SecretName: "key"},
+	}
+	c, err := NewClientCertificate(ctx, cfg, secrets)
+	if err != nil {
+		t.Fatalf("NewClientCertificate() returned unexpected error: %v", err)
+	}
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	certPEM, keyPEM = newCertificate(t, "rotated")
+	secrets.values = map[string]string{"cert": string(certPEM), "key": string(keyPEM)}
+	got, _ := c.Certificate(ctx)
+	if name := commonName(t, got); name != "initial" {
+		t.Errorf("Certificate() before the refresh interval has common name %q, want %q", name, "initial")
+	}
+
+	now = now.Add(SecretRefreshInterval)
+	got, _ = c.Certificate(ctx)
+	if name := commonName(t, got); name != "rotated" {
+		t.Errorf("Certificate() after the refresh interval has common name %q, want %q", name, "rotated")
+	}
+	gotCert, gotKey := c.PEM(ctx)
+	if string(gotCert) != string(certPEM) || string(gotKey) != string(keyPEM) {
+		t.Error("PEM() did not return the rotated certificate and key")
+	}
+	if secrets.reads != 4 {
+		t.Errorf("GetSecret() was called %d times, want 4", secrets.reads)
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package units parses and formats the memory sizes, durations and percentages found in workload
+// settings, so that all collectors report them in the same units.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Memory units. Databases use binary multiples for their memory settings, so a kilobyte is 1024 bytes.
+const (
+	Byte     int64 = 1
+	Kilobyte       = 1024 * Byte
+	Megabyte       = 1024 * Kilobyte
+	Gigabyte       = 1024 * Megabyte
+	Terabyte       = 1024 * Gigabyte
+)
+
+// Day is the duration of a day, which is the largest unit of duration settings.
+const Day = 24 * time.Hour
+
+// memoryUnits maps the lower case suffixes of memory sizes to their multiplier.
+// MySQL uses K, M, G and T, Postgres uses kB, MB, GB and TB and the kernel reports kB.
+var memoryUnits = map[string]int64{
+	"b":   Byte,
+	"k":   Kilobyte,
+	"kb":  Kilobyte,
+	"kib": Kilobyte,
+	"m":   Megabyte,
+	"mb":  Megabyte,
+	"mib": Megabyte,
+	"g":   Gigabyte,
+	"gb":  Gigabyte,
+	"gib": Gigabyte,
+	"t":   Terabyte,
+	"tb":  Terabyte,
+	"tib": Terabyte,
+}
+
+// memoryFormats are the units used to format memory sizes, from the largest.
+var memoryFormats = []struct {
+	suffix string
+	size   int64
+}{
+	{"TB", Terabyte},
+	{"GB", Gigabyte},
+	{"MB", Megabyte},
+	{"kB", Kilobyte},
+}
+
+// durationUnits maps the suffixes of durations to their unit. Postgres uses us, ms, s, min, h and d.
+var durationUnits = map[string]time.Duration{
+	"us":  time.Microsecond,
+	"ms":  time.Millisecond,
+	"s":   time.Second,
+	"m":   time.Minute,
+	"min": time.Minute,
+	"h":   time.Hour,
+	"d":   Day,
+}
+
+// splitNumber splits value into its leading number and the unit following it.
+func splitNumber(value string) (string, string) {
+	value = strings.TrimSpace(value)
+	i := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != '-' && r != '+'
+	})
+	if i < 0 {
+		return value, ""
+	}
+	return value[:i], strings.TrimSpace(value[i:])
+}
+
+// scale returns number multiplied by unit, failing if the result doesn't fit in an int64.
+// Fractional numbers are rounded to the nearest integer after scaling.
+func scale(number string, unit int64) (int64, error) {
+	if n, err := strconv.ParseInt(number, 10, 64); err == nil {
+		if n > math.MaxInt64/unit || n < math.MinInt64/unit {
+			return 0, fmt.Errorf("%s overflows", number)
+		}
+		return n * unit, nil
+	}
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", number)
+	}
+	scaled := math.Round(f * float64(unit))
+	if math.IsNaN(scaled) || scaled >= math.MaxInt64 || scaled < math.MinInt64 {
+		return 0, fmt.Errorf("%s overflows", number)
+	}
+	return int64(scaled), nil
+}
+
+// ParseMemory returns the number of bytes of a memory size such as "64kB", "80MB", "4G" or "1 GiB".
+// Units are case insensitive, a value without a unit is in defaultUnit.
+func ParseMemory(value string, defaultUnit int64) (int64, error) {
+	number, suffix := splitNumber(value)
+	unit := defaultUnit
+	if suffix != "" {
+		var ok bool
+		if unit, ok = memoryUnits[strings.ToLower(suffix)]; !ok {
+			return 0, fmt.Errorf("parsing memory size %q: unknown unit %q", value, suffix)
+		}
+	}
+	bytes, err := scale(number, unit)
+	if err != nil {
+		return 0, fmt.Errorf("parsing memory size %q: %w", value, err)
+	}
+	return bytes, nil
+}
+
+// FormatMemory returns bytes in the largest unit which represents it exactly, e.g. "4MB" or "1536kB".
+func FormatMemory(bytes int64) string {
+	if bytes != 0 {
+		for _, f := range memoryFormats {
+			if bytes%f.size == 0 {
+				return strconv.FormatInt(bytes/f.size, 10) + f.suffix
+			}
+		}
+	}
+	return strconv.FormatInt(bytes, 10) + "B"
+}
+
+// ParseDuration returns the duration of a setting such as "200ms", "5min" or "7d".
+// A value without a unit is in defaultUnit, Go durations such as "1h30m" are also accepted.
+func ParseDuration(value string, defaultUnit time.Duration) (time.Duration, error) {
+	number, suffix := splitNumber(value)
+	unit := defaultUnit
+	if suffix != "" {
+		var ok bool
+		if unit, ok = durationUnits[suffix]; !ok {
+			d, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil {
+				return 0, fmt.Errorf("parsing duration %q: unknown unit %q", value, suffix)
+			}
+			return d, nil
+		}
+	}
+	d, err := scale(number, int64(unit))
+	if err != nil {
+		return 0, fmt.Errorf("parsing duration %q: %w", value, err)
+	}
+	return time.Duration(d), nil
+}
+
+// FormatSeconds returns d in whole seconds, which is the unit of the duration metrics.
+func FormatSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
+
+// ParsePercent returns the ratio of a percentage such as "85%" or "85", i.e. 0.85.
+func ParsePercent(value string) (float64, error) {
+	number := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("parsing percentage %q: not a number", value)
+	}
+	return f / 100, nil
+}
+
+// FormatPercent returns ratio as a percentage, e.g. "85%" for 0.85.
+// The percentage is rounded to 9 decimals to hide the error of the multiplication.
+func FormatPercent(ratio float64) string {
+	return strconv.FormatFloat(math.Round(ratio*100*1e9)/1e9, 'f', -1, 64) + "%"
+}
+
+// FormatRatio returns ratio with as many digits as needed to represent it exactly.
+func FormatRatio(ratio float64) string {
+	return strconv.FormatFloat(ratio, 'f', -1, 64)
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package units
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		defaultUnit int64
+		want        int64
+		wantErr     bool
+	}{
+		{name: "NoUnitBytes", value: "134217728", defaultUnit: Byte, want: 134217728},
+		{name: "NoUnitKilobytes", value: "4096", defaultUnit: Kilobyte, want: 4 * Megabyte},
+		{name: "Bytes", value: "512B", defaultUnit: Kilobyte, want: 512},
+		{name: "PostgresKilobytes", value: "64kB", defaultUnit: Kilobyte, want: 64 * Kilobyte},
+		{name: "PostgresMegabytes", value: "80MB", defaultUnit: Kilobyte, want: 80 * Megabyte},
+		{name: "PostgresGigabytes", value: "4GB", defaultUnit: Kilobyte, want: 4 * Gigabyte},
+		{name: "PostgresTerabytes", value: "1TB", defaultUnit: Kilobyte, want: Terabyte},
+		{name: "MySQLKilobytes", value: "16K", defaultUnit: Byte, want: 16 * Kilobyte},
+		{name: "MySQLMegabytes", value: "128M", defaultUnit: Byte, want: 128 * Megabyte},
+		{name: "MySQLGigabytes", value: "2g", defaultUnit: Byte, want: 2 * Gigabyte},
+		{name: "MySQLTerabytes", value: "1T", defaultUnit: Byte, want: Terabyte},
+		{name: "Kibibytes", value: "8KiB", defaultUnit: Byte, want: 8 * Kilobyte},
+		{name: "Mebibytes", value: "8MiB", defaultUnit: Byte, want: 8 * Megabyte},
+		{name: "Gibibytes", value: "8GiB", defaultUnit: Byte, want: 8 * Gigabyte},
+		{name: "Tebibytes", value: "8TiB", defaultUnit: Byte, want: 8 * Terabyte},
+		{name: "Meminfo", value: "8190800 kB", defaultUnit: Byte, want: 8190800 * Kilobyte},
+		{name: "Whitespace", value: "  1 GB ", defaultUnit: Byte, want: Gigabyte},
+		{name: "Fraction", value: "1.5GB", defaultUnit: Byte, want: 3 * Gigabyte / 2},
+		{name: "Zero", value: "0", defaultUnit: Kilobyte, want: 0},
+		{name: "Negative", value: "-1", defaultUnit: Kilobyte, want: -Kilobyte},
+		{name: "Empty", value: "", defaultUnit: Byte, wantErr: true},
+		{name: "UnitOnly", value: "MB", defaultUnit: Byte, wantErr: true},
+		{name: "UnknownUnit", value: "4PB", defaultUnit: Byte, wantErr: true},
+		{name: "NotANumber", value: "lots", defaultUnit: Byte, wantErr: true},
+		{name: "Overflow", value: "9223372036854775807K", defaultUnit: Byte, wantErr: true},
+		{name: "FractionOverflow", value: "1e30TB", defaultUnit: Byte, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseMemory(tc.value, tc.defaultUnit)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParseMemory(%q, %d) returned error: %v, want error: %v", tc.value, tc.defaultUnit, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseMemory(%q, %d) = %d, want %d", tc.value, tc.defaultUnit, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatMemory(t *testing.T) {
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{bytes: 0, want: "0B"},
+		{bytes: 1000, want: "1000B"},
+		{bytes: Kilobyte, want: "1kB"},
+		{bytes: 1536 * Kilobyte, want: "1536kB"},
+		{bytes: 4 * Megabyte, want: "4MB"},
+		{bytes: 3 * Gigabyte, want: "3GB"},
+		{bytes: 2 * Terabyte, want: "2TB"},
+		{bytes: 2048 * Terabyte, want: "2048TB"},
+		{bytes: -Megabyte, want: "-1MB"},
+	}
+
+	for _, tc := range tests {
+		if got := FormatMemory(tc.bytes); got != tc.want {
+			t.Errorf("FormatMemory(%d) = %q, want %q", tc.bytes, got, tc.want)
+		}
+		if tc.bytes == 0 {
+			continue
+		}
+		if got, err := ParseMemory(FormatMemory(tc.bytes), Byte); err != nil || got != tc.bytes {
+			t.Errorf("ParseMemory(FormatMemory(%d)) = %d, %v, want %d, nil", tc.bytes, got, err, tc.bytes)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		defaultUnit time.Duration
+		want        time.Duration
+		wantErr     bool
+	}{
+		{name: "NoUnitSeconds", value: "3600", defaultUnit: time.Second, want: time.Hour},
+		{name: "NoUnitDays", value: "7", defaultUnit: Day, want: 7 * Day},
+		{name: "Microseconds", value: "250us", defaultUnit: time.Second, want: 250 * time.Microsecond},
+		{name: "Milliseconds", value: "200ms", defaultUnit: time.Second, want: 200 * time.Millisecond},
+		{name: "Seconds", value: "30s", defaultUnit: time.Millisecond, want: 30 * time.Second},
+		{name: "PostgresMinutes", value: "5min", defaultUnit: time.Second, want: 5 * time.Minute},
+		{name: "Minutes", value: "5m", defaultUnit: time.Second, want: 5 * time.Minute},
+		{name: "Hours", value: "2h", defaultUnit: time.Second, want: 2 * time.Hour},
+		{name: "Days", value: "1d", defaultUnit: time.Second, want: Day},
+		{name: "Whitespace", value: " 10 s ", defaultUnit: time.Millisecond, want: 10 * time.Second},
+		{name: "Fraction", value: "1.5s", defaultUnit: time.Millisecond, want: 1500 * time.Millisecond},
+		{name: "GoDuration", value: "1h30m", defaultUnit: time.Second, want: 90 * time.Minute},
+		{name: "Disabled", value: "-1", defaultUnit: time.Millisecond, want: -time.Millisecond},
+		{name: "Empty", value: "", defaultUnit: time.Second, wantErr: true},
+		{name: "UnknownUnit", value: "2w", defaultUnit: time.Second, wantErr: true},
+		{name: "NotANumber", value: "forever", defaultUnit: time.Second, wantErr: true},
+		{name: "Overflow", value: "9223372036854775807d", defaultUnit: time.Second, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseDuration(tc.value, tc.defaultUnit)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParseDuration(%q, %v) returned error: %v, want error: %v", tc.value, tc.defaultUnit, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseDuration(%q, %v) = %v, want %v", tc.value, tc.defaultUnit, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatSeconds(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{d: 0, want: "0"},
+		{d: 1500 * time.Millisecond, want: "1"},
+		{d: 7 * Day, want: "604800"},
+	}
+
+	for _, tc := range tests {
+		if got := FormatSeconds(tc.d); got != tc.want {
+			t.Errorf("FormatSeconds(%v) = %q, want %q", tc.d, got, tc.want)
+		}
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "Sign", value: "85%", want: 0.85},
+		{name: "NoSign", value: "85", want: 0.85},
+		{name: "Whitespace", value: " 12.5 % ", want: 0.125},
+		{name: "Zero", value: "0%", want: 0},
+		{name: "Above100", value: "150%", want: 1.5},
+		{name: "Empty", value: "", wantErr: true},
+		{name: "SignOnly", value: "%", wantErr: true},
+		{name: "NotANumber", value: "half", wantErr: true},
+		{name: "NaN", value: "NaN%", wantErr: true},
+		{name: "Inf", value: "Inf", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParsePercent(tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("ParsePercent(%q) returned error: %v, want error: %v", tc.value, err, tc.wantErr)
+			}
+			if math.Abs(got-tc.want) > 1e-12 {
+				t.Errorf("ParsePercent(%q) = %v, want %v", tc.value, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFormatPercent(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{ratio: 0, want: "0%"},
+		{ratio: 0.85, want: "85%"},
+		{ratio: 0.57, want: "57%"},
+		{ratio: 0.125, want: "12.5%"},
+		{ratio: 1.5, want: "150%"},
+	}
+
+	for _, tc := range tests {
+		if got := FormatPercent(tc.ratio); got != tc.want {
+			t.Errorf("FormatPercent(%v) = %q, want %q", tc.ratio, got, tc.want)
+		}
+	}
+}
+
+func TestFormatRatio(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{ratio: 0, want: "0"},
+		{ratio: 0.25, want: "0.25"},
+		{ratio: 1, want: "1"},
+	}
+
+	for _, tc := range tests {
+		if got := FormatRatio(tc.ratio); got != tc.want {
+			t.Errorf("FormatRatio(%v) = %q, want %q", tc.ratio, got, tc.want)
+		}
+	}
+}
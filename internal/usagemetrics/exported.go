@@ -17,9 +17,11 @@ limitations under the License.
 package usagemetrics
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/jonboulle/clockwork"
+	"google.golang.org/protobuf/proto"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/usagemetrics"
 
@@ -51,6 +53,25 @@ func SetCloudProperties(cp *cpb.CloudProperties) {
 	})
 }
 
+// Enabled reports whether usage metrics are logged for the configured agent properties.
+// Usage metrics are logged unless log_usage_metrics is explicitly set to false.
+func Enabled(ap *cpb.AgentProperties) bool {
+	return ap == nil || ap.LogUsageMetrics == nil || ap.GetLogUsageMetrics()
+}
+
+// AnonymizeCloudProperties returns a copy of cp without the fields identifying the instance.
+// The project and zone are kept as usage metrics are reported against them.
+func AnonymizeCloudProperties(cp *cpb.CloudProperties) *cpb.CloudProperties {
+	if cp == nil {
+		return nil
+	}
+	anonymized := proto.Clone(cp).(*cpb.CloudProperties)
+	anonymized.InstanceId = ""
+	anonymized.InstanceName = ""
+	anonymized.Image = ""
+	return anonymized
+}
+
 // ParseStatus parses the status string to a Status enum.
 func ParseStatus(status string) usagemetrics.Status {
 	return usagemetrics.Status(status)
@@ -71,6 +92,11 @@ func Stopped() {
 	UsageLogger.Stopped()
 }
 
+// StoppedAfter uses the standard Logger to log the STOPPED status with the shutdown duration in whole seconds.
+func StoppedAfter(shutdown time.Duration) {
+	UsageLogger.LogStatus(StatusStopped, strconv.FormatInt(int64(shutdown.Round(time.Second)/time.Second), 10))
+}
+
 // Configured uses the standard Logger to log the CONFIGURED status.
 func Configured() {
 	UsageLogger.Configured()
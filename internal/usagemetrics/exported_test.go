@@ -0,0 +1,89 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package usagemetrics
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		ap   *cpb.AgentProperties
+		want bool
+	}{
+		{
+			name: "NilAgentProperties",
+			want: true,
+		},
+		{
+			name: "Unset",
+			ap:   &cpb.AgentProperties{},
+			want: true,
+		},
+		{
+			name: "ExplicitlyEnabled",
+			ap:   &cpb.AgentProperties{LogUsageMetrics: proto.Bool(true)},
+			want: true,
+		},
+		{
+			name: "ExplicitlyDisabled",
+			ap:   &cpb.AgentProperties{LogUsageMetrics: proto.Bool(false)},
+			want: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Enabled(tc.ap); got != tc.want {
+				t.Errorf("Enabled(%v) = %t, want %t", tc.ap, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAnonymizeCloudProperties(t *testing.T) {
+	cp := &cpb.CloudProperties{
+		ProjectId:        "test-project",
+		NumericProjectId: "12345",
+		InstanceId:       "67890",
+		InstanceName:     "test-instance",
+		Zone:             "us-central1-a",
+		Image:            "test-image",
+	}
+	want := &cpb.CloudProperties{
+		ProjectId:        "test-project",
+		NumericProjectId: "12345",
+		Zone:             "us-central1-a",
+	}
+
+	got := AnonymizeCloudProperties(cp)
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("AnonymizeCloudProperties() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if cp.GetInstanceName() != "test-instance" {
+		t.Errorf("AnonymizeCloudProperties() modified the input cloud properties, instance name = %q", cp.GetInstanceName())
+	}
+	if got := AnonymizeCloudProperties(nil); got != nil {
+		t.Errorf("AnonymizeCloudProperties(nil) = %v, want nil", got)
+	}
+}
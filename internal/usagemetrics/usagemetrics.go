@@ -71,6 +71,14 @@ const (
 	MongoDBMetricCollectionFailure        = 33
 	MongoDBDiscoveryFailure               = 34
 	StartDaemonFailure                    = 35
+	AuthenticationFailure                 = 36
+	QueryFailure                          = 37
+	APIQuotaExceeded                      = 38
+	ParseFailure                          = 39
+	PreflightCheckFailure                 = 40
+	ShutdownTimeout                       = 41
+	ServiceQuarantined                    = 42
+	MissingPrivileges                     = 43
 )
 
 // Agent wide action mappings.
@@ -0,0 +1,83 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/audit"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// defaultDataWarehouseEndpoint is recorded in the audit log when no endpoint is configured.
+const defaultDataWarehouseEndpoint = "https://workloadmanager-datawarehouse.googleapis.com/"
+
+// AuditWriter is a WLMWriter which records every insight written to the audit log.
+type AuditWriter struct {
+	writer   WLMWriter
+	log      *audit.Log
+	endpoint string
+}
+
+// NewAuditWriter returns an AuditWriter which records the insights written to writer in l.
+func NewAuditWriter(writer WLMWriter, l *audit.Log, config *cpb.Configuration) *AuditWriter {
+	endpoint := config.GetDataWarehouseEndpoint()
+	switch {
+	case config.GetDataWarehouseExport().GetEnabled():
+		endpoint = "file://" + config.GetDataWarehouseExport().GetFilePath()
+	case endpoint == "":
+		endpoint = defaultDataWarehouseEndpoint
+	}
+	return &AuditWriter{writer: writer, log: l, endpoint: endpoint}
+}
+
+// WriteInsightAndGetResponse writes the insight and records the call.
+func (a *AuditWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	start := time.Now()
+	res, err := a.writer.WriteInsightAndGetResponse(project, location, req)
+	a.log.Record(context.Background(), audit.Record{
+		Time:         start,
+		API:          "workloadmanager-datawarehouse",
+		Method:       "WriteInsight",
+		Endpoint:     a.endpoint,
+		PayloadBytes: proto.Size(req),
+		Status:       writeInsightStatus(res, err),
+		LatencyMs:    time.Since(start).Milliseconds(),
+	})
+	return res, err
+}
+
+// writeInsightStatus returns the HTTP status code of the call if it is known.
+func writeInsightStatus(res *wlm.WriteInsightResponse, err error) string {
+	var gErr *googleapi.Error
+	switch {
+	case errors.As(err, &gErr):
+		return strconv.Itoa(gErr.Code)
+	case err != nil:
+		return audit.Status(err)
+	case res != nil && res.HTTPStatusCode != 0:
+		return strconv.Itoa(res.HTTPStatusCode)
+	}
+	return audit.StatusOK
+}
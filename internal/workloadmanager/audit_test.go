@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/audit"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestAuditWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	config := &cpb.Configuration{AuditLog: &cpb.AuditLog{Enabled: proto.Bool(true), FilePath: path}}
+	fw := &fakeWriter{}
+	a := NewAuditWriter(fw, audit.FromConfig(config), config)
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})
+
+	if _, err := a.WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	if len(fw.calls) != 1 {
+		t.Fatalf("AuditWriter wrote %d insights, want 1", len(fw.calls))
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+	}
+	var got audit.Record
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(content))), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) returned unexpected error: %v", content, err)
+	}
+	if got.Endpoint != defaultDataWarehouseEndpoint || got.PayloadBytes != proto.Size(req) || got.Status != audit.StatusOK {
+		t.Errorf("AuditWriter recorded %+v, want endpoint %s, payload %d bytes and status %s", got, defaultDataWarehouseEndpoint, proto.Size(req), audit.StatusOK)
+	}
+}
+
+func TestWriteInsightStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		res  *wlm.WriteInsightResponse
+		err  error
+		want string
+	}{
+		{
+			name: "HTTPStatus",
+			res:  &wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+			want: "201",
+		},
+		{
+			name: "NoResponse",
+			want: audit.StatusOK,
+		},
+		{
+			name: "GoogleAPIError",
+			err:  &googleapi.Error{Code: 403},
+			want: "403",
+		},
+		{
+			name: "OtherError",
+			err:  errors.New("failed"),
+			want: "ERROR",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := writeInsightStatus(tc.res, tc.err); got != tc.want {
+				t.Errorf("writeInsightStatus() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
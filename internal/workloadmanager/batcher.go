@@ -0,0 +1,185 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// DefaultBatchFlushInterval is the flush interval used when batching is enabled without an interval.
+const DefaultBatchFlushInterval = 5 * time.Second
+
+// batchKey identifies the insights of one workload which can be aggregated into a single
+// WriteInsight call.
+type batchKey struct {
+	project      string
+	location     string
+	instanceID   string
+	workloadType dwpb.TorsoValidation_WorkloadType
+	// databaseInstance keeps the insights of multiple instances of a workload on the host apart.
+	databaseInstance string
+}
+
+// newBatchKey returns the key of req written to project and location.
+func newBatchKey(project, location string, req *dwpb.WriteInsightRequest) batchKey {
+	key := batchKey{
+		project:          project,
+		location:         location,
+		instanceID:       req.GetInsight().GetInstanceId(),
+		workloadType:     req.GetInsight().GetTorsoValidation().GetWorkloadType(),
+		databaseInstance: req.GetInsight().GetTorsoValidation().GetValidationDetails()[DatabaseInstanceKey],
+	}
+	if req.GetInsight().GetSqlserverValidation() != "" {
+		key.databaseInstance = sqlServerInstance(req)
+	}
+	return key
+}
+
+// pendingBatch holds an aggregated insight waiting to be flushed.
+type pendingBatch struct {
+	req  *dwpb.WriteInsightRequest
+	done chan struct{}
+	res  *wlm.WriteInsightResponse
+	err  error
+}
+
+// BatchWriter is a WLMWriter which aggregates the insights of a workload written within a flush
+// interval.
+//
+// Insights for the same workload and database instance on the instance, written to the same
+// project and location, are merged into a single WriteInsight call; validation details written
+// later take precedence. Insights are not batched across workloads: a WriteInsight request holds
+// a single torso validation, so each workload still costs one call per flush. Collectors write one
+// insight per workload each cycle, so batching mostly merges retried or repeated writes and
+// otherwise only delays them. It is disabled unless configured.
+// Callers block until the aggregated insight is flushed and all receive its response.
+type BatchWriter struct {
+	writer   WLMWriter
+	interval time.Duration
+
+	mu      sync.Mutex
+	pending map[batchKey]*pendingBatch
+	// closed makes insights bypass batching once the agent shuts down.
+	closed bool
+}
+
+// NewBatchWriter returns a BatchWriter which flushes to writer every interval.
+func NewBatchWriter(writer WLMWriter, interval time.Duration) *BatchWriter {
+	if interval <= 0 {
+		interval = DefaultBatchFlushInterval
+	}
+	return &BatchWriter{
+		writer:   writer,
+		interval: interval,
+		pending:  make(map[batchKey]*pendingBatch),
+	}
+}
+
+// WriteInsightAndGetResponse queues the insight and returns once its batch has been written.
+func (b *BatchWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	key := newBatchKey(project, location, req)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return b.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	batch, ok := b.pending[key]
+	if ok {
+		mergeInsight(batch.req, req)
+	} else {
+		batch = &pendingBatch{
+			req:  proto.Clone(req).(*dwpb.WriteInsightRequest),
+			done: make(chan struct{}),
+		}
+		b.pending[key] = batch
+		time.AfterFunc(b.interval, func() { b.flush(key) })
+	}
+	b.mu.Unlock()
+
+	<-batch.done
+	return batch.res, batch.err
+}
+
+// flush writes the pending batch for key and releases the callers waiting on it.
+func (b *BatchWriter) flush(key batchKey) {
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	delete(b.pending, key)
+	b.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	batch.res, batch.err = b.writer.WriteInsightAndGetResponse(key.project, key.location, batch.req)
+	close(batch.done)
+}
+
+// mergeInsight merges the torso validation of src into dst.
+// Validation details from src overwrite those with the same key in dst.
+// A SQL Server validation from src replaces the one in dst.
+func mergeInsight(dst, src *dwpb.WriteInsightRequest) {
+	if v := src.GetInsight().GetSqlserverValidation(); v != "" && dst.GetInsight() != nil {
+		dst.GetInsight().SqlserverValidation = v
+		return
+	}
+	dstTV := dst.GetInsight().GetTorsoValidation()
+	srcTV := src.GetInsight().GetTorsoValidation()
+	if dstTV == nil || srcTV == nil {
+		return
+	}
+	if dstTV.ValidationDetails == nil {
+		dstTV.ValidationDetails = make(map[string]string)
+	}
+	for k, v := range srcTV.GetValidationDetails() {
+		dstTV.ValidationDetails[k] = v
+	}
+}
+
+// Close writes all pending batches and writes later insights immediately.
+// It is used on shutdown, so insights written while the agent drains its collections are not delayed.
+func (b *BatchWriter) Close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	b.Flush()
+}
+
+// Flush writes all pending batches without waiting for their flush interval.
+func (b *BatchWriter) Flush() {
+	b.mu.Lock()
+	keys := make([]batchKey, 0, len(b.pending))
+	for key := range b.pending {
+		keys = append(keys, key)
+	}
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		wg.Add(1)
+		go func(key batchKey) {
+			defer wg.Done()
+			b.flush(key)
+		}(key)
+	}
+	wg.Wait()
+}
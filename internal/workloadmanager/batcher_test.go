@@ -0,0 +1,259 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+type writeCall struct {
+	project  string
+	location string
+	req      *dwpb.WriteInsightRequest
+}
+
+// fakeWriter records the insights it receives and is safe for concurrent use.
+type fakeWriter struct {
+	mu    sync.Mutex
+	calls []writeCall
+	err   error
+}
+
+func (f *fakeWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, writeCall{project: project, location: location, req: req})
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &wlm.WriteInsightResponse{}, nil
+}
+
+func insightRequest(instanceID string, wt dwpb.TorsoValidation_WorkloadType, details map[string]string) *dwpb.WriteInsightRequest {
+	return &dwpb.WriteInsightRequest{
+		Insight: &dwpb.Insight{
+			InstanceId: instanceID,
+			TorsoValidation: &dwpb.TorsoValidation{
+				WorkloadType:      wt,
+				ValidationDetails: details,
+			},
+		},
+	}
+}
+
+func TestBatchWriter(t *testing.T) {
+	type write struct {
+		project  string
+		location string
+		req      *dwpb.WriteInsightRequest
+	}
+	tests := []struct {
+		name      string
+		writes    []write
+		writerErr error
+		want      []writeCall
+		wantErr   error
+	}{
+		{
+			name: "SingleInsight",
+			writes: []write{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+		},
+		{
+			name: "SameWorkloadIsMerged",
+			writes: []write{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"b": "2"})},
+			},
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", "b": "2"})},
+			},
+		},
+		{
+			name: "DifferentWorkloadsAreNotMerged",
+			writes: []write{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"b": "1"})},
+			},
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"b": "1"})},
+			},
+		},
+		{
+			name: "DifferentLocationsAreNotMerged",
+			writes: []write{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{"p1", "us-east1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{"p1", "us-east1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+		},
+		{
+			name: "DifferentDatabaseInstancesAreNotMerged",
+			writes: []write{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{DatabaseInstanceKey: "localhost:3306", "a": "1"})},
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{DatabaseInstanceKey: "localhost:3307", "a": "2"})},
+			},
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{DatabaseInstanceKey: "localhost:3306", "a": "1"})},
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{DatabaseInstanceKey: "localhost:3307", "a": "2"})},
+			},
+		},
+		{
+			name: "ErrorIsReturnedToAllCallers",
+			writes: []write{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"b": "1"})},
+			},
+			writerErr: errors.New("write failed"),
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", "b": "1"})},
+			},
+			wantErr: cmpopts.AnyError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := &fakeWriter{err: tc.writerErr}
+			b := NewBatchWriter(fw, 200*time.Millisecond)
+
+			var wg sync.WaitGroup
+			errs := make([]error, len(tc.writes))
+			for i, w := range tc.writes {
+				wg.Add(1)
+				go func(i int, w write) {
+					defer wg.Done()
+					_, errs[i] = b.WriteInsightAndGetResponse(w.project, w.location, w.req)
+				}(i, w)
+			}
+			wg.Wait()
+
+			for i, err := range errs {
+				if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+					t.Errorf("WriteInsightAndGetResponse() #%d returned error %v, want %v", i, err, tc.wantErr)
+				}
+			}
+			sort.Slice(fw.calls, func(i, j int) bool {
+				if fw.calls[i].location != fw.calls[j].location {
+					return fw.calls[i].location < fw.calls[j].location
+				}
+				iInstance := fw.calls[i].req.GetInsight().GetTorsoValidation().GetValidationDetails()[DatabaseInstanceKey]
+				jInstance := fw.calls[j].req.GetInsight().GetTorsoValidation().GetValidationDetails()[DatabaseInstanceKey]
+				if iInstance != jInstance {
+					return iInstance < jInstance
+				}
+				return fw.calls[i].req.GetInsight().GetTorsoValidation().GetWorkloadType() < fw.calls[j].req.GetInsight().GetTorsoValidation().GetWorkloadType()
+			})
+			if diff := cmp.Diff(tc.want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+				t.Errorf("BatchWriter wrote unexpected insights (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMergeInsight(t *testing.T) {
+	dst := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", "b": "1"})
+	src := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"b": "2", "c": "2"})
+	want := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", "b": "2", "c": "2"})
+
+	mergeInsight(dst, src)
+	if diff := cmp.Diff(want, dst, protocmp.Transform()); diff != "" {
+		t.Errorf("mergeInsight() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewBatchWriterDefaultInterval(t *testing.T) {
+	b := NewBatchWriter(&fakeWriter{}, 0)
+	if b.interval != DefaultBatchFlushInterval {
+		t.Errorf("NewBatchWriter(0).interval = %v, want %v", b.interval, DefaultBatchFlushInterval)
+	}
+}
+
+func TestBatchWriterFlush(t *testing.T) {
+	fw := &fakeWriter{}
+	b := NewBatchWriter(fw, time.Hour)
+	errCh := make(chan error)
+	go func() {
+		_, err := b.WriteInsightAndGetResponse("p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}))
+		errCh <- err
+	}()
+	for pending := 0; pending == 0; {
+		b.mu.Lock()
+		pending = len(b.pending)
+		b.mu.Unlock()
+	}
+
+	b.Flush()
+	if err := <-errCh; err != nil {
+		t.Errorf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	want := []writeCall{
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+	}
+	if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+		t.Errorf("Flush() wrote unexpected insights (-want +got):\n%s", diff)
+	}
+}
+
+func TestBatchWriterClose(t *testing.T) {
+	fw := &fakeWriter{}
+	b := NewBatchWriter(fw, time.Hour)
+	errCh := make(chan error)
+	go func() {
+		_, err := b.WriteInsightAndGetResponse("p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}))
+		errCh <- err
+	}()
+	for pending := 0; pending == 0; {
+		b.mu.Lock()
+		pending = len(b.pending)
+		b.mu.Unlock()
+	}
+
+	b.Close()
+	if err := <-errCh; err != nil {
+		t.Errorf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	// Insights written after Close are not held for the flush interval.
+	if _, err := b.WriteInsightAndGetResponse("p1", "us-central1", insightRequest("i2", dwpb.TorsoValidation_MYSQL, map[string]string{"b": "2"})); err != nil {
+		t.Errorf("WriteInsightAndGetResponse() after Close() returned unexpected error: %v", err)
+	}
+	want := []writeCall{
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+		{"p1", "us-central1", insightRequest("i2", dwpb.TorsoValidation_MYSQL, map[string]string{"b": "2"})},
+	}
+	if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+		t.Errorf("BatchWriter wrote unexpected insights after Close() (-want +got):\n%s", diff)
+	}
+}
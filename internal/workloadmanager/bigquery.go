@@ -0,0 +1,265 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentials"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+const (
+	// DefaultBigQueryTable is the table to which validation details are streamed when none is configured.
+	DefaultBigQueryTable = "workload_validation_details"
+	// bigQueryTimeout bounds how long a write waits for its validation details to be streamed.
+	bigQueryTimeout = 30 * time.Second
+)
+
+// validationDetailsSchema is the schema of the BigQuery table, one row is written per insight.
+// Columns are only ever added so that existing tables can be extended.
+var validationDetailsSchema = []*bigquery.TableFieldSchema{
+	{Name: "insert_time", Type: "TIMESTAMP"},
+	{Name: "project", Type: "STRING"},
+	{Name: "location", Type: "STRING"},
+	{Name: "instance_id", Type: "STRING"},
+	{Name: "workload_type", Type: "STRING"},
+	{Name: "validation_details", Type: "RECORD", Mode: "REPEATED", Fields: []*bigquery.TableFieldSchema{
+		{Name: "key", Type: "STRING"},
+		{Name: "value", Type: "STRING"},
+	}},
+}
+
+// TableRef identifies a BigQuery table.
+type TableRef struct {
+	Project string
+	Dataset string
+	Table   string
+}
+
+// String returns the table in the project.dataset.table notation.
+func (r TableRef) String() string {
+	return fmt.Sprintf("%s.%s.%s", r.Project, r.Dataset, r.Table)
+}
+
+// BigQueryClient reads, creates and updates BigQuery tables and streams rows into them.
+type BigQueryClient interface {
+	Table(ctx context.Context, ref TableRef) (*bigquery.Table, error)
+	CreateTable(ctx context.Context, ref TableRef, table *bigquery.Table) error
+	PatchTable(ctx context.Context, ref TableRef, table *bigquery.Table) error
+	InsertRows(ctx context.Context, ref TableRef, rows []*bigquery.TableDataInsertAllRequestRows) (*bigquery.TableDataInsertAllResponse, error)
+}
+
+// bigQueryService is a BigQueryClient using the BigQuery API.
+type bigQueryService struct {
+	service *bigquery.Service
+}
+
+func (b *bigQueryService) Table(ctx context.Context, ref TableRef) (*bigquery.Table, error) {
+	return b.service.Tables.Get(ref.Project, ref.Dataset, ref.Table).Context(ctx).Do()
+}
+
+func (b *bigQueryService) CreateTable(ctx context.Context, ref TableRef, table *bigquery.Table) error {
+	_, err := b.service.Tables.Insert(ref.Project, ref.Dataset, table).Context(ctx).Do()
+	return err
+}
+
+func (b *bigQueryService) PatchTable(ctx context.Context, ref TableRef, table *bigquery.Table) error {
+	_, err := b.service.Tables.Patch(ref.Project, ref.Dataset, ref.Table, table).Context(ctx).Do()
+	return err
+}
+
+func (b *bigQueryService) InsertRows(ctx context.Context, ref TableRef, rows []*bigquery.TableDataInsertAllRequestRows) (*bigquery.TableDataInsertAllResponse, error) {
+	return b.service.Tabledata.InsertAll(ref.Project, ref.Dataset, ref.Table, &bigquery.TableDataInsertAllRequest{Rows: rows}).Context(ctx).Do()
+}
+
+// newBigQueryClient creates a BigQueryClient authenticating with the configured credentials.
+func newBigQueryClient(ctx context.Context, config *cpb.Configuration) (BigQueryClient, error) {
+	opts, err := credentials.ClientOptions(ctx, config.GetCredentials())
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, endpoints.BigQueryOptions(config.GetEndpoints())...)
+	service, err := bigquery.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating BigQuery client: %w", err)
+	}
+	return &bigQueryService{service: service}, nil
+}
+
+// BigQueryWriter is a WLMWriter which also streams the validation details of every insight into
+// a BigQuery table, so that customers can query the history of their workload configuration.
+//
+// The table is created, partitioned by day of insert_time, the first time a row is written to it.
+// An existing table is extended with the columns it is missing. Failures are logged and do not
+// affect the write to Data Warehouse; the table is checked again on the next write.
+type BigQueryWriter struct {
+	writer  WLMWriter
+	client  BigQueryClient
+	project string
+	dataset string
+	table   string
+	now     func() time.Time
+
+	mu    sync.Mutex
+	ready map[TableRef]bool
+}
+
+// NewBigQueryWriter returns a BigQueryWriter which writes to writer and streams to the table of config.
+func NewBigQueryWriter(writer WLMWriter, client BigQueryClient, config *cpb.BigQueryExport) *BigQueryWriter {
+	table := config.GetTable()
+	if table == "" {
+		table = DefaultBigQueryTable
+	}
+	return &BigQueryWriter{
+		writer:  writer,
+		client:  client,
+		project: config.GetProjectId(),
+		dataset: config.GetDataset(),
+		table:   table,
+		now:     time.Now,
+		ready:   make(map[TableRef]bool),
+	}
+}
+
+// WriteInsightAndGetResponse streams the validation details of the insight and writes it.
+// The activation check and other insights without validation details are not streamed.
+// The details of SQL Server insights are flattened.
+func (b *BigQueryWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	if !isActivationProbe(req) && len(insightDetails(req)) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), bigQueryTimeout)
+		defer cancel()
+		if err := b.insert(ctx, project, location, req); err != nil {
+			log.Logger.Warnw("Could not stream validation details to BigQuery", "error", err)
+		}
+	}
+	return b.writer.WriteInsightAndGetResponse(project, location, req)
+}
+
+// insert streams a row with the validation details of req into the table.
+func (b *BigQueryWriter) insert(ctx context.Context, project, location string, req *dwpb.WriteInsightRequest) error {
+	ref := TableRef{Project: b.project, Dataset: b.dataset, Table: b.table}
+	if ref.Project == "" {
+		ref.Project = project
+	}
+	if err := b.ensureTable(ctx, ref); err != nil {
+		return err
+	}
+
+	validationDetails := insightDetails(req)
+	workloadType := insightWorkloadType(req)
+	keys := make([]string, 0, len(validationDetails))
+	for k := range validationDetails {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	details := make([]map[string]string, 0, len(keys))
+	for _, k := range keys {
+		details = append(details, map[string]string{"key": k, "value": validationDetails[k]})
+	}
+	now := b.now()
+	row := &bigquery.TableDataInsertAllRequestRows{
+		// Rows retried by the BigQuery client are deduplicated by their insert ID.
+		InsertId: fmt.Sprintf("%s/%s/%d", req.GetInsight().GetInstanceId(), workloadType, now.UnixNano()),
+		Json: map[string]bigquery.JsonValue{
+			"insert_time":        now.UTC().Format(time.RFC3339Nano),
+			"project":            project,
+			"location":           location,
+			"instance_id":        req.GetInsight().GetInstanceId(),
+			"workload_type":      workloadType,
+			"validation_details": details,
+		},
+	}
+	res, err := b.client.InsertRows(ctx, ref, []*bigquery.TableDataInsertAllRequestRows{row})
+	if err != nil {
+		return fmt.Errorf("inserting row into %s: %w", ref, err)
+	}
+	if len(res.InsertErrors) > 0 && len(res.InsertErrors[0].Errors) > 0 {
+		e := res.InsertErrors[0].Errors[0]
+		return fmt.Errorf("inserting row into %s: %s: %s", ref, e.Reason, e.Message)
+	}
+	return nil
+}
+
+// ensureTable creates the table, or adds the columns it is missing, unless this was already done.
+func (b *BigQueryWriter) ensureTable(ctx context.Context, ref TableRef) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ready[ref] {
+		return nil
+	}
+
+	table, err := b.client.Table(ctx, ref)
+	var apiErr *googleapi.Error
+	switch {
+	case errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound:
+		log.Logger.Infow("Creating BigQuery table for validation details", "table", ref.String())
+		err = b.client.CreateTable(ctx, ref, &bigquery.Table{
+			TableReference:   &bigquery.TableReference{ProjectId: ref.Project, DatasetId: ref.Dataset, TableId: ref.Table},
+			Schema:           &bigquery.TableSchema{Fields: validationDetailsSchema},
+			TimePartitioning: &bigquery.TimePartitioning{Type: "DAY", Field: "insert_time"},
+		})
+		if err != nil {
+			return fmt.Errorf("creating table %s: %w", ref, err)
+		}
+	case err != nil:
+		return fmt.Errorf("reading table %s: %w", ref, err)
+	default:
+		if missing := missingFields(table.Schema, validationDetailsSchema); len(missing) > 0 {
+			log.Logger.Infow("Adding columns to BigQuery table for validation details", "table", ref.String(), "columns", len(missing))
+			var fields []*bigquery.TableFieldSchema
+			if table.Schema != nil {
+				fields = table.Schema.Fields
+			}
+			fields = append(fields, missing...)
+			if err := b.client.PatchTable(ctx, ref, &bigquery.Table{Schema: &bigquery.TableSchema{Fields: fields}}); err != nil {
+				return fmt.Errorf("updating schema of table %s: %w", ref, err)
+			}
+		}
+	}
+	b.ready[ref] = true
+	return nil
+}
+
+// missingFields returns the top-level fields of want which are not in schema.
+func missingFields(schema *bigquery.TableSchema, want []*bigquery.TableFieldSchema) []*bigquery.TableFieldSchema {
+	existing := make(map[string]bool)
+	if schema != nil {
+		for _, f := range schema.Fields {
+			existing[f.Name] = true
+		}
+	}
+	var missing []*bigquery.TableFieldSchema
+	for _, f := range want {
+		if !existing[f.Name] {
+			missing = append(missing, f)
+		}
+	}
+	return missing
+}
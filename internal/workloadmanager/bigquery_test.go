@@ -0,0 +1,185 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/googleapi"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+type fakeBigQuery struct {
+	table    *bigquery.Table
+	tableErr error
+	gets     int
+	created  []*bigquery.Table
+	patched  []*bigquery.Table
+	inserted map[TableRef][]*bigquery.TableDataInsertAllRequestRows
+}
+
+func (f *fakeBigQuery) Table(ctx context.Context, ref TableRef) (*bigquery.Table, error) {
+	f.gets++
+	if f.tableErr != nil {
+		return nil, f.tableErr
+	}
+	if f.table == nil {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return f.table, nil
+}
+
+func (f *fakeBigQuery) CreateTable(ctx context.Context, ref TableRef, table *bigquery.Table) error {
+	f.created = append(f.created, table)
+	return nil
+}
+
+func (f *fakeBigQuery) PatchTable(ctx context.Context, ref TableRef, table *bigquery.Table) error {
+	f.patched = append(f.patched, table)
+	return nil
+}
+
+func (f *fakeBigQuery) InsertRows(ctx context.Context, ref TableRef, rows []*bigquery.TableDataInsertAllRequestRows) (*bigquery.TableDataInsertAllResponse, error) {
+	if f.inserted == nil {
+		f.inserted = make(map[TableRef][]*bigquery.TableDataInsertAllRequestRows)
+	}
+	f.inserted[ref] = append(f.inserted[ref], rows...)
+	return &bigquery.TableDataInsertAllResponse{}, nil
+}
+
+func TestBigQueryWriter(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"b": "2", "a": "1"})
+	row := &bigquery.TableDataInsertAllRequestRows{
+		InsertId: "i1/MYSQL/1740830400000000000",
+		Json: map[string]bigquery.JsonValue{
+			"insert_time":   "2025-03-01T12:00:00Z",
+			"project":       "p1",
+			"location":      "us-central1",
+			"instance_id":   "i1",
+			"workload_type": "MYSQL",
+			"validation_details": []map[string]string{
+				{"key": "a", "value": "1"},
+				{"key": "b", "value": "2"},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		config       *cpb.BigQueryExport
+		fake         *fakeBigQuery
+		wantCreated  int
+		wantPatched  []*bigquery.Table
+		wantInserted map[TableRef][]*bigquery.TableDataInsertAllRequestRows
+	}{
+		{
+			name:        "TableIsCreated",
+			config:      &cpb.BigQueryExport{Dataset: "wlm"},
+			fake:        &fakeBigQuery{},
+			wantCreated: 1,
+			wantInserted: map[TableRef][]*bigquery.TableDataInsertAllRequestRows{
+				{Project: "p1", Dataset: "wlm", Table: DefaultBigQueryTable}: {row, row},
+			},
+		},
+		{
+			name:   "MissingColumnsAreAdded",
+			config: &cpb.BigQueryExport{ProjectId: "central", Dataset: "wlm", Table: "history"},
+			fake: &fakeBigQuery{table: &bigquery.Table{Schema: &bigquery.TableSchema{Fields: []*bigquery.TableFieldSchema{
+				{Name: "insert_time", Type: "TIMESTAMP"},
+				{Name: "custom", Type: "STRING"},
+			}}}},
+			wantPatched: []*bigquery.Table{{Schema: &bigquery.TableSchema{Fields: append([]*bigquery.TableFieldSchema{
+				{Name: "insert_time", Type: "TIMESTAMP"},
+				{Name: "custom", Type: "STRING"},
+			}, validationDetailsSchema[1:]...)}}},
+			wantInserted: map[TableRef][]*bigquery.TableDataInsertAllRequestRows{
+				{Project: "central", Dataset: "wlm", Table: "history"}: {row, row},
+			},
+		},
+		{
+			name:   "ExistingTable",
+			config: &cpb.BigQueryExport{Dataset: "wlm"},
+			fake:   &fakeBigQuery{table: &bigquery.Table{Schema: &bigquery.TableSchema{Fields: validationDetailsSchema}}},
+			wantInserted: map[TableRef][]*bigquery.TableDataInsertAllRequestRows{
+				{Project: "p1", Dataset: "wlm", Table: DefaultBigQueryTable}: {row, row},
+			},
+		},
+		{
+			name:   "TableErrorIsIgnored",
+			config: &cpb.BigQueryExport{Dataset: "wlm"},
+			fake:   &fakeBigQuery{tableErr: errors.New("permission denied")},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := &fakeWriter{}
+			w := NewBigQueryWriter(fw, tc.fake, tc.config)
+			w.now = func() time.Time { return now }
+			for i := 0; i < 2; i++ {
+				if _, err := w.WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+					t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+				}
+			}
+			if len(fw.calls) != 2 {
+				t.Errorf("BigQueryWriter wrote %d insights, want 2", len(fw.calls))
+			}
+			if len(tc.fake.created) != tc.wantCreated {
+				t.Errorf("BigQueryWriter created %d tables, want %d", len(tc.fake.created), tc.wantCreated)
+			}
+			if diff := cmp.Diff(tc.wantPatched, tc.fake.patched); diff != "" {
+				t.Errorf("BigQueryWriter patched unexpected tables (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.wantInserted, tc.fake.inserted); diff != "" {
+				t.Errorf("BigQueryWriter inserted unexpected rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBigQueryWriterChecksTableOnce(t *testing.T) {
+	fake := &fakeBigQuery{}
+	w := NewBigQueryWriter(&fakeWriter{}, fake, &cpb.BigQueryExport{Dataset: "wlm"})
+	for i := 0; i < 3; i++ {
+		w.WriteInsightAndGetResponse("p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}))
+	}
+	if fake.gets != 1 {
+		t.Errorf("BigQueryWriter read the table %d times, want 1", fake.gets)
+	}
+}
+
+func TestBigQueryWriterSkipsActivationProbe(t *testing.T) {
+	fake := &fakeBigQuery{}
+	fw := &fakeWriter{}
+	w := NewBigQueryWriter(fw, fake, &cpb.BigQueryExport{Dataset: "wlm"})
+	probe := &dwpb.WriteInsightRequest{Insight: &dwpb.Insight{TorsoValidation: &dwpb.TorsoValidation{}}}
+	if _, err := w.WriteInsightAndGetResponse("p1", "us-central1", probe); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	if len(fw.calls) != 1 {
+		t.Errorf("BigQueryWriter wrote %d insights, want 1", len(fw.calls))
+	}
+	if fake.gets != 0 || len(fake.inserted) != 0 {
+		t.Errorf("BigQueryWriter read the table %d times and inserted %v, want no BigQuery calls", fake.gets, fake.inserted)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+const (
+	// DefaultCircuitBreakerFailureThreshold is the number of consecutive rejected writes after which writes are paused.
+	DefaultCircuitBreakerFailureThreshold = 3
+	// DefaultCircuitBreakerCoolDown is how long writes are paused before Data Warehouse is tried again.
+	DefaultCircuitBreakerCoolDown = 30 * time.Minute
+)
+
+// ErrCircuitOpen is returned for insights which are not written because Data Warehouse persistently rejected writes.
+var ErrCircuitOpen = errors.New("writes to Data Warehouse are paused after repeated rejections")
+
+// CircuitBreakerWriter is a WLMWriter which pauses writes for a cool-down after Data Warehouse
+// rejected a number of consecutive writes with 403 or 404, e.g. because the API is not enabled for
+// the project or a VPC Service Controls perimeter denies access. A single warning is logged when
+// writes are paused instead of an error for every insight.
+//
+// After the cool-down the next write is sent to Data Warehouse. Writes are paused again if it is
+// rejected and resume if it succeeds.
+type CircuitBreakerWriter struct {
+	writer    WLMWriter
+	threshold int
+	coolDown  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	lastErr   error
+}
+
+// NewCircuitBreakerWriter returns a CircuitBreakerWriter which writes to writer.
+func NewCircuitBreakerWriter(writer WLMWriter, config *cpb.DataWarehouseCircuitBreaker) *CircuitBreakerWriter {
+	threshold := int(config.GetFailureThreshold())
+	if threshold <= 0 {
+		threshold = DefaultCircuitBreakerFailureThreshold
+	}
+	coolDown := config.GetCoolDown().AsDuration()
+	if coolDown <= 0 {
+		coolDown = DefaultCircuitBreakerCoolDown
+	}
+	return &CircuitBreakerWriter{writer: writer, threshold: threshold, coolDown: coolDown, now: time.Now}
+}
+
+// WriteInsightAndGetResponse writes the insight unless writes are paused.
+// While paused, an error wrapping ErrCircuitOpen and the last rejection is returned.
+func (c *CircuitBreakerWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	c.mu.Lock()
+	if c.now().Before(c.openUntil) {
+		err := fmt.Errorf("%w until %s: %w", ErrCircuitOpen, c.openUntil.Format(time.RFC3339), c.lastErr)
+		c.mu.Unlock()
+		return nil, err
+	}
+	c.mu.Unlock()
+
+	res, err := c.writer.WriteInsightAndGetResponse(project, location, req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err == nil {
+		if c.failures >= c.threshold {
+			log.Logger.Infow("Data Warehouse accepted a write again, resuming writes")
+		}
+		c.failures = 0
+		return res, nil
+	}
+	if !isRejection(err) {
+		return res, err
+	}
+	c.failures++
+	c.lastErr = err
+	if c.failures >= c.threshold {
+		c.openUntil = c.now().Add(c.coolDown)
+		log.Logger.Warnw("Data Warehouse rejected consecutive writes, pausing writes. Verify that the Workload Manager API is enabled and that the service account is allowed to write insights.",
+			"rejectedWrites", c.failures, "coolDown", c.coolDown, "error", err)
+	}
+	return res, err
+}
+
+// isRejection reports whether Data Warehouse rejected the write because the API is not enabled or access is denied.
+func isRejection(err error) bool {
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && (apiErr.Code == http.StatusForbidden || apiErr.Code == http.StatusNotFound)
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/types/known/durationpb"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+func TestCircuitBreakerWriter(t *testing.T) {
+	forbidden := &googleapi.Error{Code: 403}
+	notFound := &googleapi.Error{Code: 404}
+	unavailable := &googleapi.Error{Code: 503}
+	type write struct {
+		// after is the time since the first write.
+		after     time.Duration
+		writerErr error
+		wantCall  bool
+		wantOpen  bool
+	}
+	tests := []struct {
+		name   string
+		writes []write
+	}{
+		{
+			name: "SuccessfulWritesAreNotPaused",
+			writes: []write{
+				{wantCall: true},
+				{wantCall: true},
+				{wantCall: true},
+				{wantCall: true},
+			},
+		},
+		{
+			name: "PausedAfterConsecutiveRejections",
+			writes: []write{
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: notFound, wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{after: time.Minute, wantOpen: true},
+				{after: 29 * time.Minute, wantOpen: true},
+			},
+		},
+		{
+			name: "SuccessResetsCount",
+			writes: []write{
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{wantCall: true},
+			},
+		},
+		{
+			name: "OtherErrorsAreNotCounted",
+			writes: []write{
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: unavailable, wantCall: true},
+				{writerErr: errors.New("connection reset"), wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: unavailable, wantCall: true},
+				{wantCall: true},
+			},
+		},
+		{
+			name: "ResumesAfterCoolDown",
+			writes: []write{
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{after: 30 * time.Minute, wantCall: true},
+				{after: 31 * time.Minute, wantCall: true},
+			},
+		},
+		{
+			name: "PausedAgainWhenRejectedAfterCoolDown",
+			writes: []write{
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{writerErr: forbidden, wantCall: true},
+				{after: 30 * time.Minute, writerErr: forbidden, wantCall: true},
+				{after: 31 * time.Minute, wantOpen: true},
+				{after: 60 * time.Minute, wantCall: true},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			now := start
+			fw := &fakeWriter{}
+			c := NewCircuitBreakerWriter(fw, nil)
+			c.now = func() time.Time { return now }
+
+			for i, w := range tc.writes {
+				now = start.Add(w.after)
+				fw.err = w.writerErr
+				calls := len(fw.calls)
+				_, err := c.WriteInsightAndGetResponse("test-project", "us-central1", DefaultWriteInsightRequest)
+
+				if gotCall := len(fw.calls) > calls; gotCall != w.wantCall {
+					t.Errorf("WriteInsightAndGetResponse() #%d called the writer: %t, want %t", i, gotCall, w.wantCall)
+				}
+				if gotOpen := errors.Is(err, ErrCircuitOpen); gotOpen != w.wantOpen {
+					t.Errorf("WriteInsightAndGetResponse() #%d returned error %v, want ErrCircuitOpen: %t", i, err, w.wantOpen)
+				}
+				if w.wantOpen && !errors.Is(err, forbidden) {
+					t.Errorf("WriteInsightAndGetResponse() #%d returned error %v, want it to wrap the last rejection %v", i, err, forbidden)
+				}
+				if !w.wantOpen && !errors.Is(err, w.writerErr) {
+					t.Errorf("WriteInsightAndGetResponse() #%d returned error %v, want %v", i, err, w.writerErr)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCircuitBreakerWriter(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        *cpb.DataWarehouseCircuitBreaker
+		wantThreshold int
+		wantCoolDown  time.Duration
+	}{
+		{
+			name:          "Defaults",
+			wantThreshold: DefaultCircuitBreakerFailureThreshold,
+			wantCoolDown:  DefaultCircuitBreakerCoolDown,
+		},
+		{
+			name: "Configured",
+			config: &cpb.DataWarehouseCircuitBreaker{
+				FailureThreshold: 5,
+				CoolDown:         durationpb.New(time.Hour),
+			},
+			wantThreshold: 5,
+			wantCoolDown:  time.Hour,
+		},
+		{
+			name: "InvalidValuesUseDefaults",
+			config: &cpb.DataWarehouseCircuitBreaker{
+				FailureThreshold: -1,
+				CoolDown:         durationpb.New(-time.Minute),
+			},
+			wantThreshold: DefaultCircuitBreakerFailureThreshold,
+			wantCoolDown:  DefaultCircuitBreakerCoolDown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewCircuitBreakerWriter(&fakeWriter{}, tc.config)
+			if c.threshold != tc.wantThreshold || c.coolDown != tc.wantCoolDown {
+				t.Errorf("NewCircuitBreakerWriter(%v) = {threshold: %d, coolDown: %v}, want {threshold: %d, coolDown: %v}", tc.config, c.threshold, c.coolDown, tc.wantThreshold, tc.wantCoolDown)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+const (
+	// ConfigDriftKey holds the settings which differ from their baseline as a JSON list.
+	ConfigDriftKey = "config_drift"
+	// ConfigBaselineKey holds the path of the baseline the settings were compared with.
+	ConfigBaselineKey = "config_baseline"
+)
+
+// Drift is a setting whose value differs from its baseline.
+type Drift struct {
+	Key      string `json:"key"`
+	Baseline string `json:"baseline"`
+	Current  string `json:"current"`
+}
+
+// baseline holds the expected settings of a workload.
+type baseline struct {
+	path     string
+	settings map[string]string
+}
+
+// driftKey identifies the insights whose drift is tracked together.
+type driftKey struct {
+	workloadType     string
+	databaseInstance string
+}
+
+// ConfigDriftWriter is a WLMWriter which compares the validation details of each insight with the
+// baseline of its workload. The settings which differ from the baseline, an empty list if none, and
+// the path of the baseline are added to the validation details of the insight. Changes of the drift
+// are logged.
+type ConfigDriftWriter struct {
+	writer    WLMWriter
+	baselines map[string]baseline
+
+	mu sync.Mutex
+	// drifts holds the last logged drift as JSON.
+	drifts map[driftKey]string
+}
+
+// NewConfigDriftWriter returns a ConfigDriftWriter which compares insights with the baselines of config.
+// Baselines which can't be read are logged and ignored.
+func NewConfigDriftWriter(ctx context.Context, writer WLMWriter, config *cpb.ConfigDrift) *ConfigDriftWriter {
+	w := &ConfigDriftWriter{writer: writer, baselines: make(map[string]baseline), drifts: make(map[driftKey]string)}
+	for _, b := range config.GetBaselines() {
+		settings, err := readBaseline(b.GetPath())
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Failed to read the configuration baseline, the drift of the workload is not reported", "workload_type", b.GetWorkloadType(), "path", b.GetPath(), "error", err)
+			continue
+		}
+		w.baselines[strings.ToUpper(b.GetWorkloadType())] = baseline{path: b.GetPath(), settings: settings}
+	}
+	return w
+}
+
+// readBaseline returns the settings of a YAML or JSON baseline file.
+func readBaseline(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var settings map[string]string
+	if err := yaml.Unmarshal(content, &settings); err != nil {
+		return nil, fmt.Errorf("parsing baseline %s: %w", path, err)
+	}
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("baseline %s has no settings", path)
+	}
+	return settings, nil
+}
+
+// compareBaseline returns the settings of details which differ from the baseline, sorted by key.
+// Settings which are not in details were not collected and are not compared.
+func compareBaseline(settings, details map[string]string) []Drift {
+	drift := []Drift{}
+	for key, want := range settings {
+		if got, ok := details[key]; ok && got != want {
+			drift = append(drift, Drift{Key: key, Baseline: want, Current: got})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool { return drift[i].Key < drift[j].Key })
+	return drift
+}
+
+// WriteInsightAndGetResponse writes the insight with the drift of its settings from the baseline.
+func (w *ConfigDriftWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	tv := req.GetInsight().GetTorsoValidation()
+	b, ok := w.baselines[tv.GetWorkloadType().String()]
+	if !ok || len(tv.GetValidationDetails()) == 0 {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	drift, err := w.drift(context.Background(), tv, b)
+	if err != nil {
+		log.Logger.Warnw("Failed to encode the configuration drift", "workload_type", tv.GetWorkloadType(), "error", err)
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+
+	req = proto.Clone(req).(*dwpb.WriteInsightRequest)
+	details := req.GetInsight().GetTorsoValidation().GetValidationDetails()
+	details[ConfigDriftKey] = drift
+	details[ConfigBaselineKey] = b.path
+	return w.writer.WriteInsightAndGetResponse(project, location, req)
+}
+
+// drift compares the validation details with the baseline and returns the drift as JSON.
+// Changes of the drift since the last insight of the workload are logged.
+func (w *ConfigDriftWriter) drift(ctx context.Context, tv *dwpb.TorsoValidation, b baseline) (string, error) {
+	details := tv.GetValidationDetails()
+	drift := compareBaseline(b.settings, details)
+	driftJSON, err := json.Marshal(drift)
+	if err != nil {
+		return "", err
+	}
+	key := driftKey{workloadType: tv.GetWorkloadType().String(), databaseInstance: details[DatabaseInstanceKey]}
+
+	w.mu.Lock()
+	previous, seen := w.drifts[key]
+	w.drifts[key] = string(driftJSON)
+	w.mu.Unlock()
+	switch {
+	case string(driftJSON) == previous:
+	case len(drift) > 0:
+		log.CtxLogger(ctx).Warnw("Workload settings drifted from the configuration baseline", "workload_type", key.workloadType, "database_instance", key.databaseInstance, "baseline", b.path, "drift", string(driftJSON))
+	case seen:
+		log.CtxLogger(ctx).Infow("Workload settings match the configuration baseline again", "workload_type", key.workloadType, "database_instance", key.databaseInstance, "baseline", b.path)
+	}
+	return string(driftJSON), nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func writeBaseline(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "baseline.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+	return path
+}
+
+func TestReadBaseline(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name:    "YAML",
+			content: "# MySQL baseline\nbuffer_pool_size: 8589934592\nbinlog_format: ROW\nlog_bin: true\n",
+			want:    map[string]string{"buffer_pool_size": "8589934592", "binlog_format": "ROW", "log_bin": "true"},
+		},
+		{
+			name:    "JSON",
+			content: `{"work_mem": "4194304", "archive_mode": "on"}`,
+			want:    map[string]string{"work_mem": "4194304", "archive_mode": "on"},
+		},
+		{
+			name:    "Empty",
+			content: "# no settings\n",
+			wantErr: true,
+		},
+		{
+			name:    "NotAMapping",
+			content: "- buffer_pool_size\n",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readBaseline(writeBaseline(t, tc.content))
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Fatalf("readBaseline() returned error: %v, want error: %v", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("readBaseline() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := readBaseline(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("readBaseline() returned nil error for a missing file, want error")
+	}
+}
+
+func TestCompareBaseline(t *testing.T) {
+	settings := map[string]string{"b": "2", "a": "1", "c": "3"}
+	details := map[string]string{"a": "10", "b": "2", "c": "", "d": "4"}
+	want := []Drift{{Key: "a", Baseline: "1", Current: "10"}, {Key: "c", Baseline: "3", Current: ""}}
+	if diff := cmp.Diff(want, compareBaseline(settings, details)); diff != "" {
+		t.Errorf("compareBaseline() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestConfigDriftWriter(t *testing.T) {
+	path := writeBaseline(t, "binlog_format: ROW\nsync_binlog: 1\n")
+	fw := &fakeWriter{}
+	w := NewConfigDriftWriter(context.Background(), fw, &cpb.ConfigDrift{
+		Enabled: proto.Bool(true),
+		Baselines: []*cpb.ConfigBaseline{
+			{WorkloadType: "mysql", Path: path},
+			{WorkloadType: "REDIS", Path: filepath.Join(t.TempDir(), "missing.yaml")},
+		},
+	})
+	withDrift := func(details map[string]string, drift string) map[string]string {
+		withDrift := map[string]string{ConfigDriftKey: drift, ConfigBaselineKey: path}
+		for k, v := range details {
+			withDrift[k] = v
+		}
+		return withDrift
+	}
+
+	cycles := []struct {
+		name    string
+		details map[string]string
+		wt      dwpb.TorsoValidation_WorkloadType
+		want    map[string]string
+	}{
+		{
+			name:    "MatchesBaseline",
+			wt:      dwpb.TorsoValidation_MYSQL,
+			details: map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "ROW", "sync_binlog": "1"},
+			want:    withDrift(map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "ROW", "sync_binlog": "1"}, "[]"),
+		},
+		{
+			name:    "Drifted",
+			wt:      dwpb.TorsoValidation_MYSQL,
+			details: map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "MIXED", "sync_binlog": "1"},
+			want:    withDrift(map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "MIXED", "sync_binlog": "1"}, `[{"key":"binlog_format","baseline":"ROW","current":"MIXED"}]`),
+		},
+		{
+			name:    "UncollectedSettingIsNotCompared",
+			wt:      dwpb.TorsoValidation_MYSQL,
+			details: map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "MIXED"},
+			want:    withDrift(map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "MIXED"}, `[{"key":"binlog_format","baseline":"ROW","current":"MIXED"}]`),
+		},
+		{
+			name:    "MatchesBaselineAgain",
+			wt:      dwpb.TorsoValidation_MYSQL,
+			details: map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "ROW"},
+			want:    withDrift(map[string]string{DatabaseInstanceKey: "localhost:3306", "binlog_format": "ROW"}, "[]"),
+		},
+		{
+			name:    "BaselineNotRead",
+			wt:      dwpb.TorsoValidation_REDIS,
+			details: map[string]string{"maxmemory": "0"},
+			want:    map[string]string{"maxmemory": "0"},
+		},
+	}
+	for _, c := range cycles {
+		fw.calls = nil
+		req := insightRequest("i1", c.wt, c.details)
+		if _, err := w.WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+			t.Fatalf("WriteInsightAndGetResponse() in cycle %s returned unexpected error: %v", c.name, err)
+		}
+		want := []writeCall{{"p1", "us-central1", insightRequest("i1", c.wt, c.want)}}
+		if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+			t.Errorf("WriteInsightAndGetResponse() in cycle %s wrote unexpected insights (-want +got):\n%s", c.name, diff)
+		}
+		if diff := cmp.Diff(insightRequest("i1", c.wt, c.details), req, protocmp.Transform()); diff != "" {
+			t.Errorf("WriteInsightAndGetResponse() in cycle %s modified the request (-want +got):\n%s", c.name, diff)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// Validation detail keys of the container context.
+const (
+	CgroupPathKey   = "cgroup_path"
+	PodNameKey      = "pod_name"
+	PodNamespaceKey = "pod_namespace"
+)
+
+// containerWorkloads maps the Data Warehouse workload types to the workloads of the signature registry.
+// Postgres and MongoDB insights have no Data Warehouse workload type and are not attributed.
+var containerWorkloads = map[dwpb.TorsoValidation_WorkloadType]string{
+	dwpb.TorsoValidation_ORACLE: servicecommunication.Oracle,
+	dwpb.TorsoValidation_MYSQL:  servicecommunication.MySQL,
+	dwpb.TorsoValidation_REDIS:  servicecommunication.Redis,
+}
+
+// ContainerContextWriter is a WLMWriter which adds the container running the workload to the
+// validation details of every insight. Insights are only attributed when all processes of the
+// workload run in the same container or pod. Validation details set by the workload take precedence.
+type ContainerContextWriter struct {
+	writer     WLMWriter
+	signatures *servicecommunication.Registry
+
+	mu      sync.Mutex
+	details map[dwpb.TorsoValidation_WorkloadType]map[string]string
+}
+
+// NewContainerContextWriter returns a ContainerContextWriter which identifies the processes of the
+// workloads with signatures and writes the attributed insights to writer.
+func NewContainerContextWriter(writer WLMWriter, signatures *servicecommunication.Registry) *ContainerContextWriter {
+	return &ContainerContextWriter{writer: writer, signatures: signatures}
+}
+
+// Listen updates the container context with the discovery results received on ch until ctx is done.
+func (c *ContainerContextWriter) Listen(ctx context.Context, ch <-chan *servicecommunication.Message) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-ch:
+			if msg.Origin == servicecommunication.Discovery {
+				c.Update(msg.DiscoveryResult)
+			}
+		}
+	}
+}
+
+// Update recomputes the container of each workload from a discovery result. Heartbeats are ignored.
+func (c *ContainerContextWriter) Update(result servicecommunication.DiscoveryResult) {
+	if result.Unchanged {
+		return
+	}
+	details := make(map[dwpb.TorsoValidation_WorkloadType]map[string]string)
+	for workloadType, workload := range containerWorkloads {
+		if d := containerDetails(c.signatures.Filter(workload, result.Processes), result.Containers); len(d) > 0 {
+			details[workloadType] = d
+		}
+	}
+	c.mu.Lock()
+	c.details = details
+	c.mu.Unlock()
+}
+
+// containerDetails returns the container shared by all processes as validation details.
+// Nothing is returned if any process runs on the host or the processes run in different pods.
+func containerDetails(processes []servicecommunication.ProcessWrapper, containers map[int32]servicecommunication.ContainerInfo) map[string]string {
+	if len(processes) == 0 {
+		return nil
+	}
+	first, ok := containers[processes[0].Pid()]
+	if !ok {
+		return nil
+	}
+	sameCgroup := true
+	for _, p := range processes[1:] {
+		info, ok := containers[p.Pid()]
+		if !ok || info.PodUID != first.PodUID {
+			return nil
+		}
+		if info.CgroupPath != first.CgroupPath {
+			if first.PodUID == "" {
+				return nil
+			}
+			sameCgroup = false
+		}
+	}
+	details := make(map[string]string)
+	if sameCgroup {
+		details[CgroupPathKey] = first.CgroupPath
+	}
+	if first.PodName != "" {
+		details[PodNameKey] = first.PodName
+		details[PodNamespaceKey] = first.PodNamespace
+	}
+	return details
+}
+
+// WriteInsightAndGetResponse adds the container context of the workload to the insight and writes it.
+func (c *ContainerContextWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	tv := req.GetInsight().GetTorsoValidation()
+	c.mu.Lock()
+	details := c.details[tv.GetWorkloadType()]
+	c.mu.Unlock()
+	if tv == nil || len(details) == 0 {
+		return c.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	req = proto.Clone(req).(*dwpb.WriteInsightRequest)
+	tv = req.GetInsight().GetTorsoValidation()
+	if tv.ValidationDetails == nil {
+		tv.ValidationDetails = make(map[string]string)
+	}
+	for k, v := range details {
+		if _, ok := tv.ValidationDetails[k]; !ok {
+			tv.ValidationDetails[k] = v
+		}
+	}
+	return c.writer.WriteInsightAndGetResponse(project, location, req)
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// processStub is a test double for servicecommunication.ProcessWrapper.
+type processStub struct {
+	pid  int32
+	name string
+}
+
+func (p processStub) Username() (string, error)       { return "", nil }
+func (p processStub) Pid() int32                      { return p.pid }
+func (p processStub) Name() (string, error)           { return p.name, nil }
+func (p processStub) CmdlineSlice() ([]string, error) { return nil, nil }
+func (p processStub) Environ() ([]string, error)      { return nil, nil }
+func (p processStub) String() string                  { return p.name }
+
+func TestContainerDetails(t *testing.T) {
+	mysqlPod := servicecommunication.ContainerInfo{CgroupPath: "/kubepods/pod1/c1", PodUID: "1", PodName: "mysql-0", PodNamespace: "db"}
+	tests := []struct {
+		name       string
+		processes  []servicecommunication.ProcessWrapper
+		containers map[int32]servicecommunication.ContainerInfo
+		want       map[string]string
+	}{
+		{
+			name:       "Pod",
+			processes:  []servicecommunication.ProcessWrapper{processStub{pid: 1}},
+			containers: map[int32]servicecommunication.ContainerInfo{1: mysqlPod},
+			want:       map[string]string{CgroupPathKey: "/kubepods/pod1/c1", PodNameKey: "mysql-0", PodNamespaceKey: "db"},
+		},
+		{
+			name:      "ContainersOfOnePod",
+			processes: []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			containers: map[int32]servicecommunication.ContainerInfo{
+				1: mysqlPod,
+				2: {CgroupPath: "/kubepods/pod1/c2", PodUID: "1", PodName: "mysql-0", PodNamespace: "db"},
+			},
+			want: map[string]string{PodNameKey: "mysql-0", PodNamespaceKey: "db"},
+		},
+		{
+			name:       "Container",
+			processes:  []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			containers: map[int32]servicecommunication.ContainerInfo{1: {CgroupPath: "/docker/c1"}, 2: {CgroupPath: "/docker/c1"}},
+			want:       map[string]string{CgroupPathKey: "/docker/c1"},
+		},
+		{
+			name:       "DifferentContainers",
+			processes:  []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			containers: map[int32]servicecommunication.ContainerInfo{1: {CgroupPath: "/docker/c1"}, 2: {CgroupPath: "/docker/c2"}},
+		},
+		{
+			name:      "DifferentPods",
+			processes: []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			containers: map[int32]servicecommunication.ContainerInfo{
+				1: mysqlPod,
+				2: {CgroupPath: "/kubepods/pod2/c1", PodUID: "2", PodName: "mysql-1", PodNamespace: "db"},
+			},
+		},
+		{
+			name:       "HostProcess",
+			processes:  []servicecommunication.ProcessWrapper{processStub{pid: 1}, processStub{pid: 2}},
+			containers: map[int32]servicecommunication.ContainerInfo{1: mysqlPod},
+		},
+		{
+			name: "NoProcesses",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := containerDetails(tc.processes, tc.containers)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("containerDetails() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestContainerContextWriter(t *testing.T) {
+	fw := &fakeWriter{}
+	c := NewContainerContextWriter(fw, servicecommunication.DefaultRegistry())
+	c.Update(servicecommunication.DiscoveryResult{
+		Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "mysqld"}, processStub{pid: 2, name: "redis-server"}},
+		Containers: map[int32]servicecommunication.ContainerInfo{
+			1: {CgroupPath: "/kubepods/pod1/c1", PodUID: "1", PodName: "mysql-0", PodNamespace: "db"},
+		},
+	})
+	// Heartbeats keep the container context of the last full result.
+	c.Update(servicecommunication.DiscoveryResult{Unchanged: true})
+
+	mysqlReq := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{PodNamespaceKey: "set-by-workload"})
+	redisReq := insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"a": "1"})
+	for _, req := range []*dwpb.WriteInsightRequest{mysqlReq, redisReq} {
+		if _, err := c.WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+			t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+		}
+	}
+
+	want := []writeCall{
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+			CgroupPathKey:   "/kubepods/pod1/c1",
+			PodNameKey:      "mysql-0",
+			PodNamespaceKey: "set-by-workload",
+		})},
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"a": "1"})},
+	}
+	if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+		t.Errorf("WriteInsightAndGetResponse() wrote unexpected insights (-want +got):\n%s", diff)
+	}
+	if _, ok := mysqlReq.GetInsight().GetTorsoValidation().GetValidationDetails()[PodNameKey]; ok {
+		t.Errorf("WriteInsightAndGetResponse() modified the request of the caller")
+	}
+}
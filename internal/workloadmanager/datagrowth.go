@@ -0,0 +1,185 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+const (
+	// DataDirectoryGrowthKey holds the size and growth rate of the data directories as a JSON list.
+	DataDirectoryGrowthKey = "data_directory_growth"
+	// dataGrowthNamespace holds the size history of each data directory in the state store.
+	dataGrowthNamespace = "data_growth"
+
+	// growthWindow is the period over which the growth rate is computed.
+	growthWindow = 7 * 24 * time.Hour
+	// growthSampleInterval is the minimum time between the sizes kept in the history.
+	growthSampleInterval = time.Hour
+)
+
+// DataGrowth describes the size and growth of a data directory.
+type DataGrowth struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes"`
+	// GrowthBytesPerDay is the average growth over the window, it is omitted until the history spans
+	// at least one sample interval.
+	GrowthBytesPerDay *float64 `json:"growth_bytes_per_day,omitempty"`
+	// DaysUntilFull projects when the growth fills the filesystem of the directory, it is omitted
+	// unless the directory is growing.
+	DaysUntilFull *float64 `json:"days_until_full,omitempty"`
+}
+
+// sizeSample is a data directory size in the history.
+type sizeSample struct {
+	Time      time.Time `json:"time"`
+	SizeBytes int64     `json:"size_bytes"`
+}
+
+// DataGrowthWriter is a WLMWriter which adds the size and growth rate of the data directories reported
+// in an insight. The sizes are kept in the state store so the growth rate survives agent restarts.
+type DataGrowthWriter struct {
+	writer WLMWriter
+	store  *statestore.Store
+
+	mu sync.Mutex
+
+	dirSize func(path string) (int64, error)
+	usage   func(path string) (*disk.UsageStat, error)
+	now     func() time.Time
+}
+
+// NewDataGrowthWriter returns a DataGrowthWriter which keeps the size history in store.
+func NewDataGrowthWriter(writer WLMWriter, store *statestore.Store) *DataGrowthWriter {
+	return &DataGrowthWriter{
+		writer:  writer,
+		store:   store,
+		dirSize: dirSize,
+		usage:   disk.Usage,
+		now:     time.Now,
+	}
+}
+
+// WriteInsightAndGetResponse adds the growth of the data directories to the insight and writes it.
+func (w *DataGrowthWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	details := req.GetInsight().GetTorsoValidation().GetValidationDetails()
+	if details[DataDirectoriesKey] == "" || details[DataDirectoryGrowthKey] != "" {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	growth := w.Growth(context.Background(), strings.Split(details[DataDirectoriesKey], ","))
+	if len(growth) == 0 {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	growthJSON, err := json.Marshal(growth)
+	if err != nil {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	req = proto.Clone(req).(*dwpb.WriteInsightRequest)
+	req.GetInsight().GetTorsoValidation().ValidationDetails[DataDirectoryGrowthKey] = string(growthJSON)
+	return w.writer.WriteInsightAndGetResponse(project, location, req)
+}
+
+// Growth measures the size of each data directory, records it in the history and returns the growth.
+// Directories which can't be measured, such as Oracle ASM disk groups, are skipped.
+func (w *DataGrowthWriter) Growth(ctx context.Context, paths []string) []DataGrowth {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	var growth []DataGrowth
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		size, err := w.dirSize(path)
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Unable to measure the size of the data directory", "path", path, "error", err)
+			continue
+		}
+		g := DataGrowth{Path: path, SizeBytes: size}
+		samples := w.record(ctx, path, sizeSample{Time: now, SizeBytes: size})
+		if elapsed := now.Sub(samples[0].Time); elapsed >= growthSampleInterval {
+			rate := math.Round(float64(size-samples[0].SizeBytes) / elapsed.Hours() * 24)
+			g.GrowthBytesPerDay = &rate
+			if usage, err := w.usage(path); err == nil && rate > 0 {
+				days := math.Round(float64(usage.Free)/rate*10) / 10
+				g.DaysUntilFull = &days
+			}
+		}
+		growth = append(growth, g)
+	}
+	return growth
+}
+
+// record adds the sample to the history of path unless the last sample is more recent than the
+// sample interval, drops the samples which are out of the window and returns the history.
+// A history which can't be read or written is restarted with the sample.
+func (w *DataGrowthWriter) record(ctx context.Context, path string, sample sizeSample) []sizeSample {
+	var samples []sizeSample
+	if _, err := w.store.Get(dataGrowthNamespace, path, &samples); err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to read the size history of the data directory", "path", path, "error", err)
+		samples = nil
+	}
+	if len(samples) == 0 || sample.Time.Sub(samples[len(samples)-1].Time) >= growthSampleInterval {
+		samples = append(samples, sample)
+	}
+	for len(samples) > 1 && sample.Time.Sub(samples[0].Time) > growthWindow {
+		samples = samples[1:]
+	}
+	if err := w.store.Set(dataGrowthNamespace, path, samples); err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to write the size history of the data directory", "path", path, "error", err)
+	}
+	return samples
+}
+
+// dirSize returns the total size of the regular files below path. Symbolic links, such as a pg_wal
+// directory on another disk, are not followed.
+// Files which can't be read, e.g. because they were removed while walking, are skipped.
+func dirSize(path string) (int64, error) {
+	if _, err := os.Stat(path); err != nil {
+		return 0, err
+	}
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
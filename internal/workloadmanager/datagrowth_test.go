@@ -0,0 +1,183 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shirou/gopsutil/v3/disk"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+var testGrowthStart = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func float(f float64) *float64 {
+	return &f
+}
+
+// testDataGrowthWriter returns a DataGrowthWriter measuring the sizes in sizes at the time in now.
+func testDataGrowthWriter(writer WLMWriter, path string, sizes map[string]int64, now *time.Time) *DataGrowthWriter {
+	w := NewDataGrowthWriter(writer, statestore.New(path))
+	w.dirSize = func(path string) (int64, error) {
+		if size, ok := sizes[path]; ok {
+			return size, nil
+		}
+		return 0, os.ErrNotExist
+	}
+	w.usage = func(path string) (*disk.UsageStat, error) {
+		if path == "/var/lib/pgsql" {
+			return nil, errors.New("usage failed")
+		}
+		return &disk.UsageStat{Path: path, Free: 1000}, nil
+	}
+	w.now = func() time.Time { return *now }
+	return w
+}
+
+func TestGrowth(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "state", "state.json")
+	sizes := map[string]int64{"/var/lib/mysql": 100, "/var/lib/pgsql": 100}
+	now := testGrowthStart
+	paths := []string{"/var/lib/mysql", " /var/lib/pgsql", "+DATA", ""}
+
+	got := testDataGrowthWriter(&fakeWriter{}, historyPath, sizes, &now).Growth(context.Background(), paths)
+	want := []DataGrowth{{Path: "/var/lib/mysql", SizeBytes: 100}, {Path: "/var/lib/pgsql", SizeBytes: 100}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Growth() returned unexpected diff on the first measurement (-want +got):\n%s", diff)
+	}
+
+	// The history is read from the state store by a new writer, as after an agent restart.
+	now = testGrowthStart.Add(12 * time.Hour)
+	sizes["/var/lib/mysql"] = 150
+	sizes["/var/lib/pgsql"] = 50
+	got = testDataGrowthWriter(&fakeWriter{}, historyPath, sizes, &now).Growth(context.Background(), paths)
+	want = []DataGrowth{
+		{Path: "/var/lib/mysql", SizeBytes: 150, GrowthBytesPerDay: float(100), DaysUntilFull: float(10)},
+		{Path: "/var/lib/pgsql", SizeBytes: 50, GrowthBytesPerDay: float(-100)},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Growth() returned unexpected diff after a restart (-want +got):\n%s", diff)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	w := NewDataGrowthWriter(&fakeWriter{}, statestore.New(filepath.Join(t.TempDir(), "state.json")))
+	w.record(context.Background(), "/data", sizeSample{Time: testGrowthStart, SizeBytes: 1})
+	w.record(context.Background(), "/data", sizeSample{Time: testGrowthStart.Add(24 * time.Hour), SizeBytes: 2})
+
+	// Samples more recent than the sample interval are not kept.
+	got := w.record(context.Background(), "/data", sizeSample{Time: testGrowthStart.Add(24*time.Hour + time.Minute), SizeBytes: 3})
+	want := []sizeSample{
+		{Time: testGrowthStart, SizeBytes: 1},
+		{Time: testGrowthStart.Add(24 * time.Hour), SizeBytes: 2},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("record() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	// Samples out of the window are dropped.
+	got = w.record(context.Background(), "/data", sizeSample{Time: testGrowthStart.Add(growthWindow + time.Hour), SizeBytes: 4})
+	want = []sizeSample{
+		{Time: testGrowthStart.Add(24 * time.Hour), SizeBytes: 2},
+		{Time: testGrowthStart.Add(growthWindow + time.Hour), SizeBytes: 4},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("record() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestDataGrowthWriter(t *testing.T) {
+	growth, err := json.Marshal([]DataGrowth{{Path: "/var/lib/mysql", SizeBytes: 100}})
+	if err != nil {
+		t.Fatalf("json.Marshal() returned unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *dwpb.WriteInsightRequest
+		want *dwpb.WriteInsightRequest
+	}{
+		{
+			name: "AddsGrowth",
+			req:  insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{DataDirectoriesKey: "/var/lib/mysql"}),
+			want: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+				DataDirectoriesKey:     "/var/lib/mysql",
+				DataDirectoryGrowthKey: string(growth),
+			}),
+		},
+		{
+			name: "NoDataDirectories",
+			req:  insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+			want: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+		},
+		{
+			name: "NoMeasuredDirectories",
+			req:  insightRequest("i1", dwpb.TorsoValidation_ORACLE, map[string]string{DataDirectoriesKey: "+DATA"}),
+			want: insightRequest("i1", dwpb.TorsoValidation_ORACLE, map[string]string{DataDirectoriesKey: "+DATA"}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := &fakeWriter{}
+			now := testGrowthStart
+			w := testDataGrowthWriter(fw, filepath.Join(t.TempDir(), "state.json"), map[string]int64{"/var/lib/mysql": 100}, &now)
+
+			if _, err := w.WriteInsightAndGetResponse("p1", "us-central1", tc.req); err != nil {
+				t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+			}
+			if len(fw.calls) != 1 {
+				t.Fatalf("WriteInsightAndGetResponse() wrote %d insights, want 1", len(fw.calls))
+			}
+			if diff := cmp.Diff(tc.want, fw.calls[0].req, protocmp.Transform()); diff != "" {
+				t.Errorf("WriteInsightAndGetResponse() wrote unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "base", "1"), 0755); err != nil {
+		t.Fatalf("os.MkdirAll() returned unexpected error: %v", err)
+	}
+	for path, size := range map[string]int{"PG_VERSION": 3, "base/1/16384": 8192} {
+		if err := os.WriteFile(filepath.Join(dir, path), make([]byte, size), 0644); err != nil {
+			t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+		}
+	}
+	if err := os.Symlink("/nonexistent", filepath.Join(dir, "pg_wal")); err != nil {
+		t.Fatalf("os.Symlink() returned unexpected error: %v", err)
+	}
+
+	got, err := dirSize(dir)
+	if err != nil || got != 8195 {
+		t.Errorf("dirSize(%s) = %d, %v, want 8195, nil", dir, got, err)
+	}
+	if _, err := dirSize(filepath.Join(dir, "missing")); err == nil {
+		t.Errorf("dirSize(%s) returned nil error for a missing directory, want error", filepath.Join(dir, "missing"))
+	}
+}
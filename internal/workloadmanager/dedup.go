@@ -0,0 +1,184 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// DefaultDedupMaxStaleness is how long an unchanged insight is skipped before it is sent again.
+const DefaultDedupMaxStaleness = time.Hour
+
+// dedupNamespace holds the last insight written for each batchKey in the state store.
+const dedupNamespace = "dedup"
+
+// volatileDetailKeys are the validation details which change on every collection even though the
+// workload did not change. They are ignored when insights are compared.
+var volatileDetailKeys = []string{
+	CollectionTimeKey,
+	CollectionDurationKey,
+	HostHugePagesFreeKey,
+	HostSwapFreeKBKey,
+}
+
+// sentInsight is the last insight successfully written for a batchKey.
+type sentInsight struct {
+	sum [sha256.Size]byte
+	at  time.Time
+	res *wlm.WriteInsightResponse
+}
+
+// storedInsight is a sentInsight in the state store.
+type storedInsight struct {
+	Sum        string    `json:"sum"`
+	At         time.Time `json:"at"`
+	StatusCode int       `json:"status_code"`
+}
+
+// DedupWriter is a WLMWriter which skips insights that are identical to the last insight written
+// for the same project, location, instance, workload type and database instance. The sent time and
+// the validation details describing the collection itself, such as its time and duration, are
+// ignored when insights are compared. An unchanged insight is still written once maxStaleness has
+// passed since it was last written, so Data Warehouse does not consider the workload stale.
+//
+// Skipped writes return the response of the last write. If a state store is set, the last writes
+// are kept in it so unchanged insights are not written again after an agent restart.
+type DedupWriter struct {
+	writer       WLMWriter
+	maxStaleness time.Duration
+	now          func() time.Time
+	store        *statestore.Store
+
+	mu   sync.Mutex
+	sent map[batchKey]sentInsight
+}
+
+// NewDedupWriter returns a DedupWriter which writes to writer and keeps the last writes in store.
+// The last writes are only kept in memory if store is nil.
+func NewDedupWriter(writer WLMWriter, config *cpb.DataWarehouseDeduplication, store *statestore.Store) *DedupWriter {
+	maxStaleness := config.GetMaxStaleness().AsDuration()
+	if maxStaleness <= 0 {
+		maxStaleness = DefaultDedupMaxStaleness
+	}
+	return &DedupWriter{
+		writer:       writer,
+		maxStaleness: maxStaleness,
+		now:          time.Now,
+		store:        store,
+		sent:         make(map[batchKey]sentInsight),
+	}
+}
+
+// WriteInsightAndGetResponse writes the insight unless it is unchanged since the last write.
+func (d *DedupWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	key := newBatchKey(project, location, req)
+	sum, err := insightSum(req)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	last, ok := d.sent[key]
+	if !ok {
+		last, ok = d.load(key)
+	}
+	d.mu.Unlock()
+	if ok && last.sum == sum && d.now().Sub(last.at) < d.maxStaleness {
+		log.Logger.Debugw("Skipping unchanged Data Warehouse insight", "workloadType", key.workloadType, "lastWritten", last.at)
+		return last.res, nil
+	}
+
+	res, err := d.writer.WriteInsightAndGetResponse(project, location, req)
+	if err != nil {
+		return res, err
+	}
+	d.mu.Lock()
+	d.sent[key] = sentInsight{sum: sum, at: d.now(), res: res}
+	d.save(key, d.sent[key])
+	d.mu.Unlock()
+	return res, nil
+}
+
+// storeKey returns the key of the last write for key in the state store.
+func storeKey(key batchKey) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", key.project, key.location, key.instanceID, key.workloadType, key.databaseInstance)
+}
+
+// load returns the last write for key from the state store. d.mu must be held.
+func (d *DedupWriter) load(key batchKey) (sentInsight, bool) {
+	if d.store == nil {
+		return sentInsight{}, false
+	}
+	var stored storedInsight
+	found, err := d.store.Get(dedupNamespace, storeKey(key), &stored)
+	if err != nil {
+		log.Logger.Debugw("Unable to read the last Data Warehouse write from the state store", "error", err)
+	}
+	sum, decodeErr := hex.DecodeString(stored.Sum)
+	if !found || err != nil || decodeErr != nil || len(sum) != sha256.Size {
+		return sentInsight{}, false
+	}
+	last := sentInsight{
+		at:  stored.At,
+		res: &wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: stored.StatusCode}},
+	}
+	copy(last.sum[:], sum)
+	d.sent[key] = last
+	return last, true
+}
+
+// save writes the last write for key to the state store. d.mu must be held.
+func (d *DedupWriter) save(key batchKey, last sentInsight) {
+	if d.store == nil {
+		return
+	}
+	stored := storedInsight{Sum: hex.EncodeToString(last.sum[:]), At: last.at}
+	if last.res != nil {
+		stored.StatusCode = last.res.HTTPStatusCode
+	}
+	if err := d.store.Set(dedupNamespace, storeKey(key), stored); err != nil {
+		log.Logger.Debugw("Unable to write the last Data Warehouse write to the state store", "error", err)
+	}
+}
+
+// insightSum returns a hash of the insight in req, ignoring its sent time and volatile details.
+func insightSum(req *dwpb.WriteInsightRequest) ([sha256.Size]byte, error) {
+	insight := proto.Clone(req.GetInsight()).(*dwpb.Insight)
+	if insight != nil {
+		insight.SentTime = nil
+		for _, key := range volatileDetailKeys {
+			delete(insight.GetTorsoValidation().GetValidationDetails(), key)
+		}
+	}
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(insight)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("marshalling insight: %w", err)
+	}
+	return sha256.Sum256(b), nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestDedupWriter(t *testing.T) {
+	type write struct {
+		// after is the time since the first write.
+		after     time.Duration
+		req       *dwpb.WriteInsightRequest
+		writerErr error
+		wantCall  bool
+	}
+	sentAt := func(req *dwpb.WriteInsightRequest, sec int64) *dwpb.WriteInsightRequest {
+		req.Insight.SentTime = &timestamppb.Timestamp{Seconds: sec}
+		return req
+	}
+	collectedAt := func(metrics map[string]string, at time.Time, took time.Duration) *dwpb.WriteInsightRequest {
+		wm := WorkloadMetrics{WorkloadType: MYSQL, Metrics: metrics, CollectionTime: at, CollectionDuration: took}
+		return insightRequest("i1", dwpb.TorsoValidation_MYSQL, validationDetails(wm))
+	}
+	collectionStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		writes []write
+	}{
+		{
+			name: "UnchangedInsightIsSkipped",
+			writes: []write{
+				{req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), wantCall: true},
+				{after: time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{after: 59 * time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+		},
+		{
+			name: "StaleInsightIsWritten",
+			writes: []write{
+				{req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), wantCall: true},
+				{after: time.Hour, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), wantCall: true},
+				{after: 90 * time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+		},
+		{
+			name: "ChangedInsightIsWritten",
+			writes: []write{
+				{req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), wantCall: true},
+				{after: time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "2"}), wantCall: true},
+				{after: 2 * time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), wantCall: true},
+			},
+		},
+		{
+			name: "SentTimeIsIgnored",
+			writes: []write{
+				{req: sentAt(insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), 1), wantCall: true},
+				{after: time.Minute, req: sentAt(insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), 61)},
+			},
+		},
+		{
+			name: "CollectionTimeIsIgnored",
+			writes: []write{
+				{req: collectedAt(map[string]string{"a": "1"}, collectionStart, 120*time.Millisecond), wantCall: true},
+				{after: time.Minute, req: collectedAt(map[string]string{"a": "1"}, collectionStart.Add(time.Minute), 95*time.Millisecond)},
+				{after: 2 * time.Minute, req: collectedAt(map[string]string{"a": "2"}, collectionStart.Add(2*time.Minute), 95*time.Millisecond), wantCall: true},
+			},
+		},
+		{
+			name: "VolatileHostDetailsAreIgnored",
+			writes: []write{
+				{req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", HostSwapFreeKBKey: "1024", HostHugePagesFreeKey: "8"}), wantCall: true},
+				{after: time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", HostSwapFreeKBKey: "2048", HostHugePagesFreeKey: "4"})},
+			},
+		},
+		{
+			name: "WorkloadsAreComparedSeparately",
+			writes: []write{
+				{req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), wantCall: true},
+				{req: insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"a": "1"}), wantCall: true},
+				{req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{DatabaseInstanceKey: "localhost:3307", "a": "1"}), wantCall: true},
+				{after: time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+				{after: time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"a": "1"})},
+			},
+		},
+		{
+			name: "FailedWriteIsRetried",
+			writes: []write{
+				{req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), writerErr: errors.New("write failed"), wantCall: true},
+				{after: time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}), wantCall: true},
+				{after: 2 * time.Minute, req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			now := start
+			fw := &fakeWriter{}
+			d := NewDedupWriter(fw, nil, nil)
+			d.now = func() time.Time { return now }
+
+			for i, w := range tc.writes {
+				now = start.Add(w.after)
+				fw.err = w.writerErr
+				calls := len(fw.calls)
+				res, err := d.WriteInsightAndGetResponse("test-project", "us-central1", w.req)
+
+				if gotCall := len(fw.calls) > calls; gotCall != w.wantCall {
+					t.Errorf("WriteInsightAndGetResponse() #%d called the writer: %t, want %t", i, gotCall, w.wantCall)
+				}
+				if !errors.Is(err, w.writerErr) {
+					t.Errorf("WriteInsightAndGetResponse() #%d returned error %v, want %v", i, err, w.writerErr)
+				}
+				if err == nil && res == nil {
+					t.Errorf("WriteInsightAndGetResponse() #%d returned nil response, want the last response", i)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDedupWriter(t *testing.T) {
+	tests := []struct {
+		name             string
+		config           *cpb.DataWarehouseDeduplication
+		wantMaxStaleness time.Duration
+	}{
+		{
+			name:             "Default",
+			wantMaxStaleness: DefaultDedupMaxStaleness,
+		},
+		{
+			name:             "Configured",
+			config:           &cpb.DataWarehouseDeduplication{MaxStaleness: durationpb.New(6 * time.Hour)},
+			wantMaxStaleness: 6 * time.Hour,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewDedupWriter(&fakeWriter{}, tc.config, nil)
+			if d.maxStaleness != tc.wantMaxStaleness {
+				t.Errorf("NewDedupWriter().maxStaleness = %v, want %v", d.maxStaleness, tc.wantMaxStaleness)
+			}
+		})
+	}
+}
+
+func TestDedupWriterStateStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})
+	fw := &fakeWriter{}
+	d := NewDedupWriter(fw, nil, statestore.New(path))
+	d.now = func() time.Time { return start }
+	if _, err := d.WriteInsightAndGetResponse("test-project", "us-central1", req); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+
+	// The last write is read from the state store by a new writer, as after an agent restart.
+	restarted := NewDedupWriter(fw, nil, statestore.New(path))
+	restarted.now = func() time.Time { return start.Add(time.Minute) }
+	res, err := restarted.WriteInsightAndGetResponse("test-project", "us-central1", req)
+	if err != nil || res == nil {
+		t.Fatalf("WriteInsightAndGetResponse() = %v, %v after a restart, want the last response", res, err)
+	}
+	if len(fw.calls) != 1 {
+		t.Errorf("WriteInsightAndGetResponse() wrote %d insights, want 1 as the insight is unchanged since before the restart", len(fw.calls))
+	}
+
+	restarted.now = func() time.Time { return start.Add(DefaultDedupMaxStaleness) }
+	if _, err := restarted.WriteInsightAndGetResponse("test-project", "us-central1", req); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	if len(fw.calls) != 2 {
+		t.Errorf("WriteInsightAndGetResponse() wrote %d insights, want 2 as the insight is stale", len(fw.calls))
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/shirou/gopsutil/v3/disk"
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// Validation detail keys of the disk layout.
+const (
+	// DataDirectoriesKey is set by the workloads to the comma separated data directories of the database.
+	DataDirectoriesKey = "data_directories"
+	// DataDirectoryLayoutKey holds the disk layout of the data directories as a JSON list.
+	DataDirectoryLayoutKey = "data_directory_layout"
+
+	// gceDeviceLinks holds the links from GCE device names to the block devices of the attached disks.
+	gceDeviceLinks = "/dev/disk/by-id"
+)
+
+// gceDeviceLinkRegex matches the links of GCE disks and their partitions, e.g. "google-data-part1".
+var gceDeviceLinkRegex = regexp.MustCompile(`^google-(.+?)(-part\d+)?$`)
+
+// DiskLayout describes the filesystem and disk a data directory is stored on.
+type DiskLayout struct {
+	Path         string  `json:"path"`
+	MountPoint   string  `json:"mount_point"`
+	Filesystem   string  `json:"filesystem"`
+	MountOptions string  `json:"mount_options"`
+	Device       string  `json:"device"`
+	DeviceName   string  `json:"device_name,omitempty"`
+	DiskType     string  `json:"disk_type,omitempty"`
+	SizeBytes    uint64  `json:"size_bytes"`
+	UsedBytes    uint64  `json:"used_bytes"`
+	UsedPercent  float64 `json:"used_percent"`
+}
+
+// DiskLayoutWriter is a WLMWriter which maps the data directories reported in an insight to their
+// filesystem, mount options, disk type and utilization. Insights without data directories are written
+// unchanged, as are insights which already hold a disk layout.
+type DiskLayoutWriter struct {
+	writer          WLMWriter
+	deviceDiskTypes map[string]string
+
+	partitions   func(all bool) ([]disk.PartitionStat, error)
+	usage        func(path string) (*disk.UsageStat, error)
+	readDir      func(name string) ([]os.DirEntry, error)
+	evalSymlinks func(path string) (string, error)
+}
+
+// NewDiskLayoutWriter returns a DiskLayoutWriter which resolves disk types with the attached disks of hc.
+func NewDiskLayoutWriter(writer WLMWriter, hc HostContext) *DiskLayoutWriter {
+	return &DiskLayoutWriter{
+		writer:          writer,
+		deviceDiskTypes: hc.DeviceDiskTypes,
+		partitions:      disk.Partitions,
+		usage:           disk.Usage,
+		readDir:         os.ReadDir,
+		evalSymlinks:    filepath.EvalSymlinks,
+	}
+}
+
+// WriteInsightAndGetResponse adds the disk layout of the data directories to the insight and writes it.
+func (w *DiskLayoutWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	details := req.GetInsight().GetTorsoValidation().GetValidationDetails()
+	if details[DataDirectoriesKey] == "" || details[DataDirectoryLayoutKey] != "" {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	layouts := w.Layouts(context.Background(), strings.Split(details[DataDirectoriesKey], ","))
+	if len(layouts) == 0 {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	layoutJSON, err := json.Marshal(layouts)
+	if err != nil {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	req = proto.Clone(req).(*dwpb.WriteInsightRequest)
+	req.GetInsight().GetTorsoValidation().ValidationDetails[DataDirectoryLayoutKey] = string(layoutJSON)
+	return w.writer.WriteInsightAndGetResponse(project, location, req)
+}
+
+// Layouts returns the disk layout of each data directory.
+// Directories which are not on a mounted filesystem, such as Oracle ASM disk groups, are skipped.
+func (w *DiskLayoutWriter) Layouts(ctx context.Context, paths []string) []DiskLayout {
+	partitions, err := w.partitions(false)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to list the mounted filesystems", "error", err)
+		return nil
+	}
+	deviceNames := w.gceDeviceNames(ctx)
+
+	var layouts []DiskLayout
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		resolved := path
+		if p, err := w.evalSymlinks(path); err == nil {
+			resolved = p
+		}
+		partition, ok := mountOf(resolved, partitions)
+		if !ok {
+			log.CtxLogger(ctx).Debugw("Data directory is not on a mounted filesystem", "path", path)
+			continue
+		}
+		layout := DiskLayout{
+			Path:         path,
+			MountPoint:   partition.Mountpoint,
+			Filesystem:   partition.Fstype,
+			MountOptions: strings.Join(partition.Opts, ","),
+			Device:       partition.Device,
+			DeviceName:   deviceNames[partition.Device],
+		}
+		layout.DiskType = w.deviceDiskTypes[layout.DeviceName]
+		if usage, err := w.usage(partition.Mountpoint); err == nil {
+			layout.SizeBytes = usage.Total
+			layout.UsedBytes = usage.Used
+			layout.UsedPercent = usage.UsedPercent
+		} else {
+			log.CtxLogger(ctx).Debugw("Unable to get the utilization of the filesystem", "mount_point", partition.Mountpoint, "error", err)
+		}
+		layouts = append(layouts, layout)
+	}
+	return layouts
+}
+
+// gceDeviceNames maps block devices, e.g. "/dev/sdb1", to the GCE device names of their disks.
+func (w *DiskLayoutWriter) gceDeviceNames(ctx context.Context) map[string]string {
+	names := make(map[string]string)
+	entries, err := w.readDir(gceDeviceLinks)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to list the GCE device links", "error", err)
+		return names
+	}
+	for _, e := range entries {
+		m := gceDeviceLinkRegex.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		device, err := w.evalSymlinks(filepath.Join(gceDeviceLinks, e.Name()))
+		if err != nil {
+			continue
+		}
+		names[device] = m[1]
+	}
+	return names
+}
+
+// mountOf returns the partition with the longest mount point containing path.
+func mountOf(path string, partitions []disk.PartitionStat) (disk.PartitionStat, bool) {
+	var best disk.PartitionStat
+	found := false
+	for _, p := range partitions {
+		if !withinDir(path, mountRoot(p.Mountpoint)) {
+			continue
+		}
+		if !found || len(p.Mountpoint) > len(best.Mountpoint) {
+			best = p
+			found = true
+		}
+	}
+	return best, found
+}
+
+// mountRoot returns the root directory of a mount point. Windows drives are listed without a
+// separator, e.g. "C:", which is the current directory of the drive rather than its root.
+func mountRoot(mountpoint string) string {
+	if mountpoint != "" && filepath.VolumeName(mountpoint) == mountpoint {
+		return mountpoint + string(filepath.Separator)
+	}
+	return mountpoint
+}
+
+// withinDir reports whether path is dir or a path below dir.
+func withinDir(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"runtime"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/shirou/gopsutil/v3/disk"
+	"google.golang.org/protobuf/testing/protocmp"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+var testPartitions = []disk.PartitionStat{
+	{Device: "/dev/sda1", Mountpoint: "/", Fstype: "ext4", Opts: []string{"rw", "relatime"}},
+	{Device: "/dev/sdb1", Mountpoint: "/var/lib", Fstype: "xfs", Opts: []string{"rw", "noatime"}},
+	{Device: "/dev/nvme0n1", Mountpoint: "/var/lib/mysql", Fstype: "ext4", Opts: []string{"rw"}},
+}
+
+var testSymlinks = map[string]string{
+	"/dev/disk/by-id/google-persistent-disk-0":       "/dev/sda",
+	"/dev/disk/by-id/google-persistent-disk-0-part1": "/dev/sda1",
+	"/dev/disk/by-id/google-data-part1":              "/dev/sdb1",
+	"/dev/disk/by-id/google-local-nvme-ssd-0":        "/dev/nvme0n1",
+	"/data/pgdata": "/var/lib/postgresql/16/main",
+}
+
+func testDiskLayoutWriter(writer WLMWriter) *DiskLayoutWriter {
+	links := fstest.MapFS{
+		"google-persistent-disk-0":         &fstest.MapFile{},
+		"google-persistent-disk-0-part1":   &fstest.MapFile{},
+		"google-data-part1":                &fstest.MapFile{},
+		"google-local-nvme-ssd-0":          &fstest.MapFile{},
+		"scsi-0Google_PersistentDisk_data": &fstest.MapFile{},
+	}
+	return &DiskLayoutWriter{
+		writer: writer,
+		deviceDiskTypes: map[string]string{
+			"persistent-disk-0": "pd-balanced",
+			"data":              "hyperdisk-balanced",
+			"local-nvme-ssd-0":  "local-ssd",
+		},
+		partitions: func(bool) ([]disk.PartitionStat, error) { return testPartitions, nil },
+		usage: func(path string) (*disk.UsageStat, error) {
+			if path == "/var/lib/mysql" {
+				return nil, errors.New("usage failed")
+			}
+			return &disk.UsageStat{Path: path, Total: 1000, Used: 250, UsedPercent: 25}, nil
+		},
+		readDir: func(name string) ([]os.DirEntry, error) {
+			return fs.ReadDir(links, ".")
+		},
+		evalSymlinks: func(path string) (string, error) {
+			if p, ok := testSymlinks[path]; ok {
+				return p, nil
+			}
+			return path, nil
+		},
+	}
+}
+
+func TestLayouts(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  []DiskLayout
+	}{
+		{
+			name:  "LongestMountPoint",
+			paths: []string{"/var/lib/mysql/"},
+			want: []DiskLayout{{
+				Path:         "/var/lib/mysql/",
+				MountPoint:   "/var/lib/mysql",
+				Filesystem:   "ext4",
+				MountOptions: "rw",
+				Device:       "/dev/nvme0n1",
+				DeviceName:   "local-nvme-ssd-0",
+				DiskType:     "local-ssd",
+			}},
+		},
+		{
+			name:  "SymlinkedDirectory",
+			paths: []string{"/data/pgdata"},
+			want: []DiskLayout{{
+				Path:         "/data/pgdata",
+				MountPoint:   "/var/lib",
+				Filesystem:   "xfs",
+				MountOptions: "rw,noatime",
+				Device:       "/dev/sdb1",
+				DeviceName:   "data",
+				DiskType:     "hyperdisk-balanced",
+				SizeBytes:    1000,
+				UsedBytes:    250,
+				UsedPercent:  25,
+			}},
+		},
+		{
+			name:  "MultipleDirectories",
+			paths: []string{"/u01/oradata", " ", "/var/lib/pgsql"},
+			want: []DiskLayout{
+				{
+					Path:         "/u01/oradata",
+					MountPoint:   "/",
+					Filesystem:   "ext4",
+					MountOptions: "rw,relatime",
+					Device:       "/dev/sda1",
+					DeviceName:   "persistent-disk-0",
+					DiskType:     "pd-balanced",
+					SizeBytes:    1000,
+					UsedBytes:    250,
+					UsedPercent:  25,
+				},
+				{
+					Path:         "/var/lib/pgsql",
+					MountPoint:   "/var/lib",
+					Filesystem:   "xfs",
+					MountOptions: "rw,noatime",
+					Device:       "/dev/sdb1",
+					DeviceName:   "data",
+					DiskType:     "hyperdisk-balanced",
+					SizeBytes:    1000,
+					UsedBytes:    250,
+					UsedPercent:  25,
+				},
+			},
+		},
+		{
+			name:  "ASMDiskGroupIsSkipped",
+			paths: []string{"+DATA/ORCL/DATAFILE"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := testDiskLayoutWriter(&fakeWriter{}).Layouts(context.Background(), tc.paths)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Layouts(%v) returned unexpected diff (-want +got):\n%s", tc.paths, diff)
+			}
+		})
+	}
+}
+
+func TestLayoutsPartitionsError(t *testing.T) {
+	w := testDiskLayoutWriter(&fakeWriter{})
+	w.partitions = func(bool) ([]disk.PartitionStat, error) { return nil, errors.New("partitions failed") }
+	if got := w.Layouts(context.Background(), []string{"/var/lib/mysql"}); got != nil {
+		t.Errorf("Layouts() = %v, want nil", got)
+	}
+}
+
+func TestDiskLayoutWriter(t *testing.T) {
+	layout, err := json.Marshal([]DiskLayout{{
+		Path:         "/var/lib/pgsql",
+		MountPoint:   "/var/lib",
+		Filesystem:   "xfs",
+		MountOptions: "rw,noatime",
+		Device:       "/dev/sdb1",
+		DeviceName:   "data",
+		DiskType:     "hyperdisk-balanced",
+		SizeBytes:    1000,
+		UsedBytes:    250,
+		UsedPercent:  25,
+	}})
+	if err != nil {
+		t.Fatalf("json.Marshal() returned unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  *dwpb.WriteInsightRequest
+		want *dwpb.WriteInsightRequest
+	}{
+		{
+			name: "AddsLayout",
+			req:  insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{DataDirectoriesKey: "/var/lib/pgsql"}),
+			want: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+				DataDirectoriesKey:     "/var/lib/pgsql",
+				DataDirectoryLayoutKey: string(layout),
+			}),
+		},
+		{
+			name: "NoDataDirectories",
+			req:  insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+			want: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+		},
+		{
+			name: "ExistingLayoutIsKept",
+			req: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+				DataDirectoriesKey:     "/var/lib/pgsql",
+				DataDirectoryLayoutKey: "[]",
+			}),
+			want: insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+				DataDirectoriesKey:     "/var/lib/pgsql",
+				DataDirectoryLayoutKey: "[]",
+			}),
+		},
+		{
+			name: "NoMountedDirectories",
+			req:  insightRequest("i1", dwpb.TorsoValidation_ORACLE, map[string]string{DataDirectoriesKey: "+DATA"}),
+			want: insightRequest("i1", dwpb.TorsoValidation_ORACLE, map[string]string{DataDirectoriesKey: "+DATA"}),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := &fakeWriter{}
+			details := make(map[string]string)
+			for k, v := range tc.req.GetInsight().GetTorsoValidation().GetValidationDetails() {
+				details[k] = v
+			}
+
+			if _, err := testDiskLayoutWriter(fw).WriteInsightAndGetResponse("p1", "us-central1", tc.req); err != nil {
+				t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+			}
+			if len(fw.calls) != 1 {
+				t.Fatalf("WriteInsightAndGetResponse() wrote %d insights, want 1", len(fw.calls))
+			}
+			if diff := cmp.Diff(tc.want, fw.calls[0].req, protocmp.Transform()); diff != "" {
+				t.Errorf("WriteInsightAndGetResponse() wrote unexpected diff (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(details, tc.req.GetInsight().GetTorsoValidation().GetValidationDetails()); diff != "" {
+				t.Errorf("WriteInsightAndGetResponse() modified the request (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithinDir(t *testing.T) {
+	tests := []struct {
+		path string
+		dir  string
+		want bool
+	}{
+		{path: "/var/lib/mysql", dir: "/var/lib/mysql", want: true},
+		{path: "/var/lib/mysql/data", dir: "/var/lib", want: true},
+		{path: "/var/lib/mysql", dir: "/", want: true},
+		{path: "/var/library", dir: "/var/lib", want: false},
+		{path: "/var", dir: "/var/lib", want: false},
+		{path: "+DATA", dir: "/", want: false},
+	}
+	for _, tc := range tests {
+		if got := withinDir(tc.path, tc.dir); got != tc.want {
+			t.Errorf("withinDir(%q, %q) = %v, want %v", tc.path, tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestMountRoot(t *testing.T) {
+	drive := "C:"
+	if runtime.GOOS == "windows" {
+		drive = `C:\`
+	}
+	tests := []struct {
+		mountpoint string
+		want       string
+	}{
+		{mountpoint: "/", want: "/"},
+		{mountpoint: "/var/lib/mysql", want: "/var/lib/mysql"},
+		{mountpoint: "C:", want: drive},
+		{mountpoint: "", want: ""},
+	}
+	for _, tc := range tests {
+		if got := mountRoot(tc.mountpoint); got != tc.want {
+			t.Errorf("mountRoot(%q) = %q, want %q", tc.mountpoint, got, tc.want)
+		}
+	}
+}
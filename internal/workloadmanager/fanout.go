@@ -0,0 +1,64 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"sync"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// Destination is an additional Data Warehouse destination to which insights are written.
+type Destination struct {
+	// Name identifies the destination in the logs.
+	Name   string
+	Writer WLMWriter
+}
+
+// FanOutWriter is a WLMWriter which writes every insight to additional destinations alongside
+// the primary writer, e.g. to a staging endpoint while validating a migration.
+//
+// The insight is written to all destinations concurrently. Failed writes to an additional
+// destination are logged and only the response of the primary writer is returned.
+type FanOutWriter struct {
+	writer       WLMWriter
+	destinations []Destination
+}
+
+// NewFanOutWriter returns a FanOutWriter which writes to writer and destinations.
+func NewFanOutWriter(writer WLMWriter, destinations []Destination) *FanOutWriter {
+	return &FanOutWriter{writer: writer, destinations: destinations}
+}
+
+// WriteInsightAndGetResponse writes the insight to all destinations and returns the response of the primary writer.
+func (f *FanOutWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	var wg sync.WaitGroup
+	for _, d := range f.destinations {
+		wg.Add(1)
+		go func(d Destination) {
+			defer wg.Done()
+			if _, err := d.Writer.WriteInsightAndGetResponse(project, location, req); err != nil {
+				log.Logger.Warnw("Could not write insight to additional Data Warehouse destination", "destination", d.Name, "error", err)
+			}
+		}(d)
+	}
+	res, err := f.writer.WriteInsightAndGetResponse(project, location, req)
+	wg.Wait()
+	return res, err
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/protobuf/testing/protocmp"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestFanOutWriter(t *testing.T) {
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})
+	// The insight is written to every destination regardless of the errors of the others.
+	want := []writeCall{{"p1", "us-central1", req}}
+	tests := []struct {
+		name       string
+		primaryErr error
+		stagingErr error
+		wantErr    error
+	}{
+		{
+			name: "AllDestinations",
+		},
+		{
+			name:       "AdditionalDestinationErrorIsIgnored",
+			stagingErr: errors.New("staging unavailable"),
+		},
+		{
+			name:       "PrimaryErrorIsReturned",
+			primaryErr: errors.New("write failed"),
+			wantErr:    cmpopts.AnyError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			primary := &fakeWriter{err: tc.primaryErr}
+			staging := &fakeWriter{err: tc.stagingErr}
+			secondary := &fakeWriter{}
+			w := NewFanOutWriter(primary, []Destination{{Name: "staging", Writer: staging}, {Name: "secondary", Writer: secondary}})
+
+			_, err := w.WriteInsightAndGetResponse("p1", "us-central1", req)
+			if !cmp.Equal(err, tc.wantErr, cmpopts.EquateErrors()) {
+				t.Errorf("WriteInsightAndGetResponse() returned error %v, want %v", err, tc.wantErr)
+			}
+			for name, fw := range map[string]*fakeWriter{"primary": primary, "staging": staging, "secondary": secondary} {
+				if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+					t.Errorf("FanOutWriter wrote unexpected insights to %s (-want +got):\n%s", name, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// exportedInsight is a single line written by the FileWriter.
+type exportedInsight struct {
+	Project  string          `json:"project"`
+	Location string          `json:"location"`
+	Request  json.RawMessage `json:"request"`
+}
+
+// FileWriter is a WLMWriter which appends insights to a local file as JSON lines
+// instead of sending them to Data Warehouse.
+type FileWriter struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileWriter returns a FileWriter which appends insights to path.
+func NewFileWriter(path string) *FileWriter {
+	return &FileWriter{path: path}
+}
+
+// WriteInsightAndGetResponse appends the insight to the export file.
+// A successful write is reported with the status code Data Warehouse returns for a created insight.
+func (f *FileWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	reqJSON, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling WriteInsightRequest: %w", err)
+	}
+	line, err := json.Marshal(exportedInsight{Project: project, Location: location, Request: reqJSON})
+	if err != nil {
+		return nil, fmt.Errorf("marshalling exported insight: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening insight export file %s: %w", f.path, err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return nil, fmt.Errorf("writing insight export file %s: %w", f.path, err)
+	}
+	return &wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: http.StatusCreated}}, nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/testing/protocmp"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestFileWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "insights.jsonl")
+	f := NewFileWriter(path)
+	reqs := []*dwpb.WriteInsightRequest{
+		insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+		insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"b": "2"}),
+	}
+
+	for _, req := range reqs {
+		res, err := f.WriteInsightAndGetResponse("test-project", "us-central1", req)
+		if err != nil {
+			t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+		}
+		if res.HTTPStatusCode != 201 {
+			t.Errorf("WriteInsightAndGetResponse() returned status code %d, want 201", res.HTTPStatusCode)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile(%s) returned unexpected error: %v", path, err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != len(reqs) {
+		t.Fatalf("export file contains %d lines, want %d", len(lines), len(reqs))
+	}
+	for i, line := range lines {
+		var got exportedInsight
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("json.Unmarshal(%q) returned unexpected error: %v", line, err)
+		}
+		if got.Project != "test-project" || got.Location != "us-central1" {
+			t.Errorf("line %d has project %q and location %q, want test-project and us-central1", i, got.Project, got.Location)
+		}
+		gotReq := &dwpb.WriteInsightRequest{}
+		if err := protojson.Unmarshal(got.Request, gotReq); err != nil {
+			t.Fatalf("protojson.Unmarshal(%s) returned unexpected error: %v", got.Request, err)
+		}
+		if diff := cmp.Diff(reqs[i], gotReq, protocmp.Transform()); diff != "" {
+			t.Errorf("line %d returned unexpected diff (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestFileWriterError(t *testing.T) {
+	f := NewFileWriter(filepath.Join(t.TempDir(), "missing", "insights.jsonl"))
+	if _, err := f.WriteInsightAndGetResponse("test-project", "us-central1", DefaultWriteInsightRequest); err == nil {
+		t.Error("WriteInsightAndGetResponse() returned nil error for a file in a missing directory, want error")
+	}
+}
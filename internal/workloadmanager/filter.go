@@ -0,0 +1,96 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// Filter removes the excluded validation detail keys and workload types from Data Warehouse insights.
+// A nil Filter excludes nothing.
+type Filter struct {
+	keys          map[string]bool
+	workloadTypes map[string]bool
+}
+
+// NewFilter returns the filter of the configuration, or nil if nothing is excluded.
+func NewFilter(config *cpb.DataWarehouseFilter) *Filter {
+	if len(config.GetExcludedKeys()) == 0 && len(config.GetExcludedWorkloadTypes()) == 0 {
+		return nil
+	}
+	f := &Filter{keys: make(map[string]bool), workloadTypes: make(map[string]bool)}
+	for _, k := range config.GetExcludedKeys() {
+		f.keys[k] = true
+	}
+	for _, wt := range config.GetExcludedWorkloadTypes() {
+		f.workloadTypes[strings.ToUpper(wt)] = true
+	}
+	return f
+}
+
+// ExcludesWorkloadType reports whether insights of the Data Warehouse workload type must not be sent.
+func (f *Filter) ExcludesWorkloadType(workloadType string) bool {
+	return f != nil && f.workloadTypes[strings.ToUpper(workloadType)]
+}
+
+// Details returns the validation details without the excluded keys.
+// details is returned as is if no key is excluded.
+func (f *Filter) Details(details map[string]string) map[string]string {
+	if f == nil || len(f.keys) == 0 {
+		return details
+	}
+	filtered := make(map[string]string, len(details))
+	for k, v := range details {
+		if !f.keys[k] {
+			filtered[k] = v
+		}
+	}
+	return filtered
+}
+
+// FilterWriter is a WLMWriter which applies a Filter to every insight before writing it.
+type FilterWriter struct {
+	writer WLMWriter
+	filter *Filter
+}
+
+// NewFilterWriter returns a FilterWriter which writes the insights allowed by filter to writer.
+func NewFilterWriter(writer WLMWriter, filter *Filter) *FilterWriter {
+	return &FilterWriter{writer: writer, filter: filter}
+}
+
+// WriteInsightAndGetResponse filters the insight and writes it.
+// Insights of excluded workload types are dropped and an empty response is returned.
+func (f *FilterWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	tv := req.GetInsight().GetTorsoValidation()
+	if len(tv.GetValidationDetails()) == 0 {
+		return f.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	if f.filter.ExcludesWorkloadType(tv.GetWorkloadType().String()) {
+		log.Logger.Debugw("Workload type is excluded from Data Warehouse, the insight is not sent", "workload_type", tv.GetWorkloadType())
+		return &wlm.WriteInsightResponse{}, nil
+	}
+	req = proto.Clone(req).(*dwpb.WriteInsightRequest)
+	req.GetInsight().GetTorsoValidation().ValidationDetails = f.filter.Details(tv.GetValidationDetails())
+	return f.writer.WriteInsightAndGetResponse(project, location, req)
+}
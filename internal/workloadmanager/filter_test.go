@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestNewFilter(t *testing.T) {
+	if got := NewFilter(&cpb.DataWarehouseFilter{}); got != nil {
+		t.Errorf("NewFilter() with no exclusions = %v, want nil", got)
+	}
+	f := NewFilter(&cpb.DataWarehouseFilter{ExcludedWorkloadTypes: []string{"mysql"}})
+	if !f.ExcludesWorkloadType("MYSQL") {
+		t.Error("ExcludesWorkloadType(MYSQL) = false, want true")
+	}
+	if f.ExcludesWorkloadType("REDIS") {
+		t.Error("ExcludesWorkloadType(REDIS) = true, want false")
+	}
+}
+
+func TestFilterWriter(t *testing.T) {
+	filter := NewFilter(&cpb.DataWarehouseFilter{
+		ExcludedKeys:          []string{"replication_zones", "host_machine_type"},
+		ExcludedWorkloadTypes: []string{"REDIS"},
+	})
+	tests := []struct {
+		name string
+		req  *dwpb.WriteInsightRequest
+		want []writeCall
+	}{
+		{
+			name: "ExcludedKeysAreRemoved",
+			req:  insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", "replication_zones": "us-central1-a", "host_machine_type": "n2-standard-4"}),
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+			},
+		},
+		{
+			name: "ExcludedWorkloadTypeIsDropped",
+			req:  insightRequest("i1", dwpb.TorsoValidation_REDIS, map[string]string{"a": "1"}),
+		},
+		{
+			name: "InsightWithoutDetailsIsSent",
+			req:  insightRequest("i1", dwpb.TorsoValidation_REDIS, nil),
+			want: []writeCall{
+				{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_REDIS, nil)},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := &fakeWriter{}
+			f := NewFilterWriter(fw, filter)
+			res, err := f.WriteInsightAndGetResponse("p1", "us-central1", tc.req)
+			if err != nil {
+				t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+			}
+			if res == nil {
+				t.Error("WriteInsightAndGetResponse() returned nil response, want non-nil")
+			}
+			if diff := cmp.Diff(tc.want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+				t.Errorf("FilterWriter wrote unexpected insights (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
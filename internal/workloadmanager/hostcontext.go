@@ -0,0 +1,175 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/proto"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// Validation detail keys of the host context.
+const (
+	HostMachineTypeKey    = "host_machine_type"
+	HostVCPUCountKey      = "host_vcpu_count"
+	HostMemorySizeMBKey   = "host_memory_size_mb"
+	HostDiskTypesKey      = "host_disk_types"
+	HostDiskInterfacesKey = "host_disk_interfaces"
+
+	// localSSDDiskType is reported for local SSDs, which are not backed by a persistent disk.
+	localSSDDiskType = "local-ssd"
+)
+
+// HostContext describes the resources of the instance running the workloads.
+type HostContext struct {
+	MachineType    string
+	VCPUCount      int64
+	MemorySizeMB   int64
+	DiskTypes      []string
+	DiskInterfaces []string
+	// DeviceDiskTypes maps the device names of the attached disks to their disk types.
+	// It is used to resolve the disk type of data directories and is not reported itself.
+	DeviceDiskTypes map[string]string
+}
+
+// gceInterface is the subset of the GCE client used to collect the host context.
+type gceInterface interface {
+	GetInstance(project, zone, instance string) (*compute.Instance, error)
+	GetDisk(project, zone, disk string) (*compute.Disk, error)
+}
+
+// CollectHostContext returns the host context of the instance described by cp.
+// Disk types are best effort, the machine type, vCPU count and memory size are always taken from cp.
+func CollectHostContext(ctx context.Context, gceService gceInterface, cp *cpb.CloudProperties) HostContext {
+	hc := HostContext{
+		MachineType:  lastSegment(cp.GetMachineType()),
+		VCPUCount:    cp.GetVcpuCount(),
+		MemorySizeMB: cp.GetMemorySizeMb(),
+	}
+	instance, err := gceService.GetInstance(cp.GetProjectId(), cp.GetZone(), cp.GetInstanceName())
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Unable to get the instance, disk types are not included in insights", "error", err)
+		return hc
+	}
+
+	diskTypes := make(map[string]bool)
+	diskInterfaces := make(map[string]bool)
+	deviceDiskTypes := make(map[string]string)
+	for _, d := range instance.Disks {
+		if d.Interface != "" {
+			diskInterfaces[d.Interface] = true
+		}
+		if d.Type == "SCRATCH" {
+			diskTypes[localSSDDiskType] = true
+			if d.DeviceName != "" {
+				deviceDiskTypes[d.DeviceName] = localSSDDiskType
+			}
+			continue
+		}
+		disk, err := gceService.GetDisk(cp.GetProjectId(), cp.GetZone(), lastSegment(d.Source))
+		if err != nil {
+			log.CtxLogger(ctx).Debugw("Unable to get the disk type", "disk", d.Source, "error", err)
+			continue
+		}
+		diskTypes[lastSegment(disk.Type)] = true
+		if d.DeviceName != "" {
+			deviceDiskTypes[d.DeviceName] = lastSegment(disk.Type)
+		}
+	}
+	hc.DiskTypes = sortedSet(diskTypes)
+	hc.DiskInterfaces = sortedSet(diskInterfaces)
+	if len(deviceDiskTypes) > 0 {
+		hc.DeviceDiskTypes = deviceDiskTypes
+	}
+	return hc
+}
+
+// Details returns the host context as validation details.
+func (hc HostContext) Details() map[string]string {
+	details := make(map[string]string)
+	if hc.MachineType != "" {
+		details[HostMachineTypeKey] = hc.MachineType
+	}
+	if hc.VCPUCount > 0 {
+		details[HostVCPUCountKey] = strconv.FormatInt(hc.VCPUCount, 10)
+	}
+	if hc.MemorySizeMB > 0 {
+		details[HostMemorySizeMBKey] = strconv.FormatInt(hc.MemorySizeMB, 10)
+	}
+	if len(hc.DiskTypes) > 0 {
+		details[HostDiskTypesKey] = strings.Join(hc.DiskTypes, ",")
+	}
+	if len(hc.DiskInterfaces) > 0 {
+		details[HostDiskInterfacesKey] = strings.Join(hc.DiskInterfaces, ",")
+	}
+	return details
+}
+
+// HostContextWriter is a WLMWriter which adds the host context to the validation details of every insight.
+// Validation details set by the workload take precedence over the host context.
+type HostContextWriter struct {
+	writer  WLMWriter
+	details map[string]string
+}
+
+// NewHostContextWriter returns a HostContextWriter which adds hc to insights before writing them to writer.
+func NewHostContextWriter(writer WLMWriter, hc HostContext) *HostContextWriter {
+	return &HostContextWriter{writer: writer, details: hc.Details()}
+}
+
+// WriteInsightAndGetResponse adds the host context to the insight and writes it.
+func (h *HostContextWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	if req.GetInsight().GetTorsoValidation() == nil || len(h.details) == 0 {
+		return h.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	req = proto.Clone(req).(*dwpb.WriteInsightRequest)
+	tv := req.GetInsight().GetTorsoValidation()
+	if tv.ValidationDetails == nil {
+		tv.ValidationDetails = make(map[string]string)
+	}
+	for k, v := range h.details {
+		if _, ok := tv.ValidationDetails[k]; !ok {
+			tv.ValidationDetails[k] = v
+		}
+	}
+	return h.writer.WriteInsightAndGetResponse(project, location, req)
+}
+
+// lastSegment returns the resource name at the end of a GCE resource URL.
+func lastSegment(url string) string {
+	return url[strings.LastIndex(url, "/")+1:]
+}
+
+func sortedSet(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
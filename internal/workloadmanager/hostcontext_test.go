@@ -0,0 +1,167 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+type fakeGCE struct {
+	instance    *compute.Instance
+	instanceErr error
+	disks       map[string]*compute.Disk
+}
+
+func (f *fakeGCE) GetInstance(project, zone, instance string) (*compute.Instance, error) {
+	return f.instance, f.instanceErr
+}
+
+func (f *fakeGCE) GetDisk(project, zone, disk string) (*compute.Disk, error) {
+	d, ok := f.disks[disk]
+	if !ok {
+		return nil, errors.New("disk not found")
+	}
+	return d, nil
+}
+
+func TestCollectHostContext(t *testing.T) {
+	cp := &cpb.CloudProperties{
+		ProjectId:    "test-project",
+		Zone:         "us-central1-a",
+		InstanceName: "test-instance",
+		MachineType:  "projects/123/machineTypes/n2-standard-4",
+		VcpuCount:    4,
+		MemorySizeMb: 16384,
+	}
+	tests := []struct {
+		name string
+		gce  *fakeGCE
+		want HostContext
+	}{
+		{
+			name: "AllDisks",
+			gce: &fakeGCE{
+				instance: &compute.Instance{Disks: []*compute.AttachedDisk{
+					{Source: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/disks/boot", DeviceName: "persistent-disk-0", Interface: "SCSI", Type: "PERSISTENT"},
+					{Source: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/disks/data", DeviceName: "data", Interface: "NVME", Type: "PERSISTENT"},
+					{DeviceName: "local-nvme-ssd-0", Interface: "NVME", Type: "SCRATCH"},
+				}},
+				disks: map[string]*compute.Disk{
+					"boot": {Type: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/diskTypes/pd-balanced"},
+					"data": {Type: "https://www.googleapis.com/compute/v1/projects/test-project/zones/us-central1-a/diskTypes/hyperdisk-balanced"},
+				},
+			},
+			want: HostContext{
+				MachineType:    "n2-standard-4",
+				VCPUCount:      4,
+				MemorySizeMB:   16384,
+				DiskTypes:      []string{"hyperdisk-balanced", "local-ssd", "pd-balanced"},
+				DiskInterfaces: []string{"NVME", "SCSI"},
+				DeviceDiskTypes: map[string]string{
+					"persistent-disk-0": "pd-balanced",
+					"data":              "hyperdisk-balanced",
+					"local-nvme-ssd-0":  "local-ssd",
+				},
+			},
+		},
+		{
+			name: "MissingDiskIsSkipped",
+			gce: &fakeGCE{
+				instance: &compute.Instance{Disks: []*compute.AttachedDisk{
+					{Source: "projects/test-project/zones/us-central1-a/disks/boot", Interface: "SCSI", Type: "PERSISTENT"},
+					{Source: "projects/test-project/zones/us-central1-a/disks/missing", Interface: "SCSI", Type: "PERSISTENT"},
+				}},
+				disks: map[string]*compute.Disk{
+					"boot": {Type: "projects/test-project/zones/us-central1-a/diskTypes/pd-ssd"},
+				},
+			},
+			want: HostContext{
+				MachineType:    "n2-standard-4",
+				VCPUCount:      4,
+				MemorySizeMB:   16384,
+				DiskTypes:      []string{"pd-ssd"},
+				DiskInterfaces: []string{"SCSI"},
+			},
+		},
+		{
+			name: "InstanceError",
+			gce:  &fakeGCE{instanceErr: errors.New("permission denied")},
+			want: HostContext{
+				MachineType:  "n2-standard-4",
+				VCPUCount:    4,
+				MemorySizeMB: 16384,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CollectHostContext(context.Background(), tc.gce, cp)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CollectHostContext() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHostContextWriter(t *testing.T) {
+	hc := HostContext{
+		MachineType:    "n2-standard-4",
+		VCPUCount:      4,
+		MemorySizeMB:   16384,
+		DiskTypes:      []string{"hyperdisk-balanced", "pd-ssd"},
+		DiskInterfaces: []string{"NVME"},
+	}
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1", HostVCPUCountKey: "8"})
+	want := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+		"a":                   "1",
+		HostMachineTypeKey:    "n2-standard-4",
+		HostVCPUCountKey:      "8",
+		HostMemorySizeMBKey:   "16384",
+		HostDiskTypesKey:      "hyperdisk-balanced,pd-ssd",
+		HostDiskInterfacesKey: "NVME",
+	})
+
+	fw := &fakeWriter{}
+	w := NewHostContextWriter(fw, hc)
+	if _, err := w.WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	if len(fw.calls) != 1 {
+		t.Fatalf("WriteInsightAndGetResponse() wrote %d insights, want 1", len(fw.calls))
+	}
+	if diff := cmp.Diff(want, fw.calls[0].req, protocmp.Transform()); diff != "" {
+		t.Errorf("WriteInsightAndGetResponse() wrote unexpected insight (-want +got):\n%s", diff)
+	}
+	if _, ok := req.GetInsight().GetTorsoValidation().GetValidationDetails()[HostMachineTypeKey]; ok {
+		t.Errorf("WriteInsightAndGetResponse() modified the request of the caller")
+	}
+}
+
+func TestHostContextDetailsEmpty(t *testing.T) {
+	if got := (HostContext{}).Details(); len(got) != 0 {
+		t.Errorf("HostContext{}.Details() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,390 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/hostinfo"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/packages"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/pacemaker"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// Validation detail keys of the host settings.
+const (
+	HostHugePagesTotalKey       = "host_hugepages_total"
+	HostHugePagesFreeKey        = "host_hugepages_free"
+	HostHugePageSizeKBKey       = "host_hugepage_size_kb"
+	HostTransparentHugePagesKey = "host_transparent_hugepages"
+	HostSwappinessKey           = "host_vm_swappiness"
+	HostShmMaxKey               = "host_kernel_shmmax"
+	HostShmAllKey               = "host_kernel_shmall"
+	HostMemTotalKBKey           = "host_mem_total_kb"
+	HostSwapTotalKBKey          = "host_swap_total_kb"
+	HostSwapFreeKBKey           = "host_swap_free_kb"
+	HostNUMANodeCountKey        = "host_numa_node_count"
+	// HostNUMANodeMemoryKBKey holds the memory of each NUMA node, e.g. "0=8190800,1=8192000".
+	HostNUMANodeMemoryKBKey = "host_numa_node_memory_kb"
+	// DBUserLimitKeyPrefix is followed by the name of the limit, e.g. "db_user_limit_nofile".
+	DBUserLimitKeyPrefix = "db_user_limit_"
+)
+
+// processLimits maps the limits in /proc/<pid>/limits to the names used by ulimit and limits.conf.
+var processLimits = map[string]string{
+	"Max open files":    "nofile",
+	"Max processes":     "nproc",
+	"Max locked memory": "memlock",
+	"Max stack size":    "stack",
+}
+
+// hostSettingsWorkloads are the workloads whose insights include the host settings.
+var hostSettingsWorkloads = []string{
+	servicecommunication.Oracle,
+	servicecommunication.MySQL,
+	servicecommunication.Postgres,
+}
+
+// ReadFile abstracts reading files for testability.
+type ReadFile func(string) ([]byte, error)
+
+// HostSettings holds the operating system settings of a database host.
+// Settings which could not be read are left empty.
+type HostSettings struct {
+	HugePagesTotal       string
+	HugePagesFree        string
+	HugePageSizeKB       string
+	TransparentHugePages string
+	Swappiness           string
+	ShmMax               string
+	ShmAll               string
+	MemTotalKB           string
+	SwapTotalKB          string
+	SwapFreeKB           string
+	// NUMANodes holds the IDs of the online NUMA nodes in ascending order.
+	NUMANodes []string
+	// NUMANodeMemoryKB holds the total memory of each NUMA node keyed by node ID.
+	NUMANodeMemoryKB map[string]string
+	// Limits holds the soft limits of the database process keyed by name, e.g. "nofile".
+	Limits map[string]string
+}
+
+// CollectHostSettings returns the settings of the host and the limits of the database process pid.
+// The limits are not collected if pid is 0. Nothing is collected on hosts without /proc.
+func CollectHostSettings(ctx context.Context, readFile ReadFile, pid int32) HostSettings {
+	var hs HostSettings
+	if !capabilities.Host().Has(capabilities.Procfs) {
+		return hs
+	}
+	if meminfo, err := readFile("/proc/meminfo"); err == nil {
+		hs.HugePagesTotal = hostinfo.MeminfoValue(meminfo, "HugePages_Total")
+		hs.HugePagesFree = hostinfo.MeminfoValue(meminfo, "HugePages_Free")
+		hs.HugePageSizeKB = hostinfo.MeminfoValue(meminfo, "Hugepagesize")
+		hs.MemTotalKB = hostinfo.MeminfoValue(meminfo, "MemTotal")
+		hs.SwapTotalKB = hostinfo.MeminfoValue(meminfo, "SwapTotal")
+		hs.SwapFreeKB = hostinfo.MeminfoValue(meminfo, "SwapFree")
+	} else {
+		log.CtxLogger(ctx).Debugw("Unable to read /proc/meminfo", "error", err)
+	}
+	if thp, err := readFile("/sys/kernel/mm/transparent_hugepage/enabled"); err == nil {
+		hs.TransparentHugePages = selectedOption(string(thp))
+	}
+	hs.Swappiness = readSetting(readFile, "/proc/sys/vm/swappiness")
+	hs.ShmMax = readSetting(readFile, "/proc/sys/kernel/shmmax")
+	hs.ShmAll = readSetting(readFile, "/proc/sys/kernel/shmall")
+	hs.NUMANodes, hs.NUMANodeMemoryKB = numaTopology(ctx, readFile)
+	if pid != 0 {
+		path := fmt.Sprintf("/proc/%d/limits", pid)
+		if limits, err := readFile(path); err == nil {
+			hs.Limits = parseLimits(limits)
+		} else {
+			log.CtxLogger(ctx).Debugw("Unable to read the limits of the database process", "path", path, "error", err)
+		}
+	}
+	return hs
+}
+
+// Details returns the host settings as validation details.
+func (hs HostSettings) Details() map[string]string {
+	details := make(map[string]string)
+	for k, v := range map[string]string{
+		HostHugePagesTotalKey:       hs.HugePagesTotal,
+		HostHugePagesFreeKey:        hs.HugePagesFree,
+		HostHugePageSizeKBKey:       hs.HugePageSizeKB,
+		HostTransparentHugePagesKey: hs.TransparentHugePages,
+		HostSwappinessKey:           hs.Swappiness,
+		HostShmMaxKey:               hs.ShmMax,
+		HostShmAllKey:               hs.ShmAll,
+		HostMemTotalKBKey:           hs.MemTotalKB,
+		HostSwapTotalKBKey:          hs.SwapTotalKB,
+		HostSwapFreeKBKey:           hs.SwapFreeKB,
+	} {
+		if v != "" {
+			details[k] = v
+		}
+	}
+	if len(hs.NUMANodes) > 0 {
+		details[HostNUMANodeCountKey] = strconv.Itoa(len(hs.NUMANodes))
+		var memory []string
+		for _, node := range hs.NUMANodes {
+			if v, ok := hs.NUMANodeMemoryKB[node]; ok {
+				memory = append(memory, fmt.Sprintf("%s=%s", node, v))
+			}
+		}
+		if len(memory) > 0 {
+			details[HostNUMANodeMemoryKBKey] = strings.Join(memory, ",")
+		}
+	}
+	for name, v := range hs.Limits {
+		details[DBUserLimitKeyPrefix+name] = v
+	}
+	return details
+}
+
+// numaTopology returns the IDs of the online NUMA nodes and the total memory of each node.
+// Kernels built without NUMA support have no node directory and no nodes are returned.
+func numaTopology(ctx context.Context, readFile ReadFile) ([]string, map[string]string) {
+	online, err := readFile("/sys/devices/system/node/online")
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to read the online NUMA nodes", "error", err)
+		return nil, nil
+	}
+	nodes := parseNodeList(strings.TrimSpace(string(online)))
+	memory := make(map[string]string)
+	for _, node := range nodes {
+		meminfo, err := readFile(fmt.Sprintf("/sys/devices/system/node/node%s/meminfo", node))
+		if err != nil {
+			continue
+		}
+		// Lines of the per-node meminfo are prefixed with the node, e.g. "Node 0 MemTotal: 8190800 kB".
+		if v := hostinfo.MeminfoValue(meminfo, fmt.Sprintf("Node %s MemTotal", node)); v != "" {
+			memory[node] = v
+		}
+	}
+	return nodes, memory
+}
+
+// parseNodeList expands a sysfs node list, e.g. "0-2,4", into the node IDs. Invalid ranges are skipped.
+func parseNodeList(list string) []string {
+	var nodes []string
+	for _, r := range strings.Split(list, ",") {
+		first, last, isRange := strings.Cut(r, "-")
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			continue
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.Atoi(last); err != nil {
+				continue
+			}
+		}
+		for n := start; n <= end; n++ {
+			nodes = append(nodes, strconv.Itoa(n))
+		}
+	}
+	return nodes
+}
+
+// selectedOption returns the selected option of a sysfs setting, e.g. "madvise" for "always [madvise] never".
+func selectedOption(setting string) string {
+	for _, option := range strings.Fields(setting) {
+		if strings.HasPrefix(option, "[") && strings.HasSuffix(option, "]") {
+			return strings.Trim(option, "[]")
+		}
+	}
+	return strings.TrimSpace(setting)
+}
+
+// readSetting returns the content of a single value sysctl file, or an empty string if it cannot be read.
+func readSetting(readFile ReadFile, path string) string {
+	content, err := readFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// parseLimits returns the soft limits of interest from the content of /proc/<pid>/limits.
+func parseLimits(content []byte) map[string]string {
+	limits := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(content)))
+	for scanner.Scan() {
+		line := scanner.Text()
+		for prefix, name := range processLimits {
+			if !strings.HasPrefix(line, prefix) {
+				continue
+			}
+			if fields := strings.Fields(strings.TrimPrefix(line, prefix)); len(fields) > 0 {
+				limits[name] = fields[0]
+			}
+		}
+	}
+	return limits
+}
+
+// HostSettingsContext tracks the database processes found by discovery, so the settings of the
+// host and the limits of the database process can be added to the insights of each workload.
+// If the host belongs to a Pacemaker cluster, the state of the cluster is added as well, and on
+// Linux the installed database packages and monitoring agents.
+type HostSettingsContext struct {
+	signatures *servicecommunication.Registry
+	readFile   ReadFile
+	execute    commandlineexecutor.Execute
+
+	mu        sync.Mutex
+	pids      map[string]int32
+	pacemaker bool
+	cluster   map[string]string
+	packages  map[string]string
+}
+
+// NewHostSettingsContext returns a HostSettingsContext which identifies the processes of the workloads with signatures.
+func NewHostSettingsContext(signatures *servicecommunication.Registry) *HostSettingsContext {
+	return &HostSettingsContext{signatures: signatures, readFile: os.ReadFile, execute: commandlineexecutor.ExecuteCommand}
+}
+
+// Listen updates the database processes with the discovery results received on ch until ctx is done.
+func (h *HostSettingsContext) Listen(ctx context.Context, ch <-chan *servicecommunication.Message) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-ch:
+			if msg.Origin == servicecommunication.Discovery {
+				h.Update(msg.DiscoveryResult)
+				h.refreshCluster(ctx)
+				if !msg.DiscoveryResult.Unchanged {
+					h.refreshPackages(ctx)
+				}
+			}
+		}
+	}
+}
+
+// Update records the lowest PID of each workload from a discovery result. Heartbeats are ignored.
+// The lowest PID is usually the parent of the other database processes, e.g. the postmaster.
+func (h *HostSettingsContext) Update(result servicecommunication.DiscoveryResult) {
+	if result.Unchanged {
+		return
+	}
+	pids := make(map[string]int32)
+	for _, workload := range hostSettingsWorkloads {
+		for _, p := range h.signatures.Filter(workload, result.Processes) {
+			if pid, ok := pids[workload]; !ok || p.Pid() < pid {
+				pids[workload] = p.Pid()
+			}
+		}
+	}
+	h.mu.Lock()
+	h.pids = pids
+	h.pacemaker = len(h.signatures.Filter(servicecommunication.Pacemaker, result.Processes)) > 0
+	h.mu.Unlock()
+}
+
+// refreshCluster reads the state of the Pacemaker cluster while pacemakerd is running.
+// The cluster state changes without the processes changing, so it is also refreshed on heartbeats.
+func (h *HostSettingsContext) refreshCluster(ctx context.Context) {
+	h.mu.Lock()
+	running := h.pacemaker
+	h.mu.Unlock()
+	var cluster map[string]string
+	if running {
+		var err error
+		if cluster, err = pacemaker.Status(ctx, h.execute); err != nil {
+			log.CtxLogger(ctx).Debugw("Unable to read the Pacemaker cluster state", "error", err)
+		}
+	}
+	h.mu.Lock()
+	h.cluster = cluster
+	h.mu.Unlock()
+}
+
+// refreshPackages reads the installed database packages and monitoring agents.
+// Packages are usually installed or upgraded along with a restart of the database, so they are
+// only read again when the processes change.
+func (h *HostSettingsContext) refreshPackages(ctx context.Context) {
+	if !capabilities.Host().IsLinux() {
+		return
+	}
+	inventory, err := packages.Inventory(ctx, h.execute)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Unable to read the installed packages", "error", err)
+	}
+	h.mu.Lock()
+	h.packages = inventory
+	h.mu.Unlock()
+}
+
+// Writer returns a WLMWriter which adds the host settings and the limits of the database process of
+// workload to every insight before writing it to writer.
+func (h *HostSettingsContext) Writer(writer WLMWriter, workload string) *HostSettingsWriter {
+	return &HostSettingsWriter{writer: writer, context: h, workload: workload}
+}
+
+// HostSettingsWriter is a WLMWriter which adds the host settings to the validation details of every
+// insight of a workload. Validation details set by the workload take precedence over the host settings.
+type HostSettingsWriter struct {
+	writer   WLMWriter
+	context  *HostSettingsContext
+	workload string
+}
+
+// WriteInsightAndGetResponse adds the current host settings to the insight and writes it.
+func (w *HostSettingsWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	if req.GetInsight().GetTorsoValidation() == nil {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	w.context.mu.Lock()
+	pid := w.context.pids[w.workload]
+	cluster := w.context.cluster
+	inventory := w.context.packages
+	w.context.mu.Unlock()
+	details := CollectHostSettings(context.Background(), w.context.readFile, pid).Details()
+	for k, v := range cluster {
+		details[k] = v
+	}
+	for k, v := range inventory {
+		details[k] = v
+	}
+	if len(details) == 0 {
+		return w.writer.WriteInsightAndGetResponse(project, location, req)
+	}
+	req = proto.Clone(req).(*dwpb.WriteInsightRequest)
+	tv := req.GetInsight().GetTorsoValidation()
+	if tv.ValidationDetails == nil {
+		tv.ValidationDetails = make(map[string]string)
+	}
+	for k, v := range details {
+		if _, ok := tv.ValidationDetails[k]; !ok {
+			tv.ValidationDetails[k] = v
+		}
+	}
+	return w.writer.WriteInsightAndGetResponse(project, location, req)
+}
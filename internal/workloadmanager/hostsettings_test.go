@@ -0,0 +1,330 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/packages"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/pacemaker"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/commandlineexecutor"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+const (
+	testMeminfo = `MemTotal:       16381600 kB
+MemFree:         1021812 kB
+SwapTotal:       2097148 kB
+SwapFree:        2097148 kB
+HugePages_Total:    2048
+HugePages_Free:      512
+HugePages_Rsvd:        0
+Hugepagesize:       2048 kB
+`
+	testLimits = `Limit                     Soft Limit           Hard Limit           Units
+Max cpu time              unlimited            unlimited            seconds
+Max stack size            10485760             33554432             bytes
+Max processes             16384                16384                processes
+Max open files            65536                65536                files
+Max locked memory         unlimited            unlimited            bytes
+`
+)
+
+// fakeReadFile serves the given files and fails to read any other file.
+func fakeReadFile(files map[string]string) ReadFile {
+	return func(path string) ([]byte, error) {
+		content, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return []byte(content), nil
+	}
+}
+
+var testHostFiles = map[string]string{
+	"/proc/meminfo": testMeminfo,
+	"/sys/kernel/mm/transparent_hugepage/enabled": "always madvise [never]\n",
+	"/proc/sys/vm/swappiness":                     "10\n",
+	"/proc/sys/kernel/shmmax":                     "18446744073692774399\n",
+	"/proc/sys/kernel/shmall":                     "18446744073692774399\n",
+	"/proc/123/limits":                            testLimits,
+	"/sys/devices/system/node/online":             "0-1\n",
+	"/sys/devices/system/node/node0/meminfo":      "Node 0 MemTotal:        8190800 kB\nNode 0 MemFree:          510906 kB\n",
+	"/sys/devices/system/node/node1/meminfo":      "Node 1 MemTotal:        8190800 kB\nNode 1 MemFree:          510906 kB\n",
+}
+
+func TestCollectHostSettings(t *testing.T) {
+	hostSettings := HostSettings{
+		HugePagesTotal:       "2048",
+		HugePagesFree:        "512",
+		HugePageSizeKB:       "2048",
+		TransparentHugePages: "never",
+		Swappiness:           "10",
+		ShmMax:               "18446744073692774399",
+		ShmAll:               "18446744073692774399",
+		MemTotalKB:           "16381600",
+		SwapTotalKB:          "2097148",
+		SwapFreeKB:           "2097148",
+		NUMANodes:            []string{"0", "1"},
+		NUMANodeMemoryKB:     map[string]string{"0": "8190800", "1": "8190800"},
+	}
+	withLimits := hostSettings
+	withLimits.Limits = map[string]string{"nofile": "65536", "nproc": "16384", "memlock": "unlimited", "stack": "10485760"}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+		pid   int32
+		want  HostSettings
+	}{
+		{
+			name:  "WithProcess",
+			files: testHostFiles,
+			pid:   123,
+			want:  withLimits,
+		},
+		{
+			name:  "WithoutProcess",
+			files: testHostFiles,
+			want:  hostSettings,
+		},
+		{
+			name:  "ProcessExited",
+			files: testHostFiles,
+			pid:   456,
+			want:  hostSettings,
+		},
+		{
+			name: "NoFiles",
+			pid:  123,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := CollectHostSettings(context.Background(), fakeReadFile(tc.files), tc.pid)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("CollectHostSettings() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHostSettingsDetails(t *testing.T) {
+	hs := HostSettings{
+		HugePagesTotal:       "0",
+		TransparentHugePages: "madvise",
+		Limits:               map[string]string{"nofile": "1024"},
+	}
+	want := map[string]string{
+		HostHugePagesTotalKey:           "0",
+		HostTransparentHugePagesKey:     "madvise",
+		DBUserLimitKeyPrefix + "nofile": "1024",
+	}
+	if diff := cmp.Diff(want, hs.Details()); diff != "" {
+		t.Errorf("Details() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestHostSettingsDetailsNUMA(t *testing.T) {
+	hs := HostSettings{
+		SwapTotalKB:      "0",
+		NUMANodes:        []string{"0", "1", "2"},
+		NUMANodeMemoryKB: map[string]string{"0": "8190800", "2": "8192000"},
+	}
+	want := map[string]string{
+		HostSwapTotalKBKey:      "0",
+		HostNUMANodeCountKey:    "3",
+		HostNUMANodeMemoryKBKey: "0=8190800,2=8192000",
+	}
+	if diff := cmp.Diff(want, hs.Details()); diff != "" {
+		t.Errorf("Details() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseNodeList(t *testing.T) {
+	tests := []struct {
+		list string
+		want []string
+	}{
+		{list: "0", want: []string{"0"}},
+		{list: "0-3", want: []string{"0", "1", "2", "3"}},
+		{list: "0,2-3,10", want: []string{"0", "2", "3", "10"}},
+		{list: "0,x-1,2", want: []string{"0", "2"}},
+		{list: ""},
+	}
+	for _, tc := range tests {
+		if diff := cmp.Diff(tc.want, parseNodeList(tc.list)); diff != "" {
+			t.Errorf("parseNodeList(%q) returned unexpected diff (-want +got):\n%s", tc.list, diff)
+		}
+	}
+}
+
+func TestSelectedOption(t *testing.T) {
+	tests := []struct {
+		setting string
+		want    string
+	}{
+		{setting: "[always] madvise never\n", want: "always"},
+		{setting: "always [madvise] never", want: "madvise"},
+		{setting: "never\n", want: "never"},
+	}
+	for _, tc := range tests {
+		if got := selectedOption(tc.setting); got != tc.want {
+			t.Errorf("selectedOption(%q) = %q, want %q", tc.setting, got, tc.want)
+		}
+	}
+}
+
+func TestHostSettingsWriter(t *testing.T) {
+	fw := &fakeWriter{}
+	h := NewHostSettingsContext(servicecommunication.DefaultRegistry())
+	h.readFile = fakeReadFile(map[string]string{
+		"/proc/sys/vm/swappiness": "60\n",
+		"/proc/123/limits":        testLimits,
+		"/proc/456/limits":        "Max open files            1024                 1024                 files\n",
+	})
+	h.Update(servicecommunication.DiscoveryResult{
+		Processes: []servicecommunication.ProcessWrapper{
+			processStub{pid: 456, name: "mysqld"},
+			processStub{pid: 123, name: "mysqld"},
+		},
+	})
+	// Heartbeats keep the processes of the last full result.
+	h.Update(servicecommunication.DiscoveryResult{Unchanged: true})
+
+	mysqlReq := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{HostSwappinessKey: "set-by-workload"})
+	if _, err := h.Writer(fw, servicecommunication.MySQL).WriteInsightAndGetResponse("p1", "us-central1", mysqlReq); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	// Postgres is not running, only the host settings are added.
+	postgresReq := insightRequest("i1", dwpb.TorsoValidation_WORKLOAD_TYPE_UNSPECIFIED, map[string]string{"a": "1"})
+	if _, err := h.Writer(fw, servicecommunication.Postgres).WriteInsightAndGetResponse("p1", "us-central1", postgresReq); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+
+	want := []writeCall{
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+			HostSwappinessKey:                "set-by-workload",
+			DBUserLimitKeyPrefix + "nofile":  "65536",
+			DBUserLimitKeyPrefix + "nproc":   "16384",
+			DBUserLimitKeyPrefix + "memlock": "unlimited",
+			DBUserLimitKeyPrefix + "stack":   "10485760",
+		})},
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_WORKLOAD_TYPE_UNSPECIFIED, map[string]string{
+			"a":               "1",
+			HostSwappinessKey: "60",
+		})},
+	}
+	if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+		t.Errorf("WriteInsightAndGetResponse() wrote unexpected insights (-want +got):\n%s", diff)
+	}
+	if _, ok := mysqlReq.GetInsight().GetTorsoValidation().GetValidationDetails()[DBUserLimitKeyPrefix+"nofile"]; ok {
+		t.Errorf("WriteInsightAndGetResponse() modified the request of the caller")
+	}
+}
+
+func TestHostSettingsWriterPacemaker(t *testing.T) {
+	fw := &fakeWriter{}
+	h := NewHostSettingsContext(servicecommunication.DefaultRegistry())
+	h.readFile = fakeReadFile(nil)
+	crmMonRuns := 0
+	h.execute = func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+		crmMonRuns++
+		if params.Executable != "crm_mon" {
+			return commandlineexecutor.Result{Error: errors.New("unexpected command")}
+		}
+		return commandlineexecutor.Result{StdOut: `<pacemaker-result>
+  <summary>
+    <current_dc present="true" name="db-1" with_quorum="true"/>
+    <cluster_options stonith-enabled="true"/>
+  </summary>
+  <nodes>
+    <node name="db-1" online="true"/>
+  </nodes>
+</pacemaker-result>`}
+	}
+	ctx := context.Background()
+
+	// crm_mon is not run without pacemakerd.
+	h.Update(servicecommunication.DiscoveryResult{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 123, name: "mysqld"}}})
+	h.refreshCluster(ctx)
+	if crmMonRuns != 0 {
+		t.Errorf("refreshCluster() ran crm_mon %d times without pacemakerd, want 0", crmMonRuns)
+	}
+
+	h.Update(servicecommunication.DiscoveryResult{Processes: []servicecommunication.ProcessWrapper{
+		processStub{pid: 123, name: "mysqld"},
+		processStub{pid: 45, name: "pacemakerd"},
+	}})
+	h.refreshCluster(ctx)
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})
+	if _, err := h.Writer(fw, servicecommunication.MySQL).WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+
+	want := []writeCall{
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+			"a":                            "1",
+			pacemaker.ManagerKey:           "pacemaker",
+			pacemaker.DCKey:                "db-1",
+			pacemaker.QuorumKey:            "true",
+			pacemaker.NodesKey:             "db-1:online",
+			pacemaker.OnlineNodesKey:       "1",
+			pacemaker.StonithEnabledKey:    "true",
+			pacemaker.FenceAgentsKey:       "",
+			pacemaker.DatabaseResourcesKey: "",
+		})},
+	}
+	if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+		t.Errorf("WriteInsightAndGetResponse() wrote unexpected insights (-want +got):\n%s", diff)
+	}
+}
+
+func TestHostSettingsWriterPackages(t *testing.T) {
+	fw := &fakeWriter{}
+	h := NewHostSettingsContext(servicecommunication.DefaultRegistry())
+	h.readFile = fakeReadFile(nil)
+	h.execute = func(ctx context.Context, params commandlineexecutor.Params) commandlineexecutor.Result {
+		if params.Executable != "dpkg-query" {
+			return commandlineexecutor.Result{Error: errors.New("unexpected command")}
+		}
+		return commandlineexecutor.Result{StdOut: "mysql-server-8.0\t8.0.36-0ubuntu0.22.04.1\tinstall ok installed\n"}
+	}
+	h.refreshPackages(context.Background())
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})
+	if _, err := h.Writer(fw, servicecommunication.MySQL).WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+
+	want := []writeCall{
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{
+			"a":                          "1",
+			packages.PackageManagerKey:   "dpkg",
+			packages.DatabasePackagesKey: "mysql-server-8.0=8.0.36-0ubuntu0.22.04.1",
+			packages.MonitoringAgentsKey: "",
+		})},
+	}
+	if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+		t.Errorf("WriteInsightAndGetResponse() wrote unexpected insights (-want +got):\n%s", diff)
+	}
+}
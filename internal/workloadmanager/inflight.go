@@ -0,0 +1,150 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// InFlight tracks the metric collections and Data Warehouse writes in progress,
+// so that the agent can let them finish before it shuts down.
+// The zero value is ready to use and a nil InFlight tracks nothing.
+type InFlight struct {
+	mu    sync.Mutex
+	count int
+	// idle is closed once count drops back to zero.
+	idle chan struct{}
+	// aborted is cancelled by Abort, cancelling the operations started with StartWork.
+	aborted context.Context
+	abort   context.CancelFunc
+}
+
+// Start records the start of an operation and returns the function which records its end.
+func (f *InFlight) Start() (done func()) {
+	if f == nil {
+		return func() {}
+	}
+	f.mu.Lock()
+	if f.count == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.count++
+	f.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			f.count--
+			if f.count == 0 {
+				close(f.idle)
+			}
+		})
+	}
+}
+
+// StartWork records the start of an operation run with ctx and returns its context and the function
+// which records its end. The context keeps the values of ctx but is not cancelled with it, so the
+// operation can finish after its service was cancelled on shutdown. It is cancelled by Abort or done.
+func (f *InFlight) StartWork(ctx context.Context) (context.Context, func()) {
+	end := f.Start()
+	if f == nil {
+		return ctx, end
+	}
+	f.mu.Lock()
+	if f.aborted == nil {
+		f.aborted, f.abort = context.WithCancel(context.Background())
+	}
+	aborted := f.aborted
+	f.mu.Unlock()
+
+	workCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(aborted, cancel)
+	return workCtx, func() {
+		stop()
+		cancel()
+		end()
+	}
+}
+
+// Abort cancels the operations in progress and those started later with StartWork.
+// It is used once the operations did not finish in time on shutdown.
+func (f *InFlight) Abort() {
+	if f == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.aborted == nil {
+		f.aborted, f.abort = context.WithCancel(context.Background())
+	}
+	f.abort()
+}
+
+// Count returns the number of operations in progress.
+func (f *InFlight) Count() int {
+	if f == nil {
+		return 0
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.count
+}
+
+// Wait blocks until no operations are in progress or ctx is done, in which case ctx.Err() is returned.
+func (f *InFlight) Wait(ctx context.Context) error {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	if f.count == 0 {
+		f.mu.Unlock()
+		return nil
+	}
+	idle := f.idle
+	f.mu.Unlock()
+
+	select {
+	case <-idle:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// InFlightWriter is a WLMWriter which tracks the writes in progress.
+type InFlightWriter struct {
+	writer   WLMWriter
+	inFlight *InFlight
+}
+
+// NewInFlightWriter returns an InFlightWriter which writes to writer and tracks the writes in inFlight.
+func NewInFlightWriter(writer WLMWriter, inFlight *InFlight) *InFlightWriter {
+	return &InFlightWriter{writer: writer, inFlight: inFlight}
+}
+
+// WriteInsightAndGetResponse writes the insight while it is tracked as in progress.
+func (w *InFlightWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	done := w.inFlight.Start()
+	defer done()
+	return w.writer.WriteInsightAndGetResponse(project, location, req)
+}
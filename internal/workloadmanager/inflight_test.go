@@ -0,0 +1,122 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestInFlightWait(t *testing.T) {
+	f := &InFlight{}
+	if err := f.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() with nothing in flight returned unexpected error: %v", err)
+	}
+
+	done1 := f.Start()
+	done2 := f.Start()
+	if got := f.Count(); got != 2 {
+		t.Errorf("Count() = %d, want 2", got)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := f.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() with operations in flight returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	done1()
+	// Calling done more than once must not end another operation.
+	done1()
+	if got := f.Count(); got != 1 {
+		t.Errorf("Count() after the first operation finished = %d, want 1", got)
+	}
+	go done2()
+	if err := f.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() returned unexpected error: %v", err)
+	}
+	if got := f.Count(); got != 0 {
+		t.Errorf("Count() after all operations finished = %d, want 0", got)
+	}
+}
+
+func TestInFlightNil(t *testing.T) {
+	var f *InFlight
+	f.Start()()
+	if got := f.Count(); got != 0 {
+		t.Errorf("Count() of a nil InFlight = %d, want 0", got)
+	}
+	if err := f.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() of a nil InFlight returned unexpected error: %v", err)
+	}
+}
+
+func TestInFlightStartWork(t *testing.T) {
+	f := &InFlight{}
+	ctx, cancel := context.WithCancel(context.Background())
+	workCtx, done := f.StartWork(ctx)
+	if got := f.Count(); got != 1 {
+		t.Errorf("Count() during the operation = %d, want 1", got)
+	}
+	cancel()
+	if err := workCtx.Err(); err != nil {
+		t.Errorf("StartWork() context returned error %v after the parent was cancelled, want nil", err)
+	}
+
+	f.Abort()
+	<-workCtx.Done()
+	done()
+	if got := f.Count(); got != 0 {
+		t.Errorf("Count() after the operation finished = %d, want 0", got)
+	}
+	// Operations started after Abort are cancelled right away.
+	aborted, done := f.StartWork(context.Background())
+	defer done()
+	<-aborted.Done()
+}
+
+func TestInFlightWriter(t *testing.T) {
+	f := &InFlight{}
+	blocked := &blockingWriter{release: make(chan struct{}), started: make(chan struct{})}
+	w := NewInFlightWriter(blocked, f)
+
+	go w.WriteInsightAndGetResponse("test-project", "us-central1", DefaultWriteInsightRequest)
+	<-blocked.started
+	if got := f.Count(); got != 1 {
+		t.Errorf("Count() during a write = %d, want 1", got)
+	}
+	close(blocked.release)
+	if err := f.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() returned unexpected error: %v", err)
+	}
+}
+
+// blockingWriter blocks each write until release is closed.
+type blockingWriter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	close(b.started)
+	<-b.release
+	return &wlm.WriteInsightResponse{}, nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"encoding/json"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"google.golang.org/protobuf/encoding/protojson"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// DefaultInsightLogName is the Cloud Logging log to which insights are written when none is configured.
+const DefaultInsightLogName = "google-cloud-workload-agent-insights"
+
+// EntryLogger writes entries to a Cloud Logging log.
+type EntryLogger interface {
+	Log(e logging.Entry)
+}
+
+// InsightLogWriter is a WLMWriter which also writes every insight as a structured entry to a
+// Cloud Logging log, giving an audit trail of exactly what was sent to Data Warehouse and when.
+//
+// The entry payload holds the project, location, status and request of the write. Entries of
+// failed writes are logged with the error severity.
+type InsightLogWriter struct {
+	writer WLMWriter
+	logger EntryLogger
+	now    func() time.Time
+}
+
+// NewInsightLogWriter returns an InsightLogWriter which writes to writer and logs to logger.
+func NewInsightLogWriter(writer WLMWriter, logger EntryLogger) *InsightLogWriter {
+	return &InsightLogWriter{writer: writer, logger: logger, now: time.Now}
+}
+
+// WriteInsightAndGetResponse writes the insight and logs it.
+// The activation check is written without being logged.
+func (w *InsightLogWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	start := w.now()
+	res, err := w.writer.WriteInsightAndGetResponse(project, location, req)
+	if isActivationProbe(req) {
+		return res, err
+	}
+	request, marshalErr := protojson.Marshal(req)
+	if marshalErr != nil {
+		log.Logger.Warnw("Could not encode insight for Cloud Logging", "error", marshalErr)
+		return res, err
+	}
+	severity := logging.Info
+	if err != nil {
+		severity = logging.Error
+	}
+	w.logger.Log(logging.Entry{
+		Timestamp: start,
+		Severity:  severity,
+		Labels: map[string]string{
+			"instance_id":   req.GetInsight().GetInstanceId(),
+			"workload_type": insightWorkloadType(req),
+		},
+		Payload: map[string]any{
+			"project":  project,
+			"location": location,
+			"status":   writeInsightStatus(res, err),
+			"request":  json.RawMessage(request),
+		},
+	})
+	return res, err
+}
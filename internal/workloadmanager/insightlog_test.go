@@ -0,0 +1,110 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/logging"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/googleapi"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+type fakeEntryLogger struct {
+	entries []logging.Entry
+}
+
+func (f *fakeEntryLogger) Log(e logging.Entry) {
+	f.entries = append(f.entries, e)
+}
+
+func TestInsightLogWriter(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})
+	labels := map[string]string{"instance_id": "i1", "workload_type": "MYSQL"}
+	request := json.RawMessage(`{"insight":{"instanceId":"i1","torsoValidation":{"workloadType":"MYSQL","validationDetails":{"a":"1"}}}}`)
+	tests := []struct {
+		name      string
+		req       *dwpb.WriteInsightRequest
+		writerErr error
+		want      []logging.Entry
+	}{
+		{
+			name: "Success",
+			req:  req,
+			want: []logging.Entry{{
+				Timestamp: now,
+				Severity:  logging.Info,
+				Labels:    labels,
+				Payload: map[string]any{
+					"project":  "p1",
+					"location": "us-central1",
+					"status":   "OK",
+					"request":  request,
+				},
+			}},
+		},
+		{
+			name:      "Failure",
+			req:       req,
+			writerErr: &googleapi.Error{Code: 403},
+			want: []logging.Entry{{
+				Timestamp: now,
+				Severity:  logging.Error,
+				Labels:    labels,
+				Payload: map[string]any{
+					"project":  "p1",
+					"location": "us-central1",
+					"status":   "403",
+					"request":  request,
+				},
+			}},
+		},
+		{
+			name: "ActivationProbe",
+			req:  &dwpb.WriteInsightRequest{Insight: &dwpb.Insight{TorsoValidation: &dwpb.TorsoValidation{}}},
+		},
+	}
+	// The request is compared as compact JSON, protojson randomly adds whitespace.
+	compactRequest := cmp.Transformer("CompactRequest", func(v json.RawMessage) string {
+		var m any
+		if err := json.Unmarshal(v, &m); err != nil {
+			return string(v)
+		}
+		b, _ := json.Marshal(m)
+		return string(b)
+	})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fl := &fakeEntryLogger{}
+			w := NewInsightLogWriter(&fakeWriter{err: tc.writerErr}, fl)
+			w.now = func() time.Time { return now }
+
+			_, err := w.WriteInsightAndGetResponse("p1", "us-central1", tc.req)
+			if !errors.Is(err, tc.writerErr) {
+				t.Errorf("WriteInsightAndGetResponse() returned error %v, want %v", err, tc.writerErr)
+			}
+			if diff := cmp.Diff(tc.want, fl.entries, compactRequest); diff != "" {
+				t.Errorf("InsightLogWriter logged unexpected entries (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// legacyAgent is an agent which collects some of the workloads also collected by this agent.
+type legacyAgent struct {
+	name string
+	// workloadTypes are the Data Warehouse workload types collected by the agent.
+	workloadTypes []string
+}
+
+// legacyAgents are keyed by the workload of their signature.
+var legacyAgents = map[string]legacyAgent{
+	servicecommunication.LegacyOracleAgent: {name: "legacy Oracle workload agent", workloadTypes: []string{"ORACLE"}},
+	servicecommunication.SQLServerAgent:    {name: "Agent for SQL Server", workloadTypes: []string{"SQLSERVER"}},
+}
+
+// LegacyAgents tracks the legacy agents running on the host, found by discovery.
+// A warning is logged when a legacy agent which collects the same workloads as this agent starts.
+// If the legacy agent is configured to take precedence, the insights of those workloads are not sent
+// while it runs. By default this agent takes precedence, so an upgrade never stops a collection.
+// A nil LegacyAgents suppresses nothing.
+type LegacyAgents struct {
+	signatures *servicecommunication.Registry
+	precedence cpb.LegacyAgents_Precedence
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewLegacyAgents returns a LegacyAgents which identifies the processes of the legacy agents with signatures.
+func NewLegacyAgents(signatures *servicecommunication.Registry, config *cpb.LegacyAgents) *LegacyAgents {
+	precedence := config.GetPrecedence()
+	if precedence == cpb.LegacyAgents_PRECEDENCE_UNSPECIFIED {
+		precedence = cpb.LegacyAgents_WORKLOAD_AGENT
+	}
+	return &LegacyAgents{signatures: signatures, precedence: precedence, running: make(map[string]bool)}
+}
+
+// Listen updates the running legacy agents with the discovery results received on ch until ctx is done.
+func (l *LegacyAgents) Listen(ctx context.Context, ch <-chan *servicecommunication.Message) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-ch:
+			if msg.Origin == servicecommunication.Discovery {
+				l.Update(ctx, msg.DiscoveryResult)
+			}
+		}
+	}
+}
+
+// Update records the legacy agents running in a discovery result and logs those which started or stopped.
+// Heartbeats are ignored.
+func (l *LegacyAgents) Update(ctx context.Context, result servicecommunication.DiscoveryResult) {
+	if result.Unchanged {
+		return
+	}
+	running := make(map[string]bool)
+	for workload := range legacyAgents {
+		if len(l.signatures.Filter(workload, result.Processes)) > 0 {
+			running[workload] = true
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for workload := range running {
+		if l.running[workload] {
+			continue
+		}
+		agent := legacyAgents[workload]
+		if l.precedence == cpb.LegacyAgents_LEGACY_AGENT {
+			log.CtxLogger(ctx).Warnw("A legacy agent collecting the same workloads is running on this host, the insights of these workloads are only sent by the legacy agent. Uninstall the legacy agent, or set legacy_agents.precedence to WORKLOAD_AGENT to send them from both agents.",
+				"agent", agent.name, "workloadTypes", strings.Join(agent.workloadTypes, ", "))
+		} else {
+			log.CtxLogger(ctx).Warnw("A legacy agent collecting the same workloads is running on this host, the insights of these workloads are sent by both agents. Uninstall the legacy agent to avoid duplicate insights.",
+				"agent", agent.name, "workloadTypes", strings.Join(agent.workloadTypes, ", "))
+		}
+	}
+	for workload := range l.running {
+		if !running[workload] {
+			log.CtxLogger(ctx).Infow("Legacy agent is no longer running on this host", "agent", legacyAgents[workload].name)
+		}
+	}
+	l.running = running
+}
+
+// Suppresses reports whether insights of the Data Warehouse workload type must not be sent,
+// because a legacy agent which takes precedence collects the workload.
+func (l *LegacyAgents) Suppresses(workloadType string) bool {
+	if l == nil || l.precedence != cpb.LegacyAgents_LEGACY_AGENT {
+		return false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for workload := range l.running {
+		for _, wt := range legacyAgents[workload].workloadTypes {
+			if strings.EqualFold(wt, workloadType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Writer returns a WLMWriter which drops the insights of the workloads collected by a running legacy
+// agent which takes precedence, and writes the other insights to writer.
+func (l *LegacyAgents) Writer(writer WLMWriter) *LegacyAgentsWriter {
+	return &LegacyAgentsWriter{writer: writer, agents: l}
+}
+
+// LegacyAgentsWriter is a WLMWriter which drops the insights suppressed by LegacyAgents.
+type LegacyAgentsWriter struct {
+	writer WLMWriter
+	agents *LegacyAgents
+}
+
+// WriteInsightAndGetResponse writes the insight unless it is suppressed, in which case an empty response is returned.
+func (w *LegacyAgentsWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	tv := req.GetInsight().GetTorsoValidation()
+	if len(tv.GetValidationDetails()) > 0 && w.agents.Suppresses(tv.GetWorkloadType().String()) {
+		log.Logger.Debugw("A legacy agent collects the workload, the insight is not sent", "workload_type", tv.GetWorkloadType())
+		return &wlm.WriteInsightResponse{}, nil
+	}
+	return w.writer.WriteInsightAndGetResponse(project, location, req)
+}
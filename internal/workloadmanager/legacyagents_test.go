@@ -0,0 +1,133 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/servicecommunication"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestLegacyAgentsSuppresses(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *cpb.LegacyAgents
+		results      []servicecommunication.DiscoveryResult
+		workloadType string
+		want         bool
+	}{
+		{
+			name:         "WorkloadAgentTakesPrecedenceByDefault",
+			results:      []servicecommunication.DiscoveryResult{{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google-cloud-sql-server-agent"}}}},
+			workloadType: "SQLSERVER",
+			want:         false,
+		},
+		{
+			name:         "LegacyAgentTakesPrecedence",
+			config:       &cpb.LegacyAgents{Precedence: cpb.LegacyAgents_LEGACY_AGENT},
+			results:      []servicecommunication.DiscoveryResult{{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google-cloud-sql-server-agent"}}}},
+			workloadType: "SQLSERVER",
+			want:         true,
+		},
+		{
+			name:         "SAPAgentIsNotALegacyAgent",
+			config:       &cpb.LegacyAgents{Precedence: cpb.LegacyAgents_LEGACY_AGENT},
+			results:      []servicecommunication.DiscoveryResult{{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google_cloud_sap_agent"}}}},
+			workloadType: "SQLSERVER",
+			want:         false,
+		},
+		{
+			name:         "OtherWorkloadType",
+			config:       &cpb.LegacyAgents{Precedence: cpb.LegacyAgents_LEGACY_AGENT},
+			results:      []servicecommunication.DiscoveryResult{{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google-cloud-sql-server-agent"}}}},
+			workloadType: "ORACLE",
+			want:         false,
+		},
+		{
+			name:         "WorkloadAgentTakesPrecedence",
+			config:       &cpb.LegacyAgents{Precedence: cpb.LegacyAgents_WORKLOAD_AGENT},
+			results:      []servicecommunication.DiscoveryResult{{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google_cloud_oracle_agent"}}}},
+			workloadType: "ORACLE",
+			want:         false,
+		},
+		{
+			name:   "HeartbeatIsIgnored",
+			config: &cpb.LegacyAgents{Precedence: cpb.LegacyAgents_LEGACY_AGENT},
+			results: []servicecommunication.DiscoveryResult{
+				{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google_cloud_oracle_agent"}}},
+				{Unchanged: true},
+			},
+			workloadType: "ORACLE",
+			want:         true,
+		},
+		{
+			name:   "LegacyAgentStopped",
+			config: &cpb.LegacyAgents{Precedence: cpb.LegacyAgents_LEGACY_AGENT},
+			results: []servicecommunication.DiscoveryResult{
+				{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google_cloud_oracle_agent"}}},
+				{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 2, name: "ora_pmon_orcl"}}},
+			},
+			workloadType: "ORACLE",
+			want:         false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewLegacyAgents(servicecommunication.DefaultRegistry(), tc.config)
+			for _, result := range tc.results {
+				l.Update(context.Background(), result)
+			}
+			if got := l.Suppresses(tc.workloadType); got != tc.want {
+				t.Errorf("Suppresses(%s) = %v, want %v", tc.workloadType, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLegacyAgentsWriter(t *testing.T) {
+	fw := &fakeWriter{}
+	l := NewLegacyAgents(servicecommunication.DefaultRegistry(), &cpb.LegacyAgents{Precedence: cpb.LegacyAgents_LEGACY_AGENT})
+	l.Update(context.Background(), servicecommunication.DiscoveryResult{Processes: []servicecommunication.ProcessWrapper{processStub{pid: 1, name: "google_cloud_oracle_agent"}}})
+	w := l.Writer(fw)
+
+	for _, req := range []*dwpb.WriteInsightRequest{
+		insightRequest("i1", dwpb.TorsoValidation_ORACLE, map[string]string{"a": "1"}),
+		insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+	} {
+		if _, err := w.WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+			t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+		}
+	}
+	want := []writeCall{
+		{"p1", "us-central1", insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})},
+	}
+	if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+		t.Errorf("LegacyAgentsWriter wrote unexpected insights (-want +got):\n%s", diff)
+	}
+}
+
+func TestNilLegacyAgentsSuppressesNothing(t *testing.T) {
+	var l *LegacyAgents
+	if l.Suppresses("SQLSERVER") {
+		t.Error("Suppresses(SQLSERVER) of a nil LegacyAgents = true, want false")
+	}
+}
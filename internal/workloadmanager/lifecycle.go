@@ -0,0 +1,46 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/lifecycle"
+)
+
+// lifecycleDetails maps the validation details whose changes are emitted as lifecycle events to
+// the type of the event.
+var lifecycleDetails = map[string]lifecycle.EventType{
+	"version":      lifecycle.VersionChanged,
+	"current_role": lifecycle.RoleChanged,
+}
+
+// lifecycleTracker remembers the tracked validation details last sent for each workload instance.
+var lifecycleTracker = &lifecycle.Tracker{}
+
+// observeLifecycle emits a lifecycle event for each tracked validation detail of wm which changed
+// since the previous insight of the same workload instance.
+func observeLifecycle(ctx context.Context, tracker *lifecycle.Tracker, wm WorkloadMetrics) []lifecycle.Event {
+	var events []lifecycle.Event
+	instance := wm.Metrics[DatabaseInstanceKey]
+	for key, eventType := range lifecycleDetails {
+		if e, ok := tracker.Observe(ctx, eventType, string(wm.WorkloadType), instance, wm.Metrics[key]); ok {
+			events = append(events, e)
+		}
+	}
+	return events
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/lifecycle"
+)
+
+func TestObserveLifecycle(t *testing.T) {
+	tracker := &lifecycle.Tracker{}
+	ctx := context.Background()
+	observeLifecycle(ctx, tracker, WorkloadMetrics{WorkloadType: MYSQL, Metrics: map[string]string{"current_role": "replica", DatabaseInstanceKey: "localhost:3306"}})
+	observeLifecycle(ctx, tracker, WorkloadMetrics{WorkloadType: MONGODB, Metrics: map[string]string{"version": "6.0.4"}})
+
+	got := observeLifecycle(ctx, tracker, WorkloadMetrics{WorkloadType: MYSQL, Metrics: map[string]string{"current_role": "source", DatabaseInstanceKey: "localhost:3306"}})
+	got = append(got, observeLifecycle(ctx, tracker, WorkloadMetrics{WorkloadType: MONGODB, Metrics: map[string]string{"version": "7.0.1"}})...)
+	want := []lifecycle.Event{
+		{Type: lifecycle.RoleChanged, Workload: "MYSQL", Instance: "localhost:3306", Previous: "replica", Current: "source"},
+		{Type: lifecycle.VersionChanged, Workload: "MONGODB", Previous: "6.0.4", Current: "7.0.1"},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("observeLifecycle() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// overrideWatchFrequency is the frequency at which the metric override file is checked for changes.
+const overrideWatchFrequency = 10 * time.Second
+
+// overrideFile is the layout of a metric override file.
+//
+//	workloads:
+//	- workload_type: MYSQL
+//	  labels:
+//	    instance_name: fake-wlmmetrics-1
+//	  metrics:
+//	  - name: buffer_pool_size
+//	    type: int
+//	    value: 134217728
+//	  - name: replication
+//	    metrics:
+//	    - name: enabled
+//	      type: bool
+//	      value: true
+//
+// Nested metrics are reported with their names joined by a dot, e.g. replication.enabled.
+type overrideFile struct {
+	Workloads []overrideWorkload `yaml:"workloads"`
+}
+
+// overrideWorkload holds the metrics reported for a single workload.
+type overrideWorkload struct {
+	WorkloadType WorkloadType      `yaml:"workload_type"`
+	Labels       map[string]string `yaml:"labels"`
+	Metrics      []overrideMetric  `yaml:"metrics"`
+}
+
+// overrideMetric is a single metric, or a group of nested metrics.
+type overrideMetric struct {
+	Name    string           `yaml:"name"`
+	Type    string           `yaml:"type"`
+	Value   yaml.Node        `yaml:"value"`
+	Metrics []overrideMetric `yaml:"metrics"`
+}
+
+// parseOverrideYAML parses a metric override file in the workloads layout.
+// It returns false if the content does not use the workloads layout, which means it uses the
+// legacy layout of workload_type lines followed by key: value lines.
+func parseOverrideYAML(ctx context.Context, content []byte) ([]WorkloadMetrics, bool) {
+	var f overrideFile
+	if err := yaml.Unmarshal(content, &f); err != nil || f.Workloads == nil {
+		return nil, false
+	}
+	wm := make([]WorkloadMetrics, 0, len(f.Workloads))
+	for _, w := range f.Workloads {
+		metrics := make(map[string]string)
+		for k, v := range w.Labels {
+			metrics[k] = v
+		}
+		addOverrideMetrics(ctx, metrics, "", w.Metrics)
+		wm = append(wm, WorkloadMetrics{WorkloadType: w.WorkloadType, Metrics: metrics})
+	}
+	return wm, true
+}
+
+// addOverrideMetrics adds the metrics to details, prefixing their names with prefix.
+// Metrics which do not match their type are skipped.
+func addOverrideMetrics(ctx context.Context, details map[string]string, prefix string, metrics []overrideMetric) {
+	for _, m := range metrics {
+		name := prefix + m.Name
+		if len(m.Metrics) > 0 {
+			addOverrideMetrics(ctx, details, name+".", m.Metrics)
+			continue
+		}
+		value, err := overrideMetricValue(m)
+		if err != nil {
+			log.CtxLogger(ctx).Warnw("Invalid metric in the metric override file", "metric", name, "error", err)
+			continue
+		}
+		details[name] = value
+	}
+}
+
+// overrideMetricValue returns the value of the metric after checking it against the metric type.
+func overrideMetricValue(m overrideMetric) (string, error) {
+	if m.Value.Kind != yaml.ScalarNode {
+		return "", fmt.Errorf("value must be a scalar")
+	}
+	value := m.Value.Value
+	var err error
+	switch m.Type {
+	case "", "string":
+	case "int":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "float":
+		_, err = strconv.ParseFloat(value, 64)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	default:
+		return "", fmt.Errorf("unsupported type %q", m.Type)
+	}
+	if err != nil {
+		return "", fmt.Errorf("value %q is not a valid %s: %w", value, m.Type, err)
+	}
+	return value, nil
+}
+
+// overrideModTime returns the modification time of the metric override file,
+// or the zero time if the file does not exist.
+func overrideModTime(path string, stat func(string) (os.FileInfo, error)) time.Time {
+	info, err := stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// waitForNextCollection blocks until the next collection is due or the metric override file changes.
+// It returns false if ctx is cancelled.
+func waitForNextCollection(ctx context.Context, path string, collect, watch <-chan time.Time, modTime *time.Time, stat func(string) (os.FileInfo, error)) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			log.CtxLogger(ctx).Info("Metric collection override cancellation requested")
+			return false
+		case <-collect:
+			return true
+		case <-watch:
+			if m := overrideModTime(path, stat); !m.Equal(*modTime) {
+				*modTime = m
+				log.CtxLogger(ctx).Infow("Metric override file changed, collecting metrics", "file", path)
+				return true
+			}
+		}
+	}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseOverrideYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []WorkloadMetrics
+		wantOK  bool
+	}{
+		{
+			name: "Workloads",
+			content: `
+workloads:
+- workload_type: MYSQL
+  labels:
+    instance_name: fake-wlmmetrics-1
+  metrics:
+  - name: buffer_pool_size
+    type: int
+    value: 134217728
+  - name: version
+    value: 8.0.36
+  - name: replication
+    metrics:
+    - name: enabled
+      type: bool
+      value: true
+    - name: lag_seconds
+      type: float
+      value: 1.5
+- workload_type: REDIS
+  metrics:
+  - name: maxmemory
+    type: int
+    value: not-a-number
+  - name: persistence
+    type: list
+    value: rdb
+`,
+			want: []WorkloadMetrics{
+				{
+					WorkloadType: MYSQL,
+					Metrics: map[string]string{
+						"instance_name":           "fake-wlmmetrics-1",
+						"buffer_pool_size":        "134217728",
+						"version":                 "8.0.36",
+						"replication.enabled":     "true",
+						"replication.lag_seconds": "1.5",
+					},
+				},
+				{
+					WorkloadType: REDIS,
+					Metrics:      map[string]string{},
+				},
+			},
+			wantOK: true,
+		},
+		{
+			name: "LegacyLayout",
+			content: `workload_type: MYSQL
+  metric_value: 1
+`,
+			wantOK: false,
+		},
+		{
+			name:    "Empty",
+			content: "",
+			wantOK:  false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := parseOverrideYAML(context.Background(), []byte(tc.content))
+			if ok != tc.wantOK {
+				t.Errorf("parseOverrideYAML() returned ok: %v, want: %v", ok, tc.wantOK)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("parseOverrideYAML() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCollectOverrideMetricsWorkloadsLayout(t *testing.T) {
+	content := `
+workloads:
+- workload_type: REDIS
+  metrics:
+  - name: metric_value
+    type: int
+    value: 1
+`
+	reader := ConfigFileReader(func(string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(content)), nil
+	})
+	want := []WorkloadMetrics{{WorkloadType: REDIS, Metrics: map[string]string{"metric_value": "1"}}}
+
+	got := collectOverrideMetrics(context.Background(), LinuxMetricOverridePath, reader)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("collectOverrideMetrics() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestWaitForNextCollection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wlmmetricoverride.yaml")
+	if err := os.WriteFile(path, []byte("workloads: []"), 0644); err != nil {
+		t.Fatalf("os.WriteFile(%s) returned unexpected error: %v", path, err)
+	}
+	stat := func(string) (os.FileInfo, error) { return os.Stat(path) }
+	missing := func(string) (os.FileInfo, error) { return nil, errors.New("not found") }
+	modTime := overrideModTime(path, stat)
+
+	tests := []struct {
+		name        string
+		cancel      bool
+		collect     bool
+		stat        func(string) (os.FileInfo, error)
+		modTime     time.Time
+		want        bool
+		wantModTime time.Time
+	}{
+		{
+			name:        "Cancelled",
+			cancel:      true,
+			stat:        stat,
+			modTime:     modTime,
+			want:        false,
+			wantModTime: modTime,
+		},
+		{
+			name:        "CollectionDue",
+			collect:     true,
+			stat:        stat,
+			modTime:     modTime,
+			want:        true,
+			wantModTime: modTime,
+		},
+		{
+			name:        "FileChanged",
+			stat:        stat,
+			modTime:     modTime.Add(-time.Minute),
+			want:        true,
+			wantModTime: modTime,
+		},
+		{
+			name:        "FileRemoved",
+			stat:        missing,
+			modTime:     modTime,
+			want:        true,
+			wantModTime: time.Time{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			collect := make(chan time.Time, 1)
+			watch := make(chan time.Time, 1)
+			switch {
+			case tc.cancel:
+				cancel()
+			case tc.collect:
+				collect <- time.Now()
+			default:
+				watch <- time.Now()
+			}
+
+			gotModTime := tc.modTime
+			got := waitForNextCollection(ctx, path, collect, watch, &gotModTime, tc.stat)
+			if got != tc.want {
+				t.Errorf("waitForNextCollection() = %v, want %v", got, tc.want)
+			}
+			if !gotModTime.Equal(tc.wantModTime) {
+				t.Errorf("waitForNextCollection() set modification time %v, want %v", gotModTime, tc.wantModTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/pubsub/v1"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentials"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// publishTimeout bounds how long a write waits for its metrics to be published.
+const publishTimeout = 30 * time.Second
+
+// Publisher publishes messages to a Pub/Sub topic.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, msg *pubsub.PubsubMessage) error
+}
+
+// pubsubPublisher is a Publisher using the Pub/Sub API.
+type pubsubPublisher struct {
+	service *pubsub.Service
+}
+
+// Publish publishes msg to topic.
+func (p *pubsubPublisher) Publish(ctx context.Context, topic string, msg *pubsub.PubsubMessage) error {
+	_, err := p.service.Projects.Topics.Publish(topic, &pubsub.PublishRequest{Messages: []*pubsub.PubsubMessage{msg}}).Context(ctx).Do()
+	return err
+}
+
+// newPubSubPublisher creates a Publisher authenticating with the configured credentials.
+func newPubSubPublisher(ctx context.Context, config *cpb.Configuration) (Publisher, error) {
+	opts, err := credentials.ClientOptions(ctx, config.GetCredentials())
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, endpoints.PubSubOptions(config.GetEndpoints())...)
+	service, err := pubsub.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating Pub/Sub client: %w", err)
+	}
+	return &pubsubPublisher{service: service}, nil
+}
+
+// PubSubWriter is a WLMWriter which also publishes the metrics of every insight to a Pub/Sub
+// topic, so that customers can build their own pipelines, e.g. into BigQuery or Dataflow.
+//
+// The message data is the JSON object of the validation details. The instance, workload type,
+// project and location of the insight are set as message attributes. Failed publishes are
+// logged and do not affect the write to Data Warehouse.
+type PubSubWriter struct {
+	writer    WLMWriter
+	publisher Publisher
+	topic     string
+}
+
+// NewPubSubWriter returns a PubSubWriter which writes to writer and publishes to topic.
+func NewPubSubWriter(writer WLMWriter, publisher Publisher, topic string) *PubSubWriter {
+	return &PubSubWriter{writer: writer, publisher: publisher, topic: topic}
+}
+
+// WriteInsightAndGetResponse publishes the metrics of the insight and writes it.
+// The activation check and other insights without validation details are not published.
+// The details of SQL Server insights are flattened.
+func (p *PubSubWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	if !isActivationProbe(req) && len(insightDetails(req)) > 0 {
+		p.publish(project, location, req)
+	}
+	return p.writer.WriteInsightAndGetResponse(project, location, req)
+}
+
+// publish publishes the validation details of req, logging any failure.
+func (p *PubSubWriter) publish(project, location string, req *dwpb.WriteInsightRequest) {
+	topic := topicName(p.topic, project)
+	data, err := json.Marshal(insightDetails(req))
+	if err != nil {
+		log.Logger.Warnw("Could not encode workload metrics for Pub/Sub", "topic", topic, "error", err)
+		return
+	}
+	msg := &pubsub.PubsubMessage{
+		Data: base64.StdEncoding.EncodeToString(data),
+		Attributes: map[string]string{
+			"instance_id":   req.GetInsight().GetInstanceId(),
+			"workload_type": insightWorkloadType(req),
+			"project":       project,
+			"location":      location,
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := p.publisher.Publish(ctx, topic, msg); err != nil {
+		log.Logger.Warnw("Could not publish workload metrics to Pub/Sub", "topic", topic, "error", err)
+	}
+}
+
+// topicName returns the full resource name of topic, which is relative to project unless it is a full name.
+func topicName(topic, project string) string {
+	if strings.HasPrefix(topic, "projects/") {
+		return topic
+	}
+	return fmt.Sprintf("projects/%s/topics/%s", project, topic)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/pubsub/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+type publishCall struct {
+	topic string
+	msg   *pubsub.PubsubMessage
+}
+
+type fakePublisher struct {
+	calls []publishCall
+	err   error
+}
+
+func (f *fakePublisher) Publish(ctx context.Context, topic string, msg *pubsub.PubsubMessage) error {
+	f.calls = append(f.calls, publishCall{topic: topic, msg: msg})
+	return f.err
+}
+
+func TestPubSubWriter(t *testing.T) {
+	attributes := map[string]string{
+		"instance_id":   "i1",
+		"workload_type": "MYSQL",
+		"project":       "p1",
+		"location":      "us-central1",
+	}
+	tests := []struct {
+		name        string
+		topic       string
+		req         *dwpb.WriteInsightRequest
+		publishErr  error
+		wantPublish []publishCall
+	}{
+		{
+			name:  "RelativeTopic",
+			topic: "metrics",
+			req:   insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"b": "2", "a": "1"}),
+			wantPublish: []publishCall{{
+				topic: "projects/p1/topics/metrics",
+				// {"a":"1","b":"2"}
+				msg: &pubsub.PubsubMessage{Data: "eyJhIjoiMSIsImIiOiIyIn0=", Attributes: attributes},
+			}},
+		},
+		{
+			name:  "FullTopicName",
+			topic: "projects/central/topics/metrics",
+			req:   insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+			wantPublish: []publishCall{{
+				topic: "projects/central/topics/metrics",
+				// {"a":"1"}
+				msg: &pubsub.PubsubMessage{Data: "eyJhIjoiMSJ9", Attributes: attributes},
+			}},
+		},
+		{
+			name:       "PublishErrorIsIgnored",
+			topic:      "metrics",
+			req:        insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+			publishErr: errors.New("topic not found"),
+			wantPublish: []publishCall{{
+				topic: "projects/p1/topics/metrics",
+				msg:   &pubsub.PubsubMessage{Data: "eyJhIjoiMSJ9", Attributes: attributes},
+			}},
+		},
+		{
+			name:  "NoValidationDetails",
+			topic: "metrics",
+			req:   insightRequest("i1", dwpb.TorsoValidation_MYSQL, nil),
+		},
+		{
+			name:  "ActivationProbe",
+			topic: "metrics",
+			req:   &dwpb.WriteInsightRequest{Insight: &dwpb.Insight{TorsoValidation: &dwpb.TorsoValidation{}}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := &fakeWriter{}
+			fp := &fakePublisher{err: tc.publishErr}
+			if _, err := NewPubSubWriter(fw, fp, tc.topic).WriteInsightAndGetResponse("p1", "us-central1", tc.req); err != nil {
+				t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.wantPublish, fp.calls, cmp.AllowUnexported(publishCall{})); diff != "" {
+				t.Errorf("PubSubWriter published unexpected messages (-want +got):\n%s", diff)
+			}
+			want := []writeCall{{"p1", "us-central1", tc.req}}
+			if diff := cmp.Diff(want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+				t.Errorf("PubSubWriter wrote unexpected insights (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
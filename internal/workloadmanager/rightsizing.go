@@ -0,0 +1,99 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// RecommendationsKey holds the right-sizing recommendations of a workload as a JSON array of strings.
+const RecommendationsKey = "recommendations"
+
+// MemoryRatio is the best-practice range of a memory setting relative to the memory available to it,
+// such as the InnoDB buffer pool relative to the host memory.
+type MemoryRatio struct {
+	// Setting is the name of the setting in the recommendation.
+	Setting string
+	// Memory describes the memory the setting is compared with, e.g. "the host memory".
+	Memory string
+	// Min and Max bound the ratio of the setting to the memory. A zero Max is unbounded.
+	Min, Max float64
+}
+
+// Recommend returns a recommendation if size is outside of the best-practice range of memory.
+// No recommendation is made if either size is unknown.
+func (r MemoryRatio) Recommend(size, memory int64) (string, bool) {
+	if size <= 0 || memory <= 0 {
+		return "", false
+	}
+	ratio := float64(size) / float64(memory)
+	var action string
+	var target float64
+	switch {
+	case ratio < r.Min:
+		action, target = "Increase", r.Min
+	case r.Max > 0 && ratio > r.Max:
+		action, target = "Decrease", r.Max
+	default:
+		return "", false
+	}
+	bestPractice := "at least " + units.FormatPercent(r.Min)
+	if r.Max > 0 {
+		bestPractice = "at most " + units.FormatPercent(r.Max)
+		if r.Min > 0 {
+			bestPractice = fmt.Sprintf("%s to %s", units.FormatPercent(r.Min), units.FormatPercent(r.Max))
+		}
+	}
+	return fmt.Sprintf("%s %s from %s to %s: it is %s of %s, best practice is %s.",
+		action, r.Setting, units.FormatMemory(size), units.FormatMemory(roundMemory(target*float64(memory))),
+		units.FormatPercent(math.Round(ratio*1000)/1000), r.Memory, bestPractice), true
+}
+
+// roundMemory rounds a recommended memory size down to a whole number of megabytes.
+func roundMemory(size float64) int64 {
+	bytes := int64(size)
+	if bytes < units.Megabyte {
+		return bytes
+	}
+	return bytes / units.Megabyte * units.Megabyte
+}
+
+// AddRecommendations adds the recommendations to the metrics and logs them, so that they are
+// available before Data Warehouse evaluates the insight.
+func (wm *WorkloadMetrics) AddRecommendations(ctx context.Context, recommendations []string) {
+	if len(recommendations) == 0 {
+		return
+	}
+	b, err := json.Marshal(recommendations)
+	if err != nil {
+		log.CtxLogger(ctx).Warnw("Failed to encode right-sizing recommendations", "error", err)
+		return
+	}
+	if wm.Metrics == nil {
+		wm.Metrics = make(map[string]string)
+	}
+	wm.Metrics[RecommendationsKey] = string(b)
+	for _, r := range recommendations {
+		log.CtxLogger(ctx).Infow("Right-sizing recommendation", "workload_type", wm.WorkloadType, "database_instance", wm.Metrics[DatabaseInstanceKey], "recommendation", r)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/units"
+)
+
+func TestMemoryRatioRecommend(t *testing.T) {
+	bufferPool := MemoryRatio{Setting: "innodb_buffer_pool_size", Memory: "the host memory", Min: 0.5, Max: 0.8}
+	workMem := MemoryRatio{Setting: "work_mem", Memory: "the host memory per connection", Max: 0.25}
+	tests := []struct {
+		name   string
+		r      MemoryRatio
+		size   int64
+		memory int64
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "BelowMin",
+			r:      bufferPool,
+			size:   128 * units.Megabyte,
+			memory: 4 * units.Gigabyte,
+			want:   "Increase innodb_buffer_pool_size from 128MB to 2GB: it is 3.1% of the host memory, best practice is 50% to 80%.",
+			wantOK: true,
+		},
+		{
+			name:   "AboveMax",
+			r:      bufferPool,
+			size:   15 * units.Gigabyte,
+			memory: 16 * units.Gigabyte,
+			want:   "Decrease innodb_buffer_pool_size from 15GB to 13107MB: it is 93.8% of the host memory, best practice is 50% to 80%.",
+			wantOK: true,
+		},
+		{
+			name:   "WithinRange",
+			r:      bufferPool,
+			size:   12 * units.Gigabyte,
+			memory: 16 * units.Gigabyte,
+		},
+		{
+			name:   "AboveMaxWithoutMin",
+			r:      workMem,
+			size:   64 * units.Megabyte,
+			memory: 100 * units.Megabyte,
+			want:   "Decrease work_mem from 64MB to 25MB: it is 64% of the host memory per connection, best practice is at most 25%.",
+			wantOK: true,
+		},
+		{
+			name:   "UnboundedMax",
+			r:      MemoryRatio{Setting: "maxmemory", Memory: "the host memory", Min: 0.1},
+			size:   8 * units.Gigabyte,
+			memory: 4 * units.Gigabyte,
+		},
+		{
+			name:   "UnknownMemory",
+			r:      bufferPool,
+			size:   128 * units.Megabyte,
+			memory: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := tc.r.Recommend(tc.size, tc.memory)
+			if got != tc.want || ok != tc.wantOK {
+				t.Errorf("Recommend(%d, %d) = (%q, %v), want (%q, %v)", tc.size, tc.memory, got, ok, tc.want, tc.wantOK)
+			}
+		})
+	}
+}
+
+func TestAddRecommendations(t *testing.T) {
+	tests := []struct {
+		name            string
+		recommendations []string
+		want            map[string]string
+	}{
+		{
+			name:            "Recommendations",
+			recommendations: []string{"Increase a.", `Decrease "b".`},
+			want: map[string]string{
+				DatabaseInstanceKey: "localhost:3306",
+				RecommendationsKey:  `["Increase a.","Decrease \"b\"."]`,
+			},
+		},
+		{
+			name: "NoRecommendations",
+			want: map[string]string{DatabaseInstanceKey: "localhost:3306"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			wm := &WorkloadMetrics{WorkloadType: MYSQL, Metrics: map[string]string{DatabaseInstanceKey: "localhost:3306"}}
+			wm.AddRecommendations(context.Background(), tc.recommendations)
+			if diff := cmp.Diff(tc.want, wm.Metrics); diff != "" {
+				t.Errorf("AddRecommendations(%v) returned unexpected diff (-want +got):\n%s", tc.recommendations, diff)
+			}
+			d, _ := testSchema.Definition(RecommendationsKey)
+			if err := d.Validate(wm.Metrics[RecommendationsKey]); err != nil {
+				t.Errorf("AddRecommendations(%v) set an invalid metric: %v", tc.recommendations, err)
+			}
+		})
+	}
+}
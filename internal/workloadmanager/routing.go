@@ -0,0 +1,52 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// RoutingWriter is a WLMWriter which writes insights to a configured project and location instead
+// of those of the instance, e.g. to centralize the insights of an organization in a monitoring project.
+type RoutingWriter struct {
+	writer   WLMWriter
+	project  string
+	location string
+}
+
+// NewRoutingWriter returns a RoutingWriter which writes to writer, or nil if config overrides
+// neither the project nor the location.
+func NewRoutingWriter(writer WLMWriter, config *cpb.DataWarehouseRouting) *RoutingWriter {
+	if config.GetProjectId() == "" && config.GetLocation() == "" {
+		return nil
+	}
+	return &RoutingWriter{writer: writer, project: config.GetProjectId(), location: config.GetLocation()}
+}
+
+// WriteInsightAndGetResponse writes the insight to the configured project and location.
+// The project and location of the instance are used for those which are not configured.
+func (r *RoutingWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	if r.project != "" {
+		project = r.project
+	}
+	if r.location != "" {
+		location = r.location
+	}
+	return r.writer.WriteInsightAndGetResponse(project, location, req)
+}
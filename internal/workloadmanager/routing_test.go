@@ -0,0 +1,71 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func TestNewRoutingWriter(t *testing.T) {
+	if got := NewRoutingWriter(&fakeWriter{}, &cpb.DataWarehouseRouting{}); got != nil {
+		t.Errorf("NewRoutingWriter() with no overrides = %v, want nil", got)
+	}
+	if got := NewRoutingWriter(&fakeWriter{}, nil); got != nil {
+		t.Errorf("NewRoutingWriter(nil) = %v, want nil", got)
+	}
+}
+
+func TestRoutingWriter(t *testing.T) {
+	req := insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"})
+	tests := []struct {
+		name   string
+		config *cpb.DataWarehouseRouting
+		want   []writeCall
+	}{
+		{
+			name:   "ProjectAndLocation",
+			config: &cpb.DataWarehouseRouting{ProjectId: "monitoring", Location: "europe-west1"},
+			want:   []writeCall{{"monitoring", "europe-west1", req}},
+		},
+		{
+			name:   "ProjectOnly",
+			config: &cpb.DataWarehouseRouting{ProjectId: "monitoring"},
+			want:   []writeCall{{"monitoring", "us-central1", req}},
+		},
+		{
+			name:   "LocationOnly",
+			config: &cpb.DataWarehouseRouting{Location: "us-east1"},
+			want:   []writeCall{{"p1", "us-east1", req}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fw := &fakeWriter{}
+			if _, err := NewRoutingWriter(fw, tc.config).WriteInsightAndGetResponse("p1", "us-central1", req); err != nil {
+				t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tc.want, fw.calls, cmp.AllowUnexported(writeCall{}), protocmp.Transform()); diff != "" {
+				t.Errorf("RoutingWriter wrote unexpected insights (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
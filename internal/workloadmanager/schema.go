@@ -0,0 +1,185 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/log"
+)
+
+// MetricType is the type of the value of a metric.
+type MetricType int
+
+// Metric types. The value of a metric of any type may be empty when it is unknown.
+const (
+	// StringMetric values are not validated.
+	StringMetric MetricType = iota
+	// IntMetric values are base 10 integers.
+	IntMetric
+	// FloatMetric values are floating point numbers.
+	FloatMetric
+	// BoolMetric values are "true" or "false".
+	BoolMetric
+	// EnumMetric values are one of the allowed values.
+	EnumMetric
+	// ListMetric values are comma separated, and each item is one of the allowed values if any are defined.
+	ListMetric
+	// TimestampMetric values are RFC 3339 timestamps.
+	TimestampMetric
+	// JSONMetric values are JSON documents.
+	JSONMetric
+)
+
+// Units of metric values. The name of a metric ends with the suffix of its unit if the unit has one.
+const (
+	UnitNone         = ""
+	UnitBytes        = "bytes"
+	UnitKilobytes    = "kilobytes"
+	UnitMegabytes    = "megabytes"
+	UnitSeconds      = "seconds"
+	UnitMilliseconds = "milliseconds"
+	UnitCount        = "count"
+	UnitRatio        = "ratio"
+)
+
+// unitSuffixes are the metric name suffixes implying a unit.
+var unitSuffixes = map[string]string{
+	"_bytes":   UnitBytes,
+	"_kb":      UnitKilobytes,
+	"_mb":      UnitMegabytes,
+	"_seconds": UnitSeconds,
+	"_ms":      UnitMilliseconds,
+}
+
+// MetricDefinition defines the name, type and unit of a metric sent to Data Warehouse.
+type MetricDefinition struct {
+	Name string
+	Type MetricType
+	Unit string
+	// AllowedValues are the values of an EnumMetric, or of the items of a ListMetric.
+	AllowedValues []string
+}
+
+// commonMetrics are the metrics set by the collectors of all workloads.
+var commonMetrics = []MetricDefinition{
+	{Name: DatabaseInstanceKey, Type: StringMetric},
+	{Name: MetricsSourceKey, Type: EnumMetric, AllowedValues: []string{MetricsSourceConfigFile}},
+	{Name: ConfigFilesKey, Type: ListMetric},
+	{Name: DataDirectoriesKey, Type: ListMetric},
+	{Name: RecommendationsKey, Type: JSONMetric},
+}
+
+// Schema is the registry of the metrics a workload sends to Data Warehouse. Metrics which are not
+// defined in the schema of a workload, or whose values don't match their definition, are not sent.
+type Schema struct {
+	definitions map[string]MetricDefinition
+}
+
+// NewSchema returns a Schema of the common metrics and definitions.
+// Schemas are defined at package level, so NewSchema panics if a metric is defined twice or its
+// unit doesn't match the suffix of its name.
+func NewSchema(definitions ...MetricDefinition) *Schema {
+	s := &Schema{definitions: make(map[string]MetricDefinition)}
+	for _, d := range append(slices.Clone(commonMetrics), definitions...) {
+		if _, ok := s.definitions[d.Name]; ok {
+			panic(fmt.Sprintf("metric %q is defined twice", d.Name))
+		}
+		for suffix, unit := range unitSuffixes {
+			if strings.HasSuffix(d.Name, suffix) && d.Unit != unit {
+				panic(fmt.Sprintf("metric %q has unit %q, want %q", d.Name, d.Unit, unit))
+			}
+		}
+		s.definitions[d.Name] = d
+	}
+	return s
+}
+
+// Definition returns the definition of the metric name.
+func (s *Schema) Definition(name string) (MetricDefinition, bool) {
+	d, ok := s.definitions[name]
+	return d, ok
+}
+
+// Validate returns an error if value is not a valid value of the metric.
+func (d MetricDefinition) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+	var err error
+	switch d.Type {
+	case IntMetric:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case FloatMetric:
+		_, err = strconv.ParseFloat(value, 64)
+	case BoolMetric:
+		if value != "true" && value != "false" {
+			err = fmt.Errorf("%q is not true or false", value)
+		}
+	case EnumMetric:
+		if !slices.Contains(d.AllowedValues, value) {
+			err = fmt.Errorf("%q is not one of %v", value, d.AllowedValues)
+		}
+	case ListMetric:
+		if len(d.AllowedValues) == 0 {
+			break
+		}
+		for _, item := range strings.Split(value, ",") {
+			if !slices.Contains(d.AllowedValues, item) {
+				err = fmt.Errorf("item %q is not one of %v", item, d.AllowedValues)
+				break
+			}
+		}
+	case TimestampMetric:
+		_, err = time.Parse(time.RFC3339, value)
+	case JSONMetric:
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("%q is not valid JSON", value)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value of metric %s: %w", d.Name, err)
+	}
+	return nil
+}
+
+// Validate returns a copy of wm without the metrics which are not defined in the schema or have
+// invalid values. Why they were removed is recorded in the Errors of the copy.
+func (s *Schema) Validate(ctx context.Context, wm WorkloadMetrics) WorkloadMetrics {
+	wm.Metrics = maps.Clone(wm.Metrics)
+	wm.Errors = maps.Clone(wm.Errors)
+	for name, value := range wm.Metrics {
+		d, ok := s.definitions[name]
+		if !ok {
+			log.CtxLogger(ctx).Warnw("Not sending metric which is not defined in the schema", "workload_type", wm.WorkloadType, "metric", name)
+			wm.SetError(name, fmt.Errorf("metric %s is not defined in the schema", name))
+			continue
+		}
+		if err := d.Validate(value); err != nil {
+			log.CtxLogger(ctx).Warnw("Not sending metric with an invalid value", "workload_type", wm.WorkloadType, "metric", name, "error", err)
+			wm.SetError(name, err)
+		}
+	}
+	return wm
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloadmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
+)
+
+var testSchema = NewSchema(
+	MetricDefinition{Name: "name", Type: StringMetric},
+	MetricDefinition{Name: "size_bytes", Type: IntMetric, Unit: UnitBytes},
+	MetricDefinition{Name: "ratio", Type: FloatMetric, Unit: UnitRatio},
+	MetricDefinition{Name: "enabled", Type: BoolMetric},
+	MetricDefinition{Name: "role", Type: EnumMetric, AllowedValues: []string{"primary", "replica"}},
+	MetricDefinition{Name: "zones", Type: ListMetric},
+	MetricDefinition{Name: "tools", Type: ListMetric, AllowedValues: []string{"a", "b"}},
+	MetricDefinition{Name: "last_backup", Type: TimestampMetric},
+	MetricDefinition{Name: "details", Type: JSONMetric},
+)
+
+func TestNewSchemaPanics(t *testing.T) {
+	tests := []struct {
+		name        string
+		definitions []MetricDefinition
+	}{
+		{
+			name: "DuplicateMetric",
+			definitions: []MetricDefinition{
+				{Name: "a", Type: StringMetric},
+				{Name: "a", Type: IntMetric},
+			},
+		},
+		{
+			name:        "DuplicateCommonMetric",
+			definitions: []MetricDefinition{{Name: DatabaseInstanceKey, Type: StringMetric}},
+		},
+		{
+			name:        "MissingUnit",
+			definitions: []MetricDefinition{{Name: "size_bytes", Type: IntMetric}},
+		},
+		{
+			name:        "WrongUnit",
+			definitions: []MetricDefinition{{Name: "lag_seconds", Type: IntMetric, Unit: UnitMilliseconds}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("NewSchema(%v) did not panic", tc.definitions)
+				}
+			}()
+			NewSchema(tc.definitions...)
+		})
+	}
+}
+
+func TestMetricDefinitionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  string
+		value   string
+		wantErr bool
+	}{
+		{name: "EmptyValue", metric: "size_bytes", value: ""},
+		{name: "String", metric: "name", value: "anything, really"},
+		{name: "Int", metric: "size_bytes", value: "1024"},
+		{name: "IntWithUnit", metric: "size_bytes", value: "1K", wantErr: true},
+		{name: "Float", metric: "ratio", value: "0.25"},
+		{name: "InvalidFloat", metric: "ratio", value: "25%", wantErr: true},
+		{name: "Bool", metric: "enabled", value: "false"},
+		{name: "InvalidBool", metric: "enabled", value: "yes", wantErr: true},
+		{name: "Enum", metric: "role", value: "replica"},
+		{name: "InvalidEnum", metric: "role", value: "Replica", wantErr: true},
+		{name: "List", metric: "zones", value: "us-central1-a,us-central1-b"},
+		{name: "ListAllowedValues", metric: "tools", value: "a,b"},
+		{name: "InvalidListItem", metric: "tools", value: "a,c", wantErr: true},
+		{name: "Timestamp", metric: "last_backup", value: "2025-01-02T03:04:05Z"},
+		{name: "InvalidTimestamp", metric: "last_backup", value: "1735787045", wantErr: true},
+		{name: "JSON", metric: "details", value: `[{"name":"t1"}]`},
+		{name: "InvalidJSON", metric: "details", value: `[{"name":}]`, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d, ok := testSchema.Definition(tc.metric)
+			if !ok {
+				t.Fatalf("Definition(%q) returned false, want true", tc.metric)
+			}
+			err := d.Validate(tc.value)
+			if gotErr := err != nil; gotErr != tc.wantErr {
+				t.Errorf("Validate(%q) returned error: %v, want error: %v", tc.value, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	wm := WorkloadMetrics{
+		WorkloadType: MYSQL,
+		Metrics: map[string]string{
+			DatabaseInstanceKey: "localhost:3306",
+			"size_bytes":        "1024",
+			"enabled":           "yes",
+			"unknown":           "1",
+		},
+		Errors: map[string]string{"step": "failed"},
+	}
+
+	got := testSchema.Validate(context.Background(), wm)
+	want := WorkloadMetrics{
+		WorkloadType: MYSQL,
+		Metrics: map[string]string{
+			DatabaseInstanceKey: "localhost:3306",
+			"size_bytes":        "1024",
+		},
+		Errors: map[string]string{
+			"step":    "failed",
+			"enabled": `invalid value of metric enabled: "yes" is not true or false`,
+			"unknown": "metric unknown is not defined in the schema",
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Validate() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	if len(wm.Metrics) != 4 || len(wm.Errors) != 1 {
+		t.Errorf("Validate() modified its input: %v", wm)
+	}
+}
+
+func TestSendDataInsightSchema(t *testing.T) {
+	fw := &fakeWriter{}
+	_, err := SendDataInsight(context.Background(), SendDataInsightParams{
+		WLMetrics: WorkloadMetrics{
+			WorkloadType: MYSQL,
+			Metrics:      map[string]string{"size_bytes": "1024", "size": "1024"},
+		},
+		CloudProps: &cpb.CloudProperties{ProjectId: "test-project", InstanceId: "test-instance", Region: "us-central1"},
+		WLMService: fw,
+		Schema:     testSchema,
+	})
+	if err != nil {
+		t.Fatalf("SendDataInsight() returned unexpected error: %v", err)
+	}
+	if len(fw.calls) != 1 {
+		t.Fatalf("SendDataInsight() wrote %d insights, want 1", len(fw.calls))
+	}
+	want := map[string]string{
+		"size_bytes":                   "1024",
+		"size" + CollectionErrorSuffix: "metric size is not defined in the schema",
+	}
+	got := fw.calls[0].req.GetInsight().GetTorsoValidation().GetValidationDetails()
+	if diff := cmp.Diff(want, got, protocmp.Transform()); diff != "" {
+		t.Errorf("SendDataInsight() sent unexpected validation details (-want +got):\n%s", diff)
+	}
+}
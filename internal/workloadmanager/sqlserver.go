@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package workloadmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	wlmngr "google.golang.org/api/workloadmanager/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+// SQLServerInsightRequest converts a SQL Server insight of the Workload Manager API into a request
+// which can be written by a WLMWriter.
+// The Data Warehouse request carries the SQL Server validation as JSON.
+func SQLServerInsightRequest(req *wlmngr.WriteInsightRequest) (*dwpb.WriteInsightRequest, error) {
+	if req == nil || req.Insight == nil || req.Insight.SqlserverValidation == nil {
+		return nil, errors.New("the request has no SQL Server validation")
+	}
+	validation, err := json.Marshal(req.Insight.SqlserverValidation)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling the SQL Server validation: %w", err)
+	}
+	insight := &dwpb.Insight{
+		InstanceId:          req.Insight.InstanceId,
+		SqlserverValidation: string(validation),
+	}
+	if sentTime, err := time.Parse(time.RFC3339, req.Insight.SentTime); err == nil {
+		insight.SentTime = timestamppb.New(sentTime)
+	}
+	return &dwpb.WriteInsightRequest{
+		Insight:      insight,
+		RequestId:    req.RequestId,
+		AgentVersion: req.AgentVersion,
+	}, nil
+}
+
+// sqlServerValidation returns the SQL Server validation carried by req, or nil if there is none.
+func sqlServerValidation(req *dwpb.WriteInsightRequest) (*wlmngr.SqlserverValidation, error) {
+	v := req.GetInsight().GetSqlserverValidation()
+	if v == "" {
+		return nil, nil
+	}
+	validation := &wlmngr.SqlserverValidation{}
+	if err := json.Unmarshal([]byte(v), validation); err != nil {
+		return nil, fmt.Errorf("unmarshalling the SQL Server validation: %w", err)
+	}
+	return validation, nil
+}
+
+// sqlServerInstance identifies the SQL Server instance and validation types of req.
+// The SQL and OS collections of an instance write separate insights, so both are part of it.
+func sqlServerInstance(req *dwpb.WriteInsightRequest) string {
+	validation, err := sqlServerValidation(req)
+	if err != nil || validation == nil {
+		return req.GetInsight().GetSqlserverValidation()
+	}
+	types := make([]string, 0, len(validation.ValidationDetails))
+	for _, d := range validation.ValidationDetails {
+		types = append(types, d.Type)
+	}
+	sort.Strings(types)
+	return validation.Instance + "/" + strings.Join(types, ",")
+}
+
+// insightWorkloadType returns the workload type of the insight in req.
+func insightWorkloadType(req *dwpb.WriteInsightRequest) string {
+	if req.GetInsight().GetSqlserverValidation() != "" {
+		return string(SQLSERVER)
+	}
+	return req.GetInsight().GetTorsoValidation().GetWorkloadType().String()
+}
+
+// insightDetails returns the validation details of the insight in req.
+// The fields of a SQL Server validation are flattened to keys of the form type.index.field.
+func insightDetails(req *dwpb.WriteInsightRequest) map[string]string {
+	validation, err := sqlServerValidation(req)
+	if err != nil || validation == nil {
+		return req.GetInsight().GetTorsoValidation().GetValidationDetails()
+	}
+	details := make(map[string]string)
+	for _, vd := range validation.ValidationDetails {
+		for i, d := range vd.Details {
+			for k, v := range d.Fields {
+				details[fmt.Sprintf("%s.%d.%s", vd.Type, i, k)] = v
+			}
+		}
+	}
+	return details
+}
+
+// dataWarehouseWriter writes insights to a Data Warehouse endpoint.
+// SQL Server insights are written with the Workload Manager API, which takes their validation as a message.
+type dataWarehouseWriter struct {
+	wlm       *wlm.WLM
+	sqlServer *wlmngr.Service
+}
+
+// WriteInsightAndGetResponse writes the insight to Data Warehouse.
+func (w *dataWarehouseWriter) WriteInsightAndGetResponse(project, location string, req *dwpb.WriteInsightRequest) (*wlm.WriteInsightResponse, error) {
+	validation, err := sqlServerValidation(req)
+	if err != nil {
+		return nil, err
+	}
+	if validation == nil {
+		return w.wlm.WriteInsightAndGetResponse(project, location, req)
+	}
+	sqlReq := &wlmngr.WriteInsightRequest{
+		Insight: &wlmngr.Insight{
+			InstanceId:          req.GetInsight().GetInstanceId(),
+			SqlserverValidation: validation,
+		},
+		RequestId:    req.GetRequestId(),
+		AgentVersion: req.GetAgentVersion(),
+	}
+	if req.GetInsight().GetSentTime() != nil {
+		sqlReq.Insight.SentTime = req.GetInsight().GetSentTime().AsTime().Format(time.RFC3339)
+	}
+	res, err := w.sqlServer.Projects.Locations.Insights.WriteInsight(fmt.Sprintf("projects/%s/locations/%s", project, location), sqlReq).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &wlm.WriteInsightResponse{ServerResponse: res.ServerResponse}, nil
+}
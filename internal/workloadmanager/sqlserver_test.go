@@ -0,0 +1,179 @@
+/*
+Copyright 2025 Google LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package workloadmanager
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/api/option"
+	wlmngr "google.golang.org/api/workloadmanager/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
+	dwpb "github.com/GoogleCloudPlatform/workloadagentplatform/sharedprotos/datawarehouse"
+)
+
+func sqlServerRequest(instance string, types ...string) *wlmngr.WriteInsightRequest {
+	validation := &wlmngr.SqlserverValidation{ProjectId: "test-project", Instance: instance}
+	for _, t := range types {
+		validation.ValidationDetails = append(validation.ValidationDetails, &wlmngr.SqlserverValidationValidationDetail{
+			Type:    t,
+			Details: []*wlmngr.SqlserverValidationDetails{{Fields: map[string]string{"name": instance}}},
+		})
+	}
+	return &wlmngr.WriteInsightRequest{
+		Insight: &wlmngr.Insight{
+			InstanceId:          "test-instance-id",
+			SentTime:            "2025-01-02T03:04:05Z",
+			SqlserverValidation: validation,
+		},
+	}
+}
+
+func mustSQLServerInsightRequest(t *testing.T, req *wlmngr.WriteInsightRequest) *dwpb.WriteInsightRequest {
+	t.Helper()
+	got, err := SQLServerInsightRequest(req)
+	if err != nil {
+		t.Fatalf("SQLServerInsightRequest() returned unexpected error: %v", err)
+	}
+	return got
+}
+
+func TestSQLServerInsightRequest(t *testing.T) {
+	req := sqlServerRequest("sql1", "DB_LOG_DISK_SEPARATION")
+	got := mustSQLServerInsightRequest(t, req)
+
+	want := &dwpb.WriteInsightRequest{
+		Insight: &dwpb.Insight{
+			InstanceId: "test-instance-id",
+			SentTime:   timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+	}
+	if diff := cmp.Diff(want, got, protocmp.Transform(), protocmp.IgnoreFields(&dwpb.Insight{}, "sqlserver_validation")); diff != "" {
+		t.Errorf("SQLServerInsightRequest() returned unexpected diff (-want +got):\n%s", diff)
+	}
+	validation, err := sqlServerValidation(got)
+	if err != nil {
+		t.Fatalf("sqlServerValidation() returned unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(req.Insight.SqlserverValidation, validation); diff != "" {
+		t.Errorf("sqlServerValidation() returned unexpected diff (-want +got):\n%s", diff)
+	}
+
+	if _, err := SQLServerInsightRequest(&wlmngr.WriteInsightRequest{}); err == nil {
+		t.Error("SQLServerInsightRequest() returned nil error for a request without a SQL Server validation, want error")
+	}
+}
+
+func TestNewBatchKeySQLServer(t *testing.T) {
+	sql1 := newBatchKey("p", "l", mustSQLServerInsightRequest(t, sqlServerRequest("sql1", "DB_LOG_DISK_SEPARATION", "DB_MAX_PARALLELISM")))
+	sql1Reordered := newBatchKey("p", "l", mustSQLServerInsightRequest(t, sqlServerRequest("sql1", "DB_MAX_PARALLELISM", "DB_LOG_DISK_SEPARATION")))
+	sql1OS := newBatchKey("p", "l", mustSQLServerInsightRequest(t, sqlServerRequest("sql1", "OS")))
+	sql2 := newBatchKey("p", "l", mustSQLServerInsightRequest(t, sqlServerRequest("sql2", "DB_LOG_DISK_SEPARATION", "DB_MAX_PARALLELISM")))
+
+	if sql1 != sql1Reordered {
+		t.Errorf("newBatchKey() returned %v and %v for the same validation types, want equal keys", sql1, sql1Reordered)
+	}
+	if sql1 == sql1OS {
+		t.Errorf("newBatchKey() returned %v for the SQL and OS collections, want different keys", sql1)
+	}
+	if sql1 == sql2 {
+		t.Errorf("newBatchKey() returned %v for different SQL Server instances, want different keys", sql1)
+	}
+}
+
+func TestMergeInsightSQLServer(t *testing.T) {
+	dst := mustSQLServerInsightRequest(t, sqlServerRequest("sql1", "OS"))
+	src := mustSQLServerInsightRequest(t, sqlServerRequest("sql1", "OS"))
+	src.Insight.SqlserverValidation = `{"instance":"sql1","projectId":"updated"}`
+
+	mergeInsight(dst, src)
+	if got := dst.GetInsight().GetSqlserverValidation(); got != src.GetInsight().GetSqlserverValidation() {
+		t.Errorf("mergeInsight() set SQL Server validation %q, want %q", got, src.GetInsight().GetSqlserverValidation())
+	}
+}
+
+func TestDataWarehouseWriterSQLServer(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		b, _ := io.ReadAll(r.Body)
+		json.Unmarshal(b, &gotBody)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+
+	sqlServer, err := wlmngr.NewService(t.Context(), option.WithEndpoint(server.URL+"/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("wlmngr.NewService() returned unexpected error: %v", err)
+	}
+	w := &dataWarehouseWriter{wlm: &wlm.WLM{}, sqlServer: sqlServer}
+	res, err := w.WriteInsightAndGetResponse("test-project", "us-central1", mustSQLServerInsightRequest(t, sqlServerRequest("sql1", "OS")))
+	if err != nil {
+		t.Fatalf("WriteInsightAndGetResponse() returned unexpected error: %v", err)
+	}
+	if res.HTTPStatusCode != http.StatusCreated {
+		t.Errorf("WriteInsightAndGetResponse() returned status code %d, want %d", res.HTTPStatusCode, http.StatusCreated)
+	}
+	if want := "/v1/projects/test-project/locations/us-central1/insights:writeInsight"; gotPath != want {
+		t.Errorf("WriteInsightAndGetResponse() wrote to %q, want %q", gotPath, want)
+	}
+	insight, _ := gotBody["insight"].(map[string]any)
+	validation, _ := insight["sqlserverValidation"].(map[string]any)
+	if validation["instance"] != "sql1" {
+		t.Errorf("WriteInsightAndGetResponse() wrote SQL Server validation %v, want the validation of instance sql1", insight["sqlserverValidation"])
+	}
+}
+
+func TestInsightDetails(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              *dwpb.WriteInsightRequest
+		wantWorkloadType string
+		wantDetails      map[string]string
+	}{
+		{
+			name:             "TorsoValidation",
+			req:              insightRequest("i1", dwpb.TorsoValidation_MYSQL, map[string]string{"a": "1"}),
+			wantWorkloadType: "MYSQL",
+			wantDetails:      map[string]string{"a": "1"},
+		},
+		{
+			name:             "SQLServerValidation",
+			req:              mustSQLServerInsightRequest(t, sqlServerRequest("sql1", "OS", "DB_MAX_PARALLELISM")),
+			wantWorkloadType: "SQLSERVER",
+			wantDetails:      map[string]string{"OS.0.name": "sql1", "DB_MAX_PARALLELISM.0.name": "sql1"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := insightWorkloadType(tc.req); got != tc.wantWorkloadType {
+				t.Errorf("insightWorkloadType() = %q, want %q", got, tc.wantWorkloadType)
+			}
+			if diff := cmp.Diff(tc.wantDetails, insightDetails(tc.req)); diff != "" {
+				t.Errorf("insightDetails() returned unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,13 +21,24 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"google.golang.org/api/option"
+	wlmngr "google.golang.org/api/workloadmanager/v1"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/agenterrors"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/audit"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/capabilities"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/credentials"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/daemon/configuration"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/endpoints"
+	"github.com/GoogleCloudPlatform/workloadagent/internal/statestore"
 	"github.com/GoogleCloudPlatform/workloadagent/internal/usagemetrics"
 	cpb "github.com/GoogleCloudPlatform/workloadagent/protos/configuration"
 	"github.com/GoogleCloudPlatform/workloadagentplatform/sharedlibraries/gce/wlm"
@@ -54,15 +65,107 @@ const (
 	POSTGRES WorkloadType = "POSTGRES"
 	// MONGODB workload type.
 	MONGODB WorkloadType = "MONGODB"
+	// SQLSERVER workload type, its insights carry a SQL Server validation instead of a torso validation.
+	SQLSERVER WorkloadType = "SQLSERVER"
 	// collectionFrequency is the frequency at which metrics are collected.
 	collectionFrequency = 5 * time.Minute
 )
 
+// DatabaseInstanceKey is the validation detail which identifies the database instance an insight
+// was collected from when metrics are collected from multiple instances of a workload on the host.
+const DatabaseInstanceKey = "database_instance"
+
+const (
+	// MetricsSourceKey is set to MetricsSourceConfigFile on the insights of a database the agent could not
+	// connect to, whose metrics were read from its configuration files instead.
+	MetricsSourceKey = "metrics_source"
+	// MetricsSourceConfigFile labels file-derived insights.
+	MetricsSourceConfigFile = "config_file"
+	// ConfigFilesKey holds the comma separated configuration files a file-derived insight was read from.
+	ConfigFilesKey = "config_files"
+)
+
+const (
+	// CollectionTimeKey is the validation detail holding when the collection of an insight started, in RFC 3339 format.
+	CollectionTimeKey = "collection_time"
+	// CollectionDurationKey is the validation detail holding how many milliseconds the collection of an insight took.
+	CollectionDurationKey = "collection_duration_ms"
+	// CollectionErrorSuffix is appended to the key of a metric which could not be collected.
+	// The validation detail holds the error instead of a placeholder value such as "0".
+	CollectionErrorSuffix = "_collection_error"
+)
+
+// torsoWorkloadTypes maps workload types to their Data Warehouse workload type.
+// POSTGRES and MONGODB have no Data Warehouse workload type yet, so they are sent as unspecified.
+var torsoWorkloadTypes = map[WorkloadType]dwpb.TorsoValidation_WorkloadType{
+	ORACLE:  dwpb.TorsoValidation_ORACLE,
+	MYSQL:   dwpb.TorsoValidation_MYSQL,
+	REDIS:   dwpb.TorsoValidation_REDIS,
+	UNKNOWN: dwpb.TorsoValidation_WORKLOAD_TYPE_UNSPECIFIED,
+}
+
 // WorkloadMetrics is a struct that collect data from override configuration file for testing purposes.
 // Future enhancements will include the collection of actual WLM metrics.
 type WorkloadMetrics struct {
 	WorkloadType WorkloadType
 	Metrics      map[string]string
+	// CollectionTime is when the collection of the metrics started, it is not sent if zero.
+	CollectionTime time.Time
+	// CollectionDuration is how long the collection of the metrics took.
+	CollectionDuration time.Duration
+	// Errors holds why metrics or collection steps failed, keyed by metric or step.
+	Errors map[string]string
+}
+
+// SetError records that the metric key could not be collected.
+// The metric is removed so that Data Warehouse can tell a failed collection from a real value.
+func (wm *WorkloadMetrics) SetError(key string, err error) {
+	delete(wm.Metrics, key)
+	if wm.Errors == nil {
+		wm.Errors = make(map[string]string)
+	}
+	wm.Errors[key] = err.Error()
+}
+
+// AddMetrics merges the metrics of a collection step into wm.
+// A failed step is recorded under its name, the metrics collected by the other steps are still sent.
+func (wm *WorkloadMetrics) AddMetrics(step string, metrics map[string]string, err error) {
+	if err != nil {
+		if wm.Errors == nil {
+			wm.Errors = make(map[string]string)
+		}
+		wm.Errors[step] = err.Error()
+	}
+	if len(metrics) > 0 && wm.Metrics == nil {
+		wm.Metrics = make(map[string]string)
+	}
+	for k, v := range metrics {
+		wm.Metrics[k] = v
+	}
+}
+
+// Failed reports whether any metric or collection step of wm failed.
+func (wm *WorkloadMetrics) Failed() bool {
+	return wm != nil && len(wm.Errors) > 0
+}
+
+// validationDetails returns the metrics of wm annotated with their collection metadata.
+func validationDetails(wm WorkloadMetrics) map[string]string {
+	if wm.CollectionTime.IsZero() && len(wm.Errors) == 0 {
+		return wm.Metrics
+	}
+	details := make(map[string]string, len(wm.Metrics)+len(wm.Errors)+2)
+	for k, v := range wm.Metrics {
+		details[k] = v
+	}
+	if !wm.CollectionTime.IsZero() {
+		details[CollectionTimeKey] = wm.CollectionTime.UTC().Format(time.RFC3339Nano)
+		details[CollectionDurationKey] = strconv.FormatInt(wm.CollectionDuration.Milliseconds(), 10)
+	}
+	for k, err := range wm.Errors {
+		details[k+CollectionErrorSuffix] = err
+	}
+	return details
 }
 
 // WLMWriter is an interface for writing insights to Data Warehouse.
@@ -82,6 +185,10 @@ type SendDataInsightParams struct {
 	WLMetrics  WorkloadMetrics
 	CloudProps *cpb.CloudProperties
 	WLMService WLMWriter
+	// StrictWorkloadTypes rejects workload types without a Data Warehouse workload type.
+	StrictWorkloadTypes bool
+	// Schema defines the metrics of the workload, metrics are sent without validation if it is nil.
+	Schema *Schema
 }
 
 // metricEmitter is a container for constructing metrics from an override configuration file
@@ -97,52 +204,239 @@ type Service struct {
 	Client WLMWriter
 }
 
-// MetricOverridePath is the path to the metric override file.
-const MetricOverridePath = "/etc/google-cloud-workload-agent/wlmmetricoverride.yaml"
+const (
+	// LinuxMetricOverridePath is the default path to the metric override file on linux.
+	LinuxMetricOverridePath = `/etc/google-cloud-workload-agent/wlmmetricoverride.yaml`
+	// WindowsMetricOverridePath is the default path to the metric override file on windows.
+	WindowsMetricOverridePath = `C:\Program Files\Google\google-cloud-workload-agent\conf\wlmmetricoverride.yaml`
+)
+
+// MetricOverridePath returns the path to the metric override file.
+// The path set in the configuration takes precedence over the default path of the operating system.
+func MetricOverridePath(config *cpb.Configuration) string {
+	if path := config.GetMetricOverridePath(); path != "" {
+		return path
+	}
+	return capabilities.ByOS(LinuxMetricOverridePath, WindowsMetricOverridePath)
+}
 
 // Client creates a new WLM client.
+// If Data Warehouse export is enabled insights are written to a local file instead.
+// If Data Warehouse batching is enabled the client aggregates the insights of the same workload before writing them.
+// If the audit log is enabled every write is recorded in it.
+// If the insight log is enabled every insight written is also written to Cloud Logging.
+// Writes are paused for a cool-down after Data Warehouse repeatedly rejects them.
+// If Data Warehouse deduplication is enabled unchanged insights are only written once they are stale.
+// Excluded validation detail keys and workload types are removed before insights are written.
+// Insights are also written to the additional Data Warehouse destinations.
+// If the Pub/Sub export is enabled the metrics of every insight are also published, after filtering.
+// If the BigQuery export is enabled the validation details of every insight are also streamed into a table.
 func Client(ctx context.Context, config *cpb.Configuration) (WLMWriter, error) {
-	client, err := wlm.NewWLMClient(ctx, config.GetDataWarehouseEndpoint())
+	client, err := auditedClient(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	if config.GetInsightLog().GetEnabled() {
+		client = insightLogWriter(ctx, client, config)
+	}
+	client = NewCircuitBreakerWriter(client, config.GetDataWarehouseCircuitBreaker())
+	if len(config.GetDataWarehouseAdditionalDestinations()) > 0 {
+		destinations, err := additionalDestinations(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		client = NewFanOutWriter(client, destinations)
+	}
+	// Skipped insights are compared after filtering and are not audited.
+	if dedup := config.GetDataWarehouseDeduplication(); dedup.GetEnabled() {
+		client = NewDedupWriter(client, dedup, statestore.Default())
+	}
+	// Metrics are published every collection, including those which are not written again.
+	if export := config.GetPubsubExport(); export.GetEnabled() {
+		publisher, err := newPubSubPublisher(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		log.CtxLogger(ctx).Infow("Publishing workload metrics to Pub/Sub", "topic", export.GetTopic())
+		client = NewPubSubWriter(client, publisher, export.GetTopic())
+	}
+	if export := config.GetBigqueryExport(); export.GetEnabled() {
+		bigQueryClient, err := newBigQueryClient(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		log.CtxLogger(ctx).Infow("Streaming validation details to BigQuery", "dataset", export.GetDataset(), "table", export.GetTable())
+		client = NewBigQueryWriter(client, bigQueryClient, export)
+	}
+	if filter := NewFilter(config.GetDataWarehouseFilter()); filter != nil {
+		client = NewFilterWriter(client, filter)
+	}
+	if routing := NewRoutingWriter(client, config.GetDataWarehouseRouting()); routing != nil {
+		log.CtxLogger(ctx).Infow("Routing Data Warehouse insights", "project", routing.project, "location", routing.location)
+		client = routing
+	}
+	batching := config.GetDataWarehouseBatching()
+	if !batching.GetEnabled() {
+		return client, nil
+	}
+	batchWriter := NewBatchWriter(client, batching.GetFlushInterval().AsDuration())
+	log.CtxLogger(ctx).Infow("Batching Data Warehouse insights, only insights of the same workload and database instance are merged", "flushInterval", batchWriter.interval)
+	return batchWriter, nil
+}
+
+// ActivationClient creates the WLM client which checks whether Data Warehouse is activated.
+// The check is written to the same destination as the insights, file export, audit and routing included,
+// but it is never skipped, batched or paused, and it is not exported anywhere else.
+func ActivationClient(ctx context.Context, config *cpb.Configuration) (WLMWriter, error) {
+	client, err := auditedClient(ctx, config)
 	if err != nil {
 		return nil, err
 	}
+	if routing := NewRoutingWriter(client, config.GetDataWarehouseRouting()); routing != nil {
+		client = routing
+	}
+	return client, nil
+}
+
+// auditedClient creates the WLM client writing to Data Warehouse, or to a file if the export is enabled.
+// If the audit log is enabled every write is recorded in it.
+func auditedClient(ctx context.Context, config *cpb.Configuration) (WLMWriter, error) {
+	var client WLMWriter
+	if export := config.GetDataWarehouseExport(); export.GetEnabled() {
+		log.CtxLogger(ctx).Infow("Exporting Data Warehouse insights to file", "filePath", export.GetFilePath())
+		client = NewFileWriter(export.GetFilePath())
+	} else {
+		wlmClient, err := newWLMClient(ctx, config, config.GetDataWarehouseEndpoint())
+		if err != nil {
+			return nil, err
+		}
+		client = wlmClient
+	}
+	// Audit the calls actually made, after any batching.
+	if l := audit.FromConfig(config); l != nil {
+		client = NewAuditWriter(client, l, config)
+	}
 	return client, nil
 }
 
+// insightLogWriter returns an InsightLogWriter which writes to the configured Cloud Logging log.
+// writer is returned as is if Cloud Logging cannot be reached.
+func insightLogWriter(ctx context.Context, writer WLMWriter, config *cpb.Configuration) WLMWriter {
+	client := endpoints.CloudLoggingClient(ctx, config.GetCloudProperties().GetProjectId(), config.GetEndpoints())
+	if client == nil {
+		log.CtxLogger(ctx).Warn("Cloud Logging cannot be reached, insights are not written to the insight log")
+		return writer
+	}
+	logName := config.GetInsightLog().GetLogName()
+	if logName == "" {
+		logName = DefaultInsightLogName
+	}
+	log.CtxLogger(ctx).Infow("Writing insights to Cloud Logging", "logName", logName)
+	return NewInsightLogWriter(writer, client.Logger(logName))
+}
+
+// additionalDestinations creates a writer for each additional Data Warehouse destination.
+// Each destination is audited and paused after repeated rejections independently of the others.
+func additionalDestinations(ctx context.Context, config *cpb.Configuration) ([]Destination, error) {
+	l := audit.FromConfig(config)
+	var destinations []Destination
+	for _, d := range config.GetDataWarehouseAdditionalDestinations() {
+		endpoint := d.GetEndpoint()
+		if endpoint == "" {
+			endpoint = config.GetDataWarehouseEndpoint()
+		}
+		wlmClient, err := newWLMClient(ctx, config, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("creating WLM client for additional destination %q: %w", endpoint, err)
+		}
+		var writer WLMWriter = wlmClient
+		if routing := NewRoutingWriter(writer, &cpb.DataWarehouseRouting{ProjectId: d.GetProjectId(), Location: d.GetLocation()}); routing != nil {
+			writer = routing
+		}
+		if endpoint == "" {
+			endpoint = defaultDataWarehouseEndpoint
+		}
+		if l != nil {
+			writer = &AuditWriter{writer: writer, log: l, endpoint: endpoint}
+		}
+		name := endpoint
+		if d.GetProjectId() != "" {
+			name += " (" + d.GetProjectId() + ")"
+		}
+		log.CtxLogger(ctx).Infow("Writing Data Warehouse insights to additional destination", "destination", name, "location", d.GetLocation())
+		destinations = append(destinations, Destination{
+			Name:   name,
+			Writer: NewCircuitBreakerWriter(writer, config.GetDataWarehouseCircuitBreaker()),
+		})
+	}
+	return destinations, nil
+}
+
+// newWLMClient creates a client for the Data Warehouse endpoint authenticating with the configured credentials.
+func newWLMClient(ctx context.Context, config *cpb.Configuration, endpoint string) (*dataWarehouseWriter, error) {
+	opts, err := credentials.ClientOptions(ctx, config.GetCredentials())
+	if err != nil {
+		return nil, err
+	}
+	sqlServerEndpoint := endpoint
+	if sqlServerEndpoint == "" {
+		sqlServerEndpoint = defaultDataWarehouseEndpoint
+	}
+	sqlServer, err := wlmngr.NewService(ctx, append(opts, option.WithEndpoint(sqlServerEndpoint))...)
+	if err != nil {
+		return nil, fmt.Errorf("creating WLM client for SQL Server: %w", err)
+	}
+	if len(opts) == 0 {
+		client, err := wlm.NewWLMClient(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		return &dataWarehouseWriter{wlm: client, sqlServer: sqlServer}, nil
+	}
+	opts = append(opts, option.WithEndpoint(endpoint))
+	s, err := wlm.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating WLM client: %w", err)
+	}
+	log.CtxLogger(ctx).Infow("WLM Service with configured credentials", "basePath", s.BasePath)
+	return &dataWarehouseWriter{wlm: &wlm.WLM{Service: s}, sqlServer: sqlServer}, nil
+}
+
 // CollectAndSendMetricsToDataWarehouse collects workload metrics and sends them to Data Warehouse.
 func (s *Service) CollectAndSendMetricsToDataWarehouse(ctx context.Context, a any) {
-	if !readAndLogMetricOverrideYAML(ctx, readFileWrapper) {
+	path := MetricOverridePath(s.Config)
+	if !readAndLogMetricOverrideYAML(ctx, path, readFileWrapper) {
 		return
 	}
 
 	ticker := time.NewTicker(collectionFrequency)
 	defer ticker.Stop()
+	// Watch the override file so its metrics can be changed without restarting the agent.
+	watch := time.NewTicker(overrideWatchFrequency)
+	defer watch.Stop()
+	modTime := overrideModTime(path, os.Stat)
 	for {
-		wm := collectOverrideMetrics(ctx, readFileWrapper)
+		wm := collectOverrideMetrics(ctx, path, readFileWrapper)
 		sendMetricsToDataWarehouse(ctx, sendMetricsParams{
 			wm:         wm,
 			cp:         s.Config.GetCloudProperties(),
 			wlmService: s.Client,
 		})
-		select {
-		case <-ctx.Done():
-			log.CtxLogger(ctx).Info("Metric collection override cancellation requested")
+		if !waitForNextCollection(ctx, path, ticker.C, watch.C, &modTime, os.Stat) {
 			return
-		case <-ticker.C:
-			continue
 		}
 	}
 }
 
-func readAndLogMetricOverrideYAML(ctx context.Context, reader ConfigFileReader) bool {
-	file, err := reader(MetricOverridePath)
+func readAndLogMetricOverrideYAML(ctx context.Context, path string, reader ConfigFileReader) bool {
+	file, err := reader(path)
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("Could not read the metric override file", "error", err)
 		return false
 	}
 	defer file.Close()
 
-	log.CtxLogger(ctx).Infow("Reading override metrics from yaml file", "file", MetricOverridePath)
+	log.CtxLogger(ctx).Infow("Reading override metrics from yaml file", "file", path)
 	// Create a new scanner
 	scanner := bufio.NewScanner(file)
 	// Loop over each line in the file
@@ -157,16 +451,26 @@ func readAndLogMetricOverrideYAML(ctx context.Context, reader ConfigFileReader)
 }
 
 // collectOverrideMetrics reads workload metrics from an override file.
-func collectOverrideMetrics(ctx context.Context, reader ConfigFileReader) []WorkloadMetrics {
-	file, err := reader(MetricOverridePath)
+// The file either uses the workloads layout described by overrideFile, or the legacy layout
+// of workload_type lines each followed by the key: value lines of that workload.
+func collectOverrideMetrics(ctx context.Context, path string, reader ConfigFileReader) []WorkloadMetrics {
+	file, err := reader(path)
 	if err != nil {
 		log.CtxLogger(ctx).Debugw("Could not read the metric override file", "error", err)
 		return []WorkloadMetrics{}
 	}
 	defer file.Close()
+	content, err := io.ReadAll(file)
+	if err != nil {
+		log.CtxLogger(ctx).Debugw("Could not read the metric override file", "error", err)
+		return []WorkloadMetrics{}
+	}
+	if wm, ok := parseOverrideYAML(ctx, content); ok {
+		return wm
+	}
 
 	var wm []WorkloadMetrics
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
 	metricEmitter := metricEmitter{scanner: scanner}
 	for {
 		wt, metrics, last := metricEmitter.getMetric(ctx)
@@ -248,17 +552,41 @@ func sendMetricsToDataWarehouse(ctx context.Context, params sendMetricsParams) {
 // QuietSendDataInsight sends a data insight to Data Warehouse without logging.
 // This is used for the data warehouse activation check.
 func QuietSendDataInsight(ctx context.Context, params SendDataInsightParams) (*wlm.WriteInsightResponse, error) {
+	if err := checkWorkloadType(params); err != nil {
+		return nil, err
+	}
 	req := createWriteInsightRequest(ctx, params.WLMetrics, params.CloudProps)
 	return params.WLMService.WriteInsightAndGetResponse(params.CloudProps.GetProjectId(), params.CloudProps.GetRegion(), req)
 }
 
+// isActivationProbe reports whether req is the activation check, an insight of an unspecified
+// workload without validation details. It is only written to Data Warehouse.
+func isActivationProbe(req *dwpb.WriteInsightRequest) bool {
+	tv := req.GetInsight().GetTorsoValidation()
+	return tv.GetWorkloadType() == dwpb.TorsoValidation_WORKLOAD_TYPE_UNSPECIFIED && len(tv.GetValidationDetails()) == 0 &&
+		req.GetInsight().GetSqlserverValidation() == ""
+}
+
 // SendDataInsight sends a data insight to Data Warehouse.
 func SendDataInsight(ctx context.Context, params SendDataInsightParams) (*wlm.WriteInsightResponse, error) {
+	if err := checkWorkloadType(params); err != nil {
+		log.CtxLogger(ctx).Errorw("Failed to send metrics to Data Warehouse", "error", err, "workload_type", params.WLMetrics.WorkloadType)
+		return nil, err
+	}
+	if params.Schema != nil {
+		params.WLMetrics = params.Schema.Validate(ctx, params.WLMetrics)
+	}
+	observeLifecycle(ctx, lifecycleTracker, params.WLMetrics)
 	req := createWriteInsightRequest(ctx, params.WLMetrics, params.CloudProps)
 	res, err := params.WLMService.WriteInsightAndGetResponse(params.CloudProps.GetProjectId(), params.CloudProps.GetRegion(), req)
+	if errors.Is(err, ErrCircuitOpen) {
+		// The rejection was already reported when writes were paused.
+		log.CtxLogger(ctx).Debugw("Did not send metrics to Data Warehouse", "error", err, "workload_type", params.WLMetrics.WorkloadType)
+		return nil, err
+	}
 	if err != nil {
 		log.CtxLogger(ctx).Errorw("Failed to send metrics to Data Warehouse", "error", err, "workload_type", params.WLMetrics.WorkloadType)
-		usagemetrics.Error(usagemetrics.DataWarehouseWriteInsightFailure)
+		usagemetrics.Error(agenterrors.UsageCode(err, usagemetrics.DataWarehouseWriteInsightFailure))
 		return nil, err
 	}
 	log.CtxLogger(ctx).Infow("Sent metrics to Data Warehouse", "workload_type", params.WLMetrics.WorkloadType)
@@ -268,15 +596,9 @@ func SendDataInsight(ctx context.Context, params SendDataInsightParams) (*wlm.Wr
 // createWriteInsightRequest creates a WriteInsightRequest from the given WorkloadMetrics and CloudProperties.
 func createWriteInsightRequest(ctx context.Context, wm WorkloadMetrics, cp *cpb.CloudProperties) *dwpb.WriteInsightRequest {
 	log.CtxLogger(ctx).Debugw("Create WriteInsightRequest and call WriteInsight", "workload_type", wm.WorkloadType)
-	workloadTypeMap := map[WorkloadType]dwpb.TorsoValidation_WorkloadType{
-		ORACLE:  dwpb.TorsoValidation_ORACLE,
-		MYSQL:   dwpb.TorsoValidation_MYSQL,
-		REDIS:   dwpb.TorsoValidation_REDIS,
-		UNKNOWN: dwpb.TorsoValidation_WORKLOAD_TYPE_UNSPECIFIED,
-	}
-
-	workloadType, ok := workloadTypeMap[wm.WorkloadType]
+	workloadType, ok := torsoWorkloadTypes[wm.WorkloadType]
 	if !ok {
+		log.CtxLogger(ctx).Debugw("Workload type has no Data Warehouse workload type, sending it as unspecified", "workload_type", wm.WorkloadType)
 		workloadType = dwpb.TorsoValidation_WORKLOAD_TYPE_UNSPECIFIED
 	}
 
@@ -285,7 +607,7 @@ func createWriteInsightRequest(ctx context.Context, wm WorkloadMetrics, cp *cpb.
 			InstanceId: cp.GetInstanceId(),
 			TorsoValidation: &dwpb.TorsoValidation{
 				WorkloadType:      workloadType,
-				ValidationDetails: wm.Metrics,
+				ValidationDetails: validationDetails(wm),
 				ProjectId:         cp.GetProjectId(),
 				InstanceName:      cp.GetInstanceName(),
 				AgentVersion:      configuration.AgentVersion,
@@ -294,6 +616,17 @@ func createWriteInsightRequest(ctx context.Context, wm WorkloadMetrics, cp *cpb.
 	}
 }
 
+// checkWorkloadType returns an error for a workload type without a Data Warehouse workload type in strict mode.
+func checkWorkloadType(params SendDataInsightParams) error {
+	if !params.StrictWorkloadTypes {
+		return nil
+	}
+	if _, ok := torsoWorkloadTypes[params.WLMetrics.WorkloadType]; !ok {
+		return fmt.Errorf("workload type %q has no Data Warehouse workload type", params.WLMetrics.WorkloadType)
+	}
+	return nil
+}
+
 func readFileWrapper(path string) (io.ReadCloser, error) {
 	content, err := os.ReadFile(path)
 	if err != nil {
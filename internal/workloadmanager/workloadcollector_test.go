@@ -22,8 +22,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -94,7 +96,7 @@ func TestIsMetricOverrideYamlFileExists(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 
-			got := readAndLogMetricOverrideYAML(ctx, tt.reader)
+			got := readAndLogMetricOverrideYAML(ctx, tt.filePath, tt.reader)
 
 			if diff := cmp.Diff(tt.want, got); diff != "" {
 				t.Errorf("readAndLogMetricOverrideYAML returned unexpected output diff (-want +got):\n%s", diff)
@@ -103,6 +105,36 @@ func TestIsMetricOverrideYamlFileExists(t *testing.T) {
 	}
 }
 
+func TestMetricOverridePath(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *cpb.Configuration
+		want   string
+	}{
+		{
+			name:   "Default",
+			config: &cpb.Configuration{},
+			want:   LinuxMetricOverridePath,
+		},
+		{
+			name:   "Configured",
+			config: &cpb.Configuration{MetricOverridePath: "/tmp/wlmmetricoverride.yaml"},
+			want:   "/tmp/wlmmetricoverride.yaml",
+		},
+	}
+	if runtime.GOOS == "windows" {
+		tests[0].want = WindowsMetricOverridePath
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := MetricOverridePath(tc.config); got != tc.want {
+				t.Errorf("MetricOverridePath() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestCollectOverrideMetrics(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -155,7 +187,7 @@ func TestCollectOverrideMetrics(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 
-			got := collectOverrideMetrics(ctx, tt.reader)
+			got := collectOverrideMetrics(ctx, tt.filePath, tt.reader)
 
 			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(WorkloadMetrics{})); diff != "" {
 				t.Errorf("collectOverrideMetrics returned unexpected metrics diff (-want +got):\n%s", diff)
@@ -248,6 +280,75 @@ func TestSendMetricsToDataWarehouse(t *testing.T) {
 	}
 }
 
+func TestSetError(t *testing.T) {
+	wm := WorkloadMetrics{Metrics: map[string]string{"metric1": "0", "metric2": "value2"}}
+	wm.SetError("metric1", errors.New("query failed"))
+
+	want := WorkloadMetrics{
+		Metrics: map[string]string{"metric2": "value2"},
+		Errors:  map[string]string{"metric1": "query failed"},
+	}
+	if diff := cmp.Diff(want, wm); diff != "" {
+		t.Errorf("SetError() returned unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestAddMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		wm         WorkloadMetrics
+		metrics    map[string]string
+		err        error
+		want       WorkloadMetrics
+		wantFailed bool
+	}{
+		{
+			name:    "Succeeded",
+			wm:      WorkloadMetrics{Metrics: map[string]string{"metric1": "value1"}},
+			metrics: map[string]string{"metric2": "value2"},
+			want:    WorkloadMetrics{Metrics: map[string]string{"metric1": "value1", "metric2": "value2"}},
+		},
+		{
+			name: "Failed",
+			wm:   WorkloadMetrics{Metrics: map[string]string{"metric1": "value1"}},
+			err:  errors.New("query failed"),
+			want: WorkloadMetrics{
+				Metrics: map[string]string{"metric1": "value1"},
+				Errors:  map[string]string{"step": "query failed"},
+			},
+			wantFailed: true,
+		},
+		{
+			name:    "PartiallySucceeded",
+			metrics: map[string]string{"metric2": "value2"},
+			err:     errors.New("scan failed"),
+			want: WorkloadMetrics{
+				Metrics: map[string]string{"metric2": "value2"},
+				Errors:  map[string]string{"step": "scan failed"},
+			},
+			wantFailed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.wm.AddMetrics("step", tc.metrics, tc.err)
+			if diff := cmp.Diff(tc.want, tc.wm); diff != "" {
+				t.Errorf("AddMetrics() returned unexpected diff (-want +got):\n%s", diff)
+			}
+			if got := tc.wm.Failed(); got != tc.wantFailed {
+				t.Errorf("Failed() = %v, want %v", got, tc.wantFailed)
+			}
+		})
+	}
+}
+
+func TestFailedNil(t *testing.T) {
+	var wm *WorkloadMetrics
+	if wm.Failed() {
+		t.Error("Failed() on nil WorkloadMetrics = true, want false")
+	}
+}
+
 func TestCreateWriteInsightRequest(t *testing.T) {
 	tests := []struct {
 		name string
@@ -278,6 +379,38 @@ func TestCreateWriteInsightRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Collection metadata",
+			wm: WorkloadMetrics{
+				WorkloadType: MYSQL,
+				Metrics: map[string]string{
+					"metric1": "value1",
+					"metric2": "0",
+				},
+				CollectionTime:     time.Date(2025, 3, 4, 5, 6, 7, 0, time.UTC),
+				CollectionDuration: 1500 * time.Millisecond,
+				Errors:             map[string]string{"metric3": "query failed"},
+			},
+			cp: DefaultCloudProperties,
+			want: &dwpb.WriteInsightRequest{
+				Insight: &dwpb.Insight{
+					InstanceId: "test-instance-id",
+					TorsoValidation: &dwpb.TorsoValidation{
+						WorkloadType: dwpb.TorsoValidation_MYSQL,
+						ValidationDetails: map[string]string{
+							"metric1":                  "value1",
+							"metric2":                  "0",
+							CollectionTimeKey:          "2025-03-04T05:06:07Z",
+							CollectionDurationKey:      "1500",
+							"metric3_collection_error": "query failed",
+						},
+						ProjectId:    "test-project",
+						InstanceName: "test-instance-name",
+						AgentVersion: configuration.AgentVersion,
+					},
+				},
+			},
+		},
 		{
 			name: "Unknown workload",
 			wm: WorkloadMetrics{
@@ -364,6 +497,86 @@ func TestSendDataInsight(t *testing.T) {
 			wantRespBody: nil,
 			wantErr:      cmpopts.AnyError,
 		},
+		{
+			name: "CircuitOpen",
+			wlmService: &wlmfake.TestWLM{
+				T:                     t,
+				WriteInsightResponses: []*wlm.WriteInsightResponse{nil},
+				WriteInsightErrs:      []error{fmt.Errorf("%w: %w", ErrCircuitOpen, &googleapi.Error{Code: 403})},
+			},
+			params: SendDataInsightParams{
+				WLMetrics: WorkloadMetrics{
+					WorkloadType: MYSQL,
+					Metrics: map[string]string{
+						"metric1": "value1",
+					},
+				},
+				CloudProps: DefaultCloudProperties,
+			},
+			wantRespBody: nil,
+			wantErr:      ErrCircuitOpen,
+		},
+		{
+			name: "StrictUnmappedWorkloadType",
+			wlmService: &wlmfake.TestWLM{
+				T: t,
+			},
+			params: SendDataInsightParams{
+				WLMetrics: WorkloadMetrics{
+					WorkloadType: POSTGRES,
+					Metrics: map[string]string{
+						"metric1": "value1",
+					},
+				},
+				CloudProps:          DefaultCloudProperties,
+				StrictWorkloadTypes: true,
+			},
+			wantRespBody: nil,
+			wantErr:      cmpopts.AnyError,
+		},
+		{
+			name: "StrictMappedWorkloadType",
+			wlmService: &wlmfake.TestWLM{
+				T: t,
+				WriteInsightResponses: []*wlm.WriteInsightResponse{
+					&wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+				},
+				WriteInsightErrs: []error{nil},
+			},
+			params: SendDataInsightParams{
+				WLMetrics: WorkloadMetrics{
+					WorkloadType: REDIS,
+					Metrics: map[string]string{
+						"metric1": "value1",
+					},
+				},
+				CloudProps:          DefaultCloudProperties,
+				StrictWorkloadTypes: true,
+			},
+			wantRespBody: &wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+			wantErr:      nil,
+		},
+		{
+			name: "NotStrictUnmappedWorkloadType",
+			wlmService: &wlmfake.TestWLM{
+				T: t,
+				WriteInsightResponses: []*wlm.WriteInsightResponse{
+					&wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+				},
+				WriteInsightErrs: []error{nil},
+			},
+			params: SendDataInsightParams{
+				WLMetrics: WorkloadMetrics{
+					WorkloadType: MONGODB,
+					Metrics: map[string]string{
+						"metric1": "value1",
+					},
+				},
+				CloudProps: DefaultCloudProperties,
+			},
+			wantRespBody: &wlm.WriteInsightResponse{ServerResponse: googleapi.ServerResponse{HTTPStatusCode: 201}},
+			wantErr:      nil,
+		},
 	}
 
 	ctx := context.Background()
@@ -449,3 +662,40 @@ func TestQuietSendDataInsight(t *testing.T) {
 		})
 	}
 }
+
+func TestActivationClient(t *testing.T) {
+	export := &cpb.DataWarehouseExport{Enabled: proto.Bool(true), FilePath: t.TempDir() + "/insights.json"}
+	tests := []struct {
+		name   string
+		config *cpb.Configuration
+		want   WLMWriter
+	}{
+		{
+			name: "NotDeduplicated",
+			config: &cpb.Configuration{
+				DataWarehouseExport:        export,
+				DataWarehouseDeduplication: &cpb.DataWarehouseDeduplication{Enabled: proto.Bool(true)},
+			},
+			want: &FileWriter{},
+		},
+		{
+			name: "Routed",
+			config: &cpb.Configuration{
+				DataWarehouseExport:  export,
+				DataWarehouseRouting: &cpb.DataWarehouseRouting{ProjectId: "central"},
+			},
+			want: &RoutingWriter{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ActivationClient(context.Background(), tc.config)
+			if err != nil {
+				t.Fatalf("ActivationClient() returned unexpected error: %v", err)
+			}
+			if gotType, wantType := fmt.Sprintf("%T", got), fmt.Sprintf("%T", tc.want); gotType != wantType {
+				t.Errorf("ActivationClient() returned a %s, want a %s", gotType, wantType)
+			}
+		})
+	}
+}
@@ -198,6 +198,214 @@ func (Configuration_LogLevel) EnumDescriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{0, 0}
 }
 
+type LegacyAgents_Precedence int32
+
+const (
+	LegacyAgents_PRECEDENCE_UNSPECIFIED LegacyAgents_Precedence = 0
+	// insights of the workloads collected by a running legacy agent are not
+	// sent by this agent
+	LegacyAgents_LEGACY_AGENT LegacyAgents_Precedence = 1
+	// insights are sent by both agents
+	LegacyAgents_WORKLOAD_AGENT LegacyAgents_Precedence = 2
+)
+
+// Enum value maps for LegacyAgents_Precedence.
+var (
+	LegacyAgents_Precedence_name = map[int32]string{
+		0: "PRECEDENCE_UNSPECIFIED",
+		1: "LEGACY_AGENT",
+		2: "WORKLOAD_AGENT",
+	}
+	LegacyAgents_Precedence_value = map[string]int32{
+		"PRECEDENCE_UNSPECIFIED": 0,
+		"LEGACY_AGENT":           1,
+		"WORKLOAD_AGENT":         2,
+	}
+)
+
+func (x LegacyAgents_Precedence) Enum() *LegacyAgents_Precedence {
+	p := new(LegacyAgents_Precedence)
+	*p = x
+	return p
+}
+
+func (x LegacyAgents_Precedence) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LegacyAgents_Precedence) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_configuration_configuration_proto_enumTypes[3].Descriptor()
+}
+
+func (LegacyAgents_Precedence) Type() protoreflect.EnumType {
+	return &file_protos_configuration_configuration_proto_enumTypes[3]
+}
+
+func (x LegacyAgents_Precedence) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LegacyAgents_Precedence.Descriptor instead.
+func (LegacyAgents_Precedence) EnumDescriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{18, 0}
+}
+
+type OracleActivitySampling_Source int32
+
+const (
+	OracleActivitySampling_SOURCE_UNSPECIFIED     OracleActivitySampling_Source = 0
+	OracleActivitySampling_ACTIVE_SESSION_HISTORY OracleActivitySampling_Source = 1
+	OracleActivitySampling_STATSPACK              OracleActivitySampling_Source = 2
+)
+
+// Enum value maps for OracleActivitySampling_Source.
+var (
+	OracleActivitySampling_Source_name = map[int32]string{
+		0: "SOURCE_UNSPECIFIED",
+		1: "ACTIVE_SESSION_HISTORY",
+		2: "STATSPACK",
+	}
+	OracleActivitySampling_Source_value = map[string]int32{
+		"SOURCE_UNSPECIFIED":     0,
+		"ACTIVE_SESSION_HISTORY": 1,
+		"STATSPACK":              2,
+	}
+)
+
+func (x OracleActivitySampling_Source) Enum() *OracleActivitySampling_Source {
+	p := new(OracleActivitySampling_Source)
+	*p = x
+	return p
+}
+
+func (x OracleActivitySampling_Source) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OracleActivitySampling_Source) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_configuration_configuration_proto_enumTypes[4].Descriptor()
+}
+
+func (OracleActivitySampling_Source) Type() protoreflect.EnumType {
+	return &file_protos_configuration_configuration_proto_enumTypes[4]
+}
+
+func (x OracleActivitySampling_Source) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OracleActivitySampling_Source.Descriptor instead.
+func (OracleActivitySampling_Source) EnumDescriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{30, 0}
+}
+
+type MongoDBConfiguration_AuthMechanism int32
+
+const (
+	// negotiated with the server, SCRAM-SHA-256 unless only SCRAM-SHA-1 is
+	// supported
+	MongoDBConfiguration_AUTH_MECHANISM_UNSPECIFIED MongoDBConfiguration_AuthMechanism = 0
+	MongoDBConfiguration_SCRAM_SHA_256              MongoDBConfiguration_AuthMechanism = 1
+	MongoDBConfiguration_SCRAM_SHA_1                MongoDBConfiguration_AuthMechanism = 2
+	// authenticates with the TLS client certificate; the username defaults to
+	// the certificate subject
+	MongoDBConfiguration_MONGODB_X509 MongoDBConfiguration_AuthMechanism = 3
+)
+
+// Enum value maps for MongoDBConfiguration_AuthMechanism.
+var (
+	MongoDBConfiguration_AuthMechanism_name = map[int32]string{
+		0: "AUTH_MECHANISM_UNSPECIFIED",
+		1: "SCRAM_SHA_256",
+		2: "SCRAM_SHA_1",
+		3: "MONGODB_X509",
+	}
+	MongoDBConfiguration_AuthMechanism_value = map[string]int32{
+		"AUTH_MECHANISM_UNSPECIFIED": 0,
+		"SCRAM_SHA_256":              1,
+		"SCRAM_SHA_1":                2,
+		"MONGODB_X509":               3,
+	}
+)
+
+func (x MongoDBConfiguration_AuthMechanism) Enum() *MongoDBConfiguration_AuthMechanism {
+	p := new(MongoDBConfiguration_AuthMechanism)
+	*p = x
+	return p
+}
+
+func (x MongoDBConfiguration_AuthMechanism) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MongoDBConfiguration_AuthMechanism) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_configuration_configuration_proto_enumTypes[5].Descriptor()
+}
+
+func (MongoDBConfiguration_AuthMechanism) Type() protoreflect.EnumType {
+	return &file_protos_configuration_configuration_proto_enumTypes[5]
+}
+
+func (x MongoDBConfiguration_AuthMechanism) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MongoDBConfiguration_AuthMechanism.Descriptor instead.
+func (MongoDBConfiguration_AuthMechanism) EnumDescriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{42, 0}
+}
+
+type SecretRef_Format int32
+
+const (
+	// the secret contains the password
+	SecretRef_FORMAT_UNSPECIFIED SecretRef_Format = 0
+	// the secret is a JSON object with the username, password, host and port
+	// of the database, e.g. {"username": "agent", "password": "...",
+	// "host": "10.0.0.5", "port": 3306}; fields in the secret take precedence
+	// over the connection parameters
+	SecretRef_JSON SecretRef_Format = 1
+)
+
+// Enum value maps for SecretRef_Format.
+var (
+	SecretRef_Format_name = map[int32]string{
+		0: "FORMAT_UNSPECIFIED",
+		1: "JSON",
+	}
+	SecretRef_Format_value = map[string]int32{
+		"FORMAT_UNSPECIFIED": 0,
+		"JSON":               1,
+	}
+)
+
+func (x SecretRef_Format) Enum() *SecretRef_Format {
+	p := new(SecretRef_Format)
+	*p = x
+	return p
+}
+
+func (x SecretRef_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SecretRef_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_protos_configuration_configuration_proto_enumTypes[6].Descriptor()
+}
+
+func (SecretRef_Format) Type() protoreflect.EnumType {
+	return &file_protos_configuration_configuration_proto_enumTypes[6]
+}
+
+func (x SecretRef_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SecretRef_Format.Descriptor instead.
+func (SecretRef_Format) EnumDescriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{46, 0}
+}
+
 type Query_DatabaseRole int32
 
 const (
@@ -234,11 +442,11 @@ func (x Query_DatabaseRole) String() string {
 }
 
 func (Query_DatabaseRole) Descriptor() protoreflect.EnumDescriptor {
-	return file_protos_configuration_configuration_proto_enumTypes[3].Descriptor()
+	return file_protos_configuration_configuration_proto_enumTypes[7].Descriptor()
 }
 
 func (Query_DatabaseRole) Type() protoreflect.EnumType {
-	return &file_protos_configuration_configuration_proto_enumTypes[3]
+	return &file_protos_configuration_configuration_proto_enumTypes[7]
 }
 
 func (x Query_DatabaseRole) Number() protoreflect.EnumNumber {
@@ -247,7 +455,7 @@ func (x Query_DatabaseRole) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Query_DatabaseRole.Descriptor instead.
 func (Query_DatabaseRole) EnumDescriptor() ([]byte, []int) {
-	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{15, 0}
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{49, 0}
 }
 
 type Configuration struct {
@@ -269,6 +477,47 @@ type Configuration struct {
 	PostgresConfiguration   *PostgresConfiguration  `protobuf:"bytes,12,opt,name=postgres_configuration,json=postgresConfiguration,proto3" json:"postgres_configuration,omitempty"`
 	OpenshiftConfiguration  *OpenShiftConfiguration `protobuf:"bytes,13,opt,name=openshift_configuration,json=openshiftConfiguration,proto3" json:"openshift_configuration,omitempty"`
 	MongoDbConfiguration    *MongoDBConfiguration   `protobuf:"bytes,14,opt,name=mongo_db_configuration,json=mongoDbConfiguration,proto3" json:"mongo_db_configuration,omitempty"`
+	DataWarehouseBatching   *DataWarehouseBatching  `protobuf:"bytes,15,opt,name=data_warehouse_batching,json=dataWarehouseBatching,proto3" json:"data_warehouse_batching,omitempty"`
+	DataWarehouseExport     *DataWarehouseExport    `protobuf:"bytes,16,opt,name=data_warehouse_export,json=dataWarehouseExport,proto3" json:"data_warehouse_export,omitempty"`
+	// Layout version of the configuration file, older layouts are migrated when
+	// the agent starts.
+	SchemaVersion       int32                `protobuf:"varint,17,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	RemoteConfiguration *RemoteConfiguration `protobuf:"bytes,18,opt,name=remote_configuration,json=remoteConfiguration,proto3" json:"remote_configuration,omitempty"`
+	// defaults to false
+	// when enabled insights for workload types without a Data Warehouse
+	// workload type are rejected instead of being sent as unspecified
+	DataWarehouseStrictWorkloadTypes *bool `protobuf:"varint,19,opt,name=data_warehouse_strict_workload_types,json=dataWarehouseStrictWorkloadTypes,proto3,oneof" json:"data_warehouse_strict_workload_types,omitempty"`
+	// defaults to the wlmmetricoverride.yaml file in the agent configuration
+	// directory
+	MetricOverridePath string `protobuf:"bytes,20,opt,name=metric_override_path,json=metricOverridePath,proto3" json:"metric_override_path,omitempty"`
+	// defaults to the application default credentials of the instance
+	Credentials *Credentials `protobuf:"bytes,21,opt,name=credentials,proto3" json:"credentials,omitempty"`
+	// read from the default configuration file when the agent starts, changes
+	// require a restart
+	Network *Network `protobuf:"bytes,22,opt,name=network,proto3" json:"network,omitempty"`
+	// API endpoints used instead of the public googleapis.com endpoints, for
+	// example Private Service Connect endpoints
+	Endpoints                   *Endpoints                   `protobuf:"bytes,23,opt,name=endpoints,proto3" json:"endpoints,omitempty"`
+	AuditLog                    *AuditLog                    `protobuf:"bytes,24,opt,name=audit_log,json=auditLog,proto3" json:"audit_log,omitempty"`
+	DataWarehouseFilter         *DataWarehouseFilter         `protobuf:"bytes,25,opt,name=data_warehouse_filter,json=dataWarehouseFilter,proto3" json:"data_warehouse_filter,omitempty"`
+	CloudMonitoringExport       *CloudMonitoringExport       `protobuf:"bytes,26,opt,name=cloud_monitoring_export,json=cloudMonitoringExport,proto3" json:"cloud_monitoring_export,omitempty"`
+	DataWarehouseActivation     *DataWarehouseActivation     `protobuf:"bytes,27,opt,name=data_warehouse_activation,json=dataWarehouseActivation,proto3" json:"data_warehouse_activation,omitempty"`
+	DataWarehouseCircuitBreaker *DataWarehouseCircuitBreaker `protobuf:"bytes,28,opt,name=data_warehouse_circuit_breaker,json=dataWarehouseCircuitBreaker,proto3" json:"data_warehouse_circuit_breaker,omitempty"`
+	ServiceRecovery             *ServiceRecovery             `protobuf:"bytes,29,opt,name=service_recovery,json=serviceRecovery,proto3" json:"service_recovery,omitempty"`
+	CollectionSchedule          *CollectionSchedule          `protobuf:"bytes,30,opt,name=collection_schedule,json=collectionSchedule,proto3" json:"collection_schedule,omitempty"`
+	DataWarehouseDeduplication  *DataWarehouseDeduplication  `protobuf:"bytes,31,opt,name=data_warehouse_deduplication,json=dataWarehouseDeduplication,proto3" json:"data_warehouse_deduplication,omitempty"`
+	DataWarehouseRouting        *DataWarehouseRouting        `protobuf:"bytes,32,opt,name=data_warehouse_routing,json=dataWarehouseRouting,proto3" json:"data_warehouse_routing,omitempty"`
+	// every insight is also sent to these destinations, e.g. a staging endpoint
+	// while validating a migration
+	// failed writes to an additional destination are logged and do not affect
+	// the writes to the other destinations
+	DataWarehouseAdditionalDestinations []*DataWarehouseDestination `protobuf:"bytes,33,rep,name=data_warehouse_additional_destinations,json=dataWarehouseAdditionalDestinations,proto3" json:"data_warehouse_additional_destinations,omitempty"`
+	PubsubExport                        *PubSubExport               `protobuf:"bytes,34,opt,name=pubsub_export,json=pubsubExport,proto3" json:"pubsub_export,omitempty"`
+	BigqueryExport                      *BigQueryExport             `protobuf:"bytes,35,opt,name=bigquery_export,json=bigqueryExport,proto3" json:"bigquery_export,omitempty"`
+	InsightLog                          *InsightLog                 `protobuf:"bytes,36,opt,name=insight_log,json=insightLog,proto3" json:"insight_log,omitempty"`
+	LegacyAgents                        *LegacyAgents               `protobuf:"bytes,37,opt,name=legacy_agents,json=legacyAgents,proto3" json:"legacy_agents,omitempty"`
+	HealthProbes                        *HealthProbes               `protobuf:"bytes,38,opt,name=health_probes,json=healthProbes,proto3" json:"health_probes,omitempty"`
+	ConfigDrift                         *ConfigDrift                `protobuf:"bytes,39,opt,name=config_drift,json=configDrift,proto3" json:"config_drift,omitempty"`
 }
 
 func (x *Configuration) Reset() {
@@ -401,6 +650,181 @@ func (x *Configuration) GetMongoDbConfiguration() *MongoDBConfiguration {
 	return nil
 }
 
+func (x *Configuration) GetDataWarehouseBatching() *DataWarehouseBatching {
+	if x != nil {
+		return x.DataWarehouseBatching
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseExport() *DataWarehouseExport {
+	if x != nil {
+		return x.DataWarehouseExport
+	}
+	return nil
+}
+
+func (x *Configuration) GetSchemaVersion() int32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *Configuration) GetRemoteConfiguration() *RemoteConfiguration {
+	if x != nil {
+		return x.RemoteConfiguration
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseStrictWorkloadTypes() bool {
+	if x != nil && x.DataWarehouseStrictWorkloadTypes != nil {
+		return *x.DataWarehouseStrictWorkloadTypes
+	}
+	return false
+}
+
+func (x *Configuration) GetMetricOverridePath() string {
+	if x != nil {
+		return x.MetricOverridePath
+	}
+	return ""
+}
+
+func (x *Configuration) GetCredentials() *Credentials {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
+func (x *Configuration) GetNetwork() *Network {
+	if x != nil {
+		return x.Network
+	}
+	return nil
+}
+
+func (x *Configuration) GetEndpoints() *Endpoints {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *Configuration) GetAuditLog() *AuditLog {
+	if x != nil {
+		return x.AuditLog
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseFilter() *DataWarehouseFilter {
+	if x != nil {
+		return x.DataWarehouseFilter
+	}
+	return nil
+}
+
+func (x *Configuration) GetCloudMonitoringExport() *CloudMonitoringExport {
+	if x != nil {
+		return x.CloudMonitoringExport
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseActivation() *DataWarehouseActivation {
+	if x != nil {
+		return x.DataWarehouseActivation
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseCircuitBreaker() *DataWarehouseCircuitBreaker {
+	if x != nil {
+		return x.DataWarehouseCircuitBreaker
+	}
+	return nil
+}
+
+func (x *Configuration) GetServiceRecovery() *ServiceRecovery {
+	if x != nil {
+		return x.ServiceRecovery
+	}
+	return nil
+}
+
+func (x *Configuration) GetCollectionSchedule() *CollectionSchedule {
+	if x != nil {
+		return x.CollectionSchedule
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseDeduplication() *DataWarehouseDeduplication {
+	if x != nil {
+		return x.DataWarehouseDeduplication
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseRouting() *DataWarehouseRouting {
+	if x != nil {
+		return x.DataWarehouseRouting
+	}
+	return nil
+}
+
+func (x *Configuration) GetDataWarehouseAdditionalDestinations() []*DataWarehouseDestination {
+	if x != nil {
+		return x.DataWarehouseAdditionalDestinations
+	}
+	return nil
+}
+
+func (x *Configuration) GetPubsubExport() *PubSubExport {
+	if x != nil {
+		return x.PubsubExport
+	}
+	return nil
+}
+
+func (x *Configuration) GetBigqueryExport() *BigQueryExport {
+	if x != nil {
+		return x.BigqueryExport
+	}
+	return nil
+}
+
+func (x *Configuration) GetInsightLog() *InsightLog {
+	if x != nil {
+		return x.InsightLog
+	}
+	return nil
+}
+
+func (x *Configuration) GetLegacyAgents() *LegacyAgents {
+	if x != nil {
+		return x.LegacyAgents
+	}
+	return nil
+}
+
+func (x *Configuration) GetHealthProbes() *HealthProbes {
+	if x != nil {
+		return x.HealthProbes
+	}
+	return nil
+}
+
+func (x *Configuration) GetConfigDrift() *ConfigDrift {
+	if x != nil {
+		return x.ConfigDrift
+	}
+	return nil
+}
+
 type CloudProperties struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -542,9 +966,12 @@ type AgentProperties struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Version         string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	Name            string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	LogUsageMetrics bool   `protobuf:"varint,3,opt,name=log_usage_metrics,json=logUsageMetrics,proto3" json:"log_usage_metrics,omitempty"`
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// defaults to true in daemon mode
+	LogUsageMetrics *bool `protobuf:"varint,3,opt,name=log_usage_metrics,json=logUsageMetrics,proto3,oneof" json:"log_usage_metrics,omitempty"`
+	// removes the instance name and image from usage metrics, defaults to false
+	AnonymizeUsageMetrics bool `protobuf:"varint,4,opt,name=anonymize_usage_metrics,json=anonymizeUsageMetrics,proto3" json:"anonymize_usage_metrics,omitempty"`
 }
 
 func (x *AgentProperties) Reset() {
@@ -594,24 +1021,36 @@ func (x *AgentProperties) GetName() string {
 }
 
 func (x *AgentProperties) GetLogUsageMetrics() bool {
+	if x != nil && x.LogUsageMetrics != nil {
+		return *x.LogUsageMetrics
+	}
+	return false
+}
+
+func (x *AgentProperties) GetAnonymizeUsageMetrics() bool {
 	if x != nil {
-		return x.LogUsageMetrics
+		return x.AnonymizeUsageMetrics
 	}
 	return false
 }
 
-type OracleConfiguration struct {
+type DataWarehouseBatching struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled         *bool            `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	OracleDiscovery *OracleDiscovery `protobuf:"bytes,2,opt,name=oracle_discovery,json=oracleDiscovery,proto3" json:"oracle_discovery,omitempty"`
-	OracleMetrics   *OracleMetrics   `protobuf:"bytes,3,opt,name=oracle_metrics,json=oracleMetrics,proto3" json:"oracle_metrics,omitempty"`
+	// defaults to false
+	// a WriteInsight request holds a single torso validation, so only the
+	// insights written for the same workload and database instance within the
+	// flush interval are merged; the other insights are delayed by the flush
+	// interval without saving any request
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// defaults to 5 seconds
+	FlushInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=flush_interval,json=flushInterval,proto3" json:"flush_interval,omitempty"`
 }
 
-func (x *OracleConfiguration) Reset() {
-	*x = OracleConfiguration{}
+func (x *DataWarehouseBatching) Reset() {
+	*x = DataWarehouseBatching{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,13 +1058,13 @@ func (x *OracleConfiguration) Reset() {
 	}
 }
 
-func (x *OracleConfiguration) String() string {
+func (x *DataWarehouseBatching) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OracleConfiguration) ProtoMessage() {}
+func (*DataWarehouseBatching) ProtoMessage() {}
 
-func (x *OracleConfiguration) ProtoReflect() protoreflect.Message {
+func (x *DataWarehouseBatching) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -637,43 +1076,41 @@ func (x *OracleConfiguration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OracleConfiguration.ProtoReflect.Descriptor instead.
-func (*OracleConfiguration) Descriptor() ([]byte, []int) {
+// Deprecated: Use DataWarehouseBatching.ProtoReflect.Descriptor instead.
+func (*DataWarehouseBatching) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *OracleConfiguration) GetEnabled() bool {
+func (x *DataWarehouseBatching) GetEnabled() bool {
 	if x != nil && x.Enabled != nil {
 		return *x.Enabled
 	}
 	return false
 }
 
-func (x *OracleConfiguration) GetOracleDiscovery() *OracleDiscovery {
-	if x != nil {
-		return x.OracleDiscovery
-	}
-	return nil
-}
-
-func (x *OracleConfiguration) GetOracleMetrics() *OracleMetrics {
+func (x *DataWarehouseBatching) GetFlushInterval() *durationpb.Duration {
 	if x != nil {
-		return x.OracleMetrics
+		return x.FlushInterval
 	}
 	return nil
 }
 
-type OracleDiscovery struct {
+type DataWarehouseDeduplication struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UpdateFrequency *durationpb.Duration `protobuf:"bytes,1,opt,name=update_frequency,json=updateFrequency,proto3" json:"update_frequency,omitempty"`
-	Enabled         *bool                `protobuf:"varint,2,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// defaults to false
+	// insights which are identical to the last insight written for the same
+	// workload are not sent again
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// defaults to 1 hour
+	// how long an unchanged insight is skipped before it is sent again
+	MaxStaleness *durationpb.Duration `protobuf:"bytes,2,opt,name=max_staleness,json=maxStaleness,proto3" json:"max_staleness,omitempty"`
 }
 
-func (x *OracleDiscovery) Reset() {
-	*x = OracleDiscovery{}
+func (x *DataWarehouseDeduplication) Reset() {
+	*x = DataWarehouseDeduplication{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -681,13 +1118,13 @@ func (x *OracleDiscovery) Reset() {
 	}
 }
 
-func (x *OracleDiscovery) String() string {
+func (x *DataWarehouseDeduplication) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OracleDiscovery) ProtoMessage() {}
+func (*DataWarehouseDeduplication) ProtoMessage() {}
 
-func (x *OracleDiscovery) ProtoReflect() protoreflect.Message {
+func (x *DataWarehouseDeduplication) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -699,40 +1136,41 @@ func (x *OracleDiscovery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OracleDiscovery.ProtoReflect.Descriptor instead.
-func (*OracleDiscovery) Descriptor() ([]byte, []int) {
+// Deprecated: Use DataWarehouseDeduplication.ProtoReflect.Descriptor instead.
+func (*DataWarehouseDeduplication) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *OracleDiscovery) GetUpdateFrequency() *durationpb.Duration {
-	if x != nil {
-		return x.UpdateFrequency
-	}
-	return nil
-}
-
-func (x *OracleDiscovery) GetEnabled() bool {
+func (x *DataWarehouseDeduplication) GetEnabled() bool {
 	if x != nil && x.Enabled != nil {
 		return *x.Enabled
 	}
 	return false
 }
 
-type OracleMetrics struct {
+func (x *DataWarehouseDeduplication) GetMaxStaleness() *durationpb.Duration {
+	if x != nil {
+		return x.MaxStaleness
+	}
+	return nil
+}
+
+type DataWarehouseRouting struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled              *bool                   `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	CollectionFrequency  *durationpb.Duration    `protobuf:"bytes,2,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
-	ConnectionParameters []*ConnectionParameters `protobuf:"bytes,3,rep,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
-	Queries              []*Query                `protobuf:"bytes,4,rep,name=queries,proto3" json:"queries,omitempty"`
-	MaxExecutionThreads  int64                   `protobuf:"varint,5,opt,name=max_execution_threads,json=maxExecutionThreads,proto3" json:"max_execution_threads,omitempty"`
-	QueryTimeout         *durationpb.Duration    `protobuf:"bytes,6,opt,name=query_timeout,json=queryTimeout,proto3" json:"query_timeout,omitempty"`
+	// defaults to the project of the instance
+	// project to which insights are written, for example a central monitoring
+	// project
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// defaults to the region of the instance
+	// location to which insights are written, for example a fixed region
+	Location string `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
 }
 
-func (x *OracleMetrics) Reset() {
-	*x = OracleMetrics{}
+func (x *DataWarehouseRouting) Reset() {
+	*x = DataWarehouseRouting{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -740,13 +1178,13 @@ func (x *OracleMetrics) Reset() {
 	}
 }
 
-func (x *OracleMetrics) String() string {
+func (x *DataWarehouseRouting) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OracleMetrics) ProtoMessage() {}
+func (*DataWarehouseRouting) ProtoMessage() {}
 
-func (x *OracleMetrics) ProtoReflect() protoreflect.Message {
+func (x *DataWarehouseRouting) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -758,66 +1196,40 @@ func (x *OracleMetrics) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OracleMetrics.ProtoReflect.Descriptor instead.
-func (*OracleMetrics) Descriptor() ([]byte, []int) {
+// Deprecated: Use DataWarehouseRouting.ProtoReflect.Descriptor instead.
+func (*DataWarehouseRouting) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *OracleMetrics) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
-	}
-	return false
-}
-
-func (x *OracleMetrics) GetCollectionFrequency() *durationpb.Duration {
-	if x != nil {
-		return x.CollectionFrequency
-	}
-	return nil
-}
-
-func (x *OracleMetrics) GetConnectionParameters() []*ConnectionParameters {
-	if x != nil {
-		return x.ConnectionParameters
-	}
-	return nil
-}
-
-func (x *OracleMetrics) GetQueries() []*Query {
+func (x *DataWarehouseRouting) GetProjectId() string {
 	if x != nil {
-		return x.Queries
-	}
-	return nil
-}
-
-func (x *OracleMetrics) GetMaxExecutionThreads() int64 {
-	if x != nil {
-		return x.MaxExecutionThreads
+		return x.ProjectId
 	}
-	return 0
+	return ""
 }
 
-func (x *OracleMetrics) GetQueryTimeout() *durationpb.Duration {
+func (x *DataWarehouseRouting) GetLocation() string {
 	if x != nil {
-		return x.QueryTimeout
+		return x.Location
 	}
-	return nil
+	return ""
 }
 
-type MySQLConfiguration struct {
+type DataWarehouseDestination struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
-	// Min 10 mins, Max 6 hours, default 1 hour
-	DbcenterCollectionFrequency *durationpb.Duration `protobuf:"bytes,3,opt,name=dbcenter_collection_frequency,json=dbcenterCollectionFrequency,proto3" json:"dbcenter_collection_frequency,omitempty"`
+	// defaults to the Data Warehouse endpoint
+	Endpoint string `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	// defaults to the project of the instance
+	ProjectId string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// defaults to the region of the instance
+	Location string `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
 }
 
-func (x *MySQLConfiguration) Reset() {
-	*x = MySQLConfiguration{}
+func (x *DataWarehouseDestination) Reset() {
+	*x = DataWarehouseDestination{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -825,13 +1237,13 @@ func (x *MySQLConfiguration) Reset() {
 	}
 }
 
-func (x *MySQLConfiguration) String() string {
+func (x *DataWarehouseDestination) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MySQLConfiguration) ProtoMessage() {}
+func (*DataWarehouseDestination) ProtoMessage() {}
 
-func (x *MySQLConfiguration) ProtoReflect() protoreflect.Message {
+func (x *DataWarehouseDestination) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -843,43 +1255,49 @@ func (x *MySQLConfiguration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MySQLConfiguration.ProtoReflect.Descriptor instead.
-func (*MySQLConfiguration) Descriptor() ([]byte, []int) {
+// Deprecated: Use DataWarehouseDestination.ProtoReflect.Descriptor instead.
+func (*DataWarehouseDestination) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *MySQLConfiguration) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
+func (x *DataWarehouseDestination) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
 	}
-	return false
+	return ""
 }
 
-func (x *MySQLConfiguration) GetConnectionParameters() *ConnectionParameters {
+func (x *DataWarehouseDestination) GetProjectId() string {
 	if x != nil {
-		return x.ConnectionParameters
+		return x.ProjectId
 	}
-	return nil
+	return ""
 }
 
-func (x *MySQLConfiguration) GetDbcenterCollectionFrequency() *durationpb.Duration {
+func (x *DataWarehouseDestination) GetLocation() string {
 	if x != nil {
-		return x.DbcenterCollectionFrequency
+		return x.Location
 	}
-	return nil
+	return ""
 }
 
-type OpenShiftConfiguration struct {
+type DataWarehouseActivation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	// defaults to 5 minutes
+	// how often the activation is checked while Data Warehouse is not activated
+	CheckInterval *durationpb.Duration `protobuf:"bytes,1,opt,name=check_interval,json=checkInterval,proto3" json:"check_interval,omitempty"`
+	// defaults to 1 hour
+	// how often the activation is verified again once Data Warehouse is
+	// activated, collectors stop sending when the API is disabled or the agent
+	// is no longer authorized
+	RecheckInterval *durationpb.Duration `protobuf:"bytes,2,opt,name=recheck_interval,json=recheckInterval,proto3" json:"recheck_interval,omitempty"`
 }
 
-func (x *OpenShiftConfiguration) Reset() {
-	*x = OpenShiftConfiguration{}
+func (x *DataWarehouseActivation) Reset() {
+	*x = DataWarehouseActivation{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -887,13 +1305,13 @@ func (x *OpenShiftConfiguration) Reset() {
 	}
 }
 
-func (x *OpenShiftConfiguration) String() string {
+func (x *DataWarehouseActivation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenShiftConfiguration) ProtoMessage() {}
+func (*DataWarehouseActivation) ProtoMessage() {}
 
-func (x *OpenShiftConfiguration) ProtoReflect() protoreflect.Message {
+func (x *DataWarehouseActivation) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -905,36 +1323,43 @@ func (x *OpenShiftConfiguration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OpenShiftConfiguration.ProtoReflect.Descriptor instead.
-func (*OpenShiftConfiguration) Descriptor() ([]byte, []int) {
+// Deprecated: Use DataWarehouseActivation.ProtoReflect.Descriptor instead.
+func (*DataWarehouseActivation) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *OpenShiftConfiguration) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
+func (x *DataWarehouseActivation) GetCheckInterval() *durationpb.Duration {
+	if x != nil {
+		return x.CheckInterval
 	}
-	return false
+	return nil
 }
 
-func (x *OpenShiftConfiguration) GetConnectionParameters() *ConnectionParameters {
+func (x *DataWarehouseActivation) GetRecheckInterval() *durationpb.Duration {
 	if x != nil {
-		return x.ConnectionParameters
+		return x.RecheckInterval
 	}
 	return nil
 }
 
-type CommonDiscovery struct {
+type CollectionSchedule struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled             *bool                `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	CollectionFrequency *durationpb.Duration `protobuf:"bytes,2,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
+	// defaults to true
+	// delays the first collection of each service by a random offset of up to
+	// its collection frequency or 5 minutes, whichever is shorter, so that
+	// agents started together do not write to Data Warehouse at the same time
+	RandomizeStart *bool `protobuf:"varint,1,opt,name=randomize_start,json=randomizeStart,proto3,oneof" json:"randomize_start,omitempty"`
+	// defaults to 0, at most 0.5
+	// fraction of the collection frequency by which each interval between
+	// collections is randomly shortened or lengthened, e.g. 0.1 for +/-10%
+	Jitter float64 `protobuf:"fixed64,2,opt,name=jitter,proto3" json:"jitter,omitempty"`
 }
 
-func (x *CommonDiscovery) Reset() {
-	*x = CommonDiscovery{}
+func (x *CollectionSchedule) Reset() {
+	*x = CollectionSchedule{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -942,13 +1367,13 @@ func (x *CommonDiscovery) Reset() {
 	}
 }
 
-func (x *CommonDiscovery) String() string {
+func (x *CollectionSchedule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommonDiscovery) ProtoMessage() {}
+func (*CollectionSchedule) ProtoMessage() {}
 
-func (x *CommonDiscovery) ProtoReflect() protoreflect.Message {
+func (x *CollectionSchedule) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -960,36 +1385,39 @@ func (x *CommonDiscovery) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommonDiscovery.ProtoReflect.Descriptor instead.
-func (*CommonDiscovery) Descriptor() ([]byte, []int) {
+// Deprecated: Use CollectionSchedule.ProtoReflect.Descriptor instead.
+func (*CollectionSchedule) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CommonDiscovery) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
+func (x *CollectionSchedule) GetRandomizeStart() bool {
+	if x != nil && x.RandomizeStart != nil {
+		return *x.RandomizeStart
 	}
 	return false
 }
 
-func (x *CommonDiscovery) GetCollectionFrequency() *durationpb.Duration {
+func (x *CollectionSchedule) GetJitter() float64 {
 	if x != nil {
-		return x.CollectionFrequency
+		return x.Jitter
 	}
-	return nil
+	return 0
 }
 
-type RedisConfiguration struct {
+type ServiceRecovery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	// defaults to 5
+	// number of times a service, or a routine started by a service, is restarted
+	// after a panic within an hour, after which it is quarantined until the
+	// agent is restarted
+	MaxRestartsPerHour int32 `protobuf:"varint,1,opt,name=max_restarts_per_hour,json=maxRestartsPerHour,proto3" json:"max_restarts_per_hour,omitempty"`
 }
 
-func (x *RedisConfiguration) Reset() {
-	*x = RedisConfiguration{}
+func (x *ServiceRecovery) Reset() {
+	*x = ServiceRecovery{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -997,13 +1425,13 @@ func (x *RedisConfiguration) Reset() {
 	}
 }
 
-func (x *RedisConfiguration) String() string {
+func (x *ServiceRecovery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RedisConfiguration) ProtoMessage() {}
+func (*ServiceRecovery) ProtoMessage() {}
 
-func (x *RedisConfiguration) ProtoReflect() protoreflect.Message {
+func (x *ServiceRecovery) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1015,38 +1443,35 @@ func (x *RedisConfiguration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RedisConfiguration.ProtoReflect.Descriptor instead.
-func (*RedisConfiguration) Descriptor() ([]byte, []int) {
+// Deprecated: Use ServiceRecovery.ProtoReflect.Descriptor instead.
+func (*ServiceRecovery) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *RedisConfiguration) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
-	}
-	return false
-}
-
-func (x *RedisConfiguration) GetConnectionParameters() *ConnectionParameters {
+func (x *ServiceRecovery) GetMaxRestartsPerHour() int32 {
 	if x != nil {
-		return x.ConnectionParameters
+		return x.MaxRestartsPerHour
 	}
-	return nil
+	return 0
 }
 
-type PostgresConfiguration struct {
+type DataWarehouseCircuitBreaker struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
-	// Min 10 mins, Max 6 hours, default 1 hour
-	DbcenterCollectionFrequency *durationpb.Duration `protobuf:"bytes,3,opt,name=dbcenter_collection_frequency,json=dbcenterCollectionFrequency,proto3" json:"dbcenter_collection_frequency,omitempty"`
+	// defaults to 3
+	// number of consecutive writes rejected with 403 or 404, e.g. because the
+	// API is not enabled or a VPC Service Controls perimeter denies access,
+	// after which writes are paused
+	FailureThreshold int32 `protobuf:"varint,1,opt,name=failure_threshold,json=failureThreshold,proto3" json:"failure_threshold,omitempty"`
+	// defaults to 30 minutes
+	// how long writes are paused before Data Warehouse is tried again
+	CoolDown *durationpb.Duration `protobuf:"bytes,2,opt,name=cool_down,json=coolDown,proto3" json:"cool_down,omitempty"`
 }
 
-func (x *PostgresConfiguration) Reset() {
-	*x = PostgresConfiguration{}
+func (x *DataWarehouseCircuitBreaker) Reset() {
+	*x = DataWarehouseCircuitBreaker{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1054,13 +1479,13 @@ func (x *PostgresConfiguration) Reset() {
 	}
 }
 
-func (x *PostgresConfiguration) String() string {
+func (x *DataWarehouseCircuitBreaker) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PostgresConfiguration) ProtoMessage() {}
+func (*DataWarehouseCircuitBreaker) ProtoMessage() {}
 
-func (x *PostgresConfiguration) ProtoReflect() protoreflect.Message {
+func (x *DataWarehouseCircuitBreaker) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1072,45 +1497,41 @@ func (x *PostgresConfiguration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PostgresConfiguration.ProtoReflect.Descriptor instead.
-func (*PostgresConfiguration) Descriptor() ([]byte, []int) {
+// Deprecated: Use DataWarehouseCircuitBreaker.ProtoReflect.Descriptor instead.
+func (*DataWarehouseCircuitBreaker) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *PostgresConfiguration) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
-	}
-	return false
-}
-
-func (x *PostgresConfiguration) GetConnectionParameters() *ConnectionParameters {
+func (x *DataWarehouseCircuitBreaker) GetFailureThreshold() int32 {
 	if x != nil {
-		return x.ConnectionParameters
+		return x.FailureThreshold
 	}
-	return nil
+	return 0
 }
 
-func (x *PostgresConfiguration) GetDbcenterCollectionFrequency() *durationpb.Duration {
+func (x *DataWarehouseCircuitBreaker) GetCoolDown() *durationpb.Duration {
 	if x != nil {
-		return x.DbcenterCollectionFrequency
+		return x.CoolDown
 	}
 	return nil
 }
 
-type MongoDBConfiguration struct {
+type RemoteConfiguration struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
-	// Min 10 mins, Max 6 hours, default 1 hour
-	CollectionFrequency *durationpb.Duration `protobuf:"bytes,3,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
+	// gs://bucket/object URI of a configuration.json merged over this file
+	GcsUri string `protobuf:"bytes,1,opt,name=gcs_uri,json=gcsUri,proto3" json:"gcs_uri,omitempty"`
+	// Secret Manager secret containing a configuration.json merged over this
+	// file, mutually exclusive with gcs_uri
+	Secret *SecretRef `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	// defaults to 10 minutes
+	RefreshInterval *durationpb.Duration `protobuf:"bytes,3,opt,name=refresh_interval,json=refreshInterval,proto3" json:"refresh_interval,omitempty"`
 }
 
-func (x *MongoDBConfiguration) Reset() {
-	*x = MongoDBConfiguration{}
+func (x *RemoteConfiguration) Reset() {
+	*x = RemoteConfiguration{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1118,13 +1539,13 @@ func (x *MongoDBConfiguration) Reset() {
 	}
 }
 
-func (x *MongoDBConfiguration) String() string {
+func (x *RemoteConfiguration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MongoDBConfiguration) ProtoMessage() {}
+func (*RemoteConfiguration) ProtoMessage() {}
 
-func (x *MongoDBConfiguration) ProtoReflect() protoreflect.Message {
+func (x *RemoteConfiguration) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1136,52 +1557,57 @@ func (x *MongoDBConfiguration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MongoDBConfiguration.ProtoReflect.Descriptor instead.
-func (*MongoDBConfiguration) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoteConfiguration.ProtoReflect.Descriptor instead.
+func (*RemoteConfiguration) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *MongoDBConfiguration) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
+func (x *RemoteConfiguration) GetGcsUri() string {
+	if x != nil {
+		return x.GcsUri
 	}
-	return false
+	return ""
 }
 
-func (x *MongoDBConfiguration) GetConnectionParameters() *ConnectionParameters {
+func (x *RemoteConfiguration) GetSecret() *SecretRef {
 	if x != nil {
-		return x.ConnectionParameters
+		return x.Secret
 	}
 	return nil
 }
 
-func (x *MongoDBConfiguration) GetCollectionFrequency() *durationpb.Duration {
+func (x *RemoteConfiguration) GetRefreshInterval() *durationpb.Duration {
 	if x != nil {
-		return x.CollectionFrequency
+		return x.RefreshInterval
 	}
 	return nil
 }
 
-type SQLServerConfiguration struct {
+type Network struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Enabled                  *bool                                             `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
-	CollectionConfiguration  *SQLServerConfiguration_CollectionConfiguration   `protobuf:"bytes,2,opt,name=collection_configuration,json=collectionConfiguration,proto3" json:"collection_configuration,omitempty"`
-	CredentialConfigurations []*SQLServerConfiguration_CredentialConfiguration `protobuf:"bytes,3,rep,name=credential_configurations,json=credentialConfigurations,proto3" json:"credential_configurations,omitempty"`
-	// default timeout is 10s
-	CollectionTimeout *durationpb.Duration `protobuf:"bytes,4,opt,name=collection_timeout,json=collectionTimeout,proto3" json:"collection_timeout,omitempty"`
-	// default max_retries is 3
-	MaxRetries int32 `protobuf:"varint,5,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
-	// default retry_frequency is 3600s
-	RetryFrequency *durationpb.Duration `protobuf:"bytes,6,opt,name=retry_frequency,json=retryFrequency,proto3" json:"retry_frequency,omitempty"`
-	// default remote collection is false
-	RemoteCollection bool `protobuf:"varint,7,opt,name=remote_collection,json=remoteCollection,proto3" json:"remote_collection,omitempty"`
-}
-
-func (x *SQLServerConfiguration) Reset() {
-	*x = SQLServerConfiguration{}
+	// proxy for all outbound HTTPS and gRPC traffic, for example
+	// http://proxy.example.com:3128
+	HttpsProxy string `protobuf:"bytes,1,opt,name=https_proxy,json=httpsProxy,proto3" json:"https_proxy,omitempty"`
+	// comma separated hosts and domains reached without the proxy, the metadata
+	// server is always reached directly
+	NoProxy string `protobuf:"bytes,2,opt,name=no_proxy,json=noProxy,proto3" json:"no_proxy,omitempty"`
+	// PEM file with the certificate authorities trusted in addition to the
+	// system ones, Linux only. The settings apply to the fully merged
+	// configuration, the remote configuration itself is fetched with those of
+	// the configuration file. gRPC connections pick up proxy changes, and all
+	// connections pick up ca_bundle_file changes, when the agent service is
+	// restarted.
+	CaBundleFile string `protobuf:"bytes,3,opt,name=ca_bundle_file,json=caBundleFile,proto3" json:"ca_bundle_file,omitempty"`
+	// timeout of the DNS lookups which resolve the hosts of database replicas to
+	// zones, defaults to 5 seconds
+	DnsLookupTimeout *durationpb.Duration `protobuf:"bytes,4,opt,name=dns_lookup_timeout,json=dnsLookupTimeout,proto3" json:"dns_lookup_timeout,omitempty"`
+}
+
+func (x *Network) Reset() {
+	*x = Network{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1189,13 +1615,13 @@ func (x *SQLServerConfiguration) Reset() {
 	}
 }
 
-func (x *SQLServerConfiguration) String() string {
+func (x *Network) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLServerConfiguration) ProtoMessage() {}
+func (*Network) ProtoMessage() {}
 
-func (x *SQLServerConfiguration) ProtoReflect() protoreflect.Message {
+func (x *Network) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1207,89 +1633,76 @@ func (x *SQLServerConfiguration) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLServerConfiguration.ProtoReflect.Descriptor instead.
-func (*SQLServerConfiguration) Descriptor() ([]byte, []int) {
+// Deprecated: Use Network.ProtoReflect.Descriptor instead.
+func (*Network) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SQLServerConfiguration) GetEnabled() bool {
-	if x != nil && x.Enabled != nil {
-		return *x.Enabled
+func (x *Network) GetHttpsProxy() string {
+	if x != nil {
+		return x.HttpsProxy
 	}
-	return false
+	return ""
 }
 
-func (x *SQLServerConfiguration) GetCollectionConfiguration() *SQLServerConfiguration_CollectionConfiguration {
+func (x *Network) GetNoProxy() string {
 	if x != nil {
-		return x.CollectionConfiguration
+		return x.NoProxy
 	}
-	return nil
+	return ""
 }
 
-func (x *SQLServerConfiguration) GetCredentialConfigurations() []*SQLServerConfiguration_CredentialConfiguration {
+func (x *Network) GetCaBundleFile() string {
 	if x != nil {
-		return x.CredentialConfigurations
+		return x.CaBundleFile
 	}
-	return nil
+	return ""
 }
 
-func (x *SQLServerConfiguration) GetCollectionTimeout() *durationpb.Duration {
+func (x *Network) GetDnsLookupTimeout() *durationpb.Duration {
 	if x != nil {
-		return x.CollectionTimeout
+		return x.DnsLookupTimeout
 	}
 	return nil
 }
 
-func (x *SQLServerConfiguration) GetMaxRetries() int32 {
-	if x != nil {
-		return x.MaxRetries
-	}
-	return 0
-}
+type Endpoints struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *SQLServerConfiguration) GetRetryFrequency() *durationpb.Duration {
-	if x != nil {
-		return x.RetryFrequency
+	// for example secretmanager-psc.p.googleapis.com:443
+	SecretManager string `protobuf:"bytes,1,opt,name=secret_manager,json=secretManager,proto3" json:"secret_manager,omitempty"`
+	// for example monitoring-psc.p.googleapis.com:443
+	CloudMonitoring string `protobuf:"bytes,2,opt,name=cloud_monitoring,json=cloudMonitoring,proto3" json:"cloud_monitoring,omitempty"`
+	// for example logging-psc.p.googleapis.com:443
+	CloudLogging string `protobuf:"bytes,3,opt,name=cloud_logging,json=cloudLogging,proto3" json:"cloud_logging,omitempty"`
+	// for example https://compute-psc.p.googleapis.com/compute/v1/
+	Compute string `protobuf:"bytes,4,opt,name=compute,proto3" json:"compute,omitempty"`
+	// for example pubsub-psc.p.googleapis.com:443
+	Pubsub string `protobuf:"bytes,5,opt,name=pubsub,proto3" json:"pubsub,omitempty"`
+	// for example https://bigquery-psc.p.googleapis.com/bigquery/v2/
+	Bigquery string `protobuf:"bytes,6,opt,name=bigquery,proto3" json:"bigquery,omitempty"`
+	// for example https://storage-psc.p.googleapis.com/storage/v1/
+	CloudStorage string `protobuf:"bytes,7,opt,name=cloud_storage,json=cloudStorage,proto3" json:"cloud_storage,omitempty"`
+}
+
+func (x *Endpoints) Reset() {
+	*x = Endpoints{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *SQLServerConfiguration) GetRemoteCollection() bool {
-	if x != nil {
-		return x.RemoteCollection
-	}
-	return false
-}
-
-type ConnectionParameters struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Username    string     `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Secret      *SecretRef `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
-	Host        string     `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
-	Port        int32      `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
-	ServiceName string     `protobuf:"bytes,5,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	Password    string     `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
-}
-
-func (x *ConnectionParameters) Reset() {
-	*x = ConnectionParameters{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_configuration_configuration_proto_msgTypes[13]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
-}
-
-func (x *ConnectionParameters) String() string {
+func (x *Endpoints) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectionParameters) ProtoMessage() {}
+func (*Endpoints) ProtoMessage() {}
 
-func (x *ConnectionParameters) ProtoReflect() protoreflect.Message {
+func (x *Endpoints) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1301,68 +1714,76 @@ func (x *ConnectionParameters) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectionParameters.ProtoReflect.Descriptor instead.
-func (*ConnectionParameters) Descriptor() ([]byte, []int) {
+// Deprecated: Use Endpoints.ProtoReflect.Descriptor instead.
+func (*Endpoints) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ConnectionParameters) GetUsername() string {
+func (x *Endpoints) GetSecretManager() string {
 	if x != nil {
-		return x.Username
+		return x.SecretManager
 	}
 	return ""
 }
 
-func (x *ConnectionParameters) GetSecret() *SecretRef {
+func (x *Endpoints) GetCloudMonitoring() string {
 	if x != nil {
-		return x.Secret
+		return x.CloudMonitoring
 	}
-	return nil
+	return ""
 }
 
-func (x *ConnectionParameters) GetHost() string {
+func (x *Endpoints) GetCloudLogging() string {
 	if x != nil {
-		return x.Host
+		return x.CloudLogging
 	}
 	return ""
 }
 
-func (x *ConnectionParameters) GetPort() int32 {
+func (x *Endpoints) GetCompute() string {
 	if x != nil {
-		return x.Port
+		return x.Compute
 	}
-	return 0
+	return ""
 }
 
-func (x *ConnectionParameters) GetServiceName() string {
+func (x *Endpoints) GetPubsub() string {
 	if x != nil {
-		return x.ServiceName
+		return x.Pubsub
 	}
 	return ""
 }
 
-func (x *ConnectionParameters) GetPassword() string {
+func (x *Endpoints) GetBigquery() string {
 	if x != nil {
-		return x.Password
+		return x.Bigquery
 	}
 	return ""
 }
 
-type SecretRef struct {
+func (x *Endpoints) GetCloudStorage() string {
+	if x != nil {
+		return x.CloudStorage
+	}
+	return ""
+}
+
+type AuditLog struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The project whose Secret Manager data is being referenced.
-	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	// Name of the secret in Cloud Secret Manager. Note: this is not the
-	// resource name (e.g., projects/<project>/secrets/<secret>). It's
-	// just the secret name, which is the last part of the resource name.
-	SecretName string `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`
+	// defaults to false
+	// when enabled every call exporting data to Data Warehouse, Database Center
+	// or Cloud Monitoring is recorded as a JSON line
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// defaults to google-cloud-workload-agent-audit.log in the agent log
+	// directory
+	FilePath string `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
 }
 
-func (x *SecretRef) Reset() {
-	*x = SecretRef{}
+func (x *AuditLog) Reset() {
+	*x = AuditLog{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1370,13 +1791,13 @@ func (x *SecretRef) Reset() {
 	}
 }
 
-func (x *SecretRef) String() string {
+func (x *AuditLog) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SecretRef) ProtoMessage() {}
+func (*AuditLog) ProtoMessage() {}
 
-func (x *SecretRef) ProtoReflect() protoreflect.Message {
+func (x *AuditLog) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1388,39 +1809,44 @@ func (x *SecretRef) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SecretRef.ProtoReflect.Descriptor instead.
-func (*SecretRef) Descriptor() ([]byte, []int) {
+// Deprecated: Use AuditLog.ProtoReflect.Descriptor instead.
+func (*AuditLog) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *SecretRef) GetProjectId() string {
-	if x != nil {
-		return x.ProjectId
+func (x *AuditLog) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
 	}
-	return ""
+	return false
 }
 
-func (x *SecretRef) GetSecretName() string {
+func (x *AuditLog) GetFilePath() string {
 	if x != nil {
-		return x.SecretName
+		return x.FilePath
 	}
 	return ""
 }
 
-type Query struct {
+// Credentials of the Data Warehouse, Compute Engine, Secret Manager, Cloud
+// Storage, Pub/Sub and BigQuery clients.
+type Credentials struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Sql          string             `protobuf:"bytes,2,opt,name=sql,proto3" json:"sql,omitempty"`
-	Columns      []*Column          `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
-	DatabaseRole Query_DatabaseRole `protobuf:"varint,4,opt,name=database_role,json=databaseRole,proto3,enum=workloadagent.protos.configuration.Query_DatabaseRole" json:"database_role,omitempty"`
-	Disabled     *bool              `protobuf:"varint,5,opt,name=disabled,proto3,oneof" json:"disabled,omitempty"`
+	// service account key or credential configuration file used instead of the
+	// application default credentials
+	CredentialsFile string `protobuf:"bytes,1,opt,name=credentials_file,json=credentialsFile,proto3" json:"credentials_file,omitempty"`
+	// email of a service account impersonated with the credentials file, the
+	// workload identity federation or the application default credentials
+	ImpersonateServiceAccount string `protobuf:"bytes,2,opt,name=impersonate_service_account,json=impersonateServiceAccount,proto3" json:"impersonate_service_account,omitempty"`
+	// mutually exclusive with credentials_file
+	WorkloadIdentityFederation *WorkloadIdentityFederation `protobuf:"bytes,3,opt,name=workload_identity_federation,json=workloadIdentityFederation,proto3" json:"workload_identity_federation,omitempty"`
 }
 
-func (x *Query) Reset() {
-	*x = Query{}
+func (x *Credentials) Reset() {
+	*x = Credentials{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1428,13 +1854,13 @@ func (x *Query) Reset() {
 	}
 }
 
-func (x *Query) String() string {
+func (x *Credentials) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Query) ProtoMessage() {}
+func (*Credentials) ProtoMessage() {}
 
-func (x *Query) ProtoReflect() protoreflect.Message {
+func (x *Credentials) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1446,59 +1872,46 @@ func (x *Query) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Query.ProtoReflect.Descriptor instead.
-func (*Query) Descriptor() ([]byte, []int) {
+// Deprecated: Use Credentials.ProtoReflect.Descriptor instead.
+func (*Credentials) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *Query) GetName() string {
+func (x *Credentials) GetCredentialsFile() string {
 	if x != nil {
-		return x.Name
+		return x.CredentialsFile
 	}
 	return ""
 }
 
-func (x *Query) GetSql() string {
+func (x *Credentials) GetImpersonateServiceAccount() string {
 	if x != nil {
-		return x.Sql
+		return x.ImpersonateServiceAccount
 	}
 	return ""
 }
 
-func (x *Query) GetColumns() []*Column {
+func (x *Credentials) GetWorkloadIdentityFederation() *WorkloadIdentityFederation {
 	if x != nil {
-		return x.Columns
+		return x.WorkloadIdentityFederation
 	}
 	return nil
 }
 
-func (x *Query) GetDatabaseRole() Query_DatabaseRole {
-	if x != nil {
-		return x.DatabaseRole
-	}
-	return Query_UNSPECIFIED
-}
-
-func (x *Query) GetDisabled() bool {
-	if x != nil && x.Disabled != nil {
-		return *x.Disabled
-	}
-	return false
-}
-
-type Column struct {
+type WorkloadIdentityFederation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name         string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	MetricType   MetricType `protobuf:"varint,2,opt,name=metric_type,json=metricType,proto3,enum=workloadagent.protos.configuration.MetricType" json:"metric_type,omitempty"`
-	ValueType    ValueType  `protobuf:"varint,3,opt,name=value_type,json=valueType,proto3,enum=workloadagent.protos.configuration.ValueType" json:"value_type,omitempty"`
-	NameOverride string     `protobuf:"bytes,4,opt,name=name_override,json=nameOverride,proto3" json:"name_override,omitempty"`
+	// audience of the workload identity pool provider, for example
+	// //iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider
+	Audience string `protobuf:"bytes,1,opt,name=audience,proto3" json:"audience,omitempty"`
+	// file containing the OIDC token exchanged for Google credentials
+	SubjectTokenFile string `protobuf:"bytes,2,opt,name=subject_token_file,json=subjectTokenFile,proto3" json:"subject_token_file,omitempty"`
 }
 
-func (x *Column) Reset() {
-	*x = Column{}
+func (x *WorkloadIdentityFederation) Reset() {
+	*x = WorkloadIdentityFederation{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1506,13 +1919,13 @@ func (x *Column) Reset() {
 	}
 }
 
-func (x *Column) String() string {
+func (x *WorkloadIdentityFederation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Column) ProtoMessage() {}
+func (*WorkloadIdentityFederation) ProtoMessage() {}
 
-func (x *Column) ProtoReflect() protoreflect.Message {
+func (x *WorkloadIdentityFederation) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1524,60 +1937,40 @@ func (x *Column) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Column.ProtoReflect.Descriptor instead.
-func (*Column) Descriptor() ([]byte, []int) {
+// Deprecated: Use WorkloadIdentityFederation.ProtoReflect.Descriptor instead.
+func (*WorkloadIdentityFederation) Descriptor() ([]byte, []int) {
 	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Column) GetName() string {
+func (x *WorkloadIdentityFederation) GetAudience() string {
 	if x != nil {
-		return x.Name
+		return x.Audience
 	}
 	return ""
 }
 
-func (x *Column) GetMetricType() MetricType {
-	if x != nil {
-		return x.MetricType
-	}
-	return MetricType_METRIC_UNSPECIFIED
-}
-
-func (x *Column) GetValueType() ValueType {
-	if x != nil {
-		return x.ValueType
-	}
-	return ValueType_VALUE_UNSPECIFIED
-}
-
-func (x *Column) GetNameOverride() string {
+func (x *WorkloadIdentityFederation) GetSubjectTokenFile() string {
 	if x != nil {
-		return x.NameOverride
+		return x.SubjectTokenFile
 	}
 	return ""
 }
 
-type SQLServerConfiguration_CollectionConfiguration struct {
+type InsightLog struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// defaults to True
-	// enables or disables guest os collection
-	CollectGuestOsMetrics bool `protobuf:"varint,1,opt,name=collect_guest_os_metrics,json=collectGuestOsMetrics,proto3" json:"collect_guest_os_metrics,omitempty"`
-	// defaults to True
-	// enables or disables SQL Server collection
-	CollectSqlMetrics bool `protobuf:"varint,2,opt,name=collect_sql_metrics,json=collectSqlMetrics,proto3" json:"collect_sql_metrics,omitempty"`
-	// defaults to 3600s (1 hour)
-	// collection frequency
-	CollectionFrequency *durationpb.Duration `protobuf:"bytes,3,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
-	// defaults to 3600s (1 hour)
-	// dbcenter metrics collection frequency
-	DbcenterMetricsCollectionFrequency *durationpb.Duration `protobuf:"bytes,4,opt,name=dbcenter_metrics_collection_frequency,json=dbcenterMetricsCollectionFrequency,proto3" json:"dbcenter_metrics_collection_frequency,omitempty"`
+	// defaults to false
+	// when enabled every insight written to Data Warehouse is also written as a
+	// structured entry to a Cloud Logging log in the project of the instance
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// defaults to google-cloud-workload-agent-insights
+	LogName string `protobuf:"bytes,2,opt,name=log_name,json=logName,proto3" json:"log_name,omitempty"`
 }
 
-func (x *SQLServerConfiguration_CollectionConfiguration) Reset() {
-	*x = SQLServerConfiguration_CollectionConfiguration{}
+func (x *InsightLog) Reset() {
+	*x = InsightLog{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1585,13 +1978,13 @@ func (x *SQLServerConfiguration_CollectionConfiguration) Reset() {
 	}
 }
 
-func (x *SQLServerConfiguration_CollectionConfiguration) String() string {
+func (x *InsightLog) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLServerConfiguration_CollectionConfiguration) ProtoMessage() {}
+func (*InsightLog) ProtoMessage() {}
 
-func (x *SQLServerConfiguration_CollectionConfiguration) ProtoReflect() protoreflect.Message {
+func (x *InsightLog) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1603,56 +1996,38 @@ func (x *SQLServerConfiguration_CollectionConfiguration) ProtoReflect() protoref
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLServerConfiguration_CollectionConfiguration.ProtoReflect.Descriptor instead.
-func (*SQLServerConfiguration_CollectionConfiguration) Descriptor() ([]byte, []int) {
-	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{12, 0}
-}
-
-func (x *SQLServerConfiguration_CollectionConfiguration) GetCollectGuestOsMetrics() bool {
-	if x != nil {
-		return x.CollectGuestOsMetrics
-	}
-	return false
+// Deprecated: Use InsightLog.ProtoReflect.Descriptor instead.
+func (*InsightLog) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *SQLServerConfiguration_CollectionConfiguration) GetCollectSqlMetrics() bool {
-	if x != nil {
-		return x.CollectSqlMetrics
+func (x *InsightLog) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
 	}
 	return false
 }
 
-func (x *SQLServerConfiguration_CollectionConfiguration) GetCollectionFrequency() *durationpb.Duration {
-	if x != nil {
-		return x.CollectionFrequency
-	}
-	return nil
-}
-
-func (x *SQLServerConfiguration_CollectionConfiguration) GetDbcenterMetricsCollectionFrequency() *durationpb.Duration {
+func (x *InsightLog) GetLogName() string {
 	if x != nil {
-		return x.DbcenterMetricsCollectionFrequency
+		return x.LogName
 	}
-	return nil
+	return ""
 }
 
-type SQLServerConfiguration_CredentialConfiguration struct {
+// Agents which collect some of the workloads also collected by this agent, the
+// legacy Oracle workload agent and the Agent for SQL Server.
+type LegacyAgents struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	VmProperties         *CloudProperties        `protobuf:"bytes,1,opt,name=vm_properties,json=vmProperties,proto3" json:"vm_properties,omitempty"`
-	ConnectionParameters []*ConnectionParameters `protobuf:"bytes,2,rep,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
-	// Types that are assignable to GuestConfigurations:
-	//
-	//	*SQLServerConfiguration_CredentialConfiguration_LocalCollection
-	//	*SQLServerConfiguration_CredentialConfiguration_RemoteWin
-	//	*SQLServerConfiguration_CredentialConfiguration_RemoteLinux
-	GuestConfigurations isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations `protobuf_oneof:"guest_configurations"`
+	// defaults to WORKLOAD_AGENT, a warning is logged while a legacy agent runs
+	Precedence LegacyAgents_Precedence `protobuf:"varint,1,opt,name=precedence,proto3,enum=workloadagent.protos.configuration.LegacyAgents_Precedence" json:"precedence,omitempty"`
 }
 
-func (x *SQLServerConfiguration_CredentialConfiguration) Reset() {
-	*x = SQLServerConfiguration_CredentialConfiguration{}
+func (x *LegacyAgents) Reset() {
+	*x = LegacyAgents{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_protos_configuration_configuration_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1660,13 +2035,13 @@ func (x *SQLServerConfiguration_CredentialConfiguration) Reset() {
 	}
 }
 
-func (x *SQLServerConfiguration_CredentialConfiguration) String() string {
+func (x *LegacyAgents) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLServerConfiguration_CredentialConfiguration) ProtoMessage() {}
+func (*LegacyAgents) ProtoMessage() {}
 
-func (x *SQLServerConfiguration_CredentialConfiguration) ProtoReflect() protoreflect.Message {
+func (x *LegacyAgents) ProtoReflect() protoreflect.Message {
 	mi := &file_protos_configuration_configuration_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1678,64 +2053,2630 @@ func (x *SQLServerConfiguration_CredentialConfiguration) ProtoReflect() protoref
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLServerConfiguration_CredentialConfiguration.ProtoReflect.Descriptor instead.
-func (*SQLServerConfiguration_CredentialConfiguration) Descriptor() ([]byte, []int) {
-	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{12, 1}
+// Deprecated: Use LegacyAgents.ProtoReflect.Descriptor instead.
+func (*LegacyAgents) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *SQLServerConfiguration_CredentialConfiguration) GetVmProperties() *CloudProperties {
+func (x *LegacyAgents) GetPrecedence() LegacyAgents_Precedence {
 	if x != nil {
-		return x.VmProperties
+		return x.Precedence
 	}
-	return nil
+	return LegacyAgents_PRECEDENCE_UNSPECIFIED
 }
 
-func (x *SQLServerConfiguration_CredentialConfiguration) GetConnectionParameters() []*ConnectionParameters {
-	if x != nil {
-		return x.ConnectionParameters
-	}
-	return nil
+type DataWarehouseFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// validation detail keys removed from every insight, for example
+	// replication_zones
+	ExcludedKeys []string `protobuf:"bytes,1,rep,name=excluded_keys,json=excludedKeys,proto3" json:"excluded_keys,omitempty"`
+	// Data Warehouse workload types whose insights are not sent, for example
+	// MYSQL or SQLSERVER
+	// insights without validation details, such as the activation check, are
+	// always sent
+	ExcludedWorkloadTypes []string `protobuf:"bytes,2,rep,name=excluded_workload_types,json=excludedWorkloadTypes,proto3" json:"excluded_workload_types,omitempty"`
 }
 
-func (m *SQLServerConfiguration_CredentialConfiguration) GetGuestConfigurations() isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations {
-	if m != nil {
-		return m.GuestConfigurations
+func (x *DataWarehouseFilter) Reset() {
+	*x = DataWarehouseFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *SQLServerConfiguration_CredentialConfiguration) GetLocalCollection() bool {
-	if x, ok := x.GetGuestConfigurations().(*SQLServerConfiguration_CredentialConfiguration_LocalCollection); ok {
-		return x.LocalCollection
+func (x *DataWarehouseFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataWarehouseFilter) ProtoMessage() {}
+
+func (x *DataWarehouseFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *SQLServerConfiguration_CredentialConfiguration) GetRemoteWin() *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin {
-	if x, ok := x.GetGuestConfigurations().(*SQLServerConfiguration_CredentialConfiguration_RemoteWin); ok {
-		return x.RemoteWin
+// Deprecated: Use DataWarehouseFilter.ProtoReflect.Descriptor instead.
+func (*DataWarehouseFilter) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DataWarehouseFilter) GetExcludedKeys() []string {
+	if x != nil {
+		return x.ExcludedKeys
 	}
 	return nil
 }
 
-func (x *SQLServerConfiguration_CredentialConfiguration) GetRemoteLinux() *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux {
-	if x, ok := x.GetGuestConfigurations().(*SQLServerConfiguration_CredentialConfiguration_RemoteLinux); ok {
-		return x.RemoteLinux
+func (x *DataWarehouseFilter) GetExcludedWorkloadTypes() []string {
+	if x != nil {
+		return x.ExcludedWorkloadTypes
 	}
 	return nil
 }
 
-type isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations interface {
-	isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations()
-}
+// Baselines of the workload settings for change management audits.
+// The validation details of each insight are compared with the baseline of its
+// workload, the settings which differ are logged and added to the insight in
+// the config_drift validation detail, with the baseline in config_baseline.
+type ConfigDrift struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-type SQLServerConfiguration_CredentialConfiguration_LocalCollection struct {
-	LocalCollection bool `protobuf:"varint,3,opt,name=local_collection,json=localCollection,proto3,oneof"`
+	// defaults to false
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// read when the agent starts, changes require a restart
+	Baselines []*ConfigBaseline `protobuf:"bytes,2,rep,name=baselines,proto3" json:"baselines,omitempty"`
 }
 
-type SQLServerConfiguration_CredentialConfiguration_RemoteWin struct {
-	RemoteWin *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin `protobuf:"bytes,4,opt,name=remote_win,json=remoteWin,proto3,oneof"`
-}
+func (x *ConfigDrift) Reset() {
+	*x = ConfigDrift{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigDrift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigDrift) ProtoMessage() {}
+
+func (x *ConfigDrift) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigDrift.ProtoReflect.Descriptor instead.
+func (*ConfigDrift) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConfigDrift) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *ConfigDrift) GetBaselines() []*ConfigBaseline {
+	if x != nil {
+		return x.Baselines
+	}
+	return nil
+}
+
+type ConfigBaseline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Data Warehouse workload type whose insights are compared with the
+	// baseline, for example MYSQL or ORACLE
+	WorkloadType string `protobuf:"bytes,1,opt,name=workload_type,json=workloadType,proto3" json:"workload_type,omitempty"`
+	// YAML or JSON file mapping validation detail keys to their expected values,
+	// for example buffer_pool_size: 8589934592
+	// settings which are not reported in an insight are not compared
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *ConfigBaseline) Reset() {
+	*x = ConfigBaseline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConfigBaseline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfigBaseline) ProtoMessage() {}
+
+func (x *ConfigBaseline) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfigBaseline.ProtoReflect.Descriptor instead.
+func (*ConfigBaseline) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ConfigBaseline) GetWorkloadType() string {
+	if x != nil {
+		return x.WorkloadType
+	}
+	return ""
+}
+
+func (x *ConfigBaseline) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type DataWarehouseExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	// when enabled insights are written to file_path instead of Data Warehouse
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// local file to which insights are appended as JSON lines
+	FilePath string `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+}
+
+func (x *DataWarehouseExport) Reset() {
+	*x = DataWarehouseExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataWarehouseExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataWarehouseExport) ProtoMessage() {}
+
+func (x *DataWarehouseExport) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataWarehouseExport.ProtoReflect.Descriptor instead.
+func (*DataWarehouseExport) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DataWarehouseExport) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *DataWarehouseExport) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+// Synthetic checks of the MySQL and PostgreSQL instances metrics are collected
+// from: a TCP connect, an authenticated connection and a trivial query.
+// The availability and latency of each check are written to Cloud Monitoring
+// as workload.googleapis.com/<engine>/probe_* gauges if the Cloud Monitoring
+// export is enabled.
+type HealthProbes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// defaults to 1 minute, at least 10 seconds
+	Interval *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// timeout of each check, defaults to 5 seconds
+	Timeout *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *HealthProbes) Reset() {
+	*x = HealthProbes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HealthProbes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthProbes) ProtoMessage() {}
+
+func (x *HealthProbes) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthProbes.ProtoReflect.Descriptor instead.
+func (*HealthProbes) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *HealthProbes) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *HealthProbes) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *HealthProbes) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+type CloudMonitoringExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	// when enabled the numeric metrics of the MySQL, PostgreSQL, Redis and
+	// MongoDB collectors are also written to Cloud Monitoring as
+	// workload.googleapis.com/<engine>/<metric> gauges
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+}
+
+func (x *CloudMonitoringExport) Reset() {
+	*x = CloudMonitoringExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudMonitoringExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudMonitoringExport) ProtoMessage() {}
+
+func (x *CloudMonitoringExport) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudMonitoringExport.ProtoReflect.Descriptor instead.
+func (*CloudMonitoringExport) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CloudMonitoringExport) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+type PubSubExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	// when enabled the metrics of every insight are also published as a JSON
+	// object to topic, for example to load them into BigQuery
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// projects/<project>/topics/<topic>, or the name of a topic in the project
+	// to which insights are written
+	Topic string `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+}
+
+func (x *PubSubExport) Reset() {
+	*x = PubSubExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PubSubExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PubSubExport) ProtoMessage() {}
+
+func (x *PubSubExport) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PubSubExport.ProtoReflect.Descriptor instead.
+func (*PubSubExport) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *PubSubExport) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *PubSubExport) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+type BigQueryExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	// when enabled the validation details of every insight are also streamed
+	// into a BigQuery table, which is created or extended with the columns
+	// written by the agent
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// defaults to the project to which insights are written
+	ProjectId string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// dataset of the table, which must already exist
+	Dataset string `protobuf:"bytes,3,opt,name=dataset,proto3" json:"dataset,omitempty"`
+	// defaults to workload_validation_details
+	Table string `protobuf:"bytes,4,opt,name=table,proto3" json:"table,omitempty"`
+}
+
+func (x *BigQueryExport) Reset() {
+	*x = BigQueryExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BigQueryExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BigQueryExport) ProtoMessage() {}
+
+func (x *BigQueryExport) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BigQueryExport.ProtoReflect.Descriptor instead.
+func (*BigQueryExport) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BigQueryExport) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *BigQueryExport) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *BigQueryExport) GetDataset() string {
+	if x != nil {
+		return x.Dataset
+	}
+	return ""
+}
+
+func (x *BigQueryExport) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+type OracleConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled         *bool            `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	OracleDiscovery *OracleDiscovery `protobuf:"bytes,2,opt,name=oracle_discovery,json=oracleDiscovery,proto3" json:"oracle_discovery,omitempty"`
+	OracleMetrics   *OracleMetrics   `protobuf:"bytes,3,opt,name=oracle_metrics,json=oracleMetrics,proto3" json:"oracle_metrics,omitempty"`
+}
+
+func (x *OracleConfiguration) Reset() {
+	*x = OracleConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OracleConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OracleConfiguration) ProtoMessage() {}
+
+func (x *OracleConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OracleConfiguration.ProtoReflect.Descriptor instead.
+func (*OracleConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *OracleConfiguration) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *OracleConfiguration) GetOracleDiscovery() *OracleDiscovery {
+	if x != nil {
+		return x.OracleDiscovery
+	}
+	return nil
+}
+
+func (x *OracleConfiguration) GetOracleMetrics() *OracleMetrics {
+	if x != nil {
+		return x.OracleMetrics
+	}
+	return nil
+}
+
+type OracleDiscovery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UpdateFrequency *durationpb.Duration `protobuf:"bytes,1,opt,name=update_frequency,json=updateFrequency,proto3" json:"update_frequency,omitempty"`
+	Enabled         *bool                `protobuf:"varint,2,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+}
+
+func (x *OracleDiscovery) Reset() {
+	*x = OracleDiscovery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OracleDiscovery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OracleDiscovery) ProtoMessage() {}
+
+func (x *OracleDiscovery) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OracleDiscovery.ProtoReflect.Descriptor instead.
+func (*OracleDiscovery) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *OracleDiscovery) GetUpdateFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.UpdateFrequency
+	}
+	return nil
+}
+
+func (x *OracleDiscovery) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+type OracleMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled              *bool                   `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	CollectionFrequency  *durationpb.Duration    `protobuf:"bytes,2,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
+	ConnectionParameters []*ConnectionParameters `protobuf:"bytes,3,rep,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	Queries              []*Query                `protobuf:"bytes,4,rep,name=queries,proto3" json:"queries,omitempty"`
+	MaxExecutionThreads  int64                   `protobuf:"varint,5,opt,name=max_execution_threads,json=maxExecutionThreads,proto3" json:"max_execution_threads,omitempty"`
+	QueryTimeout         *durationpb.Duration    `protobuf:"bytes,6,opt,name=query_timeout,json=queryTimeout,proto3" json:"query_timeout,omitempty"`
+	ActivitySampling     *OracleActivitySampling `protobuf:"bytes,7,opt,name=activity_sampling,json=activitySampling,proto3" json:"activity_sampling,omitempty"`
+}
+
+func (x *OracleMetrics) Reset() {
+	*x = OracleMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OracleMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OracleMetrics) ProtoMessage() {}
+
+func (x *OracleMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OracleMetrics.ProtoReflect.Descriptor instead.
+func (*OracleMetrics) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *OracleMetrics) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *OracleMetrics) GetCollectionFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.CollectionFrequency
+	}
+	return nil
+}
+
+func (x *OracleMetrics) GetConnectionParameters() []*ConnectionParameters {
+	if x != nil {
+		return x.ConnectionParameters
+	}
+	return nil
+}
+
+func (x *OracleMetrics) GetQueries() []*Query {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+func (x *OracleMetrics) GetMaxExecutionThreads() int64 {
+	if x != nil {
+		return x.MaxExecutionThreads
+	}
+	return 0
+}
+
+func (x *OracleMetrics) GetQueryTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.QueryTimeout
+	}
+	return nil
+}
+
+func (x *OracleMetrics) GetActivitySampling() *OracleActivitySampling {
+	if x != nil {
+		return x.ActivitySampling
+	}
+	return nil
+}
+
+// Reports the DB time and the top wait classes of each collection as
+// workload.googleapis.com/oracle/activity/* metrics.
+// Active Session History is part of the Oracle Diagnostics Pack, it is only
+// queried if diagnostics_pack_licensed is set. Statspack must be installed in
+// the PERFSTAT schema and reports the interval between its two latest
+// snapshots once, when a new snapshot is taken, with begin_snap_id and
+// end_snap_id labels.
+type OracleActivitySampling struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// acknowledges that the databases are licensed for the Oracle Diagnostics
+	// Pack, required to sample Active Session History
+	DiagnosticsPackLicensed bool `protobuf:"varint,2,opt,name=diagnostics_pack_licensed,json=diagnosticsPackLicensed,proto3" json:"diagnostics_pack_licensed,omitempty"`
+	// defaults to ACTIVE_SESSION_HISTORY if diagnostics_pack_licensed is set and
+	// to STATSPACK otherwise
+	Source OracleActivitySampling_Source `protobuf:"varint,3,opt,name=source,proto3,enum=workloadagent.protos.configuration.OracleActivitySampling_Source" json:"source,omitempty"`
+	// number of wait classes reported, defaults to 5
+	TopN int32 `protobuf:"varint,4,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+}
+
+func (x *OracleActivitySampling) Reset() {
+	*x = OracleActivitySampling{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OracleActivitySampling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OracleActivitySampling) ProtoMessage() {}
+
+func (x *OracleActivitySampling) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OracleActivitySampling.ProtoReflect.Descriptor instead.
+func (*OracleActivitySampling) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *OracleActivitySampling) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *OracleActivitySampling) GetDiagnosticsPackLicensed() bool {
+	if x != nil {
+		return x.DiagnosticsPackLicensed
+	}
+	return false
+}
+
+func (x *OracleActivitySampling) GetSource() OracleActivitySampling_Source {
+	if x != nil {
+		return x.Source
+	}
+	return OracleActivitySampling_SOURCE_UNSPECIFIED
+}
+
+func (x *OracleActivitySampling) GetTopN() int32 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+type MySQLConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	// Min 10 mins, Max 6 hours, default 1 hour
+	DbcenterCollectionFrequency *durationpb.Duration `protobuf:"bytes,3,opt,name=dbcenter_collection_frequency,json=dbcenterCollectionFrequency,proto3" json:"dbcenter_collection_frequency,omitempty"`
+	QueryDigests                *MySQLQueryDigests   `protobuf:"bytes,4,opt,name=query_digests,json=queryDigests,proto3" json:"query_digests,omitempty"`
+	// directories written by mysqldump, xtrabackup or mysqlsh, used to detect
+	// the most recent backup; a backup is only reported once its backup process
+	// has exited
+	BackupDirectories []string `protobuf:"bytes,5,rep,name=backup_directories,json=backupDirectories,proto3" json:"backup_directories,omitempty"`
+	// one entry per MySQL instance on the host, used instead of
+	// connection_parameters when set; host defaults to localhost and port to 3306
+	Instances []*ConnectionParameters `protobuf:"bytes,6,rep,name=instances,proto3" json:"instances,omitempty"`
+	Tls       *TLSConfiguration       `protobuf:"bytes,7,opt,name=tls,proto3" json:"tls,omitempty"`
+	// admin interface of a ProxySQL instance in front of MySQL, its version,
+	// backend servers and connection pool are reported with the MySQL insight;
+	// host defaults to localhost and port to 6032
+	Proxysql     *ConnectionParameters `protobuf:"bytes,8,opt,name=proxysql,proto3" json:"proxysql,omitempty"`
+	SlowQueryLog *MySQLSlowQueryLog    `protobuf:"bytes,9,opt,name=slow_query_log,json=slowQueryLog,proto3" json:"slow_query_log,omitempty"`
+}
+
+func (x *MySQLConfiguration) Reset() {
+	*x = MySQLConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MySQLConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MySQLConfiguration) ProtoMessage() {}
+
+func (x *MySQLConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MySQLConfiguration.ProtoReflect.Descriptor instead.
+func (*MySQLConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MySQLConfiguration) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *MySQLConfiguration) GetConnectionParameters() *ConnectionParameters {
+	if x != nil {
+		return x.ConnectionParameters
+	}
+	return nil
+}
+
+func (x *MySQLConfiguration) GetDbcenterCollectionFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.DbcenterCollectionFrequency
+	}
+	return nil
+}
+
+func (x *MySQLConfiguration) GetQueryDigests() *MySQLQueryDigests {
+	if x != nil {
+		return x.QueryDigests
+	}
+	return nil
+}
+
+func (x *MySQLConfiguration) GetBackupDirectories() []string {
+	if x != nil {
+		return x.BackupDirectories
+	}
+	return nil
+}
+
+func (x *MySQLConfiguration) GetInstances() []*ConnectionParameters {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+func (x *MySQLConfiguration) GetTls() *TLSConfiguration {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *MySQLConfiguration) GetProxysql() *ConnectionParameters {
+	if x != nil {
+		return x.Proxysql
+	}
+	return nil
+}
+
+func (x *MySQLConfiguration) GetSlowQueryLog() *MySQLSlowQueryLog {
+	if x != nil {
+		return x.SlowQueryLog
+	}
+	return nil
+}
+
+// Summarizes the top statement digests from
+// performance_schema.events_statements_summary_by_digest. Only digest hashes
+// are reported, never statement text or literals.
+type MySQLQueryDigests struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// number of digests reported per ranking, defaults to 5
+	TopN int32 `protobuf:"varint,2,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+}
+
+func (x *MySQLQueryDigests) Reset() {
+	*x = MySQLQueryDigests{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MySQLQueryDigests) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MySQLQueryDigests) ProtoMessage() {}
+
+func (x *MySQLQueryDigests) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MySQLQueryDigests.ProtoReflect.Descriptor instead.
+func (*MySQLQueryDigests) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MySQLQueryDigests) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *MySQLQueryDigests) GetTopN() int32 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+// Summarizes the statements written to the slow query log since the previous
+// collection. Statements are grouped by a fingerprint with their literals
+// removed, and only fingerprint hashes are reported.
+// The log must be on this host and readable by the agent.
+type MySQLSlowQueryLog struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// number of fingerprints reported per ranking, defaults to 5
+	TopN int32 `protobuf:"varint,2,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+	// defaults to the slow_query_log_file variable of the server
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *MySQLSlowQueryLog) Reset() {
+	*x = MySQLSlowQueryLog{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MySQLSlowQueryLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MySQLSlowQueryLog) ProtoMessage() {}
+
+func (x *MySQLSlowQueryLog) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MySQLSlowQueryLog.ProtoReflect.Descriptor instead.
+func (*MySQLSlowQueryLog) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *MySQLSlowQueryLog) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *MySQLSlowQueryLog) GetTopN() int32 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+func (x *MySQLSlowQueryLog) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type OpenShiftConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+}
+
+func (x *OpenShiftConfiguration) Reset() {
+	*x = OpenShiftConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OpenShiftConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenShiftConfiguration) ProtoMessage() {}
+
+func (x *OpenShiftConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenShiftConfiguration.ProtoReflect.Descriptor instead.
+func (*OpenShiftConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *OpenShiftConfiguration) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *OpenShiftConfiguration) GetConnectionParameters() *ConnectionParameters {
+	if x != nil {
+		return x.ConnectionParameters
+	}
+	return nil
+}
+
+type CommonDiscovery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled             *bool                `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	CollectionFrequency *durationpb.Duration `protobuf:"bytes,2,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
+	// extends the built-in workload signatures, e.g. for custom-named binaries
+	WorkloadSignatures   []*WorkloadSignature  `protobuf:"bytes,3,rep,name=workload_signatures,json=workloadSignatures,proto3" json:"workload_signatures,omitempty"`
+	ContainerAttribution *ContainerAttribution `protobuf:"bytes,4,opt,name=container_attribution,json=containerAttribution,proto3" json:"container_attribution,omitempty"`
+}
+
+func (x *CommonDiscovery) Reset() {
+	*x = CommonDiscovery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommonDiscovery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommonDiscovery) ProtoMessage() {}
+
+func (x *CommonDiscovery) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommonDiscovery.ProtoReflect.Descriptor instead.
+func (*CommonDiscovery) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CommonDiscovery) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *CommonDiscovery) GetCollectionFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.CollectionFrequency
+	}
+	return nil
+}
+
+func (x *CommonDiscovery) GetWorkloadSignatures() []*WorkloadSignature {
+	if x != nil {
+		return x.WorkloadSignatures
+	}
+	return nil
+}
+
+func (x *CommonDiscovery) GetContainerAttribution() *ContainerAttribution {
+	if x != nil {
+		return x.ContainerAttribution
+	}
+	return nil
+}
+
+// Attributes processes running in containers to their cgroup and Kubernetes
+// pod.
+type ContainerAttribution struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to true
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// the kubelet pods endpoint, defaults to http://localhost:10255/pods
+	KubeletUrl string `protobuf:"bytes,2,opt,name=kubelet_url,json=kubeletUrl,proto3" json:"kubelet_url,omitempty"`
+}
+
+func (x *ContainerAttribution) Reset() {
+	*x = ContainerAttribution{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContainerAttribution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerAttribution) ProtoMessage() {}
+
+func (x *ContainerAttribution) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerAttribution.ProtoReflect.Descriptor instead.
+func (*ContainerAttribution) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ContainerAttribution) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *ContainerAttribution) GetKubeletUrl() string {
+	if x != nil {
+		return x.KubeletUrl
+	}
+	return ""
+}
+
+// A process matches a signature when every criterion which is set matches.
+type WorkloadSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// one of oracle, mysql, postgres, redis, mongodb, the connection poolers
+	// proxysql or pgbouncer, the cluster manager pacemaker, or the legacy agents
+	// legacy_oracle_agent, sqlserver_agent or sap_agent
+	Workload string `protobuf:"bytes,1,opt,name=workload,proto3" json:"workload,omitempty"`
+	// regular expressions, any of which must match the process name
+	ProcessNamePatterns []string `protobuf:"bytes,2,rep,name=process_name_patterns,json=processNamePatterns,proto3" json:"process_name_patterns,omitempty"`
+	// regular expressions, any of which must match the space separated command
+	// line
+	CmdlinePatterns []string `protobuf:"bytes,3,rep,name=cmdline_patterns,json=cmdlinePatterns,proto3" json:"cmdline_patterns,omitempty"`
+	// environment variables, as NAME or NAME=value, any of which must be set
+	EnvironmentMarkers []string `protobuf:"bytes,4,rep,name=environment_markers,json=environmentMarkers,proto3" json:"environment_markers,omitempty"`
+	// ports, any of which the process must listen on
+	Ports []int32 `protobuf:"varint,5,rep,packed,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (x *WorkloadSignature) Reset() {
+	*x = WorkloadSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkloadSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkloadSignature) ProtoMessage() {}
+
+func (x *WorkloadSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkloadSignature.ProtoReflect.Descriptor instead.
+func (*WorkloadSignature) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *WorkloadSignature) GetWorkload() string {
+	if x != nil {
+		return x.Workload
+	}
+	return ""
+}
+
+func (x *WorkloadSignature) GetProcessNamePatterns() []string {
+	if x != nil {
+		return x.ProcessNamePatterns
+	}
+	return nil
+}
+
+func (x *WorkloadSignature) GetCmdlinePatterns() []string {
+	if x != nil {
+		return x.CmdlinePatterns
+	}
+	return nil
+}
+
+func (x *WorkloadSignature) GetEnvironmentMarkers() []string {
+	if x != nil {
+		return x.EnvironmentMarkers
+	}
+	return nil
+}
+
+func (x *WorkloadSignature) GetPorts() []int32 {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+type RedisConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled *bool `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	// the username authenticates as an ACL user; without it the password
+	// authenticates the default user (requirepass)
+	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	// connects over TLS, like a rediss:// URL
+	Tls *TLSConfiguration `protobuf:"bytes,3,opt,name=tls,proto3" json:"tls,omitempty"`
+}
+
+func (x *RedisConfiguration) Reset() {
+	*x = RedisConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RedisConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedisConfiguration) ProtoMessage() {}
+
+func (x *RedisConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedisConfiguration.ProtoReflect.Descriptor instead.
+func (*RedisConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RedisConfiguration) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *RedisConfiguration) GetConnectionParameters() *ConnectionParameters {
+	if x != nil {
+		return x.ConnectionParameters
+	}
+	return nil
+}
+
+func (x *RedisConfiguration) GetTls() *TLSConfiguration {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+type TLSConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// PEM file with the CA certificates which sign the server certificate;
+	// defaults to the system roots
+	CaFile string `protobuf:"bytes,2,opt,name=ca_file,json=caFile,proto3" json:"ca_file,omitempty"`
+	// PEM files with the client certificate and key, for servers which require
+	// client authentication
+	CertFile string `protobuf:"bytes,3,opt,name=cert_file,json=certFile,proto3" json:"cert_file,omitempty"`
+	KeyFile  string `protobuf:"bytes,4,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+	// defaults to false, only for testing against self-signed certificates
+	InsecureSkipVerify bool `protobuf:"varint,5,opt,name=insecure_skip_verify,json=insecureSkipVerify,proto3" json:"insecure_skip_verify,omitempty"`
+	// Secret Manager secrets with the PEM client certificate and key, used
+	// instead of cert_file and key_file. Certificate files are reloaded when they
+	// change and secrets are read again every 5 minutes, so rotated certificates
+	// are used for new connections without restarting the agent.
+	CertSecret *SecretRef `protobuf:"bytes,6,opt,name=cert_secret,json=certSecret,proto3" json:"cert_secret,omitempty"`
+	KeySecret  *SecretRef `protobuf:"bytes,7,opt,name=key_secret,json=keySecret,proto3" json:"key_secret,omitempty"`
+}
+
+func (x *TLSConfiguration) Reset() {
+	*x = TLSConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TLSConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TLSConfiguration) ProtoMessage() {}
+
+func (x *TLSConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TLSConfiguration.ProtoReflect.Descriptor instead.
+func (*TLSConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *TLSConfiguration) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *TLSConfiguration) GetCaFile() string {
+	if x != nil {
+		return x.CaFile
+	}
+	return ""
+}
+
+func (x *TLSConfiguration) GetCertFile() string {
+	if x != nil {
+		return x.CertFile
+	}
+	return ""
+}
+
+func (x *TLSConfiguration) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+func (x *TLSConfiguration) GetInsecureSkipVerify() bool {
+	if x != nil {
+		return x.InsecureSkipVerify
+	}
+	return false
+}
+
+func (x *TLSConfiguration) GetCertSecret() *SecretRef {
+	if x != nil {
+		return x.CertSecret
+	}
+	return nil
+}
+
+func (x *TLSConfiguration) GetKeySecret() *SecretRef {
+	if x != nil {
+		return x.KeySecret
+	}
+	return nil
+}
+
+type PostgresConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	// Min 10 mins, Max 6 hours, default 1 hour
+	DbcenterCollectionFrequency *durationpb.Duration `protobuf:"bytes,3,opt,name=dbcenter_collection_frequency,json=dbcenterCollectionFrequency,proto3" json:"dbcenter_collection_frequency,omitempty"`
+	// one entry per Postgres instance on the host, used instead of
+	// connection_parameters when set; host defaults to localhost and port to 5432
+	Instances []*ConnectionParameters `protobuf:"bytes,4,rep,name=instances,proto3" json:"instances,omitempty"`
+	// without TLS the agent connects with SSL if the server supports it and
+	// falls back to an unencrypted connection otherwise
+	Tls *TLSConfiguration `protobuf:"bytes,5,opt,name=tls,proto3" json:"tls,omitempty"`
+	// admin console of a PgBouncer instance in front of Postgres, its version,
+	// databases and pool sizes are reported with the Postgres insight; host
+	// defaults to localhost and port to 6432
+	Pgbouncer *ConnectionParameters `protobuf:"bytes,6,opt,name=pgbouncer,proto3" json:"pgbouncer,omitempty"`
+	// REST API of the Patroni instance managing Postgres on this host, only
+	// queried while Patroni is running; defaults to http://localhost:8008
+	PatroniUrl string              `protobuf:"bytes,7,opt,name=patroni_url,json=patroniUrl,proto3" json:"patroni_url,omitempty"`
+	Statements *PostgresStatements `protobuf:"bytes,8,opt,name=statements,proto3" json:"statements,omitempty"`
+}
+
+func (x *PostgresConfiguration) Reset() {
+	*x = PostgresConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostgresConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostgresConfiguration) ProtoMessage() {}
+
+func (x *PostgresConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostgresConfiguration.ProtoReflect.Descriptor instead.
+func (*PostgresConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PostgresConfiguration) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *PostgresConfiguration) GetConnectionParameters() *ConnectionParameters {
+	if x != nil {
+		return x.ConnectionParameters
+	}
+	return nil
+}
+
+func (x *PostgresConfiguration) GetDbcenterCollectionFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.DbcenterCollectionFrequency
+	}
+	return nil
+}
+
+func (x *PostgresConfiguration) GetInstances() []*ConnectionParameters {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+func (x *PostgresConfiguration) GetTls() *TLSConfiguration {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *PostgresConfiguration) GetPgbouncer() *ConnectionParameters {
+	if x != nil {
+		return x.Pgbouncer
+	}
+	return nil
+}
+
+func (x *PostgresConfiguration) GetPatroniUrl() string {
+	if x != nil {
+		return x.PatroniUrl
+	}
+	return ""
+}
+
+func (x *PostgresConfiguration) GetStatements() *PostgresStatements {
+	if x != nil {
+		return x.Statements
+	}
+	return nil
+}
+
+// Summarizes pg_stat_statements whenever the extension is installed. Only
+// query IDs and hashes of the normalized query text are reported. Statements
+// of other users are only visible to members of pg_read_all_stats.
+type PostgresStatements struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to false
+	Disabled *bool `protobuf:"varint,1,opt,name=disabled,proto3,oneof" json:"disabled,omitempty"`
+	// number of statements reported per ranking, defaults to 5
+	TopN int32 `protobuf:"varint,2,opt,name=top_n,json=topN,proto3" json:"top_n,omitempty"`
+}
+
+func (x *PostgresStatements) Reset() {
+	*x = PostgresStatements{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PostgresStatements) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostgresStatements) ProtoMessage() {}
+
+func (x *PostgresStatements) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostgresStatements.ProtoReflect.Descriptor instead.
+func (*PostgresStatements) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *PostgresStatements) GetDisabled() bool {
+	if x != nil && x.Disabled != nil {
+		return *x.Disabled
+	}
+	return false
+}
+
+func (x *PostgresStatements) GetTopN() int32 {
+	if x != nil {
+		return x.TopN
+	}
+	return 0
+}
+
+type MongoDBConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled              *bool                 `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	ConnectionParameters *ConnectionParameters `protobuf:"bytes,2,opt,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	// Min 10 mins, Max 6 hours, default 1 hour
+	CollectionFrequency *durationpb.Duration               `protobuf:"bytes,3,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
+	Tls                 *TLSConfiguration                  `protobuf:"bytes,4,opt,name=tls,proto3" json:"tls,omitempty"`
+	AuthMechanism       MongoDBConfiguration_AuthMechanism `protobuf:"varint,5,opt,name=auth_mechanism,json=authMechanism,proto3,enum=workloadagent.protos.configuration.MongoDBConfiguration_AuthMechanism" json:"auth_mechanism,omitempty"`
+}
+
+func (x *MongoDBConfiguration) Reset() {
+	*x = MongoDBConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MongoDBConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MongoDBConfiguration) ProtoMessage() {}
+
+func (x *MongoDBConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MongoDBConfiguration.ProtoReflect.Descriptor instead.
+func (*MongoDBConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MongoDBConfiguration) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *MongoDBConfiguration) GetConnectionParameters() *ConnectionParameters {
+	if x != nil {
+		return x.ConnectionParameters
+	}
+	return nil
+}
+
+func (x *MongoDBConfiguration) GetCollectionFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.CollectionFrequency
+	}
+	return nil
+}
+
+func (x *MongoDBConfiguration) GetTls() *TLSConfiguration {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+func (x *MongoDBConfiguration) GetAuthMechanism() MongoDBConfiguration_AuthMechanism {
+	if x != nil {
+		return x.AuthMechanism
+	}
+	return MongoDBConfiguration_AUTH_MECHANISM_UNSPECIFIED
+}
+
+type SQLServerConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Enabled                  *bool                                             `protobuf:"varint,1,opt,name=enabled,proto3,oneof" json:"enabled,omitempty"`
+	CollectionConfiguration  *SQLServerConfiguration_CollectionConfiguration   `protobuf:"bytes,2,opt,name=collection_configuration,json=collectionConfiguration,proto3" json:"collection_configuration,omitempty"`
+	CredentialConfigurations []*SQLServerConfiguration_CredentialConfiguration `protobuf:"bytes,3,rep,name=credential_configurations,json=credentialConfigurations,proto3" json:"credential_configurations,omitempty"`
+	// default timeout is 10s
+	CollectionTimeout *durationpb.Duration `protobuf:"bytes,4,opt,name=collection_timeout,json=collectionTimeout,proto3" json:"collection_timeout,omitempty"`
+	// default max_retries is 3
+	MaxRetries int32 `protobuf:"varint,5,opt,name=max_retries,json=maxRetries,proto3" json:"max_retries,omitempty"`
+	// default retry_frequency is 3600s
+	RetryFrequency *durationpb.Duration `protobuf:"bytes,6,opt,name=retry_frequency,json=retryFrequency,proto3" json:"retry_frequency,omitempty"`
+	// default remote collection is false
+	RemoteCollection bool `protobuf:"varint,7,opt,name=remote_collection,json=remoteCollection,proto3" json:"remote_collection,omitempty"`
+}
+
+func (x *SQLServerConfiguration) Reset() {
+	*x = SQLServerConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLServerConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLServerConfiguration) ProtoMessage() {}
+
+func (x *SQLServerConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLServerConfiguration.ProtoReflect.Descriptor instead.
+func (*SQLServerConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *SQLServerConfiguration) GetEnabled() bool {
+	if x != nil && x.Enabled != nil {
+		return *x.Enabled
+	}
+	return false
+}
+
+func (x *SQLServerConfiguration) GetCollectionConfiguration() *SQLServerConfiguration_CollectionConfiguration {
+	if x != nil {
+		return x.CollectionConfiguration
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration) GetCredentialConfigurations() []*SQLServerConfiguration_CredentialConfiguration {
+	if x != nil {
+		return x.CredentialConfigurations
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration) GetCollectionTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.CollectionTimeout
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration) GetMaxRetries() int32 {
+	if x != nil {
+		return x.MaxRetries
+	}
+	return 0
+}
+
+func (x *SQLServerConfiguration) GetRetryFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.RetryFrequency
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration) GetRemoteCollection() bool {
+	if x != nil {
+		return x.RemoteCollection
+	}
+	return false
+}
+
+type ConnectionParameters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Username    string     `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Secret      *SecretRef `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Host        string     `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Port        int32      `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	ServiceName string     `protobuf:"bytes,5,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Password    string     `protobuf:"bytes,6,opt,name=password,proto3" json:"password,omitempty"`
+	// read instead of secret, for credentials stored in HashiCorp Vault
+	VaultSecret *VaultSecretRef `protobuf:"bytes,7,opt,name=vault_secret,json=vaultSecret,proto3" json:"vault_secret,omitempty"`
+	// read instead of secret, for passwords encrypted in the local credential
+	// store with "configure credentials set"
+	StoredCredential *StoredCredentialRef `protobuf:"bytes,8,opt,name=stored_credential,json=storedCredential,proto3" json:"stored_credential,omitempty"`
+}
+
+func (x *ConnectionParameters) Reset() {
+	*x = ConnectionParameters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConnectionParameters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionParameters) ProtoMessage() {}
+
+func (x *ConnectionParameters) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionParameters.ProtoReflect.Descriptor instead.
+func (*ConnectionParameters) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ConnectionParameters) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ConnectionParameters) GetSecret() *SecretRef {
+	if x != nil {
+		return x.Secret
+	}
+	return nil
+}
+
+func (x *ConnectionParameters) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ConnectionParameters) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ConnectionParameters) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ConnectionParameters) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *ConnectionParameters) GetVaultSecret() *VaultSecretRef {
+	if x != nil {
+		return x.VaultSecret
+	}
+	return nil
+}
+
+func (x *ConnectionParameters) GetStoredCredential() *StoredCredentialRef {
+	if x != nil {
+		return x.StoredCredential
+	}
+	return nil
+}
+
+type StoredCredentialRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// name of the credential in the store, e.g. mysql
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// defaults to credentialstore.json in the agent configuration directory
+	StoreFile string `protobuf:"bytes,2,opt,name=store_file,json=storeFile,proto3" json:"store_file,omitempty"`
+	// file with the 256 bit key the credential is encrypted with; defaults to a
+	// key derived from the non-secret identity of the Compute Engine instance,
+	// which only obfuscates the credential
+	KeyFile string `protobuf:"bytes,3,opt,name=key_file,json=keyFile,proto3" json:"key_file,omitempty"`
+}
+
+func (x *StoredCredentialRef) Reset() {
+	*x = StoredCredentialRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoredCredentialRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoredCredentialRef) ProtoMessage() {}
+
+func (x *StoredCredentialRef) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoredCredentialRef.ProtoReflect.Descriptor instead.
+func (*StoredCredentialRef) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *StoredCredentialRef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StoredCredentialRef) GetStoreFile() string {
+	if x != nil {
+		return x.StoreFile
+	}
+	return ""
+}
+
+func (x *StoredCredentialRef) GetKeyFile() string {
+	if x != nil {
+		return x.KeyFile
+	}
+	return ""
+}
+
+type SecretRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The project whose Secret Manager data is being referenced.
+	ProjectId string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Name of the secret in Cloud Secret Manager. Note: this is not the
+	// resource name (e.g., projects/<project>/secrets/<secret>). It's
+	// just the secret name, which is the last part of the resource name.
+	SecretName string           `protobuf:"bytes,2,opt,name=secret_name,json=secretName,proto3" json:"secret_name,omitempty"`
+	Format     SecretRef_Format `protobuf:"varint,3,opt,name=format,proto3,enum=workloadagent.protos.configuration.SecretRef_Format" json:"format,omitempty"`
+}
+
+func (x *SecretRef) Reset() {
+	*x = SecretRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SecretRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SecretRef) ProtoMessage() {}
+
+func (x *SecretRef) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SecretRef.ProtoReflect.Descriptor instead.
+func (*SecretRef) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *SecretRef) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *SecretRef) GetSecretName() string {
+	if x != nil {
+		return x.SecretName
+	}
+	return ""
+}
+
+func (x *SecretRef) GetFormat() SecretRef_Format {
+	if x != nil {
+		return x.Format
+	}
+	return SecretRef_FORMAT_UNSPECIFIED
+}
+
+type VaultSecretRef struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// address of the Vault server, e.g. https://vault.example.com:8200
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	// defaults to "secret"
+	// mount path of the KV version 2 secrets engine
+	Mount string `protobuf:"bytes,2,opt,name=mount,proto3" json:"mount,omitempty"`
+	// path of the secret in the secrets engine; the secret holds the password
+	// under the "password" key and may hold the "username", "host" and "port",
+	// which take precedence over the connection parameters
+	Path string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// Vault Enterprise namespace of the secret
+	Namespace string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Types that are assignable to Auth:
+	//
+	//	*VaultSecretRef_TokenFile
+	//	*VaultSecretRef_AppRole
+	Auth isVaultSecretRef_Auth `protobuf_oneof:"auth"`
+	// needed for Vault servers with a certificate signed by a private CA or
+	// which require client certificates
+	Tls *TLSConfiguration `protobuf:"bytes,7,opt,name=tls,proto3" json:"tls,omitempty"`
+}
+
+func (x *VaultSecretRef) Reset() {
+	*x = VaultSecretRef{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VaultSecretRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VaultSecretRef) ProtoMessage() {}
+
+func (x *VaultSecretRef) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VaultSecretRef.ProtoReflect.Descriptor instead.
+func (*VaultSecretRef) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *VaultSecretRef) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *VaultSecretRef) GetMount() string {
+	if x != nil {
+		return x.Mount
+	}
+	return ""
+}
+
+func (x *VaultSecretRef) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *VaultSecretRef) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (m *VaultSecretRef) GetAuth() isVaultSecretRef_Auth {
+	if m != nil {
+		return m.Auth
+	}
+	return nil
+}
+
+func (x *VaultSecretRef) GetTokenFile() string {
+	if x, ok := x.GetAuth().(*VaultSecretRef_TokenFile); ok {
+		return x.TokenFile
+	}
+	return ""
+}
+
+func (x *VaultSecretRef) GetAppRole() *VaultAppRole {
+	if x, ok := x.GetAuth().(*VaultSecretRef_AppRole); ok {
+		return x.AppRole
+	}
+	return nil
+}
+
+func (x *VaultSecretRef) GetTls() *TLSConfiguration {
+	if x != nil {
+		return x.Tls
+	}
+	return nil
+}
+
+type isVaultSecretRef_Auth interface {
+	isVaultSecretRef_Auth()
+}
+
+type VaultSecretRef_TokenFile struct {
+	// file containing the Vault token, read for every request so that a
+	// token renewed by Vault Agent is picked up
+	TokenFile string `protobuf:"bytes,5,opt,name=token_file,json=tokenFile,proto3,oneof"`
+}
+
+type VaultSecretRef_AppRole struct {
+	AppRole *VaultAppRole `protobuf:"bytes,6,opt,name=app_role,json=appRole,proto3,oneof"`
+}
+
+func (*VaultSecretRef_TokenFile) isVaultSecretRef_Auth() {}
+
+func (*VaultSecretRef_AppRole) isVaultSecretRef_Auth() {}
+
+type VaultAppRole struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to "approle"
+	// mount path of the AppRole auth method
+	Mount  string `protobuf:"bytes,1,opt,name=mount,proto3" json:"mount,omitempty"`
+	RoleId string `protobuf:"bytes,2,opt,name=role_id,json=roleId,proto3" json:"role_id,omitempty"`
+	// file containing the secret ID
+	SecretIdFile string `protobuf:"bytes,3,opt,name=secret_id_file,json=secretIdFile,proto3" json:"secret_id_file,omitempty"`
+}
+
+func (x *VaultAppRole) Reset() {
+	*x = VaultAppRole{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VaultAppRole) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VaultAppRole) ProtoMessage() {}
+
+func (x *VaultAppRole) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VaultAppRole.ProtoReflect.Descriptor instead.
+func (*VaultAppRole) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *VaultAppRole) GetMount() string {
+	if x != nil {
+		return x.Mount
+	}
+	return ""
+}
+
+func (x *VaultAppRole) GetRoleId() string {
+	if x != nil {
+		return x.RoleId
+	}
+	return ""
+}
+
+func (x *VaultAppRole) GetSecretIdFile() string {
+	if x != nil {
+		return x.SecretIdFile
+	}
+	return ""
+}
+
+type Query struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Sql          string             `protobuf:"bytes,2,opt,name=sql,proto3" json:"sql,omitempty"`
+	Columns      []*Column          `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
+	DatabaseRole Query_DatabaseRole `protobuf:"varint,4,opt,name=database_role,json=databaseRole,proto3,enum=workloadagent.protos.configuration.Query_DatabaseRole" json:"database_role,omitempty"`
+	Disabled     *bool              `protobuf:"varint,5,opt,name=disabled,proto3,oneof" json:"disabled,omitempty"`
+}
+
+func (x *Query) Reset() {
+	*x = Query{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Query) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Query) ProtoMessage() {}
+
+func (x *Query) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Query.ProtoReflect.Descriptor instead.
+func (*Query) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Query) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Query) GetSql() string {
+	if x != nil {
+		return x.Sql
+	}
+	return ""
+}
+
+func (x *Query) GetColumns() []*Column {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *Query) GetDatabaseRole() Query_DatabaseRole {
+	if x != nil {
+		return x.DatabaseRole
+	}
+	return Query_UNSPECIFIED
+}
+
+func (x *Query) GetDisabled() bool {
+	if x != nil && x.Disabled != nil {
+		return *x.Disabled
+	}
+	return false
+}
+
+type Column struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MetricType   MetricType `protobuf:"varint,2,opt,name=metric_type,json=metricType,proto3,enum=workloadagent.protos.configuration.MetricType" json:"metric_type,omitempty"`
+	ValueType    ValueType  `protobuf:"varint,3,opt,name=value_type,json=valueType,proto3,enum=workloadagent.protos.configuration.ValueType" json:"value_type,omitempty"`
+	NameOverride string     `protobuf:"bytes,4,opt,name=name_override,json=nameOverride,proto3" json:"name_override,omitempty"`
+}
+
+func (x *Column) Reset() {
+	*x = Column{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Column) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Column) ProtoMessage() {}
+
+func (x *Column) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Column.ProtoReflect.Descriptor instead.
+func (*Column) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Column) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Column) GetMetricType() MetricType {
+	if x != nil {
+		return x.MetricType
+	}
+	return MetricType_METRIC_UNSPECIFIED
+}
+
+func (x *Column) GetValueType() ValueType {
+	if x != nil {
+		return x.ValueType
+	}
+	return ValueType_VALUE_UNSPECIFIED
+}
+
+func (x *Column) GetNameOverride() string {
+	if x != nil {
+		return x.NameOverride
+	}
+	return ""
+}
+
+type SQLServerConfiguration_CollectionConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// defaults to True
+	// enables or disables guest os collection
+	CollectGuestOsMetrics bool `protobuf:"varint,1,opt,name=collect_guest_os_metrics,json=collectGuestOsMetrics,proto3" json:"collect_guest_os_metrics,omitempty"`
+	// defaults to True
+	// enables or disables SQL Server collection
+	CollectSqlMetrics bool `protobuf:"varint,2,opt,name=collect_sql_metrics,json=collectSqlMetrics,proto3" json:"collect_sql_metrics,omitempty"`
+	// defaults to 3600s (1 hour)
+	// collection frequency
+	CollectionFrequency *durationpb.Duration `protobuf:"bytes,3,opt,name=collection_frequency,json=collectionFrequency,proto3" json:"collection_frequency,omitempty"`
+	// defaults to 3600s (1 hour)
+	// dbcenter metrics collection frequency
+	DbcenterMetricsCollectionFrequency *durationpb.Duration `protobuf:"bytes,4,opt,name=dbcenter_metrics_collection_frequency,json=dbcenterMetricsCollectionFrequency,proto3" json:"dbcenter_metrics_collection_frequency,omitempty"`
+}
+
+func (x *SQLServerConfiguration_CollectionConfiguration) Reset() {
+	*x = SQLServerConfiguration_CollectionConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLServerConfiguration_CollectionConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLServerConfiguration_CollectionConfiguration) ProtoMessage() {}
+
+func (x *SQLServerConfiguration_CollectionConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLServerConfiguration_CollectionConfiguration.ProtoReflect.Descriptor instead.
+func (*SQLServerConfiguration_CollectionConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{43, 0}
+}
+
+func (x *SQLServerConfiguration_CollectionConfiguration) GetCollectGuestOsMetrics() bool {
+	if x != nil {
+		return x.CollectGuestOsMetrics
+	}
+	return false
+}
+
+func (x *SQLServerConfiguration_CollectionConfiguration) GetCollectSqlMetrics() bool {
+	if x != nil {
+		return x.CollectSqlMetrics
+	}
+	return false
+}
+
+func (x *SQLServerConfiguration_CollectionConfiguration) GetCollectionFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.CollectionFrequency
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration_CollectionConfiguration) GetDbcenterMetricsCollectionFrequency() *durationpb.Duration {
+	if x != nil {
+		return x.DbcenterMetricsCollectionFrequency
+	}
+	return nil
+}
+
+type SQLServerConfiguration_CredentialConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmProperties         *CloudProperties        `protobuf:"bytes,1,opt,name=vm_properties,json=vmProperties,proto3" json:"vm_properties,omitempty"`
+	ConnectionParameters []*ConnectionParameters `protobuf:"bytes,2,rep,name=connection_parameters,json=connectionParameters,proto3" json:"connection_parameters,omitempty"`
+	// Types that are assignable to GuestConfigurations:
+	//
+	//	*SQLServerConfiguration_CredentialConfiguration_LocalCollection
+	//	*SQLServerConfiguration_CredentialConfiguration_RemoteWin
+	//	*SQLServerConfiguration_CredentialConfiguration_RemoteLinux
+	GuestConfigurations isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations `protobuf_oneof:"guest_configurations"`
+}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) Reset() {
+	*x = SQLServerConfiguration_CredentialConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_configuration_configuration_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SQLServerConfiguration_CredentialConfiguration) ProtoMessage() {}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_configuration_configuration_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SQLServerConfiguration_CredentialConfiguration.ProtoReflect.Descriptor instead.
+func (*SQLServerConfiguration_CredentialConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{43, 1}
+}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) GetVmProperties() *CloudProperties {
+	if x != nil {
+		return x.VmProperties
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) GetConnectionParameters() []*ConnectionParameters {
+	if x != nil {
+		return x.ConnectionParameters
+	}
+	return nil
+}
+
+func (m *SQLServerConfiguration_CredentialConfiguration) GetGuestConfigurations() isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations {
+	if m != nil {
+		return m.GuestConfigurations
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) GetLocalCollection() bool {
+	if x, ok := x.GetGuestConfigurations().(*SQLServerConfiguration_CredentialConfiguration_LocalCollection); ok {
+		return x.LocalCollection
+	}
+	return false
+}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) GetRemoteWin() *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin {
+	if x, ok := x.GetGuestConfigurations().(*SQLServerConfiguration_CredentialConfiguration_RemoteWin); ok {
+		return x.RemoteWin
+	}
+	return nil
+}
+
+func (x *SQLServerConfiguration_CredentialConfiguration) GetRemoteLinux() *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux {
+	if x, ok := x.GetGuestConfigurations().(*SQLServerConfiguration_CredentialConfiguration_RemoteLinux); ok {
+		return x.RemoteLinux
+	}
+	return nil
+}
+
+type isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations interface {
+	isSQLServerConfiguration_CredentialConfiguration_GuestConfigurations()
+}
+
+type SQLServerConfiguration_CredentialConfiguration_LocalCollection struct {
+	LocalCollection bool `protobuf:"varint,3,opt,name=local_collection,json=localCollection,proto3,oneof"`
+}
+
+type SQLServerConfiguration_CredentialConfiguration_RemoteWin struct {
+	RemoteWin *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin `protobuf:"bytes,4,opt,name=remote_win,json=remoteWin,proto3,oneof"`
+}
 
 type SQLServerConfiguration_CredentialConfiguration_RemoteLinux struct {
 	RemoteLinux *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux `protobuf:"bytes,5,opt,name=remote_linux,json=remoteLinux,proto3,oneof"`
@@ -1761,7 +4702,7 @@ type SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin st
 func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin) Reset() {
 	*x = SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_configuration_configuration_proto_msgTypes[19]
+		mi := &file_protos_configuration_configuration_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1774,7 +4715,7 @@ func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWi
 func (*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin) ProtoMessage() {}
 
 func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_configuration_configuration_proto_msgTypes[19]
+	mi := &file_protos_configuration_configuration_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1787,7 +4728,7 @@ func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWi
 
 // Deprecated: Use SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin.ProtoReflect.Descriptor instead.
 func (*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin) Descriptor() ([]byte, []int) {
-	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{12, 1, 0}
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{43, 1, 0}
 }
 
 func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin) GetConnectionParameters() *ConnectionParameters {
@@ -1810,7 +4751,7 @@ type SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux
 func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux) Reset() {
 	*x = SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_configuration_configuration_proto_msgTypes[20]
+		mi := &file_protos_configuration_configuration_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1823,7 +4764,7 @@ func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLi
 func (*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux) ProtoMessage() {}
 
 func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_configuration_configuration_proto_msgTypes[20]
+	mi := &file_protos_configuration_configuration_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1836,7 +4777,7 @@ func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLi
 
 // Deprecated: Use SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux.ProtoReflect.Descriptor instead.
 func (*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux) Descriptor() ([]byte, []int) {
-	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{12, 1, 1}
+	return file_protos_configuration_configuration_proto_rawDescGZIP(), []int{43, 1, 1}
 }
 
 func (x *SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux) GetConnectionParameters() *ConnectionParameters {
@@ -1862,8 +4803,8 @@ var file_protos_configuration_configuration_proto_rawDesc = []byte{
 	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
 	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x1e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x86,
-	0x0b, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x92,
+	0x1e, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
 	0x12, 0x3a, 0x0a, 0x19, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x65, 0x6e, 0x64, 0x70,
 	0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x17, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x6e, 0x64, 0x70,
@@ -1945,364 +4886,945 @@ var file_protos_configuration_configuration_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
 	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x44, 0x42, 0x43, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x14, 0x6d, 0x6f, 0x6e, 0x67, 0x6f, 0x44,
-	0x62, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x46,
-	0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x4e,
-	0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x45, 0x42,
-	0x55, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x02, 0x12, 0x0b,
-	0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x45,
-	0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6c, 0x6f, 0x67, 0x5f, 0x74,
-	0x6f, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x22, 0x9a, 0x03, 0x0a, 0x0f, 0x43, 0x6c, 0x6f, 0x75,
-	0x64, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x7a,
-	0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12,
-	0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x75,
-	0x6d, 0x65, 0x72, 0x69, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x50,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69,
-	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e,
-	0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54,
-	0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x76, 0x63, 0x70, 0x75, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a,
-	0x65, 0x5f, 0x6d, 0x62, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x53, 0x69, 0x7a, 0x65, 0x4d, 0x62, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70,
-	0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73,
-	0x12, 0x32, 0x0a, 0x15, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x13, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45,
-	0x6d, 0x61, 0x69, 0x6c, 0x22, 0x6b, 0x0a, 0x0f, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f,
-	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x11, 0x6c, 0x6f, 0x67, 0x5f, 0x75, 0x73, 0x61,
-	0x67, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x0f, 0x6c, 0x6f, 0x67, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x22, 0xfa, 0x01, 0x0a, 0x13, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x5e, 0x0a, 0x10, 0x6f, 0x72, 0x61, 0x63,
-	0x6c, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x33, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65,
-	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x44, 0x69,
-	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x0f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x44,
-	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x58, 0x0a, 0x0e, 0x6f, 0x72, 0x61, 0x63,
-	0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x31, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x62, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x71,
+	0x0a, 0x17, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65,
+	0x5f, 0x62, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x39, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75,
+	0x73, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x52, 0x15, 0x64, 0x61, 0x74, 0x61,
+	0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e,
+	0x67, 0x12, 0x6b, 0x0a, 0x15, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f,
+	0x75, 0x73, 0x65, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x37, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x72,
-	0x69, 0x63, 0x73, 0x52, 0x0d, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x82,
-	0x01, 0x0a, 0x0f, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
-	0x72, 0x79, 0x12, 0x44, 0x0a, 0x10, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x22, 0xb0, 0x03, 0x0a, 0x0d, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x4d, 0x65,
-	0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x63,
-	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e,
-	0x63, 0x79, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x43, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x29, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f,
+	0x75, 0x73, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x13, 0x64, 0x61, 0x74, 0x61, 0x57,
+	0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x25,
+	0x0a, 0x0e, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x61, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x6a, 0x0a, 0x14, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x53, 0x0a, 0x24, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f,
+	0x75, 0x73, 0x65, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x01, 0x52, 0x20, 0x64, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65,
+	0x53, 0x74, 0x72, 0x69, 0x63, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79,
+	0x70, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x14, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x14,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x4f, 0x76, 0x65, 0x72,
+	0x72, 0x69, 0x64, 0x65, 0x50, 0x61, 0x74, 0x68, 0x12, 0x51, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x0b,
+	0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x45, 0x0a, 0x07, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x12, 0x4b, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18,
+	0x17, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12,
+	0x49, 0x0a, 0x09, 0x61, 0x75, 0x64, 0x69, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x18, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65,
 	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x07, 0x71,
-	0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
-	0x69, 0x6f, 0x6e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x8d, 0x02, 0x0a, 0x12, 0x4d, 0x79, 0x53, 0x51, 0x4c,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
-	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
-	0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x6d, 0x0a, 0x15,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f,
-	0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x5d, 0x0a, 0x1d, 0x64,
-	0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1b, 0x64,
-	0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65,
-	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xb2, 0x01, 0x0a, 0x16, 0x4f, 0x70, 0x65, 0x6e, 0x53,
-	0x68, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01,
-	0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
-	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50,
-	0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x42,
-	0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x0f,
-	0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12,
-	0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x4c,
-	0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x42, 0x0a, 0x0a, 0x08,
-	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xae, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x64,
-	0x69, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
-	0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x6d,
-	0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72,
-	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67,
+	0x52, 0x08, 0x61, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x6b, 0x0a, 0x15, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x18, 0x19, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x52, 0x13, 0x64, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73,
+	0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x71, 0x0a, 0x17, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x5f, 0x6d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x65, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6c,
+	0x6f, 0x75, 0x64, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x15, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f,
+	0x72, 0x69, 0x6e, 0x67, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x77, 0x0a, 0x19, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e,
 	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72,
-	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x42, 0x0a, 0x0a,
-	0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x90, 0x02, 0x0a, 0x15, 0x50, 0x6f,
-	0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88,
-	0x01, 0x01, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e,
+	0x6f, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x17, 0x64, 0x61, 0x74, 0x61,
+	0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x84, 0x01, 0x0a, 0x1e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x77, 0x61, 0x72,
+	0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x63, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x5f, 0x62,
+	0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3f, 0x2e, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x43,
+	0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x52, 0x1b, 0x64,
+	0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x43, 0x69, 0x72, 0x63,
+	0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x5e, 0x0a, 0x10, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x18, 0x1d,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x0f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x67, 0x0a, 0x13, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c,
+	0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x52,
+	0x12, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x64,
+	0x75, 0x6c, 0x65, 0x12, 0x80, 0x01, 0x0a, 0x1c, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x77, 0x61, 0x72,
+	0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x44, 0x65, 0x64,
+	0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1a, 0x64, 0x61, 0x74, 0x61,
+	0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x44, 0x65, 0x64, 0x75, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x6e, 0x0a, 0x16, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x77,
+	0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x18, 0x20, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x52, 0x14, 0x64, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x52,
+	0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x91, 0x01, 0x0a, 0x26, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x21, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x23, 0x64, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68,
+	0x6f, 0x75, 0x73, 0x65, 0x41, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x55, 0x0a, 0x0d, 0x70, 0x75,
+	0x62, 0x73, 0x75, 0x62, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x22, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x30, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e,
 	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e,
-	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x5d, 0x0a, 0x1d, 0x64, 0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e,
-	0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x1b, 0x64, 0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79,
-	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xfe, 0x01, 0x0a,
-	0x14, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x44, 0x42, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x88, 0x01, 0x01, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x75, 0x62, 0x53, 0x75, 0x62, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x52, 0x0c, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x5b, 0x0a, 0x0f, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x65, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x23, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x42, 0x69, 0x67, 0x51, 0x75, 0x65, 0x72, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x0e,
+	0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x4f,
+	0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x24, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
 	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
-	0x65, 0x72, 0x73, 0x12, 0x4c, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x79, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xaa, 0x0e,
-	0x0a, 0x16, 0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62,
-	0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61,
-	0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x52, 0x2e, 0x77, 0x6f, 0x72,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74,
+	0x4c, 0x6f, 0x67, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x4c, 0x6f, 0x67, 0x12,
+	0x55, 0x0a, 0x0d, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x25, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4c, 0x65, 0x67, 0x61,
+	0x63, 0x79, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x0c, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x55, 0x0a, 0x0d, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x5f, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x18, 0x26, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x52,
+	0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x12, 0x52, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x5f, 0x64, 0x72, 0x69, 0x66, 0x74, 0x18, 0x27, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x72, 0x69, 0x66, 0x74, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44, 0x72, 0x69, 0x66,
+	0x74, 0x22, 0x46, 0x0a, 0x08, 0x4c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x0d, 0x0a,
+	0x09, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x49, 0x4e, 0x45, 0x44, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05,
+	0x44, 0x45, 0x42, 0x55, 0x47, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10,
+	0x02, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x41, 0x52, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x09,
+	0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x04, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x6c, 0x6f,
+	0x67, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x42, 0x27, 0x0a, 0x25, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x77, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x5f, 0x73, 0x74,
+	0x72, 0x69, 0x63, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x22, 0x9a, 0x03, 0x0a, 0x0f, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69,
+	0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x50, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x67, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x76, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x76, 0x63, 0x70, 0x75, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24,
+	0x0a, 0x0e, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x6d, 0x62,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x53, 0x69,
+	0x7a, 0x65, 0x4d, 0x62, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x45, 0x6d, 0x61, 0x69, 0x6c,
+	0x22, 0xbe, 0x01, 0x0a, 0x0f, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x69, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x11, 0x6c, 0x6f, 0x67, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x0f, 0x6c, 0x6f, 0x67, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x88, 0x01, 0x01, 0x12, 0x36, 0x0a, 0x17, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x7a, 0x65,
+	0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x7a, 0x65, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x42, 0x14, 0x0a, 0x12, 0x5f,
+	0x6c, 0x6f, 0x67, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x22, 0x84, 0x01, 0x0a, 0x15, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f,
+	0x75, 0x73, 0x65, 0x42, 0x61, 0x74, 0x63, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x40, 0x0a, 0x0e, 0x66, 0x6c,
+	0x75, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x66,
+	0x6c, 0x75, 0x73, 0x68, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x87, 0x01, 0x0a, 0x1a, 0x44, 0x61, 0x74,
+	0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x44, 0x65, 0x64, 0x75, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x3e, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x74,
+	0x61, 0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x53, 0x74, 0x61,
+	0x6c, 0x65, 0x6e, 0x65, 0x73, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x22, 0x51, 0x0a, 0x14, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f,
+	0x75, 0x73, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x71, 0x0a, 0x18, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72,
+	0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xa1, 0x01, 0x0a, 0x17, 0x44, 0x61, 0x74,
+	0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x40, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x72, 0x65, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x6e, 0x0a, 0x12,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75,
+	0x6c, 0x65, 0x12, 0x2c, 0x0a, 0x0f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x5f,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0e, 0x72,
+	0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x88, 0x01, 0x01,
+	0x12, 0x16, 0x0a, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x72, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x44, 0x0a, 0x0f,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12,
+	0x31, 0x0a, 0x15, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x5f,
+	0x70, 0x65, 0x72, 0x5f, 0x68, 0x6f, 0x75, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x12,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x50, 0x65, 0x72, 0x48, 0x6f,
+	0x75, 0x72, 0x22, 0x82, 0x01, 0x0a, 0x1b, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68,
+	0x6f, 0x75, 0x73, 0x65, 0x43, 0x69, 0x72, 0x63, 0x75, 0x69, 0x74, 0x42, 0x72, 0x65, 0x61, 0x6b,
+	0x65, 0x72, 0x12, 0x2b, 0x0a, 0x11, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x5f, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12,
+	0x36, 0x0a, 0x09, 0x63, 0x6f, 0x6f, 0x6c, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x63,
+	0x6f, 0x6f, 0x6c, 0x44, 0x6f, 0x77, 0x6e, 0x22, 0xbb, 0x01, 0x0a, 0x13, 0x52, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x17, 0x0a, 0x07, 0x67, 0x63, 0x73, 0x5f, 0x75, 0x72, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x67, 0x63, 0x73, 0x55, 0x72, 0x69, 0x12, 0x45, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
+	0x44, 0x0a, 0x10, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xb4, 0x01, 0x0a, 0x07, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x74, 0x74, 0x70, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x73, 0x50, 0x72, 0x6f,
+	0x78, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x6f, 0x5f, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x6f, 0x50, 0x72, 0x6f, 0x78, 0x79, 0x12, 0x24, 0x0a,
+	0x0e, 0x63, 0x61, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x47, 0x0a, 0x12, 0x64, 0x6e, 0x73, 0x5f, 0x6c, 0x6f, 0x6f, 0x6b, 0x75,
+	0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x64, 0x6e, 0x73, 0x4c,
+	0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xf5, 0x01, 0x0a,
+	0x09, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x5f, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4d, 0x61, 0x6e, 0x61, 0x67, 0x65,
+	0x72, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x6d, 0x6f, 0x6e, 0x69, 0x74,
+	0x6f, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6c, 0x6f,
+	0x75, 0x64, 0x4d, 0x6f, 0x6e, 0x69, 0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d,
+	0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x6c, 0x6f, 0x67, 0x67, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x4c, 0x6f, 0x67, 0x67, 0x69, 0x6e,
+	0x67, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x75, 0x62, 0x73, 0x75, 0x62, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62,
+	0x73, 0x75, 0x62, 0x12, 0x1a, 0x0a, 0x08, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x69, 0x67, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x23, 0x0a, 0x0d, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x53, 0x74, 0x6f,
+	0x72, 0x61, 0x67, 0x65, 0x22, 0x52, 0x0a, 0x08, 0x41, 0x75, 0x64, 0x69, 0x74, 0x4c, 0x6f, 0x67,
+	0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xfb, 0x01, 0x0a, 0x0b, 0x43, 0x72, 0x65,
+	0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x46,
+	0x69, 0x6c, 0x65, 0x12, 0x3e, 0x0a, 0x1b, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61,
+	0x74, 0x65, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x69, 0x6d, 0x70, 0x65, 0x72, 0x73,
+	0x6f, 0x6e, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x63, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x80, 0x01, 0x0a, 0x1c, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x5f, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x64, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3e, 0x2e, 0x77, 0x6f, 0x72,
 	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
 	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x17,
-	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x8f, 0x01, 0x0a, 0x19, 0x63, 0x72, 0x65, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x52, 0x2e, 0x77, 0x6f,
-	0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x18, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x48, 0x0a, 0x12, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65,
-	0x6f, 0x75, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69,
-	0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74,
-	0x72, 0x69, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x66, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79,
+	0x46, 0x65, 0x64, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1a, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x46, 0x65, 0x64, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x1a, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x46, 0x65, 0x64, 0x65, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x64, 0x69, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x2c, 0x0a, 0x12, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x75,
+	0x62, 0x6a, 0x65, 0x63, 0x74, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x52,
+	0x0a, 0x0a, 0x49, 0x6e, 0x73, 0x69, 0x67, 0x68, 0x74, 0x4c, 0x6f, 0x67, 0x12, 0x1d, 0x0a, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x19, 0x0a, 0x08, 0x6c,
+	0x6f, 0x67, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c,
+	0x6f, 0x67, 0x4e, 0x61, 0x6d, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x22, 0xbb, 0x01, 0x0a, 0x0c, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x41, 0x67, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x5b, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4c, 0x65, 0x67,
+	0x61, 0x63, 0x79, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x63, 0x65, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x52, 0x0a, 0x70, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x22, 0x4e, 0x0a, 0x0a, 0x50, 0x72, 0x65, 0x63, 0x65, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a,
+	0x0a, 0x16, 0x50, 0x52, 0x45, 0x43, 0x45, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4c, 0x45,
+	0x47, 0x41, 0x43, 0x59, 0x5f, 0x41, 0x47, 0x45, 0x4e, 0x54, 0x10, 0x01, 0x12, 0x12, 0x0a, 0x0e,
+	0x57, 0x4f, 0x52, 0x4b, 0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x41, 0x47, 0x45, 0x4e, 0x54, 0x10, 0x02,
+	0x22, 0x72, 0x0a, 0x13, 0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73,
+	0x65, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x0d, 0x65, 0x78, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x36, 0x0a, 0x17,
+	0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x5f, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x15, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x44,
+	0x72, 0x69, 0x66, 0x74, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x88, 0x01, 0x01, 0x12, 0x50, 0x0a, 0x09, 0x62, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x42, 0x61, 0x73, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x09, 0x62, 0x61, 0x73, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x22, 0x49, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x61, 0x73, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x5d, 0x0a, 0x13,
+	0x44, 0x61, 0x74, 0x61, 0x57, 0x61, 0x72, 0x65, 0x68, 0x6f, 0x75, 0x73, 0x65, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x68, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xa5, 0x01, 0x0a, 0x0c,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x35, 0x0a, 0x08, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x46,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f,
-	0x74, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x10, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0xbe, 0x02, 0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x37, 0x0a, 0x18, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x67, 0x75, 0x65,
-	0x73, 0x74, 0x5f, 0x6f, 0x73, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x15, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x47, 0x75, 0x65, 0x73,
-	0x74, 0x4f, 0x73, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x73, 0x71, 0x6c, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
-	0x53, 0x71, 0x6c, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x4c, 0x0a, 0x14, 0x63, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e,
-	0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x6c, 0x0a, 0x25, 0x64, 0x62, 0x63, 0x65,
-	0x6e, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x22, 0x64, 0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72,
-	0x69, 0x63, 0x73, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x1a, 0xa5, 0x07, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x64, 0x65,
-	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x58, 0x0a, 0x0d, 0x76, 0x6d, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74,
-	0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x12, 0x33, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07,
+	0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x22, 0x42, 0x0a, 0x15, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x4d, 0x6f, 0x6e, 0x69,
+	0x74, 0x6f, 0x72, 0x69, 0x6e, 0x67, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x4f, 0x0a, 0x0c, 0x50, 0x75, 0x62, 0x53, 0x75,
+	0x62, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x0e, 0x42, 0x69, 0x67,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61,
+	0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xfa, 0x01, 0x0a, 0x13, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00,
+	0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x5e, 0x0a, 0x10,
+	0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x72, 0x61, 0x63,
+	0x6c, 0x65, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x0f, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x58, 0x0a, 0x0e,
+	0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x0d, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x4d,
+	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x22, 0x82, 0x01, 0x0a, 0x0f, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x44, 0x0a, 0x10, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1d, 0x0a, 0x07,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52,
+	0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x99, 0x04, 0x0a, 0x0d, 0x4f, 0x72, 0x61, 0x63,
+	0x6c, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x43, 0x0a, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x52, 0x07, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x6d, 0x61,
+	0x78, 0x5f, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x68, 0x72, 0x65,
+	0x61, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x6d, 0x61, 0x78, 0x45, 0x78,
+	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x3e,
+	0x0a, 0x0d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x67,
+	0x0a, 0x11, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
 	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43,
-	0x6c, 0x6f, 0x75, 0x64, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c,
-	0x76, 0x6d, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x6d, 0x0a, 0x15,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f,
-	0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x10, 0x6c,
-	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x8d, 0x01, 0x0a, 0x0a, 0x72, 0x65, 0x6d,
-	0x6f, 0x74, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x6c, 0x2e,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f,
+	0x72, 0x61, 0x63, 0x6c, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x52, 0x10, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x53,
+	0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x22, 0xbc, 0x02, 0x0a, 0x16, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x12, 0x1d,
+	0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48,
+	0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x3a, 0x0a,
+	0x19, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x5f, 0x70, 0x61, 0x63,
+	0x6b, 0x5f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x17, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x50, 0x61, 0x63,
+	0x6b, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x64, 0x12, 0x59, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x41, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f,
+	0x72, 0x61, 0x63, 0x6c, 0x65, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x22, 0x4b, 0x0a, 0x06, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1a, 0x0a, 0x16, 0x41,
+	0x43, 0x54, 0x49, 0x56, 0x45, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x48, 0x49,
+	0x53, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x54, 0x41, 0x54, 0x53,
+	0x50, 0x41, 0x43, 0x4b, 0x10, 0x02, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x22, 0xeb, 0x05, 0x0a, 0x12, 0x4d, 0x79, 0x53, 0x51, 0x4c, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x5d, 0x0a, 0x1d, 0x64, 0x62, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1b, 0x64, 0x62, 0x63, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x5a, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x72, 0x79, 0x5f,
+	0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e,
 	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e,
-	0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
-	0x6c, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x72,
-	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x6e, 0x12, 0x93, 0x01, 0x0a, 0x0c, 0x72, 0x65, 0x6d,
-	0x6f, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6e, 0x75, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x6e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x6f, 0x6e, 0x2e, 0x4d, 0x79, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x69, 0x67,
+	0x65, 0x73, 0x74, 0x73, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x72, 0x79, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x5f, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11,
+	0x62, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x56, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x09,
+	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x03, 0x74, 0x6c, 0x73,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x4c, 0x53, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x6c,
+	0x73, 0x12, 0x54, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x73, 0x71, 0x6c, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x08, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x73, 0x71, 0x6c, 0x12, 0x5b, 0x0a, 0x0e, 0x73, 0x6c, 0x6f, 0x77, 0x5f,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x5f, 0x6c, 0x6f, 0x67, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x35, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x64,
-	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74,
-	0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x75, 0x78, 0x48,
-	0x00, 0x52, 0x0b, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x75, 0x78, 0x1a, 0x8a,
-	0x01, 0x0a, 0x19, 0x47, 0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x61, 0x6c, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x6e, 0x12, 0x6d, 0x0a, 0x15,
-	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d,
-	0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x79, 0x53, 0x51, 0x4c, 0x53, 0x6c, 0x6f, 0x77, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x52, 0x0c, 0x73, 0x6c, 0x6f, 0x77, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x4c, 0x6f, 0x67, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x22, 0x53, 0x0a, 0x11, 0x4d, 0x79, 0x53, 0x51, 0x4c, 0x51, 0x75, 0x65, 0x72, 0x79, 0x44, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x67, 0x0a, 0x11, 0x4d, 0x79, 0x53, 0x51, 0x4c, 0x53, 0x6c,
+	0x6f, 0x77, 0x51, 0x75, 0x65, 0x72, 0x79, 0x4c, 0x6f, 0x67, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x70,
+	0x5f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x12, 0x12,
+	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xb2,
+	0x01, 0x0a, 0x16, 0x4f, 0x70, 0x65, 0x6e, 0x53, 0x68, 0x69, 0x66, 0x74, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x22, 0xe1, 0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x66, 0x0a, 0x13, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x35, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x12, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x6d, 0x0a, 0x15,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f,
 	0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d,
-	0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x1a, 0xc8, 0x01, 0x0a, 0x1b,
-	0x47, 0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73,
-	0x52, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x75, 0x78, 0x12, 0x6d, 0x0a, 0x15, 0x63,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65,
-	0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72,
-	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65,
-	0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3a, 0x0a, 0x1a, 0x6c, 0x69,
-	0x6e, 0x75, 0x78, 0x5f, 0x73, 0x73, 0x68, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f,
-	0x6b, 0x65, 0x79, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16,
-	0x6c, 0x69, 0x6e, 0x75, 0x78, 0x53, 0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b,
-	0x65, 0x79, 0x50, 0x61, 0x74, 0x68, 0x42, 0x16, 0x0a, 0x14, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x0a,
-	0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xe0, 0x01, 0x0a, 0x14, 0x43,
-	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
-	0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x45, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x2d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x52, 0x06,
-	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x21,
-	0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x4b, 0x0a,
-	0x09, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0xc3, 0x02, 0x0a, 0x05, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x71, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x71, 0x6c, 0x12, 0x44, 0x0a, 0x07, 0x63, 0x6f,
-	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x77, 0x6f,
+	0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
+	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x0a, 0x0a, 0x08, 0x5f,
+	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x62, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6b, 0x75, 0x62, 0x65, 0x6c, 0x65, 0x74, 0x55, 0x72, 0x6c, 0x42,
+	0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xd5, 0x01, 0x0a, 0x11,
+	0x57, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x32, 0x0a,
+	0x15, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x70, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e,
+	0x73, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6d, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x70, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x63, 0x6d, 0x64,
+	0x6c, 0x69, 0x6e, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x13,
+	0x65, 0x6e, 0x76, 0x69, 0x72, 0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x12, 0x65, 0x6e, 0x76, 0x69, 0x72,
+	0x6f, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x05, 0x52, 0x05, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x22, 0xf6, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x64, 0x69, 0x73, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f,
+	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x46, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x6c, 0x73,
+	0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xcd, 0x02, 0x0a,
+	0x10, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x63,
+	0x61, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x61,
+	0x46, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x65, 0x72, 0x74, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x14,
+	0x69, 0x6e, 0x73, 0x65, 0x63, 0x75, 0x72, 0x65, 0x5f, 0x73, 0x6b, 0x69, 0x70, 0x5f, 0x76, 0x65,
+	0x72, 0x69, 0x66, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x12, 0x69, 0x6e, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x65, 0x53, 0x6b, 0x69, 0x70, 0x56, 0x65, 0x72, 0x69, 0x66, 0x79, 0x12, 0x4e,
+	0x0a, 0x0b, 0x63, 0x65, 0x72, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52,
+	0x65, 0x66, 0x52, 0x0a, 0x63, 0x65, 0x72, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x4c,
+	0x0a, 0x0a, 0x6b, 0x65, 0x79, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65,
+	0x66, 0x52, 0x09, 0x6b, 0x65, 0x79, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x22, 0x81, 0x05, 0x0a,
+	0x15, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14,
+	0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x5d, 0x0a, 0x1d, 0x64, 0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72,
+	0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x1b, 0x64, 0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72,
+	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x56, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x03, 0x74,
+	0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x4c,
+	0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03,
+	0x74, 0x6c, 0x73, 0x12, 0x56, 0x0a, 0x09, 0x70, 0x67, 0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x52, 0x09, 0x70, 0x67, 0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
+	0x61, 0x74, 0x72, 0x6f, 0x6e, 0x69, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x70, 0x61, 0x74, 0x72, 0x6f, 0x6e, 0x69, 0x55, 0x72, 0x6c, 0x12, 0x56, 0x0a, 0x0a,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x36, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x22, 0x57, 0x0a, 0x12, 0x50, 0x6f, 0x73, 0x74, 0x67, 0x72, 0x65, 0x73, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x5f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x42, 0x0b, 0x0a, 0x09,
+	0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x9c, 0x04, 0x0a, 0x14, 0x4d, 0x6f,
+	0x6e, 0x67, 0x6f, 0x44, 0x42, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01,
+	0x01, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x4c, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x46,
+	0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x77, 0x6f,
 	0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73,
-	0x12, 0x5b, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72, 0x6f, 0x6c,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
-	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x52,
-	0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x1f, 0x0a,
-	0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x48,
-	0x00, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01, 0x22, 0x43,
-	0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x0f,
-	0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
-	0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x4d, 0x41, 0x52, 0x59, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07,
-	0x53, 0x54, 0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x54,
-	0x48, 0x10, 0x03, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64,
-	0x22, 0xe0, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x4f, 0x0a, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x12, 0x6d, 0x0a, 0x0e, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x6d,
+	0x65, 0x63, 0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x46,
+	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x6f, 0x6e, 0x67, 0x6f, 0x44, 0x42, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x65, 0x63,
+	0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x52, 0x0d, 0x61, 0x75, 0x74, 0x68, 0x4d, 0x65, 0x63, 0x68,
+	0x61, 0x6e, 0x69, 0x73, 0x6d, 0x22, 0x65, 0x0a, 0x0d, 0x41, 0x75, 0x74, 0x68, 0x4d, 0x65, 0x63,
+	0x68, 0x61, 0x6e, 0x69, 0x73, 0x6d, 0x12, 0x1e, 0x0a, 0x1a, 0x41, 0x55, 0x54, 0x48, 0x5f, 0x4d,
+	0x45, 0x43, 0x48, 0x41, 0x4e, 0x49, 0x53, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x43, 0x52, 0x41, 0x4d, 0x5f,
+	0x53, 0x48, 0x41, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x43, 0x52,
+	0x41, 0x4d, 0x5f, 0x53, 0x48, 0x41, 0x5f, 0x31, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x4f,
+	0x4e, 0x47, 0x4f, 0x44, 0x42, 0x5f, 0x58, 0x35, 0x30, 0x39, 0x10, 0x03, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xaa, 0x0e, 0x0a, 0x16, 0x53, 0x51, 0x4c,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x8d, 0x01, 0x0a, 0x18, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x52, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x51, 0x4c, 0x53, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x17, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x8f, 0x01, 0x0a, 0x19, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x52, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x51, 0x4c, 0x53,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x18, 0x63, 0x72, 0x65, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x48, 0x0a, 0x12, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x11, 0x63, 0x6f, 0x6c,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x42, 0x0a, 0x0f, 0x72, 0x65, 0x74, 0x72, 0x79, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x72, 0x65, 0x74, 0x72, 0x79, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x1a, 0xbe, 0x02, 0x0a, 0x17, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x18,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6f, 0x73,
+	0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x4f, 0x73, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x5f, 0x73, 0x71, 0x6c, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x11, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x53, 0x71, 0x6c, 0x4d, 0x65,
+	0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x4c, 0x0a, 0x14, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x13,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x79, 0x12, 0x6c, 0x0a, 0x25, 0x64, 0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x66, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x22, 0x64,
+	0x62, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x72, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
+	0x79, 0x1a, 0xa5, 0x07, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x58, 0x0a,
+	0x0d, 0x76, 0x6d, 0x5f, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
 	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x4c, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x52, 0x0c, 0x76, 0x6d, 0x50, 0x72, 0x6f,
+	0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x10, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x48, 0x00, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x8d, 0x01, 0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x77,
+	0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x6c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x51,
+	0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x47, 0x75, 0x65,
+	0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x6d,
+	0x6f, 0x74, 0x65, 0x57, 0x69, 0x6e, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65,
+	0x57, 0x69, 0x6e, 0x12, 0x93, 0x01, 0x0a, 0x0c, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f, 0x6c,
+	0x69, 0x6e, 0x75, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x6e, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x53, 0x51, 0x4c, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61,
+	0x6c, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52,
+	0x65, 0x6d, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x75, 0x78, 0x48, 0x00, 0x52, 0x0b, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x4c, 0x69, 0x6e, 0x75, 0x78, 0x1a, 0x8a, 0x01, 0x0a, 0x19, 0x47, 0x75,
+	0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x57, 0x69, 0x6e, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x52, 0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x1a, 0xc8, 0x01, 0x0a, 0x1b, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x6d, 0x6f, 0x74,
+	0x65, 0x4c, 0x69, 0x6e, 0x75, 0x78, 0x12, 0x6d, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x14, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3a, 0x0a, 0x1a, 0x6c, 0x69, 0x6e, 0x75, 0x78, 0x5f, 0x73,
+	0x73, 0x68, 0x5f, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x6c, 0x69, 0x6e, 0x75, 0x78,
+	0x53, 0x73, 0x68, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x50, 0x61, 0x74,
+	0x68, 0x42, 0x16, 0x0a, 0x14, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x65, 0x6e,
+	0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0x9d, 0x03, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x55, 0x0a, 0x0c, 0x76, 0x61, 0x75, 0x6c,
+	0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32,
+	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52,
+	0x65, 0x66, 0x52, 0x0b, 0x76, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12,
+	0x64, 0x0a, 0x11, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x5f, 0x63, 0x72, 0x65, 0x64, 0x65, 0x6e,
+	0x74, 0x69, 0x61, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x37, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x43, 0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x52, 0x65, 0x66, 0x52, 0x10, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x43, 0x72, 0x65, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x61, 0x6c, 0x22, 0x63, 0x0a, 0x13, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x43,
+	0x72, 0x65, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x6b, 0x65, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x22, 0xc5, 0x01, 0x0a, 0x09, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72,
+	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x34, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x66, 0x2e, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x2a, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x16, 0x0a, 0x12, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e,
+	0x10, 0x01, 0x22, 0xb2, 0x02, 0x0a, 0x0e, 0x56, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x52, 0x65, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d,
+	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x09, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x4d, 0x0a, 0x08, 0x61, 0x70, 0x70, 0x5f,
+	0x72, 0x6f, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x77, 0x6f, 0x72,
+	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x56, 0x61, 0x75, 0x6c, 0x74, 0x41, 0x70, 0x70, 0x52, 0x6f, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07,
+	0x61, 0x70, 0x70, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x46, 0x0a, 0x03, 0x74, 0x6c, 0x73, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
 	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54,
-	0x79, 0x70, 0x65, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23,
-	0x0a, 0x0d, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x4f, 0x76, 0x65, 0x72, 0x72,
-	0x69, 0x64, 0x65, 0x2a, 0x5f, 0x0a, 0x0a, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x55, 0x4e, 0x53, 0x50,
-	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x45, 0x54,
-	0x52, 0x49, 0x43, 0x5f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x4d,
-	0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x47, 0x41, 0x55, 0x47, 0x45, 0x10, 0x02, 0x12, 0x15, 0x0a,
-	0x11, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x43, 0x55, 0x4d, 0x55, 0x4c, 0x41, 0x54, 0x49,
-	0x56, 0x45, 0x10, 0x03, 0x2a, 0x67, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
-	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x56, 0x41, 0x4c, 0x55,
-	0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x56, 0x41, 0x4c, 0x55,
-	0x45, 0x5f, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x56, 0x41, 0x4c,
-	0x55, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x56,
-	0x41, 0x4c, 0x55, 0x45, 0x5f, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x04, 0x42, 0x43, 0x5a,
-	0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f,
-	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x4c, 0x53, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x74, 0x6c, 0x73, 0x42,
+	0x06, 0x0a, 0x04, 0x61, 0x75, 0x74, 0x68, 0x22, 0x63, 0x0a, 0x0c, 0x56, 0x61, 0x75, 0x6c, 0x74,
+	0x41, 0x70, 0x70, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x17, 0x0a,
+	0x07, 0x72, 0x6f, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x6f, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x5f, 0x69, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x49, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x22, 0xc3, 0x02, 0x0a,
+	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x71,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x71, 0x6c, 0x12, 0x44, 0x0a, 0x07,
+	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x73, 0x12, 0x5b, 0x0a, 0x0d, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x72,
+	0x6f, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x36, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x51,
+	0x75, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6c,
+	0x65, 0x52, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12,
+	0x1f, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x48, 0x00, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x88, 0x01, 0x01,
+	0x22, 0x43, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6c, 0x65,
+	0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x52, 0x49, 0x4d, 0x41, 0x52, 0x59, 0x10, 0x01, 0x12, 0x0b,
+	0x0a, 0x07, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x42,
+	0x4f, 0x54, 0x48, 0x10, 0x03, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x22, 0xe0, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x4f, 0x0a, 0x0b, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0a, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x4c, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x4f, 0x76, 0x65,
+	0x72, 0x72, 0x69, 0x64, 0x65, 0x2a, 0x5f, 0x0a, 0x0a, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x4d,
+	0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x10, 0x01, 0x12, 0x10, 0x0a,
+	0x0c, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x47, 0x41, 0x55, 0x47, 0x45, 0x10, 0x02, 0x12,
+	0x15, 0x0a, 0x11, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x5f, 0x43, 0x55, 0x4d, 0x55, 0x4c, 0x41,
+	0x54, 0x49, 0x56, 0x45, 0x10, 0x03, 0x2a, 0x67, 0x0a, 0x09, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x15, 0x0a, 0x11, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x5f, 0x55, 0x4e, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x56, 0x41,
+	0x4c, 0x55, 0x45, 0x5f, 0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x56, 0x41,
+	0x4c, 0x55, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x56,
+	0x41, 0x4c, 0x55, 0x45, 0x5f, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x10, 0x0a,
+	0x0c, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x5f, 0x44, 0x4f, 0x55, 0x42, 0x4c, 0x45, 0x10, 0x04, 0x42,
+	0x43, 0x5a, 0x41, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72,
+	0x6d, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2317,86 +5839,182 @@ func file_protos_configuration_configuration_proto_rawDescGZIP() []byte {
 	return file_protos_configuration_configuration_proto_rawDescData
 }
 
-var file_protos_configuration_configuration_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_protos_configuration_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_protos_configuration_configuration_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_protos_configuration_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 55)
 var file_protos_configuration_configuration_proto_goTypes = []interface{}{
 	(MetricType)(0),                                        // 0: workloadagent.protos.configuration.MetricType
 	(ValueType)(0),                                         // 1: workloadagent.protos.configuration.ValueType
 	(Configuration_LogLevel)(0),                            // 2: workloadagent.protos.configuration.Configuration.LogLevel
-	(Query_DatabaseRole)(0),                                // 3: workloadagent.protos.configuration.Query.DatabaseRole
-	(*Configuration)(nil),                                  // 4: workloadagent.protos.configuration.Configuration
-	(*CloudProperties)(nil),                                // 5: workloadagent.protos.configuration.CloudProperties
-	(*AgentProperties)(nil),                                // 6: workloadagent.protos.configuration.AgentProperties
-	(*OracleConfiguration)(nil),                            // 7: workloadagent.protos.configuration.OracleConfiguration
-	(*OracleDiscovery)(nil),                                // 8: workloadagent.protos.configuration.OracleDiscovery
-	(*OracleMetrics)(nil),                                  // 9: workloadagent.protos.configuration.OracleMetrics
-	(*MySQLConfiguration)(nil),                             // 10: workloadagent.protos.configuration.MySQLConfiguration
-	(*OpenShiftConfiguration)(nil),                         // 11: workloadagent.protos.configuration.OpenShiftConfiguration
-	(*CommonDiscovery)(nil),                                // 12: workloadagent.protos.configuration.CommonDiscovery
-	(*RedisConfiguration)(nil),                             // 13: workloadagent.protos.configuration.RedisConfiguration
-	(*PostgresConfiguration)(nil),                          // 14: workloadagent.protos.configuration.PostgresConfiguration
-	(*MongoDBConfiguration)(nil),                           // 15: workloadagent.protos.configuration.MongoDBConfiguration
-	(*SQLServerConfiguration)(nil),                         // 16: workloadagent.protos.configuration.SQLServerConfiguration
-	(*ConnectionParameters)(nil),                           // 17: workloadagent.protos.configuration.ConnectionParameters
-	(*SecretRef)(nil),                                      // 18: workloadagent.protos.configuration.SecretRef
-	(*Query)(nil),                                          // 19: workloadagent.protos.configuration.Query
-	(*Column)(nil),                                         // 20: workloadagent.protos.configuration.Column
-	(*SQLServerConfiguration_CollectionConfiguration)(nil), // 21: workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration
-	(*SQLServerConfiguration_CredentialConfiguration)(nil), // 22: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration
-	(*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin)(nil),   // 23: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteWin
-	(*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux)(nil), // 24: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteLinux
-	(*durationpb.Duration)(nil), // 25: google.protobuf.Duration
+	(LegacyAgents_Precedence)(0),                           // 3: workloadagent.protos.configuration.LegacyAgents.Precedence
+	(OracleActivitySampling_Source)(0),                     // 4: workloadagent.protos.configuration.OracleActivitySampling.Source
+	(MongoDBConfiguration_AuthMechanism)(0),                // 5: workloadagent.protos.configuration.MongoDBConfiguration.AuthMechanism
+	(SecretRef_Format)(0),                                  // 6: workloadagent.protos.configuration.SecretRef.Format
+	(Query_DatabaseRole)(0),                                // 7: workloadagent.protos.configuration.Query.DatabaseRole
+	(*Configuration)(nil),                                  // 8: workloadagent.protos.configuration.Configuration
+	(*CloudProperties)(nil),                                // 9: workloadagent.protos.configuration.CloudProperties
+	(*AgentProperties)(nil),                                // 10: workloadagent.protos.configuration.AgentProperties
+	(*DataWarehouseBatching)(nil),                          // 11: workloadagent.protos.configuration.DataWarehouseBatching
+	(*DataWarehouseDeduplication)(nil),                     // 12: workloadagent.protos.configuration.DataWarehouseDeduplication
+	(*DataWarehouseRouting)(nil),                           // 13: workloadagent.protos.configuration.DataWarehouseRouting
+	(*DataWarehouseDestination)(nil),                       // 14: workloadagent.protos.configuration.DataWarehouseDestination
+	(*DataWarehouseActivation)(nil),                        // 15: workloadagent.protos.configuration.DataWarehouseActivation
+	(*CollectionSchedule)(nil),                             // 16: workloadagent.protos.configuration.CollectionSchedule
+	(*ServiceRecovery)(nil),                                // 17: workloadagent.protos.configuration.ServiceRecovery
+	(*DataWarehouseCircuitBreaker)(nil),                    // 18: workloadagent.protos.configuration.DataWarehouseCircuitBreaker
+	(*RemoteConfiguration)(nil),                            // 19: workloadagent.protos.configuration.RemoteConfiguration
+	(*Network)(nil),                                        // 20: workloadagent.protos.configuration.Network
+	(*Endpoints)(nil),                                      // 21: workloadagent.protos.configuration.Endpoints
+	(*AuditLog)(nil),                                       // 22: workloadagent.protos.configuration.AuditLog
+	(*Credentials)(nil),                                    // 23: workloadagent.protos.configuration.Credentials
+	(*WorkloadIdentityFederation)(nil),                     // 24: workloadagent.protos.configuration.WorkloadIdentityFederation
+	(*InsightLog)(nil),                                     // 25: workloadagent.protos.configuration.InsightLog
+	(*LegacyAgents)(nil),                                   // 26: workloadagent.protos.configuration.LegacyAgents
+	(*DataWarehouseFilter)(nil),                            // 27: workloadagent.protos.configuration.DataWarehouseFilter
+	(*ConfigDrift)(nil),                                    // 28: workloadagent.protos.configuration.ConfigDrift
+	(*ConfigBaseline)(nil),                                 // 29: workloadagent.protos.configuration.ConfigBaseline
+	(*DataWarehouseExport)(nil),                            // 30: workloadagent.protos.configuration.DataWarehouseExport
+	(*HealthProbes)(nil),                                   // 31: workloadagent.protos.configuration.HealthProbes
+	(*CloudMonitoringExport)(nil),                          // 32: workloadagent.protos.configuration.CloudMonitoringExport
+	(*PubSubExport)(nil),                                   // 33: workloadagent.protos.configuration.PubSubExport
+	(*BigQueryExport)(nil),                                 // 34: workloadagent.protos.configuration.BigQueryExport
+	(*OracleConfiguration)(nil),                            // 35: workloadagent.protos.configuration.OracleConfiguration
+	(*OracleDiscovery)(nil),                                // 36: workloadagent.protos.configuration.OracleDiscovery
+	(*OracleMetrics)(nil),                                  // 37: workloadagent.protos.configuration.OracleMetrics
+	(*OracleActivitySampling)(nil),                         // 38: workloadagent.protos.configuration.OracleActivitySampling
+	(*MySQLConfiguration)(nil),                             // 39: workloadagent.protos.configuration.MySQLConfiguration
+	(*MySQLQueryDigests)(nil),                              // 40: workloadagent.protos.configuration.MySQLQueryDigests
+	(*MySQLSlowQueryLog)(nil),                              // 41: workloadagent.protos.configuration.MySQLSlowQueryLog
+	(*OpenShiftConfiguration)(nil),                         // 42: workloadagent.protos.configuration.OpenShiftConfiguration
+	(*CommonDiscovery)(nil),                                // 43: workloadagent.protos.configuration.CommonDiscovery
+	(*ContainerAttribution)(nil),                           // 44: workloadagent.protos.configuration.ContainerAttribution
+	(*WorkloadSignature)(nil),                              // 45: workloadagent.protos.configuration.WorkloadSignature
+	(*RedisConfiguration)(nil),                             // 46: workloadagent.protos.configuration.RedisConfiguration
+	(*TLSConfiguration)(nil),                               // 47: workloadagent.protos.configuration.TLSConfiguration
+	(*PostgresConfiguration)(nil),                          // 48: workloadagent.protos.configuration.PostgresConfiguration
+	(*PostgresStatements)(nil),                             // 49: workloadagent.protos.configuration.PostgresStatements
+	(*MongoDBConfiguration)(nil),                           // 50: workloadagent.protos.configuration.MongoDBConfiguration
+	(*SQLServerConfiguration)(nil),                         // 51: workloadagent.protos.configuration.SQLServerConfiguration
+	(*ConnectionParameters)(nil),                           // 52: workloadagent.protos.configuration.ConnectionParameters
+	(*StoredCredentialRef)(nil),                            // 53: workloadagent.protos.configuration.StoredCredentialRef
+	(*SecretRef)(nil),                                      // 54: workloadagent.protos.configuration.SecretRef
+	(*VaultSecretRef)(nil),                                 // 55: workloadagent.protos.configuration.VaultSecretRef
+	(*VaultAppRole)(nil),                                   // 56: workloadagent.protos.configuration.VaultAppRole
+	(*Query)(nil),                                          // 57: workloadagent.protos.configuration.Query
+	(*Column)(nil),                                         // 58: workloadagent.protos.configuration.Column
+	(*SQLServerConfiguration_CollectionConfiguration)(nil), // 59: workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration
+	(*SQLServerConfiguration_CredentialConfiguration)(nil), // 60: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration
+	(*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin)(nil),   // 61: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteWin
+	(*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux)(nil), // 62: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteLinux
+	(*durationpb.Duration)(nil), // 63: google.protobuf.Duration
 }
 var file_protos_configuration_configuration_proto_depIdxs = []int32{
-	2,  // 0: workloadagent.protos.configuration.Configuration.log_level:type_name -> workloadagent.protos.configuration.Configuration.LogLevel
-	5,  // 1: workloadagent.protos.configuration.Configuration.cloud_properties:type_name -> workloadagent.protos.configuration.CloudProperties
-	6,  // 2: workloadagent.protos.configuration.Configuration.agent_properties:type_name -> workloadagent.protos.configuration.AgentProperties
-	7,  // 3: workloadagent.protos.configuration.Configuration.oracle_configuration:type_name -> workloadagent.protos.configuration.OracleConfiguration
-	10, // 4: workloadagent.protos.configuration.Configuration.mysql_configuration:type_name -> workloadagent.protos.configuration.MySQLConfiguration
-	12, // 5: workloadagent.protos.configuration.Configuration.common_discovery:type_name -> workloadagent.protos.configuration.CommonDiscovery
-	13, // 6: workloadagent.protos.configuration.Configuration.redis_configuration:type_name -> workloadagent.protos.configuration.RedisConfiguration
-	16, // 7: workloadagent.protos.configuration.Configuration.sqlserver_configuration:type_name -> workloadagent.protos.configuration.SQLServerConfiguration
-	14, // 8: workloadagent.protos.configuration.Configuration.postgres_configuration:type_name -> workloadagent.protos.configuration.PostgresConfiguration
-	11, // 9: workloadagent.protos.configuration.Configuration.openshift_configuration:type_name -> workloadagent.protos.configuration.OpenShiftConfiguration
-	15, // 10: workloadagent.protos.configuration.Configuration.mongo_db_configuration:type_name -> workloadagent.protos.configuration.MongoDBConfiguration
-	8,  // 11: workloadagent.protos.configuration.OracleConfiguration.oracle_discovery:type_name -> workloadagent.protos.configuration.OracleDiscovery
-	9,  // 12: workloadagent.protos.configuration.OracleConfiguration.oracle_metrics:type_name -> workloadagent.protos.configuration.OracleMetrics
-	25, // 13: workloadagent.protos.configuration.OracleDiscovery.update_frequency:type_name -> google.protobuf.Duration
-	25, // 14: workloadagent.protos.configuration.OracleMetrics.collection_frequency:type_name -> google.protobuf.Duration
-	17, // 15: workloadagent.protos.configuration.OracleMetrics.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	19, // 16: workloadagent.protos.configuration.OracleMetrics.queries:type_name -> workloadagent.protos.configuration.Query
-	25, // 17: workloadagent.protos.configuration.OracleMetrics.query_timeout:type_name -> google.protobuf.Duration
-	17, // 18: workloadagent.protos.configuration.MySQLConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	25, // 19: workloadagent.protos.configuration.MySQLConfiguration.dbcenter_collection_frequency:type_name -> google.protobuf.Duration
-	17, // 20: workloadagent.protos.configuration.OpenShiftConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	25, // 21: workloadagent.protos.configuration.CommonDiscovery.collection_frequency:type_name -> google.protobuf.Duration
-	17, // 22: workloadagent.protos.configuration.RedisConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	17, // 23: workloadagent.protos.configuration.PostgresConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	25, // 24: workloadagent.protos.configuration.PostgresConfiguration.dbcenter_collection_frequency:type_name -> google.protobuf.Duration
-	17, // 25: workloadagent.protos.configuration.MongoDBConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	25, // 26: workloadagent.protos.configuration.MongoDBConfiguration.collection_frequency:type_name -> google.protobuf.Duration
-	21, // 27: workloadagent.protos.configuration.SQLServerConfiguration.collection_configuration:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration
-	22, // 28: workloadagent.protos.configuration.SQLServerConfiguration.credential_configurations:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration
-	25, // 29: workloadagent.protos.configuration.SQLServerConfiguration.collection_timeout:type_name -> google.protobuf.Duration
-	25, // 30: workloadagent.protos.configuration.SQLServerConfiguration.retry_frequency:type_name -> google.protobuf.Duration
-	18, // 31: workloadagent.protos.configuration.ConnectionParameters.secret:type_name -> workloadagent.protos.configuration.SecretRef
-	20, // 32: workloadagent.protos.configuration.Query.columns:type_name -> workloadagent.protos.configuration.Column
-	3,  // 33: workloadagent.protos.configuration.Query.database_role:type_name -> workloadagent.protos.configuration.Query.DatabaseRole
-	0,  // 34: workloadagent.protos.configuration.Column.metric_type:type_name -> workloadagent.protos.configuration.MetricType
-	1,  // 35: workloadagent.protos.configuration.Column.value_type:type_name -> workloadagent.protos.configuration.ValueType
-	25, // 36: workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration.collection_frequency:type_name -> google.protobuf.Duration
-	25, // 37: workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration.dbcenter_metrics_collection_frequency:type_name -> google.protobuf.Duration
-	5,  // 38: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.vm_properties:type_name -> workloadagent.protos.configuration.CloudProperties
-	17, // 39: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	23, // 40: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.remote_win:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteWin
-	24, // 41: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.remote_linux:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteLinux
-	17, // 42: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteWin.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	17, // 43: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteLinux.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
-	44, // [44:44] is the sub-list for method output_type
-	44, // [44:44] is the sub-list for method input_type
-	44, // [44:44] is the sub-list for extension type_name
-	44, // [44:44] is the sub-list for extension extendee
-	0,  // [0:44] is the sub-list for field type_name
+	2,   // 0: workloadagent.protos.configuration.Configuration.log_level:type_name -> workloadagent.protos.configuration.Configuration.LogLevel
+	9,   // 1: workloadagent.protos.configuration.Configuration.cloud_properties:type_name -> workloadagent.protos.configuration.CloudProperties
+	10,  // 2: workloadagent.protos.configuration.Configuration.agent_properties:type_name -> workloadagent.protos.configuration.AgentProperties
+	35,  // 3: workloadagent.protos.configuration.Configuration.oracle_configuration:type_name -> workloadagent.protos.configuration.OracleConfiguration
+	39,  // 4: workloadagent.protos.configuration.Configuration.mysql_configuration:type_name -> workloadagent.protos.configuration.MySQLConfiguration
+	43,  // 5: workloadagent.protos.configuration.Configuration.common_discovery:type_name -> workloadagent.protos.configuration.CommonDiscovery
+	46,  // 6: workloadagent.protos.configuration.Configuration.redis_configuration:type_name -> workloadagent.protos.configuration.RedisConfiguration
+	51,  // 7: workloadagent.protos.configuration.Configuration.sqlserver_configuration:type_name -> workloadagent.protos.configuration.SQLServerConfiguration
+	48,  // 8: workloadagent.protos.configuration.Configuration.postgres_configuration:type_name -> workloadagent.protos.configuration.PostgresConfiguration
+	42,  // 9: workloadagent.protos.configuration.Configuration.openshift_configuration:type_name -> workloadagent.protos.configuration.OpenShiftConfiguration
+	50,  // 10: workloadagent.protos.configuration.Configuration.mongo_db_configuration:type_name -> workloadagent.protos.configuration.MongoDBConfiguration
+	11,  // 11: workloadagent.protos.configuration.Configuration.data_warehouse_batching:type_name -> workloadagent.protos.configuration.DataWarehouseBatching
+	30,  // 12: workloadagent.protos.configuration.Configuration.data_warehouse_export:type_name -> workloadagent.protos.configuration.DataWarehouseExport
+	19,  // 13: workloadagent.protos.configuration.Configuration.remote_configuration:type_name -> workloadagent.protos.configuration.RemoteConfiguration
+	23,  // 14: workloadagent.protos.configuration.Configuration.credentials:type_name -> workloadagent.protos.configuration.Credentials
+	20,  // 15: workloadagent.protos.configuration.Configuration.network:type_name -> workloadagent.protos.configuration.Network
+	21,  // 16: workloadagent.protos.configuration.Configuration.endpoints:type_name -> workloadagent.protos.configuration.Endpoints
+	22,  // 17: workloadagent.protos.configuration.Configuration.audit_log:type_name -> workloadagent.protos.configuration.AuditLog
+	27,  // 18: workloadagent.protos.configuration.Configuration.data_warehouse_filter:type_name -> workloadagent.protos.configuration.DataWarehouseFilter
+	32,  // 19: workloadagent.protos.configuration.Configuration.cloud_monitoring_export:type_name -> workloadagent.protos.configuration.CloudMonitoringExport
+	15,  // 20: workloadagent.protos.configuration.Configuration.data_warehouse_activation:type_name -> workloadagent.protos.configuration.DataWarehouseActivation
+	18,  // 21: workloadagent.protos.configuration.Configuration.data_warehouse_circuit_breaker:type_name -> workloadagent.protos.configuration.DataWarehouseCircuitBreaker
+	17,  // 22: workloadagent.protos.configuration.Configuration.service_recovery:type_name -> workloadagent.protos.configuration.ServiceRecovery
+	16,  // 23: workloadagent.protos.configuration.Configuration.collection_schedule:type_name -> workloadagent.protos.configuration.CollectionSchedule
+	12,  // 24: workloadagent.protos.configuration.Configuration.data_warehouse_deduplication:type_name -> workloadagent.protos.configuration.DataWarehouseDeduplication
+	13,  // 25: workloadagent.protos.configuration.Configuration.data_warehouse_routing:type_name -> workloadagent.protos.configuration.DataWarehouseRouting
+	14,  // 26: workloadagent.protos.configuration.Configuration.data_warehouse_additional_destinations:type_name -> workloadagent.protos.configuration.DataWarehouseDestination
+	33,  // 27: workloadagent.protos.configuration.Configuration.pubsub_export:type_name -> workloadagent.protos.configuration.PubSubExport
+	34,  // 28: workloadagent.protos.configuration.Configuration.bigquery_export:type_name -> workloadagent.protos.configuration.BigQueryExport
+	25,  // 29: workloadagent.protos.configuration.Configuration.insight_log:type_name -> workloadagent.protos.configuration.InsightLog
+	26,  // 30: workloadagent.protos.configuration.Configuration.legacy_agents:type_name -> workloadagent.protos.configuration.LegacyAgents
+	31,  // 31: workloadagent.protos.configuration.Configuration.health_probes:type_name -> workloadagent.protos.configuration.HealthProbes
+	28,  // 32: workloadagent.protos.configuration.Configuration.config_drift:type_name -> workloadagent.protos.configuration.ConfigDrift
+	63,  // 33: workloadagent.protos.configuration.DataWarehouseBatching.flush_interval:type_name -> google.protobuf.Duration
+	63,  // 34: workloadagent.protos.configuration.DataWarehouseDeduplication.max_staleness:type_name -> google.protobuf.Duration
+	63,  // 35: workloadagent.protos.configuration.DataWarehouseActivation.check_interval:type_name -> google.protobuf.Duration
+	63,  // 36: workloadagent.protos.configuration.DataWarehouseActivation.recheck_interval:type_name -> google.protobuf.Duration
+	63,  // 37: workloadagent.protos.configuration.DataWarehouseCircuitBreaker.cool_down:type_name -> google.protobuf.Duration
+	54,  // 38: workloadagent.protos.configuration.RemoteConfiguration.secret:type_name -> workloadagent.protos.configuration.SecretRef
+	63,  // 39: workloadagent.protos.configuration.RemoteConfiguration.refresh_interval:type_name -> google.protobuf.Duration
+	63,  // 40: workloadagent.protos.configuration.Network.dns_lookup_timeout:type_name -> google.protobuf.Duration
+	24,  // 41: workloadagent.protos.configuration.Credentials.workload_identity_federation:type_name -> workloadagent.protos.configuration.WorkloadIdentityFederation
+	3,   // 42: workloadagent.protos.configuration.LegacyAgents.precedence:type_name -> workloadagent.protos.configuration.LegacyAgents.Precedence
+	29,  // 43: workloadagent.protos.configuration.ConfigDrift.baselines:type_name -> workloadagent.protos.configuration.ConfigBaseline
+	63,  // 44: workloadagent.protos.configuration.HealthProbes.interval:type_name -> google.protobuf.Duration
+	63,  // 45: workloadagent.protos.configuration.HealthProbes.timeout:type_name -> google.protobuf.Duration
+	36,  // 46: workloadagent.protos.configuration.OracleConfiguration.oracle_discovery:type_name -> workloadagent.protos.configuration.OracleDiscovery
+	37,  // 47: workloadagent.protos.configuration.OracleConfiguration.oracle_metrics:type_name -> workloadagent.protos.configuration.OracleMetrics
+	63,  // 48: workloadagent.protos.configuration.OracleDiscovery.update_frequency:type_name -> google.protobuf.Duration
+	63,  // 49: workloadagent.protos.configuration.OracleMetrics.collection_frequency:type_name -> google.protobuf.Duration
+	52,  // 50: workloadagent.protos.configuration.OracleMetrics.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	57,  // 51: workloadagent.protos.configuration.OracleMetrics.queries:type_name -> workloadagent.protos.configuration.Query
+	63,  // 52: workloadagent.protos.configuration.OracleMetrics.query_timeout:type_name -> google.protobuf.Duration
+	38,  // 53: workloadagent.protos.configuration.OracleMetrics.activity_sampling:type_name -> workloadagent.protos.configuration.OracleActivitySampling
+	4,   // 54: workloadagent.protos.configuration.OracleActivitySampling.source:type_name -> workloadagent.protos.configuration.OracleActivitySampling.Source
+	52,  // 55: workloadagent.protos.configuration.MySQLConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	63,  // 56: workloadagent.protos.configuration.MySQLConfiguration.dbcenter_collection_frequency:type_name -> google.protobuf.Duration
+	40,  // 57: workloadagent.protos.configuration.MySQLConfiguration.query_digests:type_name -> workloadagent.protos.configuration.MySQLQueryDigests
+	52,  // 58: workloadagent.protos.configuration.MySQLConfiguration.instances:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	47,  // 59: workloadagent.protos.configuration.MySQLConfiguration.tls:type_name -> workloadagent.protos.configuration.TLSConfiguration
+	52,  // 60: workloadagent.protos.configuration.MySQLConfiguration.proxysql:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	41,  // 61: workloadagent.protos.configuration.MySQLConfiguration.slow_query_log:type_name -> workloadagent.protos.configuration.MySQLSlowQueryLog
+	52,  // 62: workloadagent.protos.configuration.OpenShiftConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	63,  // 63: workloadagent.protos.configuration.CommonDiscovery.collection_frequency:type_name -> google.protobuf.Duration
+	45,  // 64: workloadagent.protos.configuration.CommonDiscovery.workload_signatures:type_name -> workloadagent.protos.configuration.WorkloadSignature
+	44,  // 65: workloadagent.protos.configuration.CommonDiscovery.container_attribution:type_name -> workloadagent.protos.configuration.ContainerAttribution
+	52,  // 66: workloadagent.protos.configuration.RedisConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	47,  // 67: workloadagent.protos.configuration.RedisConfiguration.tls:type_name -> workloadagent.protos.configuration.TLSConfiguration
+	54,  // 68: workloadagent.protos.configuration.TLSConfiguration.cert_secret:type_name -> workloadagent.protos.configuration.SecretRef
+	54,  // 69: workloadagent.protos.configuration.TLSConfiguration.key_secret:type_name -> workloadagent.protos.configuration.SecretRef
+	52,  // 70: workloadagent.protos.configuration.PostgresConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	63,  // 71: workloadagent.protos.configuration.PostgresConfiguration.dbcenter_collection_frequency:type_name -> google.protobuf.Duration
+	52,  // 72: workloadagent.protos.configuration.PostgresConfiguration.instances:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	47,  // 73: workloadagent.protos.configuration.PostgresConfiguration.tls:type_name -> workloadagent.protos.configuration.TLSConfiguration
+	52,  // 74: workloadagent.protos.configuration.PostgresConfiguration.pgbouncer:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	49,  // 75: workloadagent.protos.configuration.PostgresConfiguration.statements:type_name -> workloadagent.protos.configuration.PostgresStatements
+	52,  // 76: workloadagent.protos.configuration.MongoDBConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	63,  // 77: workloadagent.protos.configuration.MongoDBConfiguration.collection_frequency:type_name -> google.protobuf.Duration
+	47,  // 78: workloadagent.protos.configuration.MongoDBConfiguration.tls:type_name -> workloadagent.protos.configuration.TLSConfiguration
+	5,   // 79: workloadagent.protos.configuration.MongoDBConfiguration.auth_mechanism:type_name -> workloadagent.protos.configuration.MongoDBConfiguration.AuthMechanism
+	59,  // 80: workloadagent.protos.configuration.SQLServerConfiguration.collection_configuration:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration
+	60,  // 81: workloadagent.protos.configuration.SQLServerConfiguration.credential_configurations:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration
+	63,  // 82: workloadagent.protos.configuration.SQLServerConfiguration.collection_timeout:type_name -> google.protobuf.Duration
+	63,  // 83: workloadagent.protos.configuration.SQLServerConfiguration.retry_frequency:type_name -> google.protobuf.Duration
+	54,  // 84: workloadagent.protos.configuration.ConnectionParameters.secret:type_name -> workloadagent.protos.configuration.SecretRef
+	55,  // 85: workloadagent.protos.configuration.ConnectionParameters.vault_secret:type_name -> workloadagent.protos.configuration.VaultSecretRef
+	53,  // 86: workloadagent.protos.configuration.ConnectionParameters.stored_credential:type_name -> workloadagent.protos.configuration.StoredCredentialRef
+	6,   // 87: workloadagent.protos.configuration.SecretRef.format:type_name -> workloadagent.protos.configuration.SecretRef.Format
+	56,  // 88: workloadagent.protos.configuration.VaultSecretRef.app_role:type_name -> workloadagent.protos.configuration.VaultAppRole
+	47,  // 89: workloadagent.protos.configuration.VaultSecretRef.tls:type_name -> workloadagent.protos.configuration.TLSConfiguration
+	58,  // 90: workloadagent.protos.configuration.Query.columns:type_name -> workloadagent.protos.configuration.Column
+	7,   // 91: workloadagent.protos.configuration.Query.database_role:type_name -> workloadagent.protos.configuration.Query.DatabaseRole
+	0,   // 92: workloadagent.protos.configuration.Column.metric_type:type_name -> workloadagent.protos.configuration.MetricType
+	1,   // 93: workloadagent.protos.configuration.Column.value_type:type_name -> workloadagent.protos.configuration.ValueType
+	63,  // 94: workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration.collection_frequency:type_name -> google.protobuf.Duration
+	63,  // 95: workloadagent.protos.configuration.SQLServerConfiguration.CollectionConfiguration.dbcenter_metrics_collection_frequency:type_name -> google.protobuf.Duration
+	9,   // 96: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.vm_properties:type_name -> workloadagent.protos.configuration.CloudProperties
+	52,  // 97: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	61,  // 98: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.remote_win:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteWin
+	62,  // 99: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.remote_linux:type_name -> workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteLinux
+	52,  // 100: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteWin.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	52,  // 101: workloadagent.protos.configuration.SQLServerConfiguration.CredentialConfiguration.GuestCredentialsRemoteLinux.connection_parameters:type_name -> workloadagent.protos.configuration.ConnectionParameters
+	102, // [102:102] is the sub-list for method output_type
+	102, // [102:102] is the sub-list for method input_type
+	102, // [102:102] is the sub-list for extension type_name
+	102, // [102:102] is the sub-list for extension extendee
+	0,   // [0:102] is the sub-list for field type_name
 }
 
 func init() { file_protos_configuration_configuration_proto_init() }
@@ -2417,8 +6035,152 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CloudProperties); i {
+		file_protos_configuration_configuration_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloudProperties); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AgentProperties); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseBatching); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseDeduplication); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseRouting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseDestination); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseActivation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CollectionSchedule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ServiceRecovery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseCircuitBreaker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoteConfiguration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Network); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Endpoints); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2429,8 +6191,8 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AgentProperties); i {
+		file_protos_configuration_configuration_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditLog); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2441,7 +6203,151 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Credentials); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadIdentityFederation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InsightLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LegacyAgents); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigDrift); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConfigBaseline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DataWarehouseExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HealthProbes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloudMonitoringExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PubSubExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BigQueryExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OracleConfiguration); i {
 			case 0:
 				return &v.state
@@ -2453,7 +6359,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OracleDiscovery); i {
 			case 0:
 				return &v.state
@@ -2465,7 +6371,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OracleMetrics); i {
 			case 0:
 				return &v.state
@@ -2477,7 +6383,19 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OracleActivitySampling); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MySQLConfiguration); i {
 			case 0:
 				return &v.state
@@ -2489,7 +6407,31 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MySQLQueryDigests); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MySQLSlowQueryLog); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OpenShiftConfiguration); i {
 			case 0:
 				return &v.state
@@ -2501,7 +6443,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CommonDiscovery); i {
 			case 0:
 				return &v.state
@@ -2513,7 +6455,31 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContainerAttribution); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkloadSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RedisConfiguration); i {
 			case 0:
 				return &v.state
@@ -2525,7 +6491,19 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TLSConfiguration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PostgresConfiguration); i {
 			case 0:
 				return &v.state
@@ -2537,7 +6515,19 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PostgresStatements); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MongoDBConfiguration); i {
 			case 0:
 				return &v.state
@@ -2549,7 +6539,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SQLServerConfiguration); i {
 			case 0:
 				return &v.state
@@ -2561,7 +6551,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ConnectionParameters); i {
 			case 0:
 				return &v.state
@@ -2573,7 +6563,19 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StoredCredentialRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SecretRef); i {
 			case 0:
 				return &v.state
@@ -2585,7 +6587,31 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VaultSecretRef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VaultAppRole); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_configuration_configuration_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Query); i {
 			case 0:
 				return &v.state
@@ -2597,7 +6623,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Column); i {
 			case 0:
 				return &v.state
@@ -2609,7 +6635,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SQLServerConfiguration_CollectionConfiguration); i {
 			case 0:
 				return &v.state
@@ -2621,7 +6647,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SQLServerConfiguration_CredentialConfiguration); i {
 			case 0:
 				return &v.state
@@ -2633,7 +6659,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteWin); i {
 			case 0:
 				return &v.state
@@ -2645,7 +6671,7 @@ func file_protos_configuration_configuration_proto_init() {
 				return nil
 			}
 		}
-		file_protos_configuration_configuration_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_configuration_configuration_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SQLServerConfiguration_CredentialConfiguration_GuestCredentialsRemoteLinux); i {
 			case 0:
 				return &v.state
@@ -2659,18 +6685,39 @@ func file_protos_configuration_configuration_proto_init() {
 		}
 	}
 	file_protos_configuration_configuration_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[2].OneofWrappers = []interface{}{}
 	file_protos_configuration_configuration_proto_msgTypes[3].OneofWrappers = []interface{}{}
 	file_protos_configuration_configuration_proto_msgTypes[4].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[5].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[6].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[7].OneofWrappers = []interface{}{}
 	file_protos_configuration_configuration_proto_msgTypes[8].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[9].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[10].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[11].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[12].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[15].OneofWrappers = []interface{}{}
-	file_protos_configuration_configuration_proto_msgTypes[18].OneofWrappers = []interface{}{
+	file_protos_configuration_configuration_proto_msgTypes[14].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[17].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[20].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[22].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[23].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[24].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[25].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[26].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[27].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[28].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[29].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[30].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[31].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[32].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[33].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[34].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[35].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[36].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[38].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[40].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[41].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[42].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[43].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[47].OneofWrappers = []interface{}{
+		(*VaultSecretRef_TokenFile)(nil),
+		(*VaultSecretRef_AppRole)(nil),
+	}
+	file_protos_configuration_configuration_proto_msgTypes[49].OneofWrappers = []interface{}{}
+	file_protos_configuration_configuration_proto_msgTypes[52].OneofWrappers = []interface{}{
 		(*SQLServerConfiguration_CredentialConfiguration_LocalCollection)(nil),
 		(*SQLServerConfiguration_CredentialConfiguration_RemoteWin)(nil),
 		(*SQLServerConfiguration_CredentialConfiguration_RemoteLinux)(nil),
@@ -2680,8 +6727,8 @@ func file_protos_configuration_configuration_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_configuration_configuration_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   21,
+			NumEnums:      8,
+			NumMessages:   55,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -91,7 +91,7 @@ func (x Discovery_Database_DatabaseRole) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Discovery_Database_DatabaseRole.Descriptor instead.
 func (Discovery_Database_DatabaseRole) EnumDescriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 2, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 0}
 }
 
 type Discovery_Database_Instance_DatabaseEdition int32
@@ -152,7 +152,7 @@ func (x Discovery_Database_Instance_DatabaseEdition) Number() protoreflect.EnumN
 
 // Deprecated: Use Discovery_Database_Instance_DatabaseEdition.Descriptor instead.
 func (Discovery_Database_Instance_DatabaseEdition) EnumDescriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 2, 0, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 0, 0}
 }
 
 // DATABASE_TYPE from V$INSTANCE
@@ -209,7 +209,7 @@ func (x Discovery_Database_Instance_DatabaseType) Number() protoreflect.EnumNumb
 
 // Deprecated: Use Discovery_Database_Instance_DatabaseType.Descriptor instead.
 func (Discovery_Database_Instance_DatabaseType) EnumDescriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 2, 0, 1}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 0, 1}
 }
 
 // The state of the handler.
@@ -261,7 +261,7 @@ func (x Discovery_Listener_Service_DatabaseInstance_Handler_State) Number() prot
 
 // Deprecated: Use Discovery_Listener_Service_DatabaseInstance_Handler_State.Descriptor instead.
 func (Discovery_Listener_Service_DatabaseInstance_Handler_State) EnumDescriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 1, 0, 0, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 1, 0, 0, 0}
 }
 
 // The schema for Oracle discovery data.
@@ -276,6 +276,8 @@ type Discovery struct {
 	LastUpdated *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
 	Listeners   []*Discovery_Listener  `protobuf:"bytes,3,rep,name=listeners,proto3" json:"listeners,omitempty"`
 	Host        *Discovery_Host        `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
+	// The Oracle homes of the discovered databases.
+	OracleHomes []*Discovery_OracleHome `protobuf:"bytes,5,rep,name=oracle_homes,json=oracleHomes,proto3" json:"oracle_homes,omitempty"`
 }
 
 func (x *Discovery) Reset() {
@@ -338,6 +340,13 @@ func (x *Discovery) GetHost() *Discovery_Host {
 	return nil
 }
 
+func (x *Discovery) GetOracleHomes() []*Discovery_OracleHome {
+	if x != nil {
+		return x.OracleHomes
+	}
+	return nil
+}
+
 type Discovery_DatabaseRoot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -496,6 +505,80 @@ type Discovery_Host_Vm struct {
 
 func (*Discovery_Host_Vm) isDiscovery_Host_HostType() {}
 
+// An Oracle home and its patch inventory from "opatch lsinventory".
+type Discovery_OracleHome struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path          string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                        // E.g., "/u01/app/oracle/product/19.0.0/dbhome_1"
+	OpatchVersion string `protobuf:"bytes,2,opt,name=opatch_version,json=opatchVersion,proto3" json:"opatch_version,omitempty"` // E.g., "12.2.0.1.37"
+	// Version of the latest database release update, patch set update or
+	// bundle patch applied to the home, e.g. "19.21.0.0.231017".
+	ReleaseUpdate string                        `protobuf:"bytes,3,opt,name=release_update,json=releaseUpdate,proto3" json:"release_update,omitempty"`
+	Patches       []*Discovery_OracleHome_Patch `protobuf:"bytes,4,rep,name=patches,proto3" json:"patches,omitempty"`
+}
+
+func (x *Discovery_OracleHome) Reset() {
+	*x = Discovery_OracleHome{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Discovery_OracleHome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discovery_OracleHome) ProtoMessage() {}
+
+func (x *Discovery_OracleHome) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discovery_OracleHome.ProtoReflect.Descriptor instead.
+func (*Discovery_OracleHome) Descriptor() ([]byte, []int) {
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *Discovery_OracleHome) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Discovery_OracleHome) GetOpatchVersion() string {
+	if x != nil {
+		return x.OpatchVersion
+	}
+	return ""
+}
+
+func (x *Discovery_OracleHome) GetReleaseUpdate() string {
+	if x != nil {
+		return x.ReleaseUpdate
+	}
+	return ""
+}
+
+func (x *Discovery_OracleHome) GetPatches() []*Discovery_OracleHome_Patch {
+	if x != nil {
+		return x.Patches
+	}
+	return nil
+}
+
 type Discovery_Database struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -515,12 +598,16 @@ type Discovery_Database struct {
 	ParentDbUniqueName string `protobuf:"bytes,9,opt,name=parent_db_unique_name,json=parentDbUniqueName,proto3" json:"parent_db_unique_name,omitempty"`
 	// List of Oracle instances associated with the database
 	Instances []*Discovery_Database_Instance `protobuf:"bytes,10,rep,name=instances,proto3" json:"instances,omitempty"`
+	// Directories holding the datafiles of the database, from v$datafile.
+	// Datafiles in ASM disk groups are reported by their disk group path, e.g.
+	// "+DATA/ORCL/DATAFILE".
+	DatafileDirectories []string `protobuf:"bytes,11,rep,name=datafile_directories,json=datafileDirectories,proto3" json:"datafile_directories,omitempty"`
 }
 
 func (x *Discovery_Database) Reset() {
 	*x = Discovery_Database{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[3]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -533,7 +620,7 @@ func (x *Discovery_Database) String() string {
 func (*Discovery_Database) ProtoMessage() {}
 
 func (x *Discovery_Database) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[3]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -546,7 +633,7 @@ func (x *Discovery_Database) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Database.ProtoReflect.Descriptor instead.
 func (*Discovery_Database) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 2}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3}
 }
 
 func (x *Discovery_Database) GetDbid() int64 {
@@ -612,6 +699,13 @@ func (x *Discovery_Database) GetInstances() []*Discovery_Database_Instance {
 	return nil
 }
 
+func (x *Discovery_Database) GetDatafileDirectories() []string {
+	if x != nil {
+		return x.DatafileDirectories
+	}
+	return nil
+}
+
 // Oracle Net Listener configuration.
 type Discovery_Listener struct {
 	state         protoimpl.MessageState
@@ -628,12 +722,20 @@ type Discovery_Listener struct {
 	TraceFile     string                         `protobuf:"bytes,8,opt,name=trace_file,json=traceFile,proto3" json:"trace_file,omitempty"`             // E.g., "/oracle/network/trace/listener.trc"
 	Services      []*Discovery_Listener_Service  `protobuf:"bytes,9,rep,name=services,proto3" json:"services,omitempty"`
 	Endpoints     []*Discovery_Listener_Endpoint `protobuf:"bytes,10,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	// The endpoints configured for the listener in its listener.ora file.
+	// Unlike endpoints, these are also reported for addresses the listener
+	// failed to listen on.
+	ConfiguredEndpoints []*Discovery_Listener_Endpoint       `protobuf:"bytes,11,rep,name=configured_endpoints,json=configuredEndpoints,proto3" json:"configured_endpoints,omitempty"`
+	TlsConfiguration    *Discovery_Listener_TlsConfiguration `protobuf:"bytes,12,opt,name=tls_configuration,json=tlsConfiguration,proto3" json:"tls_configuration,omitempty"`
+	// The net service names defined in the tnsnames.ora file next to the
+	// listener parameter file.
+	TnsAliases []*Discovery_Listener_TnsAlias `protobuf:"bytes,13,rep,name=tns_aliases,json=tnsAliases,proto3" json:"tns_aliases,omitempty"`
 }
 
 func (x *Discovery_Listener) Reset() {
 	*x = Discovery_Listener{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[4]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -646,7 +748,7 @@ func (x *Discovery_Listener) String() string {
 func (*Discovery_Listener) ProtoMessage() {}
 
 func (x *Discovery_Listener) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[4]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -659,7 +761,7 @@ func (x *Discovery_Listener) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Listener.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4}
 }
 
 func (x *Discovery_Listener) GetId() *Discovery_Listener_ListenerId {
@@ -725,6 +827,27 @@ func (x *Discovery_Listener) GetEndpoints() []*Discovery_Listener_Endpoint {
 	return nil
 }
 
+func (x *Discovery_Listener) GetConfiguredEndpoints() []*Discovery_Listener_Endpoint {
+	if x != nil {
+		return x.ConfiguredEndpoints
+	}
+	return nil
+}
+
+func (x *Discovery_Listener) GetTlsConfiguration() *Discovery_Listener_TlsConfiguration {
+	if x != nil {
+		return x.TlsConfiguration
+	}
+	return nil
+}
+
+func (x *Discovery_Listener) GetTnsAliases() []*Discovery_Listener_TnsAlias {
+	if x != nil {
+		return x.TnsAliases
+	}
+	return nil
+}
+
 // Represents a Multitenant container database (CDB) which hosts multiple
 // pluggable databases (PDBs)
 type Discovery_DatabaseRoot_ContainerDatabase struct {
@@ -741,7 +864,7 @@ type Discovery_DatabaseRoot_ContainerDatabase struct {
 func (x *Discovery_DatabaseRoot_ContainerDatabase) Reset() {
 	*x = Discovery_DatabaseRoot_ContainerDatabase{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[5]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -754,7 +877,7 @@ func (x *Discovery_DatabaseRoot_ContainerDatabase) String() string {
 func (*Discovery_DatabaseRoot_ContainerDatabase) ProtoMessage() {}
 
 func (x *Discovery_DatabaseRoot_ContainerDatabase) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[5]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -796,7 +919,7 @@ type Discovery_Host_GcpVirtualMachine struct {
 func (x *Discovery_Host_GcpVirtualMachine) Reset() {
 	*x = Discovery_Host_GcpVirtualMachine{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[6]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -809,7 +932,7 @@ func (x *Discovery_Host_GcpVirtualMachine) String() string {
 func (*Discovery_Host_GcpVirtualMachine) ProtoMessage() {}
 
 func (x *Discovery_Host_GcpVirtualMachine) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[6]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -832,6 +955,81 @@ func (x *Discovery_Host_GcpVirtualMachine) GetInstanceId() string {
 	return ""
 }
 
+// A patch applied to the Oracle home.
+type Discovery_OracleHome_Patch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // E.g., "35643107"
+	// E.g., "Database Release Update : 19.21.0.0.231017 (35643107)"
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	AppliedTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=applied_time,json=appliedTime,proto3" json:"applied_time,omitempty"`
+	// Whether the patch is a one-off patch rather than a release update,
+	// patch set update or bundle patch.
+	OneOff bool `protobuf:"varint,4,opt,name=one_off,json=oneOff,proto3" json:"one_off,omitempty"`
+}
+
+func (x *Discovery_OracleHome_Patch) Reset() {
+	*x = Discovery_OracleHome_Patch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Discovery_OracleHome_Patch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discovery_OracleHome_Patch) ProtoMessage() {}
+
+func (x *Discovery_OracleHome_Patch) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discovery_OracleHome_Patch.ProtoReflect.Descriptor instead.
+func (*Discovery_OracleHome_Patch) Descriptor() ([]byte, []int) {
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 2, 0}
+}
+
+func (x *Discovery_OracleHome_Patch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Discovery_OracleHome_Patch) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Discovery_OracleHome_Patch) GetAppliedTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AppliedTime
+	}
+	return nil
+}
+
+func (x *Discovery_OracleHome_Patch) GetOneOff() bool {
+	if x != nil {
+		return x.OneOff
+	}
+	return false
+}
+
 type Discovery_Database_Instance struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -853,12 +1051,16 @@ type Discovery_Database_Instance struct {
 	Version string                                      `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
 	Edition Discovery_Database_Instance_DatabaseEdition `protobuf:"varint,7,opt,name=edition,proto3,enum=workloadagent.protos.oraclediscovery.Discovery_Database_Instance_DatabaseEdition" json:"edition,omitempty"`
 	Type    Discovery_Database_Instance_DatabaseType    `protobuf:"varint,8,opt,name=type,proto3,enum=workloadagent.protos.oraclediscovery.Discovery_Database_Instance_DatabaseType" json:"type,omitempty"`
+	// Snapshot of the initialization parameters from v$parameter which are
+	// used for best-practice evaluation, keyed by parameter name (e.g.
+	// "sga_target").
+	InitParameters map[string]string `protobuf:"bytes,9,rep,name=init_parameters,json=initParameters,proto3" json:"init_parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *Discovery_Database_Instance) Reset() {
 	*x = Discovery_Database_Instance{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[7]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -871,7 +1073,7 @@ func (x *Discovery_Database_Instance) String() string {
 func (*Discovery_Database_Instance) ProtoMessage() {}
 
 func (x *Discovery_Database_Instance) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[7]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -884,7 +1086,7 @@ func (x *Discovery_Database_Instance) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Database_Instance.ProtoReflect.Descriptor instead.
 func (*Discovery_Database_Instance) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 2, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 0}
 }
 
 func (x *Discovery_Database_Instance) GetInstanceNumber() int64 {
@@ -943,6 +1145,13 @@ func (x *Discovery_Database_Instance) GetType() Discovery_Database_Instance_Data
 	return Discovery_Database_Instance_DATABASE_TYPE_UNKNOWN
 }
 
+func (x *Discovery_Database_Instance) GetInitParameters() map[string]string {
+	if x != nil {
+		return x.InitParameters
+	}
+	return nil
+}
+
 type Discovery_Listener_ListenerId struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -955,7 +1164,7 @@ type Discovery_Listener_ListenerId struct {
 func (x *Discovery_Listener_ListenerId) Reset() {
 	*x = Discovery_Listener_ListenerId{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[8]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -968,7 +1177,7 @@ func (x *Discovery_Listener_ListenerId) String() string {
 func (*Discovery_Listener_ListenerId) ProtoMessage() {}
 
 func (x *Discovery_Listener_ListenerId) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[8]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -981,7 +1190,7 @@ func (x *Discovery_Listener_ListenerId) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Listener_ListenerId.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_ListenerId) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 0}
 }
 
 func (x *Discovery_Listener_ListenerId) GetAlias() string {
@@ -1013,7 +1222,7 @@ type Discovery_Listener_Service struct {
 func (x *Discovery_Listener_Service) Reset() {
 	*x = Discovery_Listener_Service{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[9]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1026,7 +1235,7 @@ func (x *Discovery_Listener_Service) String() string {
 func (*Discovery_Listener_Service) ProtoMessage() {}
 
 func (x *Discovery_Listener_Service) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[9]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1039,7 +1248,7 @@ func (x *Discovery_Listener_Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Listener_Service.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_Service) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 1}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 1}
 }
 
 func (x *Discovery_Listener_Service) GetName() string {
@@ -1075,7 +1284,7 @@ type Discovery_Listener_Endpoint struct {
 func (x *Discovery_Listener_Endpoint) Reset() {
 	*x = Discovery_Listener_Endpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[10]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1088,7 +1297,7 @@ func (x *Discovery_Listener_Endpoint) String() string {
 func (*Discovery_Listener_Endpoint) ProtoMessage() {}
 
 func (x *Discovery_Listener_Endpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[10]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1101,7 +1310,7 @@ func (x *Discovery_Listener_Endpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Listener_Endpoint.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_Endpoint) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 2}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 2}
 }
 
 func (m *Discovery_Listener_Endpoint) GetProtocol() isDiscovery_Listener_Endpoint_Protocol {
@@ -1167,6 +1376,151 @@ func (*Discovery_Listener_Endpoint_Tcp) isDiscovery_Listener_Endpoint_Protocol()
 
 func (*Discovery_Listener_Endpoint_Tcps) isDiscovery_Listener_Endpoint_Protocol() {}
 
+// The TLS settings of the listener, read from listener.ora and sqlnet.ora.
+// Settings in listener.ora take precedence over those in sqlnet.ora.
+type Discovery_Listener_TlsConfiguration struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WalletLocation          string   `protobuf:"bytes,1,opt,name=wallet_location,json=walletLocation,proto3" json:"wallet_location,omitempty"`                              // E.g., "/u01/app/oracle/wallet"
+	SslClientAuthentication string   `protobuf:"bytes,2,opt,name=ssl_client_authentication,json=sslClientAuthentication,proto3" json:"ssl_client_authentication,omitempty"` // E.g., "FALSE"
+	SslVersion              string   `protobuf:"bytes,3,opt,name=ssl_version,json=sslVersion,proto3" json:"ssl_version,omitempty"`                                          // E.g., "1.2"
+	SslCipherSuites         []string `protobuf:"bytes,4,rep,name=ssl_cipher_suites,json=sslCipherSuites,proto3" json:"ssl_cipher_suites,omitempty"`
+}
+
+func (x *Discovery_Listener_TlsConfiguration) Reset() {
+	*x = Discovery_Listener_TlsConfiguration{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Discovery_Listener_TlsConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discovery_Listener_TlsConfiguration) ProtoMessage() {}
+
+func (x *Discovery_Listener_TlsConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discovery_Listener_TlsConfiguration.ProtoReflect.Descriptor instead.
+func (*Discovery_Listener_TlsConfiguration) Descriptor() ([]byte, []int) {
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 3}
+}
+
+func (x *Discovery_Listener_TlsConfiguration) GetWalletLocation() string {
+	if x != nil {
+		return x.WalletLocation
+	}
+	return ""
+}
+
+func (x *Discovery_Listener_TlsConfiguration) GetSslClientAuthentication() string {
+	if x != nil {
+		return x.SslClientAuthentication
+	}
+	return ""
+}
+
+func (x *Discovery_Listener_TlsConfiguration) GetSslVersion() string {
+	if x != nil {
+		return x.SslVersion
+	}
+	return ""
+}
+
+func (x *Discovery_Listener_TlsConfiguration) GetSslCipherSuites() []string {
+	if x != nil {
+		return x.SslCipherSuites
+	}
+	return nil
+}
+
+// A net service name which maps to a connect descriptor.
+type Discovery_Listener_TnsAlias struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string                         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // E.g., "ORCL"
+	Endpoints   []*Discovery_Listener_Endpoint `protobuf:"bytes,2,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	ServiceName string                         `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Sid         string                         `protobuf:"bytes,4,opt,name=sid,proto3" json:"sid,omitempty"`
+}
+
+func (x *Discovery_Listener_TnsAlias) Reset() {
+	*x = Discovery_Listener_TnsAlias{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Discovery_Listener_TnsAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Discovery_Listener_TnsAlias) ProtoMessage() {}
+
+func (x *Discovery_Listener_TnsAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Discovery_Listener_TnsAlias.ProtoReflect.Descriptor instead.
+func (*Discovery_Listener_TnsAlias) Descriptor() ([]byte, []int) {
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 4}
+}
+
+func (x *Discovery_Listener_TnsAlias) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Discovery_Listener_TnsAlias) GetEndpoints() []*Discovery_Listener_Endpoint {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *Discovery_Listener_TnsAlias) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *Discovery_Listener_TnsAlias) GetSid() string {
+	if x != nil {
+		return x.Sid
+	}
+	return ""
+}
+
 // Details specific to the IPC protocol.
 type Discovery_Listener_IPCProtocol struct {
 	state         protoimpl.MessageState
@@ -1181,7 +1535,7 @@ type Discovery_Listener_IPCProtocol struct {
 func (x *Discovery_Listener_IPCProtocol) Reset() {
 	*x = Discovery_Listener_IPCProtocol{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[11]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1194,7 +1548,7 @@ func (x *Discovery_Listener_IPCProtocol) String() string {
 func (*Discovery_Listener_IPCProtocol) ProtoMessage() {}
 
 func (x *Discovery_Listener_IPCProtocol) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[11]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1207,7 +1561,7 @@ func (x *Discovery_Listener_IPCProtocol) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Listener_IPCProtocol.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_IPCProtocol) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 3}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 5}
 }
 
 func (x *Discovery_Listener_IPCProtocol) GetKey() string {
@@ -1233,7 +1587,7 @@ type Discovery_Listener_NMPProtocol struct {
 func (x *Discovery_Listener_NMPProtocol) Reset() {
 	*x = Discovery_Listener_NMPProtocol{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[12]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1246,7 +1600,7 @@ func (x *Discovery_Listener_NMPProtocol) String() string {
 func (*Discovery_Listener_NMPProtocol) ProtoMessage() {}
 
 func (x *Discovery_Listener_NMPProtocol) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[12]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1259,7 +1613,7 @@ func (x *Discovery_Listener_NMPProtocol) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Listener_NMPProtocol.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_NMPProtocol) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 4}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 6}
 }
 
 func (x *Discovery_Listener_NMPProtocol) GetServer() string {
@@ -1290,7 +1644,7 @@ type Discovery_Listener_TCPProtocol struct {
 func (x *Discovery_Listener_TCPProtocol) Reset() {
 	*x = Discovery_Listener_TCPProtocol{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[13]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1303,7 +1657,7 @@ func (x *Discovery_Listener_TCPProtocol) String() string {
 func (*Discovery_Listener_TCPProtocol) ProtoMessage() {}
 
 func (x *Discovery_Listener_TCPProtocol) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[13]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1316,7 +1670,7 @@ func (x *Discovery_Listener_TCPProtocol) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Discovery_Listener_TCPProtocol.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_TCPProtocol) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 5}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 7}
 }
 
 func (x *Discovery_Listener_TCPProtocol) GetHost() string {
@@ -1351,7 +1705,7 @@ type Discovery_Listener_Service_DatabaseInstance struct {
 func (x *Discovery_Listener_Service_DatabaseInstance) Reset() {
 	*x = Discovery_Listener_Service_DatabaseInstance{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[14]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1364,7 +1718,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance) String() string {
 func (*Discovery_Listener_Service_DatabaseInstance) ProtoMessage() {}
 
 func (x *Discovery_Listener_Service_DatabaseInstance) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[14]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1377,7 +1731,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance) ProtoReflect() protoreflec
 
 // Deprecated: Use Discovery_Listener_Service_DatabaseInstance.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_Service_DatabaseInstance) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 1, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 1, 0}
 }
 
 func (x *Discovery_Listener_Service_DatabaseInstance) GetName() string {
@@ -1426,7 +1780,7 @@ type Discovery_Listener_Service_DatabaseInstance_Handler struct {
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler) Reset() {
 	*x = Discovery_Listener_Service_DatabaseInstance_Handler{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[15]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1439,7 +1793,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler) String() string {
 func (*Discovery_Listener_Service_DatabaseInstance_Handler) ProtoMessage() {}
 
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[15]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1452,7 +1806,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler) ProtoReflect() pro
 
 // Deprecated: Use Discovery_Listener_Service_DatabaseInstance_Handler.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_Service_DatabaseInstance_Handler) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 1, 0, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 1, 0, 0}
 }
 
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler) GetName() string {
@@ -1518,7 +1872,7 @@ type Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer struct
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer) Reset() {
 	*x = Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[16]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1531,7 +1885,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer) St
 func (*Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer) ProtoMessage() {}
 
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[16]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1544,7 +1898,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer) Pr
 
 // Deprecated: Use Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 1, 0, 0, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 1, 0, 0, 0}
 }
 
 // Information specific to dispatchers.
@@ -1561,7 +1915,7 @@ type Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher struct {
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher) Reset() {
 	*x = Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[17]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1574,7 +1928,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher) String(
 func (*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher) ProtoMessage() {}
 
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[17]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1587,7 +1941,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher) ProtoRe
 
 // Deprecated: Use Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 1, 0, 0, 1}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 1, 0, 0, 1}
 }
 
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher) GetMachineName() string {
@@ -1624,7 +1978,7 @@ type Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address stru
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address) Reset() {
 	*x = Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[18]
+		mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1637,7 +1991,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address)
 func (*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address) ProtoMessage() {}
 
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[18]
+	mi := &file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1650,7 +2004,7 @@ func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address)
 
 // Deprecated: Use Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address.ProtoReflect.Descriptor instead.
 func (*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address) Descriptor() ([]byte, []int) {
-	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 3, 1, 0, 0, 1, 0}
+	return file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP(), []int{0, 4, 1, 0, 0, 1, 0}
 }
 
 func (x *Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address) GetHost() string {
@@ -1684,7 +2038,7 @@ var file_protos_oraclediscovery_oraclediscovery_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f,
 	0x76, 0x65, 0x72, 0x79, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc0, 0x23, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc6, 0x2e, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76,
 	0x65, 0x72, 0x79, 0x12, 0x5a, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
 	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72,
@@ -1705,43 +2059,71 @@ var file_protos_oraclediscovery_oraclediscovery_proto_rawDesc = []byte{
 	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63,
 	0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63,
 	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74,
-	0x1a, 0x80, 0x03, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6f,
-	0x74, 0x12, 0x4a, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e,
-	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f,
-	0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x02, 0x64, 0x62, 0x12, 0x62, 0x0a,
-	0x03, 0x63, 0x64, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x4e, 0x2e, 0x77, 0x6f, 0x72,
-	0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72,
-	0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61,
-	0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x03, 0x63, 0x64,
-	0x62, 0x1a, 0xaf, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x44,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
-	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61,
-	0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73,
-	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52,
-	0x04, 0x72, 0x6f, 0x6f, 0x74, 0x12, 0x4c, 0x0a, 0x04, 0x70, 0x64, 0x62, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c,
-	0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
-	0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x04, 0x70,
-	0x64, 0x62, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x79, 0x5f, 0x74,
-	0x79, 0x70, 0x65, 0x1a, 0xbf, 0x01, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x58, 0x0a, 0x02,
-	0x76, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
-	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
-	0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e,
-	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e, 0x47,
-	0x63, 0x70, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
-	0x48, 0x00, 0x52, 0x02, 0x76, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61,
-	0x6d, 0x65, 0x1a, 0x34, 0x0a, 0x11, 0x47, 0x63, 0x70, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c,
-	0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e,
-	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74,
-	0x5f, 0x74, 0x79, 0x70, 0x65, 0x1a, 0xe2, 0x0a, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x12, 0x5d, 0x0a, 0x0c, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72,
+	0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f,
+	0x6d, 0x65, 0x52, 0x0b, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x73, 0x1a,
+	0x80, 0x03, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x74,
+	0x12, 0x4a, 0x0a, 0x02, 0x64, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x02, 0x64, 0x62, 0x12, 0x62, 0x0a, 0x03,
+	0x63, 0x64, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x4e, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62,
+	0x61, 0x73, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x48, 0x00, 0x52, 0x03, 0x63, 0x64, 0x62,
+	0x1a, 0xaf, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x44, 0x61,
+	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x04, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63,
+	0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x04,
+	0x72, 0x6f, 0x6f, 0x74, 0x12, 0x4c, 0x0a, 0x04, 0x70, 0x64, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x38, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65,
+	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65,
+	0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x04, 0x70, 0x64,
+	0x62, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x79, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x1a, 0xbf, 0x01, 0x0a, 0x04, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x58, 0x0a, 0x02, 0x76,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x46, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
+	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f,
+	0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44,
+	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x2e, 0x47, 0x63,
+	0x70, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x48,
+	0x00, 0x52, 0x02, 0x76, 0x6d, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d,
+	0x65, 0x1a, 0x34, 0x0a, 0x11, 0x47, 0x63, 0x70, 0x56, 0x69, 0x72, 0x74, 0x75, 0x61, 0x6c, 0x4d,
+	0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x42, 0x0b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x1a, 0xde, 0x02, 0x0a, 0x0a, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48,
+	0x6f, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x25, 0x0a, 0x0e, 0x6f, 0x70, 0x61, 0x74, 0x63,
+	0x68, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x6f, 0x70, 0x61, 0x74, 0x63, 0x68, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25,
+	0x0a, 0x0e, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x5a, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x40, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
+	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72,
+	0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69,
+	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f,
+	0x6d, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x63, 0x68, 0x52, 0x07, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65,
+	0x73, 0x1a, 0x91, 0x01, 0x0a, 0x05, 0x50, 0x61, 0x74, 0x63, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3d, 0x0a,
+	0x0c, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x0b, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x6f, 0x6e, 0x65, 0x5f, 0x6f, 0x66, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6f,
+	0x6e, 0x65, 0x4f, 0x66, 0x66, 0x1a, 0xd8, 0x0c, 0x0a, 0x08, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
 	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x62, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
 	0x52, 0x04, 0x64, 0x62, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x64, 0x62,
@@ -1769,211 +2151,271 @@ var file_protos_oraclediscovery_oraclediscovery_proto_rawDesc = []byte{
 	0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
 	0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x49, 0x6e,
 	0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x73, 0x1a, 0xd8, 0x05, 0x0a, 0x08, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x27,
-	0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6f,
-	0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x53, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x72,
-	0x61, 0x63, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0a, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x68,
-	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68,
-	0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x12, 0x6b, 0x0a, 0x07, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x51, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65,
-	0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65,
-	0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76,
-	0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x64,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x62,
-	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x4e, 0x2e, 0x77,
-	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76,
-	0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61,
-	0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e,
-	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x22, 0xcb, 0x01, 0x0a, 0x0f, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45,
-	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41,
-	0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
-	0x57, 0x4e, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45,
-	0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x52, 0x45, 0x5f, 0x45, 0x45,
-	0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45,
-	0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x45, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x44,
-	0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
-	0x50, 0x4f, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45,
-	0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x10, 0x04, 0x12, 0x18, 0x0a,
-	0x14, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f,
-	0x4e, 0x5f, 0x53, 0x45, 0x32, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x41, 0x54, 0x41, 0x42,
-	0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x58, 0x45, 0x10, 0x06,
-	0x22, 0x7a, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x19, 0x0a, 0x15, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50,
-	0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x44,
-	0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x41, 0x43,
-	0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x52, 0x41, 0x43, 0x5f, 0x4f, 0x4e, 0x45, 0x5f, 0x4e, 0x4f, 0x44, 0x45,
-	0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x54,
-	0x59, 0x50, 0x45, 0x5f, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45, 0x10, 0x03, 0x22, 0xcb, 0x01, 0x0a,
-	0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52, 0x6f, 0x6c, 0x65, 0x12, 0x19, 0x0a,
-	0x15, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55,
-	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x41, 0x54, 0x41,
-	0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x50, 0x52, 0x49, 0x4d, 0x41, 0x52,
-	0x59, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f,
-	0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53, 0x48, 0x4f, 0x54, 0x5f, 0x53, 0x54,
-	0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x44, 0x41, 0x54, 0x41, 0x42,
-	0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x4c, 0x4f, 0x47, 0x49, 0x43, 0x41, 0x4c,
-	0x5f, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x03, 0x12, 0x22, 0x0a, 0x1e, 0x44, 0x41,
-	0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x50, 0x48, 0x59, 0x53,
-	0x49, 0x43, 0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x04, 0x12, 0x1a,
-	0x0a, 0x16, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f,
-	0x46, 0x41, 0x52, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x05, 0x1a, 0xcb, 0x11, 0x0a, 0x08, 0x4c,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x53, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
-	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c,
-	0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
-	0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x52, 0x02, 0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72,
-	0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x63, 0x75, 0x72,
-	0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76,
-	0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x65, 0x4c,
-	0x65, 0x76, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
-	0x72, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c,
-	0x6f, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c,
-	0x6f, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x63, 0x65, 0x5f,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x63,
-	0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x5c, 0x0a, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x40, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
-	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f,
-	0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44,
-	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
-	0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x08, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x73, 0x12, 0x5f, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
-	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
-	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72,
-	0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69,
-	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
-	0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x73, 0x1a, 0x43, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x72, 0x61, 0x63,
-	0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f,
-	0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x1a, 0xf6, 0x08, 0x0a, 0x07, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x6f, 0x0a, 0x09, 0x69, 0x6e, 0x73,
-	0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x51, 0x2e, 0x77,
+	0x73, 0x12, 0x31, 0x0a, 0x14, 0x64, 0x61, 0x74, 0x61, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x13, 0x64, 0x61, 0x74, 0x61, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f,
+	0x72, 0x69, 0x65, 0x73, 0x1a, 0x9b, 0x07, 0x0a, 0x08, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x69, 0x6e, 0x73, 0x74,
+	0x61, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x53, 0x69, 0x64, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x6b, 0x0a, 0x07, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x51, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73,
+	0x65, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x62, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x4e, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x7e, 0x0a, 0x0f, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x55,
+	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x69, 0x6e, 0x69, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x41, 0x0a, 0x13, 0x49, 0x6e, 0x69, 0x74, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcb, 0x01, 0x0a, 0x0f, 0x44, 0x61, 0x74,
+	0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x18,
+	0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x44, 0x41,
+	0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43,
+	0x4f, 0x52, 0x45, 0x5f, 0x45, 0x45, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x41, 0x54, 0x41,
+	0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x45, 0x10,
+	0x02, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44,
+	0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x4f, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x41,
+	0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53,
+	0x45, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f,
+	0x45, 0x44, 0x49, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x45, 0x32, 0x10, 0x05, 0x12, 0x17, 0x0a,
+	0x13, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x45, 0x44, 0x49, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x58, 0x45, 0x10, 0x06, 0x22, 0x7a, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
+	0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41,
+	0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
+	0x00, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x52, 0x41, 0x43, 0x10, 0x01, 0x12, 0x1e, 0x0a, 0x1a, 0x44, 0x41, 0x54, 0x41,
+	0x42, 0x41, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x41, 0x43, 0x5f, 0x4f, 0x4e,
+	0x45, 0x5f, 0x4e, 0x4f, 0x44, 0x45, 0x10, 0x02, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x41, 0x54, 0x41,
+	0x42, 0x41, 0x53, 0x45, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x49, 0x4e, 0x47, 0x4c, 0x45,
+	0x10, 0x03, 0x22, 0xcb, 0x01, 0x0a, 0x0c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x52,
+	0x6f, 0x6c, 0x65, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f,
+	0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x19,
+	0x0a, 0x15, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f,
+	0x50, 0x52, 0x49, 0x4d, 0x41, 0x52, 0x59, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x44, 0x41, 0x54,
+	0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x53, 0x4e, 0x41, 0x50, 0x53,
+	0x48, 0x4f, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x02, 0x12, 0x21, 0x0a,
+	0x1d, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x4c,
+	0x4f, 0x47, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x4e, 0x44, 0x42, 0x59, 0x10, 0x03,
+	0x12, 0x22, 0x0a, 0x1e, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45, 0x5f, 0x52, 0x4f, 0x4c,
+	0x45, 0x5f, 0x50, 0x48, 0x59, 0x53, 0x49, 0x43, 0x41, 0x4c, 0x5f, 0x53, 0x54, 0x41, 0x4e, 0x44,
+	0x42, 0x59, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16, 0x44, 0x41, 0x54, 0x41, 0x42, 0x41, 0x53, 0x45,
+	0x5f, 0x52, 0x4f, 0x4c, 0x45, 0x5f, 0x46, 0x41, 0x52, 0x5f, 0x53, 0x59, 0x4e, 0x43, 0x10, 0x05,
+	0x1a, 0x9b, 0x17, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x53, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x72, 0x61,
+	0x63, 0x65, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x74, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x74, 0x72, 0x61, 0x63, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x5c, 0x0a, 0x08, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x40, 0x2e,
+	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x08, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x5f, 0x0a, 0x09, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x77,
 	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76,
 	0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52,
-	0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x1a, 0xe5, 0x07, 0x0a, 0x10, 0x44,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x75, 0x0a, 0x08, 0x68,
-	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x59, 0x2e,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x74, 0x0a, 0x14, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x13, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x75, 0x72, 0x65, 0x64, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x12, 0x76, 0x0a, 0x11, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x49, 0x2e, 0x77, 0x6f,
+	0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x54, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x10, 0x74, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x62, 0x0a, 0x0b, 0x74, 0x6e, 0x73, 0x5f,
+	0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e,
 	0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f,
 	0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65,
-	0x72, 0x73, 0x1a, 0xad, 0x06, 0x0a, 0x07, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x75, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x5f, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e,
-	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64,
-	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
-	0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x96, 0x01, 0x0a, 0x10, 0x64, 0x65,
-	0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x69, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x54, 0x6e, 0x73, 0x41, 0x6c, 0x69, 0x61, 0x73,
+	0x52, 0x0a, 0x74, 0x6e, 0x73, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x1a, 0x43, 0x0a, 0x0a,
+	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x48, 0x6f, 0x6d,
+	0x65, 0x1a, 0xf6, 0x08, 0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x6f, 0x0a, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x51, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
 	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63,
 	0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63,
 	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53,
 	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x2e,
-	0x44, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48,
-	0x00, 0x52, 0x0f, 0x64, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76,
-	0x65, 0x72, 0x12, 0x86, 0x01, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65,
-	0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x64, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f,
-	0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f,
-	0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44,
-	0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
-	0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61,
-	0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c,
-	0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x48, 0x00, 0x52,
-	0x0a, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x1a, 0x11, 0x0a, 0x0f, 0x44,
-	0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x99,
-	0x02, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x12, 0x21, 0x0a,
-	0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70,
-	0x69, 0x64, 0x12, 0x86, 0x01, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x6c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
-	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63,
-	0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63,
-	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49,
-	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x2e,
-	0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x4d, 0x0a, 0x07, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a,
-	0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x22, 0x3e, 0x0a, 0x05, 0x53, 0x74,
-	0x61, 0x74, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4b,
-	0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f,
-	0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x45,
-	0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x1a, 0x80, 0x03, 0x0a, 0x08, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
-	0x58, 0x0a, 0x03, 0x69, 0x70, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x77,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x09, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x1a, 0xe5, 0x07, 0x0a, 0x10, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x75, 0x0a, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x59, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64,
+	0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61,
+	0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65,
+	0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72,
+	0x52, 0x08, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x73, 0x1a, 0xad, 0x06, 0x0a, 0x07, 0x48,
+	0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x75, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x5f, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
+	0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
+	0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x6e,
+	0x64, 0x6c, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x96, 0x01, 0x0a, 0x10, 0x64, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x69, 0x2e, 0x77,
 	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76,
 	0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x49, 0x50, 0x43, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x48, 0x00, 0x52, 0x03, 0x69, 0x70, 0x63, 0x12, 0x58, 0x0a, 0x03, 0x6e, 0x6d, 0x70,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61,
-	0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72,
-	0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69,
-	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
-	0x2e, 0x4e, 0x4d, 0x50, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x03,
-	0x6e, 0x6d, 0x70, 0x12, 0x58, 0x0a, 0x03, 0x74, 0x63, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x44, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69,
-	0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72,
-	0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x54, 0x43, 0x50, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x03, 0x74, 0x63, 0x70, 0x12, 0x5a, 0x0a,
-	0x04, 0x74, 0x63, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x77, 0x6f,
-	0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65,
-	0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x54, 0x43, 0x50, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f,
-	0x6c, 0x48, 0x00, 0x52, 0x04, 0x74, 0x63, 0x70, 0x73, 0x42, 0x0a, 0x0a, 0x08, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x1a, 0x1f, 0x0a, 0x0b, 0x49, 0x50, 0x43, 0x50, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x1a, 0x39, 0x0a, 0x0b, 0x4e, 0x4d, 0x50, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a,
-	0x04, 0x70, 0x69, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x69, 0x70,
-	0x65, 0x1a, 0x35, 0x0a, 0x0b, 0x54, 0x43, 0x50, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c,
-	0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x43, 0x6c, 0x6f,
-	0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d, 0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
-	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f,
-	0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x2e, 0x44, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0f, 0x64, 0x65, 0x64, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x64, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x86, 0x01, 0x0a, 0x0a, 0x64,
+	0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x64, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73,
+	0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x2e, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x72, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63,
+	0x68, 0x65, 0x72, 0x1a, 0x11, 0x0a, 0x0f, 0x44, 0x65, 0x64, 0x69, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x1a, 0x99, 0x02, 0x0a, 0x0a, 0x44, 0x69, 0x73, 0x70, 0x61,
+	0x74, 0x63, 0x68, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x86, 0x01, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x6c, 0x2e, 0x77,
+	0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76,
+	0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x44,
+	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x49, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x2e,
+	0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x72, 0x2e, 0x44, 0x69, 0x73, 0x70, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x72, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x1a, 0x4d, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f,
+	0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x6f, 0x6c, 0x22, 0x3e, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f,
+	0x0a, 0x0b, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x52, 0x45, 0x41, 0x44, 0x59, 0x10, 0x01, 0x12,
+	0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44,
+	0x10, 0x02, 0x42, 0x06, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x80, 0x03, 0x0a, 0x08, 0x45,
+	0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x58, 0x0a, 0x03, 0x69, 0x70, 0x63, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63,
+	0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x49,
+	0x50, 0x43, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x03, 0x69, 0x70,
+	0x63, 0x12, 0x58, 0x0a, 0x03, 0x6e, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x44,
+	0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x4e, 0x4d, 0x50, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x03, 0x6e, 0x6d, 0x70, 0x12, 0x58, 0x0a, 0x03, 0x74,
+	0x63, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x2e, 0x54, 0x43, 0x50, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x48, 0x00,
+	0x52, 0x03, 0x74, 0x63, 0x70, 0x12, 0x5a, 0x0a, 0x04, 0x74, 0x63, 0x70, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x44, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67,
+	0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e, 0x6f, 0x72, 0x61, 0x63, 0x6c,
+	0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x44, 0x69, 0x73, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x54, 0x43,
+	0x50, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x48, 0x00, 0x52, 0x04, 0x74, 0x63, 0x70,
+	0x73, 0x42, 0x0a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x1a, 0xc4, 0x01,
+	0x0a, 0x10, 0x54, 0x6c, 0x73, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x0f, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x5f, 0x6c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x77, 0x61, 0x6c,
+	0x6c, 0x65, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x19, 0x73,
+	0x73, 0x6c, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x17,
+	0x73, 0x73, 0x6c, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x73, 0x6c, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x73,
+	0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x11, 0x73, 0x73, 0x6c, 0x5f,
+	0x63, 0x69, 0x70, 0x68, 0x65, 0x72, 0x5f, 0x73, 0x75, 0x69, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0f, 0x73, 0x73, 0x6c, 0x43, 0x69, 0x70, 0x68, 0x65, 0x72, 0x53, 0x75,
+	0x69, 0x74, 0x65, 0x73, 0x1a, 0xb4, 0x01, 0x0a, 0x08, 0x54, 0x6e, 0x73, 0x41, 0x6c, 0x69, 0x61,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x5f, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x41, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x6c,
+	0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2e,
+	0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e,
+	0x44, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x2e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x09, 0x65, 0x6e, 0x64,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x73, 0x69, 0x64, 0x1a, 0x1f, 0x0a, 0x0b, 0x49,
+	0x50, 0x43, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x1a, 0x39, 0x0a, 0x0b,
+	0x4e, 0x4d, 0x50, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x69, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x69, 0x70, 0x65, 0x1a, 0x35, 0x0a, 0x0b, 0x54, 0x43, 0x50, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
+	0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x42, 0x45,
+	0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x47, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x50, 0x6c, 0x61, 0x74, 0x66, 0x6f, 0x72, 0x6d,
+	0x2f, 0x77, 0x6f, 0x72, 0x6b, 0x6c, 0x6f, 0x61, 0x64, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x6f, 0x72, 0x61, 0x63, 0x6c, 0x65, 0x64, 0x69, 0x73, 0x63,
+	0x6f, 0x76, 0x65, 0x72, 0x79, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1989,7 +2431,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_rawDescGZIP() []byte {
 }
 
 var file_protos_oraclediscovery_oraclediscovery_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_protos_oraclediscovery_oraclediscovery_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_protos_oraclediscovery_oraclediscovery_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_protos_oraclediscovery_oraclediscovery_proto_goTypes = []interface{}{
 	(Discovery_Database_DatabaseRole)(0),                           // 0: workloadagent.protos.oraclediscovery.Discovery.Database.DatabaseRole
 	(Discovery_Database_Instance_DatabaseEdition)(0),               // 1: workloadagent.protos.oraclediscovery.Discovery.Database.Instance.DatabaseEdition
@@ -1998,58 +2440,71 @@ var file_protos_oraclediscovery_oraclediscovery_proto_goTypes = []interface{}{
 	(*Discovery)(nil),                                                              // 4: workloadagent.protos.oraclediscovery.Discovery
 	(*Discovery_DatabaseRoot)(nil),                                                 // 5: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot
 	(*Discovery_Host)(nil),                                                         // 6: workloadagent.protos.oraclediscovery.Discovery.Host
-	(*Discovery_Database)(nil),                                                     // 7: workloadagent.protos.oraclediscovery.Discovery.Database
-	(*Discovery_Listener)(nil),                                                     // 8: workloadagent.protos.oraclediscovery.Discovery.Listener
-	(*Discovery_DatabaseRoot_ContainerDatabase)(nil),                               // 9: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase
-	(*Discovery_Host_GcpVirtualMachine)(nil),                                       // 10: workloadagent.protos.oraclediscovery.Discovery.Host.GcpVirtualMachine
-	(*Discovery_Database_Instance)(nil),                                            // 11: workloadagent.protos.oraclediscovery.Discovery.Database.Instance
-	(*Discovery_Listener_ListenerId)(nil),                                          // 12: workloadagent.protos.oraclediscovery.Discovery.Listener.ListenerId
-	(*Discovery_Listener_Service)(nil),                                             // 13: workloadagent.protos.oraclediscovery.Discovery.Listener.Service
-	(*Discovery_Listener_Endpoint)(nil),                                            // 14: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint
-	(*Discovery_Listener_IPCProtocol)(nil),                                         // 15: workloadagent.protos.oraclediscovery.Discovery.Listener.IPCProtocol
-	(*Discovery_Listener_NMPProtocol)(nil),                                         // 16: workloadagent.protos.oraclediscovery.Discovery.Listener.NMPProtocol
-	(*Discovery_Listener_TCPProtocol)(nil),                                         // 17: workloadagent.protos.oraclediscovery.Discovery.Listener.TCPProtocol
-	(*Discovery_Listener_Service_DatabaseInstance)(nil),                            // 18: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance
-	(*Discovery_Listener_Service_DatabaseInstance_Handler)(nil),                    // 19: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler
-	(*Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer)(nil),    // 20: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.DedicatedServer
-	(*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher)(nil),         // 21: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher
-	(*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address)(nil), // 22: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher.Address
-	(*timestamppb.Timestamp)(nil),                                                  // 23: google.protobuf.Timestamp
+	(*Discovery_OracleHome)(nil),                                                   // 7: workloadagent.protos.oraclediscovery.Discovery.OracleHome
+	(*Discovery_Database)(nil),                                                     // 8: workloadagent.protos.oraclediscovery.Discovery.Database
+	(*Discovery_Listener)(nil),                                                     // 9: workloadagent.protos.oraclediscovery.Discovery.Listener
+	(*Discovery_DatabaseRoot_ContainerDatabase)(nil),                               // 10: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase
+	(*Discovery_Host_GcpVirtualMachine)(nil),                                       // 11: workloadagent.protos.oraclediscovery.Discovery.Host.GcpVirtualMachine
+	(*Discovery_OracleHome_Patch)(nil),                                             // 12: workloadagent.protos.oraclediscovery.Discovery.OracleHome.Patch
+	(*Discovery_Database_Instance)(nil),                                            // 13: workloadagent.protos.oraclediscovery.Discovery.Database.Instance
+	nil,                                                                            // 14: workloadagent.protos.oraclediscovery.Discovery.Database.Instance.InitParametersEntry
+	(*Discovery_Listener_ListenerId)(nil),                                          // 15: workloadagent.protos.oraclediscovery.Discovery.Listener.ListenerId
+	(*Discovery_Listener_Service)(nil),                                             // 16: workloadagent.protos.oraclediscovery.Discovery.Listener.Service
+	(*Discovery_Listener_Endpoint)(nil),                                            // 17: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint
+	(*Discovery_Listener_TlsConfiguration)(nil),                                    // 18: workloadagent.protos.oraclediscovery.Discovery.Listener.TlsConfiguration
+	(*Discovery_Listener_TnsAlias)(nil),                                            // 19: workloadagent.protos.oraclediscovery.Discovery.Listener.TnsAlias
+	(*Discovery_Listener_IPCProtocol)(nil),                                         // 20: workloadagent.protos.oraclediscovery.Discovery.Listener.IPCProtocol
+	(*Discovery_Listener_NMPProtocol)(nil),                                         // 21: workloadagent.protos.oraclediscovery.Discovery.Listener.NMPProtocol
+	(*Discovery_Listener_TCPProtocol)(nil),                                         // 22: workloadagent.protos.oraclediscovery.Discovery.Listener.TCPProtocol
+	(*Discovery_Listener_Service_DatabaseInstance)(nil),                            // 23: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance
+	(*Discovery_Listener_Service_DatabaseInstance_Handler)(nil),                    // 24: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler
+	(*Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer)(nil),    // 25: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.DedicatedServer
+	(*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher)(nil),         // 26: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher
+	(*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address)(nil), // 27: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher.Address
+	(*timestamppb.Timestamp)(nil),                                                  // 28: google.protobuf.Timestamp
 }
 var file_protos_oraclediscovery_oraclediscovery_proto_depIdxs = []int32{
 	5,  // 0: workloadagent.protos.oraclediscovery.Discovery.databases:type_name -> workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot
-	23, // 1: workloadagent.protos.oraclediscovery.Discovery.last_updated:type_name -> google.protobuf.Timestamp
-	8,  // 2: workloadagent.protos.oraclediscovery.Discovery.listeners:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener
+	28, // 1: workloadagent.protos.oraclediscovery.Discovery.last_updated:type_name -> google.protobuf.Timestamp
+	9,  // 2: workloadagent.protos.oraclediscovery.Discovery.listeners:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener
 	6,  // 3: workloadagent.protos.oraclediscovery.Discovery.host:type_name -> workloadagent.protos.oraclediscovery.Discovery.Host
-	7,  // 4: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.db:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database
-	9,  // 5: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.cdb:type_name -> workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase
-	10, // 6: workloadagent.protos.oraclediscovery.Discovery.Host.vm:type_name -> workloadagent.protos.oraclediscovery.Discovery.Host.GcpVirtualMachine
-	23, // 7: workloadagent.protos.oraclediscovery.Discovery.Database.created:type_name -> google.protobuf.Timestamp
-	0,  // 8: workloadagent.protos.oraclediscovery.Discovery.Database.database_role:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.DatabaseRole
-	11, // 9: workloadagent.protos.oraclediscovery.Discovery.Database.instances:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.Instance
-	12, // 10: workloadagent.protos.oraclediscovery.Discovery.Listener.id:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.ListenerId
-	23, // 11: workloadagent.protos.oraclediscovery.Discovery.Listener.start_time:type_name -> google.protobuf.Timestamp
-	13, // 12: workloadagent.protos.oraclediscovery.Discovery.Listener.services:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service
-	14, // 13: workloadagent.protos.oraclediscovery.Discovery.Listener.endpoints:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint
-	7,  // 14: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase.root:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database
-	7,  // 15: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase.pdbs:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database
-	1,  // 16: workloadagent.protos.oraclediscovery.Discovery.Database.Instance.edition:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.Instance.DatabaseEdition
-	2,  // 17: workloadagent.protos.oraclediscovery.Discovery.Database.Instance.type:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.Instance.DatabaseType
-	18, // 18: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.instances:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance
-	15, // 19: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.ipc:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.IPCProtocol
-	16, // 20: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.nmp:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.NMPProtocol
-	17, // 21: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.tcp:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.TCPProtocol
-	17, // 22: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.tcps:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.TCPProtocol
-	19, // 23: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.handlers:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler
-	3,  // 24: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.state:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.State
-	20, // 25: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.dedicated_server:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.DedicatedServer
-	21, // 26: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.dispatcher:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher
-	22, // 27: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher.address:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher.Address
-	28, // [28:28] is the sub-list for method output_type
-	28, // [28:28] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	7,  // 4: workloadagent.protos.oraclediscovery.Discovery.oracle_homes:type_name -> workloadagent.protos.oraclediscovery.Discovery.OracleHome
+	8,  // 5: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.db:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database
+	10, // 6: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.cdb:type_name -> workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase
+	11, // 7: workloadagent.protos.oraclediscovery.Discovery.Host.vm:type_name -> workloadagent.protos.oraclediscovery.Discovery.Host.GcpVirtualMachine
+	12, // 8: workloadagent.protos.oraclediscovery.Discovery.OracleHome.patches:type_name -> workloadagent.protos.oraclediscovery.Discovery.OracleHome.Patch
+	28, // 9: workloadagent.protos.oraclediscovery.Discovery.Database.created:type_name -> google.protobuf.Timestamp
+	0,  // 10: workloadagent.protos.oraclediscovery.Discovery.Database.database_role:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.DatabaseRole
+	13, // 11: workloadagent.protos.oraclediscovery.Discovery.Database.instances:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.Instance
+	15, // 12: workloadagent.protos.oraclediscovery.Discovery.Listener.id:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.ListenerId
+	28, // 13: workloadagent.protos.oraclediscovery.Discovery.Listener.start_time:type_name -> google.protobuf.Timestamp
+	16, // 14: workloadagent.protos.oraclediscovery.Discovery.Listener.services:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service
+	17, // 15: workloadagent.protos.oraclediscovery.Discovery.Listener.endpoints:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint
+	17, // 16: workloadagent.protos.oraclediscovery.Discovery.Listener.configured_endpoints:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint
+	18, // 17: workloadagent.protos.oraclediscovery.Discovery.Listener.tls_configuration:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.TlsConfiguration
+	19, // 18: workloadagent.protos.oraclediscovery.Discovery.Listener.tns_aliases:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.TnsAlias
+	8,  // 19: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase.root:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database
+	8,  // 20: workloadagent.protos.oraclediscovery.Discovery.DatabaseRoot.ContainerDatabase.pdbs:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database
+	28, // 21: workloadagent.protos.oraclediscovery.Discovery.OracleHome.Patch.applied_time:type_name -> google.protobuf.Timestamp
+	1,  // 22: workloadagent.protos.oraclediscovery.Discovery.Database.Instance.edition:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.Instance.DatabaseEdition
+	2,  // 23: workloadagent.protos.oraclediscovery.Discovery.Database.Instance.type:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.Instance.DatabaseType
+	14, // 24: workloadagent.protos.oraclediscovery.Discovery.Database.Instance.init_parameters:type_name -> workloadagent.protos.oraclediscovery.Discovery.Database.Instance.InitParametersEntry
+	23, // 25: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.instances:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance
+	20, // 26: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.ipc:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.IPCProtocol
+	21, // 27: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.nmp:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.NMPProtocol
+	22, // 28: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.tcp:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.TCPProtocol
+	22, // 29: workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint.tcps:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.TCPProtocol
+	17, // 30: workloadagent.protos.oraclediscovery.Discovery.Listener.TnsAlias.endpoints:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Endpoint
+	24, // 31: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.handlers:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler
+	3,  // 32: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.state:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.State
+	25, // 33: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.dedicated_server:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.DedicatedServer
+	26, // 34: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.dispatcher:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher
+	27, // 35: workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher.address:type_name -> workloadagent.protos.oraclediscovery.Discovery.Listener.Service.DatabaseInstance.Handler.Dispatcher.Address
+	36, // [36:36] is the sub-list for method output_type
+	36, // [36:36] is the sub-list for method input_type
+	36, // [36:36] is the sub-list for extension type_name
+	36, // [36:36] is the sub-list for extension extendee
+	0,  // [0:36] is the sub-list for field type_name
 }
 
 func init() { file_protos_oraclediscovery_oraclediscovery_proto_init() }
@@ -2095,7 +2550,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			}
 		}
 		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Discovery_Database); i {
+			switch v := v.(*Discovery_OracleHome); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2107,7 +2562,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			}
 		}
 		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Discovery_Listener); i {
+			switch v := v.(*Discovery_Database); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2119,7 +2574,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			}
 		}
 		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Discovery_DatabaseRoot_ContainerDatabase); i {
+			switch v := v.(*Discovery_Listener); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2131,7 +2586,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			}
 		}
 		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Discovery_Host_GcpVirtualMachine); i {
+			switch v := v.(*Discovery_DatabaseRoot_ContainerDatabase); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2143,7 +2598,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			}
 		}
 		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Discovery_Database_Instance); i {
+			switch v := v.(*Discovery_Host_GcpVirtualMachine); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2155,7 +2610,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			}
 		}
 		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Discovery_Listener_ListenerId); i {
+			switch v := v.(*Discovery_OracleHome_Patch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2167,6 +2622,30 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			}
 		}
 		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Discovery_Database_Instance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Discovery_Listener_ListenerId); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_Service); i {
 			case 0:
 				return &v.state
@@ -2178,7 +2657,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_Endpoint); i {
 			case 0:
 				return &v.state
@@ -2190,7 +2669,31 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Discovery_Listener_TlsConfiguration); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Discovery_Listener_TnsAlias); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_IPCProtocol); i {
 			case 0:
 				return &v.state
@@ -2202,7 +2705,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_NMPProtocol); i {
 			case 0:
 				return &v.state
@@ -2214,7 +2717,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_TCPProtocol); i {
 			case 0:
 				return &v.state
@@ -2226,7 +2729,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_Service_DatabaseInstance); i {
 			case 0:
 				return &v.state
@@ -2238,7 +2741,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_Service_DatabaseInstance_Handler); i {
 			case 0:
 				return &v.state
@@ -2250,7 +2753,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer); i {
 			case 0:
 				return &v.state
@@ -2262,7 +2765,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher); i {
 			case 0:
 				return &v.state
@@ -2274,7 +2777,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 				return nil
 			}
 		}
-		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_Address); i {
 			case 0:
 				return &v.state
@@ -2294,13 +2797,13 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 	file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*Discovery_Host_Vm)(nil),
 	}
-	file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[10].OneofWrappers = []interface{}{
+	file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[13].OneofWrappers = []interface{}{
 		(*Discovery_Listener_Endpoint_Ipc)(nil),
 		(*Discovery_Listener_Endpoint_Nmp)(nil),
 		(*Discovery_Listener_Endpoint_Tcp)(nil),
 		(*Discovery_Listener_Endpoint_Tcps)(nil),
 	}
-	file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[15].OneofWrappers = []interface{}{
+	file_protos_oraclediscovery_oraclediscovery_proto_msgTypes[20].OneofWrappers = []interface{}{
 		(*Discovery_Listener_Service_DatabaseInstance_Handler_DedicatedServer_)(nil),
 		(*Discovery_Listener_Service_DatabaseInstance_Handler_Dispatcher_)(nil),
 	}
@@ -2310,7 +2813,7 @@ func file_protos_oraclediscovery_oraclediscovery_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protos_oraclediscovery_oraclediscovery_proto_rawDesc,
 			NumEnums:      4,
-			NumMessages:   19,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   0,
 		},